@@ -0,0 +1,87 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"log"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/acl"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/voting"
+)
+
+// SetACL restricts which principals may sign with, answer votes for or look up the keys of
+// which app IDs, e.g. an acl.List loaded with acl.LoadFile or an acl.Func callback. Refused
+// requests fail with ErrAccessDenied before any network call. Sign requests are checked against
+// SignRequest.Principal, vote requests against the principal the requester forwarded and key
+// lookups against the client's principal (see SetPrincipal). nil removes the ACL. Must be
+// called before Init
+func (c *Client) SetACL(authorizer acl.Authorizer) {
+	c.acl = authorizer
+}
+
+// SetPrincipal sets the identity of this client's caller for requests that don't name one:
+// sign requests without SignRequest.Principal (where it is audited and forwarded the same way),
+// Ethereum and Bitcoin message signing, and key lookups. Must be called before Init
+func (c *Client) SetPrincipal(principal *voting.Principal) {
+	c.principal = principal
+}
+
+// requestPrincipal returns the principal a sign request acts for, nil if none
+func (c *Client) requestPrincipal(req *SignRequest) *voting.Principal {
+	if req.Principal != nil {
+		return req.Principal
+	}
+	return c.principal
+}
+
+// authorize checks the ACL, if any, for principal performing op on appID
+func (c *Client) authorize(ctx context.Context, principal *voting.Principal, appID string, op acl.Operation) error {
+	if c.acl == nil {
+		return nil
+	}
+	var id string
+	if principal != nil {
+		id = principal.ID
+	}
+	return c.acl.Authorize(ctx, id, appID, op)
+}
+
+// authorizeVote refuses vote requests from principals the ACL doesn't allow to ask for votes
+// on the signer app; nil if the request may go to the voting handler
+func (c *Client) authorizeVote(ctx context.Context, req *pb.VotingRequest) *pb.VotingResponse {
+	if c.acl == nil {
+		return nil
+	}
+	// gRPC round notifications carry the signer app ID in their request data; requests of older
+	// peers carry none and only pass rules for any app
+	signerAppID := req.SignerAppId
+	if commit, ok := voting.ParseCommit(req.RequestData); ok && signerAppID == "" {
+		signerAppID = commit.SignerAppID
+	} else if abort, ok := voting.ParseAbort(req.RequestData); ok && signerAppID == "" {
+		signerAppID = abort.SignerAppID
+	}
+	err := c.authorize(ctx, voting.PrincipalFromVotingRequest(ctx, req), signerAppID, acl.OpVote)
+	if err == nil {
+		return nil
+	}
+	log.Printf("🚫 Refusing vote request %s: %v", req.TaskId, err)
+	return &pb.VotingResponse{
+		Success:       false,
+		TaskId:        req.TaskId,
+		Error:         err.Error(),
+		RejectionCode: string(voting.CodeUnauthorized),
+	}
+}
@@ -0,0 +1,150 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/audit"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+)
+
+// Audit operations
+const (
+	AuditOpSign     = "sign"
+	AuditOpOffline  = "sign_offline"
+	AuditOpEthereum = "sign_ethereum"
+	AuditOpBitcoin  = "sign_bitcoin"
+	AuditOpMuSig2   = "sign_musig2"
+	AuditOpC2PA     = "sign_c2pa"
+	AuditOpSolana   = "sign_solana"
+	AuditOpCosmos   = "sign_cosmos"
+	AuditOpStellar  = "sign_stellar"
+)
+
+// ErrAuditFailed is matched by errors.Is for signatures withheld because their audit entry
+// couldn't be written, see AuditConfig.FailClosed
+var ErrAuditFailed = errors.New("audit log unavailable")
+
+// redactedHeaders are never copied into audit metadata
+var redactedHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"set-cookie":          true,
+	"x-api-key":           true,
+}
+
+// AuditConfig configures the audit trail of sign operations, see EnableAuditLog
+type AuditConfig struct {
+	Logger *audit.Logger // Chains entries and writes them to its sinks
+
+	// FailClosed withholds signatures whose audit entry couldn't be written, returning
+	// ErrAuditFailed instead; by default the failure is only logged
+	FailClosed bool
+}
+
+// EnableAuditLog records every sign operation (app ID, message hash, caller metadata and
+// outcome) in a hash-chained audit log, see package audit. Requests rejected before signing,
+// e.g. by rate limits or policies, are recorded too. Must be called before Init
+func (c *Client) EnableAuditLog(config AuditConfig) error {
+	if config.Logger == nil {
+		return fmt.Errorf("audit log requires a logger")
+	}
+	c.audit = &config
+	return nil
+}
+
+// recordAudit appends entry to the audit log. With FailClosed, a write failure for a successful
+// operation is returned as ErrAuditFailed
+func (c *Client) recordAudit(entry audit.Entry) error {
+	if _, err := c.audit.Logger.Record(entry); err != nil {
+		log.Printf("⚠️  Failed to record audit entry for app %s: %v", entry.AppID, err)
+		if c.audit.FailClosed && entry.Success {
+			return fmt.Errorf("%w: %v", ErrAuditFailed, err)
+		}
+	}
+	return nil
+}
+
+// auditSign records a Sign call and its result
+func (c *Client) auditSign(req *SignRequest, result *SignResult, err error) (*SignResult, error) {
+	entry := audit.Entry{
+		AppID:       req.AppID,
+		MessageHash: audit.HashBytes(req.Message),
+		Operation:   AuditOpSign,
+		Voting:      req.EnableVoting,
+		Metadata:    auditMetadata(req),
+		Success:     err == nil && result != nil && result.Success,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	principal := c.requestPrincipal(req)
+	if principal == nil {
+		principal = voting.PrincipalFromHeaders(entry.Metadata)
+	}
+	if principal != nil {
+		entry.Principal, entry.Justification = principal.ID, principal.Justification
+	}
+	if result != nil {
+		// Voting rounds report refusals in the result rather than as errors
+		if entry.Error == "" {
+			entry.Error = result.Error
+		}
+		entry.SignatureHash = audit.HashBytes(result.Signature)
+		entry.Cached = result.Cached
+		entry.QueuedID = result.QueuedID
+	}
+	if auditErr := c.recordAudit(entry); auditErr != nil {
+		return &SignResult{Success: false, Error: auditErr.Error()}, auditErr
+	}
+	return result, err
+}
+
+// auditResult records the outcome of a sign operation outside Sign, such as the Ethereum and
+// Bitcoin helpers or an offline queue flush
+func (c *Client) auditResult(entry audit.Entry, signature []byte, err error) error {
+	if c.audit == nil {
+		return nil
+	}
+	entry.Success = err == nil
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	entry.SignatureHash = audit.HashBytes(signature)
+	return c.recordAudit(entry)
+}
+
+// auditMetadata collects the caller headers of req, leaving out credentials
+func auditMetadata(req *SignRequest) map[string]string {
+	headers := req.Headers
+	if headers == nil && req.HTTPRequest != nil {
+		headers = voting.ExtractHeadersFromRequest(req.HTTPRequest)
+	}
+	if len(headers) == 0 {
+		return nil
+	}
+	metadata := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if !redactedHeaders[strings.ToLower(name)] {
+			metadata[http.CanonicalHeaderKey(name)] = value
+		}
+	}
+	return metadata
+}
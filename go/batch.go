@@ -0,0 +1,81 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultSignBatchConcurrency is how many requests of a batch SignBatch signs at once
+const DefaultSignBatchConcurrency = 8
+
+// SignBatch signs several requests concurrently, each exactly as Sign would, and returns their
+// results in request order. At most DefaultSignBatchConcurrency requests run at a time, and each
+// still passes the rate limit, sign queue and voting configuration of its app. Every result is
+// non-nil. The error joins the errors Sign returned, each prefixed with the request's index; as
+// with Sign, a rejected voting round is a result with Success false and no error
+func (c *Client) SignBatch(requests []*SignRequest) ([]*SignResult, error) {
+	return c.SignBatchContext(context.Background(), requests)
+}
+
+// SignBatchContext is SignBatch that stops starting requests once ctx is done; requests already
+// running finish, and the rest fail with ctx's error
+func (c *Client) SignBatchContext(ctx context.Context, requests []*SignRequest) ([]*SignResult, error) {
+	results := make([]*SignResult, len(requests))
+	errs := make([]error, len(requests))
+
+	sem := make(chan struct{}, DefaultSignBatchConcurrency)
+	var wg sync.WaitGroup
+	for i, req := range requests {
+		if !acquire(ctx, sem) {
+			results[i] = &SignResult{Success: false, Error: ctx.Err().Error()}
+			errs[i] = fmt.Errorf("request %d: %w", i, ctx.Err())
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			result, err := c.Sign(req)
+			if result == nil {
+				result = &SignResult{Success: false}
+				if err != nil {
+					result.Error = err.Error()
+				}
+			}
+			if err != nil {
+				errs[i] = fmt.Errorf("request %d: %w", i, err)
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+	return results, errors.Join(errs...)
+}
+
+// acquire takes a slot of sem, reporting false if ctx is done first
+func acquire(ctx context.Context, sem chan struct{}) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	select {
+	case sem <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
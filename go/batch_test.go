@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/votingtest"
+)
+
+func TestSignBatch(t *testing.T) {
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	var requests []*SignRequest
+	for i := 0; i < 12; i++ {
+		appID := "ed-app"
+		if i == 5 {
+			appID = "missing-app"
+		}
+		requests = append(requests, &SignRequest{AppID: appID, Message: []byte(fmt.Sprintf("message %d", i))})
+	}
+
+	results, err := c.SignBatch(requests)
+	if err == nil || !strings.Contains(err.Error(), "request 5:") {
+		t.Errorf("Expected the error of request 5, got %v", err)
+	}
+	if len(results) != len(requests) {
+		t.Fatalf("Expected %d results, got %d", len(requests), len(results))
+	}
+	for i, result := range results {
+		if i == 5 {
+			if result == nil || result.Success || result.Error == "" {
+				t.Errorf("Expected request 5 to fail, got %+v", result)
+			}
+			continue
+		}
+		if result == nil || !result.Success {
+			t.Fatalf("Request %d failed: %+v", i, result)
+		}
+		// Each result is the signature of its own request's message
+		if valid, err := c.Verify(requests[i].Message, result.Signature, "ed-app"); err != nil || !valid {
+			t.Errorf("Result %d does not verify for message %d: %v", i, i, err)
+		}
+	}
+}
+
+func TestSignBatchContextCancelled(t *testing.T) {
+	network := votingtest.NewNetwork("ed-app", "approver")
+	defer network.Close()
+	network.SetBehavior("approver", votingtest.Delay(200*time.Millisecond, votingtest.Approve()))
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := deployment.SetVoting("ed-app", network, 2, nil); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+	total := DefaultSignBatchConcurrency + 4
+	var requests []*SignRequest
+	for i := 0; i < total; i++ {
+		requests = append(requests, &SignRequest{AppID: "ed-app", Message: []byte(fmt.Sprintf("pay %d", i)), EnableVoting: true, LocalApproval: true, VoteRequestData: []byte(`{"amount":10}`)})
+	}
+
+	// Cancel once the first requests are waiting for their votes
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		for len(network.Peer("approver").Requests()) < DefaultSignBatchConcurrency {
+			time.Sleep(5 * time.Millisecond)
+		}
+		cancel()
+	}()
+	results, err := c.SignBatchContext(ctx, requests)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the batch error to match context.Canceled, got %v", err)
+	}
+	for i, result := range results {
+		if started := i < DefaultSignBatchConcurrency; result.Success != started {
+			t.Errorf("Request %d: expected success %t, got %+v", i, started, result)
+		}
+	}
+	if n := len(deployment.SignRequests()); n != DefaultSignBatchConcurrency {
+		t.Errorf("Expected only the started requests to be signed, got %d", n)
+	}
+}
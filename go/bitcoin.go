@@ -0,0 +1,38 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+// SignBitcoinMessage signs a message using the Bitcoin signmessage convention
+// The app's key must be ECDSA on SECP256K1. The double SHA-256 message digest is signed as is
+// (prehashed), and the result is returned as a base64 compact signature with recovery header,
+// compatible with Bitcoin Core's verifymessage
+func (c *Client) SignBitcoinMessage(message []byte, appID string) (string, error) {
+	hash := verification.BitcoinMessageHash(message)
+	signature, publicKey, err := c.signSecp256k1Digest(AuditOpBitcoin, message, hash, appID)
+	if err != nil {
+		return "", err
+	}
+
+	btcSignature, err := verification.BitcoinSignature(hash, signature, publicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert signature to bitcoin format: %w", err)
+	}
+	return btcSignature, nil
+}
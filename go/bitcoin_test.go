@@ -0,0 +1,37 @@
+package client
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+func TestSignBitcoinMessage(t *testing.T) {
+	c, deployment := newTestClient(t)
+	publicKey := addApp(t, deployment, "btc-app", constants.ProtocolECDSA, constants.CurveSECP256K1)
+
+	message := []byte("hello bitcoin")
+	signature, err := c.SignBitcoinMessage(message, "btc-app")
+	if err != nil {
+		t.Fatalf("SignBitcoinMessage failed: %v", err)
+	}
+	valid, err := verification.VerifyBitcoinMessage(message, publicKey, signature)
+	if err != nil || !valid {
+		t.Fatalf("Signature does not verify: %v", err)
+	}
+	recovered, err := verification.RecoverBitcoinPublicKey(message, signature)
+	if err != nil {
+		t.Fatalf("RecoverBitcoinPublicKey failed: %v", err)
+	}
+	if !bytes.Equal(recovered, publicKey) {
+		t.Errorf("Recovered key %x, want %x", recovered, publicKey)
+	}
+
+	// The double SHA-256 digest reaches the TEE to be signed as is
+	requests := deployment.SignRequests()
+	if len(requests) != 1 || !requests[0].Prehashed || !bytes.Equal(requests[0].Msg, verification.BitcoinMessageHash(message)) {
+		t.Errorf("Expected one prehashed signmessage digest, got %d requests", len(requests))
+	}
+}
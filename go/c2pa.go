@@ -0,0 +1,54 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/c2pa"
+)
+
+// SignC2PAManifest signs a C2PA manifest for the asset read from asset with the app's key and
+// returns the manifest store, for use as a sidecar (.c2pa) file. The app's key must be ECDSA on
+// SECP256R1 or ED25519, and certificates is its X.509 chain, leaf first, issued by a CA that C2PA
+// validators trust. The claim is signed without domain separation, since COSE fixes the signed
+// bytes, and recorded in the audit log as AuditOpC2PA
+func (c *Client) SignC2PAManifest(appID string, manifest *c2pa.Manifest, asset io.Reader, certificates [][]byte) ([]byte, error) {
+	keyInfo, err := c.GetPublicKeyByAppID(appID)
+	if err != nil {
+		return nil, err
+	}
+	algorithm, err := c2pa.AlgorithmFor(keyInfo.Protocol, keyInfo.Curve)
+	if err != nil {
+		return nil, fmt.Errorf("app %s: %w", appID, err)
+	}
+
+	signer := &c2pa.Signer{
+		Algorithm:    algorithm,
+		PublicKey:    keyInfo.Key,
+		Certificates: certificates,
+		Sign: func(toBeSigned []byte) ([]byte, error) {
+			signature, _, err := c.signEncoded(AuditOpC2PA, toBeSigned, toBeSigned, appID, nil, func(current *PublicKeyInfo) error {
+				if !bytes.Equal(current.Key, keyInfo.Key) {
+					return fmt.Errorf("key of app %s changed while signing its C2PA manifest", appID)
+				}
+				return nil
+			})
+			return signature, err
+		},
+	}
+	return manifest.Sign(asset, signer)
+}
@@ -0,0 +1,188 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/deadletter"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/rounds"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+)
+
+// liveRound is a voting round this client is coordinating, see CancelVotingRound
+type liveRound struct {
+	id     string
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+
+	mu      sync.Mutex
+	signing bool        // Approved and being signed; too late to cancel
+	result  *SignResult // Final result of a cancelled round, set before done is closed
+}
+
+// CancelVotingRound cancels a voting round this client is coordinating, identified by
+// VotingInfo.RoundID or PendingOperation.RoundID
+//
+// Outstanding vote requests are abandoned and every app that already approved receives an
+// abort notification so it can drop its approval. CancelVotingRound waits for the round to
+// wind down and returns its final result, with VotingInfo.Cancelled set; the Sign call that
+// started the round returns the same result with an error matching ErrVotingRoundCancelled.
+// Rounds that already collected enough approvals are being signed and can't be cancelled
+func (c *Client) CancelVotingRound(roundID string) (*SignResult, error) {
+	c.liveRoundsMu.Lock()
+	live, ok := c.liveRounds[roundID]
+	c.liveRoundsMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrVotingRoundNotFound, roundID)
+	}
+
+	live.mu.Lock()
+	if live.signing {
+		live.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrVotingRoundSigning, roundID)
+	}
+	log.Printf("🚫 Cancelling voting round %s", roundID)
+	live.cancel(fmt.Errorf("%w: %s", ErrVotingRoundCancelled, roundID))
+	live.mu.Unlock()
+
+	<-live.done
+	return live.result, nil
+}
+
+// beginVotingRound registers a round about to collect votes and returns the context its vote
+// requests run under; endVotingRound must be called when the round ends
+func (c *Client) beginVotingRound(ctx context.Context, round *rounds.Round) (context.Context, *liveRound, error) {
+	var id string
+	if round != nil {
+		id = round.ID
+	} else {
+		var err error
+		if id, err = rounds.NewID(); err != nil {
+			return nil, nil, fmt.Errorf("failed to create voting round ID: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	live := &liveRound{id: id, cancel: cancel, done: make(chan struct{})}
+	c.liveRoundsMu.Lock()
+	if c.liveRounds == nil {
+		c.liveRounds = make(map[string]*liveRound)
+	}
+	c.liveRounds[id] = live
+	c.liveRoundsMu.Unlock()
+	return ctx, live, nil
+}
+
+// endVotingRound unregisters a round and releases CancelVotingRound callers waiting on it
+func (c *Client) endVotingRound(live *liveRound) {
+	c.liveRoundsMu.Lock()
+	delete(c.liveRounds, live.id)
+	c.liveRoundsMu.Unlock()
+	live.cancel(nil)
+	close(live.done)
+}
+
+// beginSigning marks an approved round as being signed, unless it was cancelled first
+func (r *liveRound) beginSigning(ctx context.Context) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if roundCancellation(ctx) != nil {
+		return false
+	}
+	r.signing = true
+	return true
+}
+
+// roundCancellation returns the cause if ctx's round was cancelled with CancelVotingRound or
+// its operation with CancelOperation
+func roundCancellation(ctx context.Context) error {
+	cause := context.Cause(ctx)
+	if errors.Is(cause, ErrVotingRoundCancelled) || errors.Is(cause, ErrOperationCancelled) {
+		return cause
+	}
+	return nil
+}
+
+// cancelRound winds down a cancelled round: votes that didn't arrive are marked cancelled,
+// approvals are withdrawn with abort notifications and the final result is recorded
+func (c *Client) cancelRound(ctx context.Context, live *liveRound, cause error, message []byte, result *SignResult, targets map[string]*usermgmt.DeploymentTarget, headers map[string]string, signerAppID string) (*SignResult, error) {
+	var approved []string
+	for i, detail := range result.VotingInfo.VoteDetails {
+		if !detail.Success {
+			result.VotingInfo.VoteDetails[i].Code = voting.CodeCancelled
+			continue
+		}
+		if detail.Response && detail.ClientID != signerAppID {
+			approved = append(approved, detail.ClientID)
+		}
+		if detail.Response && len(detail.DelegationChain) > 1 {
+			approved = append(approved, detail.DelegationChain[1:]...)
+		}
+	}
+
+	// The round's context is cancelled, the notifications get a fresh deadline
+	abortCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), constants.VoteCommitTimeout)
+	defer cancel()
+	abort := &voting.Abort{
+		Phase:         voting.PhaseAbort,
+		IsForwarded:   true,
+		SignerAppID:   signerAppID,
+		MessageDigest: voting.MessageDigest(message),
+		Reason:        "cancelled",
+		Timestamp:     time.Now().Unix(),
+	}
+	c.sendAborts(abortCtx, live.id, abort, approved, targets, headers)
+
+	result.Success = false
+	result.Error = fmt.Sprintf("Voting round cancelled after %d/%d approvals", result.VotingInfo.SuccessfulVotes, result.VotingInfo.RequiredVotes)
+	result.VotingInfo.Cancelled = true
+	log.Printf("🚫 Voting round %s cancelled, abort sent to %d approving apps", live.id, len(approved))
+
+	live.mu.Lock()
+	live.result = result
+	live.mu.Unlock()
+	return result, cause
+}
+
+// sendAborts delivers an abort notification of a round to appIDs concurrently, logging failures
+func (c *Client) sendAborts(ctx context.Context, roundID string, abort *voting.Abort, appIDs []string, targets map[string]*usermgmt.DeploymentTarget, headers map[string]string) {
+	var wg sync.WaitGroup
+	for _, appID := range appIDs {
+		wg.Add(1)
+		go func(appID string) {
+			defer wg.Done()
+			target, ok := targets[appID]
+			var err error
+			if !ok {
+				target, err = c.delegateTarget(ctx, appID)
+			}
+			if err == nil {
+				err = c.voteSender.Abort(ctx, target, abort, headers)
+			}
+			if err != nil {
+				log.Printf("⚠️  Failed to deliver abort of round %s to %s: %v", roundID, appID, err)
+				c.deadLetterNotification(deadletter.KindAbort, roundID, abort.SignerAppID, appID, abort, headers, err)
+			}
+		}(appID)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/votingtest"
+)
+
+func TestCancelVotingRound(t *testing.T) {
+	network := votingtest.NewNetwork("ed-app", "alice", "bob")
+	defer network.Close()
+	network.SetBehavior("bob", votingtest.Drop())
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := deployment.SetVoting("ed-app", network, 3, nil); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+
+	signErr := make(chan error, 1)
+	go func() {
+		_, err := c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("pay 10"), EnableVoting: true, LocalApproval: true, VoteRequestData: []byte(`{"amount":10}`), Timeout: 10 * time.Second})
+		signErr <- err
+	}()
+
+	// Wait until alice approved and the round only waits for bob
+	var roundID string
+	deadline := time.Now().Add(5 * time.Second)
+	for roundID == "" && time.Now().Before(deadline) {
+		for _, op := range c.PendingOperations() {
+			if op.RoundID != "" && op.Approvals == 2 {
+				roundID = op.RoundID
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if roundID == "" {
+		t.Fatal("Voting round did not collect alice's approval")
+	}
+
+	result, err := c.CancelVotingRound(roundID)
+	if err != nil {
+		t.Fatalf("CancelVotingRound failed: %v", err)
+	}
+	if result.Success || !result.VotingInfo.Cancelled {
+		t.Errorf("Expected a cancelled result, got success=%t cancelled=%t", result.Success, result.VotingInfo.Cancelled)
+	}
+	if err := <-signErr; !errors.Is(err, ErrVotingRoundCancelled) {
+		t.Errorf("Expected Sign to fail with ErrVotingRoundCancelled, got %v", err)
+	}
+	if len(deployment.SignRequests()) != 0 {
+		t.Error("Expected no signature for a cancelled round")
+	}
+
+	// alice is told to drop her approval
+	aborted := false
+	for _, request := range network.Peer("alice").Requests() {
+		if abort, ok := voting.ParseAbort(request.Body); ok && abort.SignerAppID == "ed-app" {
+			aborted = true
+		}
+	}
+	if !aborted {
+		t.Error("Expected alice to receive an abort notification")
+	}
+
+	if _, err := c.CancelVotingRound(roundID); !errors.Is(err, ErrVotingRoundNotFound) {
+		t.Errorf("Expected ErrVotingRoundNotFound for an ended round, got %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/utils"
+)
+
+// AllVotes as MessageClass.RequiredVotes requires an approval from every voting target
+const AllVotes = -1
+
+// MessageMatcher reports whether a message to be signed belongs to a message class
+type MessageMatcher func(message []byte) bool
+
+// MessageClass maps matching messages to their own required-vote threshold, e.g. 1-of-n for
+// low-value operations and the full quorum for high-value transfers
+type MessageClass struct {
+	Name          string
+	Match         MessageMatcher
+	RequiredVotes int // Approvals needed for matching messages, or AllVotes
+
+	// AllowBelowServerQuorum lets RequiredVotes be lower than the server-configured required
+	// votes. Without it such a class fails the voting round, so a class can only tighten the
+	// quorum the App node enforces
+	AllowBelowServerQuorum bool
+}
+
+// SetMessageClasses sets the message classes for voting rounds of appID, replacing earlier ones
+// The first class whose matcher accepts the message sets the required votes for its round in
+// place of the server-configured value; messages no class matches use the server value. A
+// class may only lower the server value if it sets AllowBelowServerQuorum.
+// Calling it without classes removes them. Safe for concurrent use
+func (c *Client) SetMessageClasses(appID string, classes ...MessageClass) {
+	c.messageClassesMu.Lock()
+	defer c.messageClassesMu.Unlock()
+	if len(classes) == 0 {
+		delete(c.messageClasses, appID)
+		return
+	}
+	if c.messageClasses == nil {
+		c.messageClasses = make(map[string][]MessageClass)
+	}
+	c.messageClasses[appID] = append([]MessageClass(nil), classes...)
+}
+
+// classifyMessage returns the message class matching message for appID's voting rounds, if any
+func (c *Client) classifyMessage(appID string, message []byte) *MessageClass {
+	c.messageClassesMu.Lock()
+	classes := c.messageClasses[appID]
+	c.messageClassesMu.Unlock()
+	for i := range classes {
+		if classes[i].Match != nil && classes[i].Match(message) {
+			return &classes[i]
+		}
+	}
+	return nil
+}
+
+// classRequiredVotes resolves a message class's required votes against the number of voting targets
+func classRequiredVotes(class *MessageClass, serverVotes, targets int) (int, error) {
+	votes := class.RequiredVotes
+	if votes == AllVotes {
+		votes = targets
+	}
+	if votes <= 0 || votes > targets {
+		return 0, fmt.Errorf("message class %q requires %d votes, but there are %d voting targets", class.Name, class.RequiredVotes, targets)
+	}
+	if votes < serverVotes {
+		if !class.AllowBelowServerQuorum {
+			return 0, fmt.Errorf("message class %q requires %d votes, below the %d required by the server", class.Name, votes, serverVotes)
+		}
+		log.Printf("⚠️  Message class %q lowers required votes from %d to %d", class.Name, serverVotes, votes)
+	}
+	return votes, nil
+}
+
+// MatchPrefix matches messages starting with prefix
+func MatchPrefix(prefix []byte) MessageMatcher {
+	prefix = bytes.Clone(prefix)
+	return func(message []byte) bool {
+		return bytes.HasPrefix(message, prefix)
+	}
+}
+
+// MatchJSONField matches JSON object messages whose field at path (dot-separated for nested
+// objects, e.g. "transfer.asset") equals one of values, or exists at all if no values are given
+// Numbers are compared as float64
+func MatchJSONField(path string, values ...any) MessageMatcher {
+	return func(message []byte) bool {
+		value, ok := utils.JSONField(message, path)
+		if !ok {
+			return false
+		}
+		if len(values) == 0 {
+			return true
+		}
+		for _, want := range values {
+			if jsonEqual(value, want) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchJSONNumberAtLeast matches JSON object messages whose numeric field at path is at least
+// min, e.g. transfers above an amount. Numbers encoded as JSON strings are accepted too
+func MatchJSONNumberAtLeast(path string, min float64) MessageMatcher {
+	return func(message []byte) bool {
+		value, ok := utils.JSONField(message, path)
+		if !ok {
+			return false
+		}
+		number, ok := jsonNumber(value)
+		return ok && number >= min
+	}
+}
+
+// jsonNumber converts a decoded JSON number, or a string holding one, to float64
+func jsonNumber(value any) (float64, bool) {
+	var number json.Number
+	switch v := value.(type) {
+	case json.Number:
+		number = v
+	case string:
+		number = json.Number(v)
+	default:
+		return 0, false
+	}
+	f, err := number.Float64()
+	return f, err == nil
+}
+
+// jsonEqual compares a decoded JSON value with a Go value
+func jsonEqual(value, want any) bool {
+	switch w := want.(type) {
+	case string:
+		s, ok := value.(string)
+		return ok && s == w
+	case bool:
+		b, ok := value.(bool)
+		return ok && b == w
+	case nil:
+		return value == nil
+	}
+	wantNumber, err := json.Marshal(want)
+	if err != nil {
+		return false
+	}
+	number, ok := value.(json.Number)
+	if !ok {
+		return false
+	}
+	got, err1 := number.Float64()
+	expected, err2 := json.Number(wantNumber).Float64()
+	return err1 == nil && err2 == nil && got == expected
+}
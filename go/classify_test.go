@@ -0,0 +1,134 @@
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/votingtest"
+)
+
+func TestMessageClasses(t *testing.T) {
+	network := votingtest.NewNetwork("ed-app", "alice", "bob")
+	defer network.Close()
+	network.SetBehavior("bob", votingtest.Reject(voting.CodePolicyViolation, "no"))
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := deployment.SetVoting("ed-app", network, 2, nil); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+	c.SetMessageClasses("ed-app",
+		MessageClass{Name: "high", Match: MatchPrefix([]byte("high:")), RequiredVotes: AllVotes},
+		MessageClass{Name: "low", Match: MatchPrefix([]byte("low:")), RequiredVotes: 1},
+		MessageClass{Name: "ping", Match: MatchPrefix([]byte("ping:")), RequiredVotes: 1, AllowBelowServerQuorum: true},
+	)
+	sign := func(message string) (*SignResult, error) {
+		return c.Sign(&SignRequest{AppID: "ed-app", Message: []byte(message), EnableVoting: true, LocalApproval: true, VoteRequestData: []byte(`{"amount":10}`)})
+	}
+
+	// Unmatched messages use the server's 2 votes
+	if result, err := sign("transfer"); err != nil || !result.Success || result.VotingInfo.RequiredVotes != 2 {
+		t.Errorf("Expected an unclassified 2-of-3 round to succeed, got %+v, %v", result, err)
+	}
+
+	result, _ := sign("high: transfer")
+	if result == nil || result.Success || result.VotingInfo.RequiredVotes != 3 || result.VotingInfo.MessageClass != "high" {
+		t.Errorf("Expected the high class to require every vote, got %+v", result)
+	}
+
+	// A class may not undercut the server's quorum unless it says so
+	requests := len(network.Peer("alice").Requests())
+	if result, err := sign("low: status"); err == nil && result.Success {
+		t.Error("Expected a class below the server quorum to be refused")
+	}
+	if n := len(network.Peer("alice").Requests()); n != requests {
+		t.Errorf("Expected no vote requests for the refused class, got %d", n-requests)
+	}
+
+	result, err := sign("ping: status")
+	if err != nil || !result.Success || result.VotingInfo.RequiredVotes != 1 {
+		t.Errorf("Expected the opted-in class to require 1 vote, got %+v, %v", result, err)
+	}
+}
+
+func TestMessageClassBelowServerQuorum(t *testing.T) {
+	network := votingtest.NewNetwork("ed-app", "alice", "bob")
+	defer network.Close()
+	network.SetBehavior("alice", votingtest.Reject(voting.CodePolicyViolation, "no"))
+	network.SetBehavior("bob", votingtest.Reject(voting.CodePolicyViolation, "no"))
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := deployment.SetVoting("ed-app", network, 2, nil); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+	sign := func() (*SignResult, error) {
+		return c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("low: status"), EnableVoting: true, LocalApproval: true, VoteRequestData: []byte(`{"amount":10}`)})
+	}
+
+	// Only the local approval is given, so the round passes only at the class's 1 vote
+	c.SetMessageClasses("ed-app", MessageClass{Name: "low", Match: MatchPrefix([]byte("low:")), RequiredVotes: 1})
+	result, err := sign()
+	if result != nil && result.Success {
+		t.Fatal("Expected a class below the server quorum to be refused without the opt-in")
+	}
+	reason := ""
+	if err != nil {
+		reason = err.Error()
+	} else if result != nil {
+		reason = result.Error
+	}
+	if !strings.Contains(reason, "below the 2 required") {
+		t.Errorf("Expected the refusal to name the server quorum, got %q", reason)
+	}
+	if n := len(network.Peer("alice").Requests()); n != 0 {
+		t.Errorf("Expected no vote requests for the refused class, got %d", n)
+	}
+
+	c.SetMessageClasses("ed-app", MessageClass{Name: "low", Match: MatchPrefix([]byte("low:")), RequiredVotes: 1, AllowBelowServerQuorum: true})
+	result, err = sign()
+	if err != nil || !result.Success || result.VotingInfo.RequiredVotes != 1 || result.VotingInfo.MessageClass != "low" {
+		t.Errorf("Expected the opted-in class to pass with 1 vote, got %+v, %v", result, err)
+	}
+}
+
+func TestClassRequiredVotes(t *testing.T) {
+	tests := []struct {
+		name    string
+		class   MessageClass
+		want    int
+		wantErr bool
+	}{
+		{"raises", MessageClass{RequiredVotes: 3}, 3, false},
+		{"all", MessageClass{RequiredVotes: AllVotes}, 4, false},
+		{"below server", MessageClass{RequiredVotes: 1}, 0, true},
+		{"below server allowed", MessageClass{RequiredVotes: 1, AllowBelowServerQuorum: true}, 1, false},
+		{"zero", MessageClass{RequiredVotes: 0, AllowBelowServerQuorum: true}, 0, true},
+		{"above targets", MessageClass{RequiredVotes: 5}, 0, true},
+	}
+	for _, tt := range tests {
+		votes, err := classRequiredVotes(&tt.class, 2, 4)
+		if (err != nil) != tt.wantErr || votes != tt.want {
+			t.Errorf("%s: got %d, %v; want %d, error %t", tt.name, votes, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestMatchJSON(t *testing.T) {
+	message := []byte(`{"type":"transfer","transfer":{"asset":"BTC","amount":"12.5"}}`)
+	if !MatchJSONField("transfer.asset", "BTC", "ETH")(message) {
+		t.Error("Expected the asset to match")
+	}
+	if MatchJSONField("transfer.asset", "ETH")(message) {
+		t.Error("Expected another asset not to match")
+	}
+	if !MatchJSONField("type")(message) {
+		t.Error("Expected an existing field to match without values")
+	}
+	if !MatchJSONNumberAtLeast("transfer.amount", 10)(message) || MatchJSONNumberAtLeast("transfer.amount", 20)(message) {
+		t.Error("Expected the amount to be compared numerically")
+	}
+	if MatchJSONField("type")([]byte("not json")) {
+		t.Error("Expected a non-JSON message not to match")
+	}
+}
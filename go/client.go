@@ -16,17 +16,32 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"log"
+	"maps"
+	"net"
 	"net/http"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/TEENet-io/teenet-sdk/go/pkg/acl"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/audit"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/auth"
 	"github.com/TEENet-io/teenet-sdk/go/pkg/config"
 	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/deadletter"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/metrics"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/policy"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/ratelimit"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/revocation"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/secret"
 	"github.com/TEENet-io/teenet-sdk/go/pkg/task"
 	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
 	"github.com/TEENet-io/teenet-sdk/go/pkg/utils"
@@ -36,13 +51,21 @@ import (
 	"google.golang.org/grpc"
 )
 
-
 // VoteDetail contains details of each vote
 type VoteDetail struct {
 	ClientID string `json:"client_id"`
 	Success  bool   `json:"success"`
 	Response bool   `json:"response"`
 	Error    string `json:"error,omitempty"`
+	Reason   string `json:"reason,omitempty"` // The voter's reason, from vote responses of version 1 on
+
+	// Code says why the vote is not an approval: a voter's decision (e.g. "policy_violation")
+	// or a failure to get a valid vote (e.g. "timeout"); see PeerFault
+	Code voting.RejectionCode `json:"code,omitempty"`
+
+	// DelegationChain lists ClientID and the apps its vote was delegated to, in order;
+	// the vote of the last app counted in ClientID's place. Empty without delegation
+	DelegationChain []string `json:"delegation_chain,omitempty"`
 }
 
 // SignRequest contains all parameters for sign operations
@@ -56,6 +79,40 @@ type SignRequest struct {
 	VoteRequestData []byte            // Vote request body data
 	Headers         map[string]string // HTTP headers to forward
 	HTTPRequest     *http.Request     // Original HTTP request (optional)
+
+	// Timeout and Deadline bound the whole operation: key lookup, voting fanout and the TEE sign call
+	// Deadline takes precedence over Timeout; if neither is set the client default applies
+	Timeout  time.Duration
+	Deadline time.Time
+
+	// ED25519-specific fields (only used for ED25519 keys)
+	ED25519Mode    uint32 // Signing variant: constants.ED25519ModePure (default), ED25519ModePh or ED25519ModeCtx
+	ED25519Context []byte // Context string for Ed25519ph/Ed25519ctx (up to 255 bytes)
+
+	// Priority orders the request in the sign queue (see SetSignQueue); defaults to task.PriorityNormal
+	Priority task.Priority
+
+	// QueueExpiry is how long the request may wait in the offline queue (see EnableOfflineQueue)
+	QueueExpiry time.Duration
+
+	// BypassDedup forces a fresh signature even if an identical request was recently signed
+	// (see EnableSignatureDedup)
+	BypassDedup bool
+
+	// IdempotencyKey identifies the request across retries of the caller: a request with the key
+	// of an earlier one for the same app gets that request's result instead of a new voting round
+	// or signature, see EnableIdempotency
+	IdempotencyKey string
+
+	// Principal is who asked for the signature and why. It is recorded in the audit log and
+	// forwarded to voters in headers (voting.PrincipalHeader), where voting handlers read it
+	// with voting.PrincipalFromVotingRequest
+	Principal *voting.Principal
+
+	// OnVote, if set, is called with each vote of the request's voting round as it arrives,
+	// the local vote included, so callers can show quorum progress before Sign returns
+	// Calls come from the goroutine collecting votes and should not block
+	OnVote func(VoteDetail)
 }
 
 // SignResult contains the result of a sign operation
@@ -64,8 +121,71 @@ type SignResult struct {
 	Success   bool   `json:"success"`
 	Error     string `json:"error,omitempty"`
 
+	// QueuedID is set when the request was saved to the offline queue instead of signed
+	QueuedID string `json:"queued_id,omitempty"`
+
+	// Cached is set when the signature came from the dedup cache (see EnableSignatureDedup)
+	Cached bool `json:"cached,omitempty"`
+
+	// SharedRound is set when another replica ran the voting round (see EnableReplicaDedup)
+	SharedRound bool `json:"shared_round,omitempty"`
+
+	// IdempotentReplay is set when the result is that of an earlier request with the same
+	// IdempotencyKey
+	IdempotentReplay bool `json:"idempotent_replay,omitempty"`
+
 	// Voting-specific fields (only present when voting was performed)
 	VotingInfo *VotingInfo `json:"voting_info,omitempty"`
+
+	// Commit is set when the request was the commit notification of another app's voting round
+	// Success reports whether its signature verified, see EnableVoteCommit
+	Commit *voting.Commit `json:"commit,omitempty"`
+
+	// Abort is set when the request was the abort notification of another app's voting round
+	Abort *voting.Abort `json:"abort,omitempty"`
+
+	// MuSig2 is set when the request asked for this app's part in another app's MuSig2 session
+	// Signature then holds the public nonce or partial signature to answer with, see SignMuSig2
+	MuSig2 *voting.MuSig2Request `json:"musig2,omitempty"`
+}
+
+// PublicKeyInfo contains the public key of an app ID along with its signature protocol and curve
+type PublicKeyInfo struct {
+	Key      []byte             `json:"key"`
+	Protocol constants.Protocol `json:"protocol"`
+	Curve    constants.Curve    `json:"curve"`
+	Usage    constants.KeyUsage `json:"usage,omitempty"` // Operations the key may be used for; zero is unrestricted
+
+	// Key version and validity window, reported by app nodes that keep historical keys
+	Version    uint32    `json:"version,omitempty"`
+	ValidFrom  time.Time `json:"valid_from,omitzero"`
+	ValidUntil time.Time `json:"valid_until,omitzero"` // Zero while the key is current
+}
+
+// VotingConfig describes the voting configuration of an app ID as held by the server
+type VotingConfig struct {
+	AppID          string   `json:"app_id"`
+	Targets        []string `json:"targets"`
+	RequiredVotes  int      `json:"required_votes"`
+	VotingSignPath string   `json:"voting_sign_path"`
+
+	// Groups maps group names to member app IDs; approvals must include at least one member of each group
+	Groups map[string][]string `json:"groups,omitempty"`
+}
+
+// PeerFault reports whether the vote failed because the peer is broken or unreachable, rather
+// than because it decided against the request
+func (d VoteDetail) PeerFault() bool {
+	return d.Code.PeerFault()
+}
+
+// localVoteDetail records this app's own decision
+func localVoteDetail(appID string, approved bool) VoteDetail {
+	detail := VoteDetail{ClientID: appID, Success: true, Response: approved}
+	if !approved {
+		detail.Code = voting.CodeRejected
+	}
+	return detail
 }
 
 // VotingInfo contains voting-specific information
@@ -74,17 +194,119 @@ type VotingInfo struct {
 	SuccessfulVotes int          `json:"successful_votes"`
 	RequiredVotes   int          `json:"required_votes"`
 	VoteDetails     []VoteDetail `json:"vote_details"`
+	MissingGroups   []string     `json:"missing_groups,omitempty"`  // Voting groups without an approval
+	MessageClass    string       `json:"message_class,omitempty"`   // Message class that set RequiredVotes, see SetMessageClasses
+	CommitFailures  []string     `json:"commit_failures,omitempty"` // Participants the commit notification didn't reach
+	RoundID         string       `json:"round_id,omitempty"`        // Identifies the round to CancelVotingRound
+	Cancelled       bool         `json:"cancelled,omitempty"`       // The round was cancelled before it was signed
 }
 
+// lifecycleState tracks where a client is between Init and Close
+type lifecycleState int
+
+const (
+	stateNew lifecycleState = iota
+	stateInitialized
+	stateClosed
+)
+
 // Client is a simplified key management client with voting capabilities
+//
+// Configuration setters (SetTimeout, SetInterceptors, SetCompression, ...) must be called
+// before Init and are not safe for concurrent use. Once Init returns, Sign, Verify, the
+// public key and voting config queries, sessions, SetVotingHandler, SetRateLimit and
+// SetSignQueue are safe for concurrent use. Init, Close and Shutdown are serialized
 type Client struct {
-	configClient   *config.Client
+	configClient *config.Client
+	timeout      time.Duration
+
+	// initMu serializes Init, Close and Shutdown and guards lifecycle;
+	// connMu guards the connection state they publish
+	initMu         sync.Mutex
+	lifecycle      lifecycleState
+	connMu         sync.RWMutex
 	taskClient     *task.Client
 	userMgmtClient *usermgmt.Client
 	nodeConfig     *config.NodeConfig
-	timeout        time.Duration
-	votingHandler  func(context.Context, *pb.VotingRequest) (*pb.VotingResponse, error)
 	votingServer   *grpc.Server
+	votingGateway  *http.Server // REST facade of the voting service, see SetVotingGatewayAddr
+
+	// clientCertificate holds the only copy of the client key, see Init
+	clientCertificate *tls.Certificate
+
+	// votingHandler is swapped atomically so it can change while the voting service is serving
+	votingHandler atomic.Pointer[votingHandlerFunc]
+
+	metrics        *metrics.ClientMetrics
+	grpcOptions    grpcOptions
+	rateLimiter    *ratelimit.Limiter
+	signQueue      *task.Queue
+	sessions       map[string]*Session
+	sessionsMu     sync.Mutex
+	keys           keyCache // Public keys shared by all requests, see SetPublicKeyCacheTTL
+	votingDisabled bool
+	votingAddr     string
+	gatewayAddr    string
+	voteSender     voting.Sender
+	taskTimeout    time.Duration
+	locality       config.Locality
+	tlsOptions     utils.TLSOptions
+	revocation     *revocation.Checker
+	authTokens     auth.Tokens
+
+	// Payload size limits in bytes, 0 for none, see SetMaxPayloadSize
+	maxMessageSize     int
+	maxVoteRequestSize int
+
+	// Shutdown drain state: closing rejects new sign calls, inflight counts running ones. Each
+	// Init gets a fresh inflight, so a drain abandoned at its deadline never sees new requests
+	drainMu  sync.RWMutex
+	closing  bool
+	inflight *sync.WaitGroup
+
+	// Voting rounds this client is coordinating by round ID, see CancelVotingRound
+	liveRoundsMu sync.Mutex
+	liveRounds   map[string]*liveRound
+
+	// In-flight operations by ID, see PendingOperations
+	operationsMu  sync.Mutex
+	operations    map[string]*operation
+	nextOperation atomic.Uint64
+
+	offline            *offlineQueue
+	dedup              *signatureDedup
+	skipSignatureCheck bool
+	rollbackGuard      *votingConfigGuard
+	voteCommit         *CommitConfig
+	rounds             *roundPersistence
+	replicaDedup       *ReplicaDedupConfig
+	audit              *AuditConfig
+	acl                acl.Authorizer
+	votingAuthority    *usermgmt.VotingAuthority
+	principal          *voting.Principal
+
+	policiesMu    sync.Mutex
+	policies      map[string]cachedPolicy
+	policyPlugins []policy.Plugin
+
+	messageClassesMu sync.Mutex
+	messageClasses   map[string][]MessageClass
+
+	domainTagsMu sync.Mutex
+	domainTags   map[string]string
+	untaggedApps map[string]bool
+
+	previousKeysMu sync.Mutex
+	previousKeys   map[string][]previousKey // Keys replaced by RotateKey, see SetKeyRotationGracePeriod
+	rotationGrace  time.Duration
+
+	votePayloadMu sync.RWMutex
+	votePayload   VotePayloadTransformer
+
+	deadLetters deadletter.Store // Undeliverable requests, see EnableDeadLetters
+
+	idempotencyMu sync.Mutex
+	idempotency   *idempotency // Results by idempotency key, created on first use
 }
 
 // NewClient creates a new client instance
@@ -92,6 +314,10 @@ func NewClient(configServerAddr string) *Client {
 	client := &Client{
 		configClient: config.NewClient(configServerAddr),
 		timeout:      constants.DefaultClientTimeout,
+		metrics:      metrics.NewClientMetrics(metrics.NewRegistry()),
+		rateLimiter:  ratelimit.NewLimiter(),
+		votingAddr:   constants.DefaultVotingAddr,
+		inflight:     new(sync.WaitGroup),
 	}
 
 	// Set default voting handler (auto-approve all votes)
@@ -100,6 +326,9 @@ func NewClient(configServerAddr string) *Client {
 	return client
 }
 
+// votingHandlerFunc decides on an incoming voting request
+type votingHandlerFunc func(context.Context, *pb.VotingRequest) (*pb.VotingResponse, error)
+
 // createDefaultVotingHandler creates a default voting handler that auto-approves all voting requests
 func (c *Client) createDefaultVotingHandler() func(context.Context, *pb.VotingRequest) (*pb.VotingResponse, error) {
 	return func(ctx context.Context, req *pb.VotingRequest) (*pb.VotingResponse, error) {
@@ -116,22 +345,274 @@ func (c *Client) createDefaultVotingHandler() func(context.Context, *pb.VotingRe
 	}
 }
 
-// SetVotingHandler allows users to set a custom voting handler and restarts the voting service
+// SetVotingHandler sets a custom voting handler
+// It is safe to call while the voting service is running: requests already being handled
+// finish with the previous handler and subsequent requests use the new one. A nil handler
+// restores the default auto-approve handler
 func (c *Client) SetVotingHandler(handler func(context.Context, *pb.VotingRequest) (*pb.VotingResponse, error)) {
-	c.votingHandler = handler
+	if handler == nil {
+		handler = c.createDefaultVotingHandler()
+	}
+	h := votingHandlerFunc(handler)
+	c.votingHandler.Store(&h)
+}
 
-	// If voting service is already running, restart it with the new handler
-	if c.votingServer != nil {
-		log.Printf("🔄 Restarting voting service with new handler...")
-		if err := voting.StartVotingService(handler, &c.votingServer); err != nil {
-			log.Printf("⚠️  Warning: Failed to restart voting service: %v", err)
-		}
+// handleVote dispatches an incoming voting request to the current voting handler
+func (c *Client) handleVote(ctx context.Context, req *pb.VotingRequest) (*pb.VotingResponse, error) {
+	if refusal := c.checkVoteSize(req); refusal != nil {
+		return refusal, nil
+	}
+	if refusal := c.authorizeVote(ctx, req); refusal != nil {
+		return refusal, nil
+	}
+	return (*c.votingHandler.Load())(ctx, req)
+}
+
+// checkVoteSize refuses incoming voting requests over the payload size limits
+func (c *Client) checkVoteSize(req *pb.VotingRequest) *pb.VotingResponse {
+	err := voting.CheckPayloadSize("message", len(req.Message), c.maxMessageSize)
+	if err == nil {
+		err = voting.CheckPayloadSize("vote request", len(req.RequestData), c.maxVoteRequestSize)
+	}
+	if err == nil {
+		return nil
+	}
+	log.Printf("🚫 Refusing vote request %s: %v", req.TaskId, err)
+	return &pb.VotingResponse{
+		Success:       false,
+		TaskId:        req.TaskId,
+		Error:         err.Error(),
+		RejectionCode: string(voting.CodePayloadTooLarge),
 	}
 }
 
+// tee returns the TEE task client, or nil before Init
+func (c *Client) tee() *task.Client {
+	c.connMu.RLock()
+	defer c.connMu.RUnlock()
+	return c.taskClient
+}
+
+// userMgmt returns the user management client, or nil before Init
+func (c *Client) userMgmt() *usermgmt.Client {
+	c.connMu.RLock()
+	defer c.connMu.RUnlock()
+	return c.userMgmtClient
+}
+
+// SetTimeout sets the default timeout for client operations
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+}
+
+// SetVotingAddr sets the listen address of the voting service (default ":50051")
+// Takes effect the next time the voting service starts
+func (c *Client) SetVotingAddr(addr string) {
+	c.votingAddr = addr
+}
+
+// SetVotingGatewayAddr also serves the voting service as REST/JSON on addr (POST /v1/voting,
+// see voting.NewGateway), for peers that send votes with voting.TransportREST
+// Takes effect the next time the voting service starts
+func (c *Client) SetVotingGatewayAddr(addr string) {
+	c.gatewayAddr = addr
+}
+
+// SetVoteTransport sets how vote requests and round notifications reach voting targets:
+// through the deployment-client HTTP proxy (voting.TransportProxy, the default), straight to the
+// target container's IP and service port (voting.TransportDirect), or through the deployment-client's
+// gRPC VotingService (voting.TransportGRPC, see SetVotingTLS). Must be called before Init
+func (c *Client) SetVoteTransport(transport voting.Transport) {
+	c.voteSender.Transport = transport
+}
+
+// SetTargetVoteTransport overrides the vote transport for one target app. Must be called before Init
+func (c *Client) SetTargetVoteTransport(appID string, transport voting.Transport) {
+	if c.voteSender.TargetTransports == nil {
+		c.voteSender.TargetTransports = make(map[string]voting.Transport)
+	}
+	c.voteSender.TargetTransports[appID] = transport
+}
+
+// SetVoteRetry retries vote requests that fail on the way to a target, such as connection errors
+// or HTTP 503, with exponential backoff and jitter; rejections are never retried. Retries stay
+// within the round's deadline. Must be called before Init
+func (c *Client) SetVoteRetry(policy voting.RetryPolicy) {
+	c.voteSender.Retry = policy
+}
+
+// SetTargetVoteRetry overrides the vote retry policy for one target app. Must be called before Init
+func (c *Client) SetTargetVoteRetry(appID string, policy voting.RetryPolicy) {
+	if c.voteSender.TargetRetries == nil {
+		c.voteSender.TargetRetries = make(map[string]voting.RetryPolicy)
+	}
+	c.voteSender.TargetRetries[appID] = policy
+}
+
+// SetMinVoteResponseVersion refuses vote responses older than version (e.g. voting.VoteResponseV1)
+// By default unversioned responses of older peers are accepted. Must be called before Init
+func (c *Client) SetMinVoteResponseVersion(version int) {
+	c.voteSender.MinResponseVersion = version
+}
+
+// SetVoteCompression gzips HTTP vote requests and round notifications whose body is larger than
+// threshold bytes; 0 (the default) sends them uncompressed. Targets must accept gzip request
+// bodies, see voting.ReadRequestBody. Must be called before Init
+func (c *Client) SetVoteCompression(threshold int) {
+	c.voteSender.CompressThreshold = threshold
+}
+
+// SetMaxPayloadSize limits the size of messages to sign and of vote request bodies, in bytes;
+// 0 removes a limit. Sign refuses larger requests, and the voting service larger incoming votes,
+// with an error matching ErrPayloadTooLarge. Must be called before Init
+func (c *Client) SetMaxPayloadSize(maxMessage, maxVoteRequest int) {
+	c.maxMessageSize = maxMessage
+	c.maxVoteRequestSize = maxVoteRequest
+}
+
+// SetVotingTLS sets the TLS configuration for gRPC vote requests to deployment-clients
+// Without it, voting.TransportGRPC connections are not encrypted. Must be called before Init
+func (c *Client) SetVotingTLS(tlsConfig *tls.Config) {
+	c.voteSender.TLSConfig = tlsConfig
+}
+
+// SetTLSOptions hardens the TLS connections to TEE and App nodes: strict hostname verification,
+// required SANs, a TLS 1.3 minimum and certificate or SPKI pins. InsecureDev turns certificate
+// verification off for local development and is logged at Init. Must be called before Init
+func (c *Client) SetTLSOptions(opts utils.TLSOptions) {
+	c.tlsOptions = opts
+}
+
+// SetRevocationChecker checks the certificates of TEE and App nodes against OCSP responders
+// and CRLs on every connection; nil turns checking off. Must be called before Init
+func (c *Client) SetRevocationChecker(checker *revocation.Checker) {
+	c.revocation = checker
+}
+
+// SetConfigOverride supplies the client certificate, peer CA and nodes locally instead of from
+// the config server; with TEE and App node addresses set the config server isn't contacted.
+// Must be called before Init
+func (c *Client) SetConfigOverride(override *config.Override) {
+	c.configClient.SetOverride(override)
+}
+
+// SetAuthTokens authenticates to TEE and App nodes with bearer tokens instead of the client
+// certificate: each call carries the token of the app ID it acts for, or tokens.Default.
+// Connections stay TLS, verifying the nodes only. Must be called before Init
+func (c *Client) SetAuthTokens(tokens auth.Tokens) {
+	c.authTokens = tokens
+}
+
+// SetVotingAuthority requires the voting configuration (targets, required votes, groups) of
+// every voting round to be signed by a pinned authority key, so it can't be tampered with between
+// the App node and the client; see usermgmt.NewVotingAuthority. Rounds with an unsigned or
+// altered configuration fail with ErrVotingConfigSignature. Must be called before Init
+func (c *Client) SetVotingAuthority(authority *usermgmt.VotingAuthority) {
+	c.votingAuthority = authority
+}
+
+// SetPreferredLocality prefers TEE and App nodes in the given region and zone (zone may be empty)
+// Signatures and key lookups go to the nearest healthy node and fall back to others on failure
+// Must be called before Init
+func (c *Client) SetPreferredLocality(region, zone string) {
+	c.locality = config.Locality{Region: region, Zone: zone}
+	c.configClient.SetPreferredLocality(c.locality)
+}
+
+// SetSignatureVerification controls whether signatures returned by the TEE are verified against
+// the app's public key before Sign returns them (enabled by default). This catches protocol or
+// curve mismatches and corrupt responses at the cost of one verification per signature.
+// Ed25519ph/Ed25519ctx signatures are always verified. Must be called before Init
+func (c *Client) SetSignatureVerification(enabled bool) {
+	c.skipSignatureCheck = !enabled
+}
+
+// DisableVotingService keeps Init from starting the voting gRPC service, for clients
+// that only sign or verify (CLIs, batch jobs). Must be called before Init
+func (c *Client) DisableVotingService() {
+	c.votingDisabled = true
+}
+
+// SetInterceptors sets custom gRPC client interceptors (auth headers, logging, metrics, ...)
+// They are chained in order on the config, TEE task and user management connections,
+// and must be set before Init
+func (c *Client) SetInterceptors(unary []grpc.UnaryClientInterceptor, stream []grpc.StreamClientInterceptor) {
+	c.grpcOptions.unaryInterceptors = unary
+	c.grpcOptions.streamInterceptors = stream
+	c.configClient.SetDialOptions(c.grpcOptions.dialOptions()...)
+}
+
+// SetCompression enables gzip compression of outgoing messages on all gRPC connections
+// Must be set before Init
+func (c *Client) SetCompression(enabled bool) {
+	c.grpcOptions.compression = enabled
+	c.configClient.SetDialOptions(c.grpcOptions.dialOptions()...)
+}
+
+// SetContextDialer sets a custom dialer for the config, TEE and user management connections,
+// e.g. to reach a sidecar proxy. Addresses that are absolute paths or unix:// targets already
+// connect over unix domain sockets without a custom dialer. Must be set before Init
+func (c *Client) SetContextDialer(dialer func(ctx context.Context, addr string) (net.Conn, error)) {
+	c.grpcOptions.dialer = dialer
+	c.configClient.SetDialOptions(c.grpcOptions.dialOptions()...)
+}
+
+// SetMaxMessageSize sets the maximum gRPC message sizes in bytes for all connections and the
+// voting service; zero keeps the gRPC default (4MB receive). Must be set before Init
+func (c *Client) SetMaxMessageSize(maxSend, maxRecv int) {
+	c.grpcOptions.maxSendMsgSize = maxSend
+	c.grpcOptions.maxRecvMsgSize = maxRecv
+	c.configClient.SetDialOptions(c.grpcOptions.dialOptions()...)
+}
+
+// SetRateLimit limits signing for an app ID to rate requests per second with the given burst
+// Requests over the limit fail immediately with a *RateLimitError; a rate of zero removes the limit
+func (c *Client) SetRateLimit(appID string, rate float64, burst int) {
+	c.rateLimiter.SetLimit(appID, rate, burst)
+}
+
+// SetGlobalRateLimit limits signing across all app IDs to rate requests per second with the given burst
+// A rate of zero removes the limit
+func (c *Client) SetGlobalRateLimit(rate float64, burst int) {
+	c.rateLimiter.SetGlobalLimit(rate, burst)
+}
+
+// SetSignQueue bounds concurrent TEE sign calls to maxConcurrent, queueing up to maxQueued
+// further requests by SignRequest.Priority; requests beyond that fail with task.ErrQueueFull
+// A maxConcurrent of zero removes the queue
+func (c *Client) SetSignQueue(maxConcurrent, maxQueued int) {
+	var queue *task.Queue
+	if maxConcurrent > 0 {
+		queue = task.NewQueue(maxConcurrent, maxQueued)
+	}
+
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	c.signQueue = queue
+	if c.taskClient != nil {
+		c.taskClient.SetQueue(queue)
+	}
+}
+
+// checkRateLimit returns a *RateLimitError if appID is over its rate limit
+func (c *Client) checkRateLimit(appID string) error {
+	if ok, retryAfter := c.rateLimiter.Allow(appID); !ok {
+		return &RateLimitError{AppID: appID, RetryAfter: retryAfter}
+	}
+	return nil
+}
+
 // Init initializes client, fetches config and establishes TLS connection
 // If votingHandler is nil, uses the default auto-approve handler
+// Calling Init on an initialized client returns ErrAlreadyInitialized without side effects;
+// a closed client can be initialized again
 func (c *Client) Init(votingHandler func(context.Context, *pb.VotingRequest) (*pb.VotingResponse, error)) error {
+	c.initMu.Lock()
+	defer c.initMu.Unlock()
+	if c.lifecycle == stateInitialized {
+		return ErrAlreadyInitialized
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
 
@@ -140,71 +621,366 @@ func (c *Client) Init(votingHandler func(context.Context, *pb.VotingRequest) (*p
 	if err != nil {
 		return fmt.Errorf("failed to get config: %w", err)
 	}
-	c.nodeConfig = nodeConfig
+
+	// With token authentication, TEE and App nodes get a bearer token instead of the client certificate
+	var clientCertificate *tls.Certificate
+	nodeDialOptions := c.grpcOptions.dialOptions()
+	if c.authTokens.Enabled() {
+		log.Printf("🔑 Token authentication to TEE and App nodes: %s", c.authTokens)
+		nodeDialOptions = append(nodeDialOptions,
+			grpc.WithChainUnaryInterceptor(c.authTokens.UnaryClientInterceptor()),
+			grpc.WithChainStreamInterceptor(c.authTokens.StreamClientInterceptor()))
+	} else if clientCertificate, err = utils.LoadClientCertificate(nodeConfig.Cert, nodeConfig.Key); err != nil {
+		return fmt.Errorf("failed to parse client certificate: %w", err)
+	}
+	// From here on the key only lives in clientCertificate, shared by all node connections and
+	// wiped on Close or a failed Init
+	secret.Zero(nodeConfig.Key)
+	nodeConfig.Key = nil
+	initialized := false
+	defer func() {
+		if !initialized && clientCertificate != nil {
+			secret.ZeroKey(clientCertificate.PrivateKey)
+		}
+	}()
 
 	// 2. Create task client
-	c.taskClient = task.NewClient(nodeConfig)
+	c.connMu.RLock()
+	signQueue := c.signQueue
+	c.connMu.RUnlock()
+	taskClient := task.NewClient(nodeConfig)
+	taskClient.SetDialOptions(nodeDialOptions...)
+	taskClient.SetQueue(signQueue)
+	if c.taskTimeout > 0 {
+		taskClient.SetTimeout(c.taskTimeout)
+	}
+	taskClient.SetReconnectHook(func() { c.metrics.GRPCReconnects.Inc("tee") })
 
-	// 3. Create TLS configuration for TEE server
-	teeTLSConfig, err := utils.CreateTLSConfig(nodeConfig.Cert, nodeConfig.Key, nodeConfig.TargetCert)
-	if err != nil {
-		return fmt.Errorf("failed to create TEE TLS config: %w", err)
+	// 3. Create TLS configuration for each TEE node
+	tlsOptions := c.tlsOptions
+	if tlsOptions.InsecureDev {
+		log.Printf("⚠️  Insecure TLS dev mode: TEE and App node certificates are not verified")
+	}
+	if c.revocation != nil {
+		tlsOptions.Revocation = c.revocation
+	}
+	teeNodes := nodeConfig.TEENodes
+	if len(teeNodes) == 0 {
+		teeNodes = []config.TEENode{{RPCAddress: nodeConfig.RPCAddress, Cert: nodeConfig.TargetCert}}
+	}
+	targets := make([]task.Target, 0, len(teeNodes))
+	for _, teeNode := range teeNodes {
+		teeTLSConfig, err := utils.CreateTLSConfigWithCertificate(clientCertificate, teeNode.Cert, teeNode.RPCAddress, tlsOptions)
+		if err != nil {
+			return fmt.Errorf("failed to create TEE TLS config for %s: %w", teeNode.RPCAddress, err)
+		}
+		targets = append(targets, task.Target{
+			Address:   teeNode.RPCAddress,
+			TLSConfig: teeTLSConfig,
+			Affinity:  teeNode.Locality.Affinity(c.locality),
+		})
 	}
 
-	// 4. Connect to TEE server
-	if err := c.taskClient.Connect(ctx, teeTLSConfig); err != nil {
+	// 4. Connect to TEE servers
+	if err := taskClient.ConnectNodes(ctx, targets); err != nil {
 		return fmt.Errorf("failed to connect to TEE server: %w", err)
 	}
 
 	// 5. Create user management client
-	c.userMgmtClient = usermgmt.NewClient(nodeConfig.AppNodeAddr)
-
-	// 6. Create TLS configuration for App node
-	appTLSConfig, err := utils.CreateTLSConfig(nodeConfig.Cert, nodeConfig.Key, nodeConfig.AppNodeCert)
-	if err != nil {
-		return fmt.Errorf("failed to create App TLS config: %w", err)
+	userMgmtClient := usermgmt.NewClient(nodeConfig.AppNodeAddr)
+	userMgmtClient.SetDialOptions(nodeDialOptions...)
+	userMgmtClient.SetReconnectHook(func() { c.metrics.GRPCReconnects.Inc("user_management") })
+	userMgmtClient.SetVotingAuthority(c.votingAuthority)
+
+	// 6. Create TLS configuration for each App node, nearest first
+	appNodes := nodeConfig.AppNodes
+	if len(appNodes) == 0 {
+		appNodes = []config.AppNode{{RPCAddress: nodeConfig.AppNodeAddr, Cert: nodeConfig.AppNodeCert}}
+	}
+	appTargets := make([]usermgmt.Target, 0, len(appNodes))
+	for _, appNode := range appNodes {
+		appTLSConfig, err := utils.CreateTLSConfigWithCertificate(clientCertificate, appNode.Cert, appNode.RPCAddress, tlsOptions)
+		if err != nil {
+			taskClient.Close()
+			return fmt.Errorf("failed to create App TLS config for %s: %w", appNode.RPCAddress, err)
+		}
+		appTargets = append(appTargets, usermgmt.Target{Address: appNode.RPCAddress, TLSConfig: appTLSConfig})
 	}
 
 	// 7. Connect to user management system
-	if err := c.userMgmtClient.Connect(ctx, appTLSConfig); err != nil {
+	if err := userMgmtClient.ConnectNodes(ctx, appTargets); err != nil {
+		taskClient.Close()
 		return fmt.Errorf("failed to connect to user management system: %w", err)
 	}
 
 	// 8. Set voting handler and auto-start voting service
 	if votingHandler != nil {
-		c.votingHandler = votingHandler
+		c.SetVotingHandler(votingHandler)
 		log.Printf("🗳️  Using custom voting handler provided in Init()")
 	} else {
 		log.Printf("🗳️  Using default auto-approve voting handler")
 	}
 
-	if err := voting.StartVotingService(c.votingHandler, &c.votingServer); err != nil {
+	// gRPC vote requests share the interceptors, dialer and message limits of other connections
+	c.voteSender.DialOptions = c.grpcOptions.dialOptions()
+	if c.voteSender.TLSConfig == nil && c.voteSender.UsesTransport(voting.TransportGRPC) {
+		log.Printf("⚠️  gRPC vote transport without TLS, see SetVotingTLS")
+	}
+
+	c.connMu.Lock()
+	c.nodeConfig = nodeConfig
+	c.taskClient = taskClient
+	c.userMgmtClient = userMgmtClient
+	c.clientCertificate = clientCertificate
+	initialized = true
+	if c.votingDisabled {
+		log.Printf("🗳️  Voting service disabled")
+	} else if err := voting.StartVotingServiceWithOptions(c.votingAddr, c.handleVote, &c.votingServer, c.grpcOptions.serverOptions()...); err != nil {
 		log.Printf("⚠️  Warning: Failed to start voting service: %v", err)
 		// Don't fail initialization if voting service fails to start
 	} else {
 		log.Printf("🗳️  Voting service auto-started during initialization")
 	}
+	if !c.votingDisabled && c.gatewayAddr != "" {
+		if gateway, err := voting.StartVotingGateway(c.gatewayAddr, c.handleVote); err != nil {
+			log.Printf("⚠️  Warning: Failed to start voting REST gateway: %v", err)
+		} else {
+			c.votingGateway = gateway
+		}
+	}
+	c.connMu.Unlock()
+
+	// A client re-initialized after Close accepts requests again
+	c.lifecycle = stateInitialized
+	c.resumeAccepting()
+	c.startOfflineFlusher()
+	if c.rounds != nil {
+		go func() {
+			if err := c.RecoverVotingRounds(); err != nil && !errors.Is(err, ErrShuttingDown) {
+				log.Printf("⚠️  Voting round recovery failed: %v", err)
+			}
+		}()
+	}
 
 	log.Printf("✅ Client initialized successfully, node ID: %d", nodeConfig.NodeID)
 	return nil
 }
 
-// SignWithAppID signs a message using a public key from user management system by app ID
-func (c *Client) signWithAppID(message []byte, appID string) ([]byte, error) {
-	if c.taskClient == nil {
-		return nil, fmt.Errorf("client not initialized")
+// signWithAppID signs a message using a public key from user management system by app ID
+// The signature is kept for dedup, so only direct requests sign through it; voting rounds call signMessage
+func (c *Client) signWithAppID(ctx context.Context, message []byte, appID string, opts *task.SignOptions) ([]byte, error) {
+	signature, keyInfo, err := c.signMessage(ctx, message, appID, opts)
+	if err != nil {
+		return nil, err
+	}
+	var edMode uint32
+	var edContext []byte
+	if opts != nil {
+		edMode, edContext = opts.ED25519Mode, opts.ED25519Context
+	}
+	c.storeSignature(appID, c.domainMessage(appID, message), edMode, edContext, keyInfo, signature)
+	return signature, nil
+}
+
+// signMessage signs a message with the app's key and returns the signature along with the key,
+// without keeping it for dedup
+func (c *Client) signMessage(ctx context.Context, message []byte, appID string, opts *task.SignOptions) ([]byte, *PublicKeyInfo, error) {
+	taskClient := c.tee()
+	if taskClient == nil {
+		return nil, nil, fmt.Errorf("client not initialized")
 	}
 
 	// Get public key from user management system
+	keyInfo, err := c.getPublicKey(ctx, appID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := requireKeyUsage(appID, keyInfo, constants.KeyUsageSign); err != nil {
+		return nil, nil, err
+	}
+
+	edVariant := opts != nil && (opts.ED25519Mode != constants.ED25519ModePure || len(opts.ED25519Context) > 0)
+	if edVariant && keyInfo.Curve != constants.CurveED25519 {
+		return nil, nil, fmt.Errorf("ED25519 signing mode requires an ED25519 key, app %s uses curve %s", appID, keyInfo.Curve)
+	}
+
+	if err := c.enforceSigningPolicy(ctx, appID); err != nil {
+		return nil, nil, err
+	}
+
+	// Sign the message; TEE sign requests don't name the app, so the context selects its token
+	message = c.domainMessage(appID, message)
+	setOperationState(ctx, OperationSigning)
+	start := time.Now()
+	signature, err := taskClient.SignWithOptions(auth.WithAppID(ctx, appID), message, keyInfo.Key, keyInfo.Protocol, keyInfo.Curve, opts)
+	c.metrics.ObserveSign(appID, start, err)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// TEE nodes that predate Ed25519ph/Ed25519ctx ignore the mode and return a plain
+	// Ed25519 signature, so variants are always checked; other signatures are checked
+	// unless disabled with SetSignatureVerification
+	if edVariant || !c.skipSignatureCheck {
+		if err := verifyReturnedSignature(message, signature, keyInfo, opts); err != nil {
+			c.metrics.ObserveSignatureMismatch(appID)
+			return nil, nil, err
+		}
+	}
+
+	return signature, keyInfo, nil
+}
+
+// verifyReturnedSignature checks a TEE-returned signature against the app's public key
+func verifyReturnedSignature(message, signature []byte, keyInfo *PublicKeyInfo, opts *task.SignOptions) error {
+	var valid bool
+	var err error
+	if keyInfo.Curve == constants.CurveED25519 && opts != nil {
+		valid, err = verification.VerifyED25519WithOptions(message, keyInfo.Key, signature, &verification.ED25519Options{
+			Mode:    opts.ED25519Mode,
+			Context: opts.ED25519Context,
+		})
+	} else {
+		valid, err = verification.VerifySignature(message, keyInfo.Key, signature, keyInfo.Protocol, keyInfo.Curve)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s/%s: %w", ErrSignatureMismatch, keyInfo.Protocol, keyInfo.Curve, err)
+	}
+	if !valid {
+		return fmt.Errorf("%w: signature does not verify under the app's %s/%s key", ErrSignatureMismatch, keyInfo.Protocol, keyInfo.Curve)
+	}
+	return nil
+}
+
+// signSecp256k1Digest signs a precomputed 32-byte digest of message with an app's ECDSA secp256k1
+// key. The digest is sent prehashed, so the TEE signs it as is instead of hashing it again. Policy
+// plugins see the original message and the audit log records it under operation. It returns the
+// signature as produced by the TEE along with the app's public key
+func (c *Client) signSecp256k1Digest(operation string, message, hash []byte, appID string) (signature, publicKey []byte, err error) {
+	signature, keyInfo, err := c.signEncoded(operation, message, hash, appID, &task.SignOptions{Prehashed: true}, requireSecp256k1ECDSA(appID))
+	if err != nil {
+		return nil, nil, err
+	}
+	return signature, keyInfo.Key, nil
+}
+
+// requireSecp256k1ECDSA rejects app keys other than ECDSA on SECP256K1
+func requireSecp256k1ECDSA(appID string) func(*PublicKeyInfo) error {
+	return func(keyInfo *PublicKeyInfo) error {
+		if keyInfo.Protocol != constants.ProtocolECDSA || keyInfo.Curve != constants.CurveSECP256K1 {
+			return fmt.Errorf("app %s must use an ECDSA secp256k1 key, got %s/%s", appID, keyInfo.Protocol, keyInfo.Curve)
+		}
+		return nil
+	}
+}
+
+// signEncoded signs payload, an encoding of message fixed by some protocol, without domain
+// separation, so apps with a domain tag need AllowUntaggedSigning. opts may be nil, and checkKey rejects app keys the protocol can't use. Policy
+// plugins see the original message and the audit log records it under operation. It returns the
+// signature as produced by the TEE along with the app's key
+func (c *Client) signEncoded(operation string, message, payload []byte, appID string, opts *task.SignOptions, checkKey func(*PublicKeyInfo) error) (signature []byte, keyInfo *PublicKeyInfo, err error) {
+	defer func() {
+		entry := audit.Entry{AppID: appID, MessageHash: audit.HashBytes(message), Operation: operation}
+		if auditErr := c.auditResult(entry, signature, err); auditErr != nil {
+			signature, keyInfo, err = nil, nil, auditErr
+		}
+	}()
+	if err := c.authorize(context.Background(), c.principal, appID, acl.OpSign); err != nil {
+		return nil, nil, err
+	}
+	if err := c.checkUntaggedSigning(appID); err != nil {
+		return nil, nil, err
+	}
+	taskClient := c.tee()
+	if taskClient == nil {
+		return nil, nil, fmt.Errorf("client not initialized")
+	}
+	done, err := c.beginRequest()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer done()
+	if err := c.checkRateLimit(appID); err != nil {
+		return nil, nil, err
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
+	ctx, untrack := c.trackOperation(ctx, OperationSign, appID)
+	defer untrack()
+
+	keyInfo, err = c.getPublicKey(ctx, appID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkKey(keyInfo); err != nil {
+		return nil, nil, err
+	}
+	if err := requireKeyUsage(appID, keyInfo, constants.KeyUsageSign); err != nil {
+		return nil, nil, err
+	}
+	if err := c.enforceSigningPolicy(ctx, appID); err != nil {
+		return nil, nil, err
+	}
+	finish, err := c.authorizeSign(ctx, appID, message)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	setOperationState(ctx, OperationSigning)
+	start := time.Now()
+	signature, err = taskClient.SignWithOptions(auth.WithAppID(ctx, appID), payload, keyInfo.Key, keyInfo.Protocol, keyInfo.Curve, opts)
+	c.metrics.ObserveSign(appID, start, err)
+	finish(err == nil)
+	if cause := cancelledOperation(ctx); cause != nil && err != nil {
+		err = cause
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return signature, keyInfo, nil
+}
+
+// getPublicKey returns the public key for an app ID, served from the app's session cache when it has one
+func (c *Client) getPublicKey(ctx context.Context, appID string) (*PublicKeyInfo, error) {
+	session := c.lookupSession(appID)
+	if session == nil {
+		return c.loadPublicKey(ctx, appID)
+	}
 
-	publicKeyStr, protocolStr, curveStr, err := c.userMgmtClient.GetPublicKeyByAppID(ctx, appID)
+	if key := session.cachedKey(); key != nil {
+		c.metrics.ObserveCache(sessionKeyCache, true)
+		return key, nil
+	}
+	c.metrics.ObserveCache(sessionKeyCache, false)
+
+	key, err := c.loadPublicKey(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	session.storeKey(key)
+	return key, nil
+}
+
+// fetchPublicKey fetches the current public key for an app ID and decodes it along with its protocol and curve
+func (c *Client) fetchPublicKey(ctx context.Context, appID string) (*PublicKeyInfo, error) {
+	return c.fetchPublicKeyVersion(ctx, appID, 0, time.Time{})
+}
+
+// fetchPublicKeyVersion fetches the public key of an app ID by version or validity time; zero values select the current key
+func (c *Client) fetchPublicKeyVersion(ctx context.Context, appID string, version uint32, at time.Time) (*PublicKeyInfo, error) {
+	userMgmtClient := c.userMgmt()
+	if userMgmtClient == nil {
+		return nil, fmt.Errorf("client not initialized")
+	}
+	resp, err := userMgmtClient.GetPublicKeyVersion(ctx, appID, version, at)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get public key: %w", err)
 	}
+	publicKeyStr, protocolStr, curveStr := resp.Publickey, resp.Protocol, resp.Curve
 
-	// Parse protocol and curve strings to uint32
+	// Parse protocol and curve strings
 	protocol, err := utils.ParseProtocol(protocolStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse protocol: %w", err)
@@ -225,39 +1001,93 @@ func (c *Client) signWithAppID(message []byte, appID string) ([]byte, error) {
 		return nil, fmt.Errorf("failed to decode public key from hex: %w", err)
 	}
 
-	// Sign the message
-	ctx2, cancel2 := context.WithTimeout(context.Background(), c.timeout)
-	defer cancel2()
+	usage, err := constants.ParseKeyUsage(resp.KeyUsage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key usage: %w", err)
+	}
 
-	return c.taskClient.Sign(ctx2, message, publicKey, protocol, curve)
+	info := &PublicKeyInfo{
+		Key:      publicKey,
+		Protocol: protocol,
+		Curve:    curve,
+		Usage:    usage,
+		Version:  resp.KeyVersion,
+	}
+	if resp.ValidFrom != 0 {
+		info.ValidFrom = time.Unix(resp.ValidFrom, 0)
+	}
+	if resp.ValidUntil != 0 {
+		info.ValidUntil = time.Unix(resp.ValidUntil, 0)
+	}
+	return info, nil
 }
 
 // GetPublicKeyByAppID gets public key information for a specific app ID
-func (c *Client) GetPublicKeyByAppID(appID string) (publicKey, protocol, curve string, err error) {
-	if c.userMgmtClient == nil {
-		return "", "", "", fmt.Errorf("client not initialized")
+func (c *Client) GetPublicKeyByAppID(appID string) (*PublicKeyInfo, error) {
+	if c.userMgmt() == nil {
+		return nil, fmt.Errorf("client not initialized")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
+	if err := c.authorize(ctx, c.principal, appID, acl.OpGetKey); err != nil {
+		return nil, err
+	}
 
-	return c.userMgmtClient.GetPublicKeyByAppID(ctx, appID)
+	return c.getPublicKey(ctx, appID)
+}
+
+// GetVotingConfig returns the voting targets and required votes configured for an app ID
+func (c *Client) GetVotingConfig(appID string) (*VotingConfig, error) {
+	if c.userMgmt() == nil {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	signConfig, err := c.votingSignConfig(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get voting sign configuration: %w", err)
+	}
+
+	config := &VotingConfig{
+		AppID:          appID,
+		RequiredVotes:  int(signConfig.RequiredVotes),
+		VotingSignPath: signConfig.VotingSignPath,
+		Groups:         signConfig.Groups,
+	}
+	for targetAppID := range signConfig.Targets {
+		config.Targets = append(config.Targets, targetAppID)
+	}
+	sort.Strings(config.Targets)
+	return config, nil
 }
 
 // votingSignWithHeaders performs voting with custom headers forwarded to remote targets
-func (c *Client) votingSignWithHeaders(message []byte, signerAppID string, localApproval bool, voteRequestData []byte, headers map[string]string) (*SignResult, error) {
-	// Parse isForwarded from the request data
-	var requestMap map[string]interface{}
-	isForwarded := false
-	if json.Unmarshal(voteRequestData, &requestMap) == nil {
-		isForwarded, _ = requestMap["is_forwarded"].(bool)
+func (c *Client) votingSignWithHeaders(ctx context.Context, message []byte, signerAppID string, localApproval bool, voteRequestData []byte, headers map[string]string, signOpts *task.SignOptions) (*SignResult, error) {
+	isForwarded := isForwardedRequest(voteRequestData)
+
+	// Commit notifications close a round another app already signed
+	if commit, ok := voting.ParseCommit(voteRequestData); ok && isForwarded {
+		return c.receiveCommit(ctx, commit), nil
+	}
+	if abort, ok := voting.ParseAbort(voteRequestData); ok && isForwarded {
+		return receiveAbort(abort), nil
 	}
+	if request, ok := voting.ParseMuSig2(voteRequestData); ok && isForwarded {
+		return c.receiveMuSig2(ctx, signerAppID, request, localApproval), nil
+	}
+
+	roundStart := time.Now()
 
-	// Get deployment targets, voting sign path, and required votes from server
-	deploymentTargets, votingSignPath, requiredVotes, err := c.userMgmtClient.GetDeploymentTargetsForVotingSign(signerAppID, c.timeout)
+	// Get deployment targets, voting sign path, required votes and voting groups from server
+	signConfig, err := c.votingSignConfig(ctx, signerAppID)
 	if err != nil {
+		c.metrics.ObserveVotingRound(signerAppID, roundStart, metrics.ResultError)
 		return nil, fmt.Errorf("failed to get voting sign configuration: %w", err)
 	}
+	deploymentTargets, votingSignPath, requiredVotes := signConfig.Targets, signConfig.VotingSignPath, signConfig.RequiredVotes
 
 	// Extract target app IDs from deployment targets
 	var targetAppIDs []string
@@ -275,7 +1105,7 @@ func (c *Client) votingSignWithHeaders(message []byte, signerAppID string, local
 				TotalTargets:    1,
 				SuccessfulVotes: 0,
 				RequiredVotes:   int(requiredVotes),
-				VoteDetails:     []VoteDetail{{ClientID: signerAppID, Success: true, Response: localApproval}},
+				VoteDetails:     []VoteDetail{localVoteDetail(signerAppID, localApproval)},
 			},
 		}
 
@@ -289,19 +1119,58 @@ func (c *Client) votingSignWithHeaders(message []byte, signerAppID string, local
 	}
 
 	if len(targetAppIDs) == 0 {
+		c.metrics.ObserveVotingRound(signerAppID, roundStart, metrics.ResultError)
 		return nil, fmt.Errorf("no target app IDs configured for voting sign")
 	}
 
 	if requiredVotes <= 0 || requiredVotes > int32(len(targetAppIDs)) {
+		c.metrics.ObserveVotingRound(signerAppID, roundStart, metrics.ResultError)
 		return nil, fmt.Errorf("invalid required votes: %d (should be 1-%d)", requiredVotes, len(targetAppIDs))
 	}
 
+	if err := validateVotingGroups(signConfig.Groups, deploymentTargets); err != nil {
+		c.metrics.ObserveVotingRound(signerAppID, roundStart, metrics.ResultError)
+		return nil, err
+	}
+
+	if err := c.checkVotingConfig(signerAppID, targetAppIDs, int(requiredVotes), signConfig.Groups); err != nil {
+		c.metrics.ObserveVotingRound(signerAppID, roundStart, metrics.ResultError)
+		return nil, err
+	}
+
+	// A registered message class overrides the server's threshold for this round
+	messageClass := c.classifyMessage(signerAppID, message)
+	if messageClass != nil {
+		votes, err := classRequiredVotes(messageClass, int(requiredVotes), len(targetAppIDs))
+		if err != nil {
+			c.metrics.ObserveVotingRound(signerAppID, roundStart, metrics.ResultError)
+			return nil, err
+		}
+		requiredVotes = int32(votes)
+		log.Printf("🏷️  Message class %q requires %d votes", messageClass.Name, votes)
+	}
+
 	log.Printf("🗳️  Starting HTTP voting process for %s", signerAppID)
 	log.Printf("👥 Targets: %v, required votes: %d/%d", targetAppIDs, requiredVotes, len(targetAppIDs))
+	if len(signConfig.Groups) > 0 {
+		log.Printf("👥 Voting groups: %v", signConfig.Groups)
+	}
+
+	// A persisted round survives a restart until it is signed or rejected
+	round := c.startRound(signerAppID, message, signOpts, int(requiredVotes))
+	defer c.finishRound(round)
+	roundCtx, live, err := c.beginVotingRound(ctx, round)
+	if err != nil {
+		c.metrics.ObserveVotingRound(signerAppID, roundStart, metrics.ResultError)
+		return nil, err
+	}
+	defer c.endVotingRound(live)
+	startOperationVoting(ctx, live.id, targetAppIDs, int(requiredVotes))
 
 	// Initialize vote details and approval count
 	var voteDetails []VoteDetail
 	approvalCount := 0
+	approvedBy := make(map[string]bool)
 
 	// Add local vote only if signerAppID is in targetAppIDs
 	signerInTargets := false
@@ -313,9 +1182,13 @@ func (c *Client) votingSignWithHeaders(message []byte, signerAppID string, local
 	}
 
 	if signerInTargets {
-		voteDetails = append(voteDetails, VoteDetail{ClientID: signerAppID, Success: true, Response: localApproval})
+		voteDetails = append(voteDetails, localVoteDetail(signerAppID, localApproval))
+		c.recordVote(round, signerAppID, localApproval)
+		recordOperationVote(ctx, signerAppID, localApproval)
+		reportVote(ctx, voteDetails[len(voteDetails)-1])
 		if localApproval {
 			approvalCount = 1
+			approvedBy[signerAppID] = true
 		}
 	}
 
@@ -343,12 +1216,20 @@ func (c *Client) votingSignWithHeaders(message []byte, signerAppID string, local
 		type voteResult struct {
 			appID    string
 			approved bool
+			reason   string
+			code     voting.RejectionCode
+			chain    []string
 			err      error
 		}
 
 		resultChan := make(chan voteResult, len(remoteTargetAppIDs))
 		activeRequests := 0
 
+		// Every target is sent the same forwarded body, so it is built once per round
+		// and shared read-only; the payload transformer tailors copies where configured
+		forwardedData, forwardErr := c.forwardedVoteData(voteRequestData, message)
+		delegates := newRoundDelegates()
+
 		// Start concurrent HTTP voting requests
 		for _, targetAppID := range remoteTargetAppIDs {
 			target, exists := deploymentTargets[targetAppID]
@@ -359,14 +1240,26 @@ func (c *Client) votingSignWithHeaders(message []byte, signerAppID string, local
 
 			activeRequests++
 			go func(appID string, deployTarget *usermgmt.DeploymentTarget) {
-				// Modify request body to mark as forwarded
-				modifiedRequestData, err := voting.MarkRequestAsForwarded(voteRequestData)
+				if forwardErr != nil {
+					resultChan <- voteResult{appID: appID, approved: false, err: fmt.Errorf("failed to modify request: %w", forwardErr)}
+					return
+				}
+				request := &voting.VoteRequest{
+					SignerAppID:       signerAppID,
+					Message:           message,
+					Data:              forwardedData,
+					Headers:           headers,
+					Principal:         voting.PrincipalFromHeaders(headers),
+					RequiredVotes:     int(requiredVotes),
+					TotalParticipants: len(targetAppIDs),
+				}
+				response, chain, err := c.collectVote(roundCtx, signerAppID, deployTarget, request, deploymentTargets, delegates)
 				if err != nil {
-					resultChan <- voteResult{appID: appID, approved: false, err: fmt.Errorf("failed to modify request: %w", err)}
+					c.deadLetterVote(live.id, request, chain, err)
+					resultChan <- voteResult{appID: appID, chain: chain, err: err}
 					return
 				}
-				approved, err := voting.SendHTTPVoteRequestWithHeaders(deployTarget, modifiedRequestData, headers, c.timeout)
-				resultChan <- voteResult{appID: appID, approved: approved, err: err}
+				resultChan <- voteResult{appID: appID, approved: response.Approved, reason: response.Reason, code: response.Code, chain: chain}
 			}(targetAppID, target)
 		}
 
@@ -378,17 +1271,32 @@ func (c *Client) votingSignWithHeaders(message []byte, signerAppID string, local
 				ClientID: result.appID,
 				Success:  result.err == nil,
 				Response: result.approved,
+				Reason:   result.reason,
+				Code:     result.code,
+			}
+			if len(result.chain) > 1 {
+				voteDetail.DelegationChain = result.chain
 			}
 
 			if result.err != nil {
 				voteDetail.Error = result.err.Error()
-				log.Printf("❌ Failed to get vote from %s: %v", result.appID, result.err)
+				voteDetail.Code = voting.ErrorCode(result.err)
+				log.Printf("❌ Failed to get vote from %s (%s): %v", result.appID, voteDetail.Code, result.err)
 			} else if result.approved {
 				approvalCount++
+				approvedBy[result.appID] = true
 				log.Printf("✅ Vote approved by %s (%d/%d)", result.appID, approvalCount, int(requiredVotes))
 			} else {
-				log.Printf("❌ Vote rejected by %s", result.appID)
+				if voteDetail.Code == "" {
+					voteDetail.Code = voting.CodeRejected
+				}
+				log.Printf("❌ Vote rejected by %s (%s)", result.appID, voteDetail.Code)
+			}
+			if result.err == nil {
+				c.recordVote(round, result.appID, result.approved)
 			}
+			recordOperationVote(ctx, result.appID, result.err == nil && result.approved)
+			reportVote(ctx, voteDetail)
 
 			voteDetails = append(voteDetails, voteDetail)
 		}
@@ -401,20 +1309,43 @@ func (c *Client) votingSignWithHeaders(message []byte, signerAppID string, local
 			SuccessfulVotes: approvalCount,
 			RequiredVotes:   int(requiredVotes),
 			VoteDetails:     voteDetails,
+			MissingGroups:   missingVotingGroups(signConfig.Groups, approvedBy),
+			RoundID:         live.id,
 		},
 	}
+	if messageClass != nil {
+		signResult.VotingInfo.MessageClass = messageClass.Name
+	}
+	if cause := roundCancellation(roundCtx); cause != nil {
+		c.metrics.ObserveVotingRound(signerAppID, roundStart, metrics.ResultCancelled)
+		return c.cancelRound(ctx, live, cause, message, signResult, deploymentTargets, headers, signerAppID)
+	}
 
 	// Check if voting passed
 	if approvalCount < int(requiredVotes) {
 		signResult.Success = false
 		signResult.Error = fmt.Sprintf("Voting failed: only %d/%d approvals received", approvalCount, int(requiredVotes))
 		log.Printf("❌ %s", signResult.Error)
+		c.metrics.ObserveVotingRound(signerAppID, roundStart, metrics.ResultRejected)
 		return signResult, nil
 	}
+	if missing := signResult.VotingInfo.MissingGroups; len(missing) > 0 {
+		signResult.Success = false
+		signResult.Error = fmt.Sprintf("Voting failed: no approval from voting groups %v", missing)
+		log.Printf("❌ %s", signResult.Error)
+		c.metrics.ObserveVotingRound(signerAppID, roundStart, metrics.ResultRejected)
+		return signResult, nil
+	}
+	if !live.beginSigning(roundCtx) {
+		c.metrics.ObserveVotingRound(signerAppID, roundStart, metrics.ResultCancelled)
+		return c.cancelRound(ctx, live, roundCancellation(roundCtx), message, signResult, deploymentTargets, headers, signerAppID)
+	}
+	c.metrics.ObserveVotingRound(signerAppID, roundStart, metrics.ResultApproved)
+	c.approveRound(round)
 
 	// Generate signature
 	log.Printf("🔐 Generating signature for approved message (%d/%d votes received)", approvalCount, int(requiredVotes))
-	signature, err := c.signWithAppID(message, signerAppID)
+	signature, _, err := c.signMessage(ctx, message, signerAppID, signOpts)
 	if err != nil {
 		signResult.Success = false
 		signResult.Error = fmt.Sprintf("Failed to generate signature: %v", err)
@@ -424,12 +1355,28 @@ func (c *Client) votingSignWithHeaders(message []byte, signerAppID string, local
 	signResult.Success = true
 	signResult.Signature = signature
 
+	if c.voteCommit != nil {
+		setOperationState(ctx, OperationCommitting)
+		commit := newCommit(signerAppID, message, signature, signOpts, int(requiredVotes), approvedBy)
+		signResult.VotingInfo.CommitFailures = c.commitRound(ctx, live.id, commit, voteDetails, deploymentTargets, headers)
+	}
+
 	log.Printf("✅ Voting and signing completed successfully")
 	return signResult, nil
 }
 
+// isForwardedRequest reports whether vote request data was forwarded by a coordinating client
+func isForwardedRequest(voteRequestData []byte) bool {
+	var requestMap map[string]interface{}
+	if json.Unmarshal(voteRequestData, &requestMap) != nil {
+		return false
+	}
+	isForwarded, _ := requestMap["is_forwarded"].(bool)
+	return isForwarded
+}
+
 // Sign performs signing with optional voting based on SignRequest configuration
-func (c *Client) Sign(req *SignRequest) (*SignResult, error) {
+func (c *Client) Sign(req *SignRequest) (result *SignResult, err error) {
 	if req == nil {
 		return nil, fmt.Errorf("sign request cannot be nil")
 	}
@@ -438,10 +1385,114 @@ func (c *Client) Sign(req *SignRequest) (*SignResult, error) {
 	if req.AppID == "" {
 		return nil, fmt.Errorf("app ID is required")
 	}
+	if err := voting.CheckPayloadSize("message", len(req.Message), c.maxMessageSize); err != nil {
+		return &SignResult{Success: false, Error: err.Error()}, err
+	}
+	if err := voting.CheckPayloadSize("vote request", len(req.VoteRequestData), c.maxVoteRequestSize); err != nil {
+		return &SignResult{Success: false, Error: err.Error()}, err
+	}
+	if principal := c.requestPrincipal(req); principal != nil {
+		if err := principal.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid principal: %w", err)
+		}
+	}
+	var releaseKey func(*SignResult, error) // Set while the request holds its idempotency key
+	defer func() {
+		if c.audit != nil {
+			result, err = c.auditSign(req, result, err)
+		}
+		// Stores the audited outcome, so a signature the audit withheld isn't replayed
+		if releaseKey != nil {
+			releaseKey(result, err)
+		}
+	}()
+	if err := c.authorize(context.Background(), c.requestPrincipal(req), req.AppID, acl.OpSign); err != nil {
+		return &SignResult{Success: false, Error: err.Error()}, err
+	}
+
+	// A retry of a request that already ran gets its result
+	if req.IdempotencyKey != "" {
+		prior, release, err := c.claimIdempotencyKey(req)
+		if err != nil {
+			return &SignResult{Success: false, Error: err.Error()}, err
+		}
+		if prior != nil {
+			log.Printf("♻️  Returning earlier result for idempotency key of app %s", req.AppID)
+			return prior, nil
+		}
+		releaseKey = release
+	}
+
+	done, err := c.beginRequest()
+	if err != nil {
+		return &SignResult{Success: false, Error: err.Error()}, err
+	}
+	defer done()
+
+	ctx, cancel := c.requestContext(req)
+	defer cancel()
+	ctx, untrack := c.trackOperation(ctx, OperationSign, req.AppID)
+	defer untrack()
+	if req.OnVote != nil {
+		ctx = context.WithValue(ctx, voteProgressKey{}, req.OnVote)
+	}
+
+	// Refuse requests the signing policy won't allow before any voting round starts
+	if err := c.checkSigningPolicy(ctx, req.AppID); err != nil {
+		return &SignResult{Success: false, Error: err.Error()}, err
+	}
+	finish, err := c.authorizeSign(ctx, req.AppID, req.Message)
+	if err != nil {
+		return &SignResult{Success: false, Error: err.Error()}, err
+	}
+
+	// Identical recent direct requests that pass the checks above reuse their signature without
+	// signing again or taking a rate-limit token. Voting and forwarded requests always run a round
+	if !req.BypassDedup && !req.EnableVoting {
+		if signature, ok := c.cachedSignature(ctx, req.AppID, c.domainMessage(req.AppID, req.Message), req.ED25519Mode, req.ED25519Context); ok {
+			finish(false) // Policy plugins counted the signature when it was made
+			return &SignResult{Signature: signature, Success: true, Cached: true}, nil
+		}
+	}
+
+	// Reject early so a rate-limited request doesn't start a voting round
+	if err := c.checkRateLimit(req.AppID); err != nil {
+		finish(false)
+		return &SignResult{Success: false, Error: err.Error()}, err
+	}
+
+	result, err = c.dispatchSign(ctx, req)
+	if cause := cancelledOperation(ctx); cause != nil && (err != nil || result == nil || !result.Success) {
+		if result == nil {
+			result = &SignResult{}
+		}
+		result.Success, result.Error, err = false, cause.Error(), cause
+	}
+	// A round shared with another replica is accounted for by that replica, and a MuSig2
+	// nonce is no signature
+	signed := err == nil && result != nil && result.Success && !result.SharedRound
+	if signed && result.MuSig2 != nil && result.MuSig2.Phase == voting.PhaseMuSig2Nonce {
+		signed = false
+	}
+	finish(signed)
+	return result, err
+}
+
+// dispatchSign signs an admitted request directly or through a voting round
+func (c *Client) dispatchSign(ctx context.Context, req *SignRequest) (*SignResult, error) {
+	signOpts := &task.SignOptions{
+		ED25519Mode:    req.ED25519Mode,
+		ED25519Context: req.ED25519Context,
+		Priority:       req.Priority,
+	}
 
 	// If voting is not enabled, perform direct signing
 	if !req.EnableVoting {
-		signature, err := c.signWithAppID(req.Message, req.AppID)
+		signature, err := c.signWithAppID(ctx, req.Message, req.AppID, signOpts)
+		if id, queued := c.queueOffline(req, err); queued {
+			queuedErr := &QueuedError{ID: id}
+			return &SignResult{Success: false, Error: queuedErr.Error(), QueuedID: id}, queuedErr
+		}
 		if err != nil {
 			return &SignResult{
 				Success: false,
@@ -462,7 +1513,7 @@ func (c *Client) Sign(req *SignRequest) (*SignResult, error) {
 		headers = voting.ExtractHeadersFromRequest(req.HTTPRequest)
 		if req.HTTPRequest.Body != nil {
 			var err error
-			voteRequestData, err = io.ReadAll(req.HTTPRequest.Body)
+			voteRequestData, err = voting.ReadRequestBodyLimit(req.HTTPRequest, int64(c.maxVoteRequestSize))
 			if err != nil {
 				return nil, fmt.Errorf("failed to read request body: %w", err)
 			}
@@ -472,75 +1523,142 @@ func (c *Client) Sign(req *SignRequest) (*SignResult, error) {
 		headers = req.Headers
 		voteRequestData = req.VoteRequestData
 	}
+	if principal := c.requestPrincipal(req); principal != nil {
+		headers = maps.Clone(headers)
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		principal.SetHeaders(headers)
+	}
 
 	// Perform voting and signing
-	return c.votingSignWithHeaders(req.Message, req.AppID, req.LocalApproval, voteRequestData, headers)
+	votingSign := func() (*SignResult, error) {
+		return c.votingSignWithHeaders(ctx, req.Message, req.AppID, req.LocalApproval, voteRequestData, headers, signOpts)
+	}
+	// Replicas that received the same request run a single round between them
+	if c.replicaDedup != nil && !isForwardedRequest(voteRequestData) {
+		return c.sharedRound(ctx, req.AppID, req.Message, signOpts, votingSign)
+	}
+	return votingSign()
+}
+
+// requestContext derives the context bounding a sign request from its Deadline or Timeout,
+// falling back to the client default
+// The context also records when the request started, for signing policy time-locks
+func (c *Client) requestContext(req *SignRequest) (context.Context, context.CancelFunc) {
+	ctx := withRequestStart(context.Background(), time.Now())
+	if !req.Deadline.IsZero() {
+		return context.WithDeadline(ctx, req.Deadline)
+	}
+	timeout := c.timeout
+	if req.Timeout > 0 {
+		timeout = req.Timeout
+	}
+	return context.WithTimeout(ctx, timeout)
 }
 
 // Verify verifies a signature against a message using the public key associated with the given app ID
+// Within the grace period after RotateKey, signatures made with the replaced key verify too
 func (c *Client) Verify(message, signature []byte, appID string) (bool, error) {
-	if c.userMgmtClient == nil {
+	if c.userMgmt() == nil {
 		return false, fmt.Errorf("client not initialized")
 	}
 
-	// Get public key from user management system
 	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
+	if err := c.authorize(ctx, c.principal, appID, acl.OpGetKey); err != nil {
+		return false, err
+	}
 
-	publicKeyStr, protocolStr, curveStr, err := c.userMgmtClient.GetPublicKeyByAppID(ctx, appID)
+	// Get public key from user management system
+	keyInfo, err := c.getPublicKey(ctx, appID)
 	if err != nil {
-		return false, fmt.Errorf("failed to get public key: %w", err)
+		return false, err
 	}
 
-	// Parse protocol and curve strings to uint32
-	protocol, err := utils.ParseProtocol(protocolStr)
-	if err != nil {
-		return false, fmt.Errorf("failed to parse protocol: %w", err)
+	// Verify the signature using the verification package; signatures made before a
+	// RotateKey are checked against the previous keys during their grace period
+	message = c.domainMessage(appID, message)
+	valid, err := verification.VerifySignature(message, keyInfo.Key, signature, keyInfo.Protocol, keyInfo.Curve)
+	if !valid && c.verifyWithPreviousKeys(appID, message, signature) {
+		return true, nil
 	}
+	return valid, err
+}
 
-	curve, err := utils.ParseCurve(curveStr)
-	if err != nil {
-		return false, fmt.Errorf("failed to parse curve: %w", err)
+// Metrics returns the registry holding the client's metrics
+// Mount its Handler (e.g. at /metrics) to expose them to Prometheus
+func (c *Client) Metrics() *metrics.Registry {
+	return c.metrics.Registry
+}
+
+// Close closes client connections immediately, without waiting for in-flight requests
+// Use Shutdown to drain them first. Closing a closed client returns ErrAlreadyClosed
+func (c *Client) Close() error {
+	c.initMu.Lock()
+	defer c.initMu.Unlock()
+	if c.lifecycle == stateClosed {
+		return ErrAlreadyClosed
 	}
+	c.lifecycle = stateClosed
+	c.stopAccepting()
+	c.stopOfflineFlusher()
 
-	// Decode the public key from hex (remove 0x prefix if present)
-	publicKeyHex := publicKeyStr
-	if strings.HasPrefix(publicKeyStr, "0x") || strings.HasPrefix(publicKeyStr, "0X") {
-		publicKeyHex = publicKeyStr[2:]
+	// Stop voting service gracefully
+	if votingServer := c.detachVotingServer(); votingServer != nil {
+		log.Printf("🛑 Stopping voting service...")
+		votingServer.GracefulStop()
 	}
-	publicKey, err := hex.DecodeString(publicKeyHex)
-	if err != nil {
-		return false, fmt.Errorf("failed to decode public key from hex: %w", err)
+	if gateway := c.detachVotingGateway(); gateway != nil {
+		gateway.Close()
 	}
 
-	// Verify the signature using the verification package
-	return verification.VerifySignature(message, publicKey, signature, protocol, curve)
+	return c.closeConnections()
 }
 
+// detachVotingServer clears and returns the running voting server, if any
+func (c *Client) detachVotingServer() *grpc.Server {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	votingServer := c.votingServer
+	c.votingServer = nil
+	return votingServer
+}
 
-// Close closes client connections
-func (c *Client) Close() error {
-	var errs []error
+// detachVotingGateway clears and returns the running voting REST gateway, if any
+func (c *Client) detachVotingGateway() *http.Server {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	gateway := c.votingGateway
+	c.votingGateway = nil
+	return gateway
+}
 
-	// Stop voting service gracefully
-	if c.votingServer != nil {
-		log.Printf("🛑 Stopping voting service...")
-		c.votingServer.GracefulStop()
-		c.votingServer = nil
-	}
+// closeConnections closes the TEE and user management connections
+func (c *Client) closeConnections() error {
+	var errs []error
 
-	if c.taskClient != nil {
-		if err := c.taskClient.Close(); err != nil {
+	taskClient, userMgmtClient := c.tee(), c.userMgmt()
+	if taskClient != nil {
+		if err := taskClient.Close(); err != nil {
 			errs = append(errs, err)
 		}
 	}
 
-	if c.userMgmtClient != nil {
-		if err := c.userMgmtClient.Close(); err != nil {
+	if userMgmtClient != nil {
+		if err := userMgmtClient.Close(); err != nil {
 			errs = append(errs, err)
 		}
 	}
 
+	// Wipe the client key once no connection can use it any more
+	c.connMu.Lock()
+	if c.clientCertificate != nil {
+		secret.ZeroKey(c.clientCertificate.PrivateKey)
+		c.clientCertificate = nil
+	}
+	c.connMu.Unlock()
+
 	if len(errs) > 0 {
 		return fmt.Errorf("errors closing clients: %v", errs)
 	}
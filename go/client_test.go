@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/teetest"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/votingtest"
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/voting"
+)
+
+// newTestClient starts an in-process deployment and a client initialized against it, with
+// votes sent straight to votingtest peers. Each setup function configures the client before Init
+func newTestClient(t *testing.T, setup ...func(*Client)) (*Client, *teetest.Deployment) {
+	t.Helper()
+	deployment, err := teetest.New()
+	if err != nil {
+		t.Fatalf("Failed to start deployment: %v", err)
+	}
+	t.Cleanup(deployment.Close)
+
+	c := NewClient("")
+	c.SetConfigOverride(deployment.Override())
+	c.SetVoteTransport(voting.TransportDirect)
+	c.DisableVotingService()
+	for _, configure := range setup {
+		configure(c)
+	}
+	if err := c.Init(nil); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c, deployment
+}
+
+// addApp registers an app with the deployment and returns its public key
+func addApp(t *testing.T, deployment *teetest.Deployment, appID string, protocol constants.Protocol, curve constants.Curve) []byte {
+	t.Helper()
+	publicKey, err := deployment.AddApp(appID, protocol, curve)
+	if err != nil {
+		t.Fatalf("Failed to add app %s: %v", appID, err)
+	}
+	return publicKey
+}
+
+func TestInitClose(t *testing.T) {
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	req := &SignRequest{AppID: "ed-app", Message: []byte("hello")}
+
+	if err := c.Init(nil); !errors.Is(err, ErrAlreadyInitialized) {
+		t.Errorf("Expected ErrAlreadyInitialized from a second Init, got %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := c.Close(); !errors.Is(err, ErrAlreadyClosed) {
+		t.Errorf("Expected ErrAlreadyClosed from a second Close, got %v", err)
+	}
+	if _, err := c.Sign(req); !errors.Is(err, ErrShuttingDown) {
+		t.Errorf("Expected ErrShuttingDown after Close, got %v", err)
+	}
+
+	// A closed client can be initialized again
+	if err := c.Init(nil); err != nil {
+		t.Fatalf("Init after Close failed: %v", err)
+	}
+	if result, err := c.Sign(req); err != nil || !result.Success {
+		t.Errorf("Sign after re-Init failed: %v", err)
+	}
+}
+
+func TestSignBeforeInit(t *testing.T) {
+	deployment, err := teetest.New()
+	if err != nil {
+		t.Fatalf("Failed to start deployment: %v", err)
+	}
+	defer deployment.Close()
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	c := NewClient("")
+	c.SetConfigOverride(deployment.Override())
+	c.DisableVotingService()
+	req := &SignRequest{AppID: "ed-app", Message: []byte("hello")}
+
+	result, err := c.Sign(req)
+	if err == nil || result.Success {
+		t.Fatal("Expected Sign before Init to fail")
+	}
+	if result.Error != "client not initialized" {
+		t.Errorf("Expected a not-initialized error, got %q", result.Error)
+	}
+	if n := len(deployment.SignRequests()); n != 0 {
+		t.Errorf("Expected no TEE sign request, got %d", n)
+	}
+
+	if err := c.Init(nil); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	defer c.Close()
+	if result, err := c.Sign(req); err != nil || !result.Success {
+		t.Errorf("Sign after Init failed: %v", err)
+	}
+}
+
+func TestCorruptSignatureRejected(t *testing.T) {
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	addApp(t, deployment, "ecdsa-app", constants.ProtocolECDSA, constants.CurveSECP256K1)
+	deployment.CorruptSignatures(true)
+
+	for _, appID := range []string{"ed-app", "ecdsa-app"} {
+		result, err := c.Sign(&SignRequest{AppID: appID, Message: []byte("hello")})
+		if !errors.Is(err, ErrSignatureMismatch) || result.Success || len(result.Signature) != 0 {
+			t.Errorf("%s: expected ErrSignatureMismatch without a signature, got %+v, %v", appID, result, err)
+		}
+	}
+
+	deployment.CorruptSignatures(false)
+	if result, err := c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("hello")}); err != nil || !result.Success {
+		t.Errorf("Sign failed after restoring valid signatures: %v", err)
+	}
+}
+
+func TestCorruptSignatureUnverified(t *testing.T) {
+	c, deployment := newTestClient(t, func(c *Client) {
+		c.SetSignatureVerification(false)
+	})
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	deployment.CorruptSignatures(true)
+
+	if result, err := c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("hello")}); err != nil || !result.Success {
+		t.Errorf("Expected the unverified signature to be returned, got %v", err)
+	}
+	req := &SignRequest{AppID: "ed-app", Message: []byte("hello"), ED25519Mode: constants.ED25519ModeCtx, ED25519Context: []byte("ctx")}
+	// Ed25519ctx signatures are checked even with verification disabled
+	if _, err := c.Sign(req); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("Expected ErrSignatureMismatch for an Ed25519ctx signature, got %v", err)
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	network := votingtest.NewNetwork("ed-app", "slow")
+	defer network.Close()
+	network.SetBehavior("slow", votingtest.Delay(time.Minute, votingtest.Approve()))
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := deployment.SetVoting("ed-app", network, 2, nil); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+
+	// The request's timeout bounds the voting round instead of the client default
+	start := time.Now()
+	result, _ := c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("pay 10"), EnableVoting: true, LocalApproval: true, VoteRequestData: []byte(`{"amount":10}`), Timeout: 300 * time.Millisecond})
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Sign took %s despite a 300ms timeout", elapsed)
+	}
+	if result == nil || result.Success {
+		t.Fatalf("Expected the round to time out, got %+v", result)
+	}
+	if len(deployment.SignRequests()) != 0 {
+		t.Error("Expected no signature after the timeout")
+	}
+}
+
+func TestConcurrentSignAndClose(t *testing.T) {
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				_, err := c.Sign(&SignRequest{AppID: "ed-app", Message: []byte(fmt.Sprintf("message %d-%d", i, j))})
+				if errors.Is(err, ErrShuttingDown) {
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// Swapping the voting handler races with nothing, even while votes are handled
+		for i := 0; i < 10; i++ {
+			c.SetVotingHandler(func(context.Context, *pb.VotingRequest) (*pb.VotingResponse, error) {
+				return &pb.VotingResponse{Success: true}, nil
+			})
+			c.handleVote(context.Background(), &pb.VotingRequest{})
+		}
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	if err := c.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+	wg.Wait()
+	if _, err := c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("late")}); !errors.Is(err, ErrShuttingDown) {
+		t.Errorf("Expected ErrShuttingDown after Close, got %v", err)
+	}
+}
@@ -0,0 +1,447 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Command teenet-bench load-tests the TEENet signing path so operators can size TEE capacity
+//
+// Usage:
+//
+//	teenet-bench [flags]
+//
+// Workers sign random messages concurrently until -requests signs were issued or -duration
+// elapsed, then a report of throughput, latency percentiles and errors is printed. With -vote
+// every sign runs a voting round with local approval, and the report adds the time each round
+// took to reach quorum
+//
+// The config server address is taken from -config-addr or TEE_CONFIG_ADDR, and the
+// app ID from -app-id or APP_ID
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	client "github.com/TEENet-io/teenet-sdk/go"
+)
+
+// maxErrorKinds bounds the distinct error messages listed in a report
+const maxErrorKinds = 10
+
+// config is the parsed command line
+type config struct {
+	configAddr  string
+	appID       string
+	concurrency int
+	requests    int
+	duration    time.Duration
+	warmup      time.Duration
+	rate        float64
+	messageSize int
+	vote        bool
+	timeout     time.Duration
+	interval    time.Duration
+	asJSON      bool
+}
+
+// sample is the outcome of one sign
+type sample struct {
+	latency time.Duration
+	quorum  time.Duration // Time until the round reached quorum, 0 without voting
+	err     string
+}
+
+// Stats summarizes a set of durations
+type Stats struct {
+	Count int           `json:"count"`
+	Min   time.Duration `json:"min_ns"`
+	Mean  time.Duration `json:"mean_ns"`
+	P50   time.Duration `json:"p50_ns"`
+	P90   time.Duration `json:"p90_ns"`
+	P95   time.Duration `json:"p95_ns"`
+	P99   time.Duration `json:"p99_ns"`
+	Max   time.Duration `json:"max_ns"`
+}
+
+// ErrorCount is how often one error message occurred
+type ErrorCount struct {
+	Error string `json:"error"`
+	Count int    `json:"count"`
+}
+
+// Report is the result of a benchmark run
+type Report struct {
+	AppID       string        `json:"app_id"`
+	Concurrency int           `json:"concurrency"`
+	Voting      bool          `json:"voting"`
+	MessageSize int           `json:"message_size"`
+	Elapsed     time.Duration `json:"elapsed_ns"`
+	Requests    int           `json:"requests"`
+	Succeeded   int           `json:"succeeded"`
+	Failed      int           `json:"failed"`
+	Throughput  float64       `json:"throughput"` // Successful signs per second
+	Latency     Stats         `json:"latency"`    // Successful signs only
+	VotingRound *Stats        `json:"voting_round,omitempty"`
+	Errors      []ErrorCount  `json:"errors,omitempty"`
+}
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.configAddr, "config-addr", getEnv("TEE_CONFIG_ADDR", "localhost:50052"), "TEE configuration server address")
+	flag.StringVar(&cfg.appID, "app-id", os.Getenv("APP_ID"), "app ID (default $APP_ID)")
+	flag.IntVar(&cfg.concurrency, "concurrency", 8, "number of concurrent signers")
+	flag.IntVar(&cfg.requests, "requests", 0, "stop after this many signs (0: run for -duration)")
+	flag.DurationVar(&cfg.duration, "duration", 30*time.Second, "how long to run when -requests is 0")
+	flag.DurationVar(&cfg.warmup, "warmup", 0, "sign for this long before measuring")
+	flag.Float64Var(&cfg.rate, "rate", 0, "cap on signs per second across all workers (0: unlimited)")
+	flag.IntVar(&cfg.messageSize, "message-size", 32, "size in bytes of each random message")
+	flag.BoolVar(&cfg.vote, "vote", false, "run a voting round (with local approval) before each sign")
+	flag.DurationVar(&cfg.timeout, "timeout", 0, "request timeout (default: client default)")
+	flag.DurationVar(&cfg.interval, "interval", 5*time.Second, "progress output interval on stderr (0 disables)")
+	flag.BoolVar(&cfg.asJSON, "json", false, "print the report as JSON")
+	verbose := flag.Bool("v", false, "print client logs to stderr")
+	flag.Parse()
+
+	if cfg.appID == "" {
+		fatalf("-app-id or APP_ID is required")
+	}
+	if cfg.concurrency <= 0 {
+		fatalf("-concurrency must be positive")
+	}
+	if cfg.requests <= 0 && cfg.duration <= 0 {
+		fatalf("-requests or -duration is required")
+	}
+	if cfg.messageSize <= 0 {
+		fatalf("-message-size must be positive")
+	}
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	teeClient := client.NewClient(cfg.configAddr)
+	teeClient.DisableVotingService()
+	if cfg.timeout > 0 {
+		teeClient.SetTimeout(cfg.timeout)
+	}
+	if err := teeClient.Init(nil); err != nil {
+		fatalf("failed to initialize client: %v", err)
+	}
+
+	// Stop early on Ctrl-C and still report what was measured
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	if cfg.warmup > 0 {
+		fmt.Fprintf(os.Stderr, "teenet-bench: warming up for %s\n", cfg.warmup)
+		warmup := cfg
+		warmup.requests, warmup.duration, warmup.interval = 0, cfg.warmup, 0
+		run(ctx, teeClient, warmup)
+	}
+
+	samples, elapsed := run(ctx, teeClient, cfg)
+	stop()
+	teeClient.Close()
+
+	report := newReport(cfg, samples, elapsed)
+	if cfg.asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			fatalf("%v", err)
+		}
+	} else {
+		report.print(os.Stdout)
+	}
+	if report.Succeeded == 0 {
+		os.Exit(1)
+	}
+}
+
+// run signs with cfg.concurrency workers until the request budget or duration is used up
+// or ctx is cancelled, and returns every sample with the wall time taken
+func run(ctx context.Context, c *client.Client, cfg config) ([]sample, time.Duration) {
+	if cfg.requests <= 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.duration)
+		defer cancel()
+	}
+
+	// A ticker hands out one token per sign when the rate is capped
+	var tokens <-chan time.Time
+	if cfg.rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / cfg.rate))
+		defer ticker.Stop()
+		tokens = ticker.C
+	}
+
+	var issued, done atomic.Int64
+	results := make([][]sample, cfg.concurrency)
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for w := 0; w < cfg.concurrency; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for {
+				if tokens != nil {
+					select {
+					case <-tokens:
+					case <-ctx.Done():
+						return
+					}
+				}
+				if ctx.Err() != nil {
+					return
+				}
+				if cfg.requests > 0 && issued.Add(1) > int64(cfg.requests) {
+					return
+				}
+				results[w] = append(results[w], signOnce(c, cfg))
+				done.Add(1)
+			}
+		}(w)
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+	if cfg.interval > 0 {
+		ticker := time.NewTicker(cfg.interval)
+		defer ticker.Stop()
+	progress:
+		for {
+			select {
+			case <-ticker.C:
+				elapsed := time.Since(start)
+				n := done.Load()
+				fmt.Fprintf(os.Stderr, "teenet-bench: %s  %d signs  %.1f/s\n",
+					elapsed.Round(time.Second), n, float64(n)/elapsed.Seconds())
+			case <-finished:
+				break progress
+			}
+		}
+	}
+	<-finished
+	elapsed := time.Since(start)
+
+	var samples []sample
+	for _, worker := range results {
+		samples = append(samples, worker...)
+	}
+	return samples, elapsed
+}
+
+// signOnce signs a fresh random message, so dedup and idempotency caches never answer
+func signOnce(c *client.Client, cfg config) sample {
+	message := make([]byte, cfg.messageSize)
+	rand.Read(message)
+
+	req := &client.SignRequest{
+		Message:      message,
+		AppID:        cfg.appID,
+		EnableVoting: cfg.vote,
+		BypassDedup:  true,
+	}
+
+	// Approvals are timestamped as they arrive; the round reached quorum at the
+	// RequiredVotes-th one, which is only known once Sign returns
+	var mu sync.Mutex
+	var approvals []time.Duration
+	start := time.Now()
+	if cfg.vote {
+		req.LocalApproval = true
+		req.VoteRequestData, _ = json.Marshal(map[string]any{"message": message, "benchmark": true})
+		req.OnVote = func(vote client.VoteDetail) {
+			if vote.Success && vote.Response {
+				mu.Lock()
+				approvals = append(approvals, time.Since(start))
+				mu.Unlock()
+			}
+		}
+	}
+
+	result, err := c.Sign(req)
+	s := sample{latency: time.Since(start)}
+	if err == nil && !result.Success {
+		err = errors.New(result.Error)
+	}
+	if err != nil {
+		s.err = err.Error()
+		return s
+	}
+	if cfg.vote && result.VotingInfo != nil {
+		mu.Lock()
+		if required := result.VotingInfo.RequiredVotes; required > 0 && required <= len(approvals) {
+			s.quorum = approvals[required-1]
+		} else if len(approvals) > 0 {
+			s.quorum = approvals[len(approvals)-1]
+		}
+		mu.Unlock()
+	}
+	return s
+}
+
+// newReport aggregates samples into a report
+func newReport(cfg config, samples []sample, elapsed time.Duration) *Report {
+	report := &Report{
+		AppID:       cfg.appID,
+		Concurrency: cfg.concurrency,
+		Voting:      cfg.vote,
+		MessageSize: cfg.messageSize,
+		Elapsed:     elapsed,
+		Requests:    len(samples),
+	}
+
+	var latencies, rounds []time.Duration
+	errorCounts := make(map[string]int)
+	for _, s := range samples {
+		if s.err != "" {
+			errorCounts[s.err]++
+			continue
+		}
+		latencies = append(latencies, s.latency)
+		if s.quorum > 0 {
+			rounds = append(rounds, s.quorum)
+		}
+	}
+	report.Succeeded = len(latencies)
+	report.Failed = report.Requests - report.Succeeded
+	if elapsed > 0 {
+		report.Throughput = float64(report.Succeeded) / elapsed.Seconds()
+	}
+	report.Latency = summarize(latencies)
+	if cfg.vote {
+		stats := summarize(rounds)
+		report.VotingRound = &stats
+	}
+
+	for message, count := range errorCounts {
+		report.Errors = append(report.Errors, ErrorCount{Error: message, Count: count})
+	}
+	sort.Slice(report.Errors, func(i, j int) bool {
+		if report.Errors[i].Count != report.Errors[j].Count {
+			return report.Errors[i].Count > report.Errors[j].Count
+		}
+		return report.Errors[i].Error < report.Errors[j].Error
+	})
+	if len(report.Errors) > maxErrorKinds {
+		report.Errors = report.Errors[:maxErrorKinds]
+	}
+	return report
+}
+
+// summarize computes nearest-rank percentiles of durations
+func summarize(durations []time.Duration) Stats {
+	if len(durations) == 0 {
+		return Stats{}
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	percentile := func(p float64) time.Duration {
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		return sorted[max(rank-1, 0)]
+	}
+	return Stats{
+		Count: len(sorted),
+		Min:   sorted[0],
+		Mean:  total / time.Duration(len(sorted)),
+		P50:   percentile(50),
+		P90:   percentile(90),
+		P95:   percentile(95),
+		P99:   percentile(99),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// print writes the report as text
+func (r *Report) print(w io.Writer) {
+	voting := "off"
+	if r.Voting {
+		voting = "on"
+	}
+	fmt.Fprintf(w, "App:          %s (%d workers, %d-byte messages, voting %s)\n", r.AppID, r.Concurrency, r.MessageSize, voting)
+	fmt.Fprintf(w, "Elapsed:      %s\n", r.Elapsed.Round(time.Millisecond))
+	fmt.Fprintf(w, "Requests:     %d (%d succeeded, %d failed)\n", r.Requests, r.Succeeded, r.Failed)
+	fmt.Fprintf(w, "Throughput:   %.2f signs/s\n", r.Throughput)
+	fmt.Fprintf(w, "Latency:      %s\n", r.Latency)
+	if r.VotingRound != nil {
+		fmt.Fprintf(w, "Voting round: %s\n", r.VotingRound)
+	}
+	if len(r.Errors) > 0 {
+		fmt.Fprintln(w, "Errors:")
+		for _, e := range r.Errors {
+			fmt.Fprintf(w, "  %6d  %s\n", e.Count, e.Error)
+		}
+	}
+}
+
+// String formats the stats on one line
+func (s Stats) String() string {
+	if s.Count == 0 {
+		return "no samples"
+	}
+	parts := []string{
+		"min " + formatDuration(s.Min),
+		"mean " + formatDuration(s.Mean),
+		"p50 " + formatDuration(s.P50),
+		"p90 " + formatDuration(s.P90),
+		"p95 " + formatDuration(s.P95),
+		"p99 " + formatDuration(s.P99),
+		"max " + formatDuration(s.Max),
+	}
+	return strings.Join(parts, "  ")
+}
+
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
+
+// getEnv returns the environment variable or a default value
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func fatalf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "teenet-bench: "+format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,137 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Command teenet-signd runs a TEENet client as a signing microservice
+//
+// Configuration is read from environment variables:
+//
+//	TEE_CONFIG_ADDR        TEE configuration server address (default localhost:50052)
+//	TEENET_*               other client settings, see client.NewFromEnv
+//	SIGND_GRPC_ADDR        gRPC listen address (default :50060, "off" to disable)
+//	SIGND_HTTP_ADDR        REST listen address (default :8081, "off" to disable)
+//	SIGND_TOKENS           Comma-separated bearer tokens accepted from callers
+//	SIGND_API_KEYS_FILE    JSON file of named API keys with app access and rate limits
+//	                       (see server.APIKey); this or SIGND_TOKENS is required
+//	SIGND_ALLOW_NO_AUTH    Set to "true" to run without tokens, for local testing only
+//	SIGND_SIGNATURE_ENCODING
+//	                       "base64" (default) or "hex" signatures in REST sign responses
+//	SIGND_ENABLE_VOTING    Set to "true" to run every sign request through voting
+//	SIGND_LOCAL_APPROVAL   Set to "true" to approve voting rounds on this node
+//	SIGND_BYPASS_DEDUP     Set to "true" to never serve cached signatures
+//
+// Callers can't set voting, local approval, dedup bypass or their principal themselves
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	client "github.com/TEENet-io/teenet-sdk/go"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/server"
+)
+
+// shutdownTimeout bounds how long in-flight requests are drained on exit
+const shutdownTimeout = 30 * time.Second
+
+func main() {
+	grpcAddr := getEnv("SIGND_GRPC_ADDR", ":50060")
+	httpAddr := getEnv("SIGND_HTTP_ADDR", ":8081")
+
+	var tokens []string
+	for _, token := range strings.Split(os.Getenv("SIGND_TOKENS"), ",") {
+		if token = strings.TrimSpace(token); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	var apiKeys []server.APIKey
+	if path := os.Getenv("SIGND_API_KEYS_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Fatalf("Failed to read SIGND_API_KEYS_FILE: %v", err)
+		}
+		if err := json.Unmarshal(data, &apiKeys); err != nil {
+			log.Fatalf("Invalid SIGND_API_KEYS_FILE: %v", err)
+		}
+		for _, key := range apiKeys {
+			if key.Name == "" || key.Key == "" {
+				log.Fatalf("Invalid SIGND_API_KEYS_FILE: every API key needs a name and a key")
+			}
+		}
+		log.Printf("🔑 Loaded %d API keys", len(apiKeys))
+	}
+	if len(tokens) == 0 && len(apiKeys) == 0 && os.Getenv("SIGND_ALLOW_NO_AUTH") != "true" {
+		log.Fatalf("SIGND_TOKENS or SIGND_API_KEYS_FILE is required (set SIGND_ALLOW_NO_AUTH=true for local testing)")
+	}
+
+	var encoding client.SignatureEncoding
+	if value := os.Getenv("SIGND_SIGNATURE_ENCODING"); value != "" {
+		if err := encoding.UnmarshalText([]byte(value)); err != nil {
+			log.Fatalf("Invalid SIGND_SIGNATURE_ENCODING: %v", err)
+		}
+	}
+
+	teeClient, err := client.NewFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid TEE client configuration: %v", err)
+	}
+	if err := teeClient.Init(nil); err != nil {
+		log.Fatalf("Failed to initialize TEE client: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := server.New(teeClient, server.Config{
+		GRPCAddr: disabledIfOff(grpcAddr),
+		HTTPAddr: disabledIfOff(httpAddr),
+		Tokens:   tokens,
+		APIKeys:  apiKeys,
+
+		SignatureEncoding: encoding,
+		EnableVoting:      os.Getenv("SIGND_ENABLE_VOTING") == "true",
+		LocalApproval:     os.Getenv("SIGND_LOCAL_APPROVAL") == "true",
+		BypassDedup:       os.Getenv("SIGND_BYPASS_DEDUP") == "true",
+	})
+	if err := srv.Run(ctx); err != nil {
+		log.Printf("❌ Signing service error: %v", err)
+	}
+
+	// Let in-flight sign calls and voting rounds finish before disconnecting
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := teeClient.Shutdown(shutdownCtx); err != nil {
+		log.Printf("❌ TEE client shutdown error: %v", err)
+	}
+}
+
+// getEnv returns the environment variable or a default value
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// disabledIfOff maps "off" to an empty (disabled) address
+func disabledIfOff(addr string) string {
+	if addr == "off" {
+		return ""
+	}
+	return addr
+}
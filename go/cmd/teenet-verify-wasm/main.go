@@ -0,0 +1,190 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+//go:build js && wasm
+
+// Command teenet-verify-wasm exposes pkg/verification to JavaScript, so browser frontends can
+// verify signatures locally without a round trip to a server
+//
+// Build:
+//
+//	GOOS=js GOARCH=wasm go build -o teenet-verify.wasm ./cmd/teenet-verify-wasm
+//	cp "$(go env GOROOT)/lib/wasm/wasm_exec.js" .
+//
+// Once loaded it defines globalThis.teenet with:
+//
+//	verifySignature(message, publicKey, signature, protocol, curve)
+//	verifyEthereumMessage(message, signature, address[, chain])
+//	verifyBitcoinMessage(message, publicKey, signature)
+//	openEnvelope(envelope, message, publicKey)
+//
+// Each returns {valid: boolean} on success, plus the parsed envelope for openEnvelope, or
+// {error: string}. Byte arguments are Uint8Arrays or strings: messages are UTF-8, keys and
+// signatures hex (optionally 0x-prefixed), Bitcoin signatures base64 and envelopes JSON.
+// Protocols and curves are names such as "ecdsa" and "secp256k1"
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall/js"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+func main() {
+	js.Global().Set("teenet", js.ValueOf(map[string]any{
+		"verifySignature":       export(verifySignature),
+		"verifyEthereumMessage": export(verifyEthereumMessage),
+		"verifyBitcoinMessage":  export(verifyBitcoinMessage),
+		"openEnvelope":          export(openEnvelope),
+	}))
+	select {}
+}
+
+// export wraps fn as a JavaScript function returning a result object; errors and panics
+// become {error: message} instead of aborting the Go runtime
+func export(fn func(args []js.Value) (map[string]any, error)) js.Func {
+	return js.FuncOf(func(_ js.Value, args []js.Value) (result any) {
+		defer func() {
+			if r := recover(); r != nil {
+				result = js.ValueOf(map[string]any{"error": fmt.Sprint(r)})
+			}
+		}()
+		out, err := fn(args)
+		if err != nil {
+			return js.ValueOf(map[string]any{"error": err.Error()})
+		}
+		return js.ValueOf(out)
+	})
+}
+
+func verifySignature(args []js.Value) (map[string]any, error) {
+	if len(args) != 5 {
+		return nil, fmt.Errorf("verifySignature expects 5 arguments, got %d", len(args))
+	}
+	publicKey, err := hexArg(args[1], "publicKey")
+	if err != nil {
+		return nil, err
+	}
+	signature, err := hexArg(args[2], "signature")
+	if err != nil {
+		return nil, err
+	}
+	var protocol constants.Protocol
+	if err := protocol.UnmarshalJSON([]byte(strconv.Quote(args[3].String()))); err != nil {
+		return nil, err
+	}
+	var curve constants.Curve
+	if err := curve.UnmarshalJSON([]byte(strconv.Quote(args[4].String()))); err != nil {
+		return nil, err
+	}
+	valid, err := verification.VerifySignature(textArg(args[0]), publicKey, signature, protocol, curve)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"valid": valid}, nil
+}
+
+func verifyEthereumMessage(args []js.Value) (map[string]any, error) {
+	if len(args) != 3 && len(args) != 4 {
+		return nil, fmt.Errorf("verifyEthereumMessage expects 3 or 4 arguments, got %d", len(args))
+	}
+	chain := verification.ChainEthereum
+	if len(args) == 4 && !args[3].IsUndefined() {
+		var ok bool
+		if chain, ok = verification.LookupChain(args[3].String()); !ok {
+			return nil, fmt.Errorf("unknown chain %q", args[3].String())
+		}
+	}
+	signature, err := hexArg(args[1], "signature")
+	if err != nil {
+		return nil, err
+	}
+	valid, err := chain.VerifyMessageAddress(textArg(args[0]), signature, args[2].String())
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"valid": valid}, nil
+}
+
+func verifyBitcoinMessage(args []js.Value) (map[string]any, error) {
+	if len(args) != 3 {
+		return nil, fmt.Errorf("verifyBitcoinMessage expects 3 arguments, got %d", len(args))
+	}
+	publicKey, err := hexArg(args[1], "publicKey")
+	if err != nil {
+		return nil, err
+	}
+	valid, err := verification.VerifyBitcoinMessage(textArg(args[0]), publicKey, args[2].String())
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"valid": valid}, nil
+}
+
+func openEnvelope(args []js.Value) (map[string]any, error) {
+	if len(args) != 3 {
+		return nil, fmt.Errorf("openEnvelope expects 3 arguments, got %d", len(args))
+	}
+	publicKey, err := hexArg(args[2], "publicKey")
+	if err != nil {
+		return nil, err
+	}
+	envelope, err := verification.OpenEnvelope(textArg(args[0]), textArg(args[1]), publicKey)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"valid": true, "envelope": js.Global().Get("JSON").Call("parse", string(data))}, nil
+}
+
+// textArg returns a Uint8Array's bytes or a string's UTF-8 encoding
+func textArg(v js.Value) []byte {
+	if b, ok := bytesArg(v); ok {
+		return b
+	}
+	return []byte(v.String())
+}
+
+// hexArg returns a Uint8Array's bytes or decodes a hex string
+func hexArg(v js.Value, name string) ([]byte, error) {
+	if b, ok := bytesArg(v); ok {
+		return b, nil
+	}
+	if v.Type() != js.TypeString {
+		return nil, fmt.Errorf("%s must be a Uint8Array or hex string", name)
+	}
+	decoded, err := hex.DecodeString(strings.TrimPrefix(v.String(), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s hex: %w", name, err)
+	}
+	return decoded, nil
+}
+
+// bytesArg copies a Uint8Array into Go
+func bytesArg(v js.Value) ([]byte, bool) {
+	if v.Type() != js.TypeObject || !v.InstanceOf(js.Global().Get("Uint8Array")) {
+		return nil, false
+	}
+	b := make([]byte, v.Length())
+	js.CopyBytesToGo(b, v)
+	return b, true
+}
@@ -0,0 +1,512 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Command teenet is a command line client for the TEENet key management service
+//
+// Usage:
+//
+//	teenet [global flags] <command> [command flags]
+//
+// Commands:
+//
+//	get-pubkey   print an app's public key
+//	sign         sign a message
+//	verify       verify a signature (exit status 1 if invalid)
+//	vote-status  print an app's voting configuration
+//	key-details  print an app's threshold key parameters (exit status 1 if below -min-*)
+//	watch-key-op follow a DKG or resharing operation (exit status 1 if it fails)
+//	store-key    store a client key in the OS keyring, for TEENET_CLIENT_KEY_KEYRING
+//	verify-audit check the hash chain of an audit log file (exit status 1 if broken)
+//
+// The config server address is taken from -config-addr or TEE_CONFIG_ADDR, and the
+// app ID from -app-id or APP_ID
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	client "github.com/TEENet-io/teenet-sdk/go"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/audit"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/secret"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/threshold"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+// errInvalidSignature makes verify exit with status 1 without printing an error
+var errInvalidSignature = errors.New("invalid signature")
+
+// command is a CLI subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, connect func() *client.Client) error
+}
+
+var commands = []command{
+	{"get-pubkey", "print an app's public key", runGetPubkey},
+	{"sign", "sign a message", runSign},
+	{"verify", "verify a signature (exit status 1 if invalid)", runVerify},
+	{"vote-status", "print an app's voting configuration", runVoteStatus},
+	{"key-details", "print an app's threshold key parameters", runKeyDetails},
+	{"watch-key-op", "follow a DKG or resharing operation", runWatchKeyOp},
+	{"store-key", "store a client key in the OS keyring", runStoreKey},
+	{"verify-audit", "check the hash chain of an audit log file", runVerifyAudit},
+}
+
+func main() {
+	configAddr := flag.String("config-addr", getEnv("TEE_CONFIG_ADDR", "localhost:50052"), "TEE configuration server address")
+	timeout := flag.Duration("timeout", 0, "request timeout (default: client default)")
+	verbose := flag.Bool("v", false, "print client logs to stderr")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == flag.Arg(0) {
+			cmd = &commands[i]
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "teenet: unknown command %q\n\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	// Connect lazily so command flag errors and -h don't need a reachable server
+	var teeClient *client.Client
+	connect := func() *client.Client {
+		teeClient = client.NewClient(*configAddr)
+		teeClient.DisableVotingService()
+		if *timeout > 0 {
+			teeClient.SetTimeout(*timeout)
+		}
+		if err := teeClient.Init(nil); err != nil {
+			fatalf("failed to initialize client: %v", err)
+		}
+		return teeClient
+	}
+
+	err := cmd.run(flag.Args()[1:], connect)
+	if teeClient != nil {
+		teeClient.Close()
+	}
+	if errors.Is(err, errInvalidSignature) {
+		os.Exit(1)
+	}
+	if err != nil {
+		fatalf("%s: %v", cmd.name, err)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: teenet [global flags] <command> [command flags]\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(os.Stderr, "\nGlobal flags:\n")
+	flag.PrintDefaults()
+	fmt.Fprintf(os.Stderr, "\nRun 'teenet <command> -h' for command flags.\n")
+}
+
+func runGetPubkey(args []string, connect func() *client.Client) error {
+	fs := flag.NewFlagSet("get-pubkey", flag.ExitOnError)
+	appID := appIDFlag(fs)
+	format := fs.String("format", "hex", "output format: hex, raw, compressed, pem or ssh")
+	asJSON := fs.Bool("json", false, "print key, protocol and curve as JSON")
+	fs.Parse(args)
+	if err := requireAppID(*appID); err != nil {
+		return err
+	}
+	c := connect()
+
+	if *asJSON {
+		keyInfo, err := c.GetPublicKeyByAppID(*appID)
+		if err != nil {
+			return err
+		}
+		return printJSON(map[string]string{
+			"app_id":     *appID,
+			"public_key": hex.EncodeToString(keyInfo.Key),
+			"protocol":   keyInfo.Protocol.String(),
+			"curve":      keyInfo.Curve.String(),
+		})
+	}
+
+	switch *format {
+	case "hex":
+		keyInfo, err := c.GetPublicKeyByAppID(*appID)
+		if err != nil {
+			return err
+		}
+		fmt.Println(hex.EncodeToString(keyInfo.Key))
+	case "raw", "compressed":
+		key, err := c.ExportPublicKey(*appID, verification.PublicKeyFormat(*format))
+		if err != nil {
+			return err
+		}
+		fmt.Println(hex.EncodeToString(key))
+	case "pem", "ssh":
+		key, err := c.ExportPublicKey(*appID, verification.PublicKeyFormat(*format))
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(key))
+	default:
+		return fmt.Errorf("unsupported format %q", *format)
+	}
+	return nil
+}
+
+func runSign(args []string, connect func() *client.Client) error {
+	fs := flag.NewFlagSet("sign", flag.ExitOnError)
+	appID := appIDFlag(fs)
+	input := messageFlags(fs)
+	encoding := fs.String("encoding", "hex", "signature output encoding: hex or base64")
+	vote := fs.Bool("vote", false, "run a voting round (with local approval) before signing")
+	asJSON := fs.Bool("json", false, "print the full sign result as JSON")
+	fs.Parse(args)
+	if err := requireAppID(*appID); err != nil {
+		return err
+	}
+	message, err := input.read()
+	if err != nil {
+		return err
+	}
+	c := connect()
+
+	req := &client.SignRequest{
+		Message:      message,
+		AppID:        *appID,
+		EnableVoting: *vote,
+	}
+	if *vote {
+		req.LocalApproval = true
+		req.VoteRequestData, _ = json.Marshal(map[string]string{"message": string(message)})
+	}
+	result, err := c.Sign(req)
+	if *asJSON && result != nil {
+		if printErr := printJSON(result); printErr != nil {
+			return printErr
+		}
+		if err == nil && !result.Success {
+			return errors.New(result.Error)
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if !result.Success {
+		return errors.New(result.Error)
+	}
+
+	encoded, err := encodeBytes(result.Signature, *encoding)
+	if err != nil {
+		return err
+	}
+	fmt.Println(encoded)
+	return nil
+}
+
+func runVerify(args []string, connect func() *client.Client) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	appID := appIDFlag(fs)
+	input := messageFlags(fs)
+	signature := fs.String("signature", "", "signature to verify (required)")
+	encoding := fs.String("encoding", "hex", "signature encoding: hex or base64")
+	fs.Parse(args)
+	if err := requireAppID(*appID); err != nil {
+		return err
+	}
+	if *signature == "" {
+		return fmt.Errorf("-signature is required")
+	}
+	message, err := input.read()
+	if err != nil {
+		return err
+	}
+	sig, err := decodeBytes(*signature, *encoding)
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	valid, err := connect().Verify(message, sig, *appID)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		fmt.Println("invalid")
+		return errInvalidSignature
+	}
+	fmt.Println("valid")
+	return nil
+}
+
+func runVoteStatus(args []string, connect func() *client.Client) error {
+	fs := flag.NewFlagSet("vote-status", flag.ExitOnError)
+	appID := appIDFlag(fs)
+	asJSON := fs.Bool("json", false, "print as JSON")
+	fs.Parse(args)
+	if err := requireAppID(*appID); err != nil {
+		return err
+	}
+
+	config, err := connect().GetVotingConfig(*appID)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(config)
+	}
+	fmt.Printf("App ID:          %s\n", config.AppID)
+	fmt.Printf("Required votes:  %d/%d\n", config.RequiredVotes, len(config.Targets))
+	fmt.Printf("Voting path:     %s\n", config.VotingSignPath)
+	fmt.Printf("Targets:         %s\n", strings.Join(config.Targets, ", "))
+	return nil
+}
+
+func runKeyDetails(args []string, connect func() *client.Client) error {
+	fs := flag.NewFlagSet("key-details", flag.ExitOnError)
+	appID := appIDFlag(fs)
+	asJSON := fs.Bool("json", false, "print as JSON")
+	minThreshold := fs.Int("min-threshold", 0, "fail unless the key needs at least this many signers")
+	minParticipants := fs.Int("min-participants", 0, "fail unless at least this many participants hold a share")
+	schemes := fs.String("scheme", "", "comma-separated accepted schemes (default any)")
+	fs.Parse(args)
+	if err := requireAppID(*appID); err != nil {
+		return err
+	}
+
+	details, err := connect().GetKeyDetails(*appID)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		if err := printJSON(details); err != nil {
+			return err
+		}
+	} else {
+		ids := make([]string, len(details.ParticipantIDs))
+		for i, id := range details.ParticipantIDs {
+			ids[i] = fmt.Sprint(id)
+		}
+		fmt.Printf("App ID:          %s\n", *appID)
+		fmt.Printf("Scheme:          %s\n", details)
+		fmt.Printf("Key:             %s %s %x\n", details.Protocol, details.Curve, details.Key)
+		fmt.Printf("Participants:    %s\n", strings.Join(ids, ", "))
+		if !details.CreatedAt.IsZero() {
+			fmt.Printf("Created:         %s\n", details.CreatedAt.UTC().Format(time.RFC3339))
+		}
+	}
+
+	req := threshold.Requirement{MinThreshold: *minThreshold, MinParticipants: *minParticipants}
+	if *schemes != "" {
+		req.Schemes = strings.Split(*schemes, ",")
+	}
+	return details.Check(req)
+}
+
+func runWatchKeyOp(args []string, connect func() *client.Client) error {
+	fs := flag.NewFlagSet("watch-key-op", flag.ExitOnError)
+	id := fs.String("id", "", "operation ID (required)")
+	asJSON := fs.Bool("json", false, "print each status as a JSON line")
+	fs.Parse(args)
+	if *id == "" {
+		return fmt.Errorf("-id is required")
+	}
+
+	watch, err := connect().WatchKeyOperation(context.Background(), *id)
+	if err != nil {
+		return err
+	}
+	for status := range watch.Updates() {
+		if *asJSON {
+			line, err := json.Marshal(status)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(line))
+			continue
+		}
+		progress := make([]string, len(status.Participants))
+		for i, p := range status.Participants {
+			progress[i] = fmt.Sprintf("%d:%s", p.ID, p.State)
+			if p.Error != "" {
+				progress[i] += fmt.Sprintf("(%s)", p.Error)
+			}
+		}
+		fmt.Printf("%s %s round %d/%d  %s\n", status.Type, status.State, status.Round, status.TotalRounds, strings.Join(progress, " "))
+	}
+	if err := watch.Err(); err != nil {
+		return err
+	}
+	fmt.Println("completed")
+	return nil
+}
+
+func runStoreKey(args []string, connect func() *client.Client) error {
+	fs := flag.NewFlagSet("store-key", flag.ExitOnError)
+	name := fs.String("name", "", "keyring item name (required)")
+	file := fs.String("file", "-", "PEM key file ('-' for stdin)")
+	remove := fs.Bool("delete", false, "delete the item instead")
+	fs.Parse(args)
+	if *name == "" {
+		return fmt.Errorf("-name is required")
+	}
+	keyring, err := secret.OSKeyring()
+	if err != nil {
+		return err
+	}
+	if *remove {
+		return keyring.Delete(*name)
+	}
+
+	var key []byte
+	if *file == "-" {
+		key, err = io.ReadAll(os.Stdin)
+	} else {
+		key, err = os.ReadFile(*file)
+	}
+	if err != nil {
+		return err
+	}
+	defer secret.Zero(key)
+	if err := keyring.Set(*name, key); err != nil {
+		return err
+	}
+	fmt.Printf("stored; use TEENET_CLIENT_KEY_KEYRING=%s\n", *name)
+	return nil
+}
+
+func runVerifyAudit(args []string, connect func() *client.Client) error {
+	fs := flag.NewFlagSet("verify-audit", flag.ExitOnError)
+	file := fs.String("file", "", "audit log written by audit.FileSink (required)")
+	fs.Parse(args)
+	if *file == "" {
+		return fmt.Errorf("-file is required")
+	}
+	count, err := audit.VerifyFile(*file)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d entries, chain intact\n", count)
+	return nil
+}
+
+// messageInput holds the mutually exclusive message flags
+type messageInput struct {
+	text, hexText, file *string
+}
+
+func messageFlags(fs *flag.FlagSet) *messageInput {
+	return &messageInput{
+		text:    fs.String("message", "", "message as a UTF-8 string"),
+		hexText: fs.String("message-hex", "", "message as hex"),
+		file:    fs.String("message-file", "", "read the message from a file ('-' for stdin)"),
+	}
+}
+
+// read returns the message from whichever flag was set
+func (m *messageInput) read() ([]byte, error) {
+	set := 0
+	for _, v := range []string{*m.text, *m.hexText, *m.file} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, fmt.Errorf("exactly one of -message, -message-hex or -message-file is required")
+	}
+
+	switch {
+	case *m.text != "":
+		return []byte(*m.text), nil
+	case *m.hexText != "":
+		return hex.DecodeString(strings.TrimPrefix(*m.hexText, "0x"))
+	case *m.file == "-":
+		return io.ReadAll(os.Stdin)
+	default:
+		return os.ReadFile(*m.file)
+	}
+}
+
+func appIDFlag(fs *flag.FlagSet) *string {
+	return fs.String("app-id", os.Getenv("APP_ID"), "app ID (default $APP_ID)")
+}
+
+func requireAppID(appID string) error {
+	if appID == "" {
+		return fmt.Errorf("-app-id or APP_ID is required")
+	}
+	return nil
+}
+
+func encodeBytes(data []byte, encoding string) (string, error) {
+	switch encoding {
+	case "hex":
+		return hex.EncodeToString(data), nil
+	case "base64":
+		return base64.StdEncoding.EncodeToString(data), nil
+	default:
+		return "", fmt.Errorf("unsupported encoding %q", encoding)
+	}
+}
+
+func decodeBytes(data, encoding string) ([]byte, error) {
+	switch encoding {
+	case "hex":
+		return hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	case "base64":
+		return base64.StdEncoding.DecodeString(data)
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q", encoding)
+	}
+}
+
+func printJSON(v any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// getEnv returns the environment variable or a default value
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func fatalf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "teenet: "+format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,148 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/deadletter"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/task"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+)
+
+// CommitConfig configures two-phase voting rounds, see EnableVoteCommit
+type CommitConfig struct {
+	Timeout time.Duration // Bound on delivering commit notifications, constants.VoteCommitTimeout by default
+}
+
+// EnableVoteCommit runs voting rounds in two phases. Vote requests are marked as the prepare
+// phase and carry the digest of the message voters approve; once the message is signed, every
+// participant (including delegates) receives a commit notification on its voting sign path with
+// the message, the final signature and who approved. Must be called before Init
+//
+// Voting handlers pass commit notifications to Sign like any vote request: Sign verifies them
+// and reports the result in SignResult.Commit without signing anything
+func (c *Client) EnableVoteCommit(config CommitConfig) {
+	if config.Timeout <= 0 {
+		config.Timeout = constants.VoteCommitTimeout
+	}
+	c.voteCommit = &config
+}
+
+// VerifyCommit checks that a commit notification's digest matches its message and that the
+// signature verifies under the signing app's public key
+func (c *Client) VerifyCommit(ctx context.Context, commit *voting.Commit) error {
+	if commit.MessageDigest != voting.MessageDigest(commit.Message) {
+		return fmt.Errorf("commit from %s: message does not match digest %s", commit.SignerAppID, commit.MessageDigest)
+	}
+	signature, err := hex.DecodeString(commit.Signature)
+	if err != nil {
+		return fmt.Errorf("commit from %s: invalid signature encoding: %w", commit.SignerAppID, err)
+	}
+	keyInfo, err := c.getPublicKey(ctx, commit.SignerAppID)
+	if err != nil {
+		return fmt.Errorf("failed to get public key of %s: %w", commit.SignerAppID, err)
+	}
+	opts := &task.SignOptions{ED25519Mode: commit.ED25519Mode, ED25519Context: commit.ED25519Context}
+	return verifyReturnedSignature(commit.Message, signature, keyInfo, opts)
+}
+
+// receiveCommit answers a commit notification that reached this app's voting handler
+func (c *Client) receiveCommit(ctx context.Context, commit *voting.Commit) *SignResult {
+	if err := c.VerifyCommit(ctx, commit); err != nil {
+		log.Printf("❌ Invalid commit from %s: %v", commit.SignerAppID, err)
+		return &SignResult{Success: false, Error: err.Error(), Commit: commit}
+	}
+	log.Printf("📜 Commit from %s verified for message digest %s", commit.SignerAppID, commit.MessageDigest)
+	signature, _ := hex.DecodeString(commit.Signature)
+	return &SignResult{Success: true, Signature: signature, Commit: commit}
+}
+
+// newCommit builds the commit notification of a signed voting round
+func newCommit(signerAppID string, message, signature []byte, signOpts *task.SignOptions, requiredVotes int, approvedBy map[string]bool) *voting.Commit {
+	commit := &voting.Commit{
+		Phase:         voting.PhaseCommit,
+		IsForwarded:   true,
+		SignerAppID:   signerAppID,
+		Message:       message,
+		MessageDigest: voting.MessageDigest(message),
+		Signature:     hex.EncodeToString(signature),
+		RequiredVotes: requiredVotes,
+		Timestamp:     time.Now().Unix(),
+	}
+	if signOpts != nil {
+		commit.ED25519Mode, commit.ED25519Context = signOpts.ED25519Mode, signOpts.ED25519Context
+	}
+	for appID := range approvedBy {
+		commit.ApprovedBy = append(commit.ApprovedBy, appID)
+	}
+	slices.Sort(commit.ApprovedBy)
+	return commit
+}
+
+// commitRound sends the commit notification to every remote participant of a round, including
+// apps that voted through a delegation, and returns the participants it didn't reach
+func (c *Client) commitRound(ctx context.Context, roundID string, commit *voting.Commit, voteDetails []VoteDetail, targets map[string]*usermgmt.DeploymentTarget, headers map[string]string) []string {
+	// The round's own deadline may be nearly spent, the commit gets a fresh one
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.voteCommit.Timeout)
+	defer cancel()
+
+	var participants []string
+	for _, detail := range voteDetails {
+		participants = append(participants, detail.ClientID)
+		if len(detail.DelegationChain) > 1 {
+			participants = append(participants, detail.DelegationChain[1:]...)
+		}
+	}
+
+	var mu sync.Mutex
+	var failed []string
+	var wg sync.WaitGroup
+	for _, appID := range participants {
+		if appID == commit.SignerAppID {
+			continue
+		}
+		wg.Add(1)
+		go func(appID string) {
+			defer wg.Done()
+			target, ok := targets[appID]
+			var err error
+			if !ok {
+				target, err = c.delegateTarget(ctx, appID)
+			}
+			if err == nil {
+				err = c.voteSender.Commit(ctx, target, commit, headers)
+			}
+			if err != nil {
+				log.Printf("⚠️  Failed to deliver commit to %s: %v", appID, err)
+				c.deadLetterNotification(deadletter.KindCommit, roundID, commit.SignerAppID, appID, commit, headers, err)
+				mu.Lock()
+				failed = append(failed, appID)
+				mu.Unlock()
+			}
+		}(appID)
+	}
+	wg.Wait()
+
+	slices.Sort(failed)
+	return failed
+}
@@ -0,0 +1,580 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/acl"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/auth"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/cache"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/config"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/revocation"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/utils"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+)
+
+// DefaultConfigServerAddr is used when no config server address is configured
+const DefaultConfigServerAddr = "localhost:50052"
+
+// Config holds everything needed to construct a client, as loaded by NewFromEnv or NewFromConfigFile
+type Config struct {
+	ConfigServerAddr string   `json:"config_server_addr"` // TEE configuration server address
+	Timeout          Duration `json:"timeout"`            // Default timeout for client operations
+	TaskTimeout      Duration `json:"task_timeout"`       // Timeout for TEE sign calls
+	ConfigTimeout    Duration `json:"config_timeout"`     // Timeout for fetching node configuration
+
+	PublicKeyCacheTTL    Duration `json:"public_key_cache_ttl"`    // How long fetched public keys are reused
+	VotingConfigCacheTTL Duration `json:"voting_config_cache_ttl"` // How long voting configurations are reused
+
+	// KeyRotationGracePeriod is how long keys replaced by Client.RotateKey keep verifying signatures
+	KeyRotationGracePeriod Duration `json:"key_rotation_grace_period"`
+
+	// Cache selects where cached keys and voting configurations are kept, see Client.SetCacheBackend
+	Cache CacheConfig `json:"cache"`
+
+	Voting   VotingServiceConfig `json:"voting"`
+	GRPC     GRPCConfig          `json:"grpc"`
+	Logging  LoggingConfig       `json:"logging"`
+	Locality LocalityConfig      `json:"locality"`
+	Signing  SigningConfig       `json:"signing"`
+	TLS      utils.TLSOptions    `json:"tls"`
+
+	Revocation RevocationConfig `json:"revocation"`
+
+	// VotingAuthority pins the keys voting configurations must be signed with
+	VotingAuthority VotingAuthorityConfig `json:"voting_authority"`
+
+	// Auth authenticates to TEE and App nodes with bearer tokens instead of the client certificate
+	Auth auth.Tokens `json:"auth"`
+
+	// Static supplies credentials and nodes locally, bypassing the config server when complete
+	Static config.Override `json:"static"`
+
+	// ACL restricts which principals may use which app IDs, see Client.SetACL
+	ACL *acl.List `json:"acl"`
+
+	// Principal identifies this client's caller, see Client.SetPrincipal
+	Principal *voting.Principal `json:"principal"`
+}
+
+// RevocationConfig configures revocation checking of node certificates, see Client.SetRevocationChecker
+type RevocationConfig struct {
+	Enabled     bool     `json:"enabled"`
+	HardFail    bool     `json:"hard_fail"`    // Reject certificates whose status can't be determined
+	DisableOCSP bool     `json:"disable_ocsp"` // Only use CRLs
+	DisableCRL  bool     `json:"disable_crl"`  // Only use OCSP
+	CacheTTL    Duration `json:"cache_ttl"`    // Longest time a result is reused
+}
+
+// CacheConfig configures a shared cache backend; without a Redis address the cache is in memory
+type CacheConfig struct {
+	RedisAddr     string `json:"redis_addr"` // Redis server host:port
+	RedisUsername string `json:"redis_username"`
+	RedisPassword string `json:"redis_password"`
+	RedisDB       int    `json:"redis_db"`
+	RedisPrefix   string `json:"redis_prefix"` // Key prefix, so deployments can share a server
+	RedisTLS      bool   `json:"redis_tls"`    // Connect over TLS, verifying against system roots
+}
+
+// VotingAuthorityConfig configures voting configuration signature checks, see Client.SetVotingAuthority
+type VotingAuthorityConfig struct {
+	Keys   []string `json:"keys"`    // PEM public keys or hex Ed25519 keys; none disables the check
+	MaxAge Duration `json:"max_age"` // Refuse configurations signed longer ago, if set
+}
+
+// SigningConfig configures sign requests
+type SigningConfig struct {
+	SkipVerification bool `json:"skip_verification"` // Don't verify TEE-returned signatures, see Client.SetSignatureVerification
+
+	// MaxMessageSize and MaxVoteRequestSize limit payloads in bytes, see Client.SetMaxPayloadSize
+	MaxMessageSize     int `json:"max_message_size"`
+	MaxVoteRequestSize int `json:"max_vote_request_size"`
+
+	// DomainTags maps app IDs to domain separation tags, see Client.SetDomainSeparation
+	DomainTags map[string]string `json:"domain_tags"`
+	// UntaggedSigning lists apps with a domain tag that may still use digest, transaction and
+	// MuSig2 signing, see Client.AllowUntaggedSigning
+	UntaggedSigning []string `json:"untagged_signing"`
+}
+
+// LocalityConfig sets the preferred region and zone for node selection, see Client.SetPreferredLocality
+type LocalityConfig struct {
+	Region string `json:"region"`
+	Zone   string `json:"zone"`
+}
+
+// VotingServiceConfig configures the local voting service and how vote requests are sent
+type VotingServiceConfig struct {
+	Disabled  bool             `json:"disabled"`  // Don't start the voting service in Init
+	Addr      string           `json:"addr"`      // Listen address, default ":50051"
+	Transport voting.Transport `json:"transport"` // "proxy" (default), "direct", "grpc" or "rest", see Client.SetVoteTransport
+	// GatewayAddr also serves the voting service as REST/JSON, see Client.SetVotingGatewayAddr
+	GatewayAddr string `json:"gateway_addr"`
+	// CompressThreshold gzips vote requests larger than this many bytes, see Client.SetVoteCompression
+	CompressThreshold int `json:"compress_threshold"`
+	// RetryAttempts sends failed vote requests up to this many times, see Client.SetVoteRetry
+	RetryAttempts int `json:"retry_attempts"`
+}
+
+// GRPCConfig configures all gRPC connections
+type GRPCConfig struct {
+	Compression    bool `json:"compression"`
+	MaxSendMsgSize int  `json:"max_send_msg_size"`
+	MaxRecvMsgSize int  `json:"max_recv_msg_size"`
+}
+
+// LoggingConfig configures client logging
+type LoggingConfig struct {
+	// Quiet discards client logs. The client logs through the standard library's
+	// default logger, so this affects the whole process
+	Quiet bool `json:"quiet"`
+}
+
+// Duration is a time.Duration that reads from JSON/YAML as "10s" or a number of seconds
+type Duration time.Duration
+
+// UnmarshalJSON accepts a duration string such as "1m30s" or a number of seconds
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		parsed, err := parseDuration(s)
+		if err != nil {
+			return err
+		}
+		*d = Duration(parsed)
+		return nil
+	}
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err != nil {
+		return fmt.Errorf("invalid duration: %s", string(data))
+	}
+	*d = Duration(seconds * float64(time.Second))
+	return nil
+}
+
+// MarshalJSON encodes the duration as a string such as "10s"
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// NewFromEnv creates a client configured from environment variables:
+//
+//	TEE_CONFIG_ADDR                config server address (default localhost:50052)
+//	TEENET_TIMEOUT                 default timeout, e.g. "10s"
+//	TEENET_TASK_TIMEOUT            TEE sign call timeout
+//	TEENET_CONFIG_TIMEOUT          config fetch timeout
+//	TEENET_PUBLIC_KEY_CACHE_TTL    how long fetched public keys are reused
+//	TEENET_VOTING_CONFIG_CACHE_TTL how long voting configurations are reused
+//	TEENET_KEY_ROTATION_GRACE_PERIOD
+//	                               how long keys replaced by RotateKey keep verifying
+//	TEENET_CACHE_REDIS_ADDR        Redis server sharing cached keys and voting configurations
+//	TEENET_CACHE_REDIS_USERNAME, TEENET_CACHE_REDIS_PASSWORD
+//	                               Redis credentials
+//	TEENET_CACHE_REDIS_DB          Redis database number
+//	TEENET_CACHE_REDIS_PREFIX      prefix of Redis keys
+//	TEENET_CACHE_REDIS_TLS         "true" to connect to Redis over TLS
+//	TEENET_VOTING_DISABLED         "true" to not start the voting service
+//	TEENET_VOTING_ADDR             voting service listen address
+//	TEENET_VOTING_TRANSPORT        "proxy", "direct", "grpc" or "rest" vote requests
+//	TEENET_VOTING_GATEWAY_ADDR     listen address of the voting REST gateway
+//	TEENET_VOTING_COMPRESS_THRESHOLD
+//	                               gzip vote requests larger than this many bytes
+//	TEENET_VOTING_RETRY_ATTEMPTS   attempts per vote request, retrying network failures
+//	TEENET_MAX_MESSAGE_SIZE        largest message to sign in bytes
+//	TEENET_MAX_VOTE_REQUEST_SIZE   largest vote request body in bytes
+//	TEENET_DOMAIN_TAGS             comma-separated appID=tag domain separation tags
+//	TEENET_GRPC_COMPRESSION        "true" to enable gzip
+//	TEENET_GRPC_MAX_SEND_MSG_SIZE  max gRPC send size in bytes
+//	TEENET_GRPC_MAX_RECV_MSG_SIZE  max gRPC receive size in bytes
+//	TEENET_LOG_QUIET               "true" to discard client logs
+//	TEENET_REGION                  preferred node region
+//	TEENET_ZONE                    preferred node zone
+//	TEENET_SKIP_SIGNATURE_VERIFY   "true" to not verify TEE-returned signatures
+//	TEENET_TLS_STRICT_HOSTNAME     "true" to verify node certificates against their address
+//	TEENET_TLS_REQUIRED_SANS       comma-separated DNS names or IPs node certificates must carry
+//	TEENET_TLS_REQUIRE_TLS13       "true" to refuse TLS versions before 1.3
+//	TEENET_TLS_PINNED_SPKI         comma-separated SHA-256 SPKI hashes node certificates must match
+//	TEENET_TLS_INSECURE_DEV        "true" to skip node certificate verification (development only)
+//	TEENET_REVOCATION_CHECK        "true" to check node certificates against OCSP and CRLs
+//	TEENET_REVOCATION_HARD_FAIL    "true" to reject certificates whose revocation status is unknown
+//	TEENET_REVOCATION_CACHE_TTL    longest time a revocation result is reused
+//	TEENET_AUTH_TOKENS             comma-separated appID=token pairs for token authentication;
+//	                               an entry without "=" is the default token
+//	TEENET_CLIENT_CERT_FILE        client certificate file, replacing the config server's
+//	TEENET_CLIENT_KEY_FILE         client key file
+//	TEENET_PEER_CA_FILE            CA file trusted for TEE and App nodes
+//	TEENET_CLIENT_CERT, TEENET_CLIENT_KEY, TEENET_PEER_CA
+//	                               the same as inline PEM
+//	TEENET_CLIENT_KEY_KEYRING      OS keyring item holding the client key, see "teenet store-key"
+//	TEENET_NODE_ID                 node ID reported with sign requests
+//	TEENET_TEE_NODES               comma-separated TEE node addresses
+//	TEENET_APP_NODES               comma-separated App node addresses; with both set the config
+//	                               server isn't contacted
+//	TEENET_VOTING_AUTHORITY_KEYS   comma-separated hex Ed25519 voting authority keys
+//	TEENET_VOTING_AUTHORITY_KEY_FILE
+//	                               PEM file of a voting authority key
+//	TEENET_VOTING_AUTHORITY_MAX_AGE
+//	                               oldest voting configuration signature accepted
+//	TEENET_ACL_FILE                JSON ACL file, see acl.LoadFile
+//	TEENET_PRINCIPAL               identity of requests that don't name a principal
+func NewFromEnv() (*Client, error) {
+	config, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewFromConfig(config), nil
+}
+
+// NewFromConfigFile creates a client from a JSON (.json) or YAML (.yaml, .yml) file
+// Environment variables are not consulted
+func NewFromConfigFile(path string) (*Client, error) {
+	config, err := LoadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewFromConfig(config), nil
+}
+
+// ConfigFromEnv reads a Config from environment variables, see NewFromEnv
+func ConfigFromEnv() (*Config, error) {
+	config := &Config{ConfigServerAddr: os.Getenv("TEE_CONFIG_ADDR")}
+
+	durations := map[string]*Duration{
+		"TEENET_TIMEOUT":                   &config.Timeout,
+		"TEENET_TASK_TIMEOUT":              &config.TaskTimeout,
+		"TEENET_CONFIG_TIMEOUT":            &config.ConfigTimeout,
+		"TEENET_PUBLIC_KEY_CACHE_TTL":      &config.PublicKeyCacheTTL,
+		"TEENET_VOTING_CONFIG_CACHE_TTL":   &config.VotingConfigCacheTTL,
+		"TEENET_REVOCATION_CACHE_TTL":      &config.Revocation.CacheTTL,
+		"TEENET_VOTING_AUTHORITY_MAX_AGE":  &config.VotingAuthority.MaxAge,
+		"TEENET_KEY_ROTATION_GRACE_PERIOD": &config.KeyRotationGracePeriod,
+	}
+	for name, target := range durations {
+		if value := os.Getenv(name); value != "" {
+			d, err := parseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", name, err)
+			}
+			*target = Duration(d)
+		}
+	}
+
+	bools := map[string]*bool{
+		"TEENET_VOTING_DISABLED":       &config.Voting.Disabled,
+		"TEENET_GRPC_COMPRESSION":      &config.GRPC.Compression,
+		"TEENET_LOG_QUIET":             &config.Logging.Quiet,
+		"TEENET_SKIP_SIGNATURE_VERIFY": &config.Signing.SkipVerification,
+		"TEENET_TLS_STRICT_HOSTNAME":   &config.TLS.StrictHostname,
+		"TEENET_TLS_REQUIRE_TLS13":     &config.TLS.RequireTLS13,
+		"TEENET_TLS_INSECURE_DEV":      &config.TLS.InsecureDev,
+		"TEENET_REVOCATION_CHECK":      &config.Revocation.Enabled,
+		"TEENET_REVOCATION_HARD_FAIL":  &config.Revocation.HardFail,
+		"TEENET_CACHE_REDIS_TLS":       &config.Cache.RedisTLS,
+	}
+	for name, target := range bools {
+		if value := os.Getenv(name); value != "" {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", name, err)
+			}
+			*target = b
+		}
+	}
+
+	ints := map[string]*int{
+		"TEENET_GRPC_MAX_SEND_MSG_SIZE":    &config.GRPC.MaxSendMsgSize,
+		"TEENET_GRPC_MAX_RECV_MSG_SIZE":    &config.GRPC.MaxRecvMsgSize,
+		"TEENET_VOTING_COMPRESS_THRESHOLD": &config.Voting.CompressThreshold,
+		"TEENET_VOTING_RETRY_ATTEMPTS":     &config.Voting.RetryAttempts,
+		"TEENET_MAX_MESSAGE_SIZE":          &config.Signing.MaxMessageSize,
+		"TEENET_MAX_VOTE_REQUEST_SIZE":     &config.Signing.MaxVoteRequestSize,
+		"TEENET_CACHE_REDIS_DB":            &config.Cache.RedisDB,
+	}
+	for name, target := range ints {
+		if value := os.Getenv(name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", name, err)
+			}
+			*target = n
+		}
+	}
+
+	config.Voting.Addr = os.Getenv("TEENET_VOTING_ADDR")
+	config.Voting.GatewayAddr = os.Getenv("TEENET_VOTING_GATEWAY_ADDR")
+	if value := os.Getenv("TEENET_VOTING_TRANSPORT"); value != "" {
+		if err := config.Voting.Transport.UnmarshalText([]byte(value)); err != nil {
+			return nil, fmt.Errorf("invalid TEENET_VOTING_TRANSPORT: %w", err)
+		}
+	}
+	config.Locality.Region = os.Getenv("TEENET_REGION")
+	config.Locality.Zone = os.Getenv("TEENET_ZONE")
+	if value := os.Getenv("TEENET_AUTH_TOKENS"); value != "" {
+		tokens, err := auth.ParseTokens(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TEENET_AUTH_TOKENS: %w", err)
+		}
+		config.Auth = tokens
+	}
+	strs := map[string]*string{
+		"TEENET_CLIENT_CERT_FILE":     &config.Static.CertFile,
+		"TEENET_CLIENT_KEY_FILE":      &config.Static.KeyFile,
+		"TEENET_PEER_CA_FILE":         &config.Static.PeerCAFile,
+		"TEENET_CLIENT_CERT":          &config.Static.Cert,
+		"TEENET_CLIENT_KEY":           &config.Static.Key,
+		"TEENET_PEER_CA":              &config.Static.PeerCA,
+		"TEENET_CLIENT_KEY_KEYRING":   &config.Static.KeyKeyring,
+		"TEENET_CACHE_REDIS_ADDR":     &config.Cache.RedisAddr,
+		"TEENET_CACHE_REDIS_USERNAME": &config.Cache.RedisUsername,
+		"TEENET_CACHE_REDIS_PASSWORD": &config.Cache.RedisPassword,
+		"TEENET_CACHE_REDIS_PREFIX":   &config.Cache.RedisPrefix,
+	}
+	for name, target := range strs {
+		*target = os.Getenv(name)
+	}
+	if value := os.Getenv("TEENET_NODE_ID"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TEENET_NODE_ID: %w", err)
+		}
+		config.Static.NodeID = uint32(id)
+	}
+	lists := map[string]*[]string{
+		"TEENET_TLS_REQUIRED_SANS":     &config.TLS.RequiredSANs,
+		"TEENET_TLS_PINNED_SPKI":       &config.TLS.PinnedSPKI,
+		"TEENET_TEE_NODES":             &config.Static.TEENodes,
+		"TEENET_APP_NODES":             &config.Static.AppNodes,
+		"TEENET_VOTING_AUTHORITY_KEYS": &config.VotingAuthority.Keys,
+		"TEENET_UNTAGGED_SIGNING":      &config.Signing.UntaggedSigning,
+	}
+	for name, target := range lists {
+		for _, item := range strings.Split(os.Getenv(name), ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*target = append(*target, item)
+			}
+		}
+	}
+	if path := os.Getenv("TEENET_VOTING_AUTHORITY_KEY_FILE"); path != "" {
+		key, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TEENET_VOTING_AUTHORITY_KEY_FILE: %w", err)
+		}
+		config.VotingAuthority.Keys = append(config.VotingAuthority.Keys, string(key))
+	}
+	if _, err := config.VotingAuthority.authority(); err != nil {
+		return nil, err
+	}
+	if path := os.Getenv("TEENET_ACL_FILE"); path != "" {
+		list, err := acl.LoadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		config.ACL = list
+	}
+	if id := os.Getenv("TEENET_PRINCIPAL"); id != "" {
+		config.Principal = &voting.Principal{ID: id}
+	}
+	if value := os.Getenv("TEENET_DOMAIN_TAGS"); value != "" {
+		config.Signing.DomainTags = make(map[string]string)
+		for _, pair := range strings.Split(value, ",") {
+			appID, tag, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if !ok || appID == "" || tag == "" {
+				return nil, fmt.Errorf("invalid TEENET_DOMAIN_TAGS entry %q: want appID=tag", pair)
+			}
+			config.Signing.DomainTags[appID] = tag
+		}
+	}
+	return config, nil
+}
+
+// LoadConfigFile reads a Config from a JSON or YAML file, chosen by extension
+func LoadConfigFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		values, err := utils.ParseSimpleYAML(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+		if data, err = json.Marshal(values); err != nil {
+			return nil, fmt.Errorf("failed to convert config file %s: %w", path, err)
+		}
+	case ".json":
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q (want .json, .yaml or .yml)", filepath.Ext(path))
+	}
+
+	var config Config
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if config.ACL != nil {
+		if err := config.ACL.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid ACL in config file %s: %w", path, err)
+		}
+	}
+	if _, err := config.VotingAuthority.authority(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	for appID, tag := range config.Signing.DomainTags {
+		if err := checkDomainTag(appID, tag); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	}
+	return &config, nil
+}
+
+// NewFromConfig creates a client from a Config; zero values keep the defaults
+func NewFromConfig(config *Config) *Client {
+	addr := config.ConfigServerAddr
+	if addr == "" {
+		addr = DefaultConfigServerAddr
+	}
+	c := NewClient(addr)
+
+	if config.Timeout > 0 {
+		c.SetTimeout(time.Duration(config.Timeout))
+	}
+	if config.TaskTimeout > 0 {
+		c.taskTimeout = time.Duration(config.TaskTimeout)
+	}
+	if config.ConfigTimeout > 0 {
+		c.configClient.SetTimeout(time.Duration(config.ConfigTimeout))
+	}
+	if config.PublicKeyCacheTTL != 0 {
+		c.SetPublicKeyCacheTTL(time.Duration(config.PublicKeyCacheTTL))
+	}
+	if config.VotingConfigCacheTTL > 0 {
+		c.SetVotingConfigCacheTTL(time.Duration(config.VotingConfigCacheTTL))
+	}
+	if config.KeyRotationGracePeriod != 0 {
+		c.SetKeyRotationGracePeriod(time.Duration(config.KeyRotationGracePeriod))
+	}
+	if config.Cache.RedisAddr != "" {
+		c.SetCacheBackend(config.Cache.redis())
+	}
+
+	if config.Voting.Disabled {
+		c.DisableVotingService()
+	}
+	if config.Voting.Addr != "" {
+		c.SetVotingAddr(config.Voting.Addr)
+	}
+	if config.Voting.GatewayAddr != "" {
+		c.SetVotingGatewayAddr(config.Voting.GatewayAddr)
+	}
+	if config.Voting.Transport != "" {
+		c.SetVoteTransport(config.Voting.Transport)
+	}
+	if config.Voting.CompressThreshold > 0 {
+		c.SetVoteCompression(config.Voting.CompressThreshold)
+	}
+	if config.Voting.RetryAttempts > 1 {
+		c.SetVoteRetry(voting.RetryPolicy{Attempts: config.Voting.RetryAttempts})
+	}
+	c.SetMaxPayloadSize(config.Signing.MaxMessageSize, config.Signing.MaxVoteRequestSize)
+	for appID, tag := range config.Signing.DomainTags {
+		if err := c.SetDomainSeparation(appID, tag); err != nil {
+			log.Printf("⚠️  Ignoring domain separation tag of %s: %v", appID, err)
+		}
+	}
+	for _, appID := range config.Signing.UntaggedSigning {
+		c.AllowUntaggedSigning(appID, true)
+	}
+
+	c.SetCompression(config.GRPC.Compression)
+	if config.Locality.Region != "" {
+		c.SetPreferredLocality(config.Locality.Region, config.Locality.Zone)
+	}
+	c.SetMaxMessageSize(config.GRPC.MaxSendMsgSize, config.GRPC.MaxRecvMsgSize)
+	c.SetSignatureVerification(!config.Signing.SkipVerification)
+	c.SetTLSOptions(config.TLS)
+	c.SetAuthTokens(config.Auth)
+	if !config.Static.IsZero() {
+		static := config.Static
+		c.SetConfigOverride(&static)
+	}
+	if config.ACL != nil {
+		c.SetACL(config.ACL)
+	}
+	if authority, err := config.VotingAuthority.authority(); err != nil {
+		// Refuse every voting configuration rather than silently skip the check
+		log.Printf("❌ %v; voting rounds will be refused", err)
+		c.SetVotingAuthority(&usermgmt.VotingAuthority{})
+	} else if authority != nil {
+		c.SetVotingAuthority(authority)
+	}
+	c.SetPrincipal(config.Principal)
+	if config.Revocation.Enabled {
+		c.SetRevocationChecker(revocation.NewChecker(revocation.Options{
+			HardFail:    config.Revocation.HardFail,
+			DisableOCSP: config.Revocation.DisableOCSP,
+			DisableCRL:  config.Revocation.DisableCRL,
+			CacheTTL:    time.Duration(config.Revocation.CacheTTL),
+		}))
+	}
+
+	if config.Logging.Quiet {
+		log.SetOutput(io.Discard)
+	}
+	return c
+}
+
+// redis returns the configured Redis cache
+func (c CacheConfig) redis() *cache.Redis {
+	redisConfig := cache.RedisConfig{
+		Addr:     c.RedisAddr,
+		Username: c.RedisUsername,
+		Password: c.RedisPassword,
+		DB:       c.RedisDB,
+		Prefix:   c.RedisPrefix,
+	}
+	if c.RedisTLS {
+		redisConfig.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return cache.NewRedis(redisConfig)
+}
+
+// authority returns the configured voting authority, nil if no keys are pinned
+func (v VotingAuthorityConfig) authority() (*usermgmt.VotingAuthority, error) {
+	if len(v.Keys) == 0 {
+		return nil, nil
+	}
+	return usermgmt.NewVotingAuthority(v.Keys, time.Duration(v.MaxAge))
+}
+
+// parseDuration accepts Go duration strings or a bare number of seconds
+func parseDuration(s string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	return time.ParseDuration(s)
+}
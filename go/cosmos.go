@@ -0,0 +1,72 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/cosmos"
+)
+
+// CosmosAccount returns the account of an app's ECDSA SECP256K1 key: its bech32 address under
+// prefix (e.g. "cosmos", "osmo") and compressed public key, for building the AuthInfo signer info
+func (c *Client) CosmosAccount(appID, prefix string) (*cosmos.Account, error) {
+	keyInfo, err := c.GetPublicKeyByAppID(appID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireSecp256k1ECDSA(appID)(keyInfo); err != nil {
+		return nil, err
+	}
+	return cosmos.NewAccount(prefix, keyInfo.Key)
+}
+
+// SignCosmosDirect signs a Cosmos SDK sign doc in SIGN_MODE_DIRECT with the app's ECDSA SECP256K1
+// key. The encoded sign doc is submitted for signing, the TEE applying SHA-256 as Cosmos chains
+// do, and the signature is checked and returned as 64-byte low-S R || S along with the app's
+// public key and its address under prefix. Policy plugins and the audit log see the encoded sign doc
+func (c *Client) SignCosmosDirect(doc *cosmos.SignDoc, appID, prefix string) (*cosmos.DirectSignResponse, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("sign doc is required")
+	}
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := cosmos.EncodeBech32(prefix, nil); err != nil {
+		return nil, err
+	}
+	encoded := doc.Marshal()
+	signature, keyInfo, err := c.signEncoded(AuditOpCosmos, encoded, encoded, appID, nil, requireSecp256k1ECDSA(appID))
+	if err != nil {
+		return nil, err
+	}
+	publicKey := keyInfo.Key
+
+	raw, err := cosmos.NormalizeSignature(doc, signature, publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert signature to cosmos format: %w", err)
+	}
+	account, err := cosmos.NewAccount(prefix, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &cosmos.DirectSignResponse{
+		Signed: doc,
+		Signature: cosmos.StdSignature{
+			PubKey:    cosmos.PubKey{Type: cosmos.AminoPubKeyType, Value: account.PubKey},
+			Signature: raw,
+		},
+		Account: *account,
+	}, nil
+}
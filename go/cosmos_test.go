@@ -0,0 +1,42 @@
+package client
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/cosmos"
+)
+
+func TestSignCosmosDirect(t *testing.T) {
+	c, deployment := newTestClient(t)
+	publicKey := addApp(t, deployment, "cosmos-app", constants.ProtocolECDSA, constants.CurveSECP256K1)
+
+	doc := &cosmos.SignDoc{
+		BodyBytes:     []byte("body"),
+		AuthInfoBytes: []byte("auth info"),
+		ChainID:       "cosmoshub-4",
+		AccountNumber: 42,
+	}
+	resp, err := c.SignCosmosDirect(doc, "cosmos-app", "cosmos")
+	if err != nil {
+		t.Fatalf("SignCosmosDirect failed: %v", err)
+	}
+	valid, err := cosmos.VerifySignDoc(doc, publicKey, resp.Signature.Signature)
+	if err != nil || !valid {
+		t.Fatalf("Signature does not verify over the sign doc: %v", err)
+	}
+	account, err := cosmos.NewAccount("cosmos", publicKey)
+	if err != nil {
+		t.Fatalf("NewAccount failed: %v", err)
+	}
+	if resp.Account.Address != account.Address {
+		t.Errorf("Account address %s, want %s", resp.Account.Address, account.Address)
+	}
+
+	// The TEE receives the encoded sign doc and applies SHA-256 itself
+	requests := deployment.SignRequests()
+	if len(requests) != 1 || requests[0].Prehashed || !bytes.Equal(requests[0].Msg, doc.Marshal()) {
+		t.Errorf("Expected the encoded sign doc to be signed, got %d requests", len(requests))
+	}
+}
@@ -0,0 +1,218 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/deadletter"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+)
+
+// DeadLetterConfig configures capture of undeliverable requests, see EnableDeadLetters
+type DeadLetterConfig struct {
+	Store deadletter.Store // Where letters are kept, e.g. deadletter.NewFileStore for durability
+}
+
+// DeadLetterReplay is the outcome of replaying one dead letter
+type DeadLetterReplay struct {
+	Letter *deadletter.Letter
+	Vote   *voting.VoteResponse // The target's answer to a replayed vote request
+	Err    error                // Why delivery failed again; nil once delivered and removed
+}
+
+// EnableDeadLetters keeps vote requests and commit/abort notifications that could not be
+// delivered to their target, once retries are spent, in a dead letter store. Operators list
+// them with DeadLetters and send them again with ReplayDeadLetters after an outage. Vote
+// requests that timed out or couldn't reach their target are kept; rejections and invalid
+// answers are not. Must be called before Init
+func (c *Client) EnableDeadLetters(config DeadLetterConfig) error {
+	if config.Store == nil {
+		return fmt.Errorf("dead letters require a store")
+	}
+	c.deadLetters = config.Store
+	return nil
+}
+
+// DeadLetters lists the undelivered requests, oldest first
+func (c *Client) DeadLetters() ([]*deadletter.Letter, error) {
+	if c.deadLetters == nil {
+		return nil, fmt.Errorf("dead letters not enabled")
+	}
+	return c.deadLetters.List()
+}
+
+// DiscardDeadLetter removes a letter without replaying it
+func (c *Client) DiscardDeadLetter(id string) error {
+	if c.deadLetters == nil {
+		return fmt.Errorf("dead letters not enabled")
+	}
+	return c.deadLetters.Delete(id)
+}
+
+// ReplayDeadLetters sends the letters with the given IDs, or all letters, to their targets
+// again, oldest first. Delivered letters are removed; the others stay with their attempt count
+// and error updated
+//
+// A replayed vote request reports the target's answer but can't reopen its voting round, which
+// has ended; sign the message again for a signature
+func (c *Client) ReplayDeadLetters(ctx context.Context, ids ...string) ([]DeadLetterReplay, error) {
+	if c.deadLetters == nil {
+		return nil, fmt.Errorf("dead letters not enabled")
+	}
+	letters, err := c.deadLetters.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+	if len(ids) > 0 {
+		byID := make(map[string]*deadletter.Letter, len(letters))
+		for _, letter := range letters {
+			byID[letter.ID] = letter
+		}
+		letters = letters[:0]
+		for _, id := range ids {
+			letter, ok := byID[id]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", deadletter.ErrNotFound, id)
+			}
+			letters = append(letters, letter)
+		}
+	}
+
+	replays := make([]DeadLetterReplay, 0, len(letters))
+	for _, letter := range letters {
+		vote, err := c.redeliver(ctx, letter)
+		if err == nil {
+			log.Printf("📬 Dead letter %s delivered to %s", letter.ID, letter.TargetAppID)
+			if err := c.deadLetters.Delete(letter.ID); err != nil {
+				log.Printf("⚠️  Failed to remove dead letter %s: %v", letter.ID, err)
+			}
+		} else {
+			log.Printf("📮 Dead letter %s still undeliverable to %s: %v", letter.ID, letter.TargetAppID, err)
+			letter.Attempts++
+			letter.Error = err.Error()
+			letter.Code = string(voting.ErrorCode(err))
+			letter.LastAttemptAt = time.Now()
+			if err := c.deadLetters.Put(letter); err != nil {
+				log.Printf("⚠️  Failed to update dead letter %s: %v", letter.ID, err)
+			}
+		}
+		replays = append(replays, DeadLetterReplay{Letter: letter, Vote: vote, Err: err})
+	}
+	return replays, nil
+}
+
+// redeliver sends a dead letter to its target
+func (c *Client) redeliver(ctx context.Context, letter *deadletter.Letter) (*voting.VoteResponse, error) {
+	target, err := c.delegateTarget(ctx, letter.TargetAppID)
+	if err != nil {
+		return nil, err
+	}
+	switch letter.Kind {
+	case deadletter.KindVote:
+		request, err := c.votePayloadFor(&voting.VoteRequest{
+			SignerAppID:       letter.SignerAppID,
+			Message:           letter.Message,
+			Data:              letter.Payload,
+			Headers:           letter.Headers,
+			Principal:         voting.PrincipalFromHeaders(letter.Headers),
+			RequiredVotes:     letter.RequiredVotes,
+			TotalParticipants: letter.TotalParticipants,
+		}, VotePayloadTarget{AppID: letter.TargetAppID, SignerAppID: letter.SignerAppID})
+		if err != nil {
+			return nil, err
+		}
+		return c.voteSender.Vote(ctx, target, request)
+	case deadletter.KindCommit:
+		var commit voting.Commit
+		if err := json.Unmarshal(letter.Payload, &commit); err != nil {
+			return nil, fmt.Errorf("invalid commit in dead letter %s: %w", letter.ID, err)
+		}
+		return nil, c.voteSender.Commit(ctx, target, &commit, letter.Headers)
+	case deadletter.KindAbort:
+		var abort voting.Abort
+		if err := json.Unmarshal(letter.Payload, &abort); err != nil {
+			return nil, fmt.Errorf("invalid abort in dead letter %s: %w", letter.ID, err)
+		}
+		return nil, c.voteSender.Abort(ctx, target, &abort, letter.Headers)
+	default:
+		return nil, fmt.Errorf("dead letter %s has unknown kind %q", letter.ID, letter.Kind)
+	}
+}
+
+// deadLetterVote keeps a vote request that couldn't be delivered; chain is the delegation chain
+// it followed, ending at the app it failed to reach
+func (c *Client) deadLetterVote(roundID string, request *voting.VoteRequest, chain []string, err error) {
+	if c.deadLetters == nil || len(chain) == 0 {
+		return
+	}
+	if code := voting.ErrorCode(err); code != voting.CodeUnreachable && code != voting.CodeTimeout {
+		return
+	}
+	c.putDeadLetter(&deadletter.Letter{
+		Kind:              deadletter.KindVote,
+		SignerAppID:       request.SignerAppID,
+		TargetAppID:       chain[len(chain)-1],
+		RoundID:           roundID,
+		Payload:           request.Data,
+		Headers:           request.Headers,
+		Message:           request.Message,
+		RequiredVotes:     request.RequiredVotes,
+		TotalParticipants: request.TotalParticipants,
+	}, err)
+}
+
+// deadLetterNotification keeps a commit or abort notification that couldn't be delivered
+func (c *Client) deadLetterNotification(kind deadletter.Kind, roundID, signerAppID, targetAppID string, notification any, headers map[string]string, err error) {
+	if c.deadLetters == nil {
+		return
+	}
+	payload, marshalErr := json.Marshal(notification)
+	if marshalErr != nil {
+		log.Printf("⚠️  Failed to encode dead letter for %s: %v", targetAppID, marshalErr)
+		return
+	}
+	c.putDeadLetter(&deadletter.Letter{
+		Kind:        kind,
+		SignerAppID: signerAppID,
+		TargetAppID: targetAppID,
+		RoundID:     roundID,
+		Payload:     payload,
+		Headers:     headers,
+	}, err)
+}
+
+// putDeadLetter stores a new letter for a failed delivery
+func (c *Client) putDeadLetter(letter *deadletter.Letter, err error) {
+	id, idErr := deadletter.NewID()
+	if idErr != nil {
+		log.Printf("⚠️  Failed to create dead letter ID: %v", idErr)
+		return
+	}
+	now := time.Now()
+	letter.ID = id
+	letter.Error = err.Error()
+	letter.Code = string(voting.ErrorCode(err))
+	letter.Attempts = 1
+	letter.CreatedAt, letter.LastAttemptAt = now, now
+	if err := c.deadLetters.Put(letter); err != nil {
+		log.Printf("⚠️  Failed to store dead letter for %s: %v", letter.TargetAppID, err)
+		return
+	}
+	log.Printf("📮 Undelivered %s to %s kept as dead letter %s", letter.Kind, letter.TargetAppID, id)
+}
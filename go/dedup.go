@@ -0,0 +1,117 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/cache"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+)
+
+// Signature dedup defaults
+const (
+	DefaultDedupTTL        = 5 * time.Minute
+	DefaultDedupMaxEntries = 10000
+)
+
+// signatureCache is the cache label used for signature dedup lookups in metrics
+const signatureCache = "signature"
+
+// DedupConfig configures the signature deduplication cache, see EnableSignatureDedup
+type DedupConfig struct {
+	TTL        time.Duration // How long a signature is reused
+	MaxEntries int           // Size bound of the default in-memory cache
+	Cache      cache.Cache   // Optional shared backend; defaults to an in-memory LRU
+
+	// AllProtocols also caches signatures from randomized schemes (ECDSA, Schnorr). A cached
+	// signature is still valid, but callers expecting a fresh signature per request will not get one
+	AllProtocols bool
+}
+
+// signatureDedup caches signatures by (app ID, public key, signing variant, message hash)
+type signatureDedup struct {
+	config DedupConfig
+	cache  cache.Cache
+}
+
+// EnableSignatureDedup caches signatures so identical repeated direct sign requests (e.g. upstream
+// retries) return the earlier signature instead of signing again. Cached signatures are only
+// served once the request passes the ACL, signing policy and policy plugins; voting requests
+// always run a round. By default only deterministic signatures (ED25519) are cached. Set
+// SignRequest.BypassDedup to force a fresh signature. Must be called before Init
+func (c *Client) EnableSignatureDedup(config DedupConfig) {
+	if config.TTL <= 0 {
+		config.TTL = DefaultDedupTTL
+	}
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = DefaultDedupMaxEntries
+	}
+	backend := config.Cache
+	if backend == nil {
+		backend = cache.NewMemory(config.MaxEntries)
+	}
+	c.dedup = &signatureDedup{config: config, cache: backend}
+}
+
+// cachedSignature returns a previously stored signature for the request, if any
+// Signatures are looked up under appID's current key, so none made with a rotated-out key is returned
+func (c *Client) cachedSignature(ctx context.Context, appID string, message []byte, edMode uint32, edContext []byte) ([]byte, bool) {
+	if c.dedup == nil {
+		return nil, false
+	}
+	keyInfo, err := c.getPublicKey(ctx, appID)
+	if err != nil {
+		return nil, false
+	}
+	signature, ok := c.dedup.cache.Get(dedupKey(appID, keyInfo.Key, message, edMode, edContext))
+	c.metrics.ObserveCache(signatureCache, ok)
+	return signature, ok
+}
+
+// storeSignature remembers a signature produced with keyInfo, if its scheme qualifies
+func (c *Client) storeSignature(appID string, message []byte, edMode uint32, edContext []byte, keyInfo *PublicKeyInfo, signature []byte) {
+	if c.dedup == nil {
+		return
+	}
+	if keyInfo.Curve != constants.CurveED25519 && !c.dedup.config.AllProtocols {
+		return
+	}
+	c.dedup.cache.Set(dedupKey(appID, keyInfo.Key, message, edMode, edContext), signature, c.dedup.config.TTL)
+}
+
+// dedupKey identifies a sign request: the same app, key, Ed25519 variant and message give the same key
+func dedupKey(appID string, publicKey, message []byte, edMode uint32, edContext []byte) string {
+	keyHash := sha256.Sum256(publicKey)
+	return "sig:" + hex.EncodeToString(keyHash[:8]) + ":" + requestHash(appID, message, edMode, edContext)
+}
+
+// requestHash is the hex content hash of a sign request
+func requestHash(appID string, message []byte, edMode uint32, edContext []byte) string {
+	h := sha256.New()
+	var buf [8]byte
+	for _, field := range [][]byte{[]byte(appID), edContext, message} {
+		// Length-prefix each field so different splits can't collide
+		binary.BigEndian.PutUint64(buf[:], uint64(len(field)))
+		h.Write(buf[:])
+		h.Write(field)
+	}
+	binary.BigEndian.PutUint32(buf[:4], edMode)
+	h.Write(buf[:4])
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/policy"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/votingtest"
+)
+
+// switchPlugin refuses every signature while refusing is set, and counts the ones it authorized
+type switchPlugin struct {
+	mu       sync.Mutex
+	refusing bool
+	signed   int
+}
+
+func (p *switchPlugin) Authorize(ctx context.Context, appID string, message []byte) (func(bool), error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.refusing {
+		return nil, policy.ErrViolation
+	}
+	return func(signed bool) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if signed {
+			p.signed++
+		}
+	}, nil
+}
+
+func (p *switchPlugin) setRefusing(refusing bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.refusing = refusing
+}
+
+func TestSignatureDedupChecksPolicyFirst(t *testing.T) {
+	plugin := &switchPlugin{}
+	c, deployment := newTestClient(t, func(c *Client) {
+		c.EnableSignatureDedup(DedupConfig{})
+		c.AddPolicyPlugin(plugin)
+	})
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	req := &SignRequest{AppID: "ed-app", Message: []byte("pay 10")}
+
+	first, err := c.Sign(req)
+	if err != nil || first.Cached {
+		t.Fatalf("First Sign: cached=%t err=%v, want a fresh signature", first.Cached, err)
+	}
+	second, err := c.Sign(req)
+	if err != nil || !second.Cached {
+		t.Fatalf("Second Sign: cached=%t err=%v, want the cached signature", second.Cached, err)
+	}
+	if n := len(deployment.SignRequests()); n != 1 {
+		t.Errorf("Expected 1 TEE sign request, got %d", n)
+	}
+	if plugin.signed != 1 {
+		t.Errorf("Expected the plugin to count 1 signature, got %d", plugin.signed)
+	}
+
+	// A refusal applies to cached signatures as well
+	plugin.setRefusing(true)
+	if _, err := c.Sign(req); !errors.Is(err, policy.ErrViolation) {
+		t.Errorf("Expected the plugin to refuse the cached request, got %v", err)
+	}
+}
+
+func TestSignatureDedupSkipsVoting(t *testing.T) {
+	network := votingtest.NewNetwork("ed-app", "approver")
+	defer network.Close()
+	c, deployment := newTestClient(t, func(c *Client) {
+		c.EnableSignatureDedup(DedupConfig{})
+	})
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := deployment.SetVoting("ed-app", network, 2, nil); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+	req := &SignRequest{AppID: "ed-app", Message: []byte("pay 10"), EnableVoting: true, LocalApproval: true, VoteRequestData: []byte(`{"amount":10}`)}
+
+	for i := 0; i < 2; i++ {
+		result, err := c.Sign(req)
+		if err != nil || !result.Success || result.Cached {
+			t.Fatalf("Sign %d: success=%t cached=%t err=%v, want a fresh voting round", i, result.Success, result.Cached, err)
+		}
+	}
+	if n := len(network.Peer("approver").Requests()); n != 2 {
+		t.Errorf("Expected the approver to be asked twice, got %d requests", n)
+	}
+
+	// Signatures from voting rounds aren't served to direct requests either
+	direct, err := c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("pay 10")})
+	if err != nil || direct.Cached {
+		t.Errorf("Direct Sign: cached=%t err=%v, want a fresh signature", direct.Cached, err)
+	}
+}
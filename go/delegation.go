@@ -0,0 +1,164 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+)
+
+// SignDelegation signs a delegation that lets delegateAppID vote in delegatorAppID's place on
+// votingAppID's voting rounds until expiresAt. delegatorAppID's voting handler returns it as
+// the "delegation" field of its vote response, e.g. while its approver is away
+//
+// The delegate must not itself be a voting target of votingAppID or votingAppID itself, and may
+// hold the vote of one target per round, so no app holds two votes
+func (c *Client) SignDelegation(votingAppID, delegatorAppID, delegateAppID string, expiresAt time.Time) (*voting.Delegation, error) {
+	result, err := c.Sign(&SignRequest{
+		AppID:   delegatorAppID,
+		Message: voting.DelegationMessage(votingAppID, delegatorAppID, delegateAppID, expiresAt),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign delegation: %w", err)
+	}
+	return &voting.Delegation{
+		DelegateAppID: delegateAppID,
+		ExpiresAt:     expiresAt.Unix(),
+		Signature:     hex.EncodeToString(result.Signature),
+	}, nil
+}
+
+// roundDelegates records the apps that received a delegated vote in one voting round, so no app
+// answers for more than one target. Votes are collected concurrently
+type roundDelegates struct {
+	mu     sync.Mutex
+	holder map[string]string // Delegate app ID -> target whose vote it holds
+}
+
+// newRoundDelegates returns an empty record for a voting round
+func newRoundDelegates() *roundDelegates {
+	return &roundDelegates{holder: make(map[string]string)}
+}
+
+// claim records that delegate holds the vote of target, failing if it already holds another's
+func (d *roundDelegates) claim(delegate, target string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if other, held := d.holder[delegate]; held {
+		return fmt.Errorf("%w: app %s already holds the delegated vote of %s", voting.ErrInvalidDelegation, delegate, other)
+	}
+	d.holder[delegate] = target
+	return nil
+}
+
+// collectVote asks a voting target for its vote and follows signed delegations until an app
+// answers with a vote. Every delegate must be outside the round's targets, distinct from the
+// signing app, and claimed in delegates so it holds one vote only. It returns the vote along
+// with the chain of apps it passed through
+func (c *Client) collectVote(ctx context.Context, votingAppID string, target *usermgmt.DeploymentTarget, request *voting.VoteRequest, targets map[string]*usermgmt.DeploymentTarget, delegates *roundDelegates) (*voting.VoteResponse, []string, error) {
+	chain := []string{target.AppID}
+	for {
+		payloadTarget := VotePayloadTarget{AppID: target.AppID, SignerAppID: votingAppID}
+		if len(chain) > 1 {
+			payloadTarget.DelegatedBy = chain[len(chain)-2]
+		}
+		tailored, err := c.votePayloadFor(request, payloadTarget)
+		if err != nil {
+			return nil, chain, err
+		}
+		response, err := c.voteSender.Vote(ctx, target, tailored)
+		if err != nil {
+			return nil, chain, err
+		}
+		if response.Delegation == nil {
+			return response, chain, nil
+		}
+
+		delegator := chain[len(chain)-1]
+		if len(chain) > constants.MaxVoteDelegationDepth {
+			return nil, chain, fmt.Errorf("%w: vote of %s delegated more than %d times", voting.ErrInvalidDelegation, chain[0], constants.MaxVoteDelegationDepth)
+		}
+		if err := c.verifyDelegation(ctx, votingAppID, delegator, response.Delegation); err != nil {
+			return nil, chain, fmt.Errorf("%w: %w", voting.ErrInvalidDelegation, err)
+		}
+
+		delegate := response.Delegation.DelegateAppID
+		if slices.Contains(chain, delegate) {
+			return nil, chain, fmt.Errorf("%w: cycle %v -> %s", voting.ErrInvalidDelegation, chain, delegate)
+		}
+		if _, isTarget := targets[delegate]; isTarget {
+			return nil, chain, fmt.Errorf("%w: app %s delegated its vote to %s, which already votes", voting.ErrInvalidDelegation, delegator, delegate)
+		}
+		if delegate == votingAppID {
+			return nil, chain, fmt.Errorf("%w: app %s delegated its vote to the signing app %s", voting.ErrInvalidDelegation, delegator, delegate)
+		}
+		if err := delegates.claim(delegate, chain[0]); err != nil {
+			return nil, chain, err
+		}
+		chain = append(chain, delegate)
+		log.Printf("🔀 Vote of %s delegated to %s", chain[0], delegate)
+
+		if target, err = c.delegateTarget(ctx, delegate); err != nil {
+			return nil, chain, err
+		}
+	}
+}
+
+// verifyDelegation checks a delegation's fields and its signature by the delegating app's key
+func (c *Client) verifyDelegation(ctx context.Context, votingAppID, delegatorAppID string, delegation *voting.Delegation) error {
+	if err := delegation.Validate(delegatorAppID, time.Now()); err != nil {
+		return err
+	}
+	signature, err := hex.DecodeString(delegation.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid delegation signature from %s: %w", delegatorAppID, err)
+	}
+
+	keyInfo, err := c.getPublicKey(ctx, delegatorAppID)
+	if err != nil {
+		return fmt.Errorf("failed to get public key of delegating app %s: %w", delegatorAppID, err)
+	}
+	message := c.domainMessage(delegatorAppID, voting.DelegationMessage(votingAppID, delegatorAppID, delegation.DelegateAppID, time.Unix(delegation.ExpiresAt, 0)))
+	valid, err := verification.VerifySignature(message, keyInfo.Key, signature, keyInfo.Protocol, keyInfo.Curve)
+	if err != nil {
+		return fmt.Errorf("failed to verify delegation from %s: %w", delegatorAppID, err)
+	}
+	if !valid {
+		return fmt.Errorf("delegation from %s to %s has an invalid signature", delegatorAppID, delegation.DelegateAppID)
+	}
+	return nil
+}
+
+// delegateTarget looks up the deployment of an app that received a delegated vote
+func (c *Client) delegateTarget(ctx context.Context, appID string) (*usermgmt.DeploymentTarget, error) {
+	config, err := c.votingSignConfig(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up delegate %s: %w", appID, err)
+	}
+	target, ok := config.Targets[appID]
+	if !ok {
+		return nil, fmt.Errorf("delegate %s is not deployed", appID)
+	}
+	return target, nil
+}
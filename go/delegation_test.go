@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/votingtest"
+)
+
+// delegateTo makes a peer answer every vote request with delegation
+func delegateTo(delegation *voting.Delegation) votingtest.Behavior {
+	return func(ctx context.Context, request *votingtest.Request) (*voting.VoteResponse, error) {
+		return &voting.VoteResponse{Delegation: delegation}, nil
+	}
+}
+
+// signDelegation signs the delegation of delegator's vote in rounds of the signer app
+func signDelegation(t *testing.T, c *Client, signer, delegator, delegate string) *voting.Delegation {
+	t.Helper()
+	delegation, err := c.SignDelegation(signer, delegator, delegate, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("SignDelegation %s -> %s failed: %v", delegator, delegate, err)
+	}
+	return delegation
+}
+
+func TestDelegateVotesOncePerRound(t *testing.T) {
+	network := votingtest.NewNetwork("signer", "alice", "carol")
+	defer network.Close()
+	delegateNetwork := votingtest.NewNetwork("bob")
+	defer delegateNetwork.Close()
+
+	c, deployment := newTestClient(t)
+	for _, appID := range []string{"signer", "alice", "carol", "bob"} {
+		addApp(t, deployment, appID, constants.ProtocolSchnorr, constants.CurveED25519)
+	}
+	if err := deployment.SetVoting("signer", network, 3, nil); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+	if err := deployment.SetVoting("bob", delegateNetwork, 1, nil); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+	network.SetBehavior("alice", delegateTo(signDelegation(t, c, "signer", "alice", "bob")))
+	network.SetBehavior("carol", delegateTo(signDelegation(t, c, "signer", "carol", "bob")))
+
+	result, _ := c.Sign(&SignRequest{AppID: "signer", Message: []byte("pay 10"), EnableVoting: true, LocalApproval: true, VoteRequestData: []byte(`{"amount":10}`)})
+	if result == nil || result.VotingInfo == nil {
+		t.Fatalf("Expected a voting result, got %+v", result)
+	}
+	if result.Success {
+		t.Fatalf("Expected two targets delegating to one app not to reach 3 votes, got %d", result.VotingInfo.SuccessfulVotes)
+	}
+	if n := len(delegateNetwork.Peer("bob").Requests()); n != 1 {
+		t.Errorf("Expected the delegate to vote once, got %d requests", n)
+	}
+	var refused int
+	for _, detail := range result.VotingInfo.VoteDetails {
+		if detail.Code == voting.CodeInvalidDelegation {
+			refused++
+		}
+	}
+	if refused != 1 {
+		t.Errorf("Expected 1 refused delegation, got %d: %+v", refused, result.VotingInfo.VoteDetails)
+	}
+}
+
+func TestDelegationToSignerRefused(t *testing.T) {
+	network := votingtest.NewNetwork("alice", "carol")
+	defer network.Close()
+
+	c, deployment := newTestClient(t)
+	for _, appID := range []string{"signer", "alice", "carol"} {
+		addApp(t, deployment, appID, constants.ProtocolSchnorr, constants.CurveED25519)
+	}
+	if err := deployment.SetVoting("signer", network, 2, nil); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+	network.SetBehavior("alice", delegateTo(signDelegation(t, c, "signer", "alice", "signer")))
+
+	// The signing app's local approval must not stand in for a target's vote
+	result, _ := c.Sign(&SignRequest{AppID: "signer", Message: []byte("pay 10"), EnableVoting: true, LocalApproval: true, VoteRequestData: []byte(`{"amount":10}`)})
+	if result == nil || result.VotingInfo == nil {
+		t.Fatalf("Expected a voting result, got %+v", result)
+	}
+	if result.Success {
+		t.Fatal("Expected a delegation to the signing app to be refused")
+	}
+	for _, detail := range result.VotingInfo.VoteDetails {
+		if detail.ClientID == "alice" && detail.Code != voting.CodeInvalidDelegation {
+			t.Errorf("Expected alice's vote to be %s, got %+v", voting.CodeInvalidDelegation, detail)
+		}
+	}
+}
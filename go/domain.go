@@ -0,0 +1,105 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+// DefaultDomainTag is the conventional domain separation tag, see SetDomainSeparation
+const DefaultDomainTag = "TEENET-SIGN-V1"
+
+// SetDomainSeparation makes Sign and Verify for appID work on DomainMessage(tag, appID, message)
+// rather than the message itself, so a signature made for one app is not valid for another app
+// or protocol sharing the key. Voters, signing policies and the audit log still see the original
+// message. Digest, transaction and MuSig2 signing sign bytes fixed by their protocols, which
+// can't carry the tag; they are refused for the app with ErrUntaggedSigning unless
+// AllowUntaggedSigning opts it in. An empty tag removes it. Safe for concurrent use
+func (c *Client) SetDomainSeparation(appID, tag string) error {
+	if err := checkDomainTag(appID, tag); err != nil {
+		return err
+	}
+	c.domainTagsMu.Lock()
+	defer c.domainTagsMu.Unlock()
+	if tag == "" {
+		delete(c.domainTags, appID)
+		return nil
+	}
+	if c.domainTags == nil {
+		c.domainTags = make(map[string]string)
+	}
+	c.domainTags[appID] = tag
+	return nil
+}
+
+// AllowUntaggedSigning lets an app with a domain separation tag still use the signing paths that
+// can't carry it: SignBitcoin, SignEthereum, SignEthereumDigest, the Cosmos, Solana, Stellar and
+// C2PA helpers, and SignMuSig2. Those sign caller-chosen bytes as they are, so a signature made
+// through them may be valid for another app or protocol sharing the key. Safe for concurrent use
+func (c *Client) AllowUntaggedSigning(appID string, allow bool) {
+	c.domainTagsMu.Lock()
+	defer c.domainTagsMu.Unlock()
+	if !allow {
+		delete(c.untaggedApps, appID)
+		return
+	}
+	if c.untaggedApps == nil {
+		c.untaggedApps = make(map[string]bool)
+	}
+	c.untaggedApps[appID] = true
+}
+
+// DomainMessage returns the bytes signed for message under domain separation:
+// tag || 0x00 || appID || 0x00 || message. Verifiers outside the client use it to check signatures
+func DomainMessage(tag, appID string, message []byte) []byte {
+	return verification.DomainMessage(tag, appID, message)
+}
+
+// checkDomainTag rejects NUL bytes, which would make DomainMessage ambiguous
+func checkDomainTag(appID, tag string) error {
+	if strings.ContainsRune(tag, 0) || strings.ContainsRune(appID, 0) {
+		return fmt.Errorf("domain separation tag of %q must not contain NUL bytes", appID)
+	}
+	return nil
+}
+
+// domainTag returns appID's domain separation tag, empty if it has none
+func (c *Client) domainTag(appID string) string {
+	c.domainTagsMu.Lock()
+	defer c.domainTagsMu.Unlock()
+	return c.domainTags[appID]
+}
+
+// checkUntaggedSigning refuses signing without the domain tag for apps that have one, unless
+// they were opted in with AllowUntaggedSigning
+func (c *Client) checkUntaggedSigning(appID string) error {
+	c.domainTagsMu.Lock()
+	defer c.domainTagsMu.Unlock()
+	if c.domainTags[appID] == "" || c.untaggedApps[appID] {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrUntaggedSigning, appID)
+}
+
+// domainMessage applies appID's domain separation, if any, to message
+func (c *Client) domainMessage(appID string, message []byte) []byte {
+	tag := c.domainTag(appID)
+	if tag == "" {
+		return message
+	}
+	return DomainMessage(tag, appID, message)
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+func TestDomainSeparation(t *testing.T) {
+	c, deployment := newTestClient(t)
+	publicKey := addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := c.SetDomainSeparation("ed-app", DefaultDomainTag); err != nil {
+		t.Fatalf("SetDomainSeparation failed: %v", err)
+	}
+	message := []byte("pay 10")
+
+	result, err := c.Sign(&SignRequest{AppID: "ed-app", Message: message})
+	if err != nil || !result.Success {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	tagged := DomainMessage(DefaultDomainTag, "ed-app", message)
+	if requests := deployment.SignRequests(); len(requests) != 1 || !bytes.Equal(requests[0].Msg, tagged) {
+		t.Fatal("Expected the TEE to sign the domain-separated message")
+	}
+
+	if valid, err := c.Verify(message, result.Signature, "ed-app"); err != nil || !valid {
+		t.Errorf("Verify of the original message failed: valid=%t err=%v", valid, err)
+	}
+	// The signature is not one over the bare message
+	if valid, _ := verification.VerifySignature(message, publicKey, result.Signature, constants.ProtocolSchnorr, constants.CurveED25519); valid {
+		t.Error("Expected the signature not to verify for the untagged message")
+	}
+	if valid, _ := verification.VerifySignature(tagged, publicKey, result.Signature, constants.ProtocolSchnorr, constants.CurveED25519); !valid {
+		t.Error("Expected the signature to verify for DomainMessage")
+	}
+
+	// Without the tag, Verify no longer accepts it
+	if err := c.SetDomainSeparation("ed-app", ""); err != nil {
+		t.Fatalf("SetDomainSeparation failed: %v", err)
+	}
+	if valid, _ := c.Verify(message, result.Signature, "ed-app"); valid {
+		t.Error("Expected Verify without the domain tag to reject the signature")
+	}
+}
+
+func TestDomainSeparationRejectsNUL(t *testing.T) {
+	c := NewClient("")
+	if err := c.SetDomainSeparation("ed-app", "TAG\x00"); err == nil {
+		t.Error("Expected a tag with a NUL byte to be refused")
+	}
+}
+
+func TestDomainSeparationRefusesUntaggedDigests(t *testing.T) {
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "eth-app", constants.ProtocolECDSA, constants.CurveSECP256K1)
+	if err := c.SetDomainSeparation("eth-app", DefaultDomainTag); err != nil {
+		t.Fatalf("SetDomainSeparation failed: %v", err)
+	}
+	digest := bytes.Repeat([]byte{0x42}, 32)
+
+	if _, err := c.SignEthereumDigest(digest, "eth-app"); !errors.Is(err, ErrUntaggedSigning) {
+		t.Fatalf("Expected ErrUntaggedSigning for a raw digest, got %v", err)
+	}
+	if requests := deployment.SignRequests(); len(requests) != 0 {
+		t.Fatalf("Expected no TEE sign request, got %d", len(requests))
+	}
+
+	c.AllowUntaggedSigning("eth-app", true)
+	if _, err := c.SignEthereumDigest(digest, "eth-app"); err != nil {
+		t.Fatalf("SignEthereumDigest after opting in failed: %v", err)
+	}
+	if requests := deployment.SignRequests(); len(requests) != 1 || !bytes.Equal(requests[0].Msg, digest) {
+		t.Error("Expected the TEE to sign the digest as given")
+	}
+
+	c.AllowUntaggedSigning("eth-app", false)
+	if _, err := c.SignEthereumDigest(digest, "eth-app"); !errors.Is(err, ErrUntaggedSigning) {
+		t.Errorf("Expected ErrUntaggedSigning after opting out, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/acl"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+// SealEnvelope wraps a signature just made for appID in a verification.Envelope recording the
+// app's current key version, protocol, curve, hash and domain separation tag. Seal right after
+// Sign: a RotateKey in between would record the wrong key version
+func (c *Client) SealEnvelope(appID string, signature []byte) ([]byte, error) {
+	if c.userMgmt() == nil {
+		return nil, errNotInitialized
+	}
+	if len(signature) == 0 {
+		return nil, fmt.Errorf("signature is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	if err := c.authorize(ctx, c.principal, appID, acl.OpGetKey); err != nil {
+		return nil, err
+	}
+	keyInfo, err := c.getPublicKey(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope := &verification.Envelope{
+		Version:    verification.EnvelopeVersion,
+		AppID:      appID,
+		KeyVersion: keyInfo.Version,
+		Protocol:   keyInfo.Protocol,
+		Curve:      keyInfo.Curve,
+		Hash:       verification.HashAlgorithmFor(keyInfo.Protocol, keyInfo.Curve),
+		Domain:     c.domainTag(appID),
+		Signature:  signature,
+		Timestamp:  time.Now().UTC(),
+	}
+	return envelope.Marshal()
+}
+
+// OpenEnvelope verifies an envelope from SealEnvelope over message and returns it. The key is
+// the app's key of the envelope's version, or for unversioned envelopes the current key and any
+// previous key still in its rotation grace period. The envelope's protocol, curve and domain
+// must match the app's. A signature that does not verify returns verification.ErrEnvelopeSignature
+func (c *Client) OpenEnvelope(message, data []byte) (*verification.Envelope, error) {
+	if c.userMgmt() == nil {
+		return nil, errNotInitialized
+	}
+	envelope, err := verification.ParseEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+	if tag := c.domainTag(envelope.AppID); envelope.Domain != tag {
+		return nil, fmt.Errorf("envelope domain %q does not match %q configured for %s", envelope.Domain, tag, envelope.AppID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	if err := c.authorize(ctx, c.principal, envelope.AppID, acl.OpGetKey); err != nil {
+		return nil, err
+	}
+
+	var keyInfo *PublicKeyInfo
+	if envelope.KeyVersion != 0 {
+		keyInfo, err = c.lookupPublicKey(ctx, envelope.AppID, &KeyLookupOptions{Version: envelope.KeyVersion})
+	} else {
+		keyInfo, err = c.getPublicKey(ctx, envelope.AppID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if keyInfo.Protocol != envelope.Protocol || keyInfo.Curve != envelope.Curve {
+		return nil, fmt.Errorf("envelope is for %s on %s, but the key of %s is %s on %s",
+			envelope.Protocol, envelope.Curve, envelope.AppID, keyInfo.Protocol, keyInfo.Curve)
+	}
+
+	signed := envelope.SignedMessage(message)
+	valid, err := verification.VerifySignature(signed, keyInfo.Key, envelope.Signature, envelope.Protocol, envelope.Curve)
+	if !valid && envelope.KeyVersion == 0 && c.verifyWithPreviousKeys(envelope.AppID, signed, envelope.Signature) {
+		return envelope, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify envelope of %s: %w", envelope.AppID, err)
+	}
+	if !valid {
+		return nil, verification.ErrEnvelopeSignature
+	}
+	return envelope, nil
+}
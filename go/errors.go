@@ -0,0 +1,177 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/acl"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/policy"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/threshold"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+)
+
+// ErrRateLimited is matched by errors.Is for requests rejected by the client-side rate limiter
+var ErrRateLimited = errors.New("rate limited")
+
+// ErrShuttingDown is returned for sign requests made after Shutdown or Close was called, and by
+// Subscribe once the client is closed
+var ErrShuttingDown = errors.New("client is shutting down")
+
+// ErrAlreadyInitialized is returned by Init when the client is already initialized; Init is a no-op
+var ErrAlreadyInitialized = errors.New("client already initialized")
+
+// ErrAlreadyClosed is returned by Close and Shutdown when the client is already closed; the call is a no-op
+var ErrAlreadyClosed = errors.New("client already closed")
+
+// ErrSignatureMismatch is returned when a TEE-returned signature does not verify against the
+// app's public key, see Client.SetSignatureVerification
+var ErrSignatureMismatch = errors.New("TEE returned an invalid signature")
+
+// ErrVotingConfigRollback is matched by errors.Is for voting rounds refused because the server
+// reported a weaker voting configuration, see Client.EnableRollbackProtection
+var ErrVotingConfigRollback = errors.New("voting configuration rollback")
+
+// ErrVotingConfigSignature is matched by errors.Is for voting rounds refused because their
+// voting configuration wasn't signed by the voting authority, see Client.SetVotingAuthority
+var ErrVotingConfigSignature = usermgmt.ErrVotingConfigSignature
+
+// ErrPolicyViolation is matched by errors.Is for sign requests refused by the app's signing
+// policy, see Client.SigningPolicy
+var ErrPolicyViolation = policy.ErrViolation
+
+// ErrAccessDenied is matched by errors.Is for requests refused by the client's ACL, see
+// Client.SetACL
+var ErrAccessDenied = acl.ErrDenied
+
+// ErrThresholdRequirement is matched by errors.Is for keys whose threshold scheme falls short
+// of the caller's requirement, see Client.CheckKeyThreshold
+var ErrThresholdRequirement = threshold.ErrRequirement
+
+// ErrKeyUsage is matched by errors.Is for operations an app's key is not allowed to perform;
+// the error is a *KeyUsageError
+var ErrKeyUsage = errors.New("key usage not allowed")
+
+// ErrPayloadTooLarge is matched by errors.Is for messages and vote requests over the size
+// limits, see Client.SetMaxPayloadSize; the error is a *voting.PayloadTooLargeError
+var ErrPayloadTooLarge = voting.ErrPayloadTooLarge
+
+// ErrUntaggedSigning is matched by errors.Is for digest, transaction and MuSig2 signing refused
+// for apps with a domain separation tag, see Client.AllowUntaggedSigning
+var ErrUntaggedSigning = errors.New("untagged signing not allowed for domain-separated app")
+
+// ErrIdempotencyKeyReused is returned by Sign for an IdempotencyKey that an earlier request for
+// the same app used with a different message
+var ErrIdempotencyKeyReused = errors.New("idempotency key reused for a different request")
+
+// ErrQueuedOffline is matched by errors.Is for sign requests saved to the offline queue
+var ErrQueuedOffline = errors.New("queued offline")
+
+// ErrOfflineRequestExpired is reported to OfflineConfig.OnResult for queued requests that expired
+var ErrOfflineRequestExpired = errors.New("offline request expired")
+
+// ErrVotingRoundExpired is reported to RoundConfig.OnRecovered for interrupted voting rounds
+// that were abandoned instead of resumed
+var ErrVotingRoundExpired = errors.New("voting round expired")
+
+// ErrVotingRoundCancelled is matched by errors.Is for voting rounds cancelled with
+// Client.CancelVotingRound
+var ErrVotingRoundCancelled = errors.New("voting round cancelled")
+
+// ErrVotingRoundNotFound is returned by Client.CancelVotingRound for unknown or finished rounds
+var ErrVotingRoundNotFound = errors.New("voting round not found")
+
+// ErrVotingRoundSigning is returned by Client.CancelVotingRound for rounds that already
+// collected enough approvals and are being signed
+var ErrVotingRoundSigning = errors.New("voting round already approved")
+
+// QueuedError is returned by Sign when the TEE is unreachable and the request was queued
+// Its outcome is delivered later to OfflineConfig.OnResult under the same ID
+type QueuedError struct {
+	ID string
+}
+
+func (e *QueuedError) Error() string {
+	return fmt.Sprintf("TEE unreachable, request queued offline as %s", e.ID)
+}
+
+// Is reports whether target is ErrQueuedOffline
+func (e *QueuedError) Is(target error) bool {
+	return target == ErrQueuedOffline
+}
+
+// RateLimitError is returned when a sign request exceeds the configured rate limit
+type RateLimitError struct {
+	AppID      string
+	RetryAfter time.Duration // Time until the next request would be allowed
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limited: app %s, retry after %s", e.AppID, e.RetryAfter)
+}
+
+// Is reports whether target is ErrRateLimited
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// KeyUsageError is returned for an operation outside the usage of an app's key, e.g. signing
+// with a derive-only key
+type KeyUsageError struct {
+	AppID     string
+	Operation constants.KeyUsage // Usage the operation needs
+	Allowed   constants.KeyUsage // Usage of the app's key
+}
+
+func (e *KeyUsageError) Error() string {
+	return fmt.Sprintf("key of app %s may be used for %s, not %s", e.AppID, e.Allowed, e.Operation)
+}
+
+// Is reports whether target is ErrKeyUsage
+func (e *KeyUsageError) Is(target error) bool {
+	return target == ErrKeyUsage
+}
+
+// VotingConfigRollbackError describes a voting configuration weaker than the last one seen for an app
+type VotingConfigRollbackError struct {
+	AppID    string
+	Previous VotingConfig
+	Current  VotingConfig
+
+	// PreviousTargetsNeeded is how many of the previously configured targets must approve
+	// under the current configuration; it is below Previous.RequiredVotes
+	PreviousTargetsNeeded int
+
+	// WeakenedGroups lists the previous voting groups that were dropped or gained members
+	WeakenedGroups []string
+}
+
+func (e *VotingConfigRollbackError) Error() string {
+	msg := fmt.Sprintf("voting configuration rollback for app %s: %d/%d with targets %v weakened to %d/%d with targets %v (%d previously configured approvals needed)",
+		e.AppID, e.Previous.RequiredVotes, len(e.Previous.Targets), e.Previous.Targets,
+		e.Current.RequiredVotes, len(e.Current.Targets), e.Current.Targets, e.PreviousTargetsNeeded)
+	if len(e.WeakenedGroups) > 0 {
+		msg += fmt.Sprintf(", weakened voting groups %v", e.WeakenedGroups)
+	}
+	return msg
+}
+
+// Is reports whether target is ErrVotingConfigRollback
+func (e *VotingConfigRollbackError) Is(target error) bool {
+	return target == ErrVotingConfigRollback
+}
@@ -0,0 +1,85 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+// SignEthereumMessage signs a message using the EIP-191 personal_sign convention
+// The app's key must be ECDSA on SECP256K1. The EIP-191 digest is signed as is (prehashed),
+// and the returned signature is checked against it before being converted to the
+// 65-byte R || S || V form (V = 27/28) expected by MetaMask-style verifiers
+func (c *Client) SignEthereumMessage(message []byte, appID string) ([]byte, error) {
+	return c.SignEVMMessage(verification.ChainEthereum, message, appID)
+}
+
+// EVMAddress returns the address of the app's ECDSA SECP256K1 key on an EVM-compatible chain,
+// such as verification.ChainPolygon or verification.ChainTron
+func (c *Client) EVMAddress(chain *verification.Chain, appID string) (string, error) {
+	if chain == nil {
+		return "", fmt.Errorf("chain is required")
+	}
+	keyInfo, err := c.GetPublicKeyByAppID(appID)
+	if err != nil {
+		return "", err
+	}
+	if err := requireSecp256k1ECDSA(appID)(keyInfo); err != nil {
+		return "", err
+	}
+	return chain.Address(keyInfo.Key)
+}
+
+// SignEVMMessage signs a message with the chain's signed message prefix, EIP-191 on Ethereum and
+// its forks or TIP-191 on TRON, returning the 65-byte R || S || V form (V = 27/28) that wallets
+// on the chain verify. The prefixed message hash is signed as is, as in SignEthereumMessage
+func (c *Client) SignEVMMessage(chain *verification.Chain, message []byte, appID string) ([]byte, error) {
+	if chain == nil {
+		return nil, fmt.Errorf("chain is required")
+	}
+	hash := chain.MessageHash(message)
+	signature, publicKey, err := c.signSecp256k1Digest(AuditOpEthereum, message, hash, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	ethSignature, err := verification.EthereumSignature(hash, signature, publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert signature to %s format: %w", chain, err)
+	}
+	return ethSignature, nil
+}
+
+// SignEthereumDigest signs a precomputed 32-byte digest, such as the Keccak-256 signing hash of
+// a transaction, with the app's ECDSA SECP256K1 key. The signature is checked against the digest
+// and returned as R || S || V with V = 0/1, the y-parity of typed transactions; legacy
+// transactions need V + 27, or Chain.LegacyV with EIP-155 replay protection. Digests are
+// chain-agnostic: TRON transactions are signed by passing their SHA-256 txID
+func (c *Client) SignEthereumDigest(digest []byte, appID string) ([]byte, error) {
+	if len(digest) != 32 {
+		return nil, fmt.Errorf("digest must be 32 bytes, got %d", len(digest))
+	}
+	signature, publicKey, err := c.signSecp256k1Digest(AuditOpEthereum, digest, digest, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	ethSignature, err := verification.EthereumDigestSignature(digest, signature, publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert signature to ethereum format: %w", err)
+	}
+	return ethSignature, nil
+}
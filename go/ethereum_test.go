@@ -0,0 +1,106 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+func TestSignEthereumMessage(t *testing.T) {
+	c, deployment := newTestClient(t)
+	publicKey := addApp(t, deployment, "wallet", constants.ProtocolECDSA, constants.CurveSECP256K1)
+	address, err := verification.EthereumAddress(publicKey)
+	if err != nil {
+		t.Fatalf("EthereumAddress failed: %v", err)
+	}
+
+	message := []byte("hello ethereum")
+	signature, err := c.SignEthereumMessage(message, "wallet")
+	if err != nil {
+		t.Fatalf("SignEthereumMessage failed: %v", err)
+	}
+	recovered, err := verification.RecoverEthereumAddress(message, signature)
+	if err != nil {
+		t.Fatalf("RecoverEthereumAddress failed: %v", err)
+	}
+	if recovered != address {
+		t.Errorf("Recovered address %s, want %s", recovered, address)
+	}
+
+	// The EIP-191 digest reaches the TEE to be signed as is
+	requests := deployment.SignRequests()
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 sign request, got %d", len(requests))
+	}
+	if !requests[0].Prehashed || string(requests[0].Msg) != string(verification.EthereumMessageHash(message)) {
+		t.Errorf("Expected the prehashed EIP-191 digest, got prehashed=%t msg=%x", requests[0].Prehashed, requests[0].Msg)
+	}
+}
+
+func TestSignEthereumMessageRequiresSecp256k1(t *testing.T) {
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+
+	if _, err := c.SignEthereumMessage([]byte("hello"), "ed-app"); err == nil {
+		t.Fatal("Expected an Ed25519 app to be rejected")
+	}
+	if n := len(deployment.SignRequests()); n != 0 {
+		t.Errorf("Expected no sign request, got %d", n)
+	}
+}
+
+func TestSignEthereumDigest(t *testing.T) {
+	c, deployment := newTestClient(t)
+	publicKey := addApp(t, deployment, "wallet", constants.ProtocolECDSA, constants.CurveSECP256K1)
+	address, err := verification.EthereumAddress(publicKey)
+	if err != nil {
+		t.Fatalf("EthereumAddress failed: %v", err)
+	}
+
+	// Keccak-256 signing hash of a transaction, as the ethereum-signer example computes it
+	digest := verification.Keccak256([]byte{0x02}, []byte("transaction fields"))
+	signature, err := c.SignEthereumDigest(digest, "wallet")
+	if err != nil {
+		t.Fatalf("SignEthereumDigest failed: %v", err)
+	}
+	if v := signature[64]; v > 1 {
+		t.Errorf("Expected a y-parity V of 0 or 1, got %d", v)
+	}
+	recovered, err := verification.RecoverEthereumDigestAddress(digest, signature)
+	if err != nil {
+		t.Fatalf("RecoverEthereumDigestAddress failed: %v", err)
+	}
+	if recovered != address {
+		t.Errorf("Recovered address %s, want %s", recovered, address)
+	}
+
+	if _, err := c.SignEthereumDigest(digest[:31], "wallet"); err == nil {
+		t.Error("Expected a short digest to be rejected")
+	}
+}
+
+func TestSignEVMMessage(t *testing.T) {
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "evm-app", constants.ProtocolECDSA, constants.CurveSECP256K1)
+
+	message := []byte("hello tron")
+	for _, chain := range []*verification.Chain{verification.ChainTron, verification.ChainPolygon} {
+		address, err := c.EVMAddress(chain, "evm-app")
+		if err != nil {
+			t.Fatalf("EVMAddress(%s) failed: %v", chain, err)
+		}
+		signature, err := c.SignEVMMessage(chain, message, "evm-app")
+		if err != nil {
+			t.Fatalf("SignEVMMessage(%s) failed: %v", chain, err)
+		}
+		valid, err := chain.VerifyMessageAddress(message, signature, address)
+		if err != nil || !valid {
+			t.Errorf("%s signature does not recover to %s: %v", chain, address, err)
+		}
+	}
+
+	if _, err := c.SignEVMMessage(nil, message, "evm-app"); err == nil {
+		t.Error("Expected a nil chain to be rejected")
+	}
+}
@@ -0,0 +1,189 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/auth"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/proto/appid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EventType identifies what changed for an app
+type EventType string
+
+// Event types
+const (
+	EventKeyRotated          EventType = "key_rotated"           // The app's signing key changed
+	EventVotingConfigChanged EventType = "voting_config_changed" // Voting targets or required votes changed
+	EventDeploymentUp        EventType = "deployment_up"         // A deployment target became available
+	EventDeploymentDown      EventType = "deployment_down"       // A deployment target went away
+)
+
+// Event is a change notification pushed by the App node
+type Event struct {
+	Type        EventType `json:"type"`
+	AppID       string    `json:"app_id"`
+	TargetAppID string    `json:"target_app_id,omitempty"` // Deployment target that changed, for deployment events
+	Time        time.Time `json:"time"`
+}
+
+// Subscribe streams change events for appIDs (all apps if none are given) to events
+// until ctx is done, then returns ctx's error
+//
+// Cached public keys are invalidated on EventKeyRotated, and cached voting configurations on
+// voting config and deployment events, before the event is delivered. If the stream breaks,
+// Subscribe resubscribes with exponential backoff and invalidates cached keys and voting
+// configurations of the watched apps, since events may have been missed meanwhile. It returns
+// early if the client is not initialized or the App node does not support subscriptions, and
+// with ErrShuttingDown once the client is closed.
+// Delivery blocks until the receiver takes the event
+func (c *Client) Subscribe(ctx context.Context, events chan<- Event, appIDs ...string) error {
+	backoff := constants.EventResubscribeMinBackoff
+	for {
+		received, err := c.receiveEvents(ctx, events, appIDs)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if c.isClosing() {
+			return ErrShuttingDown
+		}
+		if errors.Is(err, errNotInitialized) {
+			return err
+		}
+		if status.Code(err) == codes.Unimplemented {
+			return fmt.Errorf("App node does not support event subscriptions: %w", err)
+		}
+		if received {
+			backoff = constants.EventResubscribeMinBackoff
+		}
+
+		log.Printf("⚠️  Event stream interrupted, resubscribing in %s: %v", backoff, err)
+		c.invalidateKeys(appIDs)
+		c.keys.invalidate(votingConfigPrefix, appIDs)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, constants.EventResubscribeMaxBackoff)
+	}
+}
+
+// errNotInitialized is returned by receiveEvents before Init
+var errNotInitialized = errors.New("client not initialized")
+
+// receiveEvents runs one subscription until the stream ends, reporting whether any event arrived
+func (c *Client) receiveEvents(ctx context.Context, events chan<- Event, appIDs []string) (bool, error) {
+	userMgmtClient := c.userMgmt()
+	if userMgmtClient == nil {
+		return false, errNotInitialized
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if len(appIDs) == 1 {
+		// A subscription to a single app authenticates with its token
+		streamCtx = auth.WithAppID(streamCtx, appIDs[0])
+	}
+	stream, err := userMgmtClient.SubscribeEvents(streamCtx, appIDs)
+	if err != nil {
+		return false, err
+	}
+	log.Printf("📡 Subscribed to app events")
+
+	received := false
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return received, fmt.Errorf("event stream closed by server")
+		}
+		if err != nil {
+			return received, err
+		}
+		received = true
+
+		event, ok := eventFromProto(msg)
+		if !ok {
+			continue
+		}
+		switch event.Type {
+		case EventKeyRotated:
+			c.invalidateKeys([]string{event.AppID})
+		case EventVotingConfigChanged, EventDeploymentUp, EventDeploymentDown:
+			c.keys.invalidate(votingConfigPrefix, []string{event.AppID})
+		}
+
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return received, ctx.Err()
+		}
+	}
+}
+
+// invalidateKeys drops cached public keys for appIDs, or for every app if appIDs is empty
+func (c *Client) invalidateKeys(appIDs []string) {
+	c.keys.invalidate(publicKeyPrefix, appIDs)
+
+	c.sessionsMu.Lock()
+	var sessions []*Session
+	if len(appIDs) == 0 {
+		for _, session := range c.sessions {
+			sessions = append(sessions, session)
+		}
+	} else {
+		for _, appID := range appIDs {
+			if session, ok := c.sessions[appID]; ok {
+				sessions = append(sessions, session)
+			}
+		}
+	}
+	c.sessionsMu.Unlock()
+
+	for _, session := range sessions {
+		session.InvalidateKey()
+	}
+}
+
+// eventFromProto converts a wire event; unknown event types are skipped
+func eventFromProto(msg *appid.AppEvent) (Event, bool) {
+	var eventType EventType
+	switch msg.Type {
+	case appid.AppEventType_APP_EVENT_TYPE_KEY_ROTATED:
+		eventType = EventKeyRotated
+	case appid.AppEventType_APP_EVENT_TYPE_VOTING_CONFIG_CHANGED:
+		eventType = EventVotingConfigChanged
+	case appid.AppEventType_APP_EVENT_TYPE_DEPLOYMENT_UP:
+		eventType = EventDeploymentUp
+	case appid.AppEventType_APP_EVENT_TYPE_DEPLOYMENT_DOWN:
+		eventType = EventDeploymentDown
+	default:
+		return Event{}, false
+	}
+	return Event{
+		Type:        eventType,
+		AppID:       msg.AppId,
+		TargetAppID: msg.TargetAppId,
+		Time:        time.Unix(msg.Timestamp, 0),
+	}, true
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/teetest"
+	"github.com/TEENet-io/teenet-sdk/go/proto/appid"
+)
+
+// subscribe runs Subscribe in the background until the deployment has the stream open
+func subscribe(t *testing.T, c *Client, deployment *teetest.Deployment, appIDs ...string) (<-chan Event, context.CancelFunc, <-chan error) {
+	t.Helper()
+	events := make(chan Event)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Subscribe(ctx, events, appIDs...)
+	}()
+	waitFor(t, func() bool { return deployment.Subscribers() == 1 })
+	return events, cancel, done
+}
+
+// waitFor polls cond until it holds, failing the test after a few seconds
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// expectNoEvent fails the test if an event arrives within a short wait
+func expectNoEvent(t *testing.T, events <-chan Event) {
+	t.Helper()
+	select {
+	case event := <-events:
+		t.Errorf("Expected no event, got %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestSubscribeDeliversEvents(t *testing.T) {
+	c, deployment := newTestClient(t)
+	oldKey := addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	addApp(t, deployment, "other-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if _, err := c.GetPublicKeyByAppID("ed-app"); err != nil {
+		t.Fatalf("GetPublicKeyByAppID failed: %v", err)
+	}
+	events, _, _ := subscribe(t, c, deployment, "ed-app")
+
+	newKey, err := deployment.RotateKey("ed-app")
+	if err != nil {
+		t.Fatalf("RotateKey failed: %v", err)
+	}
+	select {
+	case event := <-events:
+		if event.Type != EventKeyRotated || event.AppID != "ed-app" {
+			t.Errorf("Expected a key rotation of ed-app, got %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the key rotation event")
+	}
+	// The cached key was dropped before the event was delivered
+	keyInfo, err := c.GetPublicKeyByAppID("ed-app")
+	if err != nil {
+		t.Fatalf("GetPublicKeyByAppID failed: %v", err)
+	}
+	if bytes.Equal(keyInfo.Key, oldKey) || !bytes.Equal(keyInfo.Key, newKey) {
+		t.Error("Expected the rotated key after the event")
+	}
+
+	deployment.PublishEvent(&appid.AppEvent{Type: appid.AppEventType_APP_EVENT_TYPE_DEPLOYMENT_DOWN, AppId: "ed-app", TargetAppId: "approver"})
+	select {
+	case event := <-events:
+		if event.Type != EventDeploymentDown || event.TargetAppID != "approver" {
+			t.Errorf("Expected a deployment down event, got %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the deployment event")
+	}
+
+	// Apps that weren't subscribed to aren't delivered
+	if _, err := deployment.RotateKey("other-app"); err != nil {
+		t.Fatalf("RotateKey failed: %v", err)
+	}
+	expectNoEvent(t, events)
+}
+
+func TestUnsubscribeStopsEvents(t *testing.T) {
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	events, cancel, done := subscribe(t, c, deployment)
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected Subscribe to return context.Canceled, got %v", err)
+	}
+	waitFor(t, func() bool { return deployment.Subscribers() == 0 })
+	if _, err := deployment.RotateKey("ed-app"); err != nil {
+		t.Fatalf("RotateKey failed: %v", err)
+	}
+	expectNoEvent(t, events)
+}
+
+func TestCloseStopsEvents(t *testing.T) {
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	events, _, done := subscribe(t, c, deployment)
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	waitFor(t, func() bool { return deployment.Subscribers() == 0 })
+	if _, err := deployment.RotateKey("ed-app"); err != nil {
+		t.Fatalf("RotateKey failed: %v", err)
+	}
+	expectNoEvent(t, events)
+
+	// Subscribe gives up once it finds the client closed
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrShuttingDown) {
+			t.Errorf("Expected Subscribe to stop with the client closed, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for Subscribe to return after Close")
+	}
+}
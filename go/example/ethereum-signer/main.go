@@ -0,0 +1,194 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// ethereum-signer sends an EIP-1559 transaction from the Ethereum address of an app ID's
+// ECDSA secp256k1 key. The private key never leaves the TEE: the transaction's signing hash is
+// signed with SignEthereumDigest, which also returns the y-parity (recovery ID) the
+// transaction needs
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	client "github.com/TEENet-io/teenet-sdk/go"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+// txTypeDynamicFee is the EIP-2718 type byte of EIP-1559 transactions
+const txTypeDynamicFee = 0x02
+
+// dynamicFeeTx holds the fields of an EIP-1559 transaction
+type dynamicFeeTx struct {
+	ChainID              *big.Int
+	Nonce                *big.Int
+	MaxPriorityFeePerGas *big.Int
+	MaxFeePerGas         *big.Int
+	GasLimit             *big.Int
+	To                   []byte // 20-byte recipient
+	Value                *big.Int
+	Data                 []byte
+}
+
+// fields returns the RLP-encoded fields shared by the signing payload and the signed transaction
+func (tx *dynamicFeeTx) fields() [][]byte {
+	return [][]byte{
+		rlpUint(tx.ChainID),
+		rlpUint(tx.Nonce),
+		rlpUint(tx.MaxPriorityFeePerGas),
+		rlpUint(tx.MaxFeePerGas),
+		rlpUint(tx.GasLimit),
+		rlpBytes(tx.To),
+		rlpUint(tx.Value),
+		rlpBytes(tx.Data),
+		rlpList(), // Empty access list
+	}
+}
+
+// signingHash returns keccak256(0x02 || rlp([chain_id, nonce, ..., access_list]))
+func (tx *dynamicFeeTx) signingHash() []byte {
+	return verification.Keccak256([]byte{txTypeDynamicFee}, rlpList(tx.fields()...))
+}
+
+// encode returns the signed transaction for eth_sendRawTransaction from a 65-byte R || S || V
+// signature, V being the y-parity
+func (tx *dynamicFeeTx) encode(signature []byte) []byte {
+	fields := append(tx.fields(),
+		rlpUint(big.NewInt(int64(signature[64]))),
+		rlpUint(new(big.Int).SetBytes(signature[:32])),
+		rlpUint(new(big.Int).SetBytes(signature[32:64])),
+	)
+	return append([]byte{txTypeDynamicFee}, rlpList(fields...)...)
+}
+
+func main() {
+	appID := flag.String("app-id", os.Getenv("APP_ID"), "app ID of an ECDSA secp256k1 key")
+	rpcURL := flag.String("rpc", os.Getenv("ETH_RPC_URL"), "Ethereum JSON-RPC URL")
+	to := flag.String("to", "", "recipient address")
+	value := flag.String("value", "0", "amount in wei")
+	data := flag.String("data", "", "hex call data")
+	gasLimit := flag.Uint64("gas-limit", 0, "gas limit (estimated if 0)")
+	tip := flag.String("tip", "", "max priority fee per gas in wei (asks the node if empty)")
+	dryRun := flag.Bool("dry-run", false, "sign but don't broadcast")
+	flag.Parse()
+
+	if *appID == "" || *rpcURL == "" || *to == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	recipient, err := hex.DecodeString(strings.TrimPrefix(*to, "0x"))
+	if err != nil || len(recipient) != 20 {
+		log.Fatalf("Invalid recipient address %q", *to)
+	}
+	amount, ok := new(big.Int).SetString(*value, 10)
+	if !ok || amount.Sign() < 0 {
+		log.Fatalf("Invalid value %q", *value)
+	}
+	callData, err := hex.DecodeString(strings.TrimPrefix(*data, "0x"))
+	if err != nil {
+		log.Fatalf("Invalid call data: %v", err)
+	}
+
+	// Initialize TEE client (TEE_CONFIG_ADDR, TEENET_*); this program casts no votes
+	teeClient, err := client.NewFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid TEE client configuration: %v", err)
+	}
+	teeClient.DisableVotingService()
+	if err := teeClient.Init(nil); err != nil {
+		log.Fatalf("Failed to initialize TEE client: %v", err)
+	}
+	defer teeClient.Close()
+
+	// 1. Derive the sender address from the app's public key
+	keyInfo, err := teeClient.GetPublicKeyByAppID(*appID)
+	if err != nil {
+		log.Fatalf("Failed to get public key: %v", err)
+	}
+	from, err := verification.EthereumAddress(keyInfo.Key)
+	if err != nil {
+		log.Fatalf("App %s has no Ethereum address (%s/%s key): %v", *appID, keyInfo.Protocol, keyInfo.Curve, err)
+	}
+	fmt.Printf("Sender: %s (app %s)\n", from, *appID)
+
+	// 2. Fill in chain ID, nonce and fees from the node
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	rpc := &rpcClient{url: *rpcURL}
+
+	tx := &dynamicFeeTx{To: recipient, Value: amount, Data: callData}
+	if tx.ChainID, err = rpc.callQuantity(ctx, "eth_chainId"); err != nil {
+		log.Fatalf("Failed to get chain ID: %v", err)
+	}
+	if tx.Nonce, err = rpc.callQuantity(ctx, "eth_getTransactionCount", from, "pending"); err != nil {
+		log.Fatalf("Failed to get nonce: %v", err)
+	}
+	if *tip != "" {
+		if tx.MaxPriorityFeePerGas, ok = new(big.Int).SetString(*tip, 10); !ok {
+			log.Fatalf("Invalid tip %q", *tip)
+		}
+	} else if tx.MaxPriorityFeePerGas, err = rpc.callQuantity(ctx, "eth_maxPriorityFeePerGas"); err != nil {
+		log.Fatalf("Failed to get priority fee: %v", err)
+	}
+	baseFee, err := rpc.baseFee(ctx)
+	if err != nil {
+		log.Fatalf("Failed to get base fee: %v", err)
+	}
+	// Room for the base fee to double before the transaction is included
+	tx.MaxFeePerGas = new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), tx.MaxPriorityFeePerGas)
+	if *gasLimit > 0 {
+		tx.GasLimit = new(big.Int).SetUint64(*gasLimit)
+	} else {
+		call := map[string]string{"from": from, "to": hexBytes(recipient), "value": hexQuantity(amount)}
+		if len(callData) > 0 {
+			call["data"] = hexBytes(callData)
+		}
+		if tx.GasLimit, err = rpc.callQuantity(ctx, "eth_estimateGas", call); err != nil {
+			log.Fatalf("Failed to estimate gas: %v", err)
+		}
+	}
+	fmt.Printf("Chain %s, nonce %s, gas %s, max fee %s wei (tip %s)\n", tx.ChainID, tx.Nonce, tx.GasLimit, tx.MaxFeePerGas, tx.MaxPriorityFeePerGas)
+
+	// 3. Sign the transaction's signing hash in the TEE
+	hash := tx.signingHash()
+	signature, err := teeClient.SignEthereumDigest(hash, *appID)
+	if err != nil {
+		log.Fatalf("Failed to sign transaction: %v", err)
+	}
+	signer, err := verification.RecoverEthereumDigestAddress(hash, signature)
+	if err != nil || signer != from {
+		log.Fatalf("Signature recovers to %s, expected %s: %v", signer, from, err)
+	}
+	fmt.Printf("Signed: y-parity %d, recovers to %s\n", signature[64], signer)
+
+	raw := tx.encode(signature)
+	fmt.Printf("Transaction hash: %s\n", hexBytes(verification.Keccak256(raw)))
+	if *dryRun {
+		fmt.Printf("Raw transaction: %s\n", hexBytes(raw))
+		return
+	}
+
+	// 4. Broadcast
+	var txHash string
+	if err := rpc.call(ctx, &txHash, "eth_sendRawTransaction", hexBytes(raw)); err != nil {
+		log.Fatalf("Failed to broadcast transaction: %v", err)
+	}
+	fmt.Printf("Broadcast: %s\n", txHash)
+}
@@ -0,0 +1,115 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+)
+
+// rpcClient calls an Ethereum JSON-RPC endpoint
+type rpcClient struct {
+	url    string
+	nextID int
+}
+
+// rpcError is an error returned by the node
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message) }
+
+// call invokes method with params and decodes the result into result
+func (c *rpcClient) call(ctx context.Context, result any, method string, params ...any) error {
+	c.nextID++
+	if params == nil {
+		params = []any{}
+	}
+	body, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": c.nextID, "method": method, "params": params})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s failed: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("%s: invalid response (HTTP %d): %w", method, resp.StatusCode, err)
+	}
+	if response.Error != nil {
+		return fmt.Errorf("%s: %w", method, response.Error)
+	}
+	return json.Unmarshal(response.Result, result)
+}
+
+// callQuantity invokes a method returning a hex quantity such as "0x1a"
+func (c *rpcClient) callQuantity(ctx context.Context, method string, params ...any) (*big.Int, error) {
+	var result string
+	if err := c.call(ctx, &result, method, params...); err != nil {
+		return nil, err
+	}
+	return parseQuantity(result)
+}
+
+// baseFee returns the base fee per gas of the latest block
+func (c *rpcClient) baseFee(ctx context.Context) (*big.Int, error) {
+	var block struct {
+		BaseFeePerGas string `json:"baseFeePerGas"`
+	}
+	if err := c.call(ctx, &block, "eth_getBlockByNumber", "latest", false); err != nil {
+		return nil, err
+	}
+	if block.BaseFeePerGas == "" {
+		return nil, fmt.Errorf("latest block has no base fee, the chain does not support EIP-1559")
+	}
+	return parseQuantity(block.BaseFeePerGas)
+}
+
+// parseQuantity parses a hex-encoded JSON-RPC quantity
+func parseQuantity(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(strings.TrimPrefix(s, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid quantity %q", s)
+	}
+	return n, nil
+}
+
+// hexBytes encodes data as 0x-prefixed hex
+func hexBytes(data []byte) string {
+	return "0x" + hex.EncodeToString(data)
+}
+
+// hexQuantity encodes n as a JSON-RPC quantity
+func hexQuantity(n *big.Int) string {
+	return "0x" + n.Text(16)
+}
@@ -44,14 +44,14 @@ func main() {
 	// Example: Get public key by app ID
 	fmt.Println("\n1. Get public key by app ID")
 	appID := "secure-messaging-app"
-	publicKey, protocol, curve, err := teeClient.GetPublicKeyByAppID(appID)
+	keyInfo, err := teeClient.GetPublicKeyByAppID(appID)
 	if err != nil {
 		log.Printf("Failed to get public key by app ID: %v", err)
 	} else {
 		fmt.Printf("Public key for app ID %s:\n", appID)
-		fmt.Printf("  - Protocol: %s\n", protocol)
-		fmt.Printf("  - Curve: %s\n", curve)
-		fmt.Printf("  - Public Key: %s\n", publicKey)
+		fmt.Printf("  - Protocol: %s\n", keyInfo.Protocol)
+		fmt.Printf("  - Curve: %s\n", keyInfo.Curve)
+		fmt.Printf("  - Public Key: %x\n", keyInfo.Key)
 	}
 
 	// Example: Sign message using Sign method
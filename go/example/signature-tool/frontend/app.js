@@ -142,6 +142,48 @@ async function verifyWithAppID() {
     }
 }
 
+async function signBatch() {
+    const messages = document.getElementById('batchMessages').value
+        .split('\n')
+        .map(line => line.trim())
+        .filter(line => line.length > 0);
+    const resultDiv = document.getElementById('signBatchResult');
+
+    if (messages.length === 0) {
+        showResult(resultDiv, 'Please enter at least one message', 'error');
+        return;
+    }
+
+    showResult(resultDiv, 'Signing ' + messages.length + ' messages...', 'loading');
+
+    try {
+        const response = await makeApiCall('sign-batch', {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+            },
+            body: JSON.stringify({
+                app_id: getAppId(),
+                messages: messages
+            })
+        });
+
+        const data = await response.json();
+
+        if (data.signatures) {
+            const result = JSON.stringify({
+                app_id: data.app_id,
+                signatures: data.signatures
+            }, null, 2);
+            showResult(resultDiv, result, data.success ? 'success' : 'error');
+        } else {
+            showResult(resultDiv, 'Error: ' + data.error, 'error');
+        }
+    } catch (error) {
+        showResult(resultDiv, 'Network error: ' + error.message, 'error');
+    }
+}
+
 // Check if message contains approval keywords
 function checkMessageApproval() {
     const message = document.getElementById('votingMessage').value.toLowerCase();
@@ -182,21 +224,29 @@ async function initiateVoting() {
 
     showResult(resultDiv, 'Initiating voting round...', 'loading');
 
-    try {
-        const response = await makeApiCall('vote', {
-            method: 'POST',
-            headers: {
-                'Content-Type': 'application/json',
-            },
-            body: JSON.stringify({
-                message: btoa(message), // base64 encode
-                signer_app_id: getAppId(),
-                is_forwarded: false
-                // Target app IDs and required votes are fetched from server
-            })
-        });
+    const request = {
+        message: btoa(message), // base64 encode
+        signer_app_id: getAppId(),
+        is_forwarded: false
+        // Target app IDs and required votes are fetched from server
+    };
 
-        const data = await response.json();
+    try {
+        // Stream votes live over WebSocket, falling back to a plain request
+        let data;
+        try {
+            data = await streamVoting(request, resultDiv);
+        } catch (streamError) {
+            console.warn('Vote stream unavailable, falling back to POST:', streamError);
+            const response = await makeApiCall('vote', {
+                method: 'POST',
+                headers: {
+                    'Content-Type': 'application/json',
+                },
+                body: JSON.stringify(request)
+            });
+            data = await response.json();
+        }
         
         if (data.success) {
             // Check if there's an error in voting_results
@@ -232,6 +282,76 @@ async function initiateVoting() {
 }
 
 
+// Run a voting round over the vote-stream WebSocket, showing quorum progress as votes arrive
+// Resolves with the same body as POST /api/vote
+function streamVoting(request, resultDiv) {
+    return new Promise((resolve, reject) => {
+        const scheme = window.location.protocol === 'https:' ? 'wss://' : 'ws://';
+        const ws = new WebSocket(scheme + window.location.host + getApiBasePath() + 'api/vote-stream');
+        const progress = { required: 0, targets: [], votes: [] };
+        let settled = false;
+
+        ws.onopen = () => ws.send(JSON.stringify(request));
+        ws.onmessage = (event) => {
+            const update = JSON.parse(event.data);
+            switch (update.type) {
+            case 'config':
+                progress.required = update.required_votes;
+                progress.targets = update.targets || [];
+                break;
+            case 'vote':
+                progress.votes.push(update.vote);
+                break;
+            case 'result':
+                settled = true;
+                ws.close();
+                resolve(update.result);
+                return;
+            case 'error':
+                settled = true;
+                ws.close();
+                resolve({ success: false, message: update.error });
+                return;
+            }
+            showResult(resultDiv, formatVoteProgress(progress), 'loading');
+        };
+        ws.onerror = () => {
+            if (!settled) {
+                settled = true;
+                reject(new Error('WebSocket connection failed'));
+            }
+        };
+        ws.onclose = () => {
+            if (!settled) {
+                settled = true;
+                reject(new Error('WebSocket closed before the result'));
+            }
+        };
+    });
+}
+
+// Render live voting progress: approvals against the quorum and each target's vote so far
+function formatVoteProgress(progress) {
+    const approvals = progress.votes.filter(vote => vote.success && vote.response).length;
+    const lines = ['Voting in progress... ' + approvals + '/' + (progress.required || '?') + ' approvals'];
+    const answered = new Set();
+    for (const vote of progress.votes) {
+        answered.add(vote.client_id);
+        let status = vote.success && vote.response ? '✅ approved' : '❌ rejected';
+        if (!vote.success) {
+            status = '⚠️ failed';
+        }
+        const reason = vote.reason || vote.error || vote.code;
+        lines.push('  ' + vote.client_id + ': ' + status + (reason ? ' (' + reason + ')' : ''));
+    }
+    for (const target of progress.targets) {
+        if (!answered.has(target)) {
+            lines.push('  ' + target + ': ⏳ waiting');
+        }
+    }
+    return lines.join('\n');
+}
+
 // Verify voting signature
 async function verifyVotingSignature() {
     const message = document.getElementById('verifyVotingMessage').value.trim();
@@ -4,12 +4,22 @@ go 1.24.2
 
 toolchain go1.24.5
 
+// Build against the SDK in this repository
+replace (
+	github.com/TEENet-io/teenet-sdk/go => ../..
+	github.com/TEENet-io/teenet-sdk/go/pkg/constants => ../../pkg/constants
+	github.com/TEENet-io/teenet-sdk/go/pkg/verification => ../../pkg/verification
+)
+
 require (
-	github.com/TEENet-io/teenet-sdk/go v0.0.0-20250912074619-9e592fb9b727
+	github.com/TEENet-io/teenet-sdk/go v0.0.0-00010101000000-000000000000
 	github.com/gin-gonic/gin v1.10.1
+	golang.org/x/net v0.44.0
 )
 
 require (
+	github.com/TEENet-io/teenet-sdk/go/pkg/constants v0.1.0 // indirect
+	github.com/TEENet-io/teenet-sdk/go/pkg/verification v0.1.0 // indirect
 	github.com/btcsuite/btcd/btcec/v2 v2.3.5 // indirect
 	github.com/btcsuite/btcd/chaincfg/chainhash v1.1.0 // indirect
 	github.com/bytedance/gopkg v0.1.3 // indirect
@@ -35,7 +45,6 @@ require (
 	github.com/ugorji/go/codec v1.3.0 // indirect
 	golang.org/x/arch v0.21.0 // indirect
 	golang.org/x/crypto v0.42.0 // indirect
-	golang.org/x/net v0.44.0 // indirect
 	golang.org/x/sys v0.36.0 // indirect
 	golang.org/x/text v0.29.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250908214217-97024824d090 // indirect
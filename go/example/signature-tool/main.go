@@ -28,6 +28,7 @@ import (
 
 	client "github.com/TEENet-io/teenet-sdk/go"
 	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
 )
 
 var teeClient *client.Client
@@ -35,11 +36,6 @@ var defaultAppID string
 
 func main() {
 	// Get configuration from environment variables
-	configAddr := os.Getenv("TEE_CONFIG_ADDR")
-	if configAddr == "" {
-		configAddr = "localhost:50052" // Default TEE configuration server address
-	}
-
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080" // Default port
@@ -57,8 +53,16 @@ func main() {
 		frontendPath = "./frontend" // Default frontend path
 	}
 
-	// Initialize TEE client with custom voting handler
-	teeClient = client.NewClient(configAddr)
+	// Initialize TEE client (TEE_CONFIG_ADDR, TEENET_*) with custom voting handler
+	clientConfig, err := client.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid TEE client configuration: %v", err)
+	}
+	configAddr := clientConfig.ConfigServerAddr
+	if configAddr == "" {
+		configAddr = client.DefaultConfigServerAddr
+	}
+	teeClient = client.NewFromConfig(clientConfig)
 	votingHandler := createVotingHandler(defaultAppID)
 	if err := teeClient.Init(votingHandler); err != nil {
 		log.Fatalf("Failed to initialize TEE client: %v", err)
@@ -117,7 +121,7 @@ func main() {
 			return
 		}
 
-		publicKey, protocol, curve, err := teeClient.GetPublicKeyByAppID(req.AppID)
+		keyInfo, err := teeClient.GetPublicKeyByAppID(req.AppID)
 		if err != nil {
 			log.Printf("Failed to get public key for app ID %s: %v", req.AppID, err)
 			c.JSON(http.StatusInternalServerError, GetPublicKeyResponse{
@@ -132,9 +136,9 @@ func main() {
 		c.JSON(http.StatusOK, GetPublicKeyResponse{
 			Success:   true,
 			AppID:     req.AppID,
-			PublicKey: publicKey,
-			Protocol:  protocol,
-			Curve:     curve,
+			PublicKey: hex.EncodeToString(keyInfo.Key),
+			Protocol:  keyInfo.Protocol.String(),
+			Curve:     keyInfo.Curve.String(),
 		})
 	})
 
@@ -182,6 +186,55 @@ func main() {
 		})
 	})
 
+	// Sign several messages with app ID in one call
+	api.POST("/sign-batch", func(c *gin.Context) {
+		var req SignBatchRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, SignBatchResponse{
+				Success: false,
+				Error:   "Invalid request: " + err.Error(),
+			})
+			return
+		}
+
+		// SignBatchContext signs the messages concurrently and returns results in order;
+		// messages not yet started are skipped if the caller goes away
+		requests := make([]*client.SignRequest, len(req.Messages))
+		for i, message := range req.Messages {
+			requests[i] = &client.SignRequest{
+				Message:      []byte(message),
+				AppID:        req.AppID,
+				EnableVoting: false,
+			}
+		}
+		results, err := teeClient.SignBatchContext(c.Request.Context(), requests)
+		if err != nil {
+			log.Printf("Some messages of batch for app ID %s failed to sign: %v", req.AppID, err)
+		}
+
+		signatures := make([]SignBatchItem, len(results))
+		allSigned := true
+		for i, result := range results {
+			signatures[i] = SignBatchItem{Message: req.Messages[i]}
+			if result.Success {
+				signatures[i].Signature = hex.EncodeToString(result.Signature)
+			} else {
+				allSigned = false
+				signatures[i].Error = result.Error
+				if signatures[i].Error == "" {
+					signatures[i].Error = "Failed to sign message"
+				}
+			}
+		}
+
+		log.Printf("Signed batch of %d messages with app ID %s", len(req.Messages), req.AppID)
+		c.JSON(http.StatusOK, SignBatchResponse{
+			Success:    allSigned,
+			AppID:      req.AppID,
+			Signatures: signatures,
+		})
+	})
+
 	// Verify signature with App ID
 	api.POST("/verify-with-appid", func(c *gin.Context) {
 		var req VerifyWithAppIDRequest
@@ -194,7 +247,7 @@ func main() {
 		}
 
 		// Get public key info (for response)
-		publicKey, protocol, curve, err := teeClient.GetPublicKeyByAppID(req.AppID)
+		keyInfo, err := teeClient.GetPublicKeyByAppID(req.AppID)
 		if err != nil {
 			log.Printf("Failed to get public key for app ID %s: %v", req.AppID, err)
 			c.JSON(http.StatusInternalServerError, VerifyWithAppIDResponse{
@@ -215,7 +268,8 @@ func main() {
 			return
 		}
 
-		// Verify the signature using the SDK's Verify method
+		// Verify with the client, which checks every protocol and curve through the verification
+		// package and applies the app's domain tag and rotation grace period
 		valid, err := teeClient.Verify([]byte(req.Message), signatureBytes, req.AppID)
 		if err != nil {
 			log.Printf("Failed to verify signature: %v", err)
@@ -235,9 +289,9 @@ func main() {
 			Message:   req.Message,
 			Signature: req.Signature,
 			AppID:     req.AppID,
-			PublicKey: publicKey,
-			Protocol:  protocol,
-			Curve:     curve,
+			PublicKey: hex.EncodeToString(keyInfo.Key),
+			Protocol:  keyInfo.Protocol.String(),
+			Curve:     keyInfo.Curve.String(),
 		})
 	})
 
@@ -282,112 +336,119 @@ func main() {
 			LocalApproval: localApproval,
 			HTTPRequest:   c.Request,
 		})
-		if err != nil {
-			log.Printf("❌ [%s] VotingSign failed: %v", defaultAppID, err)
-
-			// Check if we have partial voting results
-			if signResult != nil && signResult.VotingInfo != nil {
-				c.JSON(http.StatusOK, gin.H{
-					"success":  true,
-					"approved": false,
-					"app_id":   defaultAppID,
-					"message":  fmt.Sprintf("VotingSign failed: %v", err),
-					"voting_results": gin.H{
-						"voting_complete":  signResult.Success,
-						"successful_votes": signResult.VotingInfo.SuccessfulVotes,
-						"required_votes":   signResult.VotingInfo.RequiredVotes,
-						"total_targets":    signResult.VotingInfo.TotalTargets,
-						"final_result":     signResult.Error,
-						"vote_details":     signResult.VotingInfo.VoteDetails,
-						"error":            err.Error(),
-					},
-					"signature": "",
-					"timestamp": time.Now().Format(time.RFC3339),
-				})
-			} else {
-				// No voting results at all
-				c.JSON(http.StatusOK, gin.H{
-					"success":  true,
-					"approved": false,
-					"app_id":   defaultAppID,
-					"message":  fmt.Sprintf("VotingSign failed: %v", err),
-					"voting_results": gin.H{
-						"voting_complete":  false,
-						"successful_votes": 0,
-						"required_votes":   0,
-						"total_targets":    0,
-						"final_result":     "ERROR",
-						"vote_details":     []interface{}{},
-						"error":            err.Error(),
-					},
-					"signature": "",
-					"timestamp": time.Now().Format(time.RFC3339),
-				})
-			}
-			return
-		}
+		c.JSON(http.StatusOK, voteResponse(signResult, err))
+	})
 
-		finalApproval := signResult.Success
-		log.Printf("✅ [%s] VotingSign result: %t", defaultAppID, finalApproval)
+	// Voting over WebSocket - streams each vote as it arrives, then the final result
+	api.GET("/vote-stream", gin.WrapH(websocket.Handler(streamVote)))
 
-		// Convert signature to hex string if available
-		var signatureHex string
-		if signResult.Signature != nil && len(signResult.Signature) > 0 {
-			signatureHex = hex.EncodeToString(signResult.Signature)
-		}
+	log.Printf("Starting TEENet Signature Tool on port %s...", port)
+	log.Printf("TEE Configuration Server: %s", configAddr)
+	log.Printf("Default App ID: %s", defaultAppID)
+	log.Printf("Frontend Path: %s", frontendPath)
+	log.Printf("Web interface available at: http://localhost:%s", port)
 
-		// Prepare voting results response
-		votingResults := gin.H{
-			"voting_complete":  signResult.Success,
-			"successful_votes": 0,
-			"required_votes":   0,
-			"total_targets":    0,
-			"final_result":     "DIRECT_SIGN",
-			"vote_details":     []interface{}{},
-		}
+	if err := router.Run(":" + port); err != nil {
+		log.Fatalf("Failed to start server: %v", err)
+	}
+}
 
-		if signResult.VotingInfo != nil {
-			votingResults = gin.H{
-				"voting_complete":  signResult.Success,
-				"successful_votes": signResult.VotingInfo.SuccessfulVotes,
-				"required_votes":   signResult.VotingInfo.RequiredVotes,
-				"total_targets":    signResult.VotingInfo.TotalTargets,
-				"final_result": func() string {
-					if signResult.Success {
-						return "APPROVED"
-					}
-					return "REJECTED"
-				}(),
-				"vote_details": signResult.VotingInfo.VoteDetails,
+// voteResponse builds the response of a voting sign request from its result
+func voteResponse(signResult *client.SignResult, err error) gin.H {
+	if err != nil {
+		log.Printf("❌ [%s] VotingSign failed: %v", defaultAppID, err)
+
+		// Check if we have partial voting results
+		if signResult != nil && signResult.VotingInfo != nil {
+			return gin.H{
+				"success":  true,
+				"approved": false,
+				"app_id":   defaultAppID,
+				"message":  fmt.Sprintf("VotingSign failed: %v", err),
+				"voting_results": gin.H{
+					"voting_complete":  signResult.Success,
+					"successful_votes": signResult.VotingInfo.SuccessfulVotes,
+					"required_votes":   signResult.VotingInfo.RequiredVotes,
+					"total_targets":    signResult.VotingInfo.TotalTargets,
+					"final_result":     signResult.Error,
+					"vote_details":     signResult.VotingInfo.VoteDetails,
+					"error":            err.Error(),
+				},
+				"signature": "",
+				"timestamp": time.Now().Format(time.RFC3339),
 			}
 		}
 
-		c.JSON(http.StatusOK, gin.H{
+		// No voting results at all
+		return gin.H{
 			"success":  true,
-			"approved": finalApproval,
+			"approved": false,
 			"app_id":   defaultAppID,
-			"message": func() string {
+			"message":  fmt.Sprintf("VotingSign failed: %v", err),
+			"voting_results": gin.H{
+				"voting_complete":  false,
+				"successful_votes": 0,
+				"required_votes":   0,
+				"total_targets":    0,
+				"final_result":     "ERROR",
+				"vote_details":     []interface{}{},
+				"error":            err.Error(),
+			},
+			"signature": "",
+			"timestamp": time.Now().Format(time.RFC3339),
+		}
+	}
+
+	finalApproval := signResult.Success
+	log.Printf("✅ [%s] VotingSign result: %t", defaultAppID, finalApproval)
+
+	// Convert signature to hex string if available
+	var signatureHex string
+	if signResult.Signature != nil && len(signResult.Signature) > 0 {
+		signatureHex = hex.EncodeToString(signResult.Signature)
+	}
+
+	// Prepare voting results response
+	votingResults := gin.H{
+		"voting_complete":  signResult.Success,
+		"successful_votes": 0,
+		"required_votes":   0,
+		"total_targets":    0,
+		"final_result":     "DIRECT_SIGN",
+		"vote_details":     []interface{}{},
+	}
+
+	if signResult.VotingInfo != nil {
+		votingResults = gin.H{
+			"voting_complete":  signResult.Success,
+			"successful_votes": signResult.VotingInfo.SuccessfulVotes,
+			"required_votes":   signResult.VotingInfo.RequiredVotes,
+			"total_targets":    signResult.VotingInfo.TotalTargets,
+			"final_result": func() string {
 				if signResult.Success {
 					return "APPROVED"
 				}
-				if signResult.Error != "" {
-					return signResult.Error
-				}
 				return "REJECTED"
 			}(),
-			"voting_results": votingResults,
-			"signature":      signatureHex,
-			"timestamp":      time.Now().UTC().Format(time.RFC3339),
-		})
-	})
-
-	log.Printf("Starting TEENet Signature Tool on port %s...", port)
-	log.Printf("TEE Configuration Server: %s", configAddr)
-	log.Printf("Default App ID: %s", defaultAppID)
-	log.Printf("Frontend Path: %s", frontendPath)
-	log.Printf("Web interface available at: http://localhost:%s", port)
+			"vote_details": signResult.VotingInfo.VoteDetails,
+		}
+	}
 
-	if err := router.Run(":" + port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	return gin.H{
+		"success":  true,
+		"approved": finalApproval,
+		"app_id":   defaultAppID,
+		"message": func() string {
+			if signResult.Success {
+				return "APPROVED"
+			}
+			if signResult.Error != "" {
+				return signResult.Error
+			}
+			return "REJECTED"
+		}(),
+		"voting_results": votingResults,
+		"signature":      signatureHex,
+		"timestamp":      time.Now().UTC().Format(time.RFC3339),
 	}
 }
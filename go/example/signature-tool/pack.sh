@@ -1,7 +1,7 @@
 #!/bin/bash
 
 # 构建 Docker 镜像
-docker build -t teenet-signature-tool:latest .
+docker build -t teenet-signature-tool:latest -f Dockerfile ../..
 
 # 导出并压缩
 docker save teenet-signature-tool:latest | gzip > teenet-signature-tool.tar.gz
@@ -0,0 +1,90 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	client "github.com/TEENet-io/teenet-sdk/go"
+	"golang.org/x/net/websocket"
+)
+
+// VoteStreamEvent is one message sent to vote-stream clients
+type VoteStreamEvent struct {
+	Type string `json:"type"` // "config", "vote", "result" or "error"
+
+	// Voting configuration, sent before votes are requested
+	RequiredVotes int      `json:"required_votes,omitempty"`
+	Targets       []string `json:"targets,omitempty"`
+
+	Vote   *client.VoteDetail `json:"vote,omitempty"`   // One vote as it arrives
+	Result any                `json:"result,omitempty"` // Same body as POST /api/vote
+	Error  string             `json:"error,omitempty"`
+}
+
+// streamVote runs one voting sign request per connection: the client sends an
+// IncomingVoteRequest and receives the voting configuration, each VoteDetail as it
+// arrives and finally the result, so the UI can show quorum progress live
+func streamVote(ws *websocket.Conn) {
+	defer ws.Close()
+
+	send := func(event VoteStreamEvent) {
+		ws.SetWriteDeadline(time.Now().Add(10 * time.Second))
+		if err := websocket.JSON.Send(ws, event); err != nil {
+			log.Printf("⚠️  [%s] Failed to send vote stream event: %v", defaultAppID, err)
+		}
+	}
+
+	var requestBody []byte
+	if err := websocket.Message.Receive(ws, &requestBody); err != nil {
+		log.Printf("⚠️  [%s] Failed to read vote stream request: %v", defaultAppID, err)
+		return
+	}
+	var req IncomingVoteRequest
+	if err := json.Unmarshal(requestBody, &req); err != nil || req.Message == "" || req.SignerAppID == "" {
+		send(VoteStreamEvent{Type: "error", Error: "Invalid request"})
+		return
+	}
+	messageBytes, err := base64.StdEncoding.DecodeString(req.Message)
+	if err != nil {
+		send(VoteStreamEvent{Type: "error", Error: "Invalid message encoding"})
+		return
+	}
+
+	log.Printf("🗳️  [%s] Received streamed vote request", defaultAppID)
+
+	if config, err := teeClient.GetVotingConfig(req.SignerAppID); err == nil {
+		send(VoteStreamEvent{Type: "config", RequiredVotes: config.RequiredVotes, Targets: config.Targets})
+	}
+
+	// Same decision as POST /api/vote: approve if message contains "test"
+	localApproval := strings.Contains(strings.ToLower(string(messageBytes)), "test")
+	log.Printf("📝 [%s] Local vote decision for message '%s': %t", defaultAppID, messageBytes, localApproval)
+
+	signResult, err := teeClient.Sign(&client.SignRequest{
+		Message:         messageBytes,
+		AppID:           req.SignerAppID,
+		EnableVoting:    true,
+		LocalApproval:   localApproval,
+		VoteRequestData: requestBody,
+		OnVote: func(vote client.VoteDetail) {
+			send(VoteStreamEvent{Type: "vote", Vote: &vote})
+		},
+	})
+	send(VoteStreamEvent{Type: "result", Result: voteResponse(signResult, err)})
+}
@@ -13,14 +13,6 @@
 
 package main
 
-import "math/big"
-
-// ECDSASignature represents an ECDSA signature with r and s values
-type ECDSASignature struct {
-	R, S *big.Int
-}
-
-
 // IncomingVoteRequest for handling vote requests from other apps
 type IncomingVoteRequest struct {
 	Message           string   `json:"message" binding:"required"`           // Base64 encoded message
@@ -71,4 +63,22 @@ type SignWithAppIDResponse struct {
 	AppID     string `json:"app_id,omitempty"`
 	Signature string `json:"signature,omitempty"`
 	Error     string `json:"error,omitempty"`
+}
+
+type SignBatchRequest struct {
+	Messages []string `json:"messages" binding:"required,min=1"`
+	AppID    string   `json:"app_id" binding:"required"`
+}
+
+type SignBatchItem struct {
+	Message   string `json:"message"`
+	Signature string `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type SignBatchResponse struct {
+	Success    bool            `json:"success"`
+	AppID      string          `json:"app_id,omitempty"`
+	Signatures []SignBatchItem `json:"signatures,omitempty"`
+	Error      string          `json:"error,omitempty"`
 }
\ No newline at end of file
@@ -0,0 +1,155 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// signing-service calls a teenet-signd instance the way a platform team's services would: with
+// an API key instead of TEE credentials. It fetches the app's public key, signs a batch of
+// messages, waits out 429 responses using Retry-After and verifies the signatures locally
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	client "github.com/TEENet-io/teenet-sdk/go"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+// service calls the teenet-signd REST API with an API key
+type service struct {
+	url    string
+	apiKey string
+}
+
+// statusError is a non-2xx response
+type statusError struct {
+	StatusCode int
+	RetryAfter time.Duration
+	Message    string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
+}
+
+// do sends a request and decodes the JSON response into result
+func (s *service) do(method, path string, body, result any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, s.url+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-API-Key", s.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		var response struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&response)
+		err := &statusError{StatusCode: resp.StatusCode, Message: response.Error}
+		if seconds, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil {
+			err.RetryAfter = time.Duration(seconds) * time.Second
+		}
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// sign signs message, waiting and retrying while the API key is rate limited
+func (s *service) sign(appID string, message []byte) ([]byte, error) {
+	for {
+		var result struct {
+			Success   bool   `json:"success"`
+			Signature string `json:"signature"`
+			Error     string `json:"error"`
+		}
+		err := s.do("POST", "/v1/sign", map[string]any{"app_id": appID, "message": message}, &result)
+		if statusErr, ok := err.(*statusError); ok && statusErr.StatusCode == http.StatusTooManyRequests {
+			log.Printf("⏳ Rate limited, retrying in %v", statusErr.RetryAfter)
+			time.Sleep(statusErr.RetryAfter)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !result.Success {
+			return nil, fmt.Errorf("signing failed: %s", result.Error)
+		}
+		return base64.StdEncoding.DecodeString(result.Signature)
+	}
+}
+
+func main() {
+	url := flag.String("url", getEnv("SIGND_URL", "http://localhost:8081"), "teenet-signd REST address")
+	apiKey := flag.String("api-key", os.Getenv("SIGND_API_KEY"), "API key")
+	appID := flag.String("app-id", os.Getenv("APP_ID"), "App ID of the signing key")
+	count := flag.Int("count", 5, "Number of messages to sign")
+	flag.Parse()
+	if *apiKey == "" || *appID == "" {
+		log.Fatalf("-api-key and -app-id are required")
+	}
+	s := &service{url: *url, apiKey: *apiKey}
+
+	var keyInfo client.PublicKeyInfo
+	if err := s.do("GET", "/v1/public-keys/"+*appID, nil, &keyInfo); err != nil {
+		if statusErr, ok := err.(*statusError); ok && statusErr.StatusCode == http.StatusForbidden {
+			log.Fatalf("API key may not use %s: %v", *appID, err)
+		}
+		log.Fatalf("Failed to get public key: %v", err)
+	}
+	fmt.Printf("🔑 %s: %s/%s key %x\n", *appID, keyInfo.Protocol, keyInfo.Curve, keyInfo.Key)
+
+	start := time.Now()
+	for i := 0; i < *count; i++ {
+		message := []byte(fmt.Sprintf("message %d at %s", i, time.Now().Format(time.RFC3339Nano)))
+		signature, err := s.sign(*appID, message)
+		if err != nil {
+			log.Fatalf("Failed to sign message %d: %v", i, err)
+		}
+		valid, err := verification.VerifySignature(message, keyInfo.Key, signature, keyInfo.Protocol, keyInfo.Curve)
+		if err != nil || !valid {
+			log.Fatalf("Signature %d did not verify: %v", i, err)
+		}
+		fmt.Printf("✅ Signed and verified message %d: %x\n", i, signature)
+	}
+	fmt.Printf("📊 Signed %d messages in %v\n", *count, time.Since(start).Round(time.Millisecond))
+}
+
+// getEnv returns the environment variable or a default value
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
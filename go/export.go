@@ -0,0 +1,29 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+// ExportPublicKey returns the public key of an app ID in the requested format
+// Supported formats are raw, compressed, PEM SubjectPublicKeyInfo (for OpenSSL or nginx)
+// and OpenSSH (for authorized_keys); see verification.PublicKeyFormat
+func (c *Client) ExportPublicKey(appID string, format verification.PublicKeyFormat) ([]byte, error) {
+	keyInfo, err := c.GetPublicKeyByAppID(appID)
+	if err != nil {
+		return nil, err
+	}
+	return verification.ExportPublicKey(keyInfo.Curve, keyInfo.Key, format)
+}
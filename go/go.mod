@@ -3,17 +3,28 @@ module github.com/TEENet-io/teenet-sdk/go
 go 1.24.2
 
 require (
+	github.com/TEENet-io/teenet-sdk/go/pkg/constants v0.1.0
+	github.com/TEENet-io/teenet-sdk/go/pkg/verification v0.1.0
 	github.com/btcsuite/btcd/btcec/v2 v2.3.5
+	github.com/btcsuite/btcd/chaincfg/chainhash v1.0.1
+	golang.org/x/sys v0.33.0
 	google.golang.org/grpc v1.72.0
 	google.golang.org/protobuf v1.36.6
 )
 
 require (
-	github.com/btcsuite/btcd/chaincfg/chainhash v1.0.1 // indirect
 	github.com/decred/dcrd/crypto/blake256 v1.1.0 // indirect
 	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.4.0 // indirect
 	golang.org/x/net v0.35.0 // indirect
-	golang.org/x/sys v0.33.0 // indirect
 	golang.org/x/text v0.25.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250218202821-56aae31c358a // indirect
 )
+
+// pkg/constants and pkg/verification are separate modules so verifier-only consumers don't pull
+// in gRPC. Releases tag them as go/pkg/constants/vX.Y.Z and go/pkg/verification/vX.Y.Z before the
+// client is tagged, and the versions required above must name those tags: dependents ignore
+// these replace directives, which only point local builds at the working tree
+replace (
+	github.com/TEENet-io/teenet-sdk/go/pkg/constants => ./pkg/constants
+	github.com/TEENet-io/teenet-sdk/go/pkg/verification => ./pkg/verification
+)
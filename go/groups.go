@@ -0,0 +1,67 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+)
+
+// validateVotingGroups makes sure every voting group has at least one deployed member,
+// otherwise no voting round could ever satisfy it
+func validateVotingGroups(groups map[string][]string, targets map[string]*usermgmt.DeploymentTarget) error {
+	for _, name := range sortedGroupNames(groups) {
+		deployed := false
+		for _, member := range groups[name] {
+			if _, ok := targets[member]; ok {
+				deployed = true
+				break
+			}
+		}
+		if !deployed {
+			return fmt.Errorf("voting group %q has no deployed members", name)
+		}
+	}
+	return nil
+}
+
+// missingVotingGroups returns the sorted names of groups none of whose members approved
+func missingVotingGroups(groups map[string][]string, approvedBy map[string]bool) []string {
+	var missing []string
+	for _, name := range sortedGroupNames(groups) {
+		approved := false
+		for _, member := range groups[name] {
+			if approvedBy[member] {
+				approved = true
+				break
+			}
+		}
+		if !approved {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// sortedGroupNames returns the group names in a stable order
+func sortedGroupNames(groups map[string][]string) []string {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
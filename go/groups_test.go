@@ -0,0 +1,63 @@
+package client
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/votingtest"
+)
+
+func TestVotingGroups(t *testing.T) {
+	network := votingtest.NewNetwork("ed-app", "alice", "bob", "carol")
+	defer network.Close()
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	groups := map[string][]string{"finance": {"alice"}, "security": {"bob", "carol"}}
+	if err := deployment.SetVoting("ed-app", network, 2, groups); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+	sign := func(message string) *SignResult {
+		result, _ := c.Sign(&SignRequest{AppID: "ed-app", Message: []byte(message), EnableVoting: true, VoteRequestData: []byte(`{"amount":10}`)})
+		if result == nil || result.VotingInfo == nil {
+			t.Fatalf("Expected a voting result for %q, got %+v", message, result)
+		}
+		return result
+	}
+
+	// Two approvals, but none from the security group
+	network.SetBehavior("bob", votingtest.Reject(voting.CodePolicyViolation, "no"))
+	network.SetBehavior("carol", votingtest.Reject(voting.CodePolicyViolation, "no"))
+	network.SetBehavior("ed-app", votingtest.Approve())
+	result := sign("pay 10")
+	if result.Success {
+		t.Fatal("Expected the round to fail without a security approval")
+	}
+	if !slices.Equal(result.VotingInfo.MissingGroups, []string{"security"}) {
+		t.Errorf("Expected the security group to be missing, got %v", result.VotingInfo.MissingGroups)
+	}
+
+	// One approval from each group is enough
+	network.SetBehavior("carol", votingtest.Approve())
+	network.SetBehavior("ed-app", votingtest.Reject(voting.CodePolicyViolation, "no"))
+	if result := sign("pay 20"); !result.Success || len(result.VotingInfo.MissingGroups) != 0 {
+		t.Errorf("Expected alice and carol to satisfy both groups, got success=%t missing=%v", result.Success, result.VotingInfo.MissingGroups)
+	}
+}
+
+func TestVotingGroupWithoutDeployedMembers(t *testing.T) {
+	network := votingtest.NewNetwork("ed-app", "alice")
+	defer network.Close()
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := deployment.SetVoting("ed-app", network, 1, map[string][]string{"security": {"bob"}}); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+	if result, err := c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("pay 10"), EnableVoting: true, VoteRequestData: []byte(`{"amount":10}`)}); err == nil && result.Success {
+		t.Error("Expected a group no deployed app belongs to to fail the round")
+	}
+	if n := len(network.Peer("alice").Requests()); n != 0 {
+		t.Errorf("Expected no vote requests, got %d", n)
+	}
+}
@@ -0,0 +1,75 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+// grpcOptions collects user-supplied settings applied to every gRPC connection
+type grpcOptions struct {
+	unaryInterceptors  []grpc.UnaryClientInterceptor
+	streamInterceptors []grpc.StreamClientInterceptor
+	compression        bool
+	maxSendMsgSize     int
+	maxRecvMsgSize     int
+	dialer             func(context.Context, string) (net.Conn, error)
+}
+
+// dialOptions builds the dial options for outgoing connections
+func (o *grpcOptions) dialOptions() []grpc.DialOption {
+	var opts []grpc.DialOption
+	if len(o.unaryInterceptors) > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(o.unaryInterceptors...))
+	}
+	if len(o.streamInterceptors) > 0 {
+		opts = append(opts, grpc.WithChainStreamInterceptor(o.streamInterceptors...))
+	}
+
+	if o.dialer != nil {
+		opts = append(opts, grpc.WithContextDialer(o.dialer))
+	}
+
+	var callOpts []grpc.CallOption
+	if o.compression {
+		callOpts = append(callOpts, grpc.UseCompressor(gzip.Name))
+	}
+	if o.maxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(o.maxSendMsgSize))
+	}
+	if o.maxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(o.maxRecvMsgSize))
+	}
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+	return opts
+}
+
+// serverOptions builds the options for the voting service
+// Importing gzip registers the compressor, so compressed requests are always accepted
+func (o *grpcOptions) serverOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if o.maxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(o.maxSendMsgSize))
+	}
+	if o.maxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(o.maxRecvMsgSize))
+	}
+	return opts
+}
@@ -0,0 +1,105 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"google.golang.org/grpc/connectivity"
+)
+
+// HealthStatus describes the state of the client's connections and voting service
+type HealthStatus struct {
+	Initialized    bool   `json:"initialized"`
+	TEE            string `json:"tee"`             // gRPC connectivity state of the TEE connection
+	UserManagement string `json:"user_management"` // gRPC connectivity state of the user management connection
+	VotingService  string `json:"voting_service"`  // "running", "stopped" or "disabled"
+	Live           bool   `json:"live"`
+	Ready          bool   `json:"ready"`
+}
+
+// Health reports the current connection and voting-service state
+//
+// The client is live until its connections are shut down (Close was called).
+// It is ready once initialized, while neither connection is in TRANSIENT_FAILURE
+// and the voting service is running (unless disabled). IDLE connections count as
+// ready since gRPC connects lazily on the next call
+func (c *Client) Health() HealthStatus {
+	c.connMu.RLock()
+	taskClient, userMgmtClient, votingRunning := c.taskClient, c.userMgmtClient, c.votingServer != nil
+	c.connMu.RUnlock()
+
+	status := HealthStatus{
+		Initialized:    taskClient != nil && userMgmtClient != nil,
+		TEE:            connectivity.Shutdown.String(),
+		UserManagement: connectivity.Shutdown.String(),
+		VotingService:  "stopped",
+	}
+	if !status.Initialized {
+		// Not initialized yet: alive, but not ready to serve
+		status.Live = true
+		return status
+	}
+
+	teeState := taskClient.State()
+	userMgmtState := userMgmtClient.State()
+	status.TEE = teeState.String()
+	status.UserManagement = userMgmtState.String()
+
+	votingReady := true
+	switch {
+	case c.votingDisabled:
+		status.VotingService = "disabled"
+	case votingRunning:
+		status.VotingService = "running"
+	default:
+		votingReady = false
+	}
+
+	status.Live = teeState != connectivity.Shutdown && userMgmtState != connectivity.Shutdown
+	status.Ready = status.Live && votingReady &&
+		teeState != connectivity.TransientFailure && userMgmtState != connectivity.TransientFailure
+	return status
+}
+
+// LivenessHandler returns an http.Handler for Kubernetes liveness probes
+// It responds 200 unless the client's connections have been shut down
+func (c *Client) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := c.Health()
+		writeHealth(w, status, status.Live)
+	})
+}
+
+// ReadinessHandler returns an http.Handler for Kubernetes readiness probes
+// It responds 200 only when the client can serve sign requests, see Health
+func (c *Client) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := c.Health()
+		writeHealth(w, status, status.Ready)
+	})
+}
+
+// writeHealth writes status as JSON with 200 if ok and 503 otherwise
+func writeHealth(w http.ResponseWriter, status HealthStatus, ok bool) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if ok {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(status)
+}
@@ -0,0 +1,160 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/cache"
+)
+
+// Idempotency defaults
+const (
+	DefaultIdempotencyTTL        = 24 * time.Hour
+	DefaultIdempotencyMaxEntries = 10000
+)
+
+// idempotencyCache is the cache label used for idempotency key lookups in metrics
+const idempotencyCache = "idempotency"
+
+// IdempotencyConfig configures how results of sign requests with an IdempotencyKey are kept,
+// see EnableIdempotency
+type IdempotencyConfig struct {
+	TTL        time.Duration // How long a result is returned for its key
+	MaxEntries int           // Size bound of the default in-memory store
+	Cache      cache.Cache   // Optional shared or persistent backend; defaults to an in-memory LRU
+}
+
+// idempotency is the client's idempotency key state
+type idempotency struct {
+	config IdempotencyConfig
+	cache  cache.Cache
+
+	mu       sync.Mutex
+	inflight map[string]chan struct{} // Keys whose request is running, closed when it ends
+}
+
+// idempotentRecord is a stored result along with the request it answered
+type idempotentRecord struct {
+	RequestHash string      `json:"request_hash"`
+	Result      *SignResult `json:"result"`
+}
+
+// EnableIdempotency configures where results of sign requests carrying an IdempotencyKey are
+// kept and for how long. Without it they are kept in memory for DefaultIdempotencyTTL; a
+// shared Cache extends the protection across replicas and restarts. Must be called before Init
+func (c *Client) EnableIdempotency(config IdempotencyConfig) {
+	c.idempotencyMu.Lock()
+	defer c.idempotencyMu.Unlock()
+	c.idempotency = newIdempotency(config)
+}
+
+// newIdempotency creates idempotency key state, applying defaults
+func newIdempotency(config IdempotencyConfig) *idempotency {
+	if config.TTL <= 0 {
+		config.TTL = DefaultIdempotencyTTL
+	}
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = DefaultIdempotencyMaxEntries
+	}
+	backend := config.Cache
+	if backend == nil {
+		backend = cache.NewMemory(config.MaxEntries)
+	}
+	return &idempotency{config: config, cache: backend, inflight: make(map[string]chan struct{})}
+}
+
+// idempotencyState returns the idempotency key state, creating the default on first use
+func (c *Client) idempotencyState() *idempotency {
+	c.idempotencyMu.Lock()
+	defer c.idempotencyMu.Unlock()
+	if c.idempotency == nil {
+		c.idempotency = newIdempotency(IdempotencyConfig{})
+	}
+	return c.idempotency
+}
+
+// claimIdempotencyKey returns the stored result for req's idempotency key, waiting for a request
+// with the same key that is still running. Otherwise it claims the key and returns a release
+// function that stores the outcome of the request and lets waiting requests proceed
+func (c *Client) claimIdempotencyKey(req *SignRequest) (*SignResult, func(*SignResult, error), error) {
+	state := c.idempotencyState()
+	key := "idem:" + requestHash(req.AppID, []byte(req.IdempotencyKey), 0, nil)
+	hash := requestHash(req.AppID, req.Message, req.ED25519Mode, req.ED25519Context)
+
+	for {
+		state.mu.Lock()
+		running, ok := state.inflight[key]
+		if !ok {
+			done := make(chan struct{})
+			state.inflight[key] = done
+			state.mu.Unlock()
+
+			release := func(result *SignResult, err error) {
+				state.store(key, hash, result, err)
+				state.mu.Lock()
+				delete(state.inflight, key)
+				state.mu.Unlock()
+				close(done)
+			}
+			result, err := state.lookup(key, hash)
+			c.metrics.ObserveCache(idempotencyCache, result != nil)
+			if result != nil || err != nil {
+				release(nil, nil)
+				return result, nil, err
+			}
+			return nil, release, nil
+		}
+		state.mu.Unlock()
+
+		// A retry arrived while the first request is running: share its outcome
+		<-running
+	}
+}
+
+// lookup returns the result stored for key, if any
+func (s *idempotency) lookup(key, hash string) (*SignResult, error) {
+	data, ok := s.cache.Get(key)
+	if !ok {
+		return nil, nil
+	}
+	var record idempotentRecord
+	if err := json.Unmarshal(data, &record); err != nil || record.Result == nil {
+		log.Printf("⚠️  Ignoring unreadable idempotency record: %v", err)
+		return nil, nil
+	}
+	if record.RequestHash != hash {
+		return nil, ErrIdempotencyKeyReused
+	}
+	result := record.Result
+	result.IdempotentReplay = true
+	return result, nil
+}
+
+// store keeps the outcome of a request under its idempotency key. Only answers are kept: a
+// signature, or a voting round that ended without one. Errors are not, so a retry runs again
+func (s *idempotency) store(key, hash string, result *SignResult, err error) {
+	if err != nil || result == nil || result.QueuedID != "" {
+		return
+	}
+	data, marshalErr := json.Marshal(idempotentRecord{RequestHash: hash, Result: result})
+	if marshalErr != nil {
+		log.Printf("⚠️  Failed to encode idempotency record: %v", marshalErr)
+		return
+	}
+	s.cache.Set(key, data, s.config.TTL)
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+)
+
+func TestIdempotentReplay(t *testing.T) {
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	req := &SignRequest{AppID: "ed-app", Message: []byte("pay 10"), IdempotencyKey: "order-1"}
+
+	first, err := c.Sign(req)
+	if err != nil || !first.Success || first.IdempotentReplay {
+		t.Fatalf("First Sign: success=%t replay=%t err=%v", first.Success, first.IdempotentReplay, err)
+	}
+	retry, err := c.Sign(req)
+	if err != nil || !retry.IdempotentReplay {
+		t.Fatalf("Expected the retry to be replayed, got replay=%t err=%v", retry.IdempotentReplay, err)
+	}
+	if string(retry.Signature) != string(first.Signature) {
+		t.Error("Expected the replay to return the first signature")
+	}
+	if n := len(deployment.SignRequests()); n != 1 {
+		t.Errorf("Expected 1 TEE sign request, got %d", n)
+	}
+
+	// The key is bound to the request it was first used for
+	if _, err := c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("pay 20"), IdempotencyKey: "order-1"}); !errors.Is(err, ErrIdempotencyKeyReused) {
+		t.Errorf("Expected ErrIdempotencyKeyReused, got %v", err)
+	}
+}
+
+func TestIdempotentConcurrentRetries(t *testing.T) {
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+
+	var wg sync.WaitGroup
+	results := make([]*SignResult, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("pay 10"), IdempotencyKey: "order-1"})
+			if err != nil {
+				t.Errorf("Sign %d failed: %v", i, err)
+				return
+			}
+			results[i] = result
+		}(i)
+	}
+	wg.Wait()
+
+	if n := len(deployment.SignRequests()); n != 1 {
+		t.Errorf("Expected concurrent retries to share 1 TEE sign request, got %d", n)
+	}
+	replays := 0
+	for _, result := range results {
+		if result != nil && result.IdempotentReplay {
+			replays++
+		}
+	}
+	if replays != len(results)-1 {
+		t.Errorf("Expected %d replays, got %d", len(results)-1, replays)
+	}
+}
@@ -0,0 +1,249 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/cache"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+)
+
+// DefaultPublicKeyCacheTTL is how long a fetched public key is reused by default
+const DefaultPublicKeyCacheTTL = 30 * time.Second
+
+// Cache labels used for client-wide lookups in metrics
+const (
+	publicKeyCache    = "public_key"
+	votingConfigCache = "voting_config"
+)
+
+// Backend key prefixes of client-wide cache entries
+const (
+	publicKeyPrefix    = "pubkey:"
+	votingConfigPrefix = "voting:"
+)
+
+// keyCache holds recently fetched public keys and voting configurations, and collapses
+// concurrent fetches of the same entry into one user management call
+type keyCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	votingTTL  time.Duration
+	backend    cache.Cache
+	stored     map[string]struct{} // Backend keys this client stored, dropped when all are invalidated
+	inflight   map[string]*keyFetch
+	generation uint64 // Bumped on invalidation, so a fetch started earlier isn't stored
+}
+
+// keyFetch is a running fetch shared by concurrent callers
+type keyFetch struct {
+	done  chan struct{}
+	value []byte
+	err   error
+}
+
+// SetPublicKeyCacheTTL sets how long public keys fetched from user management are reused
+// (default DefaultPublicKeyCacheTTL). A negative ttl disables the cache; concurrent lookups
+// of the same key still share one call. Must be called before Init
+func (c *Client) SetPublicKeyCacheTTL(ttl time.Duration) {
+	c.keys.mu.Lock()
+	defer c.keys.mu.Unlock()
+	c.keys.ttl = ttl
+}
+
+// SetVotingConfigCacheTTL caches the voting configuration and deployment targets of each app
+// for ttl, instead of fetching them for every voting round. Cached configurations are dropped
+// on voting config and deployment change events (see Subscribe). Must be called before Init
+func (c *Client) SetVotingConfigCacheTTL(ttl time.Duration) {
+	c.keys.mu.Lock()
+	defer c.keys.mu.Unlock()
+	c.keys.votingTTL = ttl
+}
+
+// SetCacheBackend keeps cached public keys and voting configurations in backend instead of
+// process memory, e.g. a cache.Redis shared by every replica of a horizontally scaled
+// service so they agree on cached state. Entries are trusted as read, so the backend must be
+// as protected as the client itself. Must be called before Init
+func (c *Client) SetCacheBackend(backend cache.Cache) {
+	c.keys.mu.Lock()
+	defer c.keys.mu.Unlock()
+	c.keys.backend = backend
+}
+
+// loadPublicKey returns the public key for appID from the client-wide cache, fetching it
+// once for all concurrent callers on a miss
+func (c *Client) loadPublicKey(ctx context.Context, appID string) (*PublicKeyInfo, error) {
+	ttl := c.keys.ttlFor(publicKeyPrefix)
+	data, err := c.loadCached(ctx, publicKeyCache, publicKeyPrefix+appID, ttl, func(ctx context.Context) (any, error) {
+		return c.fetchPublicKey(ctx, appID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var key PublicKeyInfo
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("invalid cached public key for %s: %w", appID, err)
+	}
+	return &key, nil
+}
+
+// votingSignConfig returns the voting configuration of appID, from the client-wide cache if
+// SetVotingConfigCacheTTL enabled it
+func (c *Client) votingSignConfig(ctx context.Context, appID string) (*usermgmt.VotingSignConfig, error) {
+	userMgmtClient := c.userMgmt()
+	if userMgmtClient == nil {
+		return nil, fmt.Errorf("client not initialized")
+	}
+	ttl := c.keys.ttlFor(votingConfigPrefix)
+	if ttl <= 0 {
+		return userMgmtClient.GetVotingSignConfig(ctx, appID)
+	}
+	data, err := c.loadCached(ctx, votingConfigCache, votingConfigPrefix+appID, ttl, func(ctx context.Context) (any, error) {
+		return userMgmtClient.GetVotingSignConfig(ctx, appID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var config usermgmt.VotingSignConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid cached voting configuration for %s: %w", appID, err)
+	}
+	return &config, nil
+}
+
+// loadCached returns the encoded entry under key, calling fetch once for all concurrent
+// callers on a miss and storing its result for ttl if ttl is positive
+func (c *Client) loadCached(ctx context.Context, label, key string, ttl time.Duration, fetch func(context.Context) (any, error)) ([]byte, error) {
+	backend := c.keys.cache()
+	for {
+		if data, ok := backend.Get(key); ok {
+			c.metrics.ObserveCache(label, true)
+			return data, nil
+		}
+
+		c.keys.mu.Lock()
+		running, ok := c.keys.inflight[key]
+		if !ok {
+			running = &keyFetch{done: make(chan struct{})}
+			if c.keys.inflight == nil {
+				c.keys.inflight = make(map[string]*keyFetch)
+			}
+			c.keys.inflight[key] = running
+			generation := c.keys.generation
+			c.keys.mu.Unlock()
+			c.metrics.ObserveCache(label, false)
+
+			value, err := fetch(ctx)
+			if err == nil {
+				running.value, err = json.Marshal(value)
+			}
+			running.err = err
+			c.keys.finish(key, running, ttl, generation)
+			return running.value, running.err
+		}
+		c.keys.mu.Unlock()
+
+		select {
+		case <-running.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		// The shared fetch ended with its caller's context; try again with ours
+		if errors.Is(running.err, context.Canceled) || errors.Is(running.err, context.DeadlineExceeded) {
+			continue
+		}
+		return running.value, running.err
+	}
+}
+
+// ttlFor returns how long entries with prefix are cached; zero or negative disables caching
+func (k *keyCache) ttlFor(prefix string) time.Duration {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if prefix == votingConfigPrefix {
+		return k.votingTTL
+	}
+	if k.ttl == 0 {
+		return DefaultPublicKeyCacheTTL
+	}
+	return k.ttl
+}
+
+// cache returns the backend, creating the in-memory default on first use
+func (k *keyCache) cache() cache.Cache {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.backend == nil {
+		k.backend = cache.NewMemory(0)
+	}
+	return k.backend
+}
+
+// finish records the outcome of a fetch and releases the callers waiting for it
+func (k *keyCache) finish(key string, fetch *keyFetch, ttl time.Duration, generation uint64) {
+	k.mu.Lock()
+	delete(k.inflight, key)
+	store := fetch.err == nil && ttl > 0 && generation == k.generation
+	if store {
+		if k.stored == nil {
+			k.stored = make(map[string]struct{})
+		}
+		k.stored[key] = struct{}{}
+	}
+	backend := k.backend
+	k.mu.Unlock()
+
+	if store {
+		backend.Set(key, fetch.value, ttl)
+	}
+	close(fetch.done)
+}
+
+// invalidate drops cached entries with prefix for appIDs, or every entry with prefix this
+// client stored if appIDs is empty
+func (k *keyCache) invalidate(prefix string, appIDs []string) {
+	k.mu.Lock()
+	k.generation++
+	var keys []string
+	if len(appIDs) == 0 {
+		for key := range k.stored {
+			if strings.HasPrefix(key, prefix) {
+				keys = append(keys, key)
+			}
+		}
+	} else {
+		for _, appID := range appIDs {
+			keys = append(keys, prefix+appID)
+		}
+	}
+	for _, key := range keys {
+		delete(k.stored, key)
+	}
+	backend := k.backend
+	k.mu.Unlock()
+
+	if backend == nil {
+		return
+	}
+	for _, key := range keys {
+		backend.Delete(key)
+	}
+}
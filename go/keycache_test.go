@@ -0,0 +1,28 @@
+package client
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+)
+
+func TestPublicKeyLookupsCollapse(t *testing.T) {
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetPublicKeyByAppID("ed-app"); err != nil {
+				t.Errorf("GetPublicKeyByAppID failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := deployment.PublicKeyLookups("ed-app"); n != 1 {
+		t.Errorf("Expected concurrent lookups to share 1 App node call, got %d", n)
+	}
+}
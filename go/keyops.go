@@ -0,0 +1,297 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/task"
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/key_management"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// KeyOperationType is the kind of key operation being watched
+type KeyOperationType string
+
+// Key operation types
+const (
+	KeyOperationDKG       KeyOperationType = "dkg"
+	KeyOperationResharing KeyOperationType = "resharing"
+)
+
+// KeyOperationState is the state of a key operation or of one participant in it
+type KeyOperationState string
+
+// Key operation states
+const (
+	KeyOperationPending   KeyOperationState = "pending"
+	KeyOperationRunning   KeyOperationState = "running"
+	KeyOperationCompleted KeyOperationState = "completed"
+	KeyOperationFailed    KeyOperationState = "failed"
+)
+
+// ErrKeyOperationFailed is matched by errors.Is for key operations that failed, see
+// KeyOperationWatch.Err
+var ErrKeyOperationFailed = errors.New("key operation failed")
+
+// KeyOperationStatus is a progress report of a DKG or resharing operation
+type KeyOperationStatus struct {
+	OperationID  string                `json:"operation_id"`
+	Type         KeyOperationType      `json:"type,omitempty"`
+	State        KeyOperationState     `json:"state"`
+	Round        int                   `json:"round"`                  // Protocol round in progress
+	TotalRounds  int                   `json:"total_rounds,omitempty"` // Rounds the protocol takes
+	Participants []ParticipantProgress `json:"participants"`
+	Error        string                `json:"error,omitempty"`      // Why the operation failed
+	PublicKey    []byte                `json:"public_key,omitempty"` // Group public key, once completed
+	UpdatedAt    time.Time             `json:"updated_at,omitzero"`
+}
+
+// ParticipantProgress is one TEE node's progress in a key operation
+type ParticipantProgress struct {
+	ID    uint32            `json:"id"`
+	State KeyOperationState `json:"state"`
+	Round int               `json:"round"` // Last round the node completed
+	Error string            `json:"error,omitempty"`
+}
+
+// Done reports whether the operation has completed or failed
+func (s *KeyOperationStatus) Done() bool {
+	return s.State == KeyOperationCompleted || s.State == KeyOperationFailed
+}
+
+// FailedParticipants returns the participants that failed, which a resharing may survive
+func (s *KeyOperationStatus) FailedParticipants() []ParticipantProgress {
+	var failed []ParticipantProgress
+	for _, p := range s.Participants {
+		if p.State == KeyOperationFailed {
+			failed = append(failed, p)
+		}
+	}
+	return failed
+}
+
+// KeyOperationError is returned by KeyOperationWatch.Err for failed operations
+type KeyOperationError struct {
+	Status KeyOperationStatus
+}
+
+func (e *KeyOperationError) Error() string {
+	msg := fmt.Sprintf("key operation %s failed", e.Status.OperationID)
+	if e.Status.Error != "" {
+		msg += ": " + e.Status.Error
+	}
+	if failed := e.Status.FailedParticipants(); len(failed) > 0 {
+		nodes := make([]string, len(failed))
+		for i, p := range failed {
+			nodes[i] = fmt.Sprintf("node %d", p.ID)
+			if p.Error != "" {
+				nodes[i] += " (" + p.Error + ")"
+			}
+		}
+		msg += "; failed participants: " + strings.Join(nodes, ", ")
+	}
+	return msg
+}
+
+// Is reports whether target is ErrKeyOperationFailed
+func (e *KeyOperationError) Is(target error) bool {
+	return target == ErrKeyOperationFailed
+}
+
+// KeyOperationWatch streams the progress of a key operation, see Client.WatchKeyOperation
+type KeyOperationWatch struct {
+	updates chan KeyOperationStatus
+	err     error
+}
+
+// Updates delivers the operation's status on every change and is closed when the watch ends
+// A status may repeat after the watch reconnected
+func (w *KeyOperationWatch) Updates() <-chan KeyOperationStatus {
+	return w.updates
+}
+
+// Err returns why the watch ended, once Updates is closed: nil if the operation completed,
+// a *KeyOperationError if it failed, or the context or stream error that stopped the watch
+func (w *KeyOperationWatch) Err() error {
+	return w.err
+}
+
+// WatchKeyOperation watches a long-running DKG or resharing operation on the TEE nodes,
+// reporting per-participant progress and failures as they happen instead of blocking until
+// the operation ends
+//
+// The current status is received before WatchKeyOperation returns, so unknown operations and
+// TEE nodes without key operation support fail here. If the stream breaks later the watch
+// reconnects with exponential backoff. Delivery blocks until the receiver takes the status;
+// cancel ctx to stop watching early
+func (c *Client) WatchKeyOperation(ctx context.Context, operationID string) (*KeyOperationWatch, error) {
+	taskClient := c.tee()
+	if taskClient == nil {
+		return nil, errNotInitialized
+	}
+
+	// Streams are bound to watchCtx so the last one is released when the watch ends
+	watchCtx, cancel := context.WithCancel(ctx)
+	stream, first, err := openKeyOperation(watchCtx, taskClient, operationID)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	watch := &KeyOperationWatch{updates: make(chan KeyOperationStatus)}
+	go func() {
+		defer close(watch.updates)
+		defer cancel()
+		watch.err = watch.run(watchCtx, taskClient, operationID, stream, first)
+	}()
+	return watch, nil
+}
+
+// run delivers statuses until the operation ends, reconnecting broken streams
+func (w *KeyOperationWatch) run(ctx context.Context, taskClient *task.Client, operationID string, stream pb.UserTask_WatchKeyOperationClient, current KeyOperationStatus) error {
+	backoff := constants.KeyOperationRewatchMinBackoff
+	for {
+		done, err := w.follow(ctx, stream, current)
+		if done {
+			return err
+		}
+
+		for {
+			if permanentWatchError(err) {
+				return err
+			}
+			log.Printf("⚠️  Key operation %s stream interrupted, reconnecting in %s: %v", operationID, backoff, err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, constants.KeyOperationRewatchMaxBackoff)
+
+			if stream, current, err = openKeyOperation(ctx, taskClient, operationID); err == nil {
+				backoff = constants.KeyOperationRewatchMinBackoff
+				break
+			}
+		}
+	}
+}
+
+// follow delivers current and the statuses that follow it on stream, reporting done once
+// the operation ended or ctx is done; otherwise err is why the stream broke
+func (w *KeyOperationWatch) follow(ctx context.Context, stream pb.UserTask_WatchKeyOperationClient, current KeyOperationStatus) (done bool, err error) {
+	for {
+		select {
+		case w.updates <- current:
+		case <-ctx.Done():
+			return true, ctx.Err()
+		}
+		switch current.State {
+		case KeyOperationCompleted:
+			return true, nil
+		case KeyOperationFailed:
+			return true, &KeyOperationError{Status: current}
+		}
+
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return false, fmt.Errorf("key operation stream closed by server")
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return true, ctx.Err()
+			}
+			return false, err
+		}
+		current = keyOperationStatusFromProto(msg)
+	}
+}
+
+// openKeyOperation opens a watch stream and receives the operation's current status
+func openKeyOperation(ctx context.Context, taskClient *task.Client, operationID string) (pb.UserTask_WatchKeyOperationClient, KeyOperationStatus, error) {
+	stream, err := taskClient.WatchKeyOperation(ctx, operationID)
+	if err != nil {
+		return nil, KeyOperationStatus{}, err
+	}
+	msg, err := stream.Recv()
+	if err == io.EOF {
+		err = fmt.Errorf("key operation stream closed by server")
+	}
+	if err != nil {
+		return nil, KeyOperationStatus{}, fmt.Errorf("failed to watch key operation %s: %w", operationID, err)
+	}
+	return stream, keyOperationStatusFromProto(msg), nil
+}
+
+// permanentWatchError reports whether reconnecting can't fix err
+func permanentWatchError(err error) bool {
+	switch status.Code(err) {
+	case codes.NotFound, codes.InvalidArgument, codes.Unimplemented, codes.PermissionDenied,
+		codes.Unauthenticated, codes.FailedPrecondition, codes.Canceled:
+		return true
+	}
+	return false
+}
+
+// keyOperationStatusFromProto converts a wire status
+func keyOperationStatusFromProto(msg *pb.KeyOperationStatus) KeyOperationStatus {
+	s := KeyOperationStatus{
+		OperationID: msg.OperationId,
+		State:       keyOperationState(msg.State),
+		Round:       int(msg.Round),
+		TotalRounds: int(msg.TotalRounds),
+		Error:       msg.Error,
+		PublicKey:   msg.PublicKey,
+	}
+	if msg.UpdatedAt > 0 {
+		s.UpdatedAt = time.Unix(msg.UpdatedAt, 0)
+	}
+	switch msg.Type {
+	case 1:
+		s.Type = KeyOperationDKG
+	case 2:
+		s.Type = KeyOperationResharing
+	}
+	for _, p := range msg.Participants {
+		s.Participants = append(s.Participants, ParticipantProgress{
+			ID:    p.Id,
+			State: keyOperationState(p.State),
+			Round: int(p.Round),
+			Error: p.Error,
+		})
+	}
+	return s
+}
+
+// keyOperationState converts a wire state; unknown states read as pending
+func keyOperationState(state uint32) KeyOperationState {
+	switch state {
+	case 1:
+		return KeyOperationRunning
+	case 2:
+		return KeyOperationCompleted
+	case 3:
+		return KeyOperationFailed
+	default:
+		return KeyOperationPending
+	}
+}
@@ -0,0 +1,51 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/acl"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+)
+
+// CheckKeyUsage returns a *KeyUsageError if appID's key may not be used for op, as reported by
+// the key's usage flags; keys without flags are unrestricted. Signing is checked by the client
+// itself; applications that encrypt or derive shared secrets with an app's key check first
+func (c *Client) CheckKeyUsage(appID string, op constants.KeyUsage) error {
+	if c.userMgmt() == nil {
+		return fmt.Errorf("client not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	if err := c.authorize(ctx, c.principal, appID, acl.OpGetKey); err != nil {
+		return err
+	}
+
+	keyInfo, err := c.getPublicKey(ctx, appID)
+	if err != nil {
+		return err
+	}
+	return requireKeyUsage(appID, keyInfo, op)
+}
+
+// requireKeyUsage returns a *KeyUsageError if keyInfo's usage doesn't allow op
+func requireKeyUsage(appID string, keyInfo *PublicKeyInfo, op constants.KeyUsage) error {
+	if keyInfo.Usage.Allows(op) {
+		return nil
+	}
+	return &KeyUsageError{AppID: appID, Operation: op, Allowed: keyInfo.Usage}
+}
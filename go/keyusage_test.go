@@ -0,0 +1,77 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/metrics"
+)
+
+func TestKeyUsage(t *testing.T) {
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "sign-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	addApp(t, deployment, "derive-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := deployment.SetKeyUsage("sign-app", constants.KeyUsageSign); err != nil {
+		t.Fatalf("SetKeyUsage failed: %v", err)
+	}
+	if err := deployment.SetKeyUsage("derive-app", constants.KeyUsageDerive); err != nil {
+		t.Fatalf("SetKeyUsage failed: %v", err)
+	}
+
+	if err := c.CheckKeyUsage("sign-app", constants.KeyUsageSign); err != nil {
+		t.Errorf("Expected a signing key to allow signing, got %v", err)
+	}
+	var usageErr *KeyUsageError
+	if err := c.CheckKeyUsage("sign-app", constants.KeyUsageDerive); !errors.As(err, &usageErr) || !errors.Is(err, ErrKeyUsage) {
+		t.Errorf("Expected a *KeyUsageError for deriving with a signing key, got %v", err)
+	} else if usageErr.Operation != constants.KeyUsageDerive || usageErr.Allowed != constants.KeyUsageSign {
+		t.Errorf("Unexpected key usage error: %+v", usageErr)
+	}
+
+	if _, err := c.Sign(&SignRequest{AppID: "derive-app", Message: []byte("hello")}); !errors.Is(err, ErrKeyUsage) {
+		t.Errorf("Expected signing with a derive-only key to fail with ErrKeyUsage, got %v", err)
+	}
+	if n := len(deployment.SignRequests()); n != 0 {
+		t.Errorf("Expected no TEE sign request for the refused key, got %d", n)
+	}
+}
+
+func TestSignCountedOnlyWhenSigned(t *testing.T) {
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "sign-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	addApp(t, deployment, "derive-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := deployment.SetKeyUsage("derive-app", constants.KeyUsageDerive); err != nil {
+		t.Fatalf("SetKeyUsage failed: %v", err)
+	}
+	counted := func(appID, result string) int {
+		return int(c.metrics.SignRequests.Value(appID, result))
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Sign(&SignRequest{AppID: "sign-app", Message: []byte{byte(i)}}); err != nil {
+			t.Fatalf("Sign failed: %v", err)
+		}
+	}
+	if n := counted("sign-app", metrics.ResultSuccess); n != 3 {
+		t.Errorf("Expected 3 successful signs counted, got %d", n)
+	}
+
+	// Refused keys never reach the TEE and aren't counted at all
+	c.Sign(&SignRequest{AppID: "derive-app", Message: []byte("hello")})
+	if n := counted("derive-app", metrics.ResultSuccess) + counted("derive-app", metrics.ResultError); n != 0 {
+		t.Errorf("Expected the refused sign not to be counted, got %d", n)
+	}
+
+	// TEE failures count as errors only
+	deployment.FailSign(errors.New("TEE unavailable"))
+	if _, err := c.Sign(&SignRequest{AppID: "sign-app", Message: []byte("fails")}); err == nil {
+		t.Fatal("Expected Sign to fail")
+	}
+	if n := counted("sign-app", metrics.ResultSuccess); n != 3 {
+		t.Errorf("Expected the failed sign not to be counted as a success, got %d", n)
+	}
+	if n := counted("sign-app", metrics.ResultError); n != 1 {
+		t.Errorf("Expected 1 failed sign counted, got %d", n)
+	}
+}
@@ -0,0 +1,110 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/acl"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+// KeyLookupOptions selects a historical key of an app. Version wins if both are set;
+// the zero value selects the current key
+type KeyLookupOptions struct {
+	Version uint32    // Key version, as reported in PublicKeyInfo.Version
+	At      time.Time // Select the key that was current at this time, e.g. when a signature was made
+}
+
+// historical reports whether the options select anything other than the current key
+func (opts *KeyLookupOptions) historical() bool {
+	return opts != nil && (opts.Version != 0 || !opts.At.IsZero())
+}
+
+// GetPublicKeyByAppIDWithOptions returns the public key of an app ID, optionally a historical
+// one replaced by RotateKey. Historical keys are fetched from the app node every time and
+// not cached. A nil opts behaves like GetPublicKeyByAppID
+func (c *Client) GetPublicKeyByAppIDWithOptions(appID string, opts *KeyLookupOptions) (*PublicKeyInfo, error) {
+	if !opts.historical() {
+		return c.GetPublicKeyByAppID(appID)
+	}
+	if c.userMgmt() == nil {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	if err := c.authorize(ctx, c.principal, appID, acl.OpGetKey); err != nil {
+		return nil, err
+	}
+
+	return c.lookupPublicKey(ctx, appID, opts)
+}
+
+// VerifyWithOptions verifies a signature against the key selected by opts, so signatures made
+// before a rotation keep verifying after its grace period. A nil opts behaves like Verify
+func (c *Client) VerifyWithOptions(message, signature []byte, appID string, opts *KeyLookupOptions) (bool, error) {
+	if !opts.historical() {
+		return c.Verify(message, signature, appID)
+	}
+	if c.userMgmt() == nil {
+		return false, fmt.Errorf("client not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	if err := c.authorize(ctx, c.principal, appID, acl.OpGetKey); err != nil {
+		return false, err
+	}
+
+	keyInfo, err := c.lookupPublicKey(ctx, appID, opts)
+	if err != nil {
+		return false, err
+	}
+	message = c.domainMessage(appID, message)
+	return verification.VerifySignature(message, keyInfo.Key, signature, keyInfo.Protocol, keyInfo.Curve)
+}
+
+// lookupPublicKey fetches a historical key and checks the app node honoured the lookup
+func (c *Client) lookupPublicKey(ctx context.Context, appID string, opts *KeyLookupOptions) (*PublicKeyInfo, error) {
+	at := opts.At
+	if opts.Version != 0 {
+		at = time.Time{}
+	}
+	keyInfo, err := c.fetchPublicKeyVersion(ctx, appID, opts.Version, at)
+	if err != nil {
+		return nil, err
+	}
+	// App nodes without key history ignore the lookup and return the current key unversioned
+	if keyInfo.Version == 0 {
+		return nil, fmt.Errorf("app node does not support historical key lookup for %s", appID)
+	}
+	if opts.Version != 0 && keyInfo.Version != opts.Version {
+		return nil, fmt.Errorf("app node returned key version %d of %s, requested %d", keyInfo.Version, appID, opts.Version)
+	}
+	if opts.Version == 0 && !keyInfo.validAt(opts.At) {
+		return nil, fmt.Errorf("app node returned key version %d of %s, not valid at %s", keyInfo.Version, appID, opts.At.Format(time.RFC3339))
+	}
+	return keyInfo, nil
+}
+
+// validAt reports whether the key was current at t, per its validity window
+func (k *PublicKeyInfo) validAt(t time.Time) bool {
+	if !k.ValidFrom.IsZero() && t.Before(k.ValidFrom) {
+		return false
+	}
+	return k.ValidUntil.IsZero() || t.Before(k.ValidUntil)
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+)
+
+func TestHistoricalKeyLookup(t *testing.T) {
+	c, deployment := newTestClient(t, func(c *Client) {
+		c.SetKeyRotationGracePeriod(-1)
+	})
+	oldKey := addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	message := []byte("hello")
+	before, err := c.Sign(&SignRequest{AppID: "ed-app", Message: message})
+	if err != nil || !before.Success {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	signedAt := time.Now().Add(-time.Hour)
+	current, err := c.RotateKey(context.Background(), "ed-app")
+	if err != nil {
+		t.Fatalf("RotateKey failed: %v", err)
+	}
+	newKey := current.Key
+
+	for _, tt := range []struct {
+		name string
+		opts *KeyLookupOptions
+		want []byte
+	}{
+		{"current", nil, newKey},
+		{"version 1", &KeyLookupOptions{Version: 1}, oldKey},
+		{"version 2", &KeyLookupOptions{Version: 2}, newKey},
+		{"before the rotation", &KeyLookupOptions{At: signedAt}, oldKey},
+		{"version wins over time", &KeyLookupOptions{Version: 2, At: signedAt}, newKey},
+	} {
+		keyInfo, err := c.GetPublicKeyByAppIDWithOptions("ed-app", tt.opts)
+		if err != nil {
+			t.Errorf("%s: lookup failed: %v", tt.name, err)
+			continue
+		}
+		if !bytes.Equal(keyInfo.Key, tt.want) {
+			t.Errorf("%s: got the wrong key version %d", tt.name, keyInfo.Version)
+		}
+	}
+
+	// The old signature no longer verifies against the current key, only the historical one
+	if valid, _ := c.Verify(message, before.Signature, "ed-app"); valid {
+		t.Error("Expected the old signature not to verify against the current key")
+	}
+	if valid, err := c.VerifyWithOptions(message, before.Signature, "ed-app", &KeyLookupOptions{Version: 1}); err != nil || !valid {
+		t.Errorf("Expected the old signature to verify against version 1: valid=%t err=%v", valid, err)
+	}
+	if valid, err := c.VerifyWithOptions(message, before.Signature, "ed-app", &KeyLookupOptions{At: signedAt}); err != nil || !valid {
+		t.Errorf("Expected the old signature to verify against the key current when it was made: valid=%t err=%v", valid, err)
+	}
+}
+
+func TestHistoricalKeyLookupMissing(t *testing.T) {
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	message := []byte("hello")
+	before, err := c.Sign(&SignRequest{AppID: "ed-app", Message: message})
+	if err != nil || !before.Success {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if _, err := deployment.RotateKey("ed-app"); err != nil {
+		t.Fatalf("RotateKey failed: %v", err)
+	}
+
+	if _, err := c.GetPublicKeyByAppIDWithOptions("ed-app", &KeyLookupOptions{Version: 9}); err == nil {
+		t.Error("Expected an unknown key version to fail")
+	}
+	if err := deployment.PruneKey("ed-app", 1); err != nil {
+		t.Fatalf("PruneKey failed: %v", err)
+	}
+	if _, err := c.GetPublicKeyByAppIDWithOptions("ed-app", &KeyLookupOptions{Version: 1}); err == nil {
+		t.Error("Expected a pruned key version to fail")
+	}
+	if valid, err := c.VerifyWithOptions(message, before.Signature, "ed-app", &KeyLookupOptions{Version: 1}); err == nil || valid {
+		t.Errorf("Expected verification against a pruned version to fail, got valid=%t err=%v", valid, err)
+	}
+}
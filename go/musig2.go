@@ -0,0 +1,377 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/acl"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/audit"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/auth"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/task"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+)
+
+// MuSig2Request asks several apps to sign a message jointly with MuSig2, see SignMuSig2
+type MuSig2Request struct {
+	AppID   string   // Coordinating app; its key is one of the signers
+	Signers []string // Other apps whose keys join the aggregate key; they must be voting targets of AppID
+	Message []byte   // 32-byte message, e.g. a BIP-341 taproot sighash
+	Taproot bool     // Sign for the BIP-86 taproot output key of the aggregate key
+
+	Headers   map[string]string // Forwarded to the other signers' voting handlers
+	Principal *voting.Principal // Who asked for the signature, see SignRequest.Principal
+
+	// Timeout bounds the whole session; the client default applies if unset
+	Timeout time.Duration
+}
+
+// MuSig2Result is the combined signature of a MuSig2 session
+type MuSig2Result struct {
+	Signature    []byte   `json:"signature"`     // 64-byte BIP-340 Schnorr signature
+	AggregateKey []byte   `json:"aggregate_key"` // 32-byte x-only key the signature verifies under
+	InternalKey  []byte   `json:"internal_key"`  // Aggregate key before the taproot tweak; equals AggregateKey without Taproot
+	Signers      []string `json:"signers"`       // Apps that signed, the coordinator first
+}
+
+// SignMuSig2 produces a single Schnorr signature by the aggregate of several apps' SECP256K1 keys
+//
+// Each key stays in the TEE: this client collects a public nonce from every signer, then a
+// partial signature under the aggregate nonce, and combines them. Other signers are asked through
+// their voting handlers over the vote transport, with voting.MuSig2Request bodies; their handlers
+// decide on the message and pass the request to Sign like a vote request. With Taproot the
+// signature is valid for the BIP-86 key-path spend of the aggregate key's taproot output
+func (c *Client) SignMuSig2(req *MuSig2Request) (result *MuSig2Result, err error) {
+	if req == nil {
+		return nil, fmt.Errorf("MuSig2 request cannot be nil")
+	}
+	if req.AppID == "" {
+		return nil, fmt.Errorf("app ID is required")
+	}
+	if len(req.Message) != 32 {
+		return nil, fmt.Errorf("MuSig2 message must be 32 bytes, got %d", len(req.Message))
+	}
+	signers := []string{req.AppID}
+	for _, appID := range req.Signers {
+		if appID == "" || appID == req.AppID {
+			continue
+		}
+		if !slices.Contains(signers, appID) {
+			signers = append(signers, appID)
+		}
+	}
+	if len(signers) < 2 {
+		return nil, fmt.Errorf("MuSig2 needs at least one signer besides %s", req.AppID)
+	}
+	principal := req.Principal
+	if principal == nil {
+		principal = c.principal
+	}
+	if principal != nil {
+		if err := principal.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid principal: %w", err)
+		}
+	}
+
+	defer func() {
+		entry := audit.Entry{AppID: req.AppID, MessageHash: audit.HashBytes(req.Message), Operation: AuditOpMuSig2, Voting: true}
+		if principal != nil {
+			entry.Principal, entry.Justification = principal.ID, principal.Justification
+		}
+		var signature []byte
+		if result != nil {
+			signature = result.Signature
+		}
+		if auditErr := c.auditResult(entry, signature, err); auditErr != nil {
+			result, err = nil, auditErr
+		}
+	}()
+	if err := c.authorize(context.Background(), principal, req.AppID, acl.OpSign); err != nil {
+		return nil, err
+	}
+	if err := c.checkUntaggedSigning(req.AppID); err != nil {
+		return nil, err
+	}
+	done, err := c.beginRequest()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	if err := c.checkRateLimit(req.AppID); err != nil {
+		return nil, err
+	}
+
+	timeout := c.timeout
+	if req.Timeout > 0 {
+		timeout = req.Timeout
+	}
+	ctx, cancel := context.WithTimeout(withRequestStart(context.Background(), time.Now()), timeout)
+	defer cancel()
+	ctx, untrack := c.trackOperation(ctx, OperationMuSig2, req.AppID)
+	defer untrack()
+
+	if err := c.checkSigningPolicy(ctx, req.AppID); err != nil {
+		return nil, err
+	}
+	finish, err := c.authorizeSign(ctx, req.AppID, req.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := maps.Clone(req.Headers)
+	if principal != nil {
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		principal.SetHeaders(headers)
+	}
+	result, err = c.runMuSig2(ctx, req, signers, headers)
+	finish(err == nil)
+	if cause := cancelledOperation(ctx); cause != nil && err != nil {
+		err = cause
+	}
+	return result, err
+}
+
+// runMuSig2 runs both rounds of a MuSig2 session coordinated by req.AppID
+func (c *Client) runMuSig2(ctx context.Context, req *MuSig2Request, signers []string, headers map[string]string) (*MuSig2Result, error) {
+	signConfig, err := c.votingSignConfig(ctx, req.AppID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get voting sign configuration: %w", err)
+	}
+	for _, appID := range signers[1:] {
+		if _, ok := signConfig.Targets[appID]; !ok {
+			return nil, fmt.Errorf("MuSig2 signer %s is not a voting target of %s", appID, req.AppID)
+		}
+	}
+
+	keys, err := c.musig2Keys(ctx, signers)
+	if err != nil {
+		return nil, err
+	}
+	session, err := verification.NewMuSig2Session(keys, req.Message, req.Taproot)
+	if err != nil {
+		return nil, err
+	}
+	sessionID, err := newMuSig2SessionID()
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("✍️  Starting MuSig2 session %s for %s with signers %v", sessionID, req.AppID, signers)
+	setOperationState(ctx, OperationSigning)
+
+	request := voting.MuSig2Request{
+		IsForwarded: true,
+		SignerAppID: req.AppID,
+		SessionID:   sessionID,
+		Signers:     signers,
+		Message:     req.Message,
+		Taproot:     req.Taproot,
+		Timestamp:   time.Now().Unix(),
+	}
+	contributions := func(phase string, aggregateNonce []byte) ([][]byte, error) {
+		request := request
+		request.Phase = phase
+		if aggregateNonce != nil {
+			request.AggregateNonce = hex.EncodeToString(aggregateNonce)
+		}
+
+		type contribution struct {
+			index int
+			data  []byte
+			err   error
+		}
+		results := make(chan contribution, len(signers))
+		for i, appID := range signers {
+			go func() {
+				var data []byte
+				var err error
+				if i == 0 {
+					data, err = c.musig2Step(ctx, appID, keys, keys[0], sessionID, req.Message, req.Taproot, aggregateNonce)
+				} else {
+					data, err = c.voteSender.MuSig2(ctx, signConfig.Targets[appID], &request, headers)
+				}
+				results <- contribution{index: i, data: data, err: err}
+			}()
+		}
+
+		out := make([][]byte, len(signers))
+		var firstErr error
+		for range signers {
+			result := <-results
+			if result.err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("MuSig2 %s from %s failed: %w", phase, signers[result.index], result.err)
+			}
+			out[result.index] = result.data
+		}
+		return out, firstErr
+	}
+
+	nonces, err := contributions(voting.PhaseMuSig2Nonce, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i, nonce := range nonces {
+		if err := verification.CheckMuSig2Nonce(nonce); err != nil {
+			return nil, fmt.Errorf("MuSig2 nonce from %s: %w", signers[i], err)
+		}
+	}
+	aggregateNonce, err := session.AggregateNonces(nonces)
+	if err != nil {
+		return nil, err
+	}
+
+	partials, err := contributions(voting.PhaseMuSig2Sign, aggregateNonce)
+	if err != nil {
+		return nil, err
+	}
+	for i, partial := range partials {
+		if err := session.VerifyPartial(i, partial); err != nil {
+			return nil, fmt.Errorf("MuSig2 partial signature from %s: %w", signers[i], err)
+		}
+	}
+	signature, err := session.Combine(partials)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("✅ MuSig2 session %s signed by %d apps", sessionID, len(signers))
+	return &MuSig2Result{
+		Signature:    signature,
+		AggregateKey: session.AggregateKey(),
+		InternalKey:  session.InternalKey(),
+		Signers:      signers,
+	}, nil
+}
+
+// receiveMuSig2 answers another app's request for this app's MuSig2 contribution
+func (c *Client) receiveMuSig2(ctx context.Context, appID string, request *voting.MuSig2Request, approved bool) *SignResult {
+	fail := func(err error) *SignResult {
+		log.Printf("❌ MuSig2 %s for session %s of %s refused: %v", request.Phase, request.SessionID, request.SignerAppID, err)
+		return &SignResult{Success: false, Error: err.Error(), MuSig2: request}
+	}
+	if !approved {
+		return fail(fmt.Errorf("vote rejected"))
+	}
+	switch {
+	case !request.Includes(appID):
+		return fail(fmt.Errorf("%s is not a signer of the session", appID))
+	case !request.Includes(request.SignerAppID):
+		return fail(fmt.Errorf("coordinator %s is not a signer of the session", request.SignerAppID))
+	case len(request.Message) != 32:
+		return fail(fmt.Errorf("MuSig2 message must be 32 bytes, got %d", len(request.Message)))
+	}
+	if err := c.checkUntaggedSigning(appID); err != nil {
+		return fail(err)
+	}
+
+	var aggregateNonce []byte
+	if request.Phase == voting.PhaseMuSig2Sign {
+		var err error
+		aggregateNonce, err = hex.DecodeString(request.AggregateNonce)
+		if err != nil || verification.CheckMuSig2Nonce(aggregateNonce) != nil {
+			return fail(fmt.Errorf("invalid aggregate nonce"))
+		}
+	}
+
+	keys, err := c.musig2Keys(ctx, request.Signers)
+	if err != nil {
+		return fail(err)
+	}
+	var own []byte
+	for i, signer := range request.Signers {
+		if signer == appID {
+			own = keys[i]
+		}
+	}
+	contribution, err := c.musig2Step(ctx, appID, keys, own, request.SessionID, request.Message, request.Taproot, aggregateNonce)
+	if err != nil {
+		return fail(err)
+	}
+	log.Printf("✍️  MuSig2 %s for session %s of %s sent", request.Phase, request.SessionID, request.SignerAppID)
+	return &SignResult{Success: true, Signature: contribution, MuSig2: request}
+}
+
+// musig2Step runs one step of a MuSig2 session with appID's key in the TEE: the public nonce
+// without aggregateNonce, the partial signature with it
+func (c *Client) musig2Step(ctx context.Context, appID string, keys [][]byte, key []byte, sessionID string, message []byte, taproot bool, aggregateNonce []byte) ([]byte, error) {
+	taskClient := c.tee()
+	if taskClient == nil {
+		return nil, fmt.Errorf("client not initialized")
+	}
+	if err := c.enforceSigningPolicy(ctx, appID); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	contribution, err := taskClient.SignWithOptions(auth.WithAppID(ctx, appID), message, key, constants.ProtocolMuSig2, constants.CurveSECP256K1, &task.SignOptions{
+		MuSig2: &task.MuSig2Step{
+			SessionID:      sessionID,
+			Signers:        keys,
+			Taproot:        taproot,
+			AggregateNonce: aggregateNonce,
+		},
+	})
+	c.metrics.ObserveSign(appID, start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if aggregateNonce == nil {
+		err = verification.CheckMuSig2Nonce(contribution)
+	} else if len(contribution) != verification.MuSig2PartialSignatureSize {
+		err = fmt.Errorf("MuSig2 partial signature must be %d bytes, got %d", verification.MuSig2PartialSignatureSize, len(contribution))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSignatureMismatch, err)
+	}
+	return contribution, nil
+}
+
+// musig2Keys looks up the public keys of MuSig2 signers, which must be Schnorr SECP256K1 keys
+func (c *Client) musig2Keys(ctx context.Context, signers []string) ([][]byte, error) {
+	keys := make([][]byte, len(signers))
+	for i, appID := range signers {
+		keyInfo, err := c.getPublicKey(ctx, appID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get public key of %s: %w", appID, err)
+		}
+		if keyInfo.Protocol != constants.ProtocolSchnorr || keyInfo.Curve != constants.CurveSECP256K1 {
+			return nil, fmt.Errorf("MuSig2 signer %s must use a Schnorr secp256k1 key, got %s/%s", appID, keyInfo.Protocol, keyInfo.Curve)
+		}
+		if err := requireKeyUsage(appID, keyInfo, constants.KeyUsageSign); err != nil {
+			return nil, err
+		}
+		keys[i] = keyInfo.Key
+	}
+	return keys, nil
+}
+
+// newMuSig2SessionID returns a random MuSig2 session ID
+func newMuSig2SessionID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate MuSig2 session ID: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
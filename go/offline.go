@@ -0,0 +1,231 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/audit"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/offline"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/task"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Offline queue defaults
+const (
+	DefaultOfflineExpiry        = time.Hour
+	DefaultOfflineFlushInterval = 5 * time.Second
+)
+
+// OfflineConfig configures store-and-forward signing, see EnableOfflineQueue
+type OfflineConfig struct {
+	Store         offline.Store // Where queued requests are kept, e.g. offline.NewFileStore for durability
+	Expiry        time.Duration // How long a request may wait by default (SignRequest.QueueExpiry overrides)
+	FlushInterval time.Duration // How often queued requests are retried
+
+	// OnResult is called once for every queued request: with the signature once it is
+	// forwarded, or with an error if the TEE rejected it or it expired (ErrOfflineRequestExpired)
+	OnResult func(id string, result *SignResult, err error)
+}
+
+// offlineQueue is the client's store-and-forward state
+type offlineQueue struct {
+	config  OfflineConfig
+	flushMu sync.Mutex    // one flush at a time
+	stop    chan struct{} // closes to stop the flusher; guarded by Client.initMu
+}
+
+// EnableOfflineQueue turns on store-and-forward mode for direct (non-voting) signing
+//
+// When a sign request fails because the TEE or user management service is unreachable,
+// it is saved to the store and Sign returns a *QueuedError (errors.Is ErrQueuedOffline)
+// carrying the request ID. A background flusher forwards queued requests in order once
+// connectivity returns and reports each outcome to OnResult. Requests still queued from a
+// previous run are picked up after Init. Must be called before Init
+func (c *Client) EnableOfflineQueue(config OfflineConfig) error {
+	if config.Store == nil {
+		return fmt.Errorf("offline queue requires a store")
+	}
+	if config.Expiry <= 0 {
+		config.Expiry = DefaultOfflineExpiry
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = DefaultOfflineFlushInterval
+	}
+	c.offline = &offlineQueue{config: config}
+	return nil
+}
+
+// FlushOfflineQueue forwards queued requests now instead of waiting for the next flush
+// It stops at the first request that fails because the TEE is still unreachable
+func (c *Client) FlushOfflineQueue() error {
+	if c.offline == nil {
+		return fmt.Errorf("offline queue not enabled")
+	}
+	q := c.offline
+	q.flushMu.Lock()
+	defer q.flushMu.Unlock()
+
+	requests, err := q.config.Store.List()
+	if err != nil {
+		return fmt.Errorf("failed to list offline requests: %w", err)
+	}
+
+	for _, req := range requests {
+		if req.Expired(time.Now()) {
+			log.Printf("⌛ Offline request %s for app %s expired", req.ID, req.AppID)
+			c.auditResult(offlineAuditEntry(req), nil, ErrOfflineRequestExpired)
+			c.finishOffline(req.ID, nil, ErrOfflineRequestExpired)
+			continue
+		}
+
+		done, err := c.beginRequest()
+		if err != nil {
+			return err
+		}
+		// Signing policy time-locks count from when the request was originally made
+		ctx, cancel := context.WithTimeout(withRequestStart(context.Background(), req.CreatedAt), c.timeout)
+		ctx, untrack := c.trackOperation(ctx, OperationSign, req.AppID)
+		finish, err := c.authorizeSign(ctx, req.AppID, req.Message)
+		var signature []byte
+		if err == nil {
+			signature, err = c.signWithAppID(ctx, req.Message, req.AppID, &task.SignOptions{
+				ED25519Mode:    req.ED25519Mode,
+				ED25519Context: req.ED25519Context,
+				Priority:       task.Priority(req.Priority),
+			})
+			finish(err == nil)
+		}
+		untrack()
+		cancel()
+		done()
+
+		if err != nil && isUnavailable(err) {
+			// Still offline: keep this and the remaining requests queued
+			return err
+		}
+		if auditErr := c.auditResult(offlineAuditEntry(req), signature, err); auditErr != nil {
+			signature, err = nil, auditErr
+		}
+		if err != nil {
+			log.Printf("❌ Offline request %s for app %s failed: %v", req.ID, req.AppID, err)
+			c.finishOffline(req.ID, &SignResult{Success: false, Error: err.Error()}, err)
+			continue
+		}
+		log.Printf("📤 Offline request %s for app %s signed", req.ID, req.AppID)
+		c.finishOffline(req.ID, &SignResult{Signature: signature, Success: true}, nil)
+	}
+	return nil
+}
+
+// offlineAuditEntry describes a queued request in the audit log
+func offlineAuditEntry(req *offline.Request) audit.Entry {
+	return audit.Entry{
+		AppID:       req.AppID,
+		MessageHash: audit.HashBytes(req.Message),
+		Operation:   AuditOpOffline,
+		QueuedID:    req.ID,
+	}
+}
+
+// queueOffline stores req if offline mode is enabled and cause means the TEE is unreachable
+// It returns the queued request's ID
+func (c *Client) queueOffline(req *SignRequest, cause error) (string, bool) {
+	if c.offline == nil || !isUnavailable(cause) {
+		return "", false
+	}
+
+	id, err := offline.NewID()
+	if err != nil {
+		log.Printf("⚠️  Failed to queue offline request: %v", err)
+		return "", false
+	}
+	expiry := c.offline.config.Expiry
+	if req.QueueExpiry > 0 {
+		expiry = req.QueueExpiry
+	}
+	now := time.Now()
+	pending := &offline.Request{
+		ID:             id,
+		AppID:          req.AppID,
+		Message:        req.Message,
+		ED25519Mode:    req.ED25519Mode,
+		ED25519Context: req.ED25519Context,
+		Priority:       int(req.Priority),
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(expiry),
+	}
+	if err := c.offline.config.Store.Put(pending); err != nil {
+		log.Printf("⚠️  Failed to queue offline request: %v", err)
+		return "", false
+	}
+	log.Printf("📥 TEE unreachable, queued request %s for app %s (expires in %s)", id, req.AppID, expiry)
+	return id, true
+}
+
+// finishOffline removes a request from the store and reports its outcome
+func (c *Client) finishOffline(id string, result *SignResult, err error) {
+	if delErr := c.offline.config.Store.Delete(id); delErr != nil && !errors.Is(delErr, offline.ErrNotFound) {
+		log.Printf("⚠️  Failed to remove offline request %s: %v", id, delErr)
+	}
+	if c.offline.config.OnResult != nil {
+		c.offline.config.OnResult(id, result, err)
+	}
+}
+
+// startOfflineFlusher starts the background flusher if offline mode is enabled
+// Callers must hold initMu
+func (c *Client) startOfflineFlusher() {
+	if c.offline == nil || c.offline.stop != nil {
+		return
+	}
+	stop := make(chan struct{})
+	c.offline.stop = stop
+
+	go func() {
+		ticker := time.NewTicker(c.offline.config.FlushInterval)
+		defer ticker.Stop()
+		for {
+			if err := c.FlushOfflineQueue(); err != nil && !errors.Is(err, ErrShuttingDown) && !isUnavailable(err) {
+				log.Printf("⚠️  Offline queue flush failed: %v", err)
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// stopOfflineFlusher stops the background flusher; queued requests stay in the store
+// Callers must hold initMu
+func (c *Client) stopOfflineFlusher() {
+	if c.offline == nil || c.offline.stop == nil {
+		return
+	}
+	close(c.offline.stop)
+	c.offline.stop = nil
+}
+
+// isUnavailable reports whether err means a server could not be reached
+func isUnavailable(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
@@ -0,0 +1,254 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// OperationKind identifies what an in-flight operation does
+type OperationKind string
+
+// Operation kinds
+const (
+	OperationSign        OperationKind = "sign"         // Signing without a voting round
+	OperationVotingRound OperationKind = "voting_round" // Signing after a voting round
+	OperationMuSig2      OperationKind = "musig2"       // MuSig2 session across app keys
+)
+
+// OperationState is how far an in-flight operation got
+type OperationState string
+
+// Operation states
+const (
+	OperationAdmitted   OperationState = "admitted"    // Checking policies and preparing
+	OperationVoting     OperationState = "voting"      // Waiting for votes
+	OperationTimeLocked OperationState = "time_locked" // Waiting out a signing policy delay
+	OperationSigning    OperationState = "signing"     // Waiting for the TEE
+	OperationCommitting OperationState = "committing"  // Notifying voters of the signature
+)
+
+// ErrOperationCancelled is matched by errors.Is for requests cancelled with
+// Client.CancelOperation
+var ErrOperationCancelled = errors.New("operation cancelled")
+
+// ErrOperationNotFound is returned by Client.CancelOperation for unknown or finished operations
+var ErrOperationNotFound = errors.New("operation not found")
+
+// PendingOperation is a snapshot of an in-flight sign request or voting round
+type PendingOperation struct {
+	ID        string         `json:"id"`
+	Kind      OperationKind  `json:"kind"`
+	AppID     string         `json:"app_id"`
+	State     OperationState `json:"state"`
+	StartedAt time.Time      `json:"started_at"`
+	Age       time.Duration  `json:"-"`
+	Deadline  time.Time      `json:"deadline,omitzero"`
+
+	// Voting progress, once the operation started collecting votes
+	RoundID       string   `json:"round_id,omitempty"` // See CancelVotingRound
+	RequiredVotes int      `json:"required_votes,omitempty"`
+	Approvals     int      `json:"approvals,omitempty"`
+	PendingVoters []string `json:"pending_voters,omitempty"` // Targets that haven't answered yet
+}
+
+// operation is the live record behind a PendingOperation
+type operation struct {
+	id      string
+	kind    OperationKind
+	appID   string
+	started time.Time
+	cancel  context.CancelCauseFunc
+	ctx     context.Context
+
+	mu            sync.Mutex
+	state         OperationState
+	roundID       string
+	requiredVotes int
+	approvals     int
+	pendingVoters []string
+}
+
+// operationKey is the context key holding the operation a request belongs to
+type operationKey struct{}
+
+// PendingOperations returns the sign requests and voting rounds in flight, oldest first
+func (c *Client) PendingOperations() []PendingOperation {
+	c.operationsMu.Lock()
+	ops := make([]*operation, 0, len(c.operations))
+	for _, op := range c.operations {
+		ops = append(ops, op)
+	}
+	c.operationsMu.Unlock()
+
+	now := time.Now()
+	pending := make([]PendingOperation, 0, len(ops))
+	for _, op := range ops {
+		op.mu.Lock()
+		p := PendingOperation{
+			ID:            op.id,
+			Kind:          op.kind,
+			AppID:         op.appID,
+			State:         op.state,
+			StartedAt:     op.started,
+			Age:           now.Sub(op.started),
+			RoundID:       op.roundID,
+			RequiredVotes: op.requiredVotes,
+			Approvals:     op.approvals,
+			PendingVoters: slices.Clone(op.pendingVoters),
+		}
+		op.mu.Unlock()
+		p.Deadline, _ = op.ctx.Deadline()
+		pending = append(pending, p)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].StartedAt.Before(pending[j].StartedAt) })
+	return pending
+}
+
+// CancelOperation cancels an in-flight operation by ID; its caller gets an error matching
+// ErrOperationCancelled. Votes already requested are abandoned and no signature is produced
+// unless the TEE was already signing
+func (c *Client) CancelOperation(id string) error {
+	c.operationsMu.Lock()
+	op, ok := c.operations[id]
+	c.operationsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrOperationNotFound, id)
+	}
+
+	log.Printf("🚫 Cancelling operation %s (%s for app %s)", id, op.kind, op.appID)
+	op.cancel(fmt.Errorf("%w: %s", ErrOperationCancelled, id))
+	return nil
+}
+
+// OperationsHandler returns an http.Handler for debugging stuck requests: GET lists
+// PendingOperations as JSON and DELETE ?id=<id> cancels one. Mount it on an admin-only listener
+func (c *Client) OperationsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Cache-Control", "no-store")
+			json.NewEncoder(w).Encode(c.PendingOperations())
+		case http.MethodDelete:
+			err := c.CancelOperation(r.URL.Query().Get("id"))
+			if errors.Is(err, ErrOperationNotFound) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("Allow", "GET, DELETE")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// trackOperation registers an in-flight operation bound to a cancellable child of ctx;
+// the returned function must be called when it completes
+func (c *Client) trackOperation(ctx context.Context, kind OperationKind, appID string) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	op := &operation{
+		id:      fmt.Sprintf("op-%d", c.nextOperation.Add(1)),
+		kind:    kind,
+		appID:   appID,
+		started: time.Now(),
+		cancel:  cancel,
+		state:   OperationAdmitted,
+	}
+	op.ctx = context.WithValue(ctx, operationKey{}, op)
+
+	c.operationsMu.Lock()
+	if c.operations == nil {
+		c.operations = make(map[string]*operation)
+	}
+	c.operations[op.id] = op
+	c.operationsMu.Unlock()
+
+	return op.ctx, func() {
+		c.operationsMu.Lock()
+		delete(c.operations, op.id)
+		c.operationsMu.Unlock()
+		cancel(nil)
+	}
+}
+
+// cancelledOperation returns the cause if ctx's operation was cancelled with CancelOperation
+func cancelledOperation(ctx context.Context) error {
+	if cause := context.Cause(ctx); errors.Is(cause, ErrOperationCancelled) {
+		return cause
+	}
+	return nil
+}
+
+// operationFrom returns the operation ctx belongs to, or nil
+func operationFrom(ctx context.Context) *operation {
+	op, _ := ctx.Value(operationKey{}).(*operation)
+	return op
+}
+
+// setOperationState records the state of ctx's operation, if any
+func setOperationState(ctx context.Context, state OperationState) {
+	if op := operationFrom(ctx); op != nil {
+		op.mu.Lock()
+		op.state = state
+		op.mu.Unlock()
+	}
+}
+
+// startOperationVoting records that ctx's operation waits for votes from targets in roundID
+func startOperationVoting(ctx context.Context, roundID string, targets []string, requiredVotes int) {
+	if op := operationFrom(ctx); op != nil {
+		op.mu.Lock()
+		op.state = OperationVoting
+		op.roundID = roundID
+		if op.kind == OperationSign {
+			op.kind = OperationVotingRound
+		}
+		op.requiredVotes = requiredVotes
+		op.pendingVoters = slices.Clone(targets)
+		op.mu.Unlock()
+	}
+}
+
+// recordOperationVote records a target's answer in ctx's operation
+func recordOperationVote(ctx context.Context, appID string, approved bool) {
+	if op := operationFrom(ctx); op != nil {
+		op.mu.Lock()
+		op.pendingVoters = slices.DeleteFunc(op.pendingVoters, func(target string) bool { return target == appID })
+		if approved {
+			op.approvals++
+		}
+		op.mu.Unlock()
+	}
+}
+
+// voteProgressKey holds the SignRequest.OnVote callback in a request's context
+type voteProgressKey struct{}
+
+// reportVote passes a vote to the OnVote callback of ctx's request, if any
+func reportVote(ctx context.Context, detail VoteDetail) {
+	if onVote, ok := ctx.Value(voteProgressKey{}).(func(VoteDetail)); ok {
+		onVote(detail)
+	}
+}
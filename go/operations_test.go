@@ -0,0 +1,94 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/votingtest"
+)
+
+func TestCancelOperation(t *testing.T) {
+	network := votingtest.NewNetwork("ed-app", "alice", "bob")
+	defer network.Close()
+	network.SetBehavior("bob", votingtest.Drop())
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := deployment.SetVoting("ed-app", network, 3, nil); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+	if ops := c.PendingOperations(); len(ops) != 0 {
+		t.Fatalf("Expected no pending operations, got %+v", ops)
+	}
+
+	signErr := make(chan error, 1)
+	go func() {
+		_, err := c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("pay 10"), EnableVoting: true, LocalApproval: true, VoteRequestData: []byte(`{"amount":10}`), Timeout: 10 * time.Second})
+		signErr <- err
+	}()
+
+	// The round is listed once alice approved and it only waits for bob
+	var op PendingOperation
+	waitFor(t, func() bool {
+		ops := c.PendingOperations()
+		if len(ops) == 1 && ops[0].Approvals == 2 {
+			op = ops[0]
+			return true
+		}
+		return false
+	})
+	if op.Kind != OperationVotingRound || op.AppID != "ed-app" || op.State != OperationVoting || op.RequiredVotes != 3 {
+		t.Errorf("Unexpected pending operation: %+v", op)
+	}
+	if !slices.Equal(op.PendingVoters, []string{"bob"}) || op.Deadline.IsZero() {
+		t.Errorf("Expected the round to wait for bob until its deadline, got %+v", op)
+	}
+
+	if err := c.CancelOperation(op.ID); err != nil {
+		t.Fatalf("CancelOperation failed: %v", err)
+	}
+	select {
+	case err := <-signErr:
+		if !errors.Is(err, ErrOperationCancelled) {
+			t.Errorf("Expected ErrOperationCancelled from Sign, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Sign did not return after CancelOperation")
+	}
+	if n := len(deployment.SignRequests()); n != 0 {
+		t.Errorf("Expected no TEE sign request for the cancelled round, got %d", n)
+	}
+	if ops := c.PendingOperations(); len(ops) != 0 {
+		t.Errorf("Expected the cancelled operation to be gone, got %+v", ops)
+	}
+
+	// Finished and unknown operations can't be cancelled
+	for _, id := range []string{op.ID, "op-unknown"} {
+		if err := c.CancelOperation(id); !errors.Is(err, ErrOperationNotFound) {
+			t.Errorf("%s: expected ErrOperationNotFound, got %v", id, err)
+		}
+	}
+}
+
+func TestOperationsHandler(t *testing.T) {
+	c, _ := newTestClient(t)
+	handler := c.OperationsHandler()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/operations", nil))
+	var ops []PendingOperation
+	if err := json.Unmarshal(recorder.Body.Bytes(), &ops); err != nil || recorder.Code != http.StatusOK || len(ops) != 0 {
+		t.Errorf("Expected an empty list, got %d %q", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, "/operations?id=op-unknown", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown operation, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,73 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+)
+
+// VotePayloadTarget is the voting target a vote request body is about to be sent to
+type VotePayloadTarget struct {
+	AppID       string // App receiving the request
+	SignerAppID string // App whose voting round this is
+	DelegatedBy string // App that delegated its vote to AppID, if the request follows a delegation
+}
+
+// VotePayloadTransformer returns the vote request body to send to target in place of data,
+// e.g. with fields redacted for lower-trust peers or target-specific metadata added
+type VotePayloadTransformer func(target VotePayloadTarget, data []byte) ([]byte, error)
+
+// SetVotePayloadTransformer tailors the vote request body sent to each voting target, delegates
+// included; nil sends every target the same body. The transformer gets the body as forwarded and
+// must return a JSON object; the forwarding and voting phase fields are set again afterwards.
+// Commit and abort notifications are not transformed. Safe for concurrent use
+func (c *Client) SetVotePayloadTransformer(transform VotePayloadTransformer) {
+	c.votePayloadMu.Lock()
+	defer c.votePayloadMu.Unlock()
+	c.votePayload = transform
+}
+
+// forwardedVoteData marks a vote request body as forwarded by the coordinating client
+func (c *Client) forwardedVoteData(data, message []byte) ([]byte, error) {
+	if c.voteCommit != nil {
+		return voting.MarkRequestAsPrepare(data, message)
+	}
+	return voting.MarkRequestAsForwarded(data)
+}
+
+// votePayloadFor returns request with its body tailored to target by the vote payload transformer
+func (c *Client) votePayloadFor(request *voting.VoteRequest, target VotePayloadTarget) (*voting.VoteRequest, error) {
+	c.votePayloadMu.RLock()
+	transform := c.votePayload
+	c.votePayloadMu.RUnlock()
+	if transform == nil {
+		return request, nil
+	}
+
+	data, err := transform(target, request.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform vote request for %s: %w", target.AppID, err)
+	}
+	if data, err = c.forwardedVoteData(data, request.Message); err != nil {
+		return nil, fmt.Errorf("transformed vote request for %s: %w", target.AppID, err)
+	}
+	if err := voting.CheckPayloadSize("vote request", len(data), c.maxVoteRequestSize); err != nil {
+		return nil, fmt.Errorf("transformed vote request for %s: %w", target.AppID, err)
+	}
+	tailored := *request
+	tailored.Data = data
+	return &tailored, nil
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/votingtest"
+)
+
+func TestVotePayloadTransformer(t *testing.T) {
+	network := votingtest.NewNetwork("ed-app", "alice", "bob")
+	defer network.Close()
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := deployment.SetVoting("ed-app", network, 3, nil); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+	// bob is told who the request is for, without the amount
+	c.SetVotePayloadTransformer(func(target VotePayloadTarget, data []byte) ([]byte, error) {
+		var body map[string]any
+		if err := json.Unmarshal(data, &body); err != nil {
+			return nil, err
+		}
+		body["for"] = target.AppID
+		if target.AppID == "bob" {
+			delete(body, "amount")
+		}
+		return json.Marshal(body)
+	})
+	message := []byte("pay 10")
+
+	result, err := c.Sign(&SignRequest{AppID: "ed-app", Message: message, EnableVoting: true, LocalApproval: true, VoteRequestData: []byte(`{"amount":10}`)})
+	if err != nil || !result.Success {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	for _, peer := range []string{"alice", "bob"} {
+		requests := network.Peer(peer).Requests()
+		if len(requests) != 1 {
+			t.Fatalf("%s: expected 1 vote request, got %d", peer, len(requests))
+		}
+		var body map[string]any
+		if err := json.Unmarshal(requests[0].Body, &body); err != nil {
+			t.Fatalf("%s: invalid vote request body: %v", peer, err)
+		}
+		_, hasAmount := body["amount"]
+		if body["for"] != peer || hasAmount != (peer == "alice") || body["is_forwarded"] != true {
+			t.Errorf("%s: unexpected vote request body %s", peer, requests[0].Body)
+		}
+	}
+	// The signed message is the original one
+	if requests := deployment.SignRequests(); len(requests) != 1 || !bytes.Equal(requests[0].Msg, message) {
+		t.Error("Expected the TEE to sign the untransformed message")
+	}
+}
+
+func TestVotePayloadTransformerError(t *testing.T) {
+	network := votingtest.NewNetwork("ed-app", "alice", "bob")
+	defer network.Close()
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := deployment.SetVoting("ed-app", network, 3, nil); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+	c.SetVotePayloadTransformer(func(target VotePayloadTarget, data []byte) ([]byte, error) {
+		if target.AppID == "bob" {
+			return nil, errors.New("no payload for bob")
+		}
+		return data, nil
+	})
+
+	result, _ := c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("pay 10"), EnableVoting: true, LocalApproval: true, VoteRequestData: []byte(`{"amount":10}`)})
+	if result == nil || result.Success {
+		t.Fatalf("Expected the round to fail without bob's vote, got %+v", result)
+	}
+	if n := len(network.Peer("bob").Requests()); n != 0 {
+		t.Errorf("Expected no vote request to bob, got %d", n)
+	}
+	found := false
+	for _, detail := range result.VotingInfo.VoteDetails {
+		if detail.ClientID == "bob" {
+			found = !detail.Success && strings.Contains(detail.Error, "no payload for bob")
+		}
+	}
+	if !found {
+		t.Errorf("Expected bob's vote to report the transformer error, got %+v", result.VotingInfo.VoteDetails)
+	}
+	if n := len(deployment.SignRequests()); n != 0 {
+		t.Errorf("Expected no TEE sign request, got %d", n)
+	}
+}
@@ -0,0 +1,146 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package acl restricts which callers may sign with, vote for or look up the keys of which app
+// IDs. The client checks it before any network call
+package acl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Operation is what a caller wants to do with an app ID
+type Operation string
+
+// Operations checked by the client
+const (
+	OpSign   Operation = "sign"   // Sign, directly or through a voting round
+	OpVote   Operation = "vote"   // Have the client answer a vote request of another app
+	OpGetKey Operation = "getkey" // Look up or verify against an app's public key
+)
+
+// Anyone matches every caller, including ones that named no principal
+const Anyone = "*"
+
+// ErrDenied is matched by errors.Is for requests the ACL refuses
+var ErrDenied = errors.New("access denied")
+
+// Authorizer decides whether a caller may perform an operation on an app ID
+type Authorizer interface {
+	// Authorize returns nil if principal (empty for callers that named none) may perform op on
+	// appID. Refusals should match ErrDenied
+	Authorize(ctx context.Context, principal, appID string, op Operation) error
+}
+
+// Func adapts a callback to an Authorizer
+type Func func(ctx context.Context, principal, appID string, op Operation) error
+
+// Authorize calls f
+func (f Func) Authorize(ctx context.Context, principal, appID string, op Operation) error {
+	return f(ctx, principal, appID, op)
+}
+
+// Rule grants principals operations on app IDs. Principals and app IDs are exact names,
+// prefixes ending in "*" (e.g. "svc:*") or Anyone
+type Rule struct {
+	Principals []string    `json:"principals"`
+	AppIDs     []string    `json:"app_ids"`
+	Operations []Operation `json:"operations"` // Empty grants every operation
+}
+
+// List is a static ACL: a request is allowed if any rule grants it
+type List struct {
+	Rules []Rule `json:"rules"`
+}
+
+// LoadFile reads a JSON ACL, e.g.
+//
+//	{"rules": [{"principals": ["alice@example.com", "svc:*"], "app_ids": ["treasury"], "operations": ["sign", "getkey"]}]}
+func LoadFile(path string) (*List, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ACL file: %w", err)
+	}
+	list, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ACL file %s: %w", path, err)
+	}
+	return list, nil
+}
+
+// Parse decodes and validates a JSON ACL
+func Parse(data []byte) (*List, error) {
+	var list List
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	if err := list.Validate(); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// Validate rejects rules that can never match or name unknown operations
+func (l *List) Validate() error {
+	for i, rule := range l.Rules {
+		if len(rule.Principals) == 0 || len(rule.AppIDs) == 0 {
+			return fmt.Errorf("rule %d needs principals and app IDs", i)
+		}
+		for _, op := range rule.Operations {
+			if op != OpSign && op != OpVote && op != OpGetKey {
+				return fmt.Errorf("rule %d has unknown operation %q", i, op)
+			}
+		}
+	}
+	return nil
+}
+
+// Authorize implements Authorizer
+func (l *List) Authorize(ctx context.Context, principal, appID string, op Operation) error {
+	for _, rule := range l.Rules {
+		if rule.allows(principal, appID, op) {
+			return nil
+		}
+	}
+	if principal == "" {
+		principal = "anonymous caller"
+	}
+	return fmt.Errorf("%w: %s may not %s for app %s", ErrDenied, principal, op, appID)
+}
+
+// allows reports whether the rule grants op on appID to principal
+func (r *Rule) allows(principal, appID string, op Operation) bool {
+	if len(r.Operations) > 0 && !slices.Contains(r.Operations, op) {
+		return false
+	}
+	return matchAny(r.Principals, principal) && matchAny(r.AppIDs, appID)
+}
+
+// matchAny reports whether value matches one of patterns
+func matchAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if pattern == Anyone || pattern == value {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && value != "" && strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package acl
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testACL = `{"rules": [
+	{"principals": ["alice@example.com"], "app_ids": ["treasury", "ops-*"], "operations": ["sign"]},
+	{"principals": ["svc:*"], "app_ids": ["metrics"]},
+	{"principals": ["*"], "app_ids": ["treasury"], "operations": ["getkey"]}
+]}`
+
+func TestListAuthorize(t *testing.T) {
+	list, err := Parse([]byte(testACL))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		principal, appID string
+		op               Operation
+		allowed          bool
+	}{
+		{"alice@example.com", "treasury", OpSign, true},
+		{"alice@example.com", "ops-deploy", OpSign, true},
+		{"alice@example.com", "treasury", OpVote, false},
+		{"alice@example.com", "metrics", OpSign, false},
+		{"bob@example.com", "treasury", OpSign, false},
+		{"svc:collector", "metrics", OpVote, true},
+		{"svc:collector", "metrics", OpGetKey, true},
+		{"svc:collector", "treasury", OpSign, false},
+		{"", "treasury", OpGetKey, true},
+		{"", "treasury", OpSign, false},
+		{"", "ops-", OpSign, false},
+	}
+	for _, tt := range tests {
+		err := list.Authorize(context.Background(), tt.principal, tt.appID, tt.op)
+		if tt.allowed && err != nil {
+			t.Errorf("%q %s %s: unexpected refusal %v", tt.principal, tt.op, tt.appID, err)
+		}
+		if !tt.allowed && !errors.Is(err, ErrDenied) {
+			t.Errorf("%q %s %s: expected ErrDenied, got %v", tt.principal, tt.op, tt.appID, err)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, data := range []string{
+		`{"rules": [{"principals": ["alice"], "app_ids": ["a"], "operations": ["delete"]}]}`,
+		`{"rules": [{"app_ids": ["a"]}]}`,
+		`{"rules": [{"principals": ["alice"]}]}`,
+		`not json`,
+	} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("Expected error for %s", data)
+		}
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "acl.json")
+	if err := os.WriteFile(path, []byte(testACL), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	list, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	if len(list.Rules) != 3 {
+		t.Errorf("Expected 3 rules, got %d", len(list.Rules))
+	}
+	if _, err := LoadFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+}
+
+func TestFunc(t *testing.T) {
+	var authorizer Authorizer = Func(func(ctx context.Context, principal, appID string, op Operation) error {
+		if principal == "root" {
+			return nil
+		}
+		return ErrDenied
+	})
+	if err := authorizer.Authorize(context.Background(), "root", "any", OpSign); err != nil {
+		t.Errorf("Expected root to be allowed, got %v", err)
+	}
+	if err := authorizer.Authorize(context.Background(), "guest", "any", OpSign); !errors.Is(err, ErrDenied) {
+		t.Errorf("Expected ErrDenied, got %v", err)
+	}
+}
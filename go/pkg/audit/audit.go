@@ -0,0 +1,185 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package audit keeps an append-only, hash-chained log of sign operations, so what was signed
+// and when can be reconstructed and any edit or gap in the log detected
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GenesisHash is the previous hash of the first entry of a chain
+var GenesisHash = strings.Repeat("0", 2*sha256.Size)
+
+// ErrTampered is matched by errors.Is for entries that break the hash chain
+var ErrTampered = errors.New("audit chain broken")
+
+// Entry records one sign operation
+type Entry struct {
+	Seq  uint64    `json:"seq"`
+	Time time.Time `json:"time"`
+
+	AppID       string `json:"app_id"`
+	MessageHash string `json:"message_hash"` // Hex SHA-256 of the signed message
+	Operation   string `json:"operation"`    // e.g. "sign", "sign_ethereum"
+	Voting      bool   `json:"voting,omitempty"`
+
+	// Principal and Justification are who asked for the signature and why, as the caller stated
+	Principal     string `json:"principal,omitempty"`
+	Justification string `json:"justification,omitempty"`
+
+	// Metadata describes the caller, e.g. the headers of the HTTP request behind a voting round
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	Success       bool   `json:"success"`
+	Error         string `json:"error,omitempty"`
+	SignatureHash string `json:"signature_hash,omitempty"` // Hex SHA-256 of the signature
+	Cached        bool   `json:"cached,omitempty"`
+	QueuedID      string `json:"queued_id,omitempty"`
+
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+}
+
+// ComputeHash returns the hex SHA-256 of the entry's JSON encoding without its Hash, which
+// covers PrevHash and so every entry before it
+func (e *Entry) ComputeHash() string {
+	unhashed := *e
+	unhashed.Hash = ""
+	data, _ := json.Marshal(&unhashed) // Entry holds nothing json can fail on
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// HashBytes returns the hex SHA-256 of data, as entries reference messages and signatures
+func HashBytes(data []byte) string {
+	if data == nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Sink stores entries, in sequence order
+type Sink interface {
+	Write(entry *Entry) error
+	Close() error
+}
+
+// Resumer is a sink that can report the last entry it stored, so a new Logger continues its chain
+type Resumer interface {
+	Last() (*Entry, error)
+}
+
+// Logger chains entries and writes each to every sink
+type Logger struct {
+	mu    sync.Mutex
+	sinks []Sink
+	seq   uint64
+	prev  string
+	now   func() time.Time
+}
+
+// NewLogger creates a logger writing to sinks. The chain continues from the last entry of the
+// first sink implementing Resumer, or starts at GenesisHash
+func NewLogger(sinks ...Sink) (*Logger, error) {
+	if len(sinks) == 0 {
+		return nil, fmt.Errorf("audit logger requires a sink")
+	}
+	l := &Logger{sinks: sinks, prev: GenesisHash, now: time.Now}
+	for _, sink := range sinks {
+		resumer, ok := sink.(Resumer)
+		if !ok {
+			continue
+		}
+		last, err := resumer.Last()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resume audit chain: %w", err)
+		}
+		if last != nil {
+			l.seq, l.prev = last.Seq, last.Hash
+		}
+		break
+	}
+	return l, nil
+}
+
+// Record chains entry to the log and writes it to every sink, returning the first sink error
+// Seq, Time, PrevHash and Hash are set by the logger. The chain advances even if a sink fails,
+// so the missing entry shows up as a gap when that sink is verified
+func (l *Logger) Record(entry Entry) (*Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.seq++
+	entry.Seq = l.seq
+	entry.Time = l.now().UTC()
+	entry.PrevHash = l.prev
+	entry.Hash = entry.ComputeHash()
+	l.prev = entry.Hash
+
+	var errs []error
+	for _, sink := range l.sinks {
+		if err := sink.Write(&entry); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return &entry, fmt.Errorf("failed to write audit entry %d: %w", entry.Seq, errors.Join(errs...))
+	}
+	return &entry, nil
+}
+
+// Close closes all sinks
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var errs []error
+	for _, sink := range l.sinks {
+		errs = append(errs, sink.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Verify checks that entries form an unbroken chain: each hash matches its entry, links to the
+// one before and sequence numbers are consecutive. A chain starting at sequence 1 must start
+// from GenesisHash; a later start (e.g. a rotated file) is trusted as given
+func Verify(entries []*Entry) error {
+	for i, entry := range entries {
+		if entry.Hash != entry.ComputeHash() {
+			return fmt.Errorf("%w: entry %d does not match its hash", ErrTampered, entry.Seq)
+		}
+		if i == 0 {
+			if entry.Seq == 1 && entry.PrevHash != GenesisHash {
+				return fmt.Errorf("%w: first entry does not start from the genesis hash", ErrTampered)
+			}
+			continue
+		}
+		prev := entries[i-1]
+		if entry.Seq != prev.Seq+1 {
+			return fmt.Errorf("%w: entry %d follows entry %d", ErrTampered, entry.Seq, prev.Seq)
+		}
+		if entry.PrevHash != prev.Hash {
+			return fmt.Errorf("%w: entry %d does not link to entry %d", ErrTampered, entry.Seq, prev.Seq)
+		}
+	}
+	return nil
+}
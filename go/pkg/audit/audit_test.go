@@ -0,0 +1,192 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/audit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// memorySink keeps entries in memory
+type memorySink struct {
+	entries []*Entry
+	fail    bool
+}
+
+func (s *memorySink) Write(entry *Entry) error {
+	if s.fail {
+		return errors.New("sink down")
+	}
+	copied := *entry
+	s.entries = append(s.entries, &copied)
+	return nil
+}
+
+func (s *memorySink) Close() error { return nil }
+
+func record(t *testing.T, l *Logger, appIDs ...string) {
+	t.Helper()
+	for _, appID := range appIDs {
+		if _, err := l.Record(Entry{AppID: appID, MessageHash: HashBytes([]byte(appID)), Operation: "sign", Success: true}); err != nil {
+			t.Fatalf("Record(%s) failed: %v", appID, err)
+		}
+	}
+}
+
+func TestRecordChain(t *testing.T) {
+	sink := &memorySink{}
+	l, err := NewLogger(sink)
+	if err != nil {
+		t.Fatalf("NewLogger failed: %v", err)
+	}
+	record(t, l, "a", "b", "c")
+
+	if len(sink.entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(sink.entries))
+	}
+	if sink.entries[0].Seq != 1 || sink.entries[0].PrevHash != GenesisHash {
+		t.Errorf("First entry should start the chain, got seq %d prev %s", sink.entries[0].Seq, sink.entries[0].PrevHash)
+	}
+	if err := Verify(sink.entries); err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+}
+
+func TestVerifyTampering(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func([]*Entry) []*Entry
+	}{
+		{"edited field", func(e []*Entry) []*Entry { e[1].AppID = "evil"; return e }},
+		{"edited and rehashed", func(e []*Entry) []*Entry { e[1].AppID = "evil"; e[1].Hash = e[1].ComputeHash(); return e }},
+		{"deleted entry", func(e []*Entry) []*Entry { return append(e[:1], e[2:]...) }},
+		{"reordered", func(e []*Entry) []*Entry { e[1], e[2] = e[2], e[1]; return e }},
+		{"wrong genesis", func(e []*Entry) []*Entry { e[0].PrevHash = e[2].Hash; e[0].Hash = e[0].ComputeHash(); return e }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &memorySink{}
+			l, _ := NewLogger(sink)
+			record(t, l, "a", "b", "c")
+			if err := Verify(tt.tamper(sink.entries)); !errors.Is(err, ErrTampered) {
+				t.Errorf("Expected ErrTampered, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRecordSinkFailure(t *testing.T) {
+	good, bad := &memorySink{}, &memorySink{}
+	l, _ := NewLogger(good, bad)
+	record(t, l, "a")
+
+	bad.fail = true
+	if _, err := l.Record(Entry{AppID: "b"}); err == nil {
+		t.Fatal("Expected an error from the failing sink")
+	}
+	bad.fail = false
+	record(t, l, "c")
+
+	if err := Verify(good.entries); err != nil {
+		t.Errorf("Healthy sink should hold the whole chain: %v", err)
+	}
+	if err := Verify(bad.entries); !errors.Is(err, ErrTampered) {
+		t.Errorf("Failing sink should show a gap, got %v", err)
+	}
+}
+
+func TestFileSinkResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	sink, err := OpenFileSink(path)
+	if err != nil {
+		t.Fatalf("OpenFileSink failed: %v", err)
+	}
+	l, err := NewLogger(sink)
+	if err != nil {
+		t.Fatalf("NewLogger failed: %v", err)
+	}
+	l.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 6, time.FixedZone("X", 3600)) }
+	record(t, l, "a", "b")
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// A new logger on the same file continues the chain
+	sink, err = OpenFileSink(path)
+	if err != nil {
+		t.Fatalf("OpenFileSink failed: %v", err)
+	}
+	l, err = NewLogger(sink)
+	if err != nil {
+		t.Fatalf("NewLogger failed: %v", err)
+	}
+	record(t, l, "c")
+	l.Close()
+
+	n, err := VerifyFile(path)
+	if err != nil {
+		t.Fatalf("VerifyFile failed: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("Expected 3 entries, got %d", n)
+	}
+}
+
+// auditServer collects appended entries
+type auditServer struct {
+	pb.UnimplementedAuditServiceServer
+	mu      sync.Mutex
+	entries []*Entry
+}
+
+func (s *auditServer) Append(ctx context.Context, req *pb.AppendRequest) (*pb.AppendResponse, error) {
+	var entry Entry
+	if err := json.Unmarshal(req.Entry, &entry); err != nil {
+		return nil, err
+	}
+	if entry.Seq != req.Seq || entry.Hash != req.Hash {
+		return nil, errors.New("request does not match entry")
+	}
+	s.mu.Lock()
+	s.entries = append(s.entries, &entry)
+	s.mu.Unlock()
+	return &pb.AppendResponse{}, nil
+}
+
+func TestGRPCSink(t *testing.T) {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	collector := &auditServer{}
+	pb.RegisterAuditServiceServer(server, collector)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	l, _ := NewLogger(NewGRPCSink(conn, 0))
+	record(t, l, "a", "b")
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if len(collector.entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(collector.entries))
+	}
+	if err := Verify(collector.entries); err != nil {
+		t.Errorf("Verify failed: %v", err)
+	}
+}
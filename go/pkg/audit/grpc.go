@@ -0,0 +1,69 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/audit"
+	"google.golang.org/grpc"
+)
+
+// DefaultGRPCTimeout bounds each Append call of a GRPCSink
+const DefaultGRPCTimeout = 5 * time.Second
+
+// GRPCSink forwards entries to a remote AuditService, e.g. a central log collector
+type GRPCSink struct {
+	client  pb.AuditServiceClient
+	closer  func() error
+	timeout time.Duration
+}
+
+// NewGRPCSink sends entries over conn, each call bounded by timeout (DefaultGRPCTimeout if zero)
+// Closing the sink closes conn if it is a *grpc.ClientConn
+func NewGRPCSink(conn grpc.ClientConnInterface, timeout time.Duration) *GRPCSink {
+	if timeout <= 0 {
+		timeout = DefaultGRPCTimeout
+	}
+	sink := &GRPCSink{client: pb.NewAuditServiceClient(conn), timeout: timeout}
+	if cc, ok := conn.(*grpc.ClientConn); ok {
+		sink.closer = cc.Close
+	}
+	return sink
+}
+
+// Write appends entry to the remote log
+func (s *GRPCSink) Write(entry *Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	if _, err := s.client.Append(ctx, &pb.AppendRequest{Seq: entry.Seq, Hash: entry.Hash, Entry: data}); err != nil {
+		return fmt.Errorf("failed to send audit entry: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connection
+func (s *GRPCSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer()
+}
@@ -0,0 +1,54 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+//go:build !windows && !plan9
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/syslog"
+)
+
+// SyslogSink sends entries as JSON to syslog with the auth facility
+type SyslogSink struct {
+	writer *syslog.Writer
+}
+
+// NewSyslogSink connects to the syslog daemon at raddr over network, or the local one if both
+// are empty, tagging messages with tag
+func NewSyslogSink(network, raddr, tag string) (*SyslogSink, error) {
+	writer, err := syslog.Dial(network, raddr, syslog.LOG_AUTH|syslog.LOG_NOTICE, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+	}
+	return &SyslogSink{writer: writer}, nil
+}
+
+// Write sends entry as one message
+func (s *SyslogSink) Write(entry *Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+	if err := s.writer.Notice(string(data)); err != nil {
+		return fmt.Errorf("failed to write audit entry to syslog: %w", err)
+	}
+	return nil
+}
+
+// Close closes the syslog connection
+func (s *SyslogSink) Close() error {
+	return s.writer.Close()
+}
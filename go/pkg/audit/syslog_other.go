@@ -0,0 +1,36 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+//go:build windows || plan9
+
+package audit
+
+import "errors"
+
+// SyslogSink is not available on this platform
+type SyslogSink struct{}
+
+// NewSyslogSink always fails on this platform
+func NewSyslogSink(network, raddr, tag string) (*SyslogSink, error) {
+	return nil, errors.New("syslog is not supported on this platform")
+}
+
+// Write always fails on this platform
+func (s *SyslogSink) Write(entry *Entry) error {
+	return errors.New("syslog is not supported on this platform")
+}
+
+// Close does nothing
+func (s *SyslogSink) Close() error {
+	return nil
+}
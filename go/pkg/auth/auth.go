@@ -0,0 +1,147 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package auth authenticates the client to TEE and App nodes with bearer tokens or API keys, an
+// alternative to mTLS client certificates
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys sent with every authenticated call
+const (
+	AuthorizationHeader = "authorization"   // "Bearer <token>"
+	AppIDHeader         = "x-teenet-app-id" // App ID the token was chosen for, if any
+)
+
+// ErrNoToken is returned for calls whose app ID has no token and no default is set
+var ErrNoToken = errors.New("no auth token")
+
+// Tokens holds the bearer tokens of the app IDs the client acts for
+type Tokens struct {
+	Default string            `json:"default"` // Used for app IDs without their own token and calls not tied to one
+	PerApp  map[string]string `json:"per_app"` // App ID to token
+}
+
+// Enabled reports whether any token is configured
+func (t Tokens) Enabled() bool {
+	return t.Default != "" || len(t.PerApp) > 0
+}
+
+// Token returns the token of appID, falling back to the default
+func (t Tokens) Token(appID string) (string, bool) {
+	if token, ok := t.PerApp[appID]; ok && token != "" {
+		return token, true
+	}
+	return t.Default, t.Default != ""
+}
+
+// ParseTokens reads "appID=token" pairs separated by commas; an entry without "=" is the default
+func ParseTokens(value string) (Tokens, error) {
+	var tokens Tokens
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		appID, token, found := strings.Cut(entry, "=")
+		if !found {
+			if tokens.Default != "" {
+				return Tokens{}, errors.New("more than one default token")
+			}
+			tokens.Default = entry
+			continue
+		}
+		appID, token = strings.TrimSpace(appID), strings.TrimSpace(token)
+		if appID == "" || token == "" {
+			return Tokens{}, fmt.Errorf("invalid token entry for app %q (want appID=token)", appID)
+		}
+		if tokens.PerApp == nil {
+			tokens.PerApp = make(map[string]string)
+		}
+		tokens.PerApp[appID] = token
+	}
+	return tokens, nil
+}
+
+// String lists the configured app IDs without revealing any token
+func (t Tokens) String() string {
+	apps := make([]string, 0, len(t.PerApp))
+	for appID := range t.PerApp {
+		apps = append(apps, appID)
+	}
+	sort.Strings(apps)
+	return fmt.Sprintf("auth.Tokens{default: %t, apps: %v}", t.Default != "", apps)
+}
+
+type appIDKey struct{}
+
+// WithAppID marks calls made with ctx as acting for appID, selecting its token for requests
+// that don't carry an app ID themselves, such as TEE sign requests
+func WithAppID(ctx context.Context, appID string) context.Context {
+	return context.WithValue(ctx, appIDKey{}, appID)
+}
+
+// AppIDFromContext returns the app ID set by WithAppID
+func AppIDFromContext(ctx context.Context) string {
+	appID, _ := ctx.Value(appIDKey{}).(string)
+	return appID
+}
+
+// UnaryClientInterceptor attaches the token of each call's app ID, taken from the request's
+// app_id field or else from the context
+func (t Tokens) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		appID := AppIDFromContext(ctx)
+		if r, ok := req.(interface{ GetAppId() string }); ok && r.GetAppId() != "" {
+			appID = r.GetAppId()
+		}
+		ctx, err := t.attach(ctx, method, appID)
+		if err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor attaches the token of the context's app ID, or the default
+func (t Tokens) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, err := t.attach(ctx, method, AppIDFromContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// attach adds the authorization metadata to an outgoing context
+func (t Tokens) attach(ctx context.Context, method, appID string) (context.Context, error) {
+	token, ok := t.Token(appID)
+	if !ok {
+		return nil, fmt.Errorf("%w for app %q calling %s", ErrNoToken, appID, method)
+	}
+	pairs := []string{AuthorizationHeader, "Bearer " + token}
+	if appID != "" {
+		pairs = append(pairs, AppIDHeader, appID)
+	}
+	return metadata.AppendToOutgoingContext(ctx, pairs...), nil
+}
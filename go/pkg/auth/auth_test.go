@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// appRequest stands in for a request message with an app_id field
+type appRequest struct{ appID string }
+
+func (r *appRequest) GetAppId() string { return r.appID }
+
+func TestParseTokens(t *testing.T) {
+	tokens, err := ParseTokens(" shared , app-a=token-a,app-b = token-b ")
+	if err != nil {
+		t.Fatalf("ParseTokens failed: %v", err)
+	}
+	if tokens.Default != "shared" || tokens.PerApp["app-a"] != "token-a" || tokens.PerApp["app-b"] != "token-b" {
+		t.Errorf("ParseTokens = %+v", tokens)
+	}
+
+	for _, value := range []string{"one,two", "=token", "app-a="} {
+		if _, err := ParseTokens(value); err == nil {
+			t.Errorf("expected %q to be rejected", value)
+		}
+	}
+	if tokens, err := ParseTokens(""); err != nil || tokens.Enabled() {
+		t.Errorf("ParseTokens(\"\") = %+v, %v", tokens, err)
+	}
+}
+
+func TestTokensString(t *testing.T) {
+	s := Tokens{Default: "secret-default", PerApp: map[string]string{"app-a": "secret-a"}}.String()
+	if strings.Contains(s, "secret") || !strings.Contains(s, "app-a") {
+		t.Errorf("String() = %q, want app IDs without tokens", s)
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	tokens := Tokens{Default: "shared", PerApp: map[string]string{"app-a": "token-a", "app-b": "token-b"}}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		req       any
+		wantToken string
+		wantApp   string
+	}{
+		{name: "request app ID", ctx: context.Background(), req: &appRequest{appID: "app-a"}, wantToken: "token-a", wantApp: "app-a"},
+		{name: "request wins over context", ctx: WithAppID(context.Background(), "app-b"), req: &appRequest{appID: "app-a"}, wantToken: "token-a", wantApp: "app-a"},
+		{name: "context app ID", ctx: WithAppID(context.Background(), "app-b"), req: struct{}{}, wantToken: "token-b", wantApp: "app-b"},
+		{name: "unknown app uses default", ctx: context.Background(), req: &appRequest{appID: "app-c"}, wantToken: "shared", wantApp: "app-c"},
+		{name: "no app ID", ctx: context.Background(), req: struct{}{}, wantToken: "shared"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var md metadata.MD
+			invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				md, _ = metadata.FromOutgoingContext(ctx)
+				return nil
+			}
+			if err := tokens.UnaryClientInterceptor()(tt.ctx, "/svc/Method", tt.req, nil, nil, invoker); err != nil {
+				t.Fatalf("interceptor failed: %v", err)
+			}
+			if got := md.Get(AuthorizationHeader); len(got) != 1 || got[0] != "Bearer "+tt.wantToken {
+				t.Errorf("authorization = %v, want Bearer %s", got, tt.wantToken)
+			}
+			if got := md.Get(AppIDHeader); tt.wantApp != "" && (len(got) != 1 || got[0] != tt.wantApp) || tt.wantApp == "" && len(got) != 0 {
+				t.Errorf("app ID header = %v, want %q", got, tt.wantApp)
+			}
+		})
+	}
+
+	noDefault := Tokens{PerApp: map[string]string{"app-a": "token-a"}}
+	invoked := false
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		invoked = true
+		return nil
+	}
+	err := noDefault.UnaryClientInterceptor()(context.Background(), "/svc/Method", &appRequest{appID: "app-c"}, nil, nil, invoker)
+	if !errors.Is(err, ErrNoToken) || invoked {
+		t.Errorf("interceptor = %v (invoked %t), want ErrNoToken without a call", err, invoked)
+	}
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	tokens := Tokens{Default: "shared", PerApp: map[string]string{"app-a": "token-a"}}
+	var md metadata.MD
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		md, _ = metadata.FromOutgoingContext(ctx)
+		return nil, nil
+	}
+	if _, err := tokens.StreamClientInterceptor()(WithAppID(context.Background(), "app-a"), nil, nil, "/svc/Stream", streamer); err != nil {
+		t.Fatalf("interceptor failed: %v", err)
+	}
+	if got := md.Get(AuthorizationHeader); len(got) != 1 || got[0] != "Bearer token-a" {
+		t.Errorf("authorization = %v, want Bearer token-a", got)
+	}
+}
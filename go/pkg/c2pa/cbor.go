@@ -0,0 +1,131 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package c2pa
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// cborMap is a CBOR map whose keys are encoded in insertion order, so manifests are reproducible
+type cborMap []cborPair
+
+// cborPair is one key/value entry of a cborMap
+type cborPair struct {
+	key   any
+	value any
+}
+
+// cborTag is a tagged CBOR data item
+type cborTag struct {
+	tag   uint64
+	value any
+}
+
+// CBOR major types
+const (
+	cborUint   = 0
+	cborNegInt = 1
+	cborBytes  = 2
+	cborText   = 3
+	cborArray  = 4
+	cborMapTyp = 5
+	cborTagged = 6
+)
+
+// cborNull is the encoding of null
+const cborNull = 0xf6
+
+// marshalCBOR encodes the subset of CBOR manifests need: integers, byte and text strings,
+// arrays, ordered maps, tags, booleans and null
+func marshalCBOR(value any) ([]byte, error) {
+	return appendCBOR(nil, value)
+}
+
+func appendCBOR(buf []byte, value any) ([]byte, error) {
+	var err error
+	switch v := value.(type) {
+	case nil:
+		return append(buf, cborNull), nil
+	case bool:
+		if v {
+			return append(buf, 0xf5), nil
+		}
+		return append(buf, 0xf4), nil
+	case int:
+		return appendCBORInt(buf, int64(v)), nil
+	case int64:
+		return appendCBORInt(buf, v), nil
+	case uint64:
+		return appendCBORHead(buf, cborUint, v), nil
+	case Algorithm:
+		return appendCBORInt(buf, int64(v)), nil
+	case []byte:
+		buf = appendCBORHead(buf, cborBytes, uint64(len(v)))
+		return append(buf, v...), nil
+	case string:
+		buf = appendCBORHead(buf, cborText, uint64(len(v)))
+		return append(buf, v...), nil
+	case []any:
+		buf = appendCBORHead(buf, cborArray, uint64(len(v)))
+		for _, item := range v {
+			if buf, err = appendCBOR(buf, item); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case cborMap:
+		buf = appendCBORHead(buf, cborMapTyp, uint64(len(v)))
+		for _, pair := range v {
+			if buf, err = appendCBOR(buf, pair.key); err != nil {
+				return nil, err
+			}
+			if buf, err = appendCBOR(buf, pair.value); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case cborTag:
+		buf = appendCBORHead(buf, cborTagged, v.tag)
+		return appendCBOR(buf, v.value)
+	default:
+		return nil, fmt.Errorf("cannot encode %T as CBOR", value)
+	}
+}
+
+// appendCBORInt encodes a signed integer as major type 0 or 1
+func appendCBORInt(buf []byte, n int64) []byte {
+	if n >= 0 {
+		return appendCBORHead(buf, cborUint, uint64(n))
+	}
+	return appendCBORHead(buf, cborNegInt, uint64(-(n + 1)))
+}
+
+// appendCBORHead encodes a major type with its argument in the shortest form
+func appendCBORHead(buf []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(buf, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(buf, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(buf, major|26), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, major|27), n)
+	}
+}
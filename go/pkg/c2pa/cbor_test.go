@@ -0,0 +1,47 @@
+package c2pa
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestMarshalCBOR(t *testing.T) {
+	// Examples from RFC 8949 Appendix A
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{0, "00"},
+		{23, "17"},
+		{24, "1818"},
+		{100, "1864"},
+		{1000, "1903e8"},
+		{1000000, "1a000f4240"},
+		{uint64(1000000000000), "1b000000e8d4a51000"},
+		{-1, "20"},
+		{-1000, "3903e7"},
+		{AlgorithmES256, "26"},
+		{"a", "6161"},
+		{"IETF", "6449455446"},
+		{[]byte{1, 2, 3, 4}, "4401020304"},
+		{[]any{1, []any{2, 3}}, "8201820203"},
+		{cborMap{{"a", 1}, {"b", []any{2, 3}}}, "a26161016162820203"},
+		{cborTag{1, 1363896240}, "c11a514b67b0"},
+		{true, "f5"},
+		{nil, "f6"},
+	}
+	for _, tt := range tests {
+		got, err := marshalCBOR(tt.value)
+		if err != nil {
+			t.Errorf("marshalCBOR(%v) failed: %v", tt.value, err)
+			continue
+		}
+		if hex.EncodeToString(got) != tt.want {
+			t.Errorf("marshalCBOR(%v) = %x, want %s", tt.value, got, tt.want)
+		}
+	}
+
+	if _, err := marshalCBOR(1.5); err == nil {
+		t.Error("Expected an error for an unsupported type")
+	}
+}
@@ -0,0 +1,99 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package c2pa
+
+import (
+	"fmt"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+)
+
+// Algorithm is a COSE signature algorithm (RFC 9053)
+type Algorithm int64
+
+// Signature algorithms C2PA accepts that TEE keys can produce
+const (
+	AlgorithmES256 Algorithm = -7 // ECDSA with SHA-256 on SECP256R1
+	AlgorithmEdDSA Algorithm = -8 // Ed25519
+)
+
+// COSE header labels and the COSE_Sign1 tag (RFC 9052)
+const (
+	headerAlgorithm = 1
+	headerX5Chain   = 33
+	coseSign1Tag    = 18
+)
+
+// AlgorithmFor returns the COSE algorithm of a TEE key. C2PA has no algorithm for SECP256K1
+// or Schnorr keys, so those are rejected
+func AlgorithmFor(protocol constants.Protocol, curve constants.Curve) (Algorithm, error) {
+	switch {
+	case curve == constants.CurveED25519:
+		return AlgorithmEdDSA, nil
+	case curve == constants.CurveSECP256R1 && protocol == constants.ProtocolECDSA:
+		return AlgorithmES256, nil
+	default:
+		return 0, fmt.Errorf("C2PA does not support %s keys on %s", protocol, curve)
+	}
+}
+
+// String returns the COSE name of the algorithm
+func (a Algorithm) String() string {
+	switch a {
+	case AlgorithmES256:
+		return "ES256"
+	case AlgorithmEdDSA:
+		return "EdDSA"
+	default:
+		return fmt.Sprintf("Algorithm(%d)", int64(a))
+	}
+}
+
+// keyType returns the protocol and curve of keys that sign with the algorithm
+func (a Algorithm) keyType() (constants.Protocol, constants.Curve, error) {
+	switch a {
+	case AlgorithmES256:
+		return constants.ProtocolECDSA, constants.CurveSECP256R1, nil
+	case AlgorithmEdDSA:
+		return constants.ProtocolSchnorr, constants.CurveED25519, nil
+	default:
+		return 0, 0, fmt.Errorf("unsupported COSE algorithm %s", a)
+	}
+}
+
+// protectedHeader encodes the protected header naming the algorithm and certificate chain
+func protectedHeader(alg Algorithm, certificates [][]byte) ([]byte, error) {
+	var chain any = certificates[0]
+	if len(certificates) > 1 {
+		certs := make([]any, len(certificates))
+		for i, cert := range certificates {
+			certs[i] = cert
+		}
+		chain = certs
+	}
+	return marshalCBOR(cborMap{
+		{headerAlgorithm, alg},
+		{headerX5Chain, chain},
+	})
+}
+
+// sigStructure encodes the bytes signed for a COSE_Sign1 with a detached payload
+func sigStructure(protected, payload []byte) ([]byte, error) {
+	return marshalCBOR([]any{"Signature1", protected, []byte{}, payload})
+}
+
+// coseSign1 encodes a tagged COSE_Sign1 with a detached payload
+func coseSign1(protected, signature []byte) ([]byte, error) {
+	return marshalCBOR(cborTag{coseSign1Tag, []any{protected, cborMap{}, nil, signature}})
+}
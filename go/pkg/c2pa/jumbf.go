@@ -0,0 +1,110 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package c2pa
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// JUMBF (ISO/IEC 19566-5) box types used by C2PA manifest stores
+const (
+	boxSuperbox    = "jumb"
+	boxDescription = "jumd"
+	boxCBOR        = "cbor"
+	boxJSON        = "json"
+)
+
+// jumbfUUID is a JUMBF content type: four ASCII characters followed by the ISO suffix
+type jumbfUUID [16]byte
+
+// newJUMBFUUID builds the UUID of a four character content type
+func newJUMBFUUID(tag string) jumbfUUID {
+	var id jumbfUUID
+	copy(id[:4], tag)
+	copy(id[4:], []byte{0x00, 0x11, 0x00, 0x10, 0x80, 0x00, 0x00, 0xAA, 0x00, 0x38, 0x9B, 0x71})
+	return id
+}
+
+// C2PA content types
+var (
+	uuidManifestStore  = newJUMBFUUID("c2pa")
+	uuidManifest       = newJUMBFUUID("c2ma")
+	uuidAssertionStore = newJUMBFUUID("c2as")
+	uuidClaim          = newJUMBFUUID("c2cl")
+	uuidSignature      = newJUMBFUUID("c2cs")
+	uuidCBORAssertion  = newJUMBFUUID("cbor")
+	uuidJSONAssertion  = newJUMBFUUID("json")
+)
+
+// descriptionToggles marks a box as requestable with a label
+const descriptionToggles = 0x03
+
+// jumbfBox is a box of content, or a superbox of child boxes if children is set
+type jumbfBox struct {
+	boxType  string
+	content  []byte
+	children []*jumbfBox
+}
+
+// superbox returns a labelled JUMBF superbox of the given content type
+func superbox(contentType jumbfUUID, label string, children ...*jumbfBox) (*jumbfBox, error) {
+	if len(label) == 0 {
+		return nil, fmt.Errorf("JUMBF label is required")
+	}
+	for i := 0; i < len(label); i++ {
+		if label[i] == 0 {
+			return nil, fmt.Errorf("JUMBF label %q must not contain NUL bytes", label)
+		}
+	}
+	description := make([]byte, 0, len(contentType)+len(label)+2)
+	description = append(description, contentType[:]...)
+	description = append(description, descriptionToggles)
+	description = append(description, label...)
+	description = append(description, 0)
+
+	box := &jumbfBox{boxType: boxSuperbox}
+	box.children = append(box.children, &jumbfBox{boxType: boxDescription, content: description})
+	box.children = append(box.children, children...)
+	return box, nil
+}
+
+// marshal encodes the box with its LBox/TBox header
+func (b *jumbfBox) marshal() []byte {
+	payload := b.payload()
+	buf := binary.BigEndian.AppendUint32(nil, uint32(8+len(payload)))
+	buf = append(buf, b.boxType...)
+	return append(buf, payload...)
+}
+
+// payload encodes the box without its header; C2PA hashes assertions over this
+func (b *jumbfBox) payload() []byte {
+	if b.children == nil {
+		return b.content
+	}
+	var buf []byte
+	for _, child := range b.children {
+		buf = append(buf, child.marshal()...)
+	}
+	return buf
+}
+
+// label returns the label of a superbox's description box
+func (b *jumbfBox) label() string {
+	if len(b.children) == 0 || b.children[0].boxType != boxDescription {
+		return ""
+	}
+	description := b.children[0].content[len(jumbfUUID{})+1:]
+	return string(description[:len(description)-1])
+}
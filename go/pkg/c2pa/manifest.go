@@ -0,0 +1,326 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package c2pa
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+// Standard C2PA actions
+const (
+	ActionCreated   = "c2pa.created"
+	ActionOpened    = "c2pa.opened"
+	ActionEdited    = "c2pa.edited"
+	ActionConverted = "c2pa.converted"
+	ActionPublished = "c2pa.published"
+)
+
+// Labels of the assertions the manifest always or optionally contains
+const (
+	labelDataHash = "c2pa.hash.data"
+	labelActions  = "c2pa.actions"
+)
+
+// hashAlgorithm is the hash of assertions, the claim and the asset
+const hashAlgorithm = "sha256"
+
+// Action records something done to the asset, in the c2pa.actions assertion
+type Action struct {
+	Action            string    // One of the Action constants or a custom label
+	When              time.Time // Optional
+	SoftwareAgent     string    // Optional, the tool that performed the action
+	DigitalSourceType string    // Optional IPTC digital source type URI, e.g. for generative AI output
+}
+
+// Assertion is an additional JSON assertion, such as stds.schema-org.CreativeWork
+type Assertion struct {
+	Label string
+	Data  any // Encoded with encoding/json
+}
+
+// Manifest describes the provenance claim made about an asset
+type Manifest struct {
+	ClaimGenerator string // Product that made the claim, e.g. "my-app/1.0"
+	Format         string // MIME type of the asset, e.g. "image/jpeg"
+	Title          string // Optional
+	Actions        []Action
+	Assertions     []Assertion
+}
+
+// Signer signs claims. Sign receives the COSE Sig_structure: ES256 signers sign its SHA-256 hash
+// (returning DER or raw R || S), EdDSA signers sign it directly
+type Signer struct {
+	Algorithm    Algorithm
+	PublicKey    []byte   // Optional, checked against the leaf certificate and the signature
+	Certificates [][]byte // DER X.509 chain, leaf first; the leaf certifies the signing key
+	Sign         func(toBeSigned []byte) ([]byte, error)
+}
+
+// Sign builds a C2PA manifest store for the asset read from r, bound to it by a hash of all of
+// its bytes, and signs the claim with signer. The result is a JUMBF box suitable as a sidecar
+// (.c2pa) manifest; embedding it into a particular file format is left to the caller
+func (m *Manifest) Sign(asset io.Reader, signer *Signer) ([]byte, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	if err := signer.validate(); err != nil {
+		return nil, err
+	}
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, asset); err != nil {
+		return nil, fmt.Errorf("failed to hash asset: %w", err)
+	}
+	assertions, err := m.assertionBoxes(hasher.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	claim, err := m.claim(assertions)
+	if err != nil {
+		return nil, err
+	}
+
+	protected, err := protectedHeader(signer.Algorithm, signer.Certificates)
+	if err != nil {
+		return nil, err
+	}
+	toBeSigned, err := sigStructure(protected, claim)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signer.sign(toBeSigned)
+	if err != nil {
+		return nil, err
+	}
+	claimSignature, err := coseSign1(protected, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	assertionStore, err := superbox(uuidAssertionStore, "c2pa.assertions", assertions...)
+	if err != nil {
+		return nil, err
+	}
+	claimBox, err := superbox(uuidClaim, "c2pa.claim", &jumbfBox{boxType: boxCBOR, content: claim})
+	if err != nil {
+		return nil, err
+	}
+	signatureBox, err := superbox(uuidSignature, "c2pa.signature", &jumbfBox{boxType: boxCBOR, content: claimSignature})
+	if err != nil {
+		return nil, err
+	}
+	manifestID, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := superbox(uuidManifest, "urn:uuid:"+manifestID, assertionStore, claimBox, signatureBox)
+	if err != nil {
+		return nil, err
+	}
+	store, err := superbox(uuidManifestStore, "c2pa", manifest)
+	if err != nil {
+		return nil, err
+	}
+	return store.marshal(), nil
+}
+
+// validate checks the manifest has what C2PA requires
+func (m *Manifest) validate() error {
+	switch {
+	case m == nil:
+		return fmt.Errorf("manifest is required")
+	case m.ClaimGenerator == "":
+		return fmt.Errorf("manifest claim generator is required")
+	case m.Format == "":
+		return fmt.Errorf("manifest format is required")
+	}
+	for _, action := range m.Actions {
+		if action.Action == "" {
+			return fmt.Errorf("manifest action label is required")
+		}
+	}
+	seen := map[string]bool{labelDataHash: true, labelActions: true}
+	for _, assertion := range m.Assertions {
+		if assertion.Label == "" {
+			return fmt.Errorf("manifest assertion label is required")
+		}
+		if seen[assertion.Label] {
+			return fmt.Errorf("duplicate manifest assertion %q", assertion.Label)
+		}
+		seen[assertion.Label] = true
+	}
+	return nil
+}
+
+// assertionBoxes encodes the data hash, actions and additional assertions
+func (m *Manifest) assertionBoxes(assetHash []byte) ([]*jumbfBox, error) {
+	dataHash, err := marshalCBOR(cborMap{
+		{"exclusions", []any{}},
+		{"name", "jumbf manifest"},
+		{"alg", hashAlgorithm},
+		{"hash", assetHash},
+		{"pad", []byte{}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	box, err := superbox(uuidCBORAssertion, labelDataHash, &jumbfBox{boxType: boxCBOR, content: dataHash})
+	if err != nil {
+		return nil, err
+	}
+	boxes := []*jumbfBox{box}
+
+	if len(m.Actions) > 0 {
+		actions := make([]any, len(m.Actions))
+		for i, action := range m.Actions {
+			entry := cborMap{{"action", action.Action}}
+			if !action.When.IsZero() {
+				entry = append(entry, cborPair{"when", action.When.UTC().Format(time.RFC3339)})
+			}
+			if action.SoftwareAgent != "" {
+				entry = append(entry, cborPair{"softwareAgent", action.SoftwareAgent})
+			}
+			if action.DigitalSourceType != "" {
+				entry = append(entry, cborPair{"digitalSourceType", action.DigitalSourceType})
+			}
+			actions[i] = entry
+		}
+		content, err := marshalCBOR(cborMap{{"actions", actions}})
+		if err != nil {
+			return nil, err
+		}
+		box, err := superbox(uuidCBORAssertion, labelActions, &jumbfBox{boxType: boxCBOR, content: content})
+		if err != nil {
+			return nil, err
+		}
+		boxes = append(boxes, box)
+	}
+
+	for _, assertion := range m.Assertions {
+		content, err := json.Marshal(assertion.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode assertion %q: %w", assertion.Label, err)
+		}
+		box, err := superbox(uuidJSONAssertion, assertion.Label, &jumbfBox{boxType: boxJSON, content: content})
+		if err != nil {
+			return nil, err
+		}
+		boxes = append(boxes, box)
+	}
+	return boxes, nil
+}
+
+// claim encodes the claim, referencing each assertion by hashed URI
+func (m *Manifest) claim(assertions []*jumbfBox) ([]byte, error) {
+	instanceID, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+	references := make([]any, len(assertions))
+	for i, box := range assertions {
+		hash := sha256.Sum256(box.payload())
+		references[i] = cborMap{
+			{"url", "self#jumbf=c2pa.assertions/" + box.label()},
+			{"hash", hash[:]},
+		}
+	}
+
+	claim := cborMap{}
+	if m.Title != "" {
+		claim = append(claim, cborPair{"dc:title", m.Title})
+	}
+	claim = append(claim,
+		cborPair{"dc:format", m.Format},
+		cborPair{"instanceID", "xmp:iid:" + instanceID},
+		cborPair{"claim_generator", m.ClaimGenerator},
+		cborPair{"signature", "self#jumbf=c2pa.signature"},
+		cborPair{"assertions", references},
+		cborPair{"alg", hashAlgorithm},
+	)
+	return marshalCBOR(claim)
+}
+
+// validate checks the signer is complete and its leaf certificate certifies its key
+func (s *Signer) validate() error {
+	switch {
+	case s == nil || s.Sign == nil:
+		return fmt.Errorf("signer is required")
+	case len(s.Certificates) == 0:
+		return fmt.Errorf("signer certificate chain is required")
+	}
+	protocol, curve, err := s.Algorithm.keyType()
+	if err != nil {
+		return err
+	}
+	leaf, err := x509.ParseCertificate(s.Certificates[0])
+	if err != nil {
+		return fmt.Errorf("failed to parse signer certificate: %w", err)
+	}
+	if len(s.PublicKey) == 0 {
+		return nil
+	}
+	key, err := verification.ParsePublicKey(curve, s.PublicKey)
+	if err != nil {
+		return err
+	}
+	if certified, ok := leaf.PublicKey.(interface{ Equal(crypto.PublicKey) bool }); !ok || !certified.Equal(key) {
+		return fmt.Errorf("signer certificate does not certify the %s key on %s", protocol, curve)
+	}
+	return nil
+}
+
+// sign signs the Sig_structure and returns the signature in its COSE encoding
+func (s *Signer) sign(toBeSigned []byte) ([]byte, error) {
+	signature, err := s.Sign(toBeSigned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign claim: %w", err)
+	}
+	// COSE encodes ECDSA signatures as raw R || S
+	if s.Algorithm == AlgorithmES256 && len(signature) != verification.RawSignatureSize {
+		if signature, err = verification.DERToRaw(signature); err != nil {
+			return nil, fmt.Errorf("failed to convert claim signature: %w", err)
+		}
+	}
+	if len(s.PublicKey) > 0 {
+		protocol, curve, _ := s.Algorithm.keyType()
+		valid, err := verification.VerifySignature(toBeSigned, s.PublicKey, signature, protocol, curve)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify claim signature: %w", err)
+		}
+		if !valid {
+			return nil, fmt.Errorf("claim signature does not verify under the signer's key")
+		}
+	}
+	return signature, nil
+}
+
+// newUUID returns a random (version 4) UUID
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
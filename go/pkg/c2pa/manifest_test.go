@@ -0,0 +1,224 @@
+package c2pa
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+// selfSignedCertificate returns a DER certificate for key, signed by itself
+func selfSignedCertificate(t *testing.T, public, private any) []byte {
+	t.Helper()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "c2pa-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, public, private)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	return der
+}
+
+// p256Signer returns a signer backed by a fresh P-256 key, like a TEE SECP256R1 ECDSA app
+func p256Signer(t *testing.T) *Signer {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	public, err := key.PublicKey.ECDH()
+	if err != nil {
+		t.Fatalf("Failed to convert key: %v", err)
+	}
+	return &Signer{
+		Algorithm:    AlgorithmES256,
+		PublicKey:    public.Bytes(),
+		Certificates: [][]byte{selfSignedCertificate(t, &key.PublicKey, key)},
+		Sign: func(toBeSigned []byte) ([]byte, error) {
+			hash := sha256.Sum256(toBeSigned)
+			return ecdsa.SignASN1(rand.Reader, key, hash[:])
+		},
+	}
+}
+
+// box is a decoded JUMBF box
+type box struct {
+	boxType  string
+	payload  []byte
+	children []box
+}
+
+// readBoxes decodes consecutive JUMBF boxes, descending into superboxes
+func readBoxes(t *testing.T, data []byte) []box {
+	t.Helper()
+	var boxes []box
+	for len(data) > 0 {
+		if len(data) < 8 {
+			t.Fatalf("Truncated box header: %x", data)
+		}
+		size := int(binary.BigEndian.Uint32(data))
+		if size < 8 || size > len(data) {
+			t.Fatalf("Invalid box size %d with %d bytes left", size, len(data))
+		}
+		b := box{boxType: string(data[4:8]), payload: data[8:size]}
+		if b.boxType == boxSuperbox {
+			b.children = readBoxes(t, b.payload)
+		}
+		boxes = append(boxes, b)
+		data = data[size:]
+	}
+	return boxes
+}
+
+// label returns the content type and label of a decoded superbox
+func (b box) label() (jumbfUUID, string) {
+	var id jumbfUUID
+	description := b.children[0].payload
+	copy(id[:], description)
+	return id, strings.TrimSuffix(string(description[len(id)+1:]), "\x00")
+}
+
+func TestManifestSign(t *testing.T) {
+	signer := p256Signer(t)
+	asset := []byte("not really a JPEG")
+	manifest := &Manifest{
+		ClaimGenerator: "teenet-test/1.0",
+		Format:         "image/jpeg",
+		Title:          "test.jpg",
+		Actions:        []Action{{Action: ActionCreated, When: time.Now(), SoftwareAgent: "teenet-test"}},
+		Assertions:     []Assertion{{Label: "stds.schema-org.CreativeWork", Data: map[string]any{"author": "TEENet"}}},
+	}
+	data, err := manifest.Sign(bytes.NewReader(asset), signer)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	store := readBoxes(t, data)
+	if len(store) != 1 {
+		t.Fatalf("Expected one manifest store box, got %d", len(store))
+	}
+	if id, label := store[0].label(); id != uuidManifestStore || label != "c2pa" {
+		t.Fatalf("Expected the c2pa manifest store, got %x %q", id, label)
+	}
+	manifestBox := store[0].children[1]
+	if id, label := manifestBox.label(); id != uuidManifest || !strings.HasPrefix(label, "urn:uuid:") {
+		t.Fatalf("Expected a urn:uuid manifest, got %x %q", id, label)
+	}
+	if len(manifestBox.children) != 4 {
+		t.Fatalf("Expected description, assertion store, claim and signature, got %d boxes", len(manifestBox.children))
+	}
+	assertionStore, claimBox, signatureBox := manifestBox.children[1], manifestBox.children[2], manifestBox.children[3]
+
+	var labels []string
+	for _, assertion := range assertionStore.children[1:] {
+		_, label := assertion.label()
+		labels = append(labels, label)
+	}
+	if strings.Join(labels, ",") != "c2pa.hash.data,c2pa.actions,stds.schema-org.CreativeWork" {
+		t.Errorf("Unexpected assertions %v", labels)
+	}
+
+	claim := claimBox.children[1].payload
+	for _, assertion := range assertionStore.children[1:] {
+		hash := sha256.Sum256(assertion.payload)
+		if !bytes.Contains(claim, hash[:]) {
+			_, label := assertion.label()
+			t.Errorf("Expected the claim to reference the hash of %s", label)
+		}
+	}
+	assetHash := sha256.Sum256(asset)
+	if !bytes.Contains(assertionStore.children[1].payload, assetHash[:]) {
+		t.Error("Expected the data hash assertion to contain the asset hash")
+	}
+
+	cose := signatureBox.children[1].payload
+	protected, err := protectedHeader(AlgorithmES256, signer.Certificates)
+	if err != nil {
+		t.Fatalf("protectedHeader failed: %v", err)
+	}
+	if cose[0] != 0xd2 || !bytes.Contains(cose, protected) {
+		t.Fatalf("Expected a tagged COSE_Sign1 with the protected header, got %x", cose[:8])
+	}
+	toBeSigned, _ := sigStructure(protected, claim)
+	signature := cose[len(cose)-verification.RawSignatureSize:]
+	valid, err := verification.VerifySignature(toBeSigned, signer.PublicKey, signature, constants.ProtocolECDSA, constants.CurveSECP256R1)
+	if err != nil || !valid {
+		t.Errorf("Expected the COSE signature to verify over the claim, got %v, %v", valid, err)
+	}
+}
+
+func TestManifestSignEd25519(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	signer := &Signer{
+		Algorithm:    AlgorithmEdDSA,
+		PublicKey:    public,
+		Certificates: [][]byte{selfSignedCertificate(t, public, private)},
+		Sign: func(toBeSigned []byte) ([]byte, error) {
+			return ed25519.Sign(private, toBeSigned), nil
+		},
+	}
+	manifest := &Manifest{ClaimGenerator: "teenet-test/1.0", Format: "image/png"}
+	if _, err := manifest.Sign(strings.NewReader("png"), signer); err != nil {
+		t.Errorf("Sign failed: %v", err)
+	}
+}
+
+func TestManifestSignRejects(t *testing.T) {
+	signer := p256Signer(t)
+	other := p256Signer(t)
+	valid := &Manifest{ClaimGenerator: "teenet-test/1.0", Format: "image/jpeg"}
+
+	tests := []struct {
+		name     string
+		manifest *Manifest
+		signer   *Signer
+		wantErr  string
+	}{
+		{"no claim generator", &Manifest{Format: "image/jpeg"}, signer, "claim generator"},
+		{"no format", &Manifest{ClaimGenerator: "x"}, signer, "format"},
+		{"duplicate assertion", &Manifest{ClaimGenerator: "x", Format: "image/jpeg", Assertions: []Assertion{{Label: "c2pa.actions"}}}, signer, "duplicate"},
+		{"no certificates", valid, &Signer{Algorithm: AlgorithmES256, Sign: signer.Sign}, "certificate chain"},
+		{"wrong certificate", valid, &Signer{Algorithm: AlgorithmES256, PublicKey: signer.PublicKey, Certificates: other.Certificates, Sign: signer.Sign}, "does not certify"},
+		{"wrong key", valid, &Signer{Algorithm: AlgorithmES256, PublicKey: other.PublicKey, Certificates: other.Certificates, Sign: signer.Sign}, "does not verify"},
+		{"unsupported algorithm", valid, &Signer{Algorithm: -47, Certificates: signer.Certificates, Sign: signer.Sign}, "unsupported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.manifest.Sign(strings.NewReader("asset"), tt.signer); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAlgorithmFor(t *testing.T) {
+	if alg, err := AlgorithmFor(constants.ProtocolECDSA, constants.CurveSECP256R1); err != nil || alg != AlgorithmES256 {
+		t.Errorf("Expected ES256 for P-256 ECDSA, got %v, %v", alg, err)
+	}
+	if alg, err := AlgorithmFor(constants.ProtocolSchnorr, constants.CurveED25519); err != nil || alg != AlgorithmEdDSA {
+		t.Errorf("Expected EdDSA for Ed25519, got %v, %v", alg, err)
+	}
+	if _, err := AlgorithmFor(constants.ProtocolECDSA, constants.CurveSECP256K1); err == nil {
+		t.Error("Expected SECP256K1 to be rejected")
+	}
+}
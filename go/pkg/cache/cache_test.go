@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryExpiry(t *testing.T) {
+	now := time.Unix(1000, 0)
+	m := NewMemory(0)
+	m.now = func() time.Time { return now }
+
+	m.Set("k", []byte("v"), time.Minute)
+	if value, ok := m.Get("k"); !ok || string(value) != "v" {
+		t.Fatalf("Expected cached value, got %q, %v", value, ok)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := m.Get("k"); ok {
+		t.Error("Expected entry to expire after its TTL")
+	}
+	if m.Len() != 0 {
+		t.Errorf("Expected expired entry to be evicted, have %d entries", m.Len())
+	}
+}
+
+func TestMemoryLRUEviction(t *testing.T) {
+	m := NewMemory(2)
+	m.Set("a", []byte("1"), time.Hour)
+	m.Set("b", []byte("2"), time.Hour)
+	m.Get("a") // a is now more recently used than b
+	m.Set("c", []byte("3"), time.Hour)
+
+	if _, ok := m.Get("b"); ok {
+		t.Error("Expected least recently used entry to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := m.Get(key); !ok {
+			t.Errorf("Expected %s to be cached", key)
+		}
+	}
+}
+
+func TestMemoryCopiesValues(t *testing.T) {
+	m := NewMemory(0)
+	value := []byte("abc")
+	m.Set("k", value, time.Hour)
+	value[0] = 'x'
+
+	got, _ := m.Get("k")
+	got[1] = 'y'
+	if again, _ := m.Get("k"); string(again) != "abc" {
+		t.Errorf("Expected cache to hold its own copy, got %q", again)
+	}
+
+	m.Delete("k")
+	if _, ok := m.Get("k"); ok {
+		t.Error("Expected entry to be deleted")
+	}
+}
@@ -0,0 +1,284 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package cache
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Redis defaults
+const (
+	DefaultRedisTimeout  = 2 * time.Second
+	DefaultRedisPoolSize = 8
+)
+
+// RedisConfig configures a Redis cache
+type RedisConfig struct {
+	Addr     string // host:port of the Redis server
+	Username string // ACL user (Redis 6+); empty authenticates with Password alone
+	Password string // AUTH password, if the server requires one
+	DB       int    // Database selected on each connection
+	Prefix   string // Prepended to every key, so deployments can share a server
+
+	Timeout  time.Duration // Dial and per-command timeout, default DefaultRedisTimeout
+	PoolSize int           // Idle connections kept for reuse, default DefaultRedisPoolSize
+	TLS      *tls.Config   // Connect over TLS if set
+}
+
+// Redis is a cache shared by every process that points at the same Redis server, so
+// horizontally scaled replicas see each other's entries. It speaks the Redis protocol
+// directly and connects lazily. Redis errors are logged and treated as cache misses
+type Redis struct {
+	config RedisConfig
+
+	mu     sync.Mutex
+	idle   []*redisConn
+	closed bool
+}
+
+// redisConn is one connection to the server
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// redisError is an error reply from the server; the connection stays usable
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+// errRedisClosed is returned for commands issued after Close
+var errRedisClosed = errors.New("redis cache closed")
+
+// NewRedis creates a Redis cache; the server is first contacted by the first command
+func NewRedis(config RedisConfig) *Redis {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultRedisTimeout
+	}
+	if config.PoolSize <= 0 {
+		config.PoolSize = DefaultRedisPoolSize
+	}
+	return &Redis{config: config}
+}
+
+// Get returns the value for key if present; Redis expires entries itself
+func (r *Redis) Get(key string) ([]byte, bool) {
+	reply, err := r.do("GET", r.config.Prefix+key)
+	if err != nil {
+		log.Printf("⚠️  Redis cache GET failed: %v", err)
+		return nil, false
+	}
+	value, ok := reply.([]byte)
+	return value, ok && value != nil
+}
+
+// Set stores value under key for ttl, rounded down to milliseconds
+func (r *Redis) Set(key string, value []byte, ttl time.Duration) {
+	ms := ttl.Milliseconds()
+	if ms <= 0 {
+		return
+	}
+	if _, err := r.do("SET", r.config.Prefix+key, value, "PX", strconv.FormatInt(ms, 10)); err != nil {
+		log.Printf("⚠️  Redis cache SET failed: %v", err)
+	}
+}
+
+// Delete removes key
+func (r *Redis) Delete(key string) {
+	if _, err := r.do("DEL", r.config.Prefix+key); err != nil {
+		log.Printf("⚠️  Redis cache DEL failed: %v", err)
+	}
+}
+
+// Ping checks that the server is reachable and accepts the configured credentials
+func (r *Redis) Ping() error {
+	_, err := r.do("PING")
+	return err
+}
+
+// Close closes idle connections; later commands fail
+func (r *Redis) Close() error {
+	r.mu.Lock()
+	idle := r.idle
+	r.idle = nil
+	r.closed = true
+	r.mu.Unlock()
+
+	for _, conn := range idle {
+		conn.conn.Close()
+	}
+	return nil
+}
+
+// do runs one command on a pooled connection. Connections that fail mid-command are
+// dropped, since their reply stream can no longer be trusted
+func (r *Redis) do(args ...any) (any, error) {
+	conn, err := r.acquire()
+	if err != nil {
+		return nil, err
+	}
+	reply, err := conn.do(r.config.Timeout, args...)
+	if err != nil {
+		conn.conn.Close()
+		return nil, err
+	}
+	r.release(conn)
+	if e, ok := reply.(redisError); ok {
+		return nil, e
+	}
+	return reply, nil
+}
+
+// acquire returns an idle connection or dials a new one
+func (r *Redis) acquire() (*redisConn, error) {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil, errRedisClosed
+	}
+	if n := len(r.idle); n > 0 {
+		conn := r.idle[n-1]
+		r.idle = r.idle[:n-1]
+		r.mu.Unlock()
+		return conn, nil
+	}
+	r.mu.Unlock()
+	return r.dial()
+}
+
+// release returns conn to the pool, closing it if the pool is full or closed
+func (r *Redis) release(conn *redisConn) {
+	r.mu.Lock()
+	if !r.closed && len(r.idle) < r.config.PoolSize {
+		r.idle = append(r.idle, conn)
+		r.mu.Unlock()
+		return
+	}
+	r.mu.Unlock()
+	conn.conn.Close()
+}
+
+// dial connects to the server, authenticating and selecting the database
+func (r *Redis) dial() (*redisConn, error) {
+	dialer := &net.Dialer{Timeout: r.config.Timeout}
+	var conn net.Conn
+	var err error
+	if r.config.TLS != nil {
+		conn, err = tls.DialWithDialer(dialer, "tcp", r.config.Addr, r.config.TLS)
+	} else {
+		conn, err = dialer.Dial("tcp", r.config.Addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to redis %s: %w", r.config.Addr, err)
+	}
+	c := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+
+	var setup [][]any
+	if r.config.Password != "" {
+		if r.config.Username != "" {
+			setup = append(setup, []any{"AUTH", r.config.Username, r.config.Password})
+		} else {
+			setup = append(setup, []any{"AUTH", r.config.Password})
+		}
+	}
+	if r.config.DB != 0 {
+		setup = append(setup, []any{"SELECT", strconv.Itoa(r.config.DB)})
+	}
+	for _, args := range setup {
+		reply, err := c.do(r.config.Timeout, args...)
+		if err == nil {
+			if e, ok := reply.(redisError); ok {
+				err = e
+			}
+		}
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("redis %s %v: %w", r.config.Addr, args[0], err)
+		}
+	}
+	return c, nil
+}
+
+// do writes a command and reads its reply
+func (c *redisConn) do(timeout time.Duration, args ...any) (any, error) {
+	if err := c.conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	buf := []byte("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		var value []byte
+		switch arg := arg.(type) {
+		case string:
+			value = []byte(arg)
+		case []byte:
+			value = arg
+		default:
+			return nil, fmt.Errorf("unsupported redis argument %T", arg)
+		}
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(value)), 10)
+		buf = append(buf, "\r\n"...)
+		buf = append(buf, value...)
+		buf = append(buf, "\r\n"...)
+	}
+	if _, err := c.conn.Write(buf); err != nil {
+		return nil, err
+	}
+	return c.readReply()
+}
+
+// readReply reads one reply: a status string, redisError, int64, or bulk []byte (nil if absent)
+func (c *redisConn) readReply() (any, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("malformed redis reply %q", line)
+	}
+	kind, body := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return body, nil
+	case '-':
+		return redisError(body), nil
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("malformed redis bulk length %q", body)
+		}
+		if n < 0 {
+			return []byte(nil), nil
+		}
+		value := make([]byte, n+2)
+		if _, err := io.ReadFull(c.reader, value); err != nil {
+			return nil, err
+		}
+		return value[:n], nil
+	default:
+		return nil, fmt.Errorf("unexpected redis reply %q", line)
+	}
+}
@@ -0,0 +1,193 @@
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis serves GET, SET, DEL, AUTH, SELECT and PING from memory
+type fakeRedis struct {
+	listener net.Listener
+	password string
+
+	mu       sync.Mutex
+	values   map[string]string
+	ttls     map[string]string
+	commands []string
+}
+
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	f := &fakeRedis{listener: listener, password: password, values: map[string]string{}, ttls: map[string]string{}}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	authed := f.password == ""
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		f.mu.Lock()
+		f.commands = append(f.commands, strings.Join(args, " "))
+		var reply string
+		switch {
+		case args[0] == "AUTH":
+			if args[len(args)-1] == f.password {
+				authed = true
+				reply = "+OK\r\n"
+			} else {
+				reply = "-WRONGPASS invalid password\r\n"
+			}
+		case !authed:
+			reply = "-NOAUTH Authentication required\r\n"
+		case args[0] == "PING":
+			reply = "+PONG\r\n"
+		case args[0] == "SELECT":
+			reply = "+OK\r\n"
+		case args[0] == "GET":
+			if value, ok := f.values[args[1]]; ok {
+				reply = fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+			} else {
+				reply = "$-1\r\n"
+			}
+		case args[0] == "SET":
+			f.values[args[1]] = args[2]
+			f.ttls[args[1]] = args[4]
+			reply = "+OK\r\n"
+		case args[0] == "DEL":
+			delete(f.values, args[1])
+			reply = ":1\r\n"
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		f.mu.Unlock()
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		if line, err = reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+		value := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, value); err != nil {
+			return nil, err
+		}
+		args[i] = string(value[:size])
+	}
+	return args, nil
+}
+
+func TestRedisRoundTrip(t *testing.T) {
+	server := newFakeRedis(t, "secret")
+	r := NewRedis(RedisConfig{Addr: server.listener.Addr().String(), Password: "secret", DB: 2, Prefix: "teenet:"})
+	defer r.Close()
+
+	if err := r.Ping(); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+	if _, ok := r.Get("k"); ok {
+		t.Error("Expected a miss for an unset key")
+	}
+	r.Set("k", []byte("v\r\nwith binary \x00"), 1500*time.Millisecond)
+	if value, ok := r.Get("k"); !ok || string(value) != "v\r\nwith binary \x00" {
+		t.Errorf("Expected stored value, got %q, %v", value, ok)
+	}
+
+	server.mu.Lock()
+	ttl, prefixed := server.ttls["teenet:k"], server.values["teenet:k"] != ""
+	server.mu.Unlock()
+	if !prefixed {
+		t.Error("Expected key to be stored under the prefix")
+	}
+	if ttl != "1500" {
+		t.Errorf("Expected PX 1500, got %q", ttl)
+	}
+
+	r.Delete("k")
+	if _, ok := r.Get("k"); ok {
+		t.Error("Expected key to be deleted")
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if server.commands[0] != "AUTH secret" || server.commands[1] != "SELECT 2" {
+		t.Errorf("Expected AUTH and SELECT on connect, got %v", server.commands[:2])
+	}
+	for _, command := range server.commands[2:] {
+		if strings.HasPrefix(command, "AUTH") {
+			t.Error("Expected the connection to be reused")
+		}
+	}
+}
+
+func TestRedisWrongPassword(t *testing.T) {
+	server := newFakeRedis(t, "secret")
+	r := NewRedis(RedisConfig{Addr: server.listener.Addr().String(), Password: "wrong"})
+	defer r.Close()
+
+	if err := r.Ping(); err == nil || !strings.Contains(err.Error(), "WRONGPASS") {
+		t.Errorf("Expected authentication error, got %v", err)
+	}
+}
+
+func TestRedisUnreachableIsMiss(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	r := NewRedis(RedisConfig{Addr: addr, Timeout: 200 * time.Millisecond})
+	r.Set("k", []byte("v"), time.Minute)
+	if _, ok := r.Get("k"); ok {
+		t.Error("Expected a miss when Redis is unreachable")
+	}
+
+	r.Close()
+	if err := r.Ping(); err != errRedisClosed {
+		t.Errorf("Expected closed error after Close, got %v", err)
+	}
+}
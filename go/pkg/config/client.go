@@ -16,9 +16,12 @@ package config
 import (
 	"context"
 	"fmt"
+	"log"
+	"sort"
 	"time"
 
 	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/utils"
 	nmpb "github.com/TEENet-io/teenet-sdk/go/proto/node_management"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -33,20 +36,59 @@ const (
 )
 
 // NodeConfig holds node configuration information
+// RPCAddress/TargetCert and AppNodeAddr/AppNodeCert describe the preferred TEE and App node;
+// TEENodes and AppNodes list all of them, nearest to the preferred locality first
 type NodeConfig struct {
-	NodeID      uint32 `json:"node_id"`
-	RPCAddress  string `json:"rpc_address"`
-	Cert        []byte `json:"cert"`
-	Key         []byte `json:"key"`
-	TargetCert  []byte `json:"target_cert"`
-	AppNodeAddr string `json:"app_node_addr"`
-	AppNodeCert []byte `json:"app_node_cert"`
+	NodeID      uint32    `json:"node_id"`
+	RPCAddress  string    `json:"rpc_address"`
+	Cert        []byte    `json:"cert"`
+	Key         []byte    `json:"key"`
+	TargetCert  []byte    `json:"target_cert"`
+	TEENodes    []TEENode `json:"tee_nodes"`
+	AppNodeAddr string    `json:"app_node_addr"`
+	AppNodeCert []byte    `json:"app_node_cert"`
+	AppNodes    []AppNode `json:"app_nodes"`
+}
+
+// TEENode is a TEE node that can serve sign requests
+type TEENode struct {
+	RPCAddress string   `json:"rpc_address"`
+	Cert       []byte   `json:"cert"`
+	Locality   Locality `json:"locality"`
+}
+
+// AppNode is an App node serving key lookups and deployment information
+type AppNode struct {
+	RPCAddress string   `json:"rpc_address"`
+	Cert       []byte   `json:"cert"`
+	Locality   Locality `json:"locality"`
+}
+
+// Locality is the deployment region and zone of a node
+type Locality struct {
+	Region string `json:"region,omitempty"`
+	Zone   string `json:"zone,omitempty"`
+}
+
+// Affinity ranks how close l is to preferred: 2 for the same region and zone,
+// 1 for the same region, 0 otherwise or when no region is preferred
+func (l Locality) Affinity(preferred Locality) int {
+	if preferred.Region == "" || l.Region != preferred.Region {
+		return 0
+	}
+	if preferred.Zone != "" && l.Zone == preferred.Zone {
+		return 2
+	}
+	return 1
 }
 
 // Client pulls configuration from server (without TLS)
 type Client struct {
 	serverAddress string
 	timeout       time.Duration
+	dialOptions   []grpc.DialOption
+	locality      Locality
+	override      *Override
 }
 
 // NewClient creates a new configuration client
@@ -60,15 +102,29 @@ func NewClient(serverAddress string) *Client {
 // GetConfig retrieves node configuration from server
 func (c *Client) GetConfig(parentCtx context.Context) (*NodeConfig, error) {
 	// Use the parent context but add our own timeout
+	if c.override != nil && c.override.Standalone() {
+		return c.override.nodeConfig()
+	}
+
 	ctx, cancel := context.WithTimeout(parentCtx, c.timeout)
 	defer cancel()
-	return c.fetchFromServer(ctx)
+	config, err := c.fetchFromServer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if c.override != nil {
+		if err := c.override.apply(config); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
 }
 
 // fetchFromServer retrieves configuration from management server
 func (c *Client) fetchFromServer(ctx context.Context) (*NodeConfig, error) {
 	// Connect to config server (without TLS)
-	conn, err := grpc.NewClient(c.serverAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	opts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, c.dialOptions...)
+	conn, err := grpc.NewClient(utils.GRPCTarget(c.serverAddress), opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to config server: %w", err)
 	}
@@ -88,37 +144,64 @@ func (c *Client) fetchFromServer(ctx context.Context) (*NodeConfig, error) {
 		return nil, fmt.Errorf("failed to get peer nodes: %w", err)
 	}
 
-	// Find TEE node
-	var teeNode, appNode *nmpb.Peer
+	// Find TEE nodes and the App node
+	var teeNodes []TEENode
+	var appNodes []AppNode
 	for _, peer := range peers.Peers {
+		locality := Locality{Region: peer.Region, Zone: peer.Zone}
 		if peer.Type == TypeAppNode {
-			appNode = peer
+			appNodes = append(appNodes, AppNode{RPCAddress: peer.RpcAddress, Cert: peer.Cert, Locality: locality})
 		} else if peer.Type == TypeTeeNode {
-			teeNode = peer
-		}
-		if teeNode != nil && appNode != nil {
-			break
+			teeNodes = append(teeNodes, TEENode{RPCAddress: peer.RpcAddress, Cert: peer.Cert, Locality: locality})
 		}
 	}
 
-	if teeNode == nil && appNode == nil {
-		return nil, fmt.Errorf("no TEE or App node found")
+	if len(teeNodes) == 0 {
+		return nil, fmt.Errorf("no TEE node found")
+	}
+	if len(appNodes) == 0 {
+		return nil, fmt.Errorf("no App node found")
 	}
 
+	// Nearest nodes first; the server's order is kept among equally close nodes
+	sort.SliceStable(teeNodes, func(i, j int) bool {
+		return teeNodes[i].Locality.Affinity(c.locality) > teeNodes[j].Locality.Affinity(c.locality)
+	})
+	sort.SliceStable(appNodes, func(i, j int) bool {
+		return appNodes[i].Locality.Affinity(c.locality) > appNodes[j].Locality.Affinity(c.locality)
+	})
+
 	config := &NodeConfig{
 		NodeID:      nodeInfo.NodeId,
 		Cert:        nodeInfo.Cert,
 		Key:         nodeInfo.Key,
-		TargetCert:  teeNode.Cert,
-		RPCAddress:  teeNode.RpcAddress,
-		AppNodeAddr: appNode.RpcAddress,
-		AppNodeCert: appNode.Cert,
+		TargetCert:  teeNodes[0].Cert,
+		RPCAddress:  teeNodes[0].RPCAddress,
+		TEENodes:    teeNodes,
+		AppNodeAddr: appNodes[0].RPCAddress,
+		AppNodeCert: appNodes[0].Cert,
+		AppNodes:    appNodes,
 	}
 
-	fmt.Printf("Retrieved config from server, node ID: %d\n", config.NodeID)
+	log.Printf("Retrieved config from server, node ID: %d, TEE nodes: %d", config.NodeID, len(teeNodes))
 	return config, nil
 }
 
+// SetDialOptions sets extra gRPC dial options (e.g. interceptors) for the config server connection
+func (c *Client) SetDialOptions(opts ...grpc.DialOption) {
+	c.dialOptions = opts
+}
+
+// SetPreferredLocality orders TEE and App nodes so those in the given region and zone come first
+func (c *Client) SetPreferredLocality(locality Locality) {
+	c.locality = locality
+}
+
+// SetOverride supplies credentials and nodes locally, see Override; nil removes it
+func (c *Client) SetOverride(override *Override) {
+	c.override = override
+}
+
 // SetTimeout sets the timeout for config operations
 func (c *Client) SetTimeout(timeout time.Duration) {
 	c.timeout = timeout
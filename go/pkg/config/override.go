@@ -0,0 +1,167 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/secret"
+)
+
+// Override supplies credentials and nodes locally for air-gapped or pre-provisioned deployments.
+// Each PEM value comes from a file or inline; set values replace those of the config server.
+// With both TEE and App node addresses set, the config server isn't contacted at all
+type Override struct {
+	NodeID uint32 `json:"node_id"`
+
+	CertFile   string `json:"cert_file"`    // Client certificate
+	KeyFile    string `json:"key_file"`     // Client private key
+	PeerCAFile string `json:"peer_ca_file"` // CA certificates, or node certificates, trusted for TEE and App nodes
+	Cert       string `json:"cert"`         // Inline PEM in place of CertFile
+	Key        string `json:"key"`          // Inline PEM in place of KeyFile
+	PeerCA     string `json:"peer_ca"`      // Inline PEM in place of PeerCAFile
+
+	// KeyKeyring names a keyring item holding the client key, in place of KeyFile
+	KeyKeyring string         `json:"key_keyring"`
+	Keyring    secret.Keyring `json:"-"` // Keyring to read it from; the OS keyring if nil
+
+	TEENodes []string `json:"tee_nodes"` // TEE node RPC addresses
+	AppNodes []string `json:"app_nodes"` // App node RPC addresses
+}
+
+// IsZero reports whether nothing is overridden
+func (o *Override) IsZero() bool {
+	return o.NodeID == 0 && o.CertFile == "" && o.KeyFile == "" && o.PeerCAFile == "" &&
+		o.Cert == "" && o.Key == "" && o.PeerCA == "" && o.KeyKeyring == "" && !o.Standalone()
+}
+
+// Standalone reports whether the override describes the whole deployment
+func (o *Override) Standalone() bool {
+	return len(o.TEENodes) > 0 || len(o.AppNodes) > 0
+}
+
+// credentials loads the client certificate, key and peer CA, each nil if not set
+func (o *Override) credentials() (cert, key, peerCA []byte, err error) {
+	if cert, err = pemValue("client certificate", o.CertFile, o.Cert); err != nil {
+		return nil, nil, nil, err
+	}
+	if key, err = pemValue("client key", o.KeyFile, o.Key); err != nil {
+		return nil, nil, nil, err
+	}
+	if o.KeyKeyring != "" {
+		if key != nil {
+			return nil, nil, nil, fmt.Errorf("client key set both in a keyring and as a file or inline")
+		}
+		if key, err = o.keyringKey(); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	if (cert == nil) != (key == nil) {
+		return nil, nil, nil, fmt.Errorf("client certificate and key must be set together")
+	}
+	if peerCA, err = pemValue("peer CA", o.PeerCAFile, o.PeerCA); err != nil {
+		return nil, nil, nil, err
+	}
+	return cert, key, peerCA, nil
+}
+
+// keyringKey reads the client key from the keyring
+func (o *Override) keyringKey() ([]byte, error) {
+	keyring := o.Keyring
+	if keyring == nil {
+		var err error
+		if keyring, err = secret.OSKeyring(); err != nil {
+			return nil, err
+		}
+	}
+	key, err := keyring.Get(o.KeyKeyring)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client key from keyring: %w", err)
+	}
+	return key, nil
+}
+
+// pemValue reads a PEM value from file or returns the inline one
+func pemValue(name, file, inline string) ([]byte, error) {
+	if file != "" && inline != "" {
+		return nil, fmt.Errorf("%s set both as a file and inline", name)
+	}
+	if inline != "" {
+		return []byte(inline), nil
+	}
+	if file == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// nodeConfig builds the configuration of a standalone override
+// The client certificate may be left out for token authentication
+func (o *Override) nodeConfig() (*NodeConfig, error) {
+	if len(o.TEENodes) == 0 || len(o.AppNodes) == 0 {
+		return nil, fmt.Errorf("static configuration needs both TEE and App node addresses")
+	}
+	cert, key, peerCA, err := o.credentials()
+	if err != nil {
+		return nil, err
+	}
+	if peerCA == nil {
+		return nil, fmt.Errorf("static configuration needs a peer CA to verify nodes")
+	}
+
+	config := &NodeConfig{NodeID: o.NodeID, Cert: cert, Key: key}
+	for _, addr := range o.TEENodes {
+		config.TEENodes = append(config.TEENodes, TEENode{RPCAddress: addr, Cert: peerCA})
+	}
+	for _, addr := range o.AppNodes {
+		config.AppNodes = append(config.AppNodes, AppNode{RPCAddress: addr, Cert: peerCA})
+	}
+	config.RPCAddress, config.TargetCert = config.TEENodes[0].RPCAddress, peerCA
+	config.AppNodeAddr, config.AppNodeCert = config.AppNodes[0].RPCAddress, peerCA
+
+	log.Printf("Using static config, node ID: %d, TEE nodes: %d, App nodes: %d", config.NodeID, len(config.TEENodes), len(config.AppNodes))
+	return config, nil
+}
+
+// apply replaces the parts of a fetched configuration the override sets
+func (o *Override) apply(config *NodeConfig) error {
+	cert, key, peerCA, err := o.credentials()
+	if err != nil {
+		return err
+	}
+	if o.NodeID != 0 {
+		config.NodeID = o.NodeID
+	}
+	if cert != nil {
+		config.Cert, config.Key = cert, key
+		log.Printf("Using local client certificate instead of the config server's")
+	}
+	if peerCA != nil {
+		config.TargetCert, config.AppNodeCert = peerCA, peerCA
+		for i := range config.TEENodes {
+			config.TEENodes[i].Cert = peerCA
+		}
+		for i := range config.AppNodes {
+			config.AppNodes[i].Cert = peerCA
+		}
+		log.Printf("Verifying nodes against the local peer CA instead of the config server's certificates")
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/secret"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestOverrideStandalone(t *testing.T) {
+	dir := t.TempDir()
+	override := &Override{
+		NodeID:     7,
+		CertFile:   writeFile(t, dir, "client.crt", "cert"),
+		KeyFile:    writeFile(t, dir, "client.key", "key"),
+		PeerCAFile: writeFile(t, dir, "ca.crt", "ca"),
+		TEENodes:   []string{"tee-1:50051", "tee-2:50051"},
+		AppNodes:   []string{"app-1:50053"},
+	}
+	// An unreachable config server proves it isn't contacted
+	client := NewClient("127.0.0.1:1")
+	client.SetOverride(override)
+
+	config, err := client.GetConfig(context.Background())
+	if err != nil {
+		t.Fatalf("GetConfig failed: %v", err)
+	}
+	if config.NodeID != 7 || string(config.Cert) != "cert" || string(config.Key) != "key" {
+		t.Errorf("credentials = %d %q %q", config.NodeID, config.Cert, config.Key)
+	}
+	if len(config.TEENodes) != 2 || config.RPCAddress != "tee-1:50051" || string(config.TEENodes[1].Cert) != "ca" {
+		t.Errorf("TEE nodes = %+v", config.TEENodes)
+	}
+	if len(config.AppNodes) != 1 || config.AppNodeAddr != "app-1:50053" || string(config.AppNodeCert) != "ca" {
+		t.Errorf("App nodes = %+v", config.AppNodes)
+	}
+
+	// Without a client certificate, as with token authentication
+	tokenOnly := &Override{PeerCA: "ca", TEENodes: []string{"tee-1:50051"}, AppNodes: []string{"app-1:50053"}}
+	if config, err := tokenOnly.nodeConfig(); err != nil || config.Cert != nil {
+		t.Errorf("nodeConfig() = %+v, %v", config, err)
+	}
+}
+
+func TestOverrideInvalid(t *testing.T) {
+	nodes := Override{TEENodes: []string{"tee:50051"}, AppNodes: []string{"app:50053"}, PeerCA: "ca"}
+	tests := []struct {
+		name   string
+		modify func(o *Override)
+	}{
+		{name: "no App nodes", modify: func(o *Override) { o.AppNodes = nil }},
+		{name: "no TEE nodes", modify: func(o *Override) { o.TEENodes = nil }},
+		{name: "no peer CA", modify: func(o *Override) { o.PeerCA = "" }},
+		{name: "certificate without key", modify: func(o *Override) { o.Cert = "cert" }},
+		{name: "file and inline", modify: func(o *Override) { o.PeerCAFile = "ca.crt" }},
+		{name: "missing file", modify: func(o *Override) { o.PeerCA, o.PeerCAFile = "", filepath.Join(t.TempDir(), "missing.crt") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			override := nodes
+			tt.modify(&override)
+			if _, err := override.nodeConfig(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestOverrideApply(t *testing.T) {
+	fetched := &NodeConfig{
+		NodeID:      1,
+		Cert:        []byte("server cert"),
+		Key:         []byte("server key"),
+		TargetCert:  []byte("tee"),
+		TEENodes:    []TEENode{{RPCAddress: "tee:50051", Cert: []byte("tee")}},
+		AppNodeCert: []byte("app"),
+		AppNodes:    []AppNode{{RPCAddress: "app:50053", Cert: []byte("app")}},
+	}
+	override := &Override{Cert: "cert", Key: "key", PeerCA: "ca"}
+	if override.Standalone() || override.IsZero() {
+		t.Fatalf("Standalone() = %t, IsZero() = %t", override.Standalone(), override.IsZero())
+	}
+	if err := override.apply(fetched); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if fetched.NodeID != 1 || string(fetched.Cert) != "cert" || string(fetched.Key) != "key" {
+		t.Errorf("credentials = %d %q %q", fetched.NodeID, fetched.Cert, fetched.Key)
+	}
+	if string(fetched.TargetCert) != "ca" || string(fetched.TEENodes[0].Cert) != "ca" ||
+		string(fetched.AppNodeCert) != "ca" || string(fetched.AppNodes[0].Cert) != "ca" {
+		t.Errorf("node certificates not replaced: %+v", fetched)
+	}
+	if fetched.TEENodes[0].RPCAddress != "tee:50051" {
+		t.Errorf("addresses changed: %+v", fetched.TEENodes)
+	}
+	if !(&Override{}).IsZero() {
+		t.Error("empty override should be zero")
+	}
+}
+
+// memKeyring is an in-memory secret.Keyring
+type memKeyring map[string][]byte
+
+func (k memKeyring) Get(name string) ([]byte, error) {
+	value, ok := k[name]
+	if !ok {
+		return nil, secret.ErrNotFound
+	}
+	return append([]byte(nil), value...), nil
+}
+
+func (k memKeyring) Set(name string, value []byte) error {
+	k[name] = append([]byte(nil), value...)
+	return nil
+}
+
+func (k memKeyring) Delete(name string) error {
+	delete(k, name)
+	return nil
+}
+
+func TestOverrideKeyring(t *testing.T) {
+	keyring := memKeyring{"client": []byte("key")}
+	override := &Override{Cert: "cert", KeyKeyring: "client", Keyring: keyring, PeerCA: "ca",
+		TEENodes: []string{"tee:50051"}, AppNodes: []string{"app:50053"}}
+	config, err := override.nodeConfig()
+	if err != nil {
+		t.Fatalf("nodeConfig failed: %v", err)
+	}
+	if string(config.Key) != "key" {
+		t.Errorf("key = %q, want the keyring's", config.Key)
+	}
+
+	override.KeyKeyring = "missing"
+	if _, err := override.nodeConfig(); !errors.Is(err, secret.ErrNotFound) {
+		t.Errorf("nodeConfig() = %v, want ErrNotFound", err)
+	}
+	override.KeyKeyring, override.Key = "client", "inline"
+	if _, err := override.nodeConfig(); err == nil {
+		t.Error("expected a key set twice to be rejected")
+	}
+}
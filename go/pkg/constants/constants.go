@@ -27,17 +27,62 @@ const (
 	DefaultTaskTimeout = 10 * time.Second
 )
 
+// TEENodeCooldown is how long a TEE node that returned UNAVAILABLE is skipped when other nodes are healthy
+const TEENodeCooldown = 5 * time.Second
+
+// AppNodeCooldown is how long an App node that returned UNAVAILABLE is tried last
+const AppNodeCooldown = 5 * time.Second
+
+// Event subscription reconnect backoff
+const (
+	EventResubscribeMinBackoff = time.Second
+	EventResubscribeMaxBackoff = 30 * time.Second
+)
+
+// Key operation watch reconnect backoff
+const (
+	KeyOperationRewatchMinBackoff = time.Second
+	KeyOperationRewatchMaxBackoff = 30 * time.Second
+)
+
+// SigningPolicyCacheTTL is how long an app's signing policy is cached before it is fetched again
+const SigningPolicyCacheTTL = time.Minute
+
+// MaxVoteDelegationDepth is how many times a vote may be delegated onwards before it is refused
+const MaxVoteDelegationDepth = 3
+
+// VoteCommitTimeout bounds delivering the commit notifications of a two-phase voting round
+const VoteCommitTimeout = 10 * time.Second
+
+// DefaultVotingAddr is the default listen address of the voting service
+const DefaultVotingAddr = ":50051"
+
 // Protocol constants
 const (
-	ProtocolECDSA   uint32 = 1
-	ProtocolSchnorr uint32 = 2
+	ProtocolECDSA   Protocol = 1
+	ProtocolSchnorr Protocol = 2
+	ProtocolMuSig2  Protocol = 3 // Steps of a MuSig2 session on a Schnorr SECP256K1 key, see task.MuSig2Step
 )
 
 // Curve constants
 const (
-	CurveED25519   uint32 = 1
-	CurveSECP256K1 uint32 = 2
-	CurveSECP256R1 uint32 = 3
+	CurveED25519   Curve = 1
+	CurveSECP256K1 Curve = 2
+	CurveSECP256R1 Curve = 3
+)
+
+// Key usage flags
+const (
+	KeyUsageSign    KeyUsage = 1 << iota // Producing signatures
+	KeyUsageEncrypt                      // Encrypting and decrypting data
+	KeyUsageDerive                       // Deriving shared secrets (ECDH)
+)
+
+// ED25519 signing mode constants (RFC 8032 variants)
+const (
+	ED25519ModePure uint32 = 0 // Ed25519 over the message as-is
+	ED25519ModePh   uint32 = 1 // Ed25519ph over the SHA-512 prehash of the message
+	ED25519ModeCtx  uint32 = 2 // Ed25519ctx with a mandatory context string
 )
 
 // gRPC retry configuration constants
@@ -0,0 +1,3 @@
+module github.com/TEENet-io/teenet-sdk/go/pkg/constants
+
+go 1.24.2
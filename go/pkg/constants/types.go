@@ -0,0 +1,201 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package constants
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Protocol identifies a signature protocol
+type Protocol uint32
+
+// Curve identifies an elliptic curve
+type Curve uint32
+
+// KeyUsage is the set of operations a key may be used for; zero leaves the key unrestricted
+type KeyUsage uint32
+
+var protocolNames = map[Protocol]string{
+	ProtocolECDSA:   "ecdsa",
+	ProtocolSchnorr: "schnorr",
+	ProtocolMuSig2:  "musig2",
+}
+
+var curveNames = map[Curve]string{
+	CurveED25519:   "ed25519",
+	CurveSECP256K1: "secp256k1",
+	CurveSECP256R1: "secp256r1",
+}
+
+var keyUsageNames = []struct {
+	usage KeyUsage
+	name  string
+}{
+	{KeyUsageSign, "sign"},
+	{KeyUsageEncrypt, "encrypt"},
+	{KeyUsageDerive, "derive"},
+}
+
+// String returns the lowercase protocol name, e.g. "ecdsa"
+func (p Protocol) String() string {
+	if name, ok := protocolNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("Protocol(%d)", uint32(p))
+}
+
+// MarshalJSON encodes known protocols by name and unknown ones as numbers
+func (p Protocol) MarshalJSON() ([]byte, error) {
+	if name, ok := protocolNames[p]; ok {
+		return json.Marshal(name)
+	}
+	return json.Marshal(uint32(p))
+}
+
+// UnmarshalJSON accepts either a protocol name or its numeric value
+func (p *Protocol) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		for value, n := range protocolNames {
+			if n == name {
+				*p = value
+				return nil
+			}
+		}
+		num, err := strconv.ParseUint(name, 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown protocol: %q", name)
+		}
+		*p = Protocol(num)
+		return nil
+	}
+
+	var num uint32
+	if err := json.Unmarshal(data, &num); err != nil {
+		return fmt.Errorf("invalid protocol: %s", string(data))
+	}
+	*p = Protocol(num)
+	return nil
+}
+
+// String returns the lowercase curve name, e.g. "secp256k1"
+func (c Curve) String() string {
+	if name, ok := curveNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("Curve(%d)", uint32(c))
+}
+
+// MarshalJSON encodes known curves by name and unknown ones as numbers
+func (c Curve) MarshalJSON() ([]byte, error) {
+	if name, ok := curveNames[c]; ok {
+		return json.Marshal(name)
+	}
+	return json.Marshal(uint32(c))
+}
+
+// UnmarshalJSON accepts either a curve name or its numeric value
+func (c *Curve) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		for value, n := range curveNames {
+			if n == name {
+				*c = value
+				return nil
+			}
+		}
+		num, err := strconv.ParseUint(name, 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown curve: %q", name)
+		}
+		*c = Curve(num)
+		return nil
+	}
+
+	var num uint32
+	if err := json.Unmarshal(data, &num); err != nil {
+		return fmt.Errorf("invalid curve: %s", string(data))
+	}
+	*c = Curve(num)
+	return nil
+}
+
+// ParseKeyUsage combines usage names ("sign", "encrypt", "derive") into a KeyUsage
+func ParseKeyUsage(names []string) (KeyUsage, error) {
+	var usage KeyUsage
+	for _, name := range names {
+		found := false
+		for _, known := range keyUsageNames {
+			if known.name == name {
+				usage |= known.usage
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("unknown key usage: %q", name)
+		}
+	}
+	return usage, nil
+}
+
+// Allows reports whether the key may be used for every operation in op; unrestricted keys allow all
+func (u KeyUsage) Allows(op KeyUsage) bool {
+	return u == 0 || u&op == op
+}
+
+// Names returns the names of the usages set in u
+func (u KeyUsage) Names() []string {
+	var names []string
+	for _, known := range keyUsageNames {
+		if u&known.usage != 0 {
+			names = append(names, known.name)
+		}
+	}
+	return names
+}
+
+// String returns the usage names joined with "|", or "unrestricted" for zero
+func (u KeyUsage) String() string {
+	if u == 0 {
+		return "unrestricted"
+	}
+	return strings.Join(u.Names(), "|")
+}
+
+// MarshalJSON encodes the usage as a list of names
+func (u KeyUsage) MarshalJSON() ([]byte, error) {
+	names := u.Names()
+	if names == nil {
+		names = []string{}
+	}
+	return json.Marshal(names)
+}
+
+// UnmarshalJSON accepts a list of usage names
+func (u *KeyUsage) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return fmt.Errorf("invalid key usage: %s", string(data))
+	}
+	usage, err := ParseKeyUsage(names)
+	if err != nil {
+		return err
+	}
+	*u = usage
+	return nil
+}
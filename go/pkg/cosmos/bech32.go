@@ -0,0 +1,141 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package cosmos
+
+import (
+	"fmt"
+	"strings"
+)
+
+// bech32Charset maps 5-bit values to bech32 characters (BIP 173)
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// EncodeBech32 encodes data under a human-readable prefix, e.g. an account address under "cosmos"
+func EncodeBech32(hrp string, data []byte) (string, error) {
+	if hrp == "" || strings.ToLower(hrp) != hrp {
+		return "", fmt.Errorf("bech32 prefix %q must be non-empty lower case", hrp)
+	}
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", fmt.Errorf("invalid character in bech32 prefix %q", hrp)
+		}
+	}
+	values, err := convertBits(data, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return bech32Encode(hrp, values), nil
+}
+
+// DecodeBech32 decodes a bech32 string into its prefix and data
+func DecodeBech32(s string) (string, []byte, error) {
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, fmt.Errorf("bech32 string %q has mixed case", s)
+	}
+	s = strings.ToLower(s)
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+7 > len(s) {
+		return "", nil, fmt.Errorf("invalid bech32 string %q", s)
+	}
+	hrp := s[:sep]
+	values := make([]byte, len(s)-sep-1)
+	for i := range values {
+		v := strings.IndexByte(bech32Charset, s[sep+1+i])
+		if v < 0 {
+			return "", nil, fmt.Errorf("invalid bech32 character %q", s[sep+1+i])
+		}
+		values[i] = byte(v)
+	}
+	if bech32Polymod(append(bech32HRPExpand(hrp), values...)) != 1 {
+		return "", nil, fmt.Errorf("invalid bech32 checksum in %q", s)
+	}
+	decoded, err := convertBits(values[:len(values)-6], 5, 8, false)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid bech32 data in %q: %w", s, err)
+	}
+	return hrp, decoded, nil
+}
+
+// bech32Encode appends the checksum to 5-bit values and encodes them
+func bech32Encode(hrp string, values []byte) string {
+	polymod := bech32Polymod(append(append(bech32HRPExpand(hrp), values...), 0, 0, 0, 0, 0, 0)) ^ 1
+	var b strings.Builder
+	b.WriteString(hrp)
+	b.WriteByte('1')
+	for _, v := range values {
+		b.WriteByte(bech32Charset[v])
+	}
+	for i := 0; i < 6; i++ {
+		b.WriteByte(bech32Charset[(polymod>>(5*(5-i)))&31])
+	}
+	return b.String()
+}
+
+// bech32Polymod computes the BCH checksum over values
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i, g := range generator {
+			if (top>>i)&1 == 1 {
+				chk ^= g
+			}
+		}
+	}
+	return chk
+}
+
+// bech32HRPExpand expands the prefix for checksumming
+func bech32HRPExpand(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+// convertBits regroups bits from groups of fromBits to groups of toBits. With pad the last group
+// is padded with zeros; without, leftover bits must be zero padding of fewer than fromBits
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var acc uint32
+	var n uint
+	maxValue := uint32(1)<<toBits - 1
+	var out []byte
+	for _, v := range data {
+		if uint32(v)>>fromBits != 0 {
+			return nil, fmt.Errorf("invalid %d-bit value %d", fromBits, v)
+		}
+		acc = acc<<fromBits | uint32(v)
+		n += fromBits
+		for n >= toBits {
+			n -= toBits
+			out = append(out, byte(acc>>n&maxValue))
+		}
+		acc &= uint32(1)<<n - 1
+	}
+	if pad {
+		if n > 0 {
+			out = append(out, byte(acc<<(toBits-n)&maxValue))
+		}
+	} else if n >= fromBits || acc != 0 {
+		return nil, fmt.Errorf("invalid padding")
+	}
+	return out, nil
+}
@@ -0,0 +1,46 @@
+package cosmos
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBech32(t *testing.T) {
+	// Valid strings from BIP 173
+	for _, s := range []string{"A12UEL5L", "a12uel5l", "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw"} {
+		hrp, data, err := DecodeBech32(s)
+		if err != nil {
+			t.Errorf("DecodeBech32(%q) failed: %v", s, err)
+			continue
+		}
+		encoded, err := EncodeBech32(hrp, data)
+		if err != nil || encoded != strings.ToLower(s) {
+			t.Errorf("EncodeBech32 round trip of %q = %q, %v", s, encoded, err)
+		}
+	}
+
+	for _, s := range []string{
+		"a12uel5m",        // bad checksum
+		"A12uEL5L",        // mixed case
+		"1qzzfhee",        // empty prefix
+		"abcdef1qpzrz9x8", // too short, bad checksum
+		"a1b2uel5l",       // invalid character
+	} {
+		if _, _, err := DecodeBech32(s); err == nil {
+			t.Errorf("Expected DecodeBech32(%q) to fail", s)
+		}
+	}
+
+	data := []byte{0x00, 0x14, 0xff, 0x7a, 0x01}
+	encoded, err := EncodeBech32("cosmos", data)
+	if err != nil {
+		t.Fatalf("EncodeBech32 failed: %v", err)
+	}
+	if hrp, decoded, err := DecodeBech32(encoded); err != nil || hrp != "cosmos" || !bytes.Equal(decoded, data) {
+		t.Errorf("Expected round trip of %x, got %q %x %v", data, hrp, decoded, err)
+	}
+	if _, err := EncodeBech32("Cosmos", data); err == nil {
+		t.Error("Expected an upper case prefix to be rejected")
+	}
+}
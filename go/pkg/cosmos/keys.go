@@ -0,0 +1,123 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package cosmos
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+// Public key types of SECP256K1 keys
+const (
+	PubKeyTypeURL   = "/cosmos.crypto.secp256k1.PubKey" // Protobuf Any type URL, used in AuthInfo signer infos
+	AminoPubKeyType = "tendermint/PubKeySecp256k1"      // Amino JSON type, used in StdSignature
+)
+
+// Account describes the account of a SECP256K1 key, like a wallet's AccountData
+type Account struct {
+	Address string `json:"address"` // Bech32 account address
+	Algo    string `json:"algo"`    // Always "secp256k1"
+	PubKey  []byte `json:"pubkey"`  // 33-byte compressed public key
+}
+
+// PubKey is an Amino JSON public key
+type PubKey struct {
+	Type  string `json:"type"`
+	Value []byte `json:"value"`
+}
+
+// StdSignature is a signature together with the public key that made it
+type StdSignature struct {
+	PubKey    PubKey `json:"pub_key"`
+	Signature []byte `json:"signature"` // 64-byte R || S with low S
+}
+
+// DirectSignResponse is the result of signing a sign doc, in the shape of a wallet's signDirect
+// response. Signature.Signature goes into TxRaw.signatures at the signer's position
+type DirectSignResponse struct {
+	Signed    *SignDoc     `json:"signed"`
+	Signature StdSignature `json:"signature"`
+	Account   Account      `json:"account"`
+}
+
+// NewAccount derives the account of a SECP256K1 public key (compressed, uncompressed or raw X || Y)
+// under a bech32 address prefix such as "cosmos" or "osmo"
+func NewAccount(prefix string, publicKey []byte) (*Account, error) {
+	compressed, err := CompressPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	sha := sha256.Sum256(compressed)
+	hash := ripemd160(sha[:])
+	address, err := EncodeBech32(prefix, hash[:])
+	if err != nil {
+		return nil, err
+	}
+	return &Account{Address: address, Algo: "secp256k1", PubKey: compressed}, nil
+}
+
+// CompressPublicKey returns the 33-byte compressed form of a SECP256K1 public key
+func CompressPublicKey(publicKey []byte) ([]byte, error) {
+	return verification.ExportPublicKey(constants.CurveSECP256K1, publicKey, verification.PublicKeyFormatCompressed)
+}
+
+// PubKeyAny encodes a SECP256K1 public key as a protobuf Any, for AuthInfo signer infos
+func PubKeyAny(publicKey []byte) ([]byte, error) {
+	compressed, err := CompressPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	var buf []byte
+	buf = appendBytesField(buf, 1, []byte(PubKeyTypeURL))
+	return appendBytesField(buf, 2, appendBytesField(nil, 1, compressed)), nil
+}
+
+// NormalizeSignature converts a SECP256K1 ECDSA signature (DER or raw) over the sign doc's hash
+// into the 64-byte low-S R || S form Cosmos chains require, and checks it verifies
+func NormalizeSignature(doc *SignDoc, signature, publicKey []byte) ([]byte, error) {
+	sig, err := verification.ParseSignature(constants.ProtocolECDSA, signature)
+	if err != nil {
+		return nil, err
+	}
+	curveOrder := btcec.S256().N
+	if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || sig.R.Cmp(curveOrder) >= 0 || sig.S.Cmp(curveOrder) >= 0 {
+		return nil, fmt.Errorf("signature R or S is out of range")
+	}
+	if sig.S.Cmp(new(big.Int).Rsh(curveOrder, 1)) > 0 {
+		sig.S = new(big.Int).Sub(curveOrder, sig.S)
+	}
+	raw := make([]byte, verification.RawSignatureSize)
+	sig.R.FillBytes(raw[:32])
+	sig.S.FillBytes(raw[32:])
+
+	valid, err := VerifySignDoc(doc, publicKey, raw)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, fmt.Errorf("signature does not verify over the sign doc")
+	}
+	return raw, nil
+}
+
+// VerifySignDoc verifies a 64-byte low-S signature over the sign doc, as Cosmos chains do
+func VerifySignDoc(doc *SignDoc, publicKey, signature []byte) (bool, error) {
+	return verification.VerifySignatureWithOptions(doc.Marshal(), publicKey, signature, constants.ProtocolECDSA, constants.CurveSECP256K1,
+		&verification.VerifyOptions{RejectHighS: true, Format: verification.SignatureFormatRaw})
+}
@@ -0,0 +1,130 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package cosmos encodes Cosmos SDK sign docs, public keys and addresses for signing with
+// SECP256K1 app keys in SIGN_MODE_DIRECT
+package cosmos
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// SignDoc is the cosmos.tx.v1beta1.SignDoc signed in SIGN_MODE_DIRECT. BodyBytes and
+// AuthInfoBytes are the encoded TxBody and AuthInfo exactly as they will be broadcast
+type SignDoc struct {
+	BodyBytes     []byte `json:"bodyBytes"`
+	AuthInfoBytes []byte `json:"authInfoBytes"`
+	ChainID       string `json:"chainId"`
+	AccountNumber uint64 `json:"accountNumber,string"`
+}
+
+// SignDoc field numbers
+const (
+	fieldBodyBytes     = 1
+	fieldAuthInfoBytes = 2
+	fieldChainID       = 3
+	fieldAccountNumber = 4
+)
+
+// Protobuf wire types
+const (
+	wireVarint = 0
+	wireBytes  = 2
+)
+
+// Marshal encodes the sign doc as protobuf the way the Cosmos SDK does: fields in order,
+// defaults omitted. These are the bytes whose SHA-256 hash is signed
+func (d *SignDoc) Marshal() []byte {
+	var buf []byte
+	buf = appendBytesField(buf, fieldBodyBytes, d.BodyBytes)
+	buf = appendBytesField(buf, fieldAuthInfoBytes, d.AuthInfoBytes)
+	buf = appendBytesField(buf, fieldChainID, []byte(d.ChainID))
+	if d.AccountNumber != 0 {
+		buf = binary.AppendUvarint(buf, fieldAccountNumber<<3|wireVarint)
+		buf = binary.AppendUvarint(buf, d.AccountNumber)
+	}
+	return buf
+}
+
+// Hash returns the SHA-256 digest of the encoded sign doc, which SECP256K1 keys sign
+func (d *SignDoc) Hash() []byte {
+	hash := sha256.Sum256(d.Marshal())
+	return hash[:]
+}
+
+// Validate checks the fields every sign doc needs
+func (d *SignDoc) Validate() error {
+	switch {
+	case len(d.BodyBytes) == 0:
+		return fmt.Errorf("sign doc body is required")
+	case len(d.AuthInfoBytes) == 0:
+		return fmt.Errorf("sign doc auth info is required")
+	case d.ChainID == "":
+		return fmt.Errorf("sign doc chain ID is required")
+	}
+	return nil
+}
+
+// ParseSignDoc decodes an encoded sign doc, such as one produced by CosmJS. Unknown fields are
+// rejected, since they would be signed without being understood
+func ParseSignDoc(data []byte) (*SignDoc, error) {
+	doc := &SignDoc{}
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid sign doc field key")
+		}
+		data = data[n:]
+		field, wireType := key>>3, key&7
+
+		switch {
+		case field == fieldAccountNumber && wireType == wireVarint:
+			value, n := binary.Uvarint(data)
+			if n <= 0 {
+				return nil, fmt.Errorf("invalid sign doc account number")
+			}
+			doc.AccountNumber = value
+			data = data[n:]
+		case field >= fieldBodyBytes && field <= fieldChainID && wireType == wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return nil, fmt.Errorf("invalid length of sign doc field %d", field)
+			}
+			value := data[n : n+int(length)]
+			data = data[n+int(length):]
+			switch field {
+			case fieldBodyBytes:
+				doc.BodyBytes = append([]byte(nil), value...)
+			case fieldAuthInfoBytes:
+				doc.AuthInfoBytes = append([]byte(nil), value...)
+			default:
+				doc.ChainID = string(value)
+			}
+		default:
+			return nil, fmt.Errorf("unknown sign doc field %d (wire type %d)", field, wireType)
+		}
+	}
+	return doc, nil
+}
+
+// appendBytesField encodes a length-delimited field, omitted if empty
+func appendBytesField(buf []byte, field uint64, value []byte) []byte {
+	if len(value) == 0 {
+		return buf
+	}
+	buf = binary.AppendUvarint(buf, field<<3|wireBytes)
+	buf = binary.AppendUvarint(buf, uint64(len(value)))
+	return append(buf, value...)
+}
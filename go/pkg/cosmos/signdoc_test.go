@@ -0,0 +1,116 @@
+package cosmos
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+func TestSignDocMarshal(t *testing.T) {
+	doc := &SignDoc{BodyBytes: []byte{0xaa}, AuthInfoBytes: []byte{0xbb, 0xcc}, ChainID: "test-1", AccountNumber: 300}
+	want := "0a01aa" + "1202bbcc" + "1a06" + hex.EncodeToString([]byte("test-1")) + "20ac02"
+	if got := hex.EncodeToString(doc.Marshal()); got != want {
+		t.Errorf("Marshal() = %s, want %s", got, want)
+	}
+
+	parsed, err := ParseSignDoc(doc.Marshal())
+	if err != nil {
+		t.Fatalf("ParseSignDoc failed: %v", err)
+	}
+	if !bytes.Equal(parsed.Marshal(), doc.Marshal()) {
+		t.Errorf("Expected round trip, got %+v", parsed)
+	}
+
+	// Account number zero is omitted, as protobuf does for defaults
+	zero := &SignDoc{BodyBytes: []byte{1}, AuthInfoBytes: []byte{2}, ChainID: "c"}
+	if got := hex.EncodeToString(zero.Marshal()); got != "0a0101120102"+"1a0163" {
+		t.Errorf("Unexpected encoding without account number: %s", got)
+	}
+
+	for name, data := range map[string]string{
+		"unknown field":   "2801",
+		"truncated":       "0a05aa",
+		"wrong wire type": "0801",
+	} {
+		raw, _ := hex.DecodeString(data)
+		if _, err := ParseSignDoc(raw); err == nil {
+			t.Errorf("%s: expected ParseSignDoc to fail", name)
+		}
+	}
+}
+
+func TestNewAccount(t *testing.T) {
+	// The generator point, whose hash160 is well known from Bitcoin (1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH)
+	privKey, _ := btcec.PrivKeyFromBytes([]byte{1})
+	publicKey := privKey.PubKey()
+
+	account, err := NewAccount("cosmos", publicKey.SerializeUncompressed())
+	if err != nil {
+		t.Fatalf("NewAccount failed: %v", err)
+	}
+	if !bytes.Equal(account.PubKey, publicKey.SerializeCompressed()) || account.Algo != "secp256k1" {
+		t.Errorf("Expected the compressed key, got %x", account.PubKey)
+	}
+	hrp, hash, err := DecodeBech32(account.Address)
+	if err != nil || hrp != "cosmos" {
+		t.Fatalf("Expected a cosmos address, got %q: %v", account.Address, err)
+	}
+	if hex.EncodeToString(hash) != "751e76e8199196d454941c45d1b3a323f1433bd6" {
+		t.Errorf("Unexpected address hash %x", hash)
+	}
+
+	any, err := PubKeyAny(publicKey.SerializeCompressed())
+	if err != nil {
+		t.Fatalf("PubKeyAny failed: %v", err)
+	}
+	want := append([]byte{0x0a, byte(len(PubKeyTypeURL))}, PubKeyTypeURL...)
+	want = append(want, 0x12, 35, 0x0a, 33)
+	want = append(want, publicKey.SerializeCompressed()...)
+	if !bytes.Equal(any, want) {
+		t.Errorf("Unexpected Any encoding %x", any)
+	}
+}
+
+func TestNormalizeSignature(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	publicKey := privKey.PubKey().SerializeCompressed()
+	doc := &SignDoc{BodyBytes: []byte("body"), AuthInfoBytes: []byte("auth"), ChainID: "test-1", AccountNumber: 7}
+	hash := sha256.Sum256(doc.Marshal())
+	if !bytes.Equal(doc.Hash(), hash[:]) {
+		t.Fatal("Expected Hash to be the SHA-256 of the encoding")
+	}
+	der := btcecdsa.Sign(privKey, hash[:]).Serialize()
+
+	raw, err := NormalizeSignature(doc, der, publicKey)
+	if err != nil {
+		t.Fatalf("NormalizeSignature failed: %v", err)
+	}
+	if len(raw) != verification.RawSignatureSize {
+		t.Fatalf("Expected a raw signature, got %d bytes", len(raw))
+	}
+
+	// The high-S twin normalizes to the same signature, but is not accepted as is
+	highS := append([]byte{}, raw...)
+	new(big.Int).Sub(btcec.S256().N, new(big.Int).SetBytes(raw[32:])).FillBytes(highS[32:])
+	if valid, _ := VerifySignDoc(doc, publicKey, highS); valid {
+		t.Error("Expected a high-S signature to be rejected")
+	}
+	if normalized, err := NormalizeSignature(doc, highS, publicKey); err != nil || !bytes.Equal(normalized, raw) {
+		t.Errorf("Expected high S to normalize, got %x, %v", normalized, err)
+	}
+
+	other := &SignDoc{BodyBytes: []byte("body"), AuthInfoBytes: []byte("auth"), ChainID: "test-2", AccountNumber: 7}
+	if _, err := NormalizeSignature(other, der, publicKey); err == nil || !strings.Contains(err.Error(), "does not verify") {
+		t.Errorf("Expected a signature for another chain to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package deadletter
+
+import (
+	"errors"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/filestore"
+)
+
+// FileStore keeps each letter as a JSON file in a directory, so letters survive restarts
+// Files are written atomically via rename and readable by the owner only, as letters carry
+// forwarded request headers
+type FileStore struct {
+	files *filestore.Store[Letter]
+}
+
+// NewFileStore creates a store in dir, creating the directory if needed
+func NewFileStore(dir string) (*FileStore, error) {
+	files, err := filestore.New[Letter](dir, "dead letter")
+	if err != nil {
+		return nil, err
+	}
+	return &FileStore{files: files}, nil
+}
+
+// Put writes letter to <dir>/<id>.json
+func (s *FileStore) Put(letter *Letter) error {
+	return s.files.Put(letter.ID, letter)
+}
+
+// List reads all stored letters, oldest first
+func (s *FileStore) List() ([]*Letter, error) {
+	letters, err := s.files.List()
+	if err != nil {
+		return nil, err
+	}
+	sortLetters(letters)
+	return letters, nil
+}
+
+// Delete removes the letter file
+func (s *FileStore) Delete(id string) error {
+	err := s.files.Delete(id)
+	if errors.Is(err, filestore.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
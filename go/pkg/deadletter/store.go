@@ -0,0 +1,136 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package deadletter keeps vote requests and round notifications that could not be delivered,
+// so they can be inspected and replayed once their targets are reachable again
+package deadletter
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned for letters that are not in the store
+var ErrNotFound = errors.New("dead letter not found")
+
+// Kind says what an undelivered request was
+type Kind string
+
+// Dead letter kinds
+const (
+	KindVote   Kind = "vote"   // Vote request of a voting round
+	KindCommit Kind = "commit" // Commit notification of a signed round
+	KindAbort  Kind = "abort"  // Abort notification of a round that ended without a signature
+)
+
+// Letter is a request that could not be delivered to its target
+type Letter struct {
+	ID          string `json:"id"`
+	Kind        Kind   `json:"kind"`
+	SignerAppID string `json:"signer_app_id"` // App whose voting round the request belonged to
+	TargetAppID string `json:"target_app_id"` // App the request was for; the delegate for delegated votes
+	RoundID     string `json:"round_id,omitempty"`
+
+	// Payload is the request body: the forwarded vote request data, or the notification as JSON
+	Payload []byte            `json:"payload"`
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Vote request fields
+	Message           []byte `json:"message,omitempty"`
+	RequiredVotes     int    `json:"required_votes,omitempty"`
+	TotalParticipants int    `json:"total_participants,omitempty"`
+
+	Error         string    `json:"error"`          // Why the last delivery failed
+	Code          string    `json:"code,omitempty"` // Rejection code of the last failure, e.g. "unreachable"
+	Attempts      int       `json:"attempts"`       // Failed deliveries, counting replays
+	CreatedAt     time.Time `json:"created_at"`     // First failed delivery
+	LastAttemptAt time.Time `json:"last_attempt_at"`
+}
+
+// Store persists dead letters
+// Implementations must be safe for concurrent use
+type Store interface {
+	// Put stores a letter, replacing any letter with the same ID
+	Put(letter *Letter) error
+	// List returns all stored letters, oldest first
+	List() ([]*Letter, error)
+	// Delete removes a letter; it returns ErrNotFound if there is none with that ID
+	Delete(id string) error
+}
+
+// NewID returns a random letter ID
+func NewID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// MemoryStore keeps letters in memory; they are lost when the process exits
+type MemoryStore struct {
+	mu      sync.Mutex
+	letters map[string]*Letter
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{letters: make(map[string]*Letter)}
+}
+
+// Put stores a copy of letter
+func (s *MemoryStore) Put(letter *Letter) error {
+	copied := *letter
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.letters[letter.ID] = &copied
+	return nil
+}
+
+// List returns copies of all stored letters, oldest first
+func (s *MemoryStore) List() ([]*Letter, error) {
+	s.mu.Lock()
+	letters := make([]*Letter, 0, len(s.letters))
+	for _, letter := range s.letters {
+		copied := *letter
+		letters = append(letters, &copied)
+	}
+	s.mu.Unlock()
+	sortLetters(letters)
+	return letters, nil
+}
+
+// Delete removes the letter with the given ID
+func (s *MemoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.letters[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.letters, id)
+	return nil
+}
+
+// sortLetters orders letters oldest first, breaking ties by ID
+func sortLetters(letters []*Letter) {
+	sort.Slice(letters, func(i, j int) bool {
+		if !letters[i].CreatedAt.Equal(letters[j].CreatedAt) {
+			return letters[i].CreatedAt.Before(letters[j].CreatedAt)
+		}
+		return letters[i].ID < letters[j].ID
+	})
+}
@@ -0,0 +1,87 @@
+package deadletter
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testStore(t *testing.T, store Store) {
+	now := time.Now()
+	for i, id := range []string{"b", "a", "c"} {
+		letter := &Letter{ID: id, Kind: KindVote, TargetAppID: "app", Payload: []byte(id), CreatedAt: now.Add(time.Duration(i) * time.Second)}
+		if err := store.Put(letter); err != nil {
+			t.Fatalf("Put(%s) failed: %v", id, err)
+		}
+	}
+
+	letters, err := store.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(letters) != 3 {
+		t.Fatalf("Expected 3 letters, got %d", len(letters))
+	}
+	for i, id := range []string{"b", "a", "c"} {
+		if letters[i].ID != id || !bytes.Equal(letters[i].Payload, []byte(id)) {
+			t.Errorf("Letter %d: expected %s, got %s", i, id, letters[i].ID)
+		}
+	}
+
+	// Put replaces a letter with the same ID
+	letters[0].Attempts = 2
+	if err := store.Put(letters[0]); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if letters, _ := store.List(); letters[0].Attempts != 2 {
+		t.Errorf("Expected updated letter, got %d attempts", letters[0].Attempts)
+	}
+
+	if err := store.Delete("a"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := store.Delete("a"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound deleting twice, got %v", err)
+	}
+	if letters, _ := store.List(); len(letters) != 2 {
+		t.Errorf("Expected 2 letters after delete, got %d", len(letters))
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	testStore(t, store)
+
+	// Letters survive reopening the store
+	reopened, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	if letters, _ := reopened.List(); len(letters) != 2 {
+		t.Errorf("Expected 2 letters after reopening, got %d", len(letters))
+	}
+
+	// Letters carry request headers and are private to the owner
+	info, err := os.Stat(filepath.Join(dir, "b.json"))
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		t.Errorf("Expected owner-only permissions, got %o", perm)
+	}
+
+	if err := store.Put(&Letter{ID: "../escape"}); err == nil {
+		t.Error("Expected error for ID containing a path")
+	}
+}
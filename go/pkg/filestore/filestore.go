@@ -0,0 +1,132 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package filestore keeps records as JSON files in a directory, one per record, so stores
+// built on it survive restarts
+package filestore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound is returned when deleting a record that is not in the store
+var ErrNotFound = errors.New("record not found")
+
+// Store keeps each record as <dir>/<id>.json. Files are written atomically via rename and
+// readable by the owner only
+type Store[T any] struct {
+	mu   sync.Mutex
+	dir  string
+	kind string // What a record is, e.g. "voting round", for error messages
+}
+
+// New creates a store of kind records in dir, creating the directory if needed
+func New[T any](dir, kind string) (*Store[T], error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create %s directory: %w", kind, err)
+	}
+	return &Store[T]{dir: dir, kind: kind}, nil
+}
+
+// Put writes record to <dir>/<id>.json, replacing any record with the same ID
+func (s *Store[T]) Put(id string, record *T) error {
+	if !validID(id) {
+		return fmt.Errorf("invalid %s ID %q", s.kind, id)
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", s.kind, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp, err := os.CreateTemp(s.dir, ".pending-*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", s.kind, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", s.kind, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync %s: %w", s.kind, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", s.kind, err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(id)); err != nil {
+		return fmt.Errorf("failed to store %s: %w", s.kind, err)
+	}
+	return nil
+}
+
+// List reads all stored records in file name order; callers sort them as they need
+func (s *Store[T]) List() ([]*T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s directory: %w", s.kind, err)
+	}
+
+	var records []*T
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s %s: %w", s.kind, entry.Name(), err)
+		}
+		record := new(T)
+		if err := json.Unmarshal(data, record); err != nil {
+			return nil, fmt.Errorf("invalid %s %s: %w", s.kind, entry.Name(), err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// Delete removes the record file; it returns ErrNotFound if there is none with that ID
+func (s *Store[T]) Delete(id string) error {
+	if !validID(id) {
+		return ErrNotFound
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := os.Remove(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// path returns the file holding the record with the given ID
+func (s *Store[T]) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+// validID rejects IDs that could escape the store directory
+func validID(id string) bool {
+	return id != "" && !strings.ContainsAny(id, `/\.`)
+}
@@ -0,0 +1,73 @@
+package filestore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type record struct {
+	ID    string `json:"id"`
+	Value int    `json:"value"`
+}
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := New[record](dir, "test record")
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	for _, r := range []*record{{ID: "b", Value: 2}, {ID: "a", Value: 1}} {
+		if err := store.Put(r.ID, r); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+	if err := store.Put("a", &record{ID: "a", Value: 3}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	// Records survive reopening the store, in file name order
+	reopened, err := New[record](dir, "test record")
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	records, err := reopened.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(records) != 2 || records[0].ID != "a" || records[0].Value != 3 || records[1].ID != "b" {
+		t.Errorf("Unexpected records: %+v", records)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, "a.json"))
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		t.Errorf("Expected a record file readable by the owner only, got %v", perm)
+	}
+
+	if err := store.Delete("a"); err != nil {
+		t.Errorf("Delete failed: %v", err)
+	}
+	if err := store.Delete("a"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestStoreRejectsPaths(t *testing.T) {
+	store, err := New[record](t.TempDir(), "test record")
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	for _, id := range []string{"", "../escape", `a\b`, "a.json"} {
+		if err := store.Put(id, &record{}); err == nil {
+			t.Errorf("Expected error for ID %q", id)
+		}
+		if err := store.Delete(id); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound deleting ID %q, got %v", id, err)
+		}
+	}
+}
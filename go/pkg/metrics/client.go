@@ -0,0 +1,90 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package metrics
+
+import "time"
+
+// Namespace prefixes every metric exported by the TEENet client
+const Namespace = "teenet"
+
+// Result label values
+const (
+	ResultSuccess   = "success"
+	ResultError     = "error"
+	ResultApproved  = "approved"
+	ResultRejected  = "rejected"
+	ResultCancelled = "cancelled"
+	ResultHit       = "hit"
+	ResultMiss      = "miss"
+)
+
+// ClientMetrics groups the metrics recorded by the TEENet client
+type ClientMetrics struct {
+	Registry *Registry
+
+	SignDuration   *HistogramVec // teenet_sign_duration_seconds{app_id,result}
+	SignRequests   *CounterVec   // teenet_sign_requests_total{app_id,result}
+	GRPCReconnects *CounterVec   // teenet_grpc_reconnects_total{target}
+	CacheRequests  *CounterVec   // teenet_cache_requests_total{cache,result}
+	VotingDuration *HistogramVec // teenet_voting_round_duration_seconds{app_id,result}
+	SignMismatches *CounterVec   // teenet_signature_mismatches_total{app_id}
+}
+
+// NewClientMetrics registers the client metrics in registry
+func NewClientMetrics(registry *Registry) *ClientMetrics {
+	return &ClientMetrics{
+		Registry: registry,
+		SignDuration: registry.NewHistogramVec(Namespace+"_sign_duration_seconds",
+			"Latency of TEE signing operations in seconds.", nil, "app_id", "result"),
+		SignRequests: registry.NewCounterVec(Namespace+"_sign_requests_total",
+			"Number of TEE signing operations by outcome.", "app_id", "result"),
+		GRPCReconnects: registry.NewCounterVec(Namespace+"_grpc_reconnects_total",
+			"Number of times a gRPC connection left the READY state and reconnected.", "target"),
+		CacheRequests: registry.NewCounterVec(Namespace+"_cache_requests_total",
+			"Number of cache lookups by outcome.", "cache", "result"),
+		VotingDuration: registry.NewHistogramVec(Namespace+"_voting_round_duration_seconds",
+			"Duration of voting rounds in seconds, excluding the final signature.", nil, "app_id", "result"),
+		SignMismatches: registry.NewCounterVec(Namespace+"_signature_mismatches_total",
+			"Number of TEE-returned signatures that failed verification against the app's public key.", "app_id"),
+	}
+}
+
+// ObserveSign records the latency and outcome of a signing operation started at start
+func (m *ClientMetrics) ObserveSign(appID string, start time.Time, err error) {
+	result := ResultSuccess
+	if err != nil {
+		result = ResultError
+	}
+	m.SignDuration.Observe(time.Since(start).Seconds(), appID, result)
+	m.SignRequests.Inc(appID, result)
+}
+
+// ObserveVotingRound records the duration and result of a voting round started at start
+func (m *ClientMetrics) ObserveVotingRound(appID string, start time.Time, result string) {
+	m.VotingDuration.Observe(time.Since(start).Seconds(), appID, result)
+}
+
+// ObserveCache records a cache lookup
+func (m *ClientMetrics) ObserveCache(cache string, hit bool) {
+	result := ResultMiss
+	if hit {
+		result = ResultHit
+	}
+	m.CacheRequests.Inc(cache, result)
+}
+
+// ObserveSignatureMismatch records a TEE-returned signature that failed verification
+func (m *ClientMetrics) ObserveSignatureMismatch(appID string) {
+	m.SignMismatches.Inc(appID)
+}
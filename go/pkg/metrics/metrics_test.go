@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegistryTextFormat(t *testing.T) {
+	registry := NewRegistry()
+	counter := registry.NewCounterVec("test_requests_total", "Requests.\nSecond line", "app_id", "result")
+	histogram := registry.NewHistogramVec("test_duration_seconds", "Duration.", []float64{1, 0.5}, "app_id")
+
+	counter.Inc("app-\"1\"", ResultSuccess)
+	counter.Add(2, "app-\"1\"", ResultSuccess)
+	counter.Add(-5, "app-\"1\"", ResultSuccess)
+	histogram.Observe(0.25, "a")
+	histogram.Observe(0.75, "a")
+	histogram.Observe(3, "a")
+
+	if v := counter.Value("app-\"1\"", ResultSuccess); v != 3 {
+		t.Errorf("Expected counter value 3, got %v", v)
+	}
+	if n := histogram.Count("a"); n != 3 {
+		t.Errorf("Expected 3 observations, got %d", n)
+	}
+
+	recorder := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := recorder.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Unexpected content type: %s", ct)
+	}
+
+	expected := `# HELP test_duration_seconds Duration.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{app_id="a",le="0.5"} 1
+test_duration_seconds_bucket{app_id="a",le="1"} 2
+test_duration_seconds_bucket{app_id="a",le="+Inf"} 3
+test_duration_seconds_sum{app_id="a"} 4
+test_duration_seconds_count{app_id="a"} 3
+# HELP test_requests_total Requests.\nSecond line
+# TYPE test_requests_total counter
+test_requests_total{app_id="app-\"1\"",result="success"} 3
+`
+	if got := recorder.Body.String(); got != expected {
+		t.Errorf("Unexpected exposition output:\n%s\nexpected:\n%s", got, expected)
+	}
+}
+
+func TestRegistryRejectsDuplicates(t *testing.T) {
+	registry := NewRegistry()
+	registry.NewGaugeVec("test_queue_depth", "Queue depth.")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for duplicate metric name")
+		}
+	}()
+	registry.NewCounterVec("test_queue_depth", "Duplicate.")
+}
@@ -0,0 +1,59 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package offline
+
+import (
+	"errors"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/filestore"
+)
+
+// FileStore keeps each request as a JSON file in a directory, so queued requests
+// survive restarts. Files are written atomically via rename
+type FileStore struct {
+	files *filestore.Store[Request]
+}
+
+// NewFileStore creates a store in dir, creating the directory if needed
+func NewFileStore(dir string) (*FileStore, error) {
+	files, err := filestore.New[Request](dir, "offline request")
+	if err != nil {
+		return nil, err
+	}
+	return &FileStore{files: files}, nil
+}
+
+// Put writes req to <dir>/<id>.json
+func (s *FileStore) Put(req *Request) error {
+	return s.files.Put(req.ID, req)
+}
+
+// List reads all stored requests, oldest first
+func (s *FileStore) List() ([]*Request, error) {
+	requests, err := s.files.List()
+	if err != nil {
+		return nil, err
+	}
+	sortRequests(requests)
+	return requests, nil
+}
+
+// Delete removes the request file
+func (s *FileStore) Delete(id string) error {
+	err := s.files.Delete(id)
+	if errors.Is(err, filestore.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
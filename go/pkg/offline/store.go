@@ -0,0 +1,118 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package offline provides durable storage for sign requests queued while the TEE is unreachable
+package offline
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned when deleting a request that is not in the store
+var ErrNotFound = errors.New("offline request not found")
+
+// Request is a sign request waiting to be forwarded to the TEE
+type Request struct {
+	ID             string    `json:"id"`
+	AppID          string    `json:"app_id"`
+	Message        []byte    `json:"message"`
+	ED25519Mode    uint32    `json:"ed25519_mode,omitempty"`
+	ED25519Context []byte    `json:"ed25519_context,omitempty"`
+	Priority       int       `json:"priority,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// Expired reports whether the request may no longer be forwarded at now
+func (r *Request) Expired(now time.Time) bool {
+	return !r.ExpiresAt.IsZero() && !now.Before(r.ExpiresAt)
+}
+
+// Store persists queued requests
+// Implementations must be safe for concurrent use
+type Store interface {
+	// Put stores a request, replacing any request with the same ID
+	Put(req *Request) error
+	// List returns all stored requests, oldest first
+	List() ([]*Request, error)
+	// Delete removes a request; it returns ErrNotFound if there is none with that ID
+	Delete(id string) error
+}
+
+// NewID returns a random request ID
+func NewID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// MemoryStore keeps requests in memory; they are lost when the process exits
+type MemoryStore struct {
+	mu       sync.Mutex
+	requests map[string]*Request
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{requests: make(map[string]*Request)}
+}
+
+// Put stores a copy of req
+func (s *MemoryStore) Put(req *Request) error {
+	copied := *req
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests[req.ID] = &copied
+	return nil
+}
+
+// List returns copies of all stored requests, oldest first
+func (s *MemoryStore) List() ([]*Request, error) {
+	s.mu.Lock()
+	requests := make([]*Request, 0, len(s.requests))
+	for _, req := range s.requests {
+		copied := *req
+		requests = append(requests, &copied)
+	}
+	s.mu.Unlock()
+	sortRequests(requests)
+	return requests, nil
+}
+
+// Delete removes the request with the given ID
+func (s *MemoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.requests[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.requests, id)
+	return nil
+}
+
+// sortRequests orders requests oldest first, breaking ties by ID
+func sortRequests(requests []*Request) {
+	sort.Slice(requests, func(i, j int) bool {
+		if !requests[i].CreatedAt.Equal(requests[j].CreatedAt) {
+			return requests[i].CreatedAt.Before(requests[j].CreatedAt)
+		}
+		return requests[i].ID < requests[j].ID
+	})
+}
@@ -0,0 +1,80 @@
+package offline
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func testStore(t *testing.T, store Store) {
+	now := time.Now()
+	for i, id := range []string{"b", "a", "c"} {
+		req := &Request{ID: id, AppID: "app", Message: []byte(id), CreatedAt: now.Add(time.Duration(i) * time.Second)}
+		if err := store.Put(req); err != nil {
+			t.Fatalf("Put(%s) failed: %v", id, err)
+		}
+	}
+
+	requests, err := store.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(requests) != 3 {
+		t.Fatalf("Expected 3 requests, got %d", len(requests))
+	}
+	for i, id := range []string{"b", "a", "c"} {
+		if requests[i].ID != id || !bytes.Equal(requests[i].Message, []byte(id)) {
+			t.Errorf("Request %d: expected %s, got %s", i, id, requests[i].ID)
+		}
+	}
+
+	if err := store.Delete("a"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := store.Delete("a"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound deleting twice, got %v", err)
+	}
+	if requests, _ := store.List(); len(requests) != 2 {
+		t.Errorf("Expected 2 requests after delete, got %d", len(requests))
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	testStore(t, store)
+
+	// Requests survive reopening the store
+	reopened, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	if requests, _ := reopened.List(); len(requests) != 2 {
+		t.Errorf("Expected 2 requests after reopening, got %d", len(requests))
+	}
+
+	if err := store.Put(&Request{ID: "../escape"}); err == nil {
+		t.Error("Expected error for ID containing a path")
+	}
+}
+
+func TestRequestExpired(t *testing.T) {
+	now := time.Now()
+	if (&Request{}).Expired(now) {
+		t.Error("Request without expiry should not expire")
+	}
+	if !(&Request{ExpiresAt: now}).Expired(now) {
+		t.Error("Request should expire at its expiry time")
+	}
+	if (&Request{ExpiresAt: now.Add(time.Second)}).Expired(now) {
+		t.Error("Request should not expire before its expiry time")
+	}
+}
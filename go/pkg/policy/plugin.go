@@ -0,0 +1,25 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package policy
+
+import "context"
+
+// Plugin is a client-side signing policy consulted before every signature, see
+// Client.AddPolicyPlugin
+type Plugin interface {
+	// Authorize decides whether appID may sign message. On success it returns a function the
+	// client calls once with whether the signature was produced, so a plugin can release
+	// whatever it reserved for a request that failed. Refusals should match ErrViolation
+	Authorize(ctx context.Context, appID string, message []byte) (done func(signed bool), err error)
+}
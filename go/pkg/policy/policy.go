@@ -0,0 +1,164 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package policy evaluates the signing policies App nodes provide for an app
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/proto/appid"
+)
+
+// ErrViolation is matched by errors.Is for sign requests refused by a signing policy
+var ErrViolation = errors.New("signing policy violation")
+
+// Policy restricts when an app's signatures may be produced
+type Policy struct {
+	Location *time.Location // Time zone the windows are expressed in
+	Windows  []TimeWindow   // Signing is only allowed inside one of these; any time if empty
+	MinDelay time.Duration  // Minimum delay between the start of a sign request and its signature
+}
+
+// TimeWindow is a daily period in which signing is allowed
+type TimeWindow struct {
+	Days  []time.Weekday // Weekdays the window opens on; every day if empty
+	Start time.Duration  // Offset from midnight the window opens at
+	End   time.Duration  // Offset from midnight the window closes at; at or below Start to close the next day
+}
+
+// WindowError is returned when a signature is requested outside every signing window
+type WindowError struct {
+	At       time.Time
+	NextOpen time.Time // Zero if no window opens within a week
+}
+
+func (e *WindowError) Error() string {
+	if e.NextOpen.IsZero() {
+		return fmt.Sprintf("outside signing windows at %s", e.At.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("outside signing windows at %s, next window opens at %s", e.At.Format(time.RFC3339), e.NextOpen.Format(time.RFC3339))
+}
+
+// Is reports whether target is ErrViolation
+func (e *WindowError) Is(target error) bool {
+	return target == ErrViolation
+}
+
+// FromProto converts a policy received from an App node; a nil policy yields nil
+func FromProto(p *appid.SigningPolicy) (*Policy, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	location := time.UTC
+	if p.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(p.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid signing policy time zone %q: %w", p.Timezone, err)
+		}
+	}
+	if p.MinDelaySeconds < 0 {
+		return nil, fmt.Errorf("invalid signing policy delay: %ds", p.MinDelaySeconds)
+	}
+
+	policy := &Policy{Location: location, MinDelay: time.Duration(p.MinDelaySeconds) * time.Second}
+	for _, w := range p.Windows {
+		if w.StartMinute < 0 || w.StartMinute >= 24*60 || w.EndMinute < 0 || w.EndMinute > 24*60 {
+			return nil, fmt.Errorf("invalid signing window %d-%d: minutes must be within a day", w.StartMinute, w.EndMinute)
+		}
+		window := TimeWindow{
+			Start: time.Duration(w.StartMinute) * time.Minute,
+			End:   time.Duration(w.EndMinute) * time.Minute,
+		}
+		for _, day := range w.Days {
+			if day < 0 || day > 6 {
+				return nil, fmt.Errorf("invalid signing window weekday %d", day)
+			}
+			window.Days = append(window.Days, time.Weekday(day))
+		}
+		policy.Windows = append(policy.Windows, window)
+	}
+	return policy, nil
+}
+
+// EarliestSign returns the earliest time a request started at start may be signed
+func (p *Policy) EarliestSign(start time.Time) time.Time {
+	return start.Add(p.MinDelay)
+}
+
+// CheckTime returns a *WindowError if t is outside every signing window
+func (p *Policy) CheckTime(t time.Time) error {
+	if len(p.Windows) == 0 {
+		return nil
+	}
+	local := t.In(p.Location)
+	for _, w := range p.Windows {
+		if w.contains(local) {
+			return nil
+		}
+	}
+	return &WindowError{At: t, NextOpen: p.nextOpen(local)}
+}
+
+// contains reports whether t, in the policy's time zone, falls inside the window
+func (w TimeWindow) contains(t time.Time) bool {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := t.Sub(midnight)
+	if w.End > w.Start {
+		return w.opensOn(t.Weekday()) && offset >= w.Start && offset < w.End
+	}
+	// The window closes the next day: t is either in today's opening or yesterday's tail
+	return (w.opensOn(t.Weekday()) && offset >= w.Start) || (w.opensOn(prevDay(t.Weekday())) && offset < w.End)
+}
+
+// opensOn reports whether the window opens on day
+func (w TimeWindow) opensOn(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// nextOpen returns when the next window opens after t, or zero if none opens within a week
+func (p *Policy) nextOpen(t time.Time) time.Time {
+	var next time.Time
+	for day := 0; day <= 7; day++ {
+		date := time.Date(t.Year(), t.Month(), t.Day()+day, 0, 0, 0, 0, t.Location())
+		for _, w := range p.Windows {
+			if !w.opensOn(date.Weekday()) {
+				continue
+			}
+			open := date.Add(w.Start)
+			if open.After(t) && (next.IsZero() || open.Before(next)) {
+				next = open
+			}
+		}
+		if !next.IsZero() {
+			return next
+		}
+	}
+	return next
+}
+
+// prevDay returns the weekday before day
+func prevDay(day time.Weekday) time.Weekday {
+	return (day + 6) % 7
+}
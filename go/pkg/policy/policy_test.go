@@ -0,0 +1,116 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/proto/appid"
+)
+
+func TestCheckTimeBusinessHours(t *testing.T) {
+	p, err := FromProto(&appid.SigningPolicy{
+		Windows: []*appid.TimeWindow{{Days: []int32{1, 2, 3, 4, 5}, StartMinute: 9 * 60, EndMinute: 17 * 60}},
+	})
+	if err != nil {
+		t.Fatalf("FromProto() error = %v", err)
+	}
+
+	// 2026-01-05 is a Monday
+	tests := []struct {
+		at      time.Time
+		allowed bool
+	}{
+		{time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC), true},
+		{time.Date(2026, 1, 5, 16, 59, 0, 0, time.UTC), true},
+		{time.Date(2026, 1, 5, 17, 0, 0, 0, time.UTC), false},
+		{time.Date(2026, 1, 5, 8, 59, 0, 0, time.UTC), false},
+		{time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC), false}, // Saturday
+	}
+	for _, tt := range tests {
+		err := p.CheckTime(tt.at)
+		if (err == nil) != tt.allowed {
+			t.Errorf("CheckTime(%s) error = %v, want allowed %v", tt.at, err, tt.allowed)
+		}
+		if err != nil && !errors.Is(err, ErrViolation) {
+			t.Errorf("CheckTime(%s) error %v does not match ErrViolation", tt.at, err)
+		}
+	}
+
+	var windowErr *WindowError
+	if err := p.CheckTime(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)); !errors.As(err, &windowErr) {
+		t.Fatalf("CheckTime() error = %v, want *WindowError", err)
+	}
+	if want := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC); !windowErr.NextOpen.Equal(want) {
+		t.Errorf("NextOpen = %s, want %s", windowErr.NextOpen, want)
+	}
+}
+
+func TestCheckTimeOvernightWindow(t *testing.T) {
+	// Friday 22:00 to Saturday 06:00
+	p, err := FromProto(&appid.SigningPolicy{
+		Windows: []*appid.TimeWindow{{Days: []int32{5}, StartMinute: 22 * 60, EndMinute: 6 * 60}},
+	})
+	if err != nil {
+		t.Fatalf("FromProto() error = %v", err)
+	}
+
+	if err := p.CheckTime(time.Date(2026, 1, 9, 23, 0, 0, 0, time.UTC)); err != nil {
+		t.Errorf("Friday 23:00 refused: %v", err)
+	}
+	if err := p.CheckTime(time.Date(2026, 1, 10, 5, 0, 0, 0, time.UTC)); err != nil {
+		t.Errorf("Saturday 05:00 refused: %v", err)
+	}
+	if err := p.CheckTime(time.Date(2026, 1, 11, 5, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("Sunday 05:00 allowed")
+	}
+}
+
+func TestCheckTimeZone(t *testing.T) {
+	p, err := FromProto(&appid.SigningPolicy{
+		Timezone: "Asia/Hong_Kong",
+		Windows:  []*appid.TimeWindow{{StartMinute: 9 * 60, EndMinute: 17 * 60}},
+	})
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	// 02:00 UTC is 10:00 in Hong Kong
+	if err := p.CheckTime(time.Date(2026, 1, 5, 2, 0, 0, 0, time.UTC)); err != nil {
+		t.Errorf("10:00 HKT refused: %v", err)
+	}
+	if err := p.CheckTime(time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("20:00 HKT allowed")
+	}
+}
+
+func TestFromProto(t *testing.T) {
+	if p, err := FromProto(nil); p != nil || err != nil {
+		t.Errorf("FromProto(nil) = %v, %v, want nil, nil", p, err)
+	}
+
+	p, err := FromProto(&appid.SigningPolicy{MinDelaySeconds: 3600})
+	if err != nil {
+		t.Fatalf("FromProto() error = %v", err)
+	}
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	if got := p.EarliestSign(start); !got.Equal(start.Add(time.Hour)) {
+		t.Errorf("EarliestSign() = %s, want %s", got, start.Add(time.Hour))
+	}
+	if err := p.CheckTime(start); err != nil {
+		t.Errorf("CheckTime() without windows error = %v", err)
+	}
+
+	invalid := []*appid.SigningPolicy{
+		{Timezone: "Not/AZone"},
+		{MinDelaySeconds: -1},
+		{Windows: []*appid.TimeWindow{{StartMinute: -1, EndMinute: 60}}},
+		{Windows: []*appid.TimeWindow{{StartMinute: 0, EndMinute: 24*60 + 1}}},
+		{Windows: []*appid.TimeWindow{{Days: []int32{7}, StartMinute: 0, EndMinute: 60}}},
+	}
+	for i, policy := range invalid {
+		if _, err := FromProto(policy); err == nil {
+			t.Errorf("invalid policy %d accepted", i)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/utils"
+)
+
+// maxAmountLength bounds the length of an amount, so parsing it stays cheap
+const maxAmountLength = 100
+
+// decimalAmount matches plain decimal numbers. Exponents such as 1e999999999, fractions and
+// base prefixes, which big.Rat would accept, are refused: expanding them costs time and memory
+var decimalAmount = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)$`)
+
+// SpendingLimit caps the amounts an app may sign for. Amounts are plain decimal numbers, without
+// exponents, read from the JSON message as JSON numbers or strings
+type SpendingLimit struct {
+	AmountPath     string // Dot-separated JSON path of the amount, e.g. "transfer.amount"
+	PerTransaction string // Maximum amount of a single signature; empty for no limit
+	Daily          string // Maximum total per day; empty for no limit
+}
+
+// SpendingLimitError is returned when a signature would exceed a spending limit
+type SpendingLimitError struct {
+	AppID  string
+	Limit  string // "per-transaction" or "daily"
+	Amount string
+	Max    string
+}
+
+func (e *SpendingLimitError) Error() string {
+	return fmt.Sprintf("app %s: amount %s exceeds the %s spending limit of %s", e.AppID, e.Amount, e.Limit, e.Max)
+}
+
+// Is reports whether target is ErrViolation
+func (e *SpendingLimitError) Is(target error) bool {
+	return target == ErrViolation
+}
+
+// SpendingPolicy is a Plugin enforcing per-transaction and daily spending limits per app ID
+// Apps without a limit are not restricted; messages of limited apps must carry a valid amount
+type SpendingPolicy struct {
+	store    SpendingStore
+	location *time.Location
+
+	mu     sync.RWMutex
+	limits map[string]spendingLimit
+}
+
+// spendingLimit is a parsed SpendingLimit
+type spendingLimit struct {
+	amountPath     string
+	perTransaction *big.Rat
+	daily          *big.Rat
+}
+
+// NewSpendingPolicy creates a spending policy keeping daily totals in store
+// Days start at midnight in location, UTC if nil
+func NewSpendingPolicy(store SpendingStore, location *time.Location) *SpendingPolicy {
+	if location == nil {
+		location = time.UTC
+	}
+	return &SpendingPolicy{store: store, location: location, limits: make(map[string]spendingLimit)}
+}
+
+// SetLimit sets appID's spending limit, replacing any earlier one
+func (p *SpendingPolicy) SetLimit(appID string, limit SpendingLimit) error {
+	if limit.AmountPath == "" {
+		return fmt.Errorf("spending limit for %s needs an amount path", appID)
+	}
+	parsed := spendingLimit{amountPath: limit.AmountPath}
+	var err error
+	if parsed.perTransaction, err = parseLimit(limit.PerTransaction); err != nil {
+		return fmt.Errorf("invalid per-transaction limit for %s: %w", appID, err)
+	}
+	if parsed.daily, err = parseLimit(limit.Daily); err != nil {
+		return fmt.Errorf("invalid daily limit for %s: %w", appID, err)
+	}
+
+	p.mu.Lock()
+	p.limits[appID] = parsed
+	p.mu.Unlock()
+	return nil
+}
+
+// RemoveLimit lifts appID's spending limit
+func (p *SpendingPolicy) RemoveLimit(appID string) {
+	p.mu.Lock()
+	delete(p.limits, appID)
+	p.mu.Unlock()
+}
+
+// Spent returns the total appID signed for today
+func (p *SpendingPolicy) Spent(ctx context.Context, appID string) (*big.Rat, error) {
+	return p.store.Total(ctx, appID, p.day(time.Now()))
+}
+
+// Authorize implements Plugin: it checks the per-transaction limit and reserves the amount
+// against the daily limit, releasing it again if the signature isn't produced
+func (p *SpendingPolicy) Authorize(ctx context.Context, appID string, message []byte) (func(bool), error) {
+	p.mu.RLock()
+	limit, ok := p.limits[appID]
+	p.mu.RUnlock()
+	if !ok {
+		return func(bool) {}, nil
+	}
+
+	amount, err := messageAmount(message, limit.amountPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: app %s: %w", ErrViolation, appID, err)
+	}
+	if limit.perTransaction != nil && amount.Cmp(limit.perTransaction) > 0 {
+		return nil, &SpendingLimitError{AppID: appID, Limit: "per-transaction", Amount: formatAmount(amount), Max: formatAmount(limit.perTransaction)}
+	}
+	if limit.daily == nil {
+		return func(bool) {}, nil
+	}
+
+	day := p.day(time.Now())
+	reserved, err := p.store.Reserve(ctx, appID, day, amount, limit.daily)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve spending for %s: %w", appID, err)
+	}
+	if !reserved {
+		return nil, &SpendingLimitError{AppID: appID, Limit: "daily", Amount: formatAmount(amount), Max: formatAmount(limit.daily)}
+	}
+	return func(signed bool) {
+		if !signed {
+			// Release with a fresh context: the request's may already be done
+			p.store.Release(context.Background(), appID, day, amount)
+		}
+	}, nil
+}
+
+// day returns the day t falls on in the policy's time zone
+func (p *SpendingPolicy) day(t time.Time) string {
+	return t.In(p.location).Format(time.DateOnly)
+}
+
+// messageAmount reads a non-negative decimal amount from a JSON message
+func messageAmount(message []byte, path string) (*big.Rat, error) {
+	value, ok := utils.JSONField(message, path)
+	if !ok {
+		return nil, fmt.Errorf("message has no amount at %s", path)
+	}
+	var text string
+	switch v := value.(type) {
+	case json.Number:
+		text = v.String()
+	case string:
+		text = v
+	default:
+		return nil, fmt.Errorf("amount at %s is not a number", path)
+	}
+	amount, ok := parseAmount(text)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q at %s", text, path)
+	}
+	if amount.Sign() < 0 {
+		return nil, fmt.Errorf("negative amount %s at %s", text, path)
+	}
+	return amount, nil
+}
+
+// parseLimit parses a decimal limit; an empty string means no limit
+func parseLimit(s string) (*big.Rat, error) {
+	if s == "" {
+		return nil, nil
+	}
+	limit, ok := parseAmount(s)
+	if !ok || limit.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+	return limit, nil
+}
+
+// parseAmount parses a plain decimal number of at most maxAmountLength characters
+func parseAmount(s string) (*big.Rat, bool) {
+	if len(s) > maxAmountLength || !decimalAmount.MatchString(s) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(s)
+}
+
+// formatAmount prints an amount as an integer when it is one, as a fraction otherwise
+func formatAmount(amount *big.Rat) string {
+	if amount.IsInt() {
+		return amount.Num().String()
+	}
+	return strings.TrimRight(amount.FloatString(18), "0")
+}
@@ -0,0 +1,209 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// SpendingStore keeps the daily spending totals of SpendingPolicy. Implementations must be
+// safe for concurrent use; a shared store (e.g. a database) lets several clients share limits
+type SpendingStore interface {
+	// Reserve adds amount to appID's total for day if the new total stays within limit,
+	// reporting whether it did
+	Reserve(ctx context.Context, appID, day string, amount, limit *big.Rat) (bool, error)
+	// Release subtracts a previously reserved amount from appID's total for day
+	Release(ctx context.Context, appID, day string, amount *big.Rat) error
+	// Total returns appID's total for day
+	Total(ctx context.Context, appID, day string) (*big.Rat, error)
+}
+
+// spendingTotal is an app's running total for one day
+type spendingTotal struct {
+	Day   string `json:"day"`
+	Total string `json:"total"` // big.Rat in a/b form
+}
+
+// MemorySpendingStore keeps daily totals in memory; they are lost on restart
+type MemorySpendingStore struct {
+	mu     sync.Mutex
+	totals map[string]spendingTotal // app ID -> total of the latest day
+}
+
+// NewMemorySpendingStore creates an empty in-memory spending store
+func NewMemorySpendingStore() *MemorySpendingStore {
+	return &MemorySpendingStore{totals: make(map[string]spendingTotal)}
+}
+
+// Reserve implements SpendingStore
+func (s *MemorySpendingStore) Reserve(_ context.Context, appID, day string, amount, limit *big.Rat) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reserved, _, err := reserve(s.totals, appID, day, amount, limit)
+	return reserved, err
+}
+
+// Release implements SpendingStore
+func (s *MemorySpendingStore) Release(_ context.Context, appID, day string, amount *big.Rat) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return release(s.totals, appID, day, amount)
+}
+
+// Total implements SpendingStore
+func (s *MemorySpendingStore) Total(_ context.Context, appID, day string) (*big.Rat, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return total(s.totals, appID, day)
+}
+
+// FileSpendingStore keeps daily totals in a JSON file so they survive restarts
+// The file is rewritten atomically via rename on every change
+type FileSpendingStore struct {
+	mu     sync.Mutex
+	path   string
+	totals map[string]spendingTotal
+}
+
+// NewFileSpendingStore opens the store at path, loading existing totals if the file exists
+func NewFileSpendingStore(path string) (*FileSpendingStore, error) {
+	store := &FileSpendingStore{path: path, totals: make(map[string]spendingTotal)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spending store: %w", err)
+	}
+	if err := json.Unmarshal(data, &store.totals); err != nil {
+		return nil, fmt.Errorf("invalid spending store %s: %w", path, err)
+	}
+	return store, nil
+}
+
+// Reserve implements SpendingStore
+func (s *FileSpendingStore) Reserve(_ context.Context, appID, day string, amount, limit *big.Rat) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reserved, previous, err := reserve(s.totals, appID, day, amount, limit)
+	if err != nil || !reserved {
+		return false, err
+	}
+	if err := s.save(); err != nil {
+		s.totals[appID] = previous
+		return false, err
+	}
+	return true, nil
+}
+
+// Release implements SpendingStore
+func (s *FileSpendingStore) Release(_ context.Context, appID, day string, amount *big.Rat) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := release(s.totals, appID, day, amount); err != nil {
+		return err
+	}
+	return s.save()
+}
+
+// Total implements SpendingStore
+func (s *FileSpendingStore) Total(_ context.Context, appID, day string) (*big.Rat, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return total(s.totals, appID, day)
+}
+
+// save writes the totals to the store file
+func (s *FileSpendingStore) save() error {
+	data, err := json.Marshal(s.totals)
+	if err != nil {
+		return fmt.Errorf("failed to encode spending store: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".spending-*")
+	if err != nil {
+		return fmt.Errorf("failed to write spending store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write spending store: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync spending store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write spending store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to store spending totals: %w", err)
+	}
+	return nil
+}
+
+// reserve adds amount to appID's total in totals if it stays within limit, starting from zero
+// on a new day. It returns the entry it replaced so callers can roll back
+func reserve(totals map[string]spendingTotal, appID, day string, amount, limit *big.Rat) (bool, spendingTotal, error) {
+	previous := totals[appID]
+	if previous.Day > day {
+		day = previous.Day // a concurrent request already rolled over to the next day
+	}
+	current, err := total(totals, appID, day)
+	if err != nil {
+		return false, previous, err
+	}
+	next := new(big.Rat).Add(current, amount)
+	if next.Cmp(limit) > 0 {
+		return false, previous, nil
+	}
+	totals[appID] = spendingTotal{Day: day, Total: next.String()}
+	return true, previous, nil
+}
+
+// release subtracts amount from appID's total for day, never going below zero
+func release(totals map[string]spendingTotal, appID, day string, amount *big.Rat) error {
+	entry, ok := totals[appID]
+	if !ok || entry.Day != day {
+		return nil // the day already rolled over
+	}
+	current, err := total(totals, appID, day)
+	if err != nil {
+		return err
+	}
+	next := new(big.Rat).Sub(current, amount)
+	if next.Sign() < 0 {
+		next.SetInt64(0)
+	}
+	totals[appID] = spendingTotal{Day: day, Total: next.String()}
+	return nil
+}
+
+// total returns appID's total for day, zero if it has none for that day
+func total(totals map[string]spendingTotal, appID, day string) (*big.Rat, error) {
+	entry, ok := totals[appID]
+	if !ok || entry.Day != day {
+		return new(big.Rat), nil
+	}
+	value, ok := new(big.Rat).SetString(entry.Total)
+	if !ok {
+		return nil, fmt.Errorf("corrupt spending total %q for %s", entry.Total, appID)
+	}
+	return value, nil
+}
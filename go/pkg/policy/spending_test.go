@@ -0,0 +1,163 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSpendingPolicyLimits(t *testing.T) {
+	ctx := context.Background()
+	p := NewSpendingPolicy(NewMemorySpendingStore(), nil)
+	if err := p.SetLimit("treasury", SpendingLimit{AmountPath: "transfer.amount", PerTransaction: "100", Daily: "250"}); err != nil {
+		t.Fatalf("SetLimit() error = %v", err)
+	}
+
+	authorize := func(message string) (func(bool), error) {
+		return p.Authorize(ctx, "treasury", []byte(message))
+	}
+
+	done, err := authorize(`{"transfer":{"amount":100}}`)
+	if err != nil {
+		t.Fatalf("Authorize(100) error = %v", err)
+	}
+	done(true)
+
+	var limitErr *SpendingLimitError
+	if _, err := authorize(`{"transfer":{"amount":100.01}}`); !errors.As(err, &limitErr) || limitErr.Limit != "per-transaction" {
+		t.Fatalf("Authorize(100.01) error = %v, want per-transaction limit", err)
+	}
+	if !errors.Is(limitErr, ErrViolation) {
+		t.Errorf("SpendingLimitError does not match ErrViolation")
+	}
+
+	done, err = authorize(`{"transfer":{"amount":"100"}}`)
+	if err != nil {
+		t.Fatalf("Authorize(\"100\") error = %v", err)
+	}
+	done(true)
+
+	if _, err := authorize(`{"transfer":{"amount":60}}`); !errors.As(err, &limitErr) || limitErr.Limit != "daily" {
+		t.Fatalf("Authorize(60) over daily limit error = %v, want daily limit", err)
+	}
+
+	// A failed signature releases its reservation
+	done, err = authorize(`{"transfer":{"amount":50}}`)
+	if err != nil {
+		t.Fatalf("Authorize(50) error = %v", err)
+	}
+	done(false)
+	spent, err := p.Spent(ctx, "treasury")
+	if err != nil || spent.RatString() != "200" {
+		t.Fatalf("Spent() = %v, %v, want 200", spent, err)
+	}
+
+	for _, message := range []string{`{"transfer":{}}`, `{"transfer":{"amount":-1}}`, `{"transfer":{"amount":"lots"}}`, `not json`} {
+		if _, err := authorize(message); !errors.Is(err, ErrViolation) {
+			t.Errorf("Authorize(%s) error = %v, want ErrViolation", message, err)
+		}
+	}
+
+	// Apps without a limit are not restricted
+	if _, err := p.Authorize(ctx, "other", []byte("anything")); err != nil {
+		t.Errorf("Authorize() for unlimited app error = %v", err)
+	}
+}
+
+func TestMessageAmountRejectsExpensiveSyntax(t *testing.T) {
+	long := `"1` + strings.Repeat("0", maxAmountLength) + `"`
+	for _, amount := range []string{`1e999999999`, `"1e999999999"`, `"1E3"`, `"0x10p999999"`, `"1/3"`, long} {
+		if _, err := messageAmount([]byte(`{"amount":`+amount+`}`), "amount"); err == nil {
+			t.Errorf("messageAmount(%.20s) accepted", amount)
+		}
+	}
+	for amount, want := range map[string]string{`12.5`: "25/2", `"0.10"`: "1/10", `".5"`: "1/2", `"7."`: "7"} {
+		got, err := messageAmount([]byte(`{"amount":`+amount+`}`), "amount")
+		if err != nil || got.RatString() != want {
+			t.Errorf("messageAmount(%s) = %v, %v, want %s", amount, got, err, want)
+		}
+	}
+}
+
+func TestSpendingPolicySetLimitValidation(t *testing.T) {
+	p := NewSpendingPolicy(NewMemorySpendingStore(), time.UTC)
+	invalid := []SpendingLimit{
+		{PerTransaction: "10"},
+		{AmountPath: "amount", PerTransaction: "ten"},
+		{AmountPath: "amount", Daily: "-5"},
+		{AmountPath: "amount", Daily: "1e9"},
+	}
+	for i, limit := range invalid {
+		if err := p.SetLimit("app", limit); err == nil {
+			t.Errorf("invalid limit %d accepted", i)
+		}
+	}
+}
+
+func TestFileSpendingStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "spending.json")
+	store, err := NewFileSpendingStore(path)
+	if err != nil {
+		t.Fatalf("NewFileSpendingStore() error = %v", err)
+	}
+
+	p := NewSpendingPolicy(store, nil)
+	if err := p.SetLimit("app", SpendingLimit{AmountPath: "amount", Daily: "10"}); err != nil {
+		t.Fatalf("SetLimit() error = %v", err)
+	}
+	done, err := p.Authorize(ctx, "app", []byte(`{"amount":7.5}`))
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	done(true)
+
+	// Totals survive reopening the store
+	reopened, err := NewFileSpendingStore(path)
+	if err != nil {
+		t.Fatalf("reopen error = %v", err)
+	}
+	p = NewSpendingPolicy(reopened, nil)
+	if err := p.SetLimit("app", SpendingLimit{AmountPath: "amount", Daily: "10"}); err != nil {
+		t.Fatalf("SetLimit() error = %v", err)
+	}
+	if _, err := p.Authorize(ctx, "app", []byte(`{"amount":3}`)); !errors.Is(err, ErrViolation) {
+		t.Fatalf("Authorize() after reopen error = %v, want daily limit", err)
+	}
+	spent, err := p.Spent(ctx, "app")
+	if err != nil || spent.RatString() != "15/2" {
+		t.Fatalf("Spent() = %v, %v, want 15/2", spent, err)
+	}
+}
+
+func TestSpendingStoreDayRollover(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemorySpendingStore()
+	limit := mustRat(t, "10")
+
+	if ok, err := store.Reserve(ctx, "app", "2026-01-05", mustRat(t, "10"), limit); !ok || err != nil {
+		t.Fatalf("Reserve() = %v, %v", ok, err)
+	}
+	if ok, _ := store.Reserve(ctx, "app", "2026-01-05", mustRat(t, "1"), limit); ok {
+		t.Fatalf("Reserve() over the daily limit succeeded")
+	}
+	if ok, err := store.Reserve(ctx, "app", "2026-01-06", mustRat(t, "10"), limit); !ok || err != nil {
+		t.Fatalf("Reserve() on the next day = %v, %v", ok, err)
+	}
+	if total, _ := store.Total(ctx, "app", "2026-01-05"); total.Sign() != 0 {
+		t.Errorf("previous day total = %v, want dropped", total)
+	}
+}
+
+func mustRat(t *testing.T, s string) *big.Rat {
+	t.Helper()
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		t.Fatalf("invalid rat %q", s)
+	}
+	return r
+}
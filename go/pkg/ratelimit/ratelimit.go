@@ -0,0 +1,125 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package ratelimit provides token-bucket rate limiting keyed by app ID
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// bucket is a single token bucket
+type bucket struct {
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// take refills the bucket and consumes one token if available
+// When no token is available it returns the time until one will be
+func (b *bucket) take(now time.Time) (bool, time.Duration) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate)
+	}
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	if b.rate <= 0 {
+		return false, time.Duration(math.MaxInt64)
+	}
+	wait := (1 - b.tokens) / b.rate
+	return false, time.Duration(wait * float64(time.Second))
+}
+
+// refund returns a token consumed by take
+func (b *bucket) refund() {
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// Limiter applies a per-key limit and an optional global limit shared by all keys
+// Keys without a configured limit are only subject to the global limit
+type Limiter struct {
+	mu     sync.Mutex
+	global *bucket
+	perKey map[string]*bucket
+	now    func() time.Time
+}
+
+// NewLimiter creates a limiter with no limits configured
+func NewLimiter() *Limiter {
+	return &Limiter{
+		perKey: make(map[string]*bucket),
+		now:    time.Now,
+	}
+}
+
+// SetLimit limits key to rate requests per second with the given burst
+// A rate of zero or less removes the limit for key
+func (l *Limiter) SetLimit(key string, rate float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if rate <= 0 {
+		delete(l.perKey, key)
+		return
+	}
+	l.perKey[key] = l.newBucket(rate, burst)
+}
+
+// SetGlobalLimit limits all keys combined to rate requests per second with the given burst
+// A rate of zero or less removes the global limit
+func (l *Limiter) SetGlobalLimit(rate float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if rate <= 0 {
+		l.global = nil
+		return
+	}
+	l.global = l.newBucket(rate, burst)
+}
+
+// Allow reports whether a request for key may proceed now
+// If not, it returns how long to wait before the next request would be allowed
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	keyBucket := l.perKey[key]
+	if keyBucket != nil {
+		if ok, wait := keyBucket.take(now); !ok {
+			return false, wait
+		}
+	}
+	if l.global != nil {
+		if ok, wait := l.global.take(now); !ok {
+			// Don't charge the tenant for a request the global limit rejected
+			if keyBucket != nil {
+				keyBucket.refund()
+			}
+			return false, wait
+		}
+	}
+	return true, 0
+}
+
+func (l *Limiter) newBucket(rate float64, burst int) *bucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &bucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: l.now()}
+}
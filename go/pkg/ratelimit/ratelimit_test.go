@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiterPerKeyAndGlobal(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := NewLimiter()
+	limiter.now = func() time.Time { return now }
+
+	limiter.SetLimit("noisy", 1, 2)
+	limiter.SetGlobalLimit(10, 3)
+
+	// Burst of two for the limited key
+	for i := 0; i < 2; i++ {
+		if ok, _ := limiter.Allow("noisy"); !ok {
+			t.Fatalf("Expected request %d to be allowed", i)
+		}
+	}
+	ok, wait := limiter.Allow("noisy")
+	if ok {
+		t.Fatal("Expected third request to be rate limited")
+	}
+	if wait != time.Second {
+		t.Errorf("Expected retry after 1s, got %v", wait)
+	}
+
+	// Unlimited key is still subject to the remaining global token
+	if ok, _ := limiter.Allow("quiet"); !ok {
+		t.Error("Expected unlimited key to be allowed by global limit")
+	}
+	if ok, _ := limiter.Allow("quiet"); ok {
+		t.Error("Expected global limit to reject once exhausted")
+	}
+
+	// Tokens refill over time
+	now = now.Add(time.Second)
+	if ok, _ := limiter.Allow("noisy"); !ok {
+		t.Error("Expected request to be allowed after refill")
+	}
+
+	// Removing limits
+	limiter.SetLimit("noisy", 0, 0)
+	limiter.SetGlobalLimit(0, 0)
+	for i := 0; i < 100; i++ {
+		if ok, _ := limiter.Allow("noisy"); !ok {
+			t.Fatal("Expected no limit after removal")
+		}
+	}
+}
+
+func TestLimiterGlobalRejectionRefundsKey(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := NewLimiter()
+	limiter.now = func() time.Time { return now }
+
+	limiter.SetLimit("app", 1, 1)
+	limiter.SetGlobalLimit(1, 1)
+
+	if ok, _ := limiter.Allow("other"); !ok {
+		t.Fatal("Expected first request to be allowed")
+	}
+	if ok, _ := limiter.Allow("app"); ok {
+		t.Fatal("Expected global limit to reject")
+	}
+	now = now.Add(time.Second)
+	if ok, _ := limiter.Allow("app"); !ok {
+		t.Error("Expected key token to have been refunded after global rejection")
+	}
+}
@@ -0,0 +1,284 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package revocation
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha1"
+	_ "crypto/sha256" // Hashes responders may use in certificate IDs
+	_ "crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+)
+
+// ocspStatus is the certificate status an OCSP responder reports
+type ocspStatus int
+
+const (
+	ocspGood ocspStatus = iota
+	ocspRevoked
+	ocspUnknown
+)
+
+var (
+	oidSHA1      = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+	oidOCSPBasic = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 1}
+)
+
+// signatureAlgorithms maps the signature algorithm OIDs OCSP responders use
+var signatureAlgorithms = map[string]x509.SignatureAlgorithm{
+	"1.2.840.113549.1.1.5":  x509.SHA1WithRSA,
+	"1.2.840.113549.1.1.11": x509.SHA256WithRSA,
+	"1.2.840.113549.1.1.12": x509.SHA384WithRSA,
+	"1.2.840.113549.1.1.13": x509.SHA512WithRSA,
+	"1.2.840.10045.4.1":     x509.ECDSAWithSHA1,
+	"1.2.840.10045.4.3.2":   x509.ECDSAWithSHA256,
+	"1.2.840.10045.4.3.3":   x509.ECDSAWithSHA384,
+	"1.2.840.10045.4.3.4":   x509.ECDSAWithSHA512,
+	"1.3.101.112":           x509.PureEd25519,
+}
+
+// ASN.1 structures of RFC 6960
+type certID struct {
+	HashAlgorithm pkix.AlgorithmIdentifier
+	NameHash      []byte
+	IssuerKeyHash []byte
+	SerialNumber  *big.Int
+}
+
+type ocspRequest struct {
+	TBSRequest tbsRequest
+}
+
+type tbsRequest struct {
+	Version     int `asn1:"explicit,tag:0,default:0,optional"`
+	RequestList []singleRequest
+}
+
+type singleRequest struct {
+	Cert certID
+}
+
+type responseASN1 struct {
+	Status   asn1.Enumerated
+	Response responseBytes `asn1:"explicit,tag:0,optional"`
+}
+
+type responseBytes struct {
+	ResponseType asn1.ObjectIdentifier
+	Response     []byte
+}
+
+type basicResponse struct {
+	TBSResponseData    responseData
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          asn1.BitString
+	Certificates       []asn1.RawValue `asn1:"explicit,tag:0,optional"`
+}
+
+type responseData struct {
+	Raw                asn1.RawContent
+	Version            int `asn1:"optional,default:0,explicit,tag:0"`
+	RawResponderID     asn1.RawValue
+	ProducedAt         time.Time `asn1:"generalized"`
+	Responses          []singleResponse
+	ResponseExtensions []pkix.Extension `asn1:"explicit,tag:1,optional"`
+}
+
+type singleResponse struct {
+	CertID           certID
+	Good             asn1.Flag        `asn1:"tag:0,optional"`
+	Revoked          revokedInfo      `asn1:"tag:1,optional"`
+	Unknown          asn1.Flag        `asn1:"tag:2,optional"`
+	ThisUpdate       time.Time        `asn1:"generalized"`
+	NextUpdate       time.Time        `asn1:"generalized,explicit,tag:0,optional"`
+	SingleExtensions []pkix.Extension `asn1:"explicit,tag:1,optional"`
+}
+
+type revokedInfo struct {
+	RevocationTime time.Time       `asn1:"generalized"`
+	Reason         asn1.Enumerated `asn1:"explicit,tag:0,optional"`
+}
+
+// newCertID identifies cert of issuer with SHA-1 hashes, which all responders support
+func newCertID(cert, issuer *x509.Certificate) (certID, error) {
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(issuer.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return certID{}, fmt.Errorf("failed to parse issuer public key: %w", err)
+	}
+	nameHash := sha1.Sum(issuer.RawSubject)
+	keyHash := sha1.Sum(spki.PublicKey.RightAlign())
+	return certID{
+		HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidSHA1, Parameters: asn1.NullRawValue},
+		NameHash:      nameHash[:],
+		IssuerKeyHash: keyHash[:],
+		SerialNumber:  cert.SerialNumber,
+	}, nil
+}
+
+// checkOCSP asks the responder at url about cert; done is false if it gave no usable answer
+func (c *Checker) checkOCSP(ctx context.Context, url string, cert, issuer *x509.Certificate) (done bool, err error) {
+	id, err := newCertID(cert, issuer)
+	if err != nil {
+		return false, err
+	}
+	key := serialKey(id.IssuerKeyHash, cert.SerialNumber)
+	now := c.now()
+
+	c.mu.Lock()
+	cached, ok := c.ocsp[key]
+	c.mu.Unlock()
+	if !ok || !now.Before(cached.expires) {
+		if cached, err = c.fetchOCSP(ctx, url, id, issuer); err != nil {
+			return false, err
+		}
+		c.mu.Lock()
+		c.ocsp[key] = cached
+		c.mu.Unlock()
+	}
+
+	switch cached.status {
+	case ocspRevoked:
+		return true, fmt.Errorf("%w: %s (serial %s) revoked at %s according to %s",
+			ErrRevoked, cert.Subject, cert.SerialNumber, cached.revokedAt.Format(time.RFC3339), url)
+	case ocspGood:
+		return true, nil
+	default:
+		return false, fmt.Errorf("OCSP responder %s doesn't know serial %s", url, cert.SerialNumber)
+	}
+}
+
+// fetchOCSP sends an OCSP request and verifies the response
+func (c *Checker) fetchOCSP(ctx context.Context, url string, id certID, issuer *x509.Certificate) (*cachedOCSP, error) {
+	request, err := asn1.Marshal(ocspRequest{TBSRequest: tbsRequest{RequestList: []singleRequest{{Cert: id}}}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OCSP request: %w", err)
+	}
+	fetchCtx, cancel := context.WithTimeout(ctx, c.fetchTimeout)
+	defer cancel()
+	data, err := c.fetcher.FetchOCSP(fetchCtx, url, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query OCSP responder %s: %w", url, err)
+	}
+
+	single, err := parseOCSPResponse(data, id, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response from OCSP responder %s: %w", url, err)
+	}
+	now := c.now()
+	if !single.NextUpdate.IsZero() && now.After(single.NextUpdate) {
+		return nil, fmt.Errorf("stale response from OCSP responder %s, next update was %s", url, single.NextUpdate.Format(time.RFC3339))
+	}
+
+	cached := &cachedOCSP{status: ocspUnknown, expires: c.expiry(now, single.NextUpdate)}
+	switch {
+	case bool(single.Good):
+		cached.status = ocspGood
+	case !single.Revoked.RevocationTime.IsZero():
+		cached.status, cached.revokedAt = ocspRevoked, single.Revoked.RevocationTime
+	}
+	return cached, nil
+}
+
+// parseOCSPResponse returns the verified single response about id
+func parseOCSPResponse(data []byte, id certID, issuer *x509.Certificate) (*singleResponse, error) {
+	var resp responseASN1
+	if rest, err := asn1.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	} else if len(rest) > 0 {
+		return nil, errors.New("trailing data")
+	}
+	if resp.Status != 0 {
+		return nil, fmt.Errorf("responder status %d", resp.Status)
+	}
+	if !resp.Response.ResponseType.Equal(oidOCSPBasic) {
+		return nil, fmt.Errorf("unsupported response type %v", resp.Response.ResponseType)
+	}
+	var basic basicResponse
+	if _, err := asn1.Unmarshal(resp.Response.Response, &basic); err != nil {
+		return nil, err
+	}
+
+	// The issuer signs its responses itself or through a responder certificate it issued
+	signer := issuer
+	if len(basic.Certificates) > 0 {
+		responder, err := x509.ParseCertificate(basic.Certificates[0].FullBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid responder certificate: %w", err)
+		}
+		if !bytes.Equal(responder.Raw, issuer.Raw) {
+			if err := responder.CheckSignatureFrom(issuer); err != nil {
+				return nil, fmt.Errorf("responder certificate not issued by %s: %w", issuer.Subject, err)
+			}
+			if !slices.Contains(responder.ExtKeyUsage, x509.ExtKeyUsageOCSPSigning) {
+				return nil, errors.New("responder certificate lacks the OCSP signing usage")
+			}
+		}
+		signer = responder
+	}
+	algorithm, ok := signatureAlgorithms[basic.SignatureAlgorithm.Algorithm.String()]
+	if !ok {
+		return nil, fmt.Errorf("unsupported signature algorithm %v", basic.SignatureAlgorithm.Algorithm)
+	}
+	if err := signer.CheckSignature(algorithm, basic.TBSResponseData.Raw, basic.Signature.RightAlign()); err != nil {
+		return nil, fmt.Errorf("bad signature: %w", err)
+	}
+
+	for i, single := range basic.TBSResponseData.Responses {
+		if single.CertID.SerialNumber.Cmp(id.SerialNumber) == 0 && matchesIssuer(single.CertID, id, issuer) {
+			return &basic.TBSResponseData.Responses[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no status for serial %s", id.SerialNumber)
+}
+
+// matchesIssuer reports whether a response's certificate ID names the same issuer as id; the
+// responder may hash with another algorithm than the request did
+func matchesIssuer(got, want certID, issuer *x509.Certificate) bool {
+	if got.HashAlgorithm.Algorithm.Equal(want.HashAlgorithm.Algorithm) {
+		return bytes.Equal(got.NameHash, want.NameHash) && bytes.Equal(got.IssuerKeyHash, want.IssuerKeyHash)
+	}
+	hash, ok := map[string]crypto.Hash{
+		"2.16.840.1.101.3.4.2.1": crypto.SHA256,
+		"2.16.840.1.101.3.4.2.2": crypto.SHA384,
+		"2.16.840.1.101.3.4.2.3": crypto.SHA512,
+	}[got.HashAlgorithm.Algorithm.String()]
+	if !ok || !hash.Available() {
+		return false
+	}
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(issuer.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return false
+	}
+	h := hash.New()
+	h.Write(issuer.RawSubject)
+	nameHash := h.Sum(nil)
+	h.Reset()
+	h.Write(spki.PublicKey.RightAlign())
+	return bytes.Equal(got.NameHash, nameHash) && bytes.Equal(got.IssuerKeyHash, h.Sum(nil))
+}
@@ -0,0 +1,294 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package revocation checks whether peer certificates have been revoked, through OCSP
+// responders and CRL distribution points
+package revocation
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrRevoked is returned when a certificate has been revoked
+var ErrRevoked = errors.New("certificate revoked")
+
+// Default settings of a Checker
+const (
+	DefaultCacheTTL     = time.Hour
+	DefaultFetchTimeout = 5 * time.Second
+
+	// maxResponseSize bounds fetched CRLs and OCSP responses
+	maxResponseSize = 16 << 20
+)
+
+// Fetcher retrieves revocation data. Replace the default HTTPFetcher to go through a proxy,
+// read from a local mirror or serve fixed data in tests
+type Fetcher interface {
+	// FetchCRL returns the DER or PEM CRL published at url
+	FetchCRL(ctx context.Context, url string) ([]byte, error)
+	// FetchOCSP sends a DER OCSP request to the responder at url and returns its DER response
+	FetchOCSP(ctx context.Context, url string, request []byte) ([]byte, error)
+}
+
+// HTTPFetcher fetches CRLs and OCSP responses over HTTP
+type HTTPFetcher struct {
+	Client *http.Client // http.DefaultClient if nil
+}
+
+// FetchCRL downloads a CRL
+func (f *HTTPFetcher) FetchCRL(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return f.do(req)
+}
+
+// FetchOCSP posts an OCSP request
+func (f *HTTPFetcher) FetchOCSP(ctx context.Context, url string, request []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(string(request)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/ocsp-request")
+	req.Header.Set("Accept", "application/ocsp-response")
+	return f.do(req)
+}
+
+// do runs a request and returns the body of a successful response
+func (f *HTTPFetcher) do(req *http.Request) ([]byte, error) {
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s: HTTP %d", req.Method, req.URL, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxResponseSize {
+		return nil, fmt.Errorf("%s %s: response larger than %d bytes", req.Method, req.URL, maxResponseSize)
+	}
+	return body, nil
+}
+
+// Options configures a Checker; zero values keep the defaults
+type Options struct {
+	Fetcher Fetcher // HTTPFetcher if nil
+
+	DisableOCSP bool // Don't ask OCSP responders
+	DisableCRL  bool // Don't download CRLs
+
+	// HardFail rejects certificates whose status can't be determined, because no responder or
+	// CRL could be reached or the certificate names none. By default they are accepted and logged
+	HardFail bool
+
+	CacheTTL     time.Duration // Longest time a result is reused; also bounded by the next update it announces
+	FetchTimeout time.Duration // Timeout of each fetch
+}
+
+// Checker checks certificates against OCSP responders and CRLs, caching the results
+type Checker struct {
+	fetcher      Fetcher
+	disableOCSP  bool
+	disableCRL   bool
+	hardFail     bool
+	cacheTTL     time.Duration
+	fetchTimeout time.Duration
+
+	mu   sync.Mutex
+	crls map[string]*cachedCRL  // by distribution point URL
+	ocsp map[string]*cachedOCSP // by issuer key hash and serial number
+	now  func() time.Time
+}
+
+// cachedCRL is a verified CRL
+type cachedCRL struct {
+	revoked map[string]time.Time // serial number to revocation time
+	expires time.Time
+}
+
+// cachedOCSP is a verified OCSP status
+type cachedOCSP struct {
+	status    ocspStatus
+	revokedAt time.Time
+	expires   time.Time
+}
+
+// NewChecker creates a revocation checker
+func NewChecker(opts Options) *Checker {
+	c := &Checker{
+		fetcher:      opts.Fetcher,
+		disableOCSP:  opts.DisableOCSP,
+		disableCRL:   opts.DisableCRL,
+		hardFail:     opts.HardFail,
+		cacheTTL:     opts.CacheTTL,
+		fetchTimeout: opts.FetchTimeout,
+		crls:         make(map[string]*cachedCRL),
+		ocsp:         make(map[string]*cachedOCSP),
+		now:          time.Now,
+	}
+	if c.fetcher == nil {
+		c.fetcher = &HTTPFetcher{}
+	}
+	if c.cacheTTL <= 0 {
+		c.cacheTTL = DefaultCacheTTL
+	}
+	if c.fetchTimeout <= 0 {
+		c.fetchTimeout = DefaultFetchTimeout
+	}
+	return c
+}
+
+// VerifyConnection checks every certificate of the peer's chain but the root; use it as
+// tls.Config.VerifyConnection
+func (c *Checker) VerifyConnection(state tls.ConnectionState) error {
+	chain := state.PeerCertificates
+	if len(state.VerifiedChains) > 0 {
+		chain = state.VerifiedChains[0]
+	}
+	for i := 0; i+1 < len(chain); i++ {
+		if err := c.Check(context.Background(), chain[i], chain[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Check returns an error wrapping ErrRevoked if cert, issued by issuer, has been revoked. OCSP is
+// asked first, then the CRLs; an error is also returned for an unknown status with HardFail
+func (c *Checker) Check(ctx context.Context, cert, issuer *x509.Certificate) error {
+	var failures []error
+	if !c.disableOCSP {
+		for _, url := range cert.OCSPServer {
+			done, err := c.checkOCSP(ctx, url, cert, issuer)
+			if done {
+				return err
+			}
+			failures = append(failures, err)
+		}
+	}
+	if !c.disableCRL {
+		for _, url := range cert.CRLDistributionPoints {
+			done, err := c.checkCRL(ctx, url, cert, issuer)
+			if done {
+				return err
+			}
+			failures = append(failures, err)
+		}
+	}
+
+	if len(failures) == 0 && len(cert.OCSPServer) == 0 && len(cert.CRLDistributionPoints) == 0 {
+		failures = append(failures, errors.New("certificate names no OCSP responder or CRL"))
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	err := fmt.Errorf("revocation status of %s (serial %s) unknown: %w", cert.Subject, cert.SerialNumber, errors.Join(failures...))
+	if c.hardFail {
+		return err
+	}
+	log.Printf("⚠️  %v; accepting it", err)
+	return nil
+}
+
+// checkCRL looks cert up in the CRL at url; done is false if the CRL couldn't be used
+func (c *Checker) checkCRL(ctx context.Context, url string, cert, issuer *x509.Certificate) (done bool, err error) {
+	crl, err := c.crl(ctx, url, issuer)
+	if err != nil {
+		return false, err
+	}
+	if revokedAt, ok := crl.revoked[cert.SerialNumber.String()]; ok {
+		return true, fmt.Errorf("%w: %s (serial %s) revoked at %s according to %s",
+			ErrRevoked, cert.Subject, cert.SerialNumber, revokedAt.Format(time.RFC3339), url)
+	}
+	return true, nil
+}
+
+// crl returns the cached CRL of url, fetching and verifying it when missing or expired
+func (c *Checker) crl(ctx context.Context, url string, issuer *x509.Certificate) (*cachedCRL, error) {
+	now := c.now()
+	c.mu.Lock()
+	cached, ok := c.crls[url]
+	c.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached, nil
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, c.fetchTimeout)
+	defer cancel()
+	data, err := c.fetcher.FetchCRL(fetchCtx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch CRL %s: %w", url, err)
+	}
+	list, err := x509.ParseRevocationList(decodePEM(data, "X509 CRL"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CRL %s: %w", url, err)
+	}
+	if err := list.CheckSignatureFrom(issuer); err != nil {
+		return nil, fmt.Errorf("CRL %s not signed by %s: %w", url, issuer.Subject, err)
+	}
+	if !list.NextUpdate.IsZero() && now.After(list.NextUpdate) {
+		return nil, fmt.Errorf("CRL %s expired at %s", url, list.NextUpdate.Format(time.RFC3339))
+	}
+
+	cached = &cachedCRL{revoked: make(map[string]time.Time, len(list.RevokedCertificateEntries)), expires: c.expiry(now, list.NextUpdate)}
+	for _, entry := range list.RevokedCertificateEntries {
+		cached.revoked[entry.SerialNumber.String()] = entry.RevocationTime
+	}
+	c.mu.Lock()
+	c.crls[url] = cached
+	c.mu.Unlock()
+	return cached, nil
+}
+
+// expiry returns when a result fetched at now stops being reused
+func (c *Checker) expiry(now, nextUpdate time.Time) time.Time {
+	expires := now.Add(c.cacheTTL)
+	if !nextUpdate.IsZero() && nextUpdate.Before(expires) {
+		expires = nextUpdate
+	}
+	return expires
+}
+
+// decodePEM returns the DER of a PEM block of the given type, or data unchanged if it isn't PEM
+func decodePEM(data []byte, blockType string) []byte {
+	if block, _ := pem.Decode(data); block != nil && block.Type == blockType {
+		return block.Bytes
+	}
+	return data
+}
+
+// serialKey identifies a certificate of an issuer in the OCSP cache
+func serialKey(issuerKeyHash []byte, serial *big.Int) string {
+	return fmt.Sprintf("%x/%s", issuerKeyHash, serial)
+}
@@ -0,0 +1,367 @@
+package revocation
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+const (
+	ocspURL = "http://ocsp.test"
+	crlURL  = "http://crl.test/ca.crl"
+)
+
+// testCA is a CA issuing certificates that name a test OCSP responder and CRL
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create CA certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse CA certificate: %v", err)
+	}
+	return &testCA{cert: cert, key: key}
+}
+
+// issue returns a certificate with the given serial number naming the given endpoints
+func (ca *testCA) issue(t *testing.T, serial int64, ocspServers, crls []string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "tee-node"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		OCSPServer:            ocspServers,
+		CRLDistributionPoints: crls,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	return cert
+}
+
+// crl returns a CRL revoking the given serial numbers
+func (ca *testCA) crl(t *testing.T, nextUpdate time.Time, revoked ...int64) []byte {
+	t.Helper()
+	template := &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: time.Now().Add(-time.Minute),
+		NextUpdate: nextUpdate,
+	}
+	for _, serial := range revoked {
+		template.RevokedCertificateEntries = append(template.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   big.NewInt(serial),
+			RevocationTime: time.Now().Add(-time.Minute),
+		})
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, template, ca.cert, ca.key)
+	if err != nil {
+		t.Fatalf("failed to create CRL: %v", err)
+	}
+	return der
+}
+
+// ocsp returns an OCSP response about cert with the given status, signed by signer
+func (ca *testCA) ocsp(t *testing.T, cert *x509.Certificate, status ocspStatus, nextUpdate time.Time, signer *ecdsa.PrivateKey) []byte {
+	t.Helper()
+	id, err := newCertID(cert, ca.cert)
+	if err != nil {
+		t.Fatalf("newCertID failed: %v", err)
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	single := singleResponse{CertID: id, ThisUpdate: now, NextUpdate: nextUpdate.UTC().Truncate(time.Second)}
+	switch status {
+	case ocspGood:
+		single.Good = true
+	case ocspRevoked:
+		single.Revoked = revokedInfo{RevocationTime: now.Add(-time.Minute)}
+	default:
+		single.Unknown = true
+	}
+	tbs, err := asn1.Marshal(responseData{
+		RawResponderID: asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 1, IsCompound: true, Bytes: mustMarshal(t, asn1.RawValue{FullBytes: ca.cert.RawSubject})},
+		ProducedAt:     now,
+		Responses:      []singleResponse{single},
+	})
+	if err != nil {
+		t.Fatalf("failed to encode response data: %v", err)
+	}
+	digest := sha256.Sum256(tbs)
+	signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		t.Fatalf("failed to sign response: %v", err)
+	}
+	basic := mustMarshal(t, basicResponse{
+		TBSResponseData:    responseData{Raw: tbs},
+		SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}},
+		Signature:          asn1.BitString{Bytes: signature, BitLength: 8 * len(signature)},
+	})
+	return mustMarshal(t, responseASN1{Response: responseBytes{ResponseType: oidOCSPBasic, Response: basic}})
+}
+
+func mustMarshal(t *testing.T, value any) []byte {
+	t.Helper()
+	der, err := asn1.Marshal(value)
+	if err != nil {
+		t.Fatalf("failed to encode %T: %v", value, err)
+	}
+	return der
+}
+
+// fakeFetcher serves fixed responses and counts fetches
+type fakeFetcher struct {
+	crl      []byte
+	ocsp     []byte
+	crlErr   error
+	ocspErr  error
+	crlCalls int
+	ocspCall int
+}
+
+func (f *fakeFetcher) FetchCRL(ctx context.Context, url string) ([]byte, error) {
+	f.crlCalls++
+	return f.crl, f.crlErr
+}
+
+func (f *fakeFetcher) FetchOCSP(ctx context.Context, url string, request []byte) ([]byte, error) {
+	f.ocspCall++
+	var req ocspRequest
+	if _, err := asn1.Unmarshal(request, &req); err != nil {
+		return nil, err
+	}
+	return f.ocsp, f.ocspErr
+}
+
+func TestCheckCRL(t *testing.T) {
+	ca := newTestCA(t)
+	good := ca.issue(t, 10, nil, []string{crlURL})
+	revoked := ca.issue(t, 11, nil, []string{crlURL})
+	fetcher := &fakeFetcher{crl: ca.crl(t, time.Now().Add(time.Hour), 11)}
+	checker := NewChecker(Options{Fetcher: fetcher, HardFail: true})
+
+	if err := checker.Check(context.Background(), good, ca.cert); err != nil {
+		t.Errorf("good certificate rejected: %v", err)
+	}
+	if err := checker.Check(context.Background(), revoked, ca.cert); !errors.Is(err, ErrRevoked) {
+		t.Errorf("Check() = %v, want ErrRevoked", err)
+	}
+	if fetcher.crlCalls != 1 {
+		t.Errorf("CRL fetched %d times, want 1", fetcher.crlCalls)
+	}
+
+	// The cached CRL is reused until the cache TTL passes
+	checker.now = func() time.Time { return time.Now().Add(DefaultCacheTTL / 2) }
+	checker.Check(context.Background(), good, ca.cert)
+	if fetcher.crlCalls != 1 {
+		t.Errorf("CRL fetched %d times before expiry, want 1", fetcher.crlCalls)
+	}
+	checker.now = func() time.Time { return time.Now().Add(DefaultCacheTTL + time.Minute) }
+	fetcher.crl = ca.crl(t, time.Now().Add(2*DefaultCacheTTL))
+	if err := checker.Check(context.Background(), revoked, ca.cert); err != nil {
+		t.Errorf("certificate rejected after refetch: %v", err)
+	}
+	if fetcher.crlCalls != 2 {
+		t.Errorf("CRL fetched %d times after expiry, want 2", fetcher.crlCalls)
+	}
+}
+
+func TestCheckCRLInvalid(t *testing.T) {
+	ca := newTestCA(t)
+	other := newTestCA(t)
+	cert := ca.issue(t, 10, nil, []string{crlURL})
+
+	tests := []struct {
+		name    string
+		fetcher *fakeFetcher
+	}{
+		{name: "fetch error", fetcher: &fakeFetcher{crlErr: errors.New("unreachable")}},
+		{name: "garbage", fetcher: &fakeFetcher{crl: []byte("not a CRL")}},
+		{name: "wrong issuer", fetcher: &fakeFetcher{crl: other.crl(t, time.Now().Add(time.Hour))}},
+		{name: "expired", fetcher: &fakeFetcher{crl: ca.crl(t, time.Now().Add(-time.Second))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewChecker(Options{Fetcher: tt.fetcher, HardFail: true}).Check(context.Background(), cert, ca.cert)
+			if err == nil || errors.Is(err, ErrRevoked) {
+				t.Errorf("hard fail Check() = %v, want unknown status error", err)
+			}
+			if err := NewChecker(Options{Fetcher: tt.fetcher}).Check(context.Background(), cert, ca.cert); err != nil {
+				t.Errorf("soft fail Check() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestCheckOCSP(t *testing.T) {
+	ca := newTestCA(t)
+	cert := ca.issue(t, 10, []string{ocspURL}, []string{crlURL})
+	nextUpdate := time.Now().Add(time.Hour)
+
+	// A delegated responder certificate issued by the CA
+	responderKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	responderDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(99),
+		Subject:      pkix.Name{CommonName: "responder"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageOCSPSigning},
+	}, ca.cert, &responderKey.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("failed to create responder certificate: %v", err)
+	}
+	delegated := func(response []byte) []byte {
+		var resp responseASN1
+		var basic basicResponse
+		asn1.Unmarshal(response, &resp)
+		asn1.Unmarshal(resp.Response.Response, &basic)
+		basic.TBSResponseData = responseData{Raw: basic.TBSResponseData.Raw}
+		basic.Certificates = []asn1.RawValue{{FullBytes: responderDER}}
+		resp.Response.Response = mustMarshal(t, basic)
+		return mustMarshal(t, resp)
+	}
+
+	tests := []struct {
+		name        string
+		ocsp        []byte
+		wantRevoked bool
+		wantErr     bool
+	}{
+		{name: "good", ocsp: ca.ocsp(t, cert, ocspGood, nextUpdate, ca.key)},
+		{name: "revoked", ocsp: ca.ocsp(t, cert, ocspRevoked, nextUpdate, ca.key), wantRevoked: true},
+		{name: "delegated responder", ocsp: delegated(ca.ocsp(t, cert, ocspRevoked, nextUpdate, responderKey)), wantRevoked: true},
+		{name: "unknown falls back to CRL", ocsp: ca.ocsp(t, cert, ocspUnknown, nextUpdate, ca.key)},
+		{name: "bad signature", ocsp: ca.ocsp(t, cert, ocspRevoked, nextUpdate, responderKey), wantErr: true},
+		{name: "stale", ocsp: ca.ocsp(t, cert, ocspRevoked, time.Now().Add(-time.Minute), ca.key), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The CRL is unreachable, so only a usable OCSP response gives a status
+			fetcher := &fakeFetcher{ocsp: tt.ocsp, crlErr: errors.New("unreachable")}
+			if tt.name == "unknown falls back to CRL" {
+				fetcher.crl, fetcher.crlErr = ca.crl(t, nextUpdate), nil
+			}
+			err := NewChecker(Options{Fetcher: fetcher, HardFail: true}).Check(context.Background(), cert, ca.cert)
+			switch {
+			case tt.wantRevoked && !errors.Is(err, ErrRevoked):
+				t.Errorf("Check() = %v, want ErrRevoked", err)
+			case tt.wantErr && (err == nil || errors.Is(err, ErrRevoked)):
+				t.Errorf("Check() = %v, want unknown status error", err)
+			case !tt.wantRevoked && !tt.wantErr && err != nil:
+				t.Errorf("Check() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestCheckOCSPCache(t *testing.T) {
+	ca := newTestCA(t)
+	cert := ca.issue(t, 10, []string{ocspURL}, nil)
+	fetcher := &fakeFetcher{ocsp: ca.ocsp(t, cert, ocspGood, time.Now().Add(10*time.Minute), ca.key)}
+	checker := NewChecker(Options{Fetcher: fetcher, HardFail: true})
+
+	for i := 0; i < 3; i++ {
+		if err := checker.Check(context.Background(), cert, ca.cert); err != nil {
+			t.Fatalf("Check() = %v", err)
+		}
+	}
+	if fetcher.ocspCall != 1 {
+		t.Errorf("OCSP queried %d times, want 1", fetcher.ocspCall)
+	}
+
+	// The response's next update comes before the cache TTL
+	fetcher.ocsp = ca.ocsp(t, cert, ocspRevoked, time.Now().Add(time.Hour), ca.key)
+	checker.now = func() time.Time { return time.Now().Add(11 * time.Minute) }
+	if err := checker.Check(context.Background(), cert, ca.cert); !errors.Is(err, ErrRevoked) {
+		t.Errorf("Check() = %v, want ErrRevoked", err)
+	}
+	if fetcher.ocspCall != 2 {
+		t.Errorf("OCSP queried %d times, want 2", fetcher.ocspCall)
+	}
+}
+
+func TestCheckOptions(t *testing.T) {
+	ca := newTestCA(t)
+	cert := ca.issue(t, 10, []string{ocspURL}, []string{crlURL})
+	fetcher := &fakeFetcher{
+		ocsp: ca.ocsp(t, cert, ocspGood, time.Now().Add(time.Hour), ca.key),
+		crl:  ca.crl(t, time.Now().Add(time.Hour), 10),
+	}
+
+	if err := NewChecker(Options{Fetcher: fetcher}).Check(context.Background(), cert, ca.cert); err != nil {
+		t.Errorf("OCSP should be asked first, got %v", err)
+	}
+	if err := NewChecker(Options{Fetcher: fetcher, DisableOCSP: true}).Check(context.Background(), cert, ca.cert); !errors.Is(err, ErrRevoked) {
+		t.Errorf("Check() without OCSP = %v, want ErrRevoked", err)
+	}
+	if err := NewChecker(Options{Fetcher: fetcher, DisableOCSP: true, DisableCRL: true, HardFail: true}).Check(context.Background(), cert, ca.cert); err != nil {
+		t.Errorf("Check() with both sources disabled = %v, want nil", err)
+	}
+
+	bare := ca.issue(t, 11, nil, nil)
+	if err := NewChecker(Options{Fetcher: fetcher}).Check(context.Background(), bare, ca.cert); err != nil {
+		t.Errorf("soft fail Check() = %v, want nil", err)
+	}
+	if err := NewChecker(Options{Fetcher: fetcher, HardFail: true}).Check(context.Background(), bare, ca.cert); err == nil {
+		t.Error("expected a certificate without endpoints to fail with HardFail")
+	}
+}
+
+func TestVerifyConnection(t *testing.T) {
+	ca := newTestCA(t)
+	cert := ca.issue(t, 10, nil, []string{crlURL})
+	checker := NewChecker(Options{Fetcher: &fakeFetcher{crl: ca.crl(t, time.Now().Add(time.Hour), 10)}, HardFail: true})
+
+	state := tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert, ca.cert}}}
+	if err := checker.VerifyConnection(state); !errors.Is(err, ErrRevoked) {
+		t.Errorf("VerifyConnection() = %v, want ErrRevoked", err)
+	}
+	state = tls.ConnectionState{PeerCertificates: []*x509.Certificate{ca.cert}}
+	if err := checker.VerifyConnection(state); err != nil {
+		t.Errorf("VerifyConnection() of a lone root = %v, want nil", err)
+	}
+}
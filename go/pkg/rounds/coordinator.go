@@ -0,0 +1,102 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package rounds
+
+import (
+	"sync"
+	"time"
+)
+
+// Coordinator lets replicas of an app agree on which one runs the voting round for a message
+// and share its outcome with the others. Implementations back it with storage all replicas
+// reach (e.g. Redis SET NX) and must be safe for concurrent use
+type Coordinator interface {
+	// Claim makes owner the leader of the round identified by key for ttl, unless another
+	// owner holds an unexpired claim. It reports whether owner is now the leader
+	Claim(key, owner string, ttl time.Duration) (bool, error)
+	// Release drops owner's claim on key; claims held by other owners are kept
+	Release(key, owner string) error
+	// Publish stores the outcome of the round identified by key for ttl
+	Publish(key string, outcome []byte, ttl time.Duration) error
+	// Outcome returns the published outcome of a round, if any
+	Outcome(key string) ([]byte, bool, error)
+}
+
+// expiring is a value with an expiry time
+type expiring struct {
+	value   string
+	expires time.Time
+}
+
+// MemoryCoordinator coordinates replicas running in one process, e.g. in tests
+type MemoryCoordinator struct {
+	mu       sync.Mutex
+	claims   map[string]expiring
+	outcomes map[string]expiring
+	now      func() time.Time
+}
+
+// NewMemoryCoordinator creates an in-memory coordinator
+func NewMemoryCoordinator() *MemoryCoordinator {
+	return &MemoryCoordinator{
+		claims:   make(map[string]expiring),
+		outcomes: make(map[string]expiring),
+		now:      time.Now,
+	}
+}
+
+// Claim takes key for owner if it is free or its claim expired
+func (m *MemoryCoordinator) Claim(key, owner string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	if claim, ok := m.claims[key]; ok && now.Before(claim.expires) {
+		return claim.value == owner, nil
+	}
+	m.claims[key] = expiring{value: owner, expires: now.Add(ttl)}
+	return true, nil
+}
+
+// Release drops owner's claim on key
+func (m *MemoryCoordinator) Release(key, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if claim, ok := m.claims[key]; ok && claim.value == owner {
+		delete(m.claims, key)
+	}
+	return nil
+}
+
+// Publish stores a copy of outcome under key
+func (m *MemoryCoordinator) Publish(key string, outcome []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.outcomes[key] = expiring{value: string(outcome), expires: m.now().Add(ttl)}
+	return nil
+}
+
+// Outcome returns a copy of the unexpired outcome under key
+func (m *MemoryCoordinator) Outcome(key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	outcome, ok := m.outcomes[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !m.now().Before(outcome.expires) {
+		delete(m.outcomes, key)
+		return nil, false, nil
+	}
+	return []byte(outcome.value), true, nil
+}
@@ -0,0 +1,58 @@
+package rounds
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryCoordinatorClaim(t *testing.T) {
+	m := NewMemoryCoordinator()
+	now := time.Now()
+	m.now = func() time.Time { return now }
+
+	if ok, _ := m.Claim("k", "a", time.Minute); !ok {
+		t.Fatal("Expected first claim to succeed")
+	}
+	if ok, _ := m.Claim("k", "b", time.Minute); ok {
+		t.Error("Expected claim held by another owner to fail")
+	}
+	if ok, _ := m.Claim("k", "a", time.Minute); !ok {
+		t.Error("Expected owner to keep its claim")
+	}
+
+	// Releasing as another owner keeps the claim
+	m.Release("k", "b")
+	if ok, _ := m.Claim("k", "b", time.Minute); ok {
+		t.Error("Expected claim to survive release by another owner")
+	}
+	m.Release("k", "a")
+	if ok, _ := m.Claim("k", "b", time.Minute); !ok {
+		t.Error("Expected claim to succeed after release")
+	}
+
+	// An expired claim can be taken over
+	now = now.Add(2 * time.Minute)
+	if ok, _ := m.Claim("k", "a", time.Minute); !ok {
+		t.Error("Expected expired claim to be taken over")
+	}
+}
+
+func TestMemoryCoordinatorOutcome(t *testing.T) {
+	m := NewMemoryCoordinator()
+	now := time.Now()
+	m.now = func() time.Time { return now }
+
+	if _, ok, _ := m.Outcome("k"); ok {
+		t.Fatal("Expected no outcome before publish")
+	}
+	m.Publish("k", []byte("result"), time.Minute)
+	outcome, ok, err := m.Outcome("k")
+	if err != nil || !ok || string(outcome) != "result" {
+		t.Fatalf("Outcome = %q, %t, %v", outcome, ok, err)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok, _ := m.Outcome("k"); ok {
+		t.Error("Expected outcome to expire")
+	}
+}
@@ -0,0 +1,59 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package rounds
+
+import (
+	"errors"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/filestore"
+)
+
+// FileStore keeps each round as a JSON file in a directory, so rounds in progress
+// survive restarts. Files are written atomically via rename
+type FileStore struct {
+	files *filestore.Store[Round]
+}
+
+// NewFileStore creates a store in dir, creating the directory if needed
+func NewFileStore(dir string) (*FileStore, error) {
+	files, err := filestore.New[Round](dir, "voting round")
+	if err != nil {
+		return nil, err
+	}
+	return &FileStore{files: files}, nil
+}
+
+// Put writes round to <dir>/<id>.json
+func (s *FileStore) Put(round *Round) error {
+	return s.files.Put(round.ID, round)
+}
+
+// List reads all stored rounds, oldest first
+func (s *FileStore) List() ([]*Round, error) {
+	rounds, err := s.files.List()
+	if err != nil {
+		return nil, err
+	}
+	sortRounds(rounds)
+	return rounds, nil
+}
+
+// Delete removes the round file
+func (s *FileStore) Delete(id string) error {
+	err := s.files.Delete(id)
+	if errors.Is(err, filestore.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,138 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package rounds provides durable storage for voting rounds in progress, so a restarted
+// client can resume or expire them, and coordination of rounds between app replicas
+package rounds
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned when deleting a round that is not in the store
+var ErrNotFound = errors.New("voting round not found")
+
+// State is how far a voting round got
+type State string
+
+// Round states
+const (
+	StateVoting   State = "voting"   // Collecting votes
+	StateApproved State = "approved" // Enough approvals, the signature is pending
+)
+
+// Round is a voting round in progress
+type Round struct {
+	ID             string          `json:"id"`
+	SignerAppID    string          `json:"signer_app_id"`
+	Message        []byte          `json:"message"`
+	ED25519Mode    uint32          `json:"ed25519_mode,omitempty"`
+	ED25519Context []byte          `json:"ed25519_context,omitempty"`
+	Priority       int             `json:"priority,omitempty"`
+	RequiredVotes  int             `json:"required_votes"`
+	State          State           `json:"state"`
+	Votes          map[string]bool `json:"votes,omitempty"` // Answers received so far, by app ID
+	CreatedAt      time.Time       `json:"created_at"`
+	ExpiresAt      time.Time       `json:"expires_at"`
+}
+
+// Expired reports whether the round may no longer be resumed at now
+func (r *Round) Expired(now time.Time) bool {
+	return !r.ExpiresAt.IsZero() && !now.Before(r.ExpiresAt)
+}
+
+// Clone returns a copy of r that shares no mutable state with it
+func (r *Round) Clone() *Round {
+	copied := *r
+	copied.Votes = maps.Clone(r.Votes)
+	return &copied
+}
+
+// Store persists voting rounds in progress
+// Implementations must be safe for concurrent use
+type Store interface {
+	// Put stores a round, replacing any round with the same ID
+	Put(round *Round) error
+	// List returns all stored rounds, oldest first
+	List() ([]*Round, error)
+	// Delete removes a round; it returns ErrNotFound if there is none with that ID
+	Delete(id string) error
+}
+
+// NewID returns a random round ID
+func NewID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// MemoryStore keeps rounds in memory; they are lost when the process exits
+type MemoryStore struct {
+	mu     sync.Mutex
+	rounds map[string]*Round
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{rounds: make(map[string]*Round)}
+}
+
+// Put stores a copy of round
+func (s *MemoryStore) Put(round *Round) error {
+	copied := round.Clone()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rounds[round.ID] = copied
+	return nil
+}
+
+// List returns copies of all stored rounds, oldest first
+func (s *MemoryStore) List() ([]*Round, error) {
+	s.mu.Lock()
+	rounds := make([]*Round, 0, len(s.rounds))
+	for _, round := range s.rounds {
+		rounds = append(rounds, round.Clone())
+	}
+	s.mu.Unlock()
+	sortRounds(rounds)
+	return rounds, nil
+}
+
+// Delete removes the round with the given ID
+func (s *MemoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.rounds[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.rounds, id)
+	return nil
+}
+
+// sortRounds orders rounds oldest first, breaking ties by ID
+func sortRounds(rounds []*Round) {
+	sort.Slice(rounds, func(i, j int) bool {
+		if !rounds[i].CreatedAt.Equal(rounds[j].CreatedAt) {
+			return rounds[i].CreatedAt.Before(rounds[j].CreatedAt)
+		}
+		return rounds[i].ID < rounds[j].ID
+	})
+}
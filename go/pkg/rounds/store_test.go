@@ -0,0 +1,99 @@
+package rounds
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func testStore(t *testing.T, store Store) {
+	now := time.Now()
+	for i, id := range []string{"b", "a", "c"} {
+		round := &Round{ID: id, SignerAppID: "app", State: StateVoting, Votes: map[string]bool{"x": true}, CreatedAt: now.Add(time.Duration(i) * time.Second)}
+		if err := store.Put(round); err != nil {
+			t.Fatalf("Put(%s) failed: %v", id, err)
+		}
+	}
+
+	// Updating a round replaces it
+	if err := store.Put(&Round{ID: "a", State: StateApproved, Votes: map[string]bool{"x": true, "y": false}, CreatedAt: now.Add(time.Second)}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	rounds, err := store.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(rounds) != 3 {
+		t.Fatalf("Expected 3 rounds, got %d", len(rounds))
+	}
+	for i, id := range []string{"b", "a", "c"} {
+		if rounds[i].ID != id {
+			t.Errorf("Round %d: expected %s, got %s", i, id, rounds[i].ID)
+		}
+	}
+	if rounds[1].State != StateApproved || len(rounds[1].Votes) != 2 {
+		t.Errorf("Expected updated round, got %+v", rounds[1])
+	}
+
+	if err := store.Delete("a"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := store.Delete("a"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound deleting twice, got %v", err)
+	}
+	if rounds, _ := store.List(); len(rounds) != 2 {
+		t.Errorf("Expected 2 rounds after delete, got %d", len(rounds))
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+	testStore(t, store)
+
+	// Stored rounds don't share votes with the caller
+	round := &Round{ID: "d", Votes: map[string]bool{}}
+	store.Put(round)
+	round.Votes["x"] = true
+	rounds, _ := store.List()
+	for _, stored := range rounds {
+		if stored.ID == "d" && len(stored.Votes) != 0 {
+			t.Error("Stored round changed with the caller's copy")
+		}
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	testStore(t, store)
+
+	// Rounds survive reopening the store
+	reopened, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	if rounds, _ := reopened.List(); len(rounds) != 2 {
+		t.Errorf("Expected 2 rounds after reopening, got %d", len(rounds))
+	}
+
+	if err := store.Put(&Round{ID: "../escape"}); err == nil {
+		t.Error("Expected error for ID containing a path")
+	}
+}
+
+func TestRoundExpired(t *testing.T) {
+	now := time.Now()
+	if (&Round{}).Expired(now) {
+		t.Error("Round without expiry should not expire")
+	}
+	if !(&Round{ExpiresAt: now}).Expired(now) {
+		t.Error("Round should expire at its expiry time")
+	}
+	if (&Round{ExpiresAt: now.Add(time.Second)}).Expired(now) {
+		t.Error("Round should not expire before its expiry time")
+	}
+}
@@ -0,0 +1,33 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package secret
+
+import "errors"
+
+// ErrNotFound is returned for keyring items that don't exist
+var ErrNotFound = errors.New("keyring item not found")
+
+// ErrKeyringUnsupported is returned by OSKeyring on platforms without a supported keyring
+var ErrKeyringUnsupported = errors.New("no supported OS keyring on this platform")
+
+// Keyring stores secrets by name outside the process, such as the kernel keyring on Linux.
+// Implement it to use another store, e.g. the macOS Keychain or a hardware module
+type Keyring interface {
+	Get(name string) ([]byte, error)
+	Set(name string, secret []byte) error
+	Delete(name string) error
+}
+
+// KeyringPrefix namespaces the items the SDK stores
+const KeyringPrefix = "teenet:"
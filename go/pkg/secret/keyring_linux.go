@@ -0,0 +1,86 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package secret
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// kernelKeyring keeps secrets as "user" keys in the kernel's per-user keyring, readable only by
+// processes of the same user and never swapped to disk
+type kernelKeyring struct{}
+
+// OSKeyring returns the kernel keyring
+func OSKeyring() (Keyring, error) {
+	return kernelKeyring{}, nil
+}
+
+// Get reads a secret
+func (kernelKeyring) Get(name string) ([]byte, error) {
+	id, err := kernelSearch(name)
+	if err != nil {
+		return nil, err
+	}
+	size, err := unix.KeyctlBuffer(unix.KEYCTL_READ, id, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keyring item %s: %w", name, err)
+	}
+	buf := make([]byte, size)
+	n, err := unix.KeyctlBuffer(unix.KEYCTL_READ, id, buf, 0)
+	if err != nil {
+		Zero(buf)
+		return nil, fmt.Errorf("failed to read keyring item %s: %w", name, err)
+	}
+	if n > size {
+		// The item grew between the two reads
+		Zero(buf)
+		return nil, fmt.Errorf("keyring item %s changed while reading", name)
+	}
+	return buf[:n], nil
+}
+
+// Set stores a secret, replacing any previous one
+func (kernelKeyring) Set(name string, secret []byte) error {
+	if _, err := unix.AddKey("user", KeyringPrefix+name, secret, unix.KEY_SPEC_USER_KEYRING); err != nil {
+		return fmt.Errorf("failed to store keyring item %s: %w", name, err)
+	}
+	return nil
+}
+
+// Delete removes a secret
+func (kernelKeyring) Delete(name string) error {
+	id, err := kernelSearch(name)
+	if err != nil {
+		return err
+	}
+	if _, err := unix.KeyctlInt(unix.KEYCTL_INVALIDATE, id, 0, 0, 0); err != nil {
+		return fmt.Errorf("failed to delete keyring item %s: %w", name, err)
+	}
+	return nil
+}
+
+// kernelSearch finds a secret in the user keyring
+func kernelSearch(name string) (int, error) {
+	id, err := unix.KeyctlSearch(unix.KEY_SPEC_USER_KEYRING, "user", KeyringPrefix+name, 0)
+	if errors.Is(err, unix.ENOKEY) {
+		return 0, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up keyring item %s: %w", name, err)
+	}
+	return id, nil
+}
@@ -0,0 +1,21 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+//go:build !linux
+
+package secret
+
+// OSKeyring returns ErrKeyringUnsupported; pass a custom Keyring instead
+func OSKeyring() (Keyring, error) {
+	return nil, ErrKeyringUnsupported
+}
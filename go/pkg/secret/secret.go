@@ -0,0 +1,60 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package secret limits the lifetime of private key material in memory: zeroization of key
+// bytes and parsed keys, and OS keyring storage so keys needn't sit in files
+package secret
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"math/big"
+)
+
+// Zero overwrites b with zeros
+func Zero(b []byte) {
+	clear(b)
+}
+
+// ZeroKey overwrites the private values of an RSA, ECDSA or Ed25519 key, leaving it unusable.
+// Other key types are left alone. Go's crypto packages may keep internal copies, so this is
+// best-effort
+func ZeroKey(key crypto.PrivateKey) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		zeroInt(k.D)
+		for _, prime := range k.Primes {
+			zeroInt(prime)
+		}
+		zeroInt(k.Precomputed.Dp)
+		zeroInt(k.Precomputed.Dq)
+		zeroInt(k.Precomputed.Qinv)
+	case *ecdsa.PrivateKey:
+		zeroInt(k.D)
+	case ed25519.PrivateKey:
+		Zero(k)
+	case *ed25519.PrivateKey:
+		Zero(*k)
+	}
+}
+
+// zeroInt overwrites the words of n and sets it to zero
+func zeroInt(n *big.Int) {
+	if n == nil {
+		return
+	}
+	clear(n.Bits())
+	n.SetInt64(0)
+}
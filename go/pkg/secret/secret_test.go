@@ -0,0 +1,86 @@
+package secret
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestZero(t *testing.T) {
+	b := []byte("secret")
+	Zero(b)
+	if !bytes.Equal(b, make([]byte, 6)) {
+		t.Errorf("Zero left %q", b)
+	}
+}
+
+func TestZeroKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ECDSA key: %v", err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate Ed25519 key: %v", err)
+	}
+
+	ZeroKey(rsaKey)
+	if rsaKey.D.Sign() != 0 || rsaKey.Primes[0].Sign() != 0 || rsaKey.Primes[1].Sign() != 0 {
+		t.Error("RSA private values not zeroed")
+	}
+	ZeroKey(ecKey)
+	if ecKey.D.Sign() != 0 {
+		t.Error("ECDSA private value not zeroed")
+	}
+	ZeroKey(edKey)
+	if !bytes.Equal(edKey, make([]byte, ed25519.PrivateKeySize)) {
+		t.Error("Ed25519 key not zeroed")
+	}
+
+	// Unknown types and nil values are ignored
+	ZeroKey("not a key")
+	ZeroKey(&ecdsa.PrivateKey{})
+}
+
+func TestOSKeyring(t *testing.T) {
+	keyring, err := OSKeyring()
+	if errors.Is(err, ErrKeyringUnsupported) {
+		t.Skip("no OS keyring on this platform")
+	}
+	if err != nil {
+		t.Fatalf("OSKeyring failed: %v", err)
+	}
+	name := fmt.Sprintf("test-%d", time.Now().UnixNano())
+	if err := keyring.Set(name, []byte("key material")); err != nil {
+		t.Skipf("keyring not usable here: %v", err)
+	}
+	defer keyring.Delete(name)
+
+	got, err := keyring.Get(name)
+	if err != nil || string(got) != "key material" {
+		t.Errorf("Get() = %q, %v", got, err)
+	}
+	if err := keyring.Set(name, []byte("replaced")); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if got, err := keyring.Get(name); err != nil || string(got) != "replaced" {
+		t.Errorf("Get() after replacing = %q, %v", got, err)
+	}
+	if err := keyring.Delete(name); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := keyring.Get(name); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete = %v, want ErrNotFound", err)
+	}
+}
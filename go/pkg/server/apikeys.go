@@ -0,0 +1,104 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	client "github.com/TEENet-io/teenet-sdk/go"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+)
+
+// APIKey is a named caller credential with its own app access and rate limit. Callers send it as
+// "Authorization: Bearer <key>" or "X-API-Key: <key>"
+type APIKey struct {
+	Name      string   `json:"name"`       // Identifies the caller in logs and as the principal of its sign requests
+	Key       string   `json:"key"`        // Secret sent by the caller
+	AppIDs    []string `json:"app_ids"`    // App IDs the key may use; empty allows all
+	RateLimit float64  `json:"rate_limit"` // Requests per second across all methods; 0 for no limit
+	Burst     int      `json:"burst"`      // Requests allowed at once, default 1
+}
+
+// errForbiddenApp is returned for requests an API key may not make for an app
+var errForbiddenApp = errors.New("app not allowed for API key")
+
+// apiKeyContextKey holds the APIKey a request authenticated with
+type apiKeyContextKey struct{}
+
+// authenticate checks the Authorization or X-API-Key header value against the configured tokens
+// and API keys. It returns the matching API key, nil for a token, and false if neither matched
+func (s *Server) authenticate(authorization, apiKey string) (*APIKey, bool) {
+	if len(s.config.Tokens) == 0 && len(s.config.APIKeys) == 0 {
+		return nil, true
+	}
+	secret, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		secret = apiKey
+	}
+	if secret == "" {
+		return nil, false
+	}
+
+	// Compare against every credential so timing doesn't reveal which one matched
+	var matched *APIKey
+	for i := range s.config.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(secret), []byte(s.config.APIKeys[i].Key)) == 1 {
+			matched = &s.config.APIKeys[i]
+		}
+	}
+	match := 0
+	for _, expected := range s.config.Tokens {
+		match |= subtle.ConstantTimeCompare([]byte(secret), []byte(expected))
+	}
+	return matched, matched != nil || match == 1
+}
+
+// admit checks that the request's API key, if any, may use appID and is within its rate limit
+// Rate-limited requests fail with a *client.RateLimitError
+func (s *Server) admit(ctx context.Context, appID string) error {
+	key, _ := ctx.Value(apiKeyContextKey{}).(*APIKey)
+	if key == nil {
+		return nil
+	}
+	if len(key.AppIDs) > 0 && !slices.Contains(key.AppIDs, appID) {
+		return fmt.Errorf("%w: %s may not use app %s", errForbiddenApp, key.Name, appID)
+	}
+	if ok, wait := s.limiter.Allow(key.Name); !ok {
+		return fmt.Errorf("API key %s: %w", key.Name, &client.RateLimitError{AppID: appID, RetryAfter: wait})
+	}
+	return nil
+}
+
+// applyControls replaces the fields of a sign request the caller may not choose: the principal
+// becomes the API key, so the audit log and voters see which caller asked, with the caller's
+// justification kept, and voting, local approval and dedup bypass come from the server config
+func (s *Server) applyControls(ctx context.Context, req *client.SignRequest) {
+	req.EnableVoting = s.config.EnableVoting
+	req.LocalApproval = s.config.LocalApproval
+	req.BypassDedup = s.config.BypassDedup
+
+	var justification string
+	if req.Principal != nil {
+		justification = req.Principal.Justification
+	}
+	req.Principal = nil
+	if key, _ := ctx.Value(apiKeyContextKey{}).(*APIKey); key != nil {
+		req.Principal = &voting.Principal{ID: key.Name, Justification: justification}
+	}
+}
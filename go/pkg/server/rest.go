@@ -0,0 +1,225 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	client "github.com/TEENet-io/teenet-sdk/go"
+)
+
+// maxRequestBodySize bounds REST request bodies
+const maxRequestBodySize = 16 << 20
+
+// restSignRequest is the JSON body of POST /v1/sign; byte fields are base64 encoded
+type restSignRequest struct {
+	AppID           string            `json:"app_id"`
+	Message         []byte            `json:"message"`
+	VoteRequestData []byte            `json:"vote_request_data,omitempty"`
+	Headers         map[string]string `json:"headers,omitempty"`
+	TimeoutMs       uint32            `json:"timeout_ms,omitempty"`
+	ED25519Mode     uint32            `json:"ed25519_mode,omitempty"`
+	ED25519Context  []byte            `json:"ed25519_context,omitempty"`
+	IdempotencyKey  string            `json:"idempotency_key,omitempty"`
+}
+
+// restVerifyRequest is the JSON body of POST /v1/verify
+type restVerifyRequest struct {
+	AppID     string `json:"app_id"`
+	Message   []byte `json:"message"`
+	Signature []byte `json:"signature"`
+}
+
+// restVerifyResponse is the JSON response of POST /v1/verify
+type restVerifyResponse struct {
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+// restErrorResponse is returned for failed requests
+type restErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handler returns the REST API:
+//
+//	POST /v1/sign                    sign a message
+//	POST /v1/verify                  verify a signature
+//	GET  /v1/public-keys/{app_id}    get an app's public key
+//	GET  /healthz                    liveness, unauthenticated
+//	GET  /readyz                     readiness, unauthenticated (when the signer provides ReadinessHandler)
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/sign", s.requireAuth(s.handleSign))
+	mux.HandleFunc("POST /v1/verify", s.requireAuth(s.handleVerify))
+	mux.HandleFunc("GET /v1/public-keys/{app_id}", s.requireAuth(s.handleGetPublicKey))
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	if probe, ok := s.signer.(interface{ ReadinessHandler() http.Handler }); ok {
+		mux.Handle("GET /readyz", probe.ReadinessHandler())
+	}
+	return mux
+}
+
+// requireAuth wraps a handler with bearer token or API key authentication
+func (s *Server) requireAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key, ok := s.authenticate(r.Header.Get("Authorization"), r.Header.Get("X-API-Key"))
+		if !ok {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeJSON(w, http.StatusUnauthorized, restErrorResponse{Error: "missing or invalid bearer token"})
+			return
+		}
+		if key != nil {
+			r = r.WithContext(context.WithValue(r.Context(), apiKeyContextKey{}, key))
+		}
+		next(w, r)
+	}
+}
+
+// admitREST runs admit for appID, writing a 403 or 429 response if the request is refused
+func (s *Server) admitREST(w http.ResponseWriter, r *http.Request, appID string) bool {
+	err := s.admit(r.Context(), appID)
+	if err == nil {
+		return true
+	}
+	if errors.Is(err, errForbiddenApp) {
+		writeJSON(w, http.StatusForbidden, restErrorResponse{Error: err.Error()})
+		return false
+	}
+	var rlErr *client.RateLimitError
+	if errors.As(err, &rlErr) {
+		w.Header().Set("Retry-After", retryAfterSeconds(rlErr.RetryAfter))
+	}
+	writeJSON(w, http.StatusTooManyRequests, restErrorResponse{Error: err.Error()})
+	return false
+}
+
+func (s *Server) handleSign(w http.ResponseWriter, r *http.Request) {
+	var req restSignRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.AppID == "" {
+		writeJSON(w, http.StatusBadRequest, restErrorResponse{Error: "app_id is required"})
+		return
+	}
+	if !s.admitREST(w, r, req.AppID) {
+		return
+	}
+
+	signReq := &client.SignRequest{
+		Message:         req.Message,
+		AppID:           req.AppID,
+		VoteRequestData: req.VoteRequestData,
+		Headers:         req.Headers,
+		ED25519Mode:     req.ED25519Mode,
+		ED25519Context:  req.ED25519Context,
+		Timeout:         time.Duration(req.TimeoutMs) * time.Millisecond,
+		IdempotencyKey:  req.IdempotencyKey,
+	}
+	if signReq.IdempotencyKey == "" {
+		signReq.IdempotencyKey = r.Header.Get("Idempotency-Key")
+	}
+	s.applyControls(r.Context(), signReq)
+
+	result, err := s.signer.Sign(signReq)
+	if err != nil {
+		if isOverloaded(err) {
+			var rlErr *client.RateLimitError
+			if errors.As(err, &rlErr) {
+				w.Header().Set("Retry-After", retryAfterSeconds(rlErr.RetryAfter))
+			}
+			writeJSON(w, http.StatusTooManyRequests, restErrorResponse{Error: err.Error()})
+			return
+		}
+		if result == nil {
+			writeJSON(w, http.StatusInternalServerError, restErrorResponse{Error: err.Error()})
+			return
+		}
+	}
+	body, err := client.MarshalSignResult(result, client.JSONOptions{SignatureEncoding: s.config.SignatureEncoding})
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, restErrorResponse{Error: err.Error()})
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(append(body, '\n'))
+}
+
+func (s *Server) handleVerify(w http.ResponseWriter, r *http.Request) {
+	var req restVerifyRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.AppID == "" {
+		writeJSON(w, http.StatusBadRequest, restErrorResponse{Error: "app_id is required"})
+		return
+	}
+	if !s.admitREST(w, r, req.AppID) {
+		return
+	}
+
+	valid, err := s.signer.Verify(req.Message, req.Signature, req.AppID)
+	if err != nil {
+		writeJSON(w, http.StatusOK, restVerifyResponse{Valid: false, Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, restVerifyResponse{Valid: valid})
+}
+
+func (s *Server) handleGetPublicKey(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("app_id")
+	if !s.admitREST(w, r, appID) {
+		return
+	}
+	keyInfo, err := s.signer.GetPublicKeyByAppID(appID)
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, restErrorResponse{Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, keyInfo)
+}
+
+// decodeJSON decodes a bounded JSON body, writing a 400 response on failure
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodySize)
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeJSON(w, http.StatusBadRequest, restErrorResponse{Error: "invalid request: " + err.Error()})
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(v)
+}
+
+// retryAfterSeconds formats a Retry-After header value, rounding up to whole seconds
+func retryAfterSeconds(d time.Duration) string {
+	seconds := int64((d + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return strconv.FormatInt(seconds, 10)
+}
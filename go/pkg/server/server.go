@@ -0,0 +1,253 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package server exposes a TEENet client as a signing microservice over gRPC and REST
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	client "github.com/TEENet-io/teenet-sdk/go"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/ratelimit"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/task"
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/signing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Signer is the subset of *client.Client served by the microservice
+type Signer interface {
+	Sign(req *client.SignRequest) (*client.SignResult, error)
+	Verify(message, signature []byte, appID string) (bool, error)
+	GetPublicKeyByAppID(appID string) (*client.PublicKeyInfo, error)
+}
+
+// Config configures the signing microservice
+type Config struct {
+	GRPCAddr string   // gRPC listen address, empty disables gRPC
+	HTTPAddr string   // REST listen address, empty disables REST
+	Tokens   []string // Accepted bearer tokens; empty disables authentication unless APIKeys are set
+
+	// APIKeys are named credentials with per-key app access and rate limits, accepted
+	// alongside Tokens
+	APIKeys []APIKey
+
+	// SignatureEncoding of signatures in REST sign responses, base64 by default;
+	// responses are canonical JSON, see client.MarshalSignResult
+	SignatureEncoding client.SignatureEncoding
+	// EnableVoting, LocalApproval and BypassDedup are set on every sign request. Callers can't
+	// choose them, since they decide whether a signature needs approval; the principal of a request
+	// is likewise the caller's API key name, or the client's own for tokens, never one sent by the caller
+	EnableVoting  bool
+	LocalApproval bool
+	BypassDedup   bool
+}
+
+// Server serves Sign, Verify and GetPublicKey over gRPC and REST
+type Server struct {
+	pb.UnimplementedSigningServiceServer
+	signer  Signer
+	config  Config
+	limiter *ratelimit.Limiter // Per API key rate limits
+}
+
+// New creates a signing server backed by signer
+func New(signer Signer, config Config) *Server {
+	limiter := ratelimit.NewLimiter()
+	for _, key := range config.APIKeys {
+		limiter.SetLimit(key.Name, key.RateLimit, key.Burst)
+	}
+	return &Server{
+		signer:  signer,
+		config:  config,
+		limiter: limiter,
+	}
+}
+
+// Run serves until ctx is cancelled, then shuts both listeners down gracefully
+func (s *Server) Run(ctx context.Context) error {
+	if s.config.GRPCAddr == "" && s.config.HTTPAddr == "" {
+		return fmt.Errorf("no listen address configured")
+	}
+	if len(s.config.Tokens) == 0 && len(s.config.APIKeys) == 0 {
+		log.Printf("⚠️  Warning: signing service is running without authentication")
+	}
+
+	errChan := make(chan error, 2)
+	var grpcServer *grpc.Server
+	var httpServer *http.Server
+
+	if s.config.GRPCAddr != "" {
+		lis, err := net.Listen("tcp", s.config.GRPCAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", s.config.GRPCAddr, err)
+		}
+		grpcServer = grpc.NewServer(grpc.UnaryInterceptor(s.authInterceptor))
+		pb.RegisterSigningServiceServer(grpcServer, s)
+		log.Printf("🔐 Signing gRPC service started on %s", s.config.GRPCAddr)
+		go func() {
+			if err := grpcServer.Serve(lis); err != nil {
+				errChan <- fmt.Errorf("gRPC server error: %w", err)
+			}
+		}()
+	}
+
+	if s.config.HTTPAddr != "" {
+		httpServer = &http.Server{
+			Addr:              s.config.HTTPAddr,
+			Handler:           s.Handler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		log.Printf("🔐 Signing REST service started on %s", s.config.HTTPAddr)
+		go func() {
+			if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errChan <- fmt.Errorf("HTTP server error: %w", err)
+			}
+		}()
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+	case runErr = <-errChan:
+	}
+
+	log.Printf("🛑 Stopping signing service...")
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
+	if httpServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil && runErr == nil {
+			runErr = err
+		}
+	}
+	return runErr
+}
+
+// Sign implements SigningServiceServer
+func (s *Server) Sign(ctx context.Context, req *pb.SignRequest) (*pb.SignResponse, error) {
+	if req.AppId == "" {
+		return nil, status.Error(codes.InvalidArgument, "app_id is required")
+	}
+
+	if err := s.admit(ctx, req.AppId); err != nil {
+		return nil, admitStatus(err)
+	}
+
+	signReq := client.SignRequestFromProto(req)
+	s.applyControls(ctx, signReq)
+	if deadline, ok := ctx.Deadline(); ok {
+		requested := signReq.Deadline
+		if requested.IsZero() && signReq.Timeout > 0 {
+			requested = time.Now().Add(signReq.Timeout)
+		}
+		if requested.IsZero() || requested.After(deadline) {
+			signReq.Deadline = deadline
+		}
+	}
+
+	result, err := s.signer.Sign(signReq)
+	if err != nil {
+		if isOverloaded(err) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		if result == nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	return client.SignResultToProto(result), nil
+}
+
+// Verify implements SigningServiceServer
+func (s *Server) Verify(ctx context.Context, req *pb.VerifyRequest) (*pb.VerifyResponse, error) {
+	if req.AppId == "" {
+		return nil, status.Error(codes.InvalidArgument, "app_id is required")
+	}
+
+	if err := s.admit(ctx, req.AppId); err != nil {
+		return nil, admitStatus(err)
+	}
+
+	valid, err := s.signer.Verify(req.Message, req.Signature, req.AppId)
+	if err != nil {
+		return &pb.VerifyResponse{Valid: false, Error: err.Error()}, nil
+	}
+	return &pb.VerifyResponse{Valid: valid}, nil
+}
+
+// GetPublicKey implements SigningServiceServer
+func (s *Server) GetPublicKey(ctx context.Context, req *pb.GetPublicKeyRequest) (*pb.GetPublicKeyResponse, error) {
+	if req.AppId == "" {
+		return nil, status.Error(codes.InvalidArgument, "app_id is required")
+	}
+
+	if err := s.admit(ctx, req.AppId); err != nil {
+		return nil, admitStatus(err)
+	}
+
+	keyInfo, err := s.signer.GetPublicKeyByAppID(req.AppId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &pb.GetPublicKeyResponse{
+		PublicKey: keyInfo.Key,
+		Protocol:  keyInfo.Protocol.String(),
+		Curve:     keyInfo.Curve.String(),
+		KeyUsage:  keyInfo.Usage.Names(),
+	}, nil
+}
+
+// authInterceptor rejects gRPC calls without a valid "authorization: Bearer <token>" or
+// "x-api-key" header
+func (s *Server) authInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var authorization, apiKey string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			authorization = values[0]
+		}
+		if values := md.Get("x-api-key"); len(values) > 0 {
+			apiKey = values[0]
+		}
+	}
+	key, ok := s.authenticate(authorization, apiKey)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid bearer token")
+	}
+	if key != nil {
+		ctx = context.WithValue(ctx, apiKeyContextKey{}, key)
+	}
+	return handler(ctx, req)
+}
+
+// admitStatus maps an admit error to its gRPC status
+func admitStatus(err error) error {
+	if errors.Is(err, errForbiddenApp) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return status.Error(codes.ResourceExhausted, err.Error())
+}
+
+// isOverloaded reports whether err means the client shed load rather than failed
+func isOverloaded(err error) bool {
+	return errors.Is(err, client.ErrRateLimited) || errors.Is(err, task.ErrQueueFull)
+}
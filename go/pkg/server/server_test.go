@@ -0,0 +1,367 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	client "github.com/TEENet-io/teenet-sdk/go"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/task"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/signing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeSigner returns canned results
+type fakeSigner struct {
+	signErr error
+	lastReq *client.SignRequest
+}
+
+func (f *fakeSigner) Sign(req *client.SignRequest) (*client.SignResult, error) {
+	f.lastReq = req
+	if f.signErr != nil {
+		return nil, f.signErr
+	}
+	return &client.SignResult{Success: true, Signature: []byte{1, 2, 3}}, nil
+}
+
+func (f *fakeSigner) Verify(message, signature []byte, appID string) (bool, error) {
+	return bytes.Equal(signature, []byte{1, 2, 3}), nil
+}
+
+func (f *fakeSigner) GetPublicKeyByAppID(appID string) (*client.PublicKeyInfo, error) {
+	return &client.PublicKeyInfo{Key: []byte{4, 5}, Protocol: constants.ProtocolECDSA, Curve: constants.CurveSECP256K1}, nil
+}
+
+func TestRESTAuthentication(t *testing.T) {
+	srv := New(&fakeSigner{}, Config{Tokens: []string{"secret"}})
+	handler := srv.Handler()
+
+	body := []byte(`{"app_id":"app","message":"aGVsbG8="}`)
+	cases := map[string]int{
+		"":              http.StatusUnauthorized,
+		"Bearer wrong":  http.StatusUnauthorized,
+		"secret":        http.StatusUnauthorized,
+		"Bearer secret": http.StatusOK,
+	}
+	for header, expected := range cases {
+		req := httptest.NewRequest("POST", "/v1/sign", bytes.NewReader(body))
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		if recorder.Code != expected {
+			t.Errorf("Authorization %q: expected status %d, got %d", header, expected, recorder.Code)
+		}
+	}
+
+	// Health check needs no token
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/healthz", nil))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected health check to succeed, got %d", recorder.Code)
+	}
+}
+
+func TestRESTSignAndPublicKey(t *testing.T) {
+	signer := &fakeSigner{}
+	handler := New(signer, Config{}).Handler()
+
+	req := httptest.NewRequest("POST", "/v1/sign", bytes.NewReader([]byte(`{"app_id":"app","message":"aGVsbG8=","timeout_ms":1500}`)))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var result client.SignResult
+	if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+		t.Fatalf("Failed to decode sign result: %v", err)
+	}
+	if !result.Success || !bytes.Equal(result.Signature, []byte{1, 2, 3}) {
+		t.Errorf("Unexpected sign result: %+v", result)
+	}
+	if string(signer.lastReq.Message) != "hello" || signer.lastReq.Timeout != 1500*time.Millisecond {
+		t.Errorf("Sign request not forwarded correctly: %+v", signer.lastReq)
+	}
+
+	req = httptest.NewRequest("POST", "/v1/sign", bytes.NewReader([]byte(`{"app_id":"app","message":"aGVsbG8="}`)))
+	req.Header.Set("Idempotency-Key", "order-42")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if signer.lastReq.IdempotencyKey != "order-42" {
+		t.Errorf("Expected Idempotency-Key header to be forwarded, got %q", signer.lastReq.IdempotencyKey)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/v1/public-keys/app", nil))
+	if recorder.Code != http.StatusOK || !bytes.Contains(recorder.Body.Bytes(), []byte(`"curve":"secp256k1"`)) {
+		t.Errorf("Unexpected public key response %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	signer.signErr = &client.RateLimitError{AppID: "app", RetryAfter: 1500 * time.Millisecond}
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/v1/sign", bytes.NewReader([]byte(`{"app_id":"app"}`))))
+	if recorder.Code != http.StatusTooManyRequests || recorder.Header().Get("Retry-After") != "2" {
+		t.Errorf("Expected 429 with Retry-After 2, got %d %q", recorder.Code, recorder.Header().Get("Retry-After"))
+	}
+}
+
+func TestRESTSignatureEncoding(t *testing.T) {
+	handler := New(&fakeSigner{}, Config{SignatureEncoding: client.SignatureEncodingHex}).Handler()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/v1/sign", bytes.NewReader([]byte(`{"app_id":"app","message":"aGVsbG8="}`))))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if body := recorder.Body.String(); body != `{"signature":"010203","success":true}`+"\n" {
+		t.Errorf("Expected canonical JSON with a hex signature, got %q", body)
+	}
+
+	data, err := client.MarshalSignResult(&client.SignResult{
+		Success:   true,
+		Signature: []byte{0xff},
+		VotingInfo: &client.VotingInfo{
+			RequiredVotes: 2,
+			MessageClass:  "<transfer>",
+			VoteDetails:   []client.VoteDetail{{ClientID: "b", Success: true}},
+		},
+	}, client.JSONOptions{})
+	if err != nil {
+		t.Fatalf("MarshalSignResult failed: %v", err)
+	}
+	expected := `{"signature":"/w==","success":true,"voting_info":{"message_class":"<transfer>","required_votes":2,` +
+		`"successful_votes":0,"total_targets":0,"vote_details":[{"client_id":"b","response":false,"success":true}]}}`
+	if string(data) != expected {
+		t.Errorf("Unexpected canonical JSON:\n got %s\nwant %s", data, expected)
+	}
+
+	if _, err := client.MarshalSignResult(&client.SignResult{}, client.JSONOptions{SignatureEncoding: "base32"}); err == nil {
+		t.Error("Expected an unknown signature encoding to be rejected")
+	}
+}
+
+func TestRESTAPIKeys(t *testing.T) {
+	signer := &fakeSigner{}
+	handler := New(signer, Config{
+		Tokens: []string{"admin-token"},
+		APIKeys: []APIKey{
+			{Name: "payments", Key: "pay-key", AppIDs: []string{"payments-app"}, RateLimit: 0.001, Burst: 2},
+			{Name: "reports", Key: "report-key"},
+		},
+	}).Handler()
+
+	sign := func(appID string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/v1/sign", bytes.NewReader([]byte(`{"app_id":"`+appID+`","message":"aGVsbG8="}`)))
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	if code := sign("payments-app", map[string]string{"X-API-Key": "pay-key"}).Code; code != http.StatusOK {
+		t.Fatalf("Expected X-API-Key to authenticate, got %d", code)
+	}
+	if p := signer.lastReq.Principal; p == nil || p.ID != "payments" {
+		t.Errorf("Expected the key name as principal, got %+v", p)
+	}
+	if code := sign("payments-app", map[string]string{"Authorization": "Bearer pay-key"}).Code; code != http.StatusOK {
+		t.Errorf("Expected bearer API key to authenticate, got %d", code)
+	}
+	if code := sign("other-app", map[string]string{"X-API-Key": "pay-key"}).Code; code != http.StatusForbidden {
+		t.Errorf("Expected 403 for an app outside the key's list, got %d", code)
+	}
+
+	// The burst of 2 is used up
+	recorder := sign("payments-app", map[string]string{"X-API-Key": "pay-key"})
+	if recorder.Code != http.StatusTooManyRequests || recorder.Header().Get("Retry-After") == "" {
+		t.Errorf("Expected 429 with Retry-After, got %d", recorder.Code)
+	}
+
+	// Other keys and plain tokens have their own limits
+	for _, headers := range []map[string]string{{"X-API-Key": "report-key"}, {"Authorization": "Bearer admin-token"}} {
+		if code := sign("other-app", headers).Code; code != http.StatusOK {
+			t.Errorf("%v: expected 200, got %d", headers, code)
+		}
+	}
+	if signer.lastReq.Principal != nil {
+		t.Errorf("Expected no principal for a plain token, got %+v", signer.lastReq.Principal)
+	}
+	if code := sign("other-app", map[string]string{"X-API-Key": "wrong"}).Code; code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for an unknown key, got %d", code)
+	}
+}
+
+func TestGRPCSign(t *testing.T) {
+	signer := &fakeSigner{}
+	srv := New(signer, Config{})
+
+	resp, err := srv.Sign(context.Background(), &pb.SignRequest{AppId: "app", Message: []byte("hello")})
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if !resp.Success || !bytes.Equal(resp.Signature, []byte{1, 2, 3}) {
+		t.Errorf("Unexpected sign response: %v", resp)
+	}
+
+	if _, err := srv.Sign(context.Background(), &pb.SignRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for missing app ID, got %v", err)
+	}
+
+	signer.signErr = client.ErrRateLimited
+	if _, err := srv.Sign(context.Background(), &pb.SignRequest{AppId: "app"}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted when rate limited, got %v", err)
+	}
+}
+
+func TestGRPCSignIgnoresCallerControls(t *testing.T) {
+	signer := &fakeSigner{}
+	srv := New(signer, Config{})
+	req := &pb.SignRequest{
+		AppId:         "app",
+		Message:       []byte("hello"),
+		EnableVoting:  true,
+		LocalApproval: true,
+		BypassDedup:   true,
+		Principal:     &pb.Principal{Id: "root", Justification: "TICKET-1"},
+	}
+
+	// A plain token signs under the client's own principal, without voting
+	if _, err := srv.Sign(context.Background(), req); err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	got := signer.lastReq
+	if got.EnableVoting || got.LocalApproval || got.BypassDedup || got.Principal != nil {
+		t.Errorf("Expected caller-set controls to be dropped, got %+v", got)
+	}
+
+	// An API key is the principal, and the server config decides on voting
+	srv = New(signer, Config{EnableVoting: true})
+	ctx := context.WithValue(context.Background(), apiKeyContextKey{}, &APIKey{Name: "payments"})
+	if _, err := srv.Sign(ctx, req); err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	got = signer.lastReq
+	if !got.EnableVoting || got.LocalApproval || got.BypassDedup {
+		t.Errorf("Expected the server's voting config, got %+v", got)
+	}
+	if p := got.Principal; p == nil || p.ID != "payments" || p.Justification != "TICKET-1" {
+		t.Errorf("Expected the key name as principal with the caller's justification, got %+v", p)
+	}
+}
+
+func TestRESTSignIgnoresCallerControls(t *testing.T) {
+	signer := &fakeSigner{}
+	handler := New(signer, Config{LocalApproval: true}).Handler()
+	body := `{"app_id":"app","message":"aGVsbG8=","enable_voting":true,"local_approval":false}`
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/v1/sign", bytes.NewReader([]byte(body))))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", recorder.Code)
+	}
+	if got := signer.lastReq; got.EnableVoting || !got.LocalApproval {
+		t.Errorf("Expected the server's voting config, got %+v", got)
+	}
+}
+
+func TestProtoConversionRoundTrip(t *testing.T) {
+	req := &client.SignRequest{
+		Message:        []byte("hello"),
+		AppID:          "app",
+		EnableVoting:   true,
+		Headers:        map[string]string{"X-Trace": "1"},
+		Timeout:        1500 * time.Millisecond,
+		Deadline:       time.UnixMilli(1700000000123),
+		ED25519Mode:    constants.ED25519ModeCtx,
+		ED25519Context: []byte("ctx"),
+		Priority:       task.PriorityInteractive,
+		QueueExpiry:    time.Minute,
+		BypassDedup:    true,
+		IdempotencyKey: "order-42",
+		Principal:      &voting.Principal{ID: "alice", Justification: "TICKET-1"},
+	}
+	data, err := proto.Marshal(client.SignRequestToProto(req))
+	if err != nil {
+		t.Fatalf("Failed to marshal sign request: %v", err)
+	}
+	var reqMsg pb.SignRequest
+	if err := proto.Unmarshal(data, &reqMsg); err != nil {
+		t.Fatalf("Failed to unmarshal sign request: %v", err)
+	}
+	if got := client.SignRequestFromProto(&reqMsg); !reflect.DeepEqual(got, req) {
+		t.Errorf("Sign request changed on the wire:\n got %+v\nwant %+v", got, req)
+	}
+
+	result := &client.SignResult{
+		Signature:   []byte{1, 2, 3},
+		Success:     true,
+		Cached:      true,
+		SharedRound: true,
+		VotingInfo: &client.VotingInfo{
+			TotalTargets:    3,
+			SuccessfulVotes: 2,
+			RequiredVotes:   2,
+			VoteDetails: []client.VoteDetail{
+				{ClientID: "a", Success: true, Response: true},
+				{ClientID: "b", Success: true, Reason: "over limit", Code: voting.CodePolicyViolation, DelegationChain: []string{"b", "c"}},
+			},
+			MissingGroups:  []string{"ops"},
+			MessageClass:   "transfer",
+			CommitFailures: []string{"c"},
+			RoundID:        "round-1",
+		},
+	}
+	if data, err = proto.Marshal(client.SignResultToProto(result)); err != nil {
+		t.Fatalf("Failed to marshal sign result: %v", err)
+	}
+	var resultMsg pb.SignResponse
+	if err := proto.Unmarshal(data, &resultMsg); err != nil {
+		t.Fatalf("Failed to unmarshal sign result: %v", err)
+	}
+	if got := client.SignResultFromProto(&resultMsg); !reflect.DeepEqual(got, result) {
+		t.Errorf("Sign result changed on the wire:\n got %+v\nwant %+v", got, result)
+	}
+}
+
+func TestRESTReadinessProbe(t *testing.T) {
+	// An uninitialized client is alive but not ready
+	teeClient := client.NewClient("localhost:0")
+	handler := New(teeClient, Config{Tokens: []string{"secret"}}).Handler()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/readyz", nil))
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected readiness to fail before Init, got %d", recorder.Code)
+	}
+	var status client.HealthStatus
+	if err := json.Unmarshal(recorder.Body.Bytes(), &status); err != nil {
+		t.Fatalf("Failed to decode health status: %v", err)
+	}
+	if status.Initialized || status.Ready || !status.Live {
+		t.Errorf("Unexpected health status: %+v", status)
+	}
+
+	recorder = httptest.NewRecorder()
+	teeClient.LivenessHandler().ServeHTTP(recorder, httptest.NewRequest("GET", "/livez", nil))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected liveness to succeed before Init, got %d", recorder.Code)
+	}
+
+	// Signers without a readiness handler don't expose /readyz
+	recorder = httptest.NewRecorder()
+	New(&fakeSigner{}, Config{}).Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/readyz", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 without readiness handler, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,274 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package solana builds and serializes Solana transactions for signing with ED25519 app keys
+package solana
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+// PublicKey is a Solana account address: an ED25519 public key or a program derived address
+type PublicKey [ed25519.PublicKeySize]byte
+
+// Hash is a 32-byte Solana hash, such as a recent blockhash or durable nonce
+type Hash [32]byte
+
+// Well-known program and sysvar addresses
+var (
+	SystemProgramID           = MustPublicKey("11111111111111111111111111111111")
+	SysvarRecentBlockhashesID = MustPublicKey("SysvarRecentB1ockHashes11111111111111111111")
+)
+
+// ParsePublicKey decodes a base58 address
+func ParsePublicKey(address string) (PublicKey, error) {
+	var key PublicKey
+	decoded, err := verification.DecodeBase58(address)
+	if err != nil {
+		return key, err
+	}
+	if len(decoded) != len(key) {
+		return key, fmt.Errorf("invalid Solana address length: expected %d bytes, got %d", len(key), len(decoded))
+	}
+	copy(key[:], decoded)
+	return key, nil
+}
+
+// MustPublicKey decodes a base58 address known to be valid, panicking otherwise
+func MustPublicKey(address string) PublicKey {
+	key, err := ParsePublicKey(address)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// PublicKeyFromBytes converts a 32-byte ED25519 public key to an address
+func PublicKeyFromBytes(key []byte) (PublicKey, error) {
+	var address PublicKey
+	if len(key) != len(address) {
+		return address, fmt.Errorf("invalid ED25519 public key size: expected %d, got %d", len(address), len(key))
+	}
+	copy(address[:], key)
+	return address, nil
+}
+
+// String returns the base58 address
+func (k PublicKey) String() string {
+	return verification.EncodeBase58(k[:])
+}
+
+// ParseHash decodes a base58 hash, such as the blockhash returned by getLatestBlockhash
+func ParseHash(s string) (Hash, error) {
+	var hash Hash
+	decoded, err := verification.DecodeBase58(s)
+	if err != nil {
+		return hash, err
+	}
+	if len(decoded) != len(hash) {
+		return hash, fmt.Errorf("invalid Solana hash length: expected %d bytes, got %d", len(hash), len(decoded))
+	}
+	copy(hash[:], decoded)
+	return hash, nil
+}
+
+// String returns the base58 hash
+func (h Hash) String() string {
+	return verification.EncodeBase58(h[:])
+}
+
+// AccountMeta is an account an instruction reads or writes
+type AccountMeta struct {
+	PublicKey  PublicKey
+	IsSigner   bool
+	IsWritable bool
+}
+
+// Instruction invokes a program with the given accounts and data
+type Instruction struct {
+	ProgramID PublicKey
+	Accounts  []AccountMeta
+	Data      []byte
+}
+
+// MessageHeader counts the signer and read-only accounts of a message
+type MessageHeader struct {
+	NumRequiredSignatures       uint8
+	NumReadonlySignedAccounts   uint8
+	NumReadonlyUnsignedAccounts uint8
+}
+
+// CompiledInstruction is an instruction with accounts replaced by indexes into the message's account keys
+type CompiledInstruction struct {
+	ProgramIDIndex uint8
+	Accounts       []uint8
+	Data           []byte
+}
+
+// Message is a legacy Solana transaction message, the bytes every signer signs
+type Message struct {
+	Header          MessageHeader
+	AccountKeys     []PublicKey
+	RecentBlockhash Hash
+	Instructions    []CompiledInstruction
+}
+
+// NewMessage compiles instructions into a message paid for by feePayer. recentBlockhash bounds
+// the transaction's lifetime: validators reject it once the blockhash is about 150 blocks old,
+// see LatestBlockhash. For transactions signed long before they are sent, see NewNonceMessage
+func NewMessage(feePayer PublicKey, instructions []Instruction, recentBlockhash Hash) (*Message, error) {
+	if recentBlockhash == (Hash{}) {
+		return nil, fmt.Errorf("recent blockhash is required")
+	}
+	if len(instructions) == 0 {
+		return nil, fmt.Errorf("at least one instruction is required")
+	}
+
+	// Collect accounts in first-seen order, merging their signer and writable flags
+	accounts := []AccountMeta{{PublicKey: feePayer, IsSigner: true, IsWritable: true}}
+	index := map[PublicKey]int{feePayer: 0}
+	add := func(meta AccountMeta) {
+		if i, ok := index[meta.PublicKey]; ok {
+			accounts[i].IsSigner = accounts[i].IsSigner || meta.IsSigner
+			accounts[i].IsWritable = accounts[i].IsWritable || meta.IsWritable
+			return
+		}
+		index[meta.PublicKey] = len(accounts)
+		accounts = append(accounts, meta)
+	}
+	for _, instruction := range instructions {
+		for _, meta := range instruction.Accounts {
+			add(meta)
+		}
+		add(AccountMeta{PublicKey: instruction.ProgramID})
+	}
+	if len(accounts) > 256 {
+		return nil, fmt.Errorf("message references %d accounts, at most 256 are allowed", len(accounts))
+	}
+
+	// Order writable signers, read-only signers, writable non-signers, read-only non-signers
+	message := &Message{RecentBlockhash: recentBlockhash}
+	for _, group := range []struct{ signer, writable bool }{{true, true}, {true, false}, {false, true}, {false, false}} {
+		for _, meta := range accounts {
+			if meta.IsSigner != group.signer || meta.IsWritable != group.writable {
+				continue
+			}
+			message.AccountKeys = append(message.AccountKeys, meta.PublicKey)
+			switch {
+			case meta.IsSigner && !meta.IsWritable:
+				message.Header.NumRequiredSignatures++
+				message.Header.NumReadonlySignedAccounts++
+			case meta.IsSigner:
+				message.Header.NumRequiredSignatures++
+			case !meta.IsWritable:
+				message.Header.NumReadonlyUnsignedAccounts++
+			}
+		}
+	}
+	for i, key := range message.AccountKeys {
+		index[key] = i
+	}
+
+	for _, instruction := range instructions {
+		compiled := CompiledInstruction{
+			ProgramIDIndex: uint8(index[instruction.ProgramID]),
+			Accounts:       make([]uint8, len(instruction.Accounts)),
+			Data:           instruction.Data,
+		}
+		for i, meta := range instruction.Accounts {
+			compiled.Accounts[i] = uint8(index[meta.PublicKey])
+		}
+		message.Instructions = append(message.Instructions, compiled)
+	}
+	return message, nil
+}
+
+// NewNonceMessage compiles a message whose lifetime is bound to a durable nonce instead of a
+// recent blockhash: it advances nonceAccount, authorized by nonceAuthority, before the other
+// instructions, and uses the nonce stored in the account as its blockhash
+func NewNonceMessage(feePayer, nonceAccount, nonceAuthority PublicKey, nonce Hash, instructions []Instruction) (*Message, error) {
+	advance := AdvanceNonceAccount(nonceAccount, nonceAuthority)
+	return NewMessage(feePayer, append([]Instruction{advance}, instructions...), nonce)
+}
+
+// Signers returns the accounts that must sign the message, fee payer first
+func (m *Message) Signers() []PublicKey {
+	return m.AccountKeys[:m.Header.NumRequiredSignatures]
+}
+
+// Serialize encodes the message in the wire format signed by each signer
+func (m *Message) Serialize() []byte {
+	buf := []byte{m.Header.NumRequiredSignatures, m.Header.NumReadonlySignedAccounts, m.Header.NumReadonlyUnsignedAccounts}
+	buf = appendCompactU16(buf, len(m.AccountKeys))
+	for _, key := range m.AccountKeys {
+		buf = append(buf, key[:]...)
+	}
+	buf = append(buf, m.RecentBlockhash[:]...)
+	buf = appendCompactU16(buf, len(m.Instructions))
+	for _, instruction := range m.Instructions {
+		buf = append(buf, instruction.ProgramIDIndex)
+		buf = appendCompactU16(buf, len(instruction.Accounts))
+		buf = append(buf, instruction.Accounts...)
+		buf = appendCompactU16(buf, len(instruction.Data))
+		buf = append(buf, instruction.Data...)
+	}
+	return buf
+}
+
+// appendCompactU16 encodes a length as Solana's compact-u16: 7 bits per byte, low bits first
+func appendCompactU16(buf []byte, n int) []byte {
+	for {
+		b := byte(n & 0x7f)
+		n >>= 7
+		if n == 0 {
+			return append(buf, b)
+		}
+		buf = append(buf, b|0x80)
+	}
+}
+
+// System program instruction indexes
+const (
+	systemTransfer            = 2
+	systemAdvanceNonceAccount = 4
+)
+
+// Transfer moves lamports between system accounts; from must sign
+func Transfer(from, to PublicKey, lamports uint64) Instruction {
+	data := binary.LittleEndian.AppendUint32(nil, systemTransfer)
+	return Instruction{
+		ProgramID: SystemProgramID,
+		Accounts: []AccountMeta{
+			{PublicKey: from, IsSigner: true, IsWritable: true},
+			{PublicKey: to, IsWritable: true},
+		},
+		Data: binary.LittleEndian.AppendUint64(data, lamports),
+	}
+}
+
+// AdvanceNonceAccount consumes the durable nonce stored in nonceAccount; authority must sign
+func AdvanceNonceAccount(nonceAccount, authority PublicKey) Instruction {
+	return Instruction{
+		ProgramID: SystemProgramID,
+		Accounts: []AccountMeta{
+			{PublicKey: nonceAccount, IsWritable: true},
+			{PublicKey: SysvarRecentBlockhashesID},
+			{PublicKey: authority, IsSigner: true},
+		},
+		Data: binary.LittleEndian.AppendUint32(nil, systemAdvanceNonceAccount),
+	}
+}
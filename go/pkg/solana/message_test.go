@@ -0,0 +1,138 @@
+package solana
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+func testKey(b byte) PublicKey {
+	var key PublicKey
+	for i := range key {
+		key[i] = b
+	}
+	return key
+}
+
+func TestNewMessageTransfer(t *testing.T) {
+	from, to := testKey(1), testKey(2)
+	blockhash := Hash(testKey(9))
+	message, err := NewMessage(from, []Instruction{Transfer(from, to, 1000)}, blockhash)
+	if err != nil {
+		t.Fatalf("NewMessage failed: %v", err)
+	}
+
+	// header, 3 keys, blockhash, 1 instruction: program 2, accounts [0 1], data transfer(1000)
+	want := []byte{1, 0, 1, 3}
+	want = append(want, from[:]...)
+	want = append(want, to[:]...)
+	want = append(want, SystemProgramID[:]...)
+	want = append(want, blockhash[:]...)
+	want = append(want, 1, 2, 2, 0, 1, 12)
+	want = binary.LittleEndian.AppendUint32(want, 2)
+	want = binary.LittleEndian.AppendUint64(want, 1000)
+	if got := message.Serialize(); !bytes.Equal(got, want) {
+		t.Errorf("Unexpected message\ngot  %x\nwant %x", got, want)
+	}
+	if signers := message.Signers(); len(signers) != 1 || signers[0] != from {
+		t.Errorf("Expected the fee payer as only signer, got %v", signers)
+	}
+}
+
+func TestNewNonceMessage(t *testing.T) {
+	payer, nonceAccount, authority, to := testKey(1), testKey(3), testKey(4), testKey(2)
+	nonce := Hash(testKey(7))
+	message, err := NewNonceMessage(payer, nonceAccount, authority, nonce, []Instruction{Transfer(payer, to, 5)})
+	if err != nil {
+		t.Fatalf("NewNonceMessage failed: %v", err)
+	}
+
+	// Writable signer, read-only signer, writable accounts, read-only accounts
+	wantKeys := []PublicKey{payer, authority, nonceAccount, to, SysvarRecentBlockhashesID, SystemProgramID}
+	if len(message.AccountKeys) != len(wantKeys) {
+		t.Fatalf("Expected %d account keys, got %d", len(wantKeys), len(message.AccountKeys))
+	}
+	for i, key := range wantKeys {
+		if message.AccountKeys[i] != key {
+			t.Errorf("Account %d: got %s, want %s", i, message.AccountKeys[i], key)
+		}
+	}
+	if message.Header != (MessageHeader{2, 1, 2}) {
+		t.Errorf("Unexpected header %+v", message.Header)
+	}
+	if message.RecentBlockhash != nonce {
+		t.Error("Expected the nonce as blockhash")
+	}
+	advance := message.Instructions[0]
+	if advance.ProgramIDIndex != 5 || !bytes.Equal(advance.Accounts, []uint8{2, 4, 1}) || binary.LittleEndian.Uint32(advance.Data) != systemAdvanceNonceAccount {
+		t.Errorf("Expected AdvanceNonceAccount first, got %+v", advance)
+	}
+}
+
+func TestNewMessageRejects(t *testing.T) {
+	if _, err := NewMessage(testKey(1), []Instruction{Transfer(testKey(1), testKey(2), 1)}, Hash{}); err == nil {
+		t.Error("Expected an error without a blockhash")
+	}
+	if _, err := NewMessage(testKey(1), nil, Hash(testKey(9))); err == nil {
+		t.Error("Expected an error without instructions")
+	}
+}
+
+func TestTransactionSign(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	payer, err := PublicKeyFromBytes(public)
+	if err != nil {
+		t.Fatalf("PublicKeyFromBytes failed: %v", err)
+	}
+	message, err := NewMessage(payer, []Instruction{Transfer(payer, testKey(2), 1)}, Hash(testKey(9)))
+	if err != nil {
+		t.Fatalf("NewMessage failed: %v", err)
+	}
+	tx := NewTransaction(message)
+	if tx.IsSigned() {
+		t.Error("Expected a new transaction to be unsigned")
+	}
+
+	if _, err := tx.AddSignature(payer, make([]byte, ed25519.SignatureSize)); err == nil {
+		t.Error("Expected an invalid signature to be rejected")
+	}
+	if _, err := tx.AddSignature(testKey(2), ed25519.Sign(private, message.Serialize())); err == nil {
+		t.Error("Expected a non-signer to be rejected")
+	}
+	signature := ed25519.Sign(private, message.Serialize())
+	encoded, err := tx.AddSignature(payer, signature)
+	if err != nil {
+		t.Fatalf("AddSignature failed: %v", err)
+	}
+	if encoded != verification.EncodeBase58(signature) || !tx.IsSigned() {
+		t.Errorf("Expected the base58 signature and a signed transaction, got %q", encoded)
+	}
+
+	wire := tx.Serialize()
+	if wire[0] != 1 || !bytes.Equal(wire[1:65], signature) || !bytes.Equal(wire[65:], message.Serialize()) {
+		t.Error("Unexpected transaction wire format")
+	}
+}
+
+func TestAppendCompactU16(t *testing.T) {
+	tests := map[int][]byte{
+		0:      {0x00},
+		0x7f:   {0x7f},
+		0x80:   {0x80, 0x01},
+		0x3fff: {0xff, 0x7f},
+		0x4000: {0x80, 0x80, 0x01},
+		0xffff: {0xff, 0xff, 0x03},
+	}
+	for n, want := range tests {
+		if got := appendCompactU16(nil, n); !bytes.Equal(got, want) {
+			t.Errorf("appendCompactU16(%#x) = %x, want %x", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package solana
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Blockhash is a recent blockhash and the last block height at which transactions using it are accepted
+type Blockhash struct {
+	Hash                 Hash
+	LastValidBlockHeight uint64
+}
+
+// LatestBlockhash fetches the latest blockhash from a Solana JSON-RPC endpoint at the
+// "confirmed" commitment. A nil client uses http.DefaultClient
+func LatestBlockhash(ctx context.Context, client *http.Client, endpoint string) (*Blockhash, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	body, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "getLatestBlockhash",
+		"params":  []any{map[string]string{"commitment": "confirmed"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest blockhash: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get latest blockhash: HTTP %d", resp.StatusCode)
+	}
+
+	var reply struct {
+		Result *struct {
+			Value struct {
+				Blockhash            string `json:"blockhash"`
+				LastValidBlockHeight uint64 `json:"lastValidBlockHeight"`
+			} `json:"value"`
+		} `json:"result"`
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		return nil, fmt.Errorf("failed to decode latest blockhash: %w", err)
+	}
+	if reply.Error != nil {
+		return nil, fmt.Errorf("failed to get latest blockhash: %s (%d)", reply.Error.Message, reply.Error.Code)
+	}
+	if reply.Result == nil {
+		return nil, fmt.Errorf("failed to get latest blockhash: empty result")
+	}
+	hash, err := ParseHash(reply.Result.Value.Blockhash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid latest blockhash: %w", err)
+	}
+	return &Blockhash{Hash: hash, LastValidBlockHeight: reply.Result.Value.LastValidBlockHeight}, nil
+}
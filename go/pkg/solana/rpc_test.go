@@ -0,0 +1,45 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLatestBlockhash(t *testing.T) {
+	blockhash := Hash(testKey(9))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Method != "getLatestBlockhash" {
+			w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"Method not found"}}`))
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"context":{"slot":1},"value":{"blockhash":"` + blockhash.String() + `","lastValidBlockHeight":3090}}}`))
+	}))
+	defer server.Close()
+
+	latest, err := LatestBlockhash(context.Background(), nil, server.URL)
+	if err != nil {
+		t.Fatalf("LatestBlockhash failed: %v", err)
+	}
+	if latest.Hash != blockhash || latest.LastValidBlockHeight != 3090 {
+		t.Errorf("Unexpected blockhash %+v", latest)
+	}
+}
+
+func TestLatestBlockhashError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32005,"message":"Node is behind"}}`))
+	}))
+	defer server.Close()
+
+	if _, err := LatestBlockhash(context.Background(), nil, server.URL); err == nil || !strings.Contains(err.Error(), "Node is behind") {
+		t.Errorf("Expected the RPC error, got %v", err)
+	}
+}
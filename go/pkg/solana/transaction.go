@@ -0,0 +1,91 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package solana
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+// Signature is an ED25519 transaction signature
+type Signature [ed25519.SignatureSize]byte
+
+// String returns the base58 signature, the form used as a transaction ID
+func (s Signature) String() string {
+	return verification.EncodeBase58(s[:])
+}
+
+// Transaction is a message with one signature slot per required signer
+type Transaction struct {
+	Signatures []Signature
+	Message    *Message
+}
+
+// NewTransaction returns an unsigned transaction for message
+func NewTransaction(message *Message) *Transaction {
+	return &Transaction{
+		Signatures: make([]Signature, message.Header.NumRequiredSignatures),
+		Message:    message,
+	}
+}
+
+// AddSignature verifies signature over the message under signer's key and stores it in the
+// signer's slot. It returns the base58 signature
+func (t *Transaction) AddSignature(signer PublicKey, signature []byte) (string, error) {
+	if len(signature) != ed25519.SignatureSize {
+		return "", fmt.Errorf("invalid ED25519 signature size: expected %d, got %d", ed25519.SignatureSize, len(signature))
+	}
+	slot := -1
+	for i, key := range t.Message.Signers() {
+		if key == signer {
+			slot = i
+			break
+		}
+	}
+	if slot < 0 {
+		return "", fmt.Errorf("%s is not a signer of the transaction", signer)
+	}
+	if !ed25519.Verify(signer[:], t.Message.Serialize(), signature) {
+		return "", fmt.Errorf("signature of %s does not verify over the transaction message", signer)
+	}
+	copy(t.Signatures[slot][:], signature)
+	return t.Signatures[slot].String(), nil
+}
+
+// IsSigned reports whether every signature slot is filled
+func (t *Transaction) IsSigned() bool {
+	for _, signature := range t.Signatures {
+		if signature == (Signature{}) {
+			return false
+		}
+	}
+	return true
+}
+
+// Serialize encodes the transaction in wire format, for sendTransaction
+func (t *Transaction) Serialize() []byte {
+	buf := appendCompactU16(nil, len(t.Signatures))
+	for _, signature := range t.Signatures {
+		buf = append(buf, signature[:]...)
+	}
+	return append(buf, t.Message.Serialize()...)
+}
+
+// Base64 returns the serialized transaction in base64, the encoding sendTransaction prefers
+func (t *Transaction) Base64() string {
+	return base64.StdEncoding.EncodeToString(t.Serialize())
+}
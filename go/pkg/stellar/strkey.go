@@ -0,0 +1,71 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package stellar computes Stellar transaction hashes, account IDs and decorated signatures for
+// signing with ED25519 app keys
+package stellar
+
+import (
+	"crypto/ed25519"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+)
+
+// versionByteAccountID is the StrKey version byte of account IDs, which encode as "G..."
+const versionByteAccountID = 6 << 3
+
+// strKeyEncoding is unpadded RFC 4648 base32
+var strKeyEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// EncodeAccountID returns the StrKey account ID ("G...") of an ED25519 public key
+func EncodeAccountID(publicKey []byte) (string, error) {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return "", fmt.Errorf("invalid ED25519 public key size: expected %d, got %d", ed25519.PublicKeySize, len(publicKey))
+	}
+	payload := append([]byte{versionByteAccountID}, publicKey...)
+	payload = binary.LittleEndian.AppendUint16(payload, crc16XModem(payload))
+	return strKeyEncoding.EncodeToString(payload), nil
+}
+
+// DecodeAccountID returns the ED25519 public key of a StrKey account ID
+func DecodeAccountID(accountID string) ([]byte, error) {
+	payload, err := strKeyEncoding.DecodeString(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account ID %q: %w", accountID, err)
+	}
+	if len(payload) != 1+ed25519.PublicKeySize+2 || payload[0] != versionByteAccountID {
+		return nil, fmt.Errorf("invalid account ID %q: not an ED25519 account", accountID)
+	}
+	body, checksum := payload[:len(payload)-2], binary.LittleEndian.Uint16(payload[len(payload)-2:])
+	if crc16XModem(body) != checksum {
+		return nil, fmt.Errorf("invalid account ID %q: checksum mismatch", accountID)
+	}
+	return append([]byte(nil), body[1:]...), nil
+}
+
+// crc16XModem computes the CRC-16/XMODEM checksum of StrKeys
+func crc16XModem(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
@@ -0,0 +1,47 @@
+package stellar
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+)
+
+func TestEncodeAccountID(t *testing.T) {
+	// The all-zero key is Stellar's well-known null account
+	accountID, err := EncodeAccountID(make([]byte, ed25519.PublicKeySize))
+	if err != nil {
+		t.Fatalf("EncodeAccountID failed: %v", err)
+	}
+	if accountID != "GAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAWHF" {
+		t.Errorf("Unexpected null account ID %s", accountID)
+	}
+
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	accountID, err = EncodeAccountID(publicKey)
+	if err != nil {
+		t.Fatalf("EncodeAccountID failed: %v", err)
+	}
+	decoded, err := DecodeAccountID(accountID)
+	if err != nil || !bytes.Equal(decoded, publicKey) {
+		t.Errorf("Expected round trip of %x, got %x, %v", publicKey, decoded, err)
+	}
+
+	corrupted := []byte(accountID)
+	corrupted[10] ^= 'A' ^ 'B'
+	if _, err := DecodeAccountID(string(corrupted)); err == nil {
+		t.Error("Expected a corrupted account ID to be rejected")
+	}
+	if _, err := DecodeAccountID("SAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAWHF"); err == nil {
+		t.Error("Expected another version byte to be rejected")
+	}
+}
+
+func TestCRC16XModem(t *testing.T) {
+	if got := crc16XModem([]byte("123456789")); got != 0x31c3 {
+		t.Errorf("crc16XModem(123456789) = %#x, want 0x31c3", got)
+	}
+}
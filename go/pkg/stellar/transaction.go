@@ -0,0 +1,100 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package stellar
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+)
+
+// Network passphrases
+const (
+	PublicNetworkPassphrase = "Public Global Stellar Network ; September 2015"
+	TestNetworkPassphrase   = "Test SDF Network ; September 2015"
+	FuturenetPassphrase     = "Test SDF Future Network ; October 2022"
+)
+
+// EnvelopeType selects the kind of transaction a signature payload covers
+type EnvelopeType int32
+
+// Envelope types of signature payloads. V0 transactions are signed as EnvelopeTypeTx
+const (
+	EnvelopeTypeTx        EnvelopeType = 2
+	EnvelopeTypeTxFeeBump EnvelopeType = 5
+)
+
+// NetworkID returns the network ID of a passphrase, mixed into every transaction hash
+func NetworkID(passphrase string) [32]byte {
+	return sha256.Sum256([]byte(passphrase))
+}
+
+// TransactionHash returns the hash signers sign: SHA-256 of the network ID, the envelope type
+// and the XDR-encoded Transaction (or FeeBumpTransaction), without its envelope or signatures
+func TransactionHash(passphrase string, envelopeType EnvelopeType, transactionXDR []byte) ([32]byte, error) {
+	if passphrase == "" {
+		return [32]byte{}, fmt.Errorf("network passphrase is required")
+	}
+	if len(transactionXDR) == 0 {
+		return [32]byte{}, fmt.Errorf("transaction XDR is required")
+	}
+	networkID := NetworkID(passphrase)
+	payload := append(networkID[:], binary.BigEndian.AppendUint32(nil, uint32(envelopeType))...)
+	return sha256.Sum256(append(payload, transactionXDR...)), nil
+}
+
+// DecoratedSignature is a signature with the hint that tells validators which signer made it
+type DecoratedSignature struct {
+	Hint      [4]byte
+	Signature []byte
+}
+
+// SignatureHint returns the hint of an ED25519 signer: the last four bytes of its public key
+func SignatureHint(publicKey []byte) [4]byte {
+	var hint [4]byte
+	if len(publicKey) >= len(hint) {
+		copy(hint[:], publicKey[len(publicKey)-len(hint):])
+	}
+	return hint
+}
+
+// NewDecoratedSignature checks signature is an ED25519 signature of hash under publicKey and decorates it
+func NewDecoratedSignature(publicKey []byte, hash [32]byte, signature []byte) (*DecoratedSignature, error) {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid ED25519 public key size: expected %d, got %d", ed25519.PublicKeySize, len(publicKey))
+	}
+	if len(signature) != ed25519.SignatureSize || !ed25519.Verify(publicKey, hash[:], signature) {
+		return nil, fmt.Errorf("signature does not verify over the transaction hash")
+	}
+	return &DecoratedSignature{Hint: SignatureHint(publicKey), Signature: append([]byte(nil), signature...)}, nil
+}
+
+// MarshalXDR encodes the decorated signature as XDR, for the signatures of a TransactionEnvelope
+func (s *DecoratedSignature) MarshalXDR() []byte {
+	buf := append([]byte{}, s.Hint[:]...)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(s.Signature)))
+	buf = append(buf, s.Signature...)
+	// XDR pads variable-length opaque data to a multiple of four bytes
+	for len(buf)%4 != 0 {
+		buf = append(buf, 0)
+	}
+	return buf
+}
+
+// Base64 returns the XDR encoding in base64, the form Stellar SDKs and Horizon exchange
+func (s *DecoratedSignature) Base64() string {
+	return base64.StdEncoding.EncodeToString(s.MarshalXDR())
+}
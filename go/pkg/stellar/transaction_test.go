@@ -0,0 +1,65 @@
+package stellar
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+)
+
+func TestNetworkID(t *testing.T) {
+	id := NetworkID(PublicNetworkPassphrase)
+	if got := hex.EncodeToString(id[:]); got != "7ac33997544e3175d266bd022439b22cdb16508c01163f26e5cb2a3e1045a979" {
+		t.Errorf("Unexpected public network ID %s", got)
+	}
+}
+
+func TestTransactionHash(t *testing.T) {
+	tx := []byte{0, 0, 0, 1, 2, 3, 4, 5}
+	hash, err := TransactionHash(TestNetworkPassphrase, EnvelopeTypeTx, tx)
+	if err != nil {
+		t.Fatalf("TransactionHash failed: %v", err)
+	}
+	networkID := NetworkID(TestNetworkPassphrase)
+	payload := append(networkID[:], 0, 0, 0, 2)
+	if want := sha256.Sum256(append(payload, tx...)); hash != want {
+		t.Errorf("Unexpected transaction hash %x", hash)
+	}
+
+	feeBump, _ := TransactionHash(TestNetworkPassphrase, EnvelopeTypeTxFeeBump, tx)
+	public, _ := TransactionHash(PublicNetworkPassphrase, EnvelopeTypeTx, tx)
+	if feeBump == hash || public == hash {
+		t.Error("Expected the envelope type and network to change the hash")
+	}
+	if _, err := TransactionHash("", EnvelopeTypeTx, tx); err == nil {
+		t.Error("Expected an error without a passphrase")
+	}
+}
+
+func TestDecoratedSignature(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	hash, _ := TransactionHash(TestNetworkPassphrase, EnvelopeTypeTx, []byte("tx"))
+	signature := ed25519.Sign(privateKey, hash[:])
+
+	decorated, err := NewDecoratedSignature(publicKey, hash, signature)
+	if err != nil {
+		t.Fatalf("NewDecoratedSignature failed: %v", err)
+	}
+	xdr := decorated.MarshalXDR()
+	if len(xdr) != 72 {
+		t.Fatalf("Expected 72 XDR bytes, got %d", len(xdr))
+	}
+	if hex.EncodeToString(xdr[:4]) != hex.EncodeToString(publicKey[28:]) || binary.BigEndian.Uint32(xdr[4:8]) != 64 {
+		t.Errorf("Unexpected hint or length in %x", xdr[:8])
+	}
+
+	other, _ := TransactionHash(PublicNetworkPassphrase, EnvelopeTypeTx, []byte("tx"))
+	if _, err := NewDecoratedSignature(publicKey, other, signature); err == nil {
+		t.Error("Expected a signature over another hash to be rejected")
+	}
+}
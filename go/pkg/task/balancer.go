@@ -0,0 +1,160 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package task
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/key_management"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+)
+
+// node is the connection to a single TEE node
+type node struct {
+	address  string
+	affinity int
+	conn     *grpc.ClientConn
+	client   pb.UserTaskClient
+
+	mu               sync.Mutex
+	unavailableUntil time.Time // set when the node returned UNAVAILABLE
+}
+
+// healthy reports whether the node should be preferred for new calls
+func (n *node) healthy(now time.Time) bool {
+	if state := n.conn.GetState(); state == connectivity.TransientFailure || state == connectivity.Shutdown {
+		return false
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return !now.Before(n.unavailableUntil)
+}
+
+// setUnavailable takes the node out of rotation for constants.TEENodeCooldown
+func (n *node) setUnavailable(now time.Time) {
+	n.mu.Lock()
+	n.unavailableUntil = now.Add(constants.TEENodeCooldown)
+	n.mu.Unlock()
+}
+
+// setAvailable returns the node to rotation
+func (n *node) setAvailable() {
+	n.mu.Lock()
+	n.unavailableUntil = time.Time{}
+	n.mu.Unlock()
+}
+
+// signOnNodes sends req to the nodes in balancing order, moving on to the next node
+// when one is UNAVAILABLE. Other errors are returned without failover
+func (c *Client) signOnNodes(ctx context.Context, nodes []*node, req *pb.SignRequest) (*pb.SignResponse, error) {
+	var lastErr error
+	for _, n := range c.order(nodes, time.Now()) {
+		resp, err := n.client.Sign(ctx, req)
+		if status.Code(err) != codes.Unavailable {
+			if err == nil {
+				n.setAvailable()
+			}
+			return resp, err
+		}
+		lastErr = err
+		n.setUnavailable(time.Now())
+		if len(nodes) > 1 {
+			log.Printf("⚠️  TEE node %s unavailable, failing over: %v", n.address, err)
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+// watchOnNodes opens a key operation stream on the nodes in balancing order, moving on to
+// the next node when one is UNAVAILABLE
+func (c *Client) watchOnNodes(ctx context.Context, nodes []*node, req *pb.WatchKeyOperationRequest) (pb.UserTask_WatchKeyOperationClient, error) {
+	var lastErr error
+	for _, n := range c.order(nodes, time.Now()) {
+		stream, err := n.client.WatchKeyOperation(ctx, req)
+		if status.Code(err) != codes.Unavailable {
+			return stream, err
+		}
+		lastErr = err
+		n.setUnavailable(time.Now())
+		if len(nodes) > 1 {
+			log.Printf("⚠️  TEE node %s unavailable, failing over: %v", n.address, err)
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+// order returns the nodes to try for one call: healthy nodes first, nearest (highest
+// affinity) first and rotating among equally near nodes on every call, then unhealthy
+// nodes as a last resort
+func (c *Client) order(nodes []*node, now time.Time) []*node {
+	turn := c.next.Add(1) - 1
+	var healthy, unhealthy []*node
+	for _, n := range nodes {
+		if n.healthy(now) {
+			healthy = append(healthy, n)
+		} else {
+			unhealthy = append(unhealthy, n)
+		}
+	}
+	return append(rotateTiers(healthy, turn), rotateTiers(unhealthy, turn)...)
+}
+
+// rotateTiers orders nodes by affinity, highest first, rotating each group of equal
+// affinity by turn so calls are spread across it
+func rotateTiers(nodes []*node, turn uint64) []*node {
+	sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].affinity > nodes[j].affinity })
+	ordered := make([]*node, 0, len(nodes))
+	for start := 0; start < len(nodes); {
+		end := start + 1
+		for end < len(nodes) && nodes[end].affinity == nodes[start].affinity {
+			end++
+		}
+		tier := nodes[start:end]
+		offset := int(turn % uint64(len(tier)))
+		ordered = append(ordered, tier[offset:]...)
+		ordered = append(ordered, tier[:offset]...)
+		start = end
+	}
+	return ordered
+}
+
+// stateRank orders connectivity states from least to most usable
+func stateRank(state connectivity.State) int {
+	switch state {
+	case connectivity.Ready:
+		return 4
+	case connectivity.Idle:
+		return 3
+	case connectivity.Connecting:
+		return 2
+	case connectivity.TransientFailure:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,176 @@
+package task
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/config"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/key_management"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// fakeNode answers sign calls with a fixed error or success and counts them
+type fakeNode struct {
+	err   error
+	calls int
+	last  *pb.SignRequest
+}
+
+func (f *fakeNode) Sign(ctx context.Context, in *pb.SignRequest, opts ...grpc.CallOption) (*pb.SignResponse, error) {
+	f.calls++
+	f.last = in
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &pb.SignResponse{Success: true, Signature: []byte{1}}, nil
+}
+
+func (f *fakeNode) WatchKeyOperation(ctx context.Context, in *pb.WatchKeyOperationRequest, opts ...grpc.CallOption) (pb.UserTask_WatchKeyOperationClient, error) {
+	f.calls++
+	return nil, f.err
+}
+
+// newTestClient builds a task client whose nodes are served by fakes
+func newTestClient(t *testing.T, fakes ...*fakeNode) *Client {
+	c := NewClient(&config.NodeConfig{})
+	for i, fake := range fakes {
+		// The connection is never used for RPCs; it only reports an IDLE state
+		conn, err := grpc.NewClient("passthrough:///unused", grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatalf("Failed to create connection: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		c.nodes = append(c.nodes, &node{address: string(rune('a' + i)), conn: conn, client: fake})
+	}
+	return c
+}
+
+func TestSignRoundRobin(t *testing.T) {
+	a, b := &fakeNode{}, &fakeNode{}
+	c := newTestClient(t, a, b)
+
+	for i := 0; i < 4; i++ {
+		if _, err := c.Sign(context.Background(), []byte("msg"), []byte("key"), 1, 2); err != nil {
+			t.Fatalf("Sign failed: %v", err)
+		}
+	}
+	if a.calls != 2 || b.calls != 2 {
+		t.Errorf("Expected calls to alternate, got %d and %d", a.calls, b.calls)
+	}
+}
+
+func TestSignFailover(t *testing.T) {
+	down := &fakeNode{err: status.Error(codes.Unavailable, "down")}
+	up := &fakeNode{}
+	c := newTestClient(t, down, up)
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Sign(context.Background(), []byte("msg"), []byte("key"), 1, 2); err != nil {
+			t.Fatalf("Sign %d failed despite a healthy node: %v", i, err)
+		}
+	}
+	// The unavailable node is tried once, then skipped during its cooldown
+	if down.calls != 1 || up.calls != 3 {
+		t.Errorf("Expected 1 call to the down node and 3 to the healthy node, got %d and %d", down.calls, up.calls)
+	}
+}
+
+func TestSignNoFailoverOnOtherErrors(t *testing.T) {
+	rejecting := &fakeNode{err: status.Error(codes.InvalidArgument, "bad key")}
+	other := &fakeNode{}
+	c := newTestClient(t, rejecting, other)
+
+	if _, err := c.Sign(context.Background(), []byte("msg"), []byte("key"), 1, 2); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument, got %v", err)
+	}
+	if other.calls != 0 {
+		t.Errorf("Expected no failover for non-UNAVAILABLE errors, got %d calls", other.calls)
+	}
+}
+
+func TestSignAllNodesDown(t *testing.T) {
+	a := &fakeNode{err: status.Error(codes.Unavailable, "down")}
+	b := &fakeNode{err: status.Error(codes.Unavailable, "down")}
+	c := newTestClient(t, a, b)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Sign(context.Background(), []byte("msg"), []byte("key"), 1, 2); status.Code(err) != codes.Unavailable {
+			t.Fatalf("Expected Unavailable, got %v", err)
+		}
+	}
+	// Unhealthy nodes are still tried as a last resort
+	if a.calls != 2 || b.calls != 2 {
+		t.Errorf("Expected every node to be tried on each call, got %d and %d", a.calls, b.calls)
+	}
+}
+
+func TestSignPrefersNearNodes(t *testing.T) {
+	far, near1, near2 := &fakeNode{}, &fakeNode{}, &fakeNode{}
+	c := newTestClient(t, far, near1, near2)
+	c.nodes[1].affinity = 2
+	c.nodes[2].affinity = 2
+
+	for i := 0; i < 4; i++ {
+		if _, err := c.Sign(context.Background(), []byte("msg"), []byte("key"), 1, 2); err != nil {
+			t.Fatalf("Sign failed: %v", err)
+		}
+	}
+	if far.calls != 0 || near1.calls != 2 || near2.calls != 2 {
+		t.Errorf("Expected calls balanced over near nodes only, got far=%d near=%d,%d", far.calls, near1.calls, near2.calls)
+	}
+
+	// Falls back to the far node when the near ones are unavailable
+	near1.err = status.Error(codes.Unavailable, "down")
+	near2.err = status.Error(codes.Unavailable, "down")
+	if _, err := c.Sign(context.Background(), []byte("msg"), []byte("key"), 1, 2); err != nil {
+		t.Fatalf("Sign failed despite a healthy far node: %v", err)
+	}
+	if far.calls != 1 {
+		t.Errorf("Expected failover to the far node, got %d calls", far.calls)
+	}
+}
+
+func TestSignPrehashed(t *testing.T) {
+	fake := &fakeNode{}
+	c := newTestClient(t, fake)
+	digest := make([]byte, 32)
+
+	if _, err := c.SignWithOptions(context.Background(), digest, []byte("key"), constants.ProtocolECDSA, constants.CurveSECP256K1, &SignOptions{Prehashed: true}); err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if !fake.last.GetPrehashed() {
+		t.Error("Expected the request to be marked prehashed")
+	}
+
+	// Digests are 32 bytes and only ECDSA signs them as is
+	if _, err := c.SignWithOptions(context.Background(), []byte("msg"), []byte("key"), constants.ProtocolECDSA, constants.CurveSECP256K1, &SignOptions{Prehashed: true}); err == nil {
+		t.Error("Expected a short digest to be rejected")
+	}
+	if _, err := c.SignWithOptions(context.Background(), digest, []byte("key"), constants.ProtocolSchnorr, constants.CurveSECP256K1, &SignOptions{Prehashed: true}); err == nil {
+		t.Error("Expected a prehashed Schnorr request to be rejected")
+	}
+	if fake.calls != 1 {
+		t.Errorf("Expected rejected requests not to reach the node, got %d calls", fake.calls)
+	}
+}
+
+func TestWatchKeyOperationFailover(t *testing.T) {
+	down := &fakeNode{err: status.Error(codes.Unavailable, "down")}
+	up := &fakeNode{}
+	c := newTestClient(t, down, up)
+
+	if _, err := c.WatchKeyOperation(context.Background(), "op-1"); err != nil {
+		t.Fatalf("WatchKeyOperation failed despite a healthy node: %v", err)
+	}
+	if down.calls != 1 || up.calls != 1 {
+		t.Errorf("Expected failover to the healthy node, got %d and %d calls", down.calls, up.calls)
+	}
+
+	if _, err := c.WatchKeyOperation(context.Background(), ""); err == nil {
+		t.Error("WatchKeyOperation accepted an empty operation ID")
+	}
+}
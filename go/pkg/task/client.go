@@ -17,12 +17,16 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/TEENet-io/teenet-sdk/go/pkg/config"
 	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/utils"
 	pb "github.com/TEENet-io/teenet-sdk/go/proto/key_management"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 )
@@ -32,11 +36,27 @@ const (
 )
 
 // Client executes tasks (with TLS and gRPC built-in retry)
+// With several TEE nodes configured, sign calls are balanced round-robin across the healthy
+// nodes with the highest affinity and fail over to the next node when one returns UNAVAILABLE
+// Sign, SetQueue, State and Close are safe for concurrent use
 type Client struct {
 	config  *config.NodeConfig
-	conn    *grpc.ClientConn
-	client  pb.UserTaskClient
 	timeout time.Duration
+
+	mu    sync.RWMutex // guards nodes and queue
+	nodes []*node
+	queue *Queue
+	next  atomic.Uint64 // round-robin position
+
+	reconnectHook func()
+	dialOptions   []grpc.DialOption
+}
+
+// Target is a TEE node address with the TLS configuration used to reach it
+type Target struct {
+	Address   string
+	TLSConfig *tls.Config
+	Affinity  int // Nodes with higher affinity are preferred while healthy, see config.Locality
 }
 
 // NewClient creates a new task client
@@ -47,61 +67,188 @@ func NewClient(nodeConfig *config.NodeConfig) *Client {
 	}
 }
 
-// Connect connects to TEE server
+// Connect connects to the TEE server in the node configuration
 func (c *Client) Connect(ctx context.Context, tlsConfig *tls.Config) error {
-	if c.conn != nil {
-		c.conn.Close()
+	return c.ConnectNodes(ctx, []Target{{Address: c.config.RPCAddress, TLSConfig: tlsConfig}})
+}
+
+// ConnectNodes connects to several TEE nodes, replacing any existing connections
+func (c *Client) ConnectNodes(ctx context.Context, targets []Target) error {
+	if len(targets) == 0 {
+		return fmt.Errorf("no TEE nodes to connect to")
 	}
 
-	// gRPC connection options with TLS and retry configuration
-	creds := credentials.NewTLS(tlsConfig)
+	nodes := make([]*node, 0, len(targets))
+	for _, target := range targets {
+		// gRPC connection options with TLS and retry configuration
+		opts := []grpc.DialOption{
+			grpc.WithTransportCredentials(credentials.NewTLS(target.TLSConfig)),
+			grpc.WithDefaultServiceConfig(constants.GRPCRetryPolicy),
+		}
+		opts = append(opts, c.dialOptions...)
 
-	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(creds),
-		grpc.WithDefaultServiceConfig(constants.GRPCRetryPolicy),
+		conn, err := grpc.NewClient(utils.GRPCTarget(target.Address), opts...)
+		if err != nil {
+			for _, n := range nodes {
+				n.conn.Close()
+			}
+			return fmt.Errorf("failed to connect to TEE server %s: %w", target.Address, err)
+		}
+		if c.reconnectHook != nil {
+			utils.WatchReconnects(conn, c.reconnectHook)
+		}
+		nodes = append(nodes, &node{address: target.Address, affinity: target.Affinity, conn: conn, client: pb.NewUserTaskClient(conn)})
 	}
 
-	conn, err := grpc.NewClient(c.config.RPCAddress, opts...)
-	if err != nil {
-		return fmt.Errorf("failed to connect to TEE server: %w", err)
+	c.mu.Lock()
+	old := c.nodes
+	c.nodes = nodes
+	c.mu.Unlock()
+	for _, n := range old {
+		n.conn.Close()
 	}
-
-	c.conn = conn
-	c.client = pb.NewUserTaskClient(conn)
 	return nil
 }
 
-// Close closes the connection
+// SetDialOptions sets extra gRPC dial options (e.g. interceptors) applied on Connect
+func (c *Client) SetDialOptions(opts ...grpc.DialOption) {
+	c.dialOptions = opts
+}
+
+// SetReconnectHook sets a function called whenever a TEE connection is re-established
+// It must be set before Connect
+func (c *Client) SetReconnectHook(hook func()) {
+	c.reconnectHook = hook
+}
+
+// State returns the best connectivity state across TEE nodes, or Shutdown if not connected
+func (c *Client) State() connectivity.State {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	best := connectivity.Shutdown
+	for _, n := range c.nodes {
+		if state := n.conn.GetState(); stateRank(state) > stateRank(best) {
+			best = state
+		}
+	}
+	return best
+}
+
+// Nodes returns the addresses of the connected TEE nodes
+func (c *Client) Nodes() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	addresses := make([]string, len(c.nodes))
+	for i, n := range c.nodes {
+		addresses[i] = n.address
+	}
+	return addresses
+}
+
+// Close closes all connections
 func (c *Client) Close() error {
-	if c.conn != nil {
-		err := c.conn.Close()
-		c.conn = nil
-		c.client = nil
-		return err
+	c.mu.Lock()
+	nodes := c.nodes
+	c.nodes = nil
+	c.mu.Unlock()
+
+	var firstErr error
+	for _, n := range nodes {
+		if err := n.conn.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
 	}
-	return nil
+	return firstErr
+}
+
+// SignOptions carries optional parameters for a signing task
+type SignOptions struct {
+	ED25519Mode    uint32      // ED25519 variant, see constants.ED25519Mode*
+	ED25519Context []byte      // Context string for Ed25519ph/Ed25519ctx
+	Priority       Priority    // Position in the sign queue, if one is configured
+	MuSig2         *MuSig2Step // Session step for constants.ProtocolMuSig2
+	Prehashed      bool        // ECDSA only: the message is a 32-byte digest the TEE signs without hashing it again
+}
+
+// MuSig2Step is one TEE step of a MuSig2 signing session
+// Without AggregateNonce the TEE returns its 66-byte public nonce for the session, with it the
+// 32-byte partial signature of the message; each secret nonce signs at most once
+type MuSig2Step struct {
+	SessionID      string
+	Signers        [][]byte // Public keys of all signers, including the signing key
+	Taproot        bool     // Sign for the BIP-86 taproot output key of the aggregate key
+	AggregateNonce []byte   // Sum of all public nonces; nil for the nonce step
 }
 
 // Sign executes signing operation
-func (c *Client) Sign(ctx context.Context, message, publicKey []byte, protocol, curve uint32) ([]byte, error) {
+func (c *Client) Sign(ctx context.Context, message, publicKey []byte, protocol constants.Protocol, curve constants.Curve) ([]byte, error) {
+	return c.SignWithOptions(ctx, message, publicKey, protocol, curve, nil)
+}
+
+// SignWithOptions executes signing operation with optional parameters
+func (c *Client) SignWithOptions(ctx context.Context, message, publicKey []byte, protocol constants.Protocol, curve constants.Curve, opts *SignOptions) ([]byte, error) {
 	if len(message) == 0 || len(publicKey) == 0 {
 		return nil, fmt.Errorf("message and public key cannot be empty")
 	}
 
-	if c.client == nil {
+	c.mu.RLock()
+	nodes, queue := c.nodes, c.queue
+	c.mu.RUnlock()
+	if len(nodes) == 0 {
 		return nil, fmt.Errorf("not connected to server")
 	}
 
-	taskCtx, cancel := context.WithTimeout(ctx, c.timeout)
-	defer cancel()
+	if opts != nil && opts.Prehashed {
+		if protocol != constants.ProtocolECDSA {
+			return nil, fmt.Errorf("prehashed signing requires ECDSA, got %s", protocol)
+		}
+		if len(message) != 32 {
+			return nil, fmt.Errorf("prehashed message must be a 32-byte digest, got %d bytes", len(message))
+		}
+	}
+
+	if queue != nil {
+		priority := PriorityNormal
+		if opts != nil {
+			priority = opts.Priority
+		}
+		release, err := queue.Acquire(ctx, priority)
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire sign slot: %w", err)
+		}
+		defer release()
+	}
 
-	resp, err := c.client.Sign(taskCtx, &pb.SignRequest{
+	// A caller-supplied deadline takes precedence over the task default
+	taskCtx := ctx
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		taskCtx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	req := &pb.SignRequest{
 		From:          c.config.NodeID,
 		PublicKeyInfo: publicKey,
 		Msg:           message,
-		Protocol:      protocol,
-		Curve:         curve,
-	})
+		Protocol:      uint32(protocol),
+		Curve:         uint32(curve),
+	}
+	if opts != nil {
+		req.Ed25519Mode = opts.ED25519Mode
+		req.Ed25519Context = opts.ED25519Context
+		req.Prehashed = opts.Prehashed
+		if step := opts.MuSig2; step != nil {
+			req.Musig2 = &pb.MuSig2Step{
+				SessionId:      step.SessionID,
+				Signers:        step.Signers,
+				Taproot:        step.Taproot,
+				AggregateNonce: step.AggregateNonce,
+			}
+		}
+	}
+
+	resp, err := c.signOnNodes(taskCtx, nodes, req)
 	if err != nil {
 		// Check if it's a gRPC error
 		if st, ok := status.FromError(err); ok {
@@ -117,6 +264,38 @@ func (c *Client) Sign(ctx context.Context, message, publicKey []byte, protocol,
 	return resp.GetSignature(), nil
 }
 
+// WatchKeyOperation opens a stream of status updates for a DKG or resharing operation
+// The stream is not bound to the task timeout; cancel ctx to close it
+func (c *Client) WatchKeyOperation(ctx context.Context, operationID string) (pb.UserTask_WatchKeyOperationClient, error) {
+	if operationID == "" {
+		return nil, fmt.Errorf("operation ID cannot be empty")
+	}
+
+	c.mu.RLock()
+	nodes := c.nodes
+	c.mu.RUnlock()
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("not connected to server")
+	}
+
+	req := &pb.WatchKeyOperationRequest{
+		From:        c.config.NodeID,
+		OperationId: operationID,
+	}
+	stream, err := c.watchOnNodes(ctx, nodes, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch key operation: %w", err)
+	}
+	return stream, nil
+}
+
+// SetQueue places a priority queue in front of sign calls; nil removes it
+func (c *Client) SetQueue(queue *Queue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queue = queue
+}
+
 // SetTimeout sets task timeout
 func (c *Client) SetTimeout(timeout time.Duration) {
 	c.timeout = timeout
@@ -0,0 +1,160 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package task
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+)
+
+// Priority orders sign requests waiting for a free TEE slot; higher runs first
+type Priority int
+
+// Priority levels
+const (
+	PriorityBatch       Priority = -1 // Bulk workloads that can tolerate queueing
+	PriorityNormal      Priority = 0  // Default
+	PriorityInteractive Priority = 1  // Latency-sensitive, user-facing requests
+)
+
+// ErrQueueFull is returned when the sign queue has no room for another waiting request
+var ErrQueueFull = errors.New("sign queue is full")
+
+// Queue bounds the number of concurrent sign calls and orders waiting requests by priority
+// Requests of equal priority are served first come, first served
+type Queue struct {
+	mu            sync.Mutex
+	maxConcurrent int
+	maxQueued     int
+	inFlight      int
+	seq           uint64
+	waiting       waiterHeap
+}
+
+// NewQueue creates a queue allowing maxConcurrent calls in flight and up to maxQueued waiting
+func NewQueue(maxConcurrent, maxQueued int) *Queue {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	if maxQueued < 0 {
+		maxQueued = 0
+	}
+	return &Queue{
+		maxConcurrent: maxConcurrent,
+		maxQueued:     maxQueued,
+	}
+}
+
+// Acquire waits for a free slot and returns a function that releases it
+// It fails with ErrQueueFull if too many requests are waiting, or with ctx's error if ctx ends first
+func (q *Queue) Acquire(ctx context.Context, priority Priority) (func(), error) {
+	q.mu.Lock()
+	if q.inFlight < q.maxConcurrent && len(q.waiting) == 0 {
+		q.inFlight++
+		q.mu.Unlock()
+		return q.releaseFunc(), nil
+	}
+	if len(q.waiting) >= q.maxQueued {
+		q.mu.Unlock()
+		return nil, ErrQueueFull
+	}
+	w := &waiter{priority: priority, seq: q.seq, ready: make(chan struct{})}
+	q.seq++
+	heap.Push(&q.waiting, w)
+	q.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return q.releaseFunc(), nil
+	case <-ctx.Done():
+		q.mu.Lock()
+		if w.index >= 0 {
+			heap.Remove(&q.waiting, w.index)
+			q.mu.Unlock()
+		} else {
+			// The slot was handed over just as ctx ended; pass it on
+			q.mu.Unlock()
+			q.release()
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// Stats returns the number of calls in flight and waiting
+func (q *Queue) Stats() (inFlight, queued int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.inFlight, len(q.waiting)
+}
+
+func (q *Queue) releaseFunc() func() {
+	var once sync.Once
+	return func() { once.Do(q.release) }
+}
+
+// release hands the slot to the highest priority waiter, or frees it
+func (q *Queue) release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.waiting) > 0 {
+		w := heap.Pop(&q.waiting).(*waiter)
+		close(w.ready)
+		return
+	}
+	q.inFlight--
+}
+
+// waiter is a request blocked in Acquire
+type waiter struct {
+	priority Priority
+	seq      uint64
+	ready    chan struct{}
+	index    int // position in the heap, -1 once removed
+}
+
+// waiterHeap implements heap.Interface ordered by priority, then arrival
+type waiterHeap []*waiter
+
+func (h waiterHeap) Len() int { return len(h) }
+
+func (h waiterHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h waiterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *waiterHeap) Push(x any) {
+	w := x.(*waiter)
+	w.index = len(*h)
+	*h = append(*h, w)
+}
+
+func (h *waiterHeap) Pop() any {
+	old := *h
+	n := len(old)
+	w := old[n-1]
+	old[n-1] = nil
+	w.index = -1
+	*h = old[:n-1]
+	return w
+}
@@ -0,0 +1,99 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestQueuePriorityOrder(t *testing.T) {
+	queue := NewQueue(1, 10)
+	release, err := queue.Acquire(context.Background(), PriorityNormal)
+	if err != nil {
+		t.Fatalf("Failed to acquire free slot: %v", err)
+	}
+
+	order := make(chan Priority, 3)
+	start := func(p Priority) {
+		go func() {
+			r, err := queue.Acquire(context.Background(), p)
+			if err != nil {
+				t.Errorf("Failed to acquire slot: %v", err)
+				return
+			}
+			order <- p
+			r()
+		}()
+		// Wait until the request is queued so arrival order is deterministic
+		for {
+			if _, queued := queue.Stats(); queued > 0 && waitingContains(queue, p) {
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	start(PriorityBatch)
+	start(PriorityNormal)
+	start(PriorityInteractive)
+
+	release()
+	for _, expected := range []Priority{PriorityInteractive, PriorityNormal, PriorityBatch} {
+		select {
+		case got := <-order:
+			if got != expected {
+				t.Errorf("Expected priority %d, got %d", expected, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for queued request")
+		}
+	}
+	if inFlight, queued := queue.Stats(); inFlight != 0 || queued != 0 {
+		t.Errorf("Expected empty queue, got %d in flight and %d queued", inFlight, queued)
+	}
+}
+
+func TestQueueFullAndCancel(t *testing.T) {
+	queue := NewQueue(1, 1)
+	release, err := queue.Acquire(context.Background(), PriorityNormal)
+	if err != nil {
+		t.Fatalf("Failed to acquire free slot: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := queue.Acquire(ctx, PriorityNormal)
+		done <- err
+	}()
+	for {
+		if _, queued := queue.Stats(); queued == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if _, err := queue.Acquire(context.Background(), PriorityInteractive); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("Expected ErrQueueFull, got %v", err)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	release()
+	if inFlight, queued := queue.Stats(); inFlight != 0 || queued != 0 {
+		t.Errorf("Expected empty queue, got %d in flight and %d queued", inFlight, queued)
+	}
+}
+
+func waitingContains(q *Queue, p Priority) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, w := range q.waiting {
+		if w.priority == p {
+			return true
+		}
+	}
+	return false
+}
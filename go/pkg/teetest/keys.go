@@ -0,0 +1,124 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package teetest
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/key_management"
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+)
+
+// key is one version of an app's signing key
+type key struct {
+	version    uint32
+	protocol   constants.Protocol
+	curve      constants.Curve
+	publicKey  []byte
+	validFrom  int64
+	validUntil int64
+
+	secp256k1 *btcec.PrivateKey
+	p256      *ecdsa.PrivateKey
+	ed25519   ed25519.PrivateKey
+}
+
+// newKey generates a key the TEE node can sign with: ECDSA on SECP256K1 or SECP256R1, Schnorr
+// (BIP-340) on SECP256K1, or Ed25519
+func newKey(protocol constants.Protocol, curve constants.Curve) (*key, error) {
+	k := &key{protocol: protocol, curve: curve}
+	switch {
+	case curve == constants.CurveSECP256K1 && (protocol == constants.ProtocolECDSA || protocol == constants.ProtocolSchnorr):
+		privateKey, err := btcec.NewPrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		k.secp256k1, k.publicKey = privateKey, privateKey.PubKey().SerializeCompressed()
+	case curve == constants.CurveSECP256R1 && protocol == constants.ProtocolECDSA:
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		k.p256 = privateKey
+		k.publicKey = elliptic.MarshalCompressed(elliptic.P256(), privateKey.X, privateKey.Y)
+	case curve == constants.CurveED25519 && protocol == constants.ProtocolSchnorr:
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		k.ed25519, k.publicKey = privateKey, publicKey
+	default:
+		return nil, fmt.Errorf("unsupported key type %s/%s", protocol, curve)
+	}
+	return k, nil
+}
+
+// sign signs a request the way the TEE does: ECDSA and Schnorr sign the SHA-256 of the message,
+// or the message itself when it is prehashed, and Ed25519 follows the requested RFC 8032 mode.
+// ECDSA and Schnorr signatures are raw R || S
+func (k *key) sign(req *pb.SignRequest) ([]byte, error) {
+	if req.Prehashed && k.protocol != constants.ProtocolECDSA {
+		return nil, fmt.Errorf("prehashed signing requires ECDSA")
+	}
+	digest := req.Msg
+	if !req.Prehashed {
+		hash := sha256.Sum256(req.Msg)
+		digest = hash[:]
+	} else if len(digest) != 32 {
+		return nil, fmt.Errorf("prehashed message must be 32 bytes, got %d", len(digest))
+	}
+
+	switch {
+	case k.secp256k1 != nil && k.protocol == constants.ProtocolSchnorr:
+		signature, err := schnorr.Sign(k.secp256k1, digest)
+		if err != nil {
+			return nil, err
+		}
+		return signature.Serialize(), nil
+	case k.secp256k1 != nil:
+		// The compact signature is a recovery byte followed by R and S
+		return btcecdsa.SignCompact(k.secp256k1, digest, true)[1:], nil
+	case k.p256 != nil:
+		r, s, err := ecdsa.Sign(rand.Reader, k.p256, digest)
+		if err != nil {
+			return nil, err
+		}
+		signature := make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+		return signature, nil
+	default:
+		message, opts := req.Msg, &ed25519.Options{Context: string(req.Ed25519Context)}
+		switch req.Ed25519Mode {
+		case constants.ED25519ModePure:
+		case constants.ED25519ModePh:
+			hash := sha512.Sum512(req.Msg)
+			message, opts.Hash = hash[:], crypto.SHA512
+		case constants.ED25519ModeCtx:
+		default:
+			return nil, fmt.Errorf("unsupported ED25519 mode: %d", req.Ed25519Mode)
+		}
+		return k.ed25519.Sign(nil, message, opts)
+	}
+}
@@ -0,0 +1,561 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package teetest runs an in-process deployment, a TEE node and an App node that sign with real
+// keys, so clients can be tested end to end without the mock server
+//
+// Deployment.Override points a client at the nodes over mutual TLS on the loopback interface.
+// Voting targets are votingtest peers, reached with the direct vote transport
+package teetest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/config"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/votingtest"
+	"github.com/TEENet-io/teenet-sdk/go/proto/appid"
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/key_management"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// NodeID is the node ID clients of a deployment sign requests as
+const NodeID = 1
+
+// app is an app registered with the App node
+type app struct {
+	keys          []*key // Every version, oldest first
+	usage         constants.KeyUsage
+	policy        *appid.SigningPolicy
+	network       *votingtest.Network
+	requiredVotes int
+	groups        map[string][]string
+}
+
+// current returns the app's current key
+func (a *app) current() *key {
+	return a.keys[len(a.keys)-1]
+}
+
+// Deployment is an in-process TEE node and App node
+type Deployment struct {
+	override *config.Override
+	tee      *grpc.Server
+	appNode  *grpc.Server
+
+	mu          sync.Mutex
+	apps        map[string]*app
+	requests    []*pb.SignRequest
+	failSign    error
+	corrupt     bool              // Flip a bit of every signature before returning it
+	lookups     map[string]int    // Public key lookups per app ID
+	operations  map[string][]byte // Completed key rotations by operation ID, with the new public key
+	subscribers map[*subscriber]struct{}
+}
+
+// subscriber is an open SubscribeEvents stream
+type subscriber struct {
+	appIDs []string // Empty for all apps
+	events chan *appid.AppEvent
+}
+
+// New starts a TEE node and an App node with no apps
+func New() (*Deployment, error) {
+	nodeCert, nodeKey, err := selfSigned("teetest node")
+	if err != nil {
+		return nil, err
+	}
+	clientCert, clientKey, err := selfSigned("teetest client")
+	if err != nil {
+		return nil, err
+	}
+	certificate, err := tls.X509KeyPair(nodeCert, nodeKey)
+	if err != nil {
+		return nil, err
+	}
+	// Nodes ask for the client certificate like real ones, but trust any
+	creds := credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientAuth:   tls.RequireAnyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	})
+
+	d := &Deployment{
+		apps:        make(map[string]*app),
+		lookups:     make(map[string]int),
+		operations:  make(map[string][]byte),
+		subscribers: make(map[*subscriber]struct{}),
+	}
+	d.tee = grpc.NewServer(grpc.Creds(creds))
+	pb.RegisterUserTaskServer(d.tee, &teeNode{d: d})
+	d.appNode = grpc.NewServer(grpc.Creds(creds))
+	appid.RegisterAppIDServiceServer(d.appNode, &appNode{d: d})
+
+	teeAddr, err := serve(d.tee)
+	if err != nil {
+		return nil, err
+	}
+	appAddr, err := serve(d.appNode)
+	if err != nil {
+		d.tee.Stop()
+		return nil, err
+	}
+	d.override = &config.Override{
+		NodeID:   NodeID,
+		Cert:     string(clientCert),
+		Key:      string(clientKey),
+		PeerCA:   string(nodeCert),
+		TEENodes: []string{teeAddr},
+		AppNodes: []string{appAddr},
+	}
+	return d, nil
+}
+
+// serve starts a gRPC server on a loopback port and returns its address
+func serve(server *grpc.Server) (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("failed to listen: %w", err)
+	}
+	go server.Serve(listener)
+	return listener.Addr().String(), nil
+}
+
+// Override returns a static configuration that reaches the deployment without a config server
+func (d *Deployment) Override() *config.Override {
+	override := *d.override
+	return &override
+}
+
+// AddApp registers an app with a freshly generated key and returns its public key
+func (d *Deployment) AddApp(appID string, protocol constants.Protocol, curve constants.Curve) ([]byte, error) {
+	k, err := newKey(protocol, curve)
+	if err != nil {
+		return nil, err
+	}
+	k.version = 1
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, exists := d.apps[appID]; exists {
+		return nil, fmt.Errorf("app %s already exists", appID)
+	}
+	d.apps[appID] = &app{keys: []*key{k}}
+	return k.publicKey, nil
+}
+
+// RotateKey replaces an app's key with a new version of the same type and returns its public key
+// The TEE node keeps signing with older versions, as it does during a rotation grace period
+func (d *Deployment) RotateKey(appID string) ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	k, err := d.rotateKey(appID)
+	if err != nil {
+		return nil, err
+	}
+	return k.publicKey, nil
+}
+
+// rotateKey adds a new key version to an app; the caller holds d.mu
+func (d *Deployment) rotateKey(appID string) (*key, error) {
+	a, ok := d.apps[appID]
+	if !ok {
+		return nil, fmt.Errorf("app %s not found", appID)
+	}
+	previous := a.current()
+	k, err := newKey(previous.protocol, previous.curve)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+	k.version, k.validFrom = previous.version+1, now
+	previous.validUntil = now
+	a.keys = append(a.keys, k)
+	d.publish(&appid.AppEvent{Type: appid.AppEventType_APP_EVENT_TYPE_KEY_ROTATED, AppId: appID, Timestamp: now})
+	return k, nil
+}
+
+// PruneKey drops an old version of an app's key from the App node's history, as key retention
+// would; the TEE node can no longer sign with it either. The current version can't be pruned
+func (d *Deployment) PruneKey(appID string, version uint32) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	a, ok := d.apps[appID]
+	if !ok {
+		return fmt.Errorf("app %s not found", appID)
+	}
+	if a.current().version == version {
+		return fmt.Errorf("key version %d of %s is current", version, appID)
+	}
+	for i, k := range a.keys {
+		if k.version == version {
+			a.keys = append(a.keys[:i:i], a.keys[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("key version %d of %s not found", version, appID)
+}
+
+// SetVoting makes the peers of network the voting targets of an app; a peer with the app's own
+// ID stands for the app's local vote. groups may be nil
+func (d *Deployment) SetVoting(appID string, network *votingtest.Network, requiredVotes int, groups map[string][]string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	a, ok := d.apps[appID]
+	if !ok {
+		return fmt.Errorf("app %s not found", appID)
+	}
+	a.network, a.requiredVotes, a.groups = network, requiredVotes, groups
+	d.publish(&appid.AppEvent{Type: appid.AppEventType_APP_EVENT_TYPE_VOTING_CONFIG_CHANGED, AppId: appID, Timestamp: time.Now().Unix()})
+	return nil
+}
+
+// SetSigningPolicy sets the signing policy the App node returns for an app; nil removes it
+func (d *Deployment) SetSigningPolicy(appID string, policy *appid.SigningPolicy) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	a, ok := d.apps[appID]
+	if !ok {
+		return fmt.Errorf("app %s not found", appID)
+	}
+	a.policy = policy
+	return nil
+}
+
+// SetKeyUsage restricts the operations the App node reports an app's key may be used for; zero
+// leaves it unrestricted. The TEE node signs regardless, like one that trusts the client to check
+func (d *Deployment) SetKeyUsage(appID string, usage constants.KeyUsage) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	a, ok := d.apps[appID]
+	if !ok {
+		return fmt.Errorf("app %s not found", appID)
+	}
+	a.usage = usage
+	return nil
+}
+
+// FailSign makes the TEE node fail later sign requests with err, a gRPC status error or any
+// error to report in the response; nil restores signing
+func (d *Deployment) FailSign(err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.failSign = err
+}
+
+// CorruptSignatures makes the TEE node return signatures with a flipped bit, as a faulty or
+// malicious node would; false restores valid signatures
+func (d *Deployment) CorruptSignatures(corrupt bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.corrupt = corrupt
+}
+
+// SignRequests returns the sign requests the TEE node received, in order
+func (d *Deployment) SignRequests() []*pb.SignRequest {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]*pb.SignRequest(nil), d.requests...)
+}
+
+// PublicKeyLookups returns how many times the App node was asked for an app's public key
+func (d *Deployment) PublicKeyLookups(appID string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.lookups[appID]
+}
+
+// PublishEvent sends an event to the subscribers watching its app. Key rotations and SetVoting
+// publish their own events
+func (d *Deployment) PublishEvent(event *appid.AppEvent) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.publish(event)
+}
+
+// publish queues event for matching subscribers, dropping it for any whose queue is full
+// The caller holds d.mu
+func (d *Deployment) publish(event *appid.AppEvent) {
+	for sub := range d.subscribers {
+		if len(sub.appIDs) > 0 && !slices.Contains(sub.appIDs, event.AppId) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+		}
+	}
+}
+
+// Subscribers returns how many SubscribeEvents streams are open
+func (d *Deployment) Subscribers() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.subscribers)
+}
+
+// Close stops both nodes
+func (d *Deployment) Close() {
+	d.tee.Stop()
+	d.appNode.Stop()
+}
+
+// teeNode signs with the key of whichever app the request's public key belongs to
+type teeNode struct {
+	pb.UnimplementedUserTaskServer
+	d *Deployment
+}
+
+func (n *teeNode) Sign(ctx context.Context, req *pb.SignRequest) (*pb.SignResponse, error) {
+	n.d.mu.Lock()
+	n.d.requests = append(n.d.requests, req)
+	failSign, corrupt := n.d.failSign, n.d.corrupt
+	signer := n.d.lookupKey(req.PublicKeyInfo)
+	n.d.mu.Unlock()
+
+	if failSign != nil {
+		if _, ok := status.FromError(failSign); ok {
+			return nil, failSign
+		}
+		return &pb.SignResponse{Error: failSign.Error()}, nil
+	}
+	if signer == nil {
+		return &pb.SignResponse{Error: "unknown public key"}, nil
+	}
+	if constants.Protocol(req.Protocol) != signer.protocol || constants.Curve(req.Curve) != signer.curve {
+		return &pb.SignResponse{Error: fmt.Sprintf("key is %s/%s", signer.protocol, signer.curve)}, nil
+	}
+	signature, err := signer.sign(req)
+	if err != nil {
+		return &pb.SignResponse{Error: err.Error()}, nil
+	}
+	if corrupt {
+		signature[len(signature)/2] ^= 0x01
+	}
+	return &pb.SignResponse{Success: true, Signature: signature}, nil
+}
+
+// WatchKeyOperation reports key rotations started through the App node, which complete at once
+func (n *teeNode) WatchKeyOperation(req *pb.WatchKeyOperationRequest, stream pb.UserTask_WatchKeyOperationServer) error {
+	n.d.mu.Lock()
+	publicKey, ok := n.d.operations[req.OperationId]
+	n.d.mu.Unlock()
+	if !ok {
+		return status.Errorf(codes.NotFound, "key operation %s not found", req.OperationId)
+	}
+	return stream.Send(&pb.KeyOperationStatus{
+		OperationId:  req.OperationId,
+		Type:         1,
+		State:        2,
+		Participants: []*pb.ParticipantProgress{{Id: NodeID, State: 2}},
+		PublicKey:    publicKey,
+		UpdatedAt:    time.Now().Unix(),
+	})
+}
+
+// lookupKey finds the key with a public key among every version of every app's key
+// The caller holds d.mu
+func (d *Deployment) lookupKey(publicKey []byte) *key {
+	for _, a := range d.apps {
+		for _, k := range a.keys {
+			if string(k.publicKey) == string(publicKey) {
+				return k
+			}
+		}
+	}
+	return nil
+}
+
+// appNode serves app keys, voting configurations and signing policies
+type appNode struct {
+	appid.UnimplementedAppIDServiceServer
+	d *Deployment
+}
+
+// lookupApp returns a copy of an app's registration
+func (n *appNode) lookupApp(appID string) (app, error) {
+	n.d.mu.Lock()
+	defer n.d.mu.Unlock()
+	a, ok := n.d.apps[appID]
+	if !ok {
+		return app{}, status.Errorf(codes.NotFound, "app %s not found", appID)
+	}
+	copied := *a
+	copied.keys = append([]*key(nil), a.keys...)
+	return copied, nil
+}
+
+func (n *appNode) GetPublicKeyByAppID(ctx context.Context, req *appid.GetPublicKeyByAppIDRequest) (*appid.GetPublicKeyByAppIDResponse, error) {
+	n.d.mu.Lock()
+	n.d.lookups[req.AppId]++
+	n.d.mu.Unlock()
+	a, err := n.lookupApp(req.AppId)
+	if err != nil {
+		return nil, err
+	}
+	k := a.current()
+	if req.KeyVersion != 0 || req.ValidAt != 0 {
+		k = nil
+		for _, candidate := range a.keys {
+			if req.KeyVersion != 0 && candidate.version != req.KeyVersion {
+				continue
+			}
+			if req.ValidAt != 0 && (req.ValidAt < candidate.validFrom || candidate.validUntil != 0 && req.ValidAt >= candidate.validUntil) {
+				continue
+			}
+			k = candidate
+		}
+		if k == nil {
+			return nil, status.Errorf(codes.NotFound, "no matching key for app %s", req.AppId)
+		}
+	}
+	return &appid.GetPublicKeyByAppIDResponse{
+		Publickey:  hex.EncodeToString(k.publicKey),
+		Protocol:   k.protocol.String(),
+		Curve:      k.curve.String(),
+		KeyVersion: k.version,
+		ValidFrom:  k.validFrom,
+		ValidUntil: k.validUntil,
+		KeyUsage:   a.usage.Names(),
+	}, nil
+}
+
+func (n *appNode) GetDeploymentAddresses(ctx context.Context, req *appid.GetDeploymentAddressesRequest) (*appid.GetDeploymentAddressesResponse, error) {
+	a, err := n.lookupApp(req.AppId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &appid.GetDeploymentAddressesResponse{
+		Deployments:    make(map[string]*appid.DeploymentInfo),
+		VotingSignPath: votingtest.VotingSignPath,
+		RequiredVotes:  int32(a.requiredVotes),
+	}
+	if a.network == nil {
+		return resp, nil
+	}
+	for targetAppID, target := range a.network.Targets() {
+		address := net.JoinHostPort(target.ContainerIP, fmt.Sprint(target.ServicePort))
+		resp.Deployments[targetAppID] = &appid.DeploymentInfo{
+			AppId:                   targetAppID,
+			DeploymentHost:          target.HTTPBaseURL,
+			ContainerIp:             target.ContainerIP,
+			ServicePort:             target.ServicePort,
+			DeploymentClientAddress: address,
+		}
+	}
+	names := make([]string, 0, len(a.groups))
+	for name := range a.groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		resp.Groups = append(resp.Groups, &appid.VotingGroup{Name: name, Members: a.groups[name]})
+	}
+	return resp, nil
+}
+
+// RotateKey generates the app's next key version right away, as a completed key operation
+func (n *appNode) RotateKey(ctx context.Context, req *appid.RotateKeyRequest) (*appid.RotateKeyResponse, error) {
+	n.d.mu.Lock()
+	defer n.d.mu.Unlock()
+	if _, ok := n.d.apps[req.AppId]; !ok {
+		return nil, status.Errorf(codes.NotFound, "app %s not found", req.AppId)
+	}
+	k, err := n.d.rotateKey(req.AppId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	operationID := fmt.Sprintf("rotate-%s-%d", req.AppId, k.version)
+	n.d.operations[operationID] = k.publicKey
+	return &appid.RotateKeyResponse{OperationId: operationID}, nil
+}
+
+// SubscribeEvents streams the events published for the requested apps until the client goes away
+func (n *appNode) SubscribeEvents(req *appid.SubscribeEventsRequest, stream appid.AppIDService_SubscribeEventsServer) error {
+	sub := &subscriber{appIDs: req.AppIds, events: make(chan *appid.AppEvent, 64)}
+	n.d.mu.Lock()
+	n.d.subscribers[sub] = struct{}{}
+	n.d.mu.Unlock()
+	defer func() {
+		n.d.mu.Lock()
+		delete(n.d.subscribers, sub)
+		n.d.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-sub.events:
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (n *appNode) GetSigningPolicy(ctx context.Context, req *appid.GetSigningPolicyRequest) (*appid.GetSigningPolicyResponse, error) {
+	a, err := n.lookupApp(req.AppId)
+	if err != nil {
+		return nil, err
+	}
+	return &appid.GetSigningPolicyResponse{Policy: a.policy}, nil
+}
+
+// selfSigned creates a self-signed certificate for the loopback interface, PEM encoded
+func selfSigned(commonName string) (certPEM, keyPEM []byte, err error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		DNSNames:              []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
@@ -0,0 +1,56 @@
+package teetest
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/key_management"
+)
+
+func TestKeySign(t *testing.T) {
+	message := []byte("hello")
+	digest := sha256.Sum256(message)
+	tests := []struct {
+		protocol  constants.Protocol
+		curve     constants.Curve
+		prehashed bool
+	}{
+		{constants.ProtocolECDSA, constants.CurveSECP256K1, false},
+		{constants.ProtocolECDSA, constants.CurveSECP256K1, true},
+		{constants.ProtocolECDSA, constants.CurveSECP256R1, false},
+		{constants.ProtocolECDSA, constants.CurveSECP256R1, true},
+		{constants.ProtocolSchnorr, constants.CurveSECP256K1, false},
+		{constants.ProtocolSchnorr, constants.CurveED25519, false},
+	}
+	for _, tt := range tests {
+		k, err := newKey(tt.protocol, tt.curve)
+		if err != nil {
+			t.Fatalf("newKey(%s, %s) failed: %v", tt.protocol, tt.curve, err)
+		}
+		req := &pb.SignRequest{Msg: message}
+		if tt.prehashed {
+			// A prehashed digest signs like the message it is the SHA-256 of
+			req = &pb.SignRequest{Msg: digest[:], Prehashed: true}
+		}
+		signature, err := k.sign(req)
+		if err != nil {
+			t.Fatalf("%s/%s sign failed: %v", tt.protocol, tt.curve, err)
+		}
+		valid, err := verification.VerifySignature(message, k.publicKey, signature, tt.protocol, tt.curve)
+		if err != nil || !valid {
+			t.Errorf("%s/%s prehashed=%t: signature does not verify (%v)", tt.protocol, tt.curve, tt.prehashed, err)
+		}
+	}
+}
+
+func TestKeySignRejectsPrehashedSchnorr(t *testing.T) {
+	k, err := newKey(constants.ProtocolSchnorr, constants.CurveSECP256K1)
+	if err != nil {
+		t.Fatalf("newKey failed: %v", err)
+	}
+	if _, err := k.sign(&pb.SignRequest{Msg: make([]byte, 32), Prehashed: true}); err == nil {
+		t.Error("Expected a prehashed Schnorr request to be rejected")
+	}
+}
@@ -0,0 +1,135 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package threshold describes the threshold scheme behind an app's key and checks it
+// against an operator's threshold requirements
+package threshold
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/utils"
+	"github.com/TEENet-io/teenet-sdk/go/proto/appid"
+)
+
+// ErrRequirement is matched by errors.Is for keys that don't meet a Requirement
+var ErrRequirement = errors.New("key does not meet threshold requirement")
+
+// Details are the threshold scheme parameters of an app's key
+type Details struct {
+	Key            []byte             `json:"key"`
+	Protocol       constants.Protocol `json:"protocol"`
+	Curve          constants.Curve    `json:"curve"`
+	Scheme         string             `json:"scheme"`              // Threshold scheme, e.g. "frost"
+	SchemeVersion  string             `json:"scheme_version"`      // Version of the scheme implementation that generated the key
+	Threshold      int                `json:"threshold"`           // t: participants needed to produce a signature
+	Participants   int                `json:"participants"`        // n: participants holding a key share
+	ParticipantIDs []uint32           `json:"participant_ids"`     // TEE node IDs holding a key share, sorted
+	CreatedAt      time.Time          `json:"created_at,omitzero"` // Zero if the App node doesn't know
+}
+
+// Requirement is the minimum threshold scheme an operator accepts for a key
+type Requirement struct {
+	MinThreshold    int      // Minimum t; unchecked if zero
+	MinParticipants int      // Minimum n; unchecked if zero
+	Schemes         []string // Accepted schemes, case-insensitive; any if empty
+	Participants    []uint32 // Node IDs allowed to hold a key share; any if empty
+}
+
+// FromProto converts and sanity-checks the key details received from an App node
+func FromProto(resp *appid.GetKeyDetailsResponse) (*Details, error) {
+	if resp == nil {
+		return nil, fmt.Errorf("missing key details")
+	}
+
+	protocol, err := utils.ParseProtocol(resp.Protocol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse protocol: %w", err)
+	}
+	curve, err := utils.ParseCurve(resp.Curve)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse curve: %w", err)
+	}
+	keyHex := resp.Publickey
+	if strings.HasPrefix(keyHex, "0x") || strings.HasPrefix(keyHex, "0X") {
+		keyHex = keyHex[2:]
+	}
+	key, err := hex.DecodeString(keyHex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode public key from hex: %w", err)
+	}
+
+	details := &Details{
+		Key:            key,
+		Protocol:       protocol,
+		Curve:          curve,
+		Scheme:         resp.Scheme,
+		SchemeVersion:  resp.SchemeVersion,
+		Threshold:      int(resp.Threshold),
+		Participants:   int(resp.TotalParticipants),
+		ParticipantIDs: slices.Sorted(slices.Values(resp.ParticipantIds)),
+	}
+	if resp.CreatedAt > 0 {
+		details.CreatedAt = time.Unix(resp.CreatedAt, 0)
+	}
+
+	if details.Threshold < 1 || details.Threshold > details.Participants {
+		return nil, fmt.Errorf("invalid threshold %d-of-%d", details.Threshold, details.Participants)
+	}
+	if len(details.ParticipantIDs) != details.Participants {
+		return nil, fmt.Errorf("%d participant IDs reported for %d participants", len(details.ParticipantIDs), details.Participants)
+	}
+	if len(slices.Compact(slices.Clone(details.ParticipantIDs))) != details.Participants {
+		return nil, fmt.Errorf("duplicate participant IDs %v", details.ParticipantIDs)
+	}
+	return details, nil
+}
+
+// String formats the scheme as e.g. "frost v1 2-of-3"
+func (d *Details) String() string {
+	scheme := d.Scheme
+	if scheme == "" {
+		scheme = "unknown scheme"
+	}
+	if d.SchemeVersion != "" {
+		scheme += " " + d.SchemeVersion
+	}
+	return fmt.Sprintf("%s %d-of-%d", scheme, d.Threshold, d.Participants)
+}
+
+// Check returns an error matching ErrRequirement if the key falls short of req
+func (d *Details) Check(req Requirement) error {
+	if d.Threshold < req.MinThreshold {
+		return fmt.Errorf("%w: threshold %d below minimum %d", ErrRequirement, d.Threshold, req.MinThreshold)
+	}
+	if d.Participants < req.MinParticipants {
+		return fmt.Errorf("%w: %d participants below minimum %d", ErrRequirement, d.Participants, req.MinParticipants)
+	}
+	if len(req.Schemes) > 0 && !slices.ContainsFunc(req.Schemes, func(s string) bool { return strings.EqualFold(s, d.Scheme) }) {
+		return fmt.Errorf("%w: scheme %q not in %v", ErrRequirement, d.Scheme, req.Schemes)
+	}
+	if len(req.Participants) > 0 {
+		for _, id := range d.ParticipantIDs {
+			if !slices.Contains(req.Participants, id) {
+				return fmt.Errorf("%w: participant %d holds a key share but is not allowed", ErrRequirement, id)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package threshold
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/proto/appid"
+)
+
+func keyDetails() *appid.GetKeyDetailsResponse {
+	return &appid.GetKeyDetailsResponse{
+		Publickey:         "0x02aabbcc",
+		Protocol:          "schnorr",
+		Curve:             "secp256k1",
+		Scheme:            "frost",
+		SchemeVersion:     "v1",
+		Threshold:         2,
+		TotalParticipants: 3,
+		ParticipantIds:    []uint32{3, 1, 2},
+		CreatedAt:         1767225600,
+	}
+}
+
+func TestFromProto(t *testing.T) {
+	d, err := FromProto(keyDetails())
+	if err != nil {
+		t.Fatalf("FromProto() error = %v", err)
+	}
+	if d.Protocol != constants.ProtocolSchnorr || d.Curve != constants.CurveSECP256K1 {
+		t.Errorf("protocol, curve = %v, %v", d.Protocol, d.Curve)
+	}
+	if len(d.Key) != 4 || d.Key[0] != 0x02 {
+		t.Errorf("Key = %x", d.Key)
+	}
+	if !slices.Equal(d.ParticipantIDs, []uint32{1, 2, 3}) {
+		t.Errorf("ParticipantIDs = %v, want sorted", d.ParticipantIDs)
+	}
+	if d.CreatedAt.Unix() != 1767225600 {
+		t.Errorf("CreatedAt = %s", d.CreatedAt)
+	}
+	if got := d.String(); got != "frost v1 2-of-3" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestFromProtoInconsistent(t *testing.T) {
+	tests := map[string]func(*appid.GetKeyDetailsResponse){
+		"zero threshold":        func(r *appid.GetKeyDetailsResponse) { r.Threshold = 0 },
+		"threshold above n":     func(r *appid.GetKeyDetailsResponse) { r.Threshold = 4 },
+		"missing participant":   func(r *appid.GetKeyDetailsResponse) { r.ParticipantIds = []uint32{1, 2} },
+		"duplicate participant": func(r *appid.GetKeyDetailsResponse) { r.ParticipantIds = []uint32{1, 2, 2} },
+		"bad key":               func(r *appid.GetKeyDetailsResponse) { r.Publickey = "zz" },
+	}
+	for name, mutate := range tests {
+		resp := keyDetails()
+		mutate(resp)
+		if _, err := FromProto(resp); err == nil {
+			t.Errorf("%s: FromProto() succeeded", name)
+		}
+	}
+	if _, err := FromProto(nil); err == nil {
+		t.Error("FromProto(nil) succeeded")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	d, err := FromProto(keyDetails())
+	if err != nil {
+		t.Fatalf("FromProto() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  Requirement
+		ok   bool
+	}{
+		{"no requirement", Requirement{}, true},
+		{"met", Requirement{MinThreshold: 2, MinParticipants: 3, Schemes: []string{"FROST"}, Participants: []uint32{1, 2, 3, 4}}, true},
+		{"threshold too low", Requirement{MinThreshold: 3}, false},
+		{"too few participants", Requirement{MinParticipants: 5}, false},
+		{"scheme not accepted", Requirement{Schemes: []string{"gg20"}}, false},
+		{"unknown participant", Requirement{Participants: []uint32{1, 2}}, false},
+	}
+	for _, tt := range tests {
+		err := d.Check(tt.req)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: Check() error = %v, want ok %v", tt.name, err, tt.ok)
+		}
+		if err != nil && !errors.Is(err, ErrRequirement) {
+			t.Errorf("%s: Check() error %v does not match ErrRequirement", tt.name, err)
+		}
+	}
+}
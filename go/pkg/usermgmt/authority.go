@@ -0,0 +1,190 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package usermgmt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/proto/appid"
+)
+
+// votingConfigDomain separates voting configuration signatures from anything else the
+// authority key might sign
+const votingConfigDomain = "teenet-voting-config-v1"
+
+// ErrVotingConfigSignature is matched by errors.Is for voting configurations that are unsigned,
+// signed by an unknown key or altered after signing
+var ErrVotingConfigSignature = errors.New("invalid voting configuration signature")
+
+// VotingAuthority verifies that voting configurations were signed by a pinned authority key,
+// so targets and required votes can't be changed in transit
+type VotingAuthority struct {
+	keys   []crypto.PublicKey
+	maxAge time.Duration
+	now    func() time.Time
+}
+
+// NewVotingAuthority pins keys, each a PEM public key (Ed25519 or ECDSA) or a hex Ed25519 key
+// A configuration signed by any of them is accepted. With maxAge set, configurations signed
+// longer ago are refused, which bounds how long a captured configuration can be replayed
+func NewVotingAuthority(keys []string, maxAge time.Duration) (*VotingAuthority, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("voting authority requires a key")
+	}
+	authority := &VotingAuthority{maxAge: maxAge, now: time.Now}
+	for i, key := range keys {
+		publicKey, err := ParseAuthorityKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid voting authority key %d: %w", i, err)
+		}
+		authority.keys = append(authority.keys, publicKey)
+	}
+	return authority, nil
+}
+
+// ParseAuthorityKey parses a PEM public key (Ed25519 or ECDSA) or a hex Ed25519 key
+func ParseAuthorityKey(key string) (crypto.PublicKey, error) {
+	key = strings.TrimSpace(key)
+	if block, _ := pem.Decode([]byte(key)); block != nil {
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		switch publicKey.(type) {
+		case ed25519.PublicKey, *ecdsa.PublicKey:
+			return publicKey, nil
+		default:
+			return nil, fmt.Errorf("unsupported key type %T", publicKey)
+		}
+	}
+	raw, err := hex.DecodeString(key)
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("want a PEM public key or a hex Ed25519 key")
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// signedVotingConfig is the canonical form of a voting configuration that is signed
+type signedVotingConfig struct {
+	Domain         string                      `json:"domain"`
+	AppID          string                      `json:"app_id"`
+	Deployments    map[string]signedDeployment `json:"deployments"`
+	NotFound       []string                    `json:"not_found"`
+	VotingSignPath string                      `json:"voting_sign_path"`
+	RequiredVotes  int32                       `json:"required_votes"`
+	Groups         map[string][]string         `json:"groups"`
+	IssuedAt       int64                       `json:"issued_at"`
+}
+
+// signedDeployment is the part of a deployment that decides where vote requests go
+type signedDeployment struct {
+	ContainerIP             string `json:"container_ip"`
+	DeploymentClientAddress string `json:"deployment_client_address"`
+	DeploymentHost          string `json:"deployment_host"`
+	ServicePort             int32  `json:"service_port"`
+}
+
+// VotingConfigDigest returns the SHA-256 digest the voting authority signs for the voting
+// configuration of appID: a JSON document with sorted keys, lists and group members covering
+// every field that routes or counts votes
+func VotingConfigDigest(appID string, resp *appid.GetDeploymentAddressesResponse) []byte {
+	config := signedVotingConfig{
+		Domain:         votingConfigDomain,
+		AppID:          appID,
+		Deployments:    make(map[string]signedDeployment, len(resp.Deployments)),
+		NotFound:       slices.Sorted(slices.Values(resp.NotFound)),
+		VotingSignPath: resp.VotingSignPath,
+		RequiredVotes:  resp.RequiredVotes,
+		Groups:         make(map[string][]string, len(resp.Groups)),
+		IssuedAt:       resp.IssuedAt,
+	}
+	for targetAppID, deployment := range resp.Deployments {
+		config.Deployments[targetAppID] = signedDeployment{
+			ContainerIP:             deployment.ContainerIp,
+			DeploymentClientAddress: deployment.DeploymentClientAddress,
+			DeploymentHost:          deployment.DeploymentHost,
+			ServicePort:             deployment.ServicePort,
+		}
+	}
+	for _, group := range resp.Groups {
+		config.Groups[group.Name] = slices.Sorted(slices.Values(group.Members))
+	}
+	data, _ := json.Marshal(config) // Maps and strings only, which always encode
+	digest := sha256.Sum256(data)
+	return digest[:]
+}
+
+// SignVotingConfig sets IssuedAt and the signature of resp, as App nodes do for the voting
+// configuration of appID. The signer must hold an Ed25519 or ECDSA key
+func SignVotingConfig(signer crypto.Signer, appID string, resp *appid.GetDeploymentAddressesResponse) error {
+	resp.IssuedAt = time.Now().Unix()
+	digest := VotingConfigDigest(appID, resp)
+	var err error
+	switch signer.Public().(type) {
+	case ed25519.PublicKey:
+		resp.Signature, err = signer.Sign(rand.Reader, digest, crypto.Hash(0))
+	case *ecdsa.PublicKey:
+		resp.Signature, err = signer.Sign(rand.Reader, digest, crypto.SHA256)
+	default:
+		return fmt.Errorf("unsupported voting authority key type %T", signer.Public())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to sign voting configuration: %w", err)
+	}
+	return nil
+}
+
+// Verify checks the signature and age of the voting configuration of appID
+func (a *VotingAuthority) Verify(appID string, resp *appid.GetDeploymentAddressesResponse) error {
+	if len(resp.Signature) == 0 {
+		return fmt.Errorf("%w: voting configuration of %s is unsigned", ErrVotingConfigSignature, appID)
+	}
+	digest := VotingConfigDigest(appID, resp)
+	if !slices.ContainsFunc(a.keys, func(key crypto.PublicKey) bool { return verifyDigest(key, digest, resp.Signature) }) {
+		return fmt.Errorf("%w: voting configuration of %s not signed by a pinned authority key", ErrVotingConfigSignature, appID)
+	}
+	if a.maxAge > 0 {
+		issued := time.Unix(resp.IssuedAt, 0)
+		if age := a.now().Sub(issued); age > a.maxAge {
+			return fmt.Errorf("%w: voting configuration of %s signed %s ago, longer than %s",
+				ErrVotingConfigSignature, appID, age.Round(time.Second), a.maxAge)
+		}
+	}
+	return nil
+}
+
+// verifyDigest verifies a signature over digest with an Ed25519 or ECDSA key
+func verifyDigest(key crypto.PublicKey, digest, signature []byte) bool {
+	switch key := key.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, digest, signature)
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest, signature)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,134 @@
+package usermgmt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/proto/appid"
+	"google.golang.org/protobuf/proto"
+)
+
+func testVotingConfig() *appid.GetDeploymentAddressesResponse {
+	return &appid.GetDeploymentAddressesResponse{
+		Deployments: map[string]*appid.DeploymentInfo{
+			"app-b": {AppId: "app-b", ContainerIp: "10.0.0.2", DeploymentClientAddress: "10.0.0.1:50054", ServicePort: 8081},
+			"app-c": {AppId: "app-c", ContainerIp: "10.0.0.3", DeploymentClientAddress: "10.0.0.1:50054", ServicePort: 8082},
+		},
+		VotingSignPath: "/vote",
+		RequiredVotes:  2,
+		Groups:         []*appid.VotingGroup{{Name: "finance", Members: []string{"app-c", "app-b"}}},
+	}
+}
+
+func publicPEM(t *testing.T, key crypto.PublicKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey failed: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestVotingAuthorityVerify(t *testing.T) {
+	edPublic, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	authority, err := NewVotingAuthority([]string{hex.EncodeToString(edPublic), publicPEM(t, &ecKey.PublicKey)}, 0)
+	if err != nil {
+		t.Fatalf("NewVotingAuthority failed: %v", err)
+	}
+
+	for name, signer := range map[string]crypto.Signer{"ed25519": edKey, "ecdsa": ecKey} {
+		resp := testVotingConfig()
+		if err := SignVotingConfig(signer, "app-a", resp); err != nil {
+			t.Fatalf("%s: SignVotingConfig failed: %v", name, err)
+		}
+		if err := authority.Verify("app-a", resp); err != nil {
+			t.Errorf("%s: Verify failed: %v", name, err)
+		}
+	}
+
+	resp := testVotingConfig()
+	if err := authority.Verify("app-a", resp); !errors.Is(err, ErrVotingConfigSignature) {
+		t.Errorf("Expected unsigned configuration to be refused, got %v", err)
+	}
+	SignVotingConfig(otherKey, "app-a", resp)
+	if err := authority.Verify("app-a", resp); !errors.Is(err, ErrVotingConfigSignature) {
+		t.Errorf("Expected unknown key to be refused, got %v", err)
+	}
+}
+
+func TestVotingAuthorityTampering(t *testing.T) {
+	public, key, _ := ed25519.GenerateKey(rand.Reader)
+	authority, _ := NewVotingAuthority([]string{hex.EncodeToString(public)}, 0)
+
+	tests := []struct {
+		name   string
+		appID  string
+		tamper func(*appid.GetDeploymentAddressesResponse)
+	}{
+		{"required votes", "app-a", func(r *appid.GetDeploymentAddressesResponse) { r.RequiredVotes = 1 }},
+		{"target address", "app-a", func(r *appid.GetDeploymentAddressesResponse) { r.Deployments["app-b"].ContainerIp = "6.6.6.6" }},
+		{"target removed", "app-a", func(r *appid.GetDeploymentAddressesResponse) { delete(r.Deployments, "app-c") }},
+		{"group member", "app-a", func(r *appid.GetDeploymentAddressesResponse) { r.Groups[0].Members = []string{"app-b"} }},
+		{"sign path", "app-a", func(r *appid.GetDeploymentAddressesResponse) { r.VotingSignPath = "/evil" }},
+		{"issued at", "app-a", func(r *appid.GetDeploymentAddressesResponse) { r.IssuedAt++ }},
+		{"other app", "app-x", func(r *appid.GetDeploymentAddressesResponse) {}},
+	}
+	for _, tt := range tests {
+		resp := testVotingConfig()
+		if err := SignVotingConfig(key, "app-a", resp); err != nil {
+			t.Fatalf("SignVotingConfig failed: %v", err)
+		}
+		tt.tamper(resp)
+		if err := authority.Verify(tt.appID, resp); !errors.Is(err, ErrVotingConfigSignature) {
+			t.Errorf("%s: expected ErrVotingConfigSignature, got %v", tt.name, err)
+		}
+	}
+
+	// Order of lists and group members doesn't matter
+	resp := testVotingConfig()
+	SignVotingConfig(key, "app-a", resp)
+	reordered := proto.Clone(resp).(*appid.GetDeploymentAddressesResponse)
+	reordered.Groups[0].Members = []string{"app-b", "app-c"}
+	if err := authority.Verify("app-a", reordered); err != nil {
+		t.Errorf("Reordered members should verify: %v", err)
+	}
+}
+
+func TestVotingAuthorityMaxAge(t *testing.T) {
+	public, key, _ := ed25519.GenerateKey(rand.Reader)
+	authority, _ := NewVotingAuthority([]string{hex.EncodeToString(public)}, time.Minute)
+
+	resp := testVotingConfig()
+	SignVotingConfig(key, "app-a", resp)
+	if err := authority.Verify("app-a", resp); err != nil {
+		t.Fatalf("Fresh configuration should verify: %v", err)
+	}
+	authority.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	if err := authority.Verify("app-a", resp); !errors.Is(err, ErrVotingConfigSignature) {
+		t.Errorf("Expected stale configuration to be refused, got %v", err)
+	}
+}
+
+func TestParseAuthorityKey(t *testing.T) {
+	rsaPEM := "-----BEGIN PUBLIC KEY-----\nMFwwDQYJKoZIhvcNAQEBBQADSwAwSAJBAL3QXdGlzIGlzIG5vdCBhIHJlYWwga2V5IGJ1dCBpdCBwYXJzZXMgZmluZSEhAgMBAAE=\n-----END PUBLIC KEY-----\n"
+	for _, key := range []string{"", "zz", hex.EncodeToString(make([]byte, 31)), rsaPEM} {
+		if _, err := ParseAuthorityKey(key); err == nil {
+			t.Errorf("Expected error for %q", key)
+		}
+	}
+	if _, err := NewVotingAuthority(nil, 0); err == nil {
+		t.Error("Expected error without keys")
+	}
+}
@@ -19,20 +19,47 @@ import (
 	"crypto/tls"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 
 	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/utils"
 	"github.com/TEENet-io/teenet-sdk/go/proto/appid"
 )
 
 // Client handles gRPC communication with the user management system
+// With several App nodes configured, calls go to the first reachable node in order and
+// fail over to the next one when a node returns UNAVAILABLE
 type Client struct {
-	conn       *grpc.ClientConn
-	client     appid.AppIDServiceClient
+	mu         sync.RWMutex // guards nodes
+	nodes      []*appNode
 	serverAddr string
+
+	reconnectHook func()
+	dialOptions   []grpc.DialOption
+	authority     *VotingAuthority
+}
+
+// appNode is the connection to a single App node
+type appNode struct {
+	address string
+	conn    *grpc.ClientConn
+	client  appid.AppIDServiceClient
+
+	mu               sync.Mutex
+	unavailableUntil time.Time // set when the node returned UNAVAILABLE
+}
+
+// Target is an App node address with the TLS configuration used to reach it
+type Target struct {
+	Address   string
+	TLSConfig *tls.Config
 }
 
 // DeploymentTarget contains deployment information for voting requests
@@ -54,66 +81,176 @@ func NewClient(serverAddr string) *Client {
 
 // Connect establishes gRPC connection to user management service
 func (c *Client) Connect(ctx context.Context, tlsConfig *tls.Config) error {
-	// gRPC connection options with TLS and retry configuration
-	if c.conn != nil {
-		c.conn.Close()
+	return c.ConnectNodes(ctx, []Target{{Address: c.serverAddr, TLSConfig: tlsConfig}})
+}
+
+// ConnectNodes connects to several App nodes, in order of preference, replacing any existing connections
+func (c *Client) ConnectNodes(ctx context.Context, targets []Target) error {
+	if len(targets) == 0 {
+		return fmt.Errorf("no user management nodes to connect to")
 	}
 
-	// gRPC connection options with TLS and retry configuration
-	creds := credentials.NewTLS(tlsConfig)
+	nodes := make([]*appNode, 0, len(targets))
+	for _, target := range targets {
+		// gRPC connection options with TLS and retry configuration
+		opts := []grpc.DialOption{
+			grpc.WithTransportCredentials(credentials.NewTLS(target.TLSConfig)),
+			grpc.WithDefaultServiceConfig(constants.GRPCRetryPolicy),
+		}
+		opts = append(opts, c.dialOptions...)
 
-	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(creds),
-		grpc.WithDefaultServiceConfig(constants.GRPCRetryPolicy),
+		conn, err := grpc.NewClient(utils.GRPCTarget(target.Address), opts...)
+		if err != nil {
+			for _, n := range nodes {
+				n.conn.Close()
+			}
+			return fmt.Errorf("failed to connect to user management service: %w", err)
+		}
+		if c.reconnectHook != nil {
+			utils.WatchReconnects(conn, c.reconnectHook)
+		}
+		nodes = append(nodes, &appNode{address: target.Address, conn: conn, client: appid.NewAppIDServiceClient(conn)})
 	}
 
-	conn, err := grpc.NewClient(c.serverAddr, opts...)
-	if err != nil {
-		return fmt.Errorf("failed to connect to user management service: %w", err)
+	c.mu.Lock()
+	old := c.nodes
+	c.nodes = nodes
+	c.mu.Unlock()
+	for _, n := range old {
+		n.conn.Close()
 	}
-
-	c.conn = conn
-	c.client = appid.NewAppIDServiceClient(conn)
 	return nil
 }
 
-// Close closes the gRPC connection
+// SetDialOptions sets extra gRPC dial options (e.g. interceptors) applied on Connect
+func (c *Client) SetDialOptions(opts ...grpc.DialOption) {
+	c.dialOptions = opts
+}
+
+// SetVotingAuthority requires voting configurations to be signed by the authority; nil accepts
+// unsigned ones. It must be set before Connect
+func (c *Client) SetVotingAuthority(authority *VotingAuthority) {
+	c.authority = authority
+}
+
+// SetReconnectHook sets a function called whenever the connection is re-established
+// It must be set before Connect
+func (c *Client) SetReconnectHook(hook func()) {
+	c.reconnectHook = hook
+}
+
+// State returns the best connectivity state across App nodes, or Shutdown if not connected
+func (c *Client) State() connectivity.State {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	best := connectivity.Shutdown
+	for _, n := range c.nodes {
+		if state := n.conn.GetState(); stateRank(state) > stateRank(best) {
+			best = state
+		}
+	}
+	return best
+}
+
+// Close closes the gRPC connections
 func (c *Client) Close() error {
-	if c.conn != nil {
-		return c.conn.Close()
+	c.mu.Lock()
+	nodes := c.nodes
+	c.nodes = nil
+	c.mu.Unlock()
+
+	var firstErr error
+	for _, n := range nodes {
+		if err := n.conn.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
 	}
-	return nil
+	return firstErr
 }
 
-// GetPublicKeyByAppID retrieves public key by app ID via gRPC
-func (c *Client) GetPublicKeyByAppID(ctx context.Context, appID string) (string, string, string, error) {
-	if c.client == nil {
-		return "", "", "", fmt.Errorf("client not connected")
+// call runs fn against the first healthy App node, failing over to the next on UNAVAILABLE
+// Nodes that recently returned UNAVAILABLE are tried last
+func (c *Client) call(ctx context.Context, fn func(appid.AppIDServiceClient) error) error {
+	c.mu.RLock()
+	nodes := c.nodes
+	c.mu.RUnlock()
+	if len(nodes) == 0 {
+		return fmt.Errorf("client not connected")
+	}
+
+	now := time.Now()
+	ordered := make([]*appNode, 0, len(nodes))
+	var cooling []*appNode
+	for _, n := range nodes {
+		n.mu.Lock()
+		available := !now.Before(n.unavailableUntil)
+		n.mu.Unlock()
+		if available {
+			ordered = append(ordered, n)
+		} else {
+			cooling = append(cooling, n)
+		}
+	}
+	ordered = append(ordered, cooling...)
+
+	var err error
+	for _, n := range ordered {
+		err = fn(n.client)
+		if status.Code(err) != codes.Unavailable {
+			return err
+		}
+		n.mu.Lock()
+		n.unavailableUntil = time.Now().Add(constants.AppNodeCooldown)
+		n.mu.Unlock()
+		if len(nodes) > 1 {
+			log.Printf("⚠️  App node %s unavailable, failing over: %v", n.address, err)
+		}
+		if ctx.Err() != nil {
+			break
+		}
 	}
+	return err
+}
+
+// GetPublicKeyByAppID retrieves the current public key, protocol, curve and key usage of an app ID via gRPC
+func (c *Client) GetPublicKeyByAppID(ctx context.Context, appID string) (*appid.GetPublicKeyByAppIDResponse, error) {
+	return c.GetPublicKeyVersion(ctx, appID, 0, time.Time{})
+}
 
+// GetPublicKeyVersion retrieves a historical public key of an app ID via gRPC: the given version,
+// or the key that was current at validAt. Zero values select the current key
+func (c *Client) GetPublicKeyVersion(ctx context.Context, appID string, version uint32, validAt time.Time) (*appid.GetPublicKeyByAppIDResponse, error) {
 	req := &appid.GetPublicKeyByAppIDRequest{
-		AppId: appID,
+		AppId:      appID,
+		KeyVersion: version,
+	}
+	if !validAt.IsZero() {
+		req.ValidAt = validAt.Unix()
 	}
 
-	resp, err := c.client.GetPublicKeyByAppID(ctx, req)
+	var resp *appid.GetPublicKeyByAppIDResponse
+	err := c.call(ctx, func(client appid.AppIDServiceClient) (err error) {
+		resp, err = client.GetPublicKeyByAppID(ctx, req)
+		return err
+	})
 	if err != nil {
-		return "", "", "", fmt.Errorf("failed to get public key: %w", err)
+		return nil, fmt.Errorf("failed to get public key: %w", err)
 	}
 
-	return resp.Publickey, resp.Protocol, resp.Curve, nil
+	return resp, nil
 }
 
 // GetDeploymentAddresses retrieves deployment addresses for given app ID via gRPC
 func (c *Client) GetDeploymentAddresses(ctx context.Context, appID string) (*appid.GetDeploymentAddressesResponse, error) {
-	if c.client == nil {
-		return nil, fmt.Errorf("client not connected")
-	}
-
 	req := &appid.GetDeploymentAddressesRequest{
 		AppId: appID,
 	}
 
-	resp, err := c.client.GetDeploymentAddresses(ctx, req)
+	var resp *appid.GetDeploymentAddressesResponse
+	err := c.call(ctx, func(client appid.AppIDServiceClient) (err error) {
+		resp, err = client.GetDeploymentAddresses(ctx, req)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get deployment addresses: %w", err)
 	}
@@ -121,21 +258,135 @@ func (c *Client) GetDeploymentAddresses(ctx context.Context, appID string) (*app
 	return resp, nil
 }
 
+// SubscribeEvents opens a stream of change events for the given app IDs (all apps if none)
+func (c *Client) SubscribeEvents(ctx context.Context, appIDs []string) (appid.AppIDService_SubscribeEventsClient, error) {
+	req := &appid.SubscribeEventsRequest{
+		AppIds: appIDs,
+	}
+
+	var stream appid.AppIDService_SubscribeEventsClient
+	err := c.call(ctx, func(client appid.AppIDServiceClient) (err error) {
+		stream, err = client.SubscribeEvents(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to events: %w", err)
+	}
+
+	return stream, nil
+}
+
+// GetSigningPolicy retrieves the signing policy of an app ID via gRPC, nil if it has none
+func (c *Client) GetSigningPolicy(ctx context.Context, appID string) (*appid.SigningPolicy, error) {
+	req := &appid.GetSigningPolicyRequest{
+		AppId: appID,
+	}
+
+	var resp *appid.GetSigningPolicyResponse
+	err := c.call(ctx, func(client appid.AppIDServiceClient) (err error) {
+		resp, err = client.GetSigningPolicy(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signing policy: %w", err)
+	}
+
+	return resp.Policy, nil
+}
+
+// GetKeyDetails retrieves the threshold scheme parameters of an app ID's key via gRPC
+func (c *Client) GetKeyDetails(ctx context.Context, appID string) (*appid.GetKeyDetailsResponse, error) {
+	req := &appid.GetKeyDetailsRequest{
+		AppId: appID,
+	}
+
+	var resp *appid.GetKeyDetailsResponse
+	err := c.call(ctx, func(client appid.AppIDServiceClient) (err error) {
+		resp, err = client.GetKeyDetails(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key details: %w", err)
+	}
+
+	return resp, nil
+}
+
+// RotateKey starts generating a new key for an app ID via gRPC, returning the DKG operation ID
+func (c *Client) RotateKey(ctx context.Context, appID string) (string, error) {
+	req := &appid.RotateKeyRequest{
+		AppId: appID,
+	}
+
+	var resp *appid.RotateKeyResponse
+	err := c.call(ctx, func(client appid.AppIDServiceClient) (err error) {
+		resp, err = client.RotateKey(ctx, req)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to rotate key: %w", err)
+	}
+
+	return resp.OperationId, nil
+}
+
+// stateRank orders connectivity states from least to most usable
+func stateRank(state connectivity.State) int {
+	switch state {
+	case connectivity.Ready:
+		return 4
+	case connectivity.Idle:
+		return 3
+	case connectivity.Connecting:
+		return 2
+	case connectivity.TransientFailure:
+		return 1
+	default:
+		return 0
+	}
+}
+
 // GetDeploymentTargetsForVotingSign gets deployment targets for voting sign based on a single app ID
 // It returns all target app IDs configured for the voting sign project
 func (c *Client) GetDeploymentTargetsForVotingSign(appID string, timeout time.Duration) (map[string]*DeploymentTarget, string, int32, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
+	return c.GetDeploymentTargetsForVotingSignContext(ctx, appID)
+}
+
+// VotingSignConfig is the voting configuration of an app as delivered by the deployment-targets RPC
+type VotingSignConfig struct {
+	Targets        map[string]*DeploymentTarget // Deployed targets by app ID
+	VotingSignPath string
+	RequiredVotes  int32
+	Groups         map[string][]string // Group name -> member app IDs; each group needs at least one approval
+}
+
+// GetDeploymentTargetsForVotingSignContext is GetDeploymentTargetsForVotingSign bounded by ctx instead of a timeout
+func (c *Client) GetDeploymentTargetsForVotingSignContext(ctx context.Context, appID string) (map[string]*DeploymentTarget, string, int32, error) {
+	config, err := c.GetVotingSignConfig(ctx, appID)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	return config.Targets, config.VotingSignPath, config.RequiredVotes, nil
+}
+
+// GetVotingSignConfig gets the deployment targets, required votes and voting groups for an app ID
+func (c *Client) GetVotingSignConfig(ctx context.Context, appID string) (*VotingSignConfig, error) {
 	resp, err := c.GetDeploymentAddresses(ctx, appID)
 	if err != nil {
-		return nil, "", 0, fmt.Errorf("failed to get deployment info: %w", err)
+		return nil, fmt.Errorf("failed to get deployment info: %w", err)
+	}
+	if c.authority != nil {
+		if err := c.authority.Verify(appID, resp); err != nil {
+			return nil, err
+		}
 	}
 
 	deployments := resp.Deployments
 	notFound := resp.NotFound
 	votingSignPath := resp.VotingSignPath
-	requiredVotes := resp.RequiredVotes
 
 	result := make(map[string]*DeploymentTarget)
 
@@ -160,5 +411,24 @@ func (c *Client) GetDeploymentTargetsForVotingSign(appID string, timeout time.Du
 		log.Printf("⚠️  App IDs not found or not deployed: %v", notFound)
 	}
 
-	return result, votingSignPath, requiredVotes, nil
+	var groups map[string][]string
+	if len(resp.Groups) > 0 {
+		groups = make(map[string][]string, len(resp.Groups))
+		for _, group := range resp.Groups {
+			if group.Name == "" {
+				return nil, fmt.Errorf("voting group without a name")
+			}
+			if _, dup := groups[group.Name]; dup {
+				return nil, fmt.Errorf("duplicate voting group %q", group.Name)
+			}
+			groups[group.Name] = group.Members
+		}
+	}
+
+	return &VotingSignConfig{
+		Targets:        result,
+		VotingSignPath: votingSignPath,
+		RequiredVotes:  resp.RequiredVotes,
+		Groups:         groups,
+	}, nil
 }
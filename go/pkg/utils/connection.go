@@ -0,0 +1,59 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package utils
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// GRPCTarget converts an address into a gRPC target
+// Absolute paths are treated as unix domain sockets; host:port and scheme-qualified
+// targets such as "unix:///run/tee.sock" are passed through unchanged
+func GRPCTarget(address string) string {
+	if strings.HasPrefix(address, "/") {
+		return "unix://" + address
+	}
+	return address
+}
+
+// WatchReconnects calls onReconnect every time conn returns to READY after having lost
+// a previously established connection. It stops when the connection is closed
+func WatchReconnects(conn *grpc.ClientConn, onReconnect func()) {
+	go func() {
+		wasReady := false
+		lost := false
+		state := conn.GetState()
+		for state != connectivity.Shutdown {
+			switch {
+			case state == connectivity.Ready:
+				if lost {
+					onReconnect()
+				}
+				wasReady = true
+				lost = false
+			case wasReady && state != connectivity.Idle:
+				// Idle is a normal inactivity timeout, not a lost connection
+				lost = true
+			}
+			if !conn.WaitForStateChange(context.Background(), state) {
+				return
+			}
+			state = conn.GetState()
+		}
+	}()
+}
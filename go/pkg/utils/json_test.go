@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONField(t *testing.T) {
+	data := []byte(`{"type":"transfer","transfer":{"amount":123456789012345678901234567890,"asset":"ETH"}}`)
+
+	if value, ok := JSONField(data, "type"); !ok || value != "transfer" {
+		t.Errorf("JSONField(type) = %v, %v", value, ok)
+	}
+	value, ok := JSONField(data, "transfer.amount")
+	if !ok {
+		t.Fatalf("JSONField(transfer.amount) not found")
+	}
+	if number, isNumber := value.(json.Number); !isNumber || number.String() != "123456789012345678901234567890" {
+		t.Errorf("JSONField(transfer.amount) = %#v, want exact json.Number", value)
+	}
+
+	for _, path := range []string{"missing", "transfer.missing", "type.nested"} {
+		if _, ok := JSONField(data, path); ok {
+			t.Errorf("JSONField(%s) found", path)
+		}
+	}
+	if _, ok := JSONField([]byte("not json"), "type"); ok {
+		t.Errorf("JSONField on invalid JSON found a value")
+	}
+}
@@ -20,8 +20,8 @@ import (
 	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
 )
 
-// ParseProtocol converts protocol string to uint32
-func ParseProtocol(protocol string) (uint32, error) {
+// ParseProtocol converts protocol string to constants.Protocol
+func ParseProtocol(protocol string) (constants.Protocol, error) {
 	switch protocol {
 	case "schnorr":
 		return constants.ProtocolSchnorr, nil
@@ -29,14 +29,14 @@ func ParseProtocol(protocol string) (uint32, error) {
 		return constants.ProtocolECDSA, nil
 	default:
 		if num, err := strconv.ParseUint(protocol, 10, 32); err == nil {
-			return uint32(num), nil
+			return constants.Protocol(num), nil
 		}
 		return constants.ProtocolSchnorr, nil // Default to schnorr
 	}
 }
 
-// ParseCurve converts curve string to uint32
-func ParseCurve(curve string) (uint32, error) {
+// ParseCurve converts curve string to constants.Curve
+func ParseCurve(curve string) (constants.Curve, error) {
 	switch curve {
 	case "ed25519":
 		return constants.CurveED25519, nil
@@ -46,7 +46,7 @@ func ParseCurve(curve string) (uint32, error) {
 		return constants.CurveSECP256R1, nil
 	default:
 		if num, err := strconv.ParseUint(curve, 10, 32); err == nil {
-			return uint32(num), nil
+			return constants.Curve(num), nil
 		}
 		return constants.CurveED25519, nil // Default to ed25519
 	}
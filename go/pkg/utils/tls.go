@@ -15,25 +15,320 @@
 package utils
 
 import (
+	"crypto"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/revocation"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/secret"
 )
 
+// TLSOptions hardens the TLS configurations built by CreateTLSConfigWithOptions
+type TLSOptions struct {
+	// StrictHostname verifies the server certificate against the host of the node's RPC address,
+	// even where the dialer would otherwise send another server name
+	StrictHostname bool `json:"strict_hostname"`
+
+	// RequiredSANs are DNS names or IP addresses that the server certificate must all carry
+	RequiredSANs []string `json:"required_sans"`
+
+	// RequireTLS13 refuses TLS versions before 1.3
+	RequireTLS13 bool `json:"require_tls13"`
+
+	// PinnedSPKI lists SHA-256 hashes of trusted subject public key infos, base64 (as printed by
+	// SPKIHash, optionally prefixed "sha256/") or hex. With pins set, the server certificate must
+	// match one of them or of PinnedCerts, whatever certificate the config server reported
+	PinnedSPKI []string `json:"pinned_spki"`
+
+	// PinnedCerts lists trusted server certificates in PEM
+	PinnedCerts []string `json:"pinned_certs"`
+
+	// Revocation checks the server's certificate chain against OCSP responders and CRLs
+	Revocation *revocation.Checker `json:"-"`
+
+	// InsecureDev skips server certificate verification entirely. For local development only;
+	// RequiredSANs and pins are still checked
+	InsecureDev bool `json:"insecure_dev"`
+}
+
+// SPKIHash returns the base64 SHA-256 hash of a certificate's subject public key info, the form
+// PinnedSPKI takes
+func SPKIHash(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
 // CreateTLSConfig creates TLS configuration for TEE server
+// Without cert and key no client certificate is presented, for token authentication
 func CreateTLSConfig(cert, key, targetCert []byte) (*tls.Config, error) {
-	certificate, err := tls.X509KeyPair(cert, key)
+	return CreateTLSConfigWithOptions(cert, key, targetCert, "", TLSOptions{})
+}
+
+// maxCachedTLSConfigs bounds the TLS configuration cache; it starts over when full, which only
+// happens when node certificates or addresses churn
+const maxCachedTLSConfigs = 256
+
+// tlsConfigKey identifies a cached TLS configuration by everything it is built from
+type tlsConfigKey struct {
+	targetCert [sha256.Size]byte
+	address    string
+	options    string // TLSOptions as JSON
+	revocation *revocation.Checker
+}
+
+// tlsConfigs caches the TLS configurations of nodes without a client certificate, so Init and
+// reconnects parse a node's certificate and the pins only when they change. Client certificates
+// are not cached: their keys are wiped on Close
+var tlsConfigs = struct {
+	sync.Mutex
+	configs map[tlsConfigKey]*tls.Config
+}{configs: make(map[tlsConfigKey]*tls.Config)}
+
+// buildTLSConfig returns the TLS configuration of the node at address, trusting targetCert,
+// hardened by opts and presenting certificate if not nil
+func buildTLSConfig(certificate *tls.Certificate, targetCert []byte, address string, opts TLSOptions) (*tls.Config, error) {
+	template, err := cachedTLSConfig(targetCert, address, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse client certificate: %w", err)
+		return nil, err
+	}
+	// Clones share the template's read-only pool and verification callback
+	tlsConfig := template.Clone()
+	if certificate != nil {
+		tlsConfig.Certificates = []tls.Certificate{*certificate}
+	}
+	return tlsConfig, nil
+}
+
+// cachedTLSConfig returns the cached TLS configuration template for a node, building it on a miss
+// Templates must not be modified; failed builds are not cached
+func cachedTLSConfig(targetCert []byte, address string, opts TLSOptions) (*tls.Config, error) {
+	options, err := json.Marshal(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode TLS options: %w", err)
+	}
+	key := tlsConfigKey{targetCert: sha256.Sum256(targetCert), address: address, options: string(options), revocation: opts.Revocation}
+
+	tlsConfigs.Lock()
+	template, ok := tlsConfigs.configs[key]
+	tlsConfigs.Unlock()
+	if ok {
+		return template, nil
 	}
 
 	caPool := x509.NewCertPool()
 	if !caPool.AppendCertsFromPEM(targetCert) {
 		return nil, fmt.Errorf("failed to parse TEE server certificate")
 	}
+	if template, err = harden(&tls.Config{RootCAs: caPool}, address, opts); err != nil {
+		return nil, err
+	}
+
+	tlsConfigs.Lock()
+	defer tlsConfigs.Unlock()
+	if len(tlsConfigs.configs) >= maxCachedTLSConfigs {
+		clear(tlsConfigs.configs)
+	}
+	tlsConfigs.configs[key] = template
+	return template, nil
+}
+
+// LoadClientCertificate parses a PEM certificate chain and private key. The decoded key bytes
+// are zeroed once parsed, so the key only lives in the returned certificate, which can be shared
+// by the TLS configurations of all nodes and wiped with secret.ZeroKey when done
+func LoadClientCertificate(certPEM, keyPEM []byte) (*tls.Certificate, error) {
+	certificate := &tls.Certificate{}
+	for rest := certPEM; ; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			certificate.Certificate = append(certificate.Certificate, block.Bytes)
+		}
+	}
+	if len(certificate.Certificate) == 0 {
+		return nil, fmt.Errorf("no certificate found")
+	}
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	certificate.Leaf = leaf
+
+	var block *pem.Block
+	for rest := keyPEM; ; {
+		if block, rest = pem.Decode(rest); block == nil || strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			break
+		}
+	}
+	if block == nil {
+		return nil, fmt.Errorf("no private key found")
+	}
+	defer secret.Zero(block.Bytes)
+	key, err := parsePrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	public, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !public.Equal(leaf.PublicKey) {
+		secret.ZeroKey(key)
+		return nil, fmt.Errorf("private key does not match certificate")
+	}
+	certificate.PrivateKey = key
+	return certificate, nil
+}
+
+// parsePrivateKey parses a PKCS #8, PKCS #1 or SEC 1 private key
+func parsePrivateKey(der []byte) (crypto.Signer, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("failed to parse private key")
+}
+
+// CreateTLSConfigWithOptions creates TLS configuration for a node at address, applying opts
+func CreateTLSConfigWithOptions(cert, key, targetCert []byte, address string, opts TLSOptions) (*tls.Config, error) {
+	var certificate *tls.Certificate
+	if len(cert) > 0 || len(key) > 0 {
+		var err error
+		if certificate, err = LoadClientCertificate(cert, key); err != nil {
+			return nil, fmt.Errorf("failed to parse client certificate: %w", err)
+		}
+	}
+	return buildTLSConfig(certificate, targetCert, address, opts)
+}
+
+// CreateTLSConfigWithCertificate is CreateTLSConfigWithOptions for a client certificate loaded
+// by LoadClientCertificate; nil presents none
+func CreateTLSConfigWithCertificate(certificate *tls.Certificate, targetCert []byte, address string, opts TLSOptions) (*tls.Config, error) {
+	return buildTLSConfig(certificate, targetCert, address, opts)
+}
+
+// harden applies opts to the TLS configuration of the node at address
+func harden(tlsConfig *tls.Config, address string, opts TLSOptions) (*tls.Config, error) {
+	if opts.StrictHostname {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			host = address
+		}
+		if host == "" {
+			return nil, fmt.Errorf("no hostname to verify in address %q", address)
+		}
+		tlsConfig.ServerName = host
+	}
+	if opts.RequireTLS13 {
+		tlsConfig.MinVersion = tls.VersionTLS13
+	}
+	if opts.InsecureDev {
+		tlsConfig.InsecureSkipVerify = true
+	}
+	pins, err := parsePins(opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.RequiredSANs) > 0 || pins != nil || opts.Revocation != nil {
+		required := slices.Clone(opts.RequiredSANs)
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 {
+				return fmt.Errorf("server sent no certificate")
+			}
+			leaf := state.PeerCertificates[0]
+			if err := checkSANs(leaf, required); err != nil {
+				return err
+			}
+			if pins != nil && !pins.match(leaf) {
+				return fmt.Errorf("server certificate of %s matches no pin (SPKI %s)", address, SPKIHash(leaf))
+			}
+			if opts.Revocation != nil {
+				return opts.Revocation.VerifyConnection(state)
+			}
+			return nil
+		}
+	}
+	return tlsConfig, nil
+}
+
+// pinSet holds the parsed certificate pins
+type pinSet struct {
+	spki  map[[sha256.Size]byte]bool
+	certs map[[sha256.Size]byte]bool
+}
 
-	return &tls.Config{
-		Certificates: []tls.Certificate{certificate},
-		RootCAs:      caPool,
-	}, nil
+// parsePins decodes the pins of opts; nil if there are none
+func parsePins(opts TLSOptions) (*pinSet, error) {
+	if len(opts.PinnedSPKI) == 0 && len(opts.PinnedCerts) == 0 {
+		return nil, nil
+	}
+	pins := &pinSet{spki: make(map[[sha256.Size]byte]bool), certs: make(map[[sha256.Size]byte]bool)}
+	for _, pin := range opts.PinnedSPKI {
+		encoded := strings.TrimPrefix(strings.TrimSpace(pin), "sha256/")
+		hash, err := hex.DecodeString(encoded)
+		if err != nil {
+			hash, err = base64.StdEncoding.DecodeString(encoded)
+		}
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("invalid SPKI pin %q (want a base64 or hex SHA-256 hash)", pin)
+		}
+		pins.spki[[sha256.Size]byte(hash)] = true
+	}
+	for i, certPEM := range opts.PinnedCerts {
+		rest := []byte(certPEM)
+		found := false
+		for {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			if block.Type == "CERTIFICATE" {
+				pins.certs[sha256.Sum256(block.Bytes)] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("pinned certificate %d holds no PEM certificate", i)
+		}
+	}
+	return pins, nil
+}
+
+// match reports whether the certificate or its public key is pinned
+func (p *pinSet) match(cert *x509.Certificate) bool {
+	return p.spki[sha256.Sum256(cert.RawSubjectPublicKeyInfo)] || p.certs[sha256.Sum256(cert.Raw)]
+}
+
+// checkSANs returns an error unless the certificate carries every required DNS name or IP address
+func checkSANs(cert *x509.Certificate, required []string) error {
+	for _, san := range required {
+		if ip := net.ParseIP(san); ip != nil {
+			if !slices.ContainsFunc(cert.IPAddresses, ip.Equal) {
+				return fmt.Errorf("server certificate lacks required IP SAN %s", san)
+			}
+			continue
+		}
+		if !slices.Contains(cert.DNSNames, san) {
+			return fmt.Errorf("server certificate lacks required DNS SAN %s", san)
+		}
+	}
+	return nil
 }
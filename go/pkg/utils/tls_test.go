@@ -0,0 +1,344 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+)
+
+// selfSigned returns a PEM certificate and key valid for the given DNS names and IPs
+func selfSigned(t testing.TB, dnsNames []string, ips []net.IP) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              dnsNames,
+		IPAddresses:           ips,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to encode key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+// handshake runs a TLS handshake between a server with the given certificate and a client
+// with clientConfig, returning the client's error
+func handshake(t *testing.T, serverCert, serverKey []byte, clientConfig *tls.Config, maxVersion uint16) error {
+	t.Helper()
+	certificate, err := tls.X509KeyPair(serverCert, serverKey)
+	if err != nil {
+		t.Fatalf("failed to load server certificate: %v", err)
+	}
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientAuth:   tls.RequireAnyClientCert,
+		MaxVersion:   maxVersion,
+	})
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.(*tls.Conn).Handshake()
+	}()
+	if clientConfig.ServerName == "" {
+		// As gRPC does, verify against the dialed host when no server name is set
+		clientConfig = clientConfig.Clone()
+		clientConfig.ServerName = "localhost"
+	}
+	conn, err := tls.Dial("tcp", listener.Addr().String(), clientConfig)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	// TLS 1.3 clients finish before the server has checked their certificate
+	_, err = conn.Read(make([]byte, 1))
+	if err == io.EOF {
+		err = nil
+	}
+	return err
+}
+
+func TestCreateTLSConfigWithOptions(t *testing.T) {
+	clientCert, clientKey := selfSigned(t, []string{"client"}, nil)
+	serverCert, serverKey := selfSigned(t, []string{"localhost", "tee-node"}, []net.IP{net.IPv4(127, 0, 0, 1)})
+
+	tests := []struct {
+		name       string
+		address    string
+		opts       TLSOptions
+		maxVersion uint16
+		wantErr    bool
+	}{
+		{name: "defaults", address: "localhost:50051"},
+		{name: "strict hostname match", address: "tee-node:50051", opts: TLSOptions{StrictHostname: true}},
+		{name: "strict hostname mismatch", address: "other-node:50051", opts: TLSOptions{StrictHostname: true}, wantErr: true},
+		{name: "strict hostname IP", address: "127.0.0.1:50051", opts: TLSOptions{StrictHostname: true}},
+		{name: "required SANs present", address: "localhost:50051", opts: TLSOptions{RequiredSANs: []string{"tee-node", "127.0.0.1"}}},
+		{name: "required DNS SAN missing", address: "localhost:50051", opts: TLSOptions{RequiredSANs: []string{"tee-node-2"}}, wantErr: true},
+		{name: "required IP SAN missing", address: "localhost:50051", opts: TLSOptions{RequiredSANs: []string{"10.0.0.1"}}, wantErr: true},
+		{name: "TLS 1.3 required", address: "localhost:50051", opts: TLSOptions{RequireTLS13: true}},
+		{name: "TLS 1.3 required, server 1.2", address: "localhost:50051", opts: TLSOptions{RequireTLS13: true}, maxVersion: tls.VersionTLS12, wantErr: true},
+		{name: "insecure dev hostname mismatch", address: "other-node:50051", opts: TLSOptions{StrictHostname: true, InsecureDev: true}},
+		{name: "insecure dev still checks SANs", address: "localhost:50051", opts: TLSOptions{InsecureDev: true, RequiredSANs: []string{"tee-node-2"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := CreateTLSConfigWithOptions(clientCert, clientKey, serverCert, tt.address, tt.opts)
+			if err != nil {
+				t.Fatalf("CreateTLSConfigWithOptions failed: %v", err)
+			}
+			err = handshake(t, serverCert, serverKey, config, tt.maxVersion)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("handshake error = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateTLSConfigWithOptionsUntrustedServer(t *testing.T) {
+	clientCert, clientKey := selfSigned(t, []string{"client"}, nil)
+	trustedCert, _ := selfSigned(t, []string{"localhost"}, nil)
+	serverCert, serverKey := selfSigned(t, []string{"localhost"}, nil)
+
+	config, err := CreateTLSConfigWithOptions(clientCert, clientKey, trustedCert, "localhost:50051", TLSOptions{})
+	if err != nil {
+		t.Fatalf("CreateTLSConfigWithOptions failed: %v", err)
+	}
+	if err := handshake(t, serverCert, serverKey, config, 0); err == nil {
+		t.Error("expected handshake with an untrusted server certificate to fail")
+	}
+
+	config, err = CreateTLSConfigWithOptions(clientCert, clientKey, trustedCert, "localhost:50051", TLSOptions{InsecureDev: true})
+	if err != nil {
+		t.Fatalf("CreateTLSConfigWithOptions failed: %v", err)
+	}
+	if err := handshake(t, serverCert, serverKey, config, 0); err != nil {
+		t.Errorf("insecure dev handshake failed: %v", err)
+	}
+}
+
+func TestCreateTLSConfigWithOptionsPinning(t *testing.T) {
+	clientCert, clientKey := selfSigned(t, []string{"client"}, nil)
+	serverCert, serverKey := selfSigned(t, []string{"localhost"}, nil)
+	otherCert, _ := selfSigned(t, []string{"localhost"}, nil)
+
+	block, _ := pem.Decode(serverCert)
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	spki := SPKIHash(parsed)
+	sum := sha256.Sum256(parsed.RawSubjectPublicKeyInfo)
+
+	tests := []struct {
+		name    string
+		opts    TLSOptions
+		wantErr bool
+	}{
+		{name: "SPKI pin", opts: TLSOptions{PinnedSPKI: []string{spki}}},
+		{name: "SPKI pin with prefix", opts: TLSOptions{PinnedSPKI: []string{"sha256/" + spki}}},
+		{name: "hex SPKI pin", opts: TLSOptions{PinnedSPKI: []string{hex.EncodeToString(sum[:])}}},
+		{name: "certificate pin", opts: TLSOptions{PinnedCerts: []string{string(otherCert), string(serverCert)}}},
+		{name: "no matching pin", opts: TLSOptions{PinnedCerts: []string{string(otherCert)}}, wantErr: true},
+		{name: "insecure dev still checks pins", opts: TLSOptions{InsecureDev: true, PinnedCerts: []string{string(otherCert)}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := CreateTLSConfigWithOptions(clientCert, clientKey, serverCert, "localhost:50051", tt.opts)
+			if err != nil {
+				t.Fatalf("CreateTLSConfigWithOptions failed: %v", err)
+			}
+			err = handshake(t, serverCert, serverKey, config, 0)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("handshake error = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+
+	// A config server reporting an attacker's certificate: trusted by the pool, rejected by the pin
+	attackerCert, attackerKey := selfSigned(t, []string{"localhost"}, nil)
+	config, err := CreateTLSConfigWithOptions(clientCert, clientKey, attackerCert, "localhost:50051", TLSOptions{PinnedSPKI: []string{spki}})
+	if err != nil {
+		t.Fatalf("CreateTLSConfigWithOptions failed: %v", err)
+	}
+	if err := handshake(t, attackerCert, attackerKey, config, 0); err == nil {
+		t.Error("expected handshake with an unpinned certificate to fail")
+	}
+
+	for _, opts := range []TLSOptions{
+		{PinnedSPKI: []string{"not-a-hash"}},
+		{PinnedSPKI: []string{base64.StdEncoding.EncodeToString([]byte("short"))}},
+		{PinnedCerts: []string{"no pem here"}},
+	} {
+		if _, err := CreateTLSConfigWithOptions(clientCert, clientKey, serverCert, "localhost:50051", opts); err == nil {
+			t.Errorf("expected invalid pins %+v to be rejected", opts)
+		}
+	}
+}
+
+func TestLoadClientCertificate(t *testing.T) {
+	cert, key := selfSigned(t, []string{"client"}, nil)
+	otherCert, otherKey := selfSigned(t, []string{"client"}, nil)
+
+	certificate, err := LoadClientCertificate(cert, key)
+	if err != nil {
+		t.Fatalf("LoadClientCertificate failed: %v", err)
+	}
+	if certificate.Leaf == nil || len(certificate.Certificate) != 1 || certificate.PrivateKey == nil {
+		t.Errorf("incomplete certificate: %+v", certificate)
+	}
+
+	// SEC 1 keys as written by openssl ecparam
+	block, _ := pem.Decode(key)
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("failed to parse key: %v", err)
+	}
+	sec1, err := x509.MarshalECPrivateKey(parsed.(*ecdsa.PrivateKey))
+	if err != nil {
+		t.Fatalf("failed to encode key: %v", err)
+	}
+	if _, err := LoadClientCertificate(cert, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1})); err != nil {
+		t.Errorf("SEC 1 key rejected: %v", err)
+	}
+
+	for name, pair := range map[string][2][]byte{
+		"mismatched key": {cert, otherKey},
+		"no key":         {cert, otherCert},
+		"no certificate": {key, key},
+	} {
+		if _, err := LoadClientCertificate(pair[0], pair[1]); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	// Configurations of all nodes share the one parsed key
+	serverCert, serverKey := selfSigned(t, []string{"localhost"}, nil)
+	config, err := CreateTLSConfigWithCertificate(certificate, serverCert, "localhost:50051", TLSOptions{})
+	if err != nil {
+		t.Fatalf("CreateTLSConfigWithCertificate failed: %v", err)
+	}
+	if config.Certificates[0].PrivateKey != certificate.PrivateKey {
+		t.Error("configuration holds a copy of the key")
+	}
+	if err := handshake(t, serverCert, serverKey, config, 0); err != nil {
+		t.Errorf("handshake failed: %v", err)
+	}
+	config, err = CreateTLSConfigWithCertificate(nil, serverCert, "localhost:50051", TLSOptions{})
+	if err != nil || len(config.Certificates) != 0 {
+		t.Errorf("CreateTLSConfigWithCertificate(nil) = %v, %v", config.Certificates, err)
+	}
+}
+
+func TestCreateTLSConfigCache(t *testing.T) {
+	serverCert, serverKey := selfSigned(t, []string{"localhost"}, nil)
+	first, firstKey := selfSigned(t, []string{"client-1"}, nil)
+	second, secondKey := selfSigned(t, []string{"client-2"}, nil)
+	firstCertificate, err := LoadClientCertificate(first, firstKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondCertificate, err := LoadClientCertificate(second, secondKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := TLSOptions{RequiredSANs: []string{"localhost"}, PinnedCerts: []string{string(serverCert)}}
+	a, err := CreateTLSConfigWithCertificate(firstCertificate, serverCert, "localhost:50051", opts)
+	if err != nil {
+		t.Fatalf("CreateTLSConfigWithCertificate failed: %v", err)
+	}
+	b, err := CreateTLSConfigWithCertificate(secondCertificate, serverCert, "localhost:50051", opts)
+	if err != nil {
+		t.Fatalf("CreateTLSConfigWithCertificate failed: %v", err)
+	}
+	if a == b || a.RootCAs != b.RootCAs {
+		t.Error("Expected separate configurations sharing the parsed pool")
+	}
+	if a.Certificates[0].PrivateKey != firstCertificate.PrivateKey || b.Certificates[0].PrivateKey != secondCertificate.PrivateKey {
+		t.Error("Expected each configuration to present its own client certificate")
+	}
+	a.ServerName = "changed"
+	if c, _ := CreateTLSConfigWithCertificate(nil, serverCert, "localhost:50051", opts); c.ServerName != "" || len(c.Certificates) != 0 {
+		t.Error("Expected changes to a returned configuration not to reach the cache")
+	}
+	if err := handshake(t, serverCert, serverKey, b, 0); err != nil {
+		t.Errorf("handshake with cached configuration failed: %v", err)
+	}
+
+	// Changed options and a rotated server certificate are built afresh
+	strict := opts
+	strict.RequireTLS13 = true
+	c, err := CreateTLSConfigWithCertificate(nil, serverCert, "localhost:50051", strict)
+	if err != nil || c.MinVersion != tls.VersionTLS13 || c.RootCAs == b.RootCAs {
+		t.Errorf("Expected a new configuration for changed options, got MinVersion %x, %v", c.MinVersion, err)
+	}
+	rotatedCert, rotatedKey := selfSigned(t, []string{"localhost"}, nil)
+	d, err := CreateTLSConfigWithCertificate(firstCertificate, rotatedCert, "localhost:50051", TLSOptions{})
+	if err != nil || d.RootCAs == b.RootCAs {
+		t.Fatalf("Expected a new pool for a rotated certificate, got %v", err)
+	}
+	if err := handshake(t, rotatedCert, rotatedKey, d, 0); err != nil {
+		t.Errorf("handshake with rotated certificate failed: %v", err)
+	}
+	if err := handshake(t, rotatedCert, rotatedKey, b, 0); err == nil {
+		t.Error("Expected the old configuration to refuse the rotated certificate")
+	}
+
+	// Failed builds are not cached
+	if _, err := CreateTLSConfigWithCertificate(nil, []byte("not a certificate"), "localhost:50051", TLSOptions{}); err == nil {
+		t.Error("Expected an error for an invalid server certificate")
+	}
+	tlsConfigs.Lock()
+	defer tlsConfigs.Unlock()
+	for key := range tlsConfigs.configs {
+		if key.targetCert == sha256.Sum256([]byte("not a certificate")) {
+			t.Error("Expected the failed build not to be cached")
+		}
+	}
+}
+
+func BenchmarkCreateTLSConfigWithCertificate(b *testing.B) {
+	serverCert, _ := selfSigned(b, []string{"localhost"}, nil)
+	opts := TLSOptions{PinnedCerts: []string{string(serverCert)}}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := CreateTLSConfigWithCertificate(nil, serverCert, "localhost:50051", opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
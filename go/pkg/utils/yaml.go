@@ -0,0 +1,172 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseSimpleYAML parses the YAML subset used by configuration files: nested block
+// mappings, block sequences of scalars, scalars (plain, single or double quoted) and
+// comments. Anchors, flow collections and multi-line strings are not supported
+func ParseSimpleYAML(data []byte) (map[string]any, error) {
+	type frame struct {
+		indent int
+		value  map[string]any
+	}
+
+	root := map[string]any{}
+	stack := []frame{{indent: -1, value: root}}
+	var pendingKey string // key awaiting a nested block
+	var pendingParent map[string]any
+	var currentList []any
+	var listKey string
+	var listParent map[string]any
+	var listIndent int
+
+	lines := strings.Split(string(data), "\n")
+	for i, raw := range lines {
+		lineNo := i + 1
+		line := strings.TrimRight(stripComment(raw), " \t\r")
+		if strings.TrimSpace(line) == "" || strings.TrimSpace(line) == "---" {
+			continue
+		}
+		if strings.Contains(line, "\t") && strings.TrimLeft(line, "\t") != line {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", lineNo)
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		content := strings.TrimSpace(line)
+
+		// Sequence item
+		if strings.HasPrefix(content, "- ") || content == "-" {
+			if pendingKey != "" {
+				currentList = []any{}
+				listIndent = indent
+				listKey, listParent = pendingKey, pendingParent
+				pendingKey = ""
+			}
+			if currentList == nil || indent != listIndent {
+				return nil, fmt.Errorf("line %d: unexpected sequence item", lineNo)
+			}
+			item := strings.TrimSpace(strings.TrimPrefix(content, "-"))
+			value, err := parseScalar(item)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			currentList = append(currentList, value)
+			listParent[listKey] = currentList
+			continue
+		}
+		currentList = nil
+
+		key, rest, ok := strings.Cut(content, ":")
+		if !ok || (rest != "" && !strings.HasPrefix(rest, " ")) {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", lineNo)
+		}
+		key, err := unquote(strings.TrimSpace(key))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		rest = strings.TrimSpace(rest)
+
+		if pendingKey != "" {
+			// The previous key opens a nested mapping at this indentation
+			if indent <= stack[len(stack)-1].indent {
+				pendingParent[pendingKey] = nil
+			} else {
+				nested := map[string]any{}
+				pendingParent[pendingKey] = nested
+				stack = append(stack, frame{indent: indent, value: nested})
+			}
+			pendingKey = ""
+		}
+		for len(stack) > 1 && indent < stack[len(stack)-1].indent {
+			stack = stack[:len(stack)-1]
+		}
+		if indent != stack[len(stack)-1].indent && len(stack) > 1 {
+			return nil, fmt.Errorf("line %d: inconsistent indentation", lineNo)
+		}
+		parent := stack[len(stack)-1].value
+		if _, exists := parent[key]; exists {
+			return nil, fmt.Errorf("line %d: duplicate key %q", lineNo, key)
+		}
+
+		if rest == "" {
+			pendingKey = key
+			pendingParent = parent
+			parent[key] = nil
+			continue
+		}
+		value, err := parseScalar(rest)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		parent[key] = value
+	}
+	return root, nil
+}
+
+// stripComment removes a trailing # comment that is not inside quotes
+func stripComment(line string) string {
+	inSingle, inDouble := false, false
+	for i, r := range line {
+		switch {
+		case r == '\'' && !inDouble:
+			inSingle = !inSingle
+		case r == '"' && !inSingle:
+			inDouble = !inDouble
+		case r == '#' && !inSingle && !inDouble && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// parseScalar converts a YAML scalar into a bool, int64, float64, nil or string
+func parseScalar(s string) (any, error) {
+	if strings.HasPrefix(s, "\"") || strings.HasPrefix(s, "'") {
+		return unquote(s)
+	}
+	switch s {
+	case "", "~", "null":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, nil
+	}
+	return s, nil
+}
+
+// unquote removes YAML single or double quotes
+func unquote(s string) (string, error) {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return strconv.Unquote(s)
+	}
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	}
+	if strings.HasPrefix(s, "\"") || strings.HasPrefix(s, "'") {
+		return "", fmt.Errorf("unterminated quoted string: %s", s)
+	}
+	return s, nil
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSimpleYAML(t *testing.T) {
+	data := []byte(`# client config
+config_server_addr: "tee-config:50052"
+timeout: 10s
+voting:
+  disabled: false
+  addr: ':50051' # inline comment
+grpc:
+  compression: true
+  max_recv_msg_size: 8388608
+tags:
+  - a
+  - "b c"
+`)
+	got, err := ParseSimpleYAML(data)
+	if err != nil {
+		t.Fatalf("ParseSimpleYAML failed: %v", err)
+	}
+	want := map[string]any{
+		"config_server_addr": "tee-config:50052",
+		"timeout":            "10s",
+		"voting":             map[string]any{"disabled": false, "addr": ":50051"},
+		"grpc":               map[string]any{"compression": true, "max_recv_msg_size": int64(8388608)},
+		"tags":               []any{"a", "b c"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected result:\n got  %#v\n want %#v", got, want)
+	}
+}
+
+func TestParseSimpleYAMLErrors(t *testing.T) {
+	for _, data := range []string{
+		"\tkey: value\n",
+		"just a scalar\n",
+	} {
+		if _, err := ParseSimpleYAML([]byte(data)); err == nil {
+			t.Errorf("expected error for %q", data)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package verification
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// base58Alphabet is the Bitcoin base58 alphabet, also used by Solana and TRON
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var (
+	base58Radix   = big.NewInt(58)
+	base58Indexes = newBase58Indexes()
+)
+
+// newBase58Indexes maps each byte to its base58 digit, or -1 if it is not in the alphabet
+func newBase58Indexes() [256]int8 {
+	var indexes [256]int8
+	for i := range indexes {
+		indexes[i] = -1
+	}
+	for i := 0; i < len(base58Alphabet); i++ {
+		indexes[base58Alphabet[i]] = int8(i)
+	}
+	return indexes
+}
+
+// EncodeBase58 encodes data in base58, keeping leading zero bytes as leading '1's
+func EncodeBase58(data []byte) string {
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
+	}
+
+	n := new(big.Int).SetBytes(data[zeros:])
+	mod := new(big.Int)
+	var digits []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, base58Radix, mod)
+		digits = append(digits, base58Alphabet[mod.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		digits = append(digits, base58Alphabet[0])
+	}
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return string(digits)
+}
+
+// DecodeBase58 decodes a base58 string
+func DecodeBase58(s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	n := new(big.Int)
+	for i := zeros; i < len(s); i++ {
+		digit := base58Indexes[s[i]]
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q at offset %d", s[i], i)
+		}
+		n.Mul(n, base58Radix)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
@@ -0,0 +1,38 @@
+package verification
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestBase58(t *testing.T) {
+	tests := []struct {
+		hex     string
+		encoded string
+	}{
+		{"", ""},
+		{"00", "1"},
+		{"0000287fb4cd", "11233QC4"},
+		{hex.EncodeToString([]byte("Hello World!")), "2NEpo7TZRRrLZSi2U"},
+		{"0000000000000000000000000000000000000000000000000000000000000000", "11111111111111111111111111111111"},
+	}
+	for _, tt := range tests {
+		data, _ := hex.DecodeString(tt.hex)
+		if got := EncodeBase58(data); got != tt.encoded {
+			t.Errorf("EncodeBase58(%s) = %q, want %q", tt.hex, got, tt.encoded)
+		}
+		decoded, err := DecodeBase58(tt.encoded)
+		if err != nil {
+			t.Errorf("DecodeBase58(%q) failed: %v", tt.encoded, err)
+			continue
+		}
+		if !bytes.Equal(decoded, data) {
+			t.Errorf("DecodeBase58(%q) = %x, want %s", tt.encoded, decoded, tt.hex)
+		}
+	}
+
+	if _, err := DecodeBase58("0OIl"); err == nil {
+		t.Error("Expected an error for characters outside the alphabet")
+	}
+}
@@ -0,0 +1,122 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package verification
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+// BitcoinMessageMagic is the prefix used by the Bitcoin signmessage/verifymessage convention
+const BitcoinMessageMagic = "Bitcoin Signed Message:\n"
+
+// BitcoinMessageHash returns the double SHA-256 of varint(len(magic)) || magic || varint(len(message)) || message
+func BitcoinMessageHash(message []byte) []byte {
+	var buf bytes.Buffer
+	writeVarInt(&buf, uint64(len(BitcoinMessageMagic)))
+	buf.WriteString(BitcoinMessageMagic)
+	writeVarInt(&buf, uint64(len(message)))
+	buf.Write(message)
+
+	first := sha256.Sum256(buf.Bytes())
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
+
+// BitcoinSignature converts a secp256k1 ECDSA signature over hash into the base64 compact
+// form produced by Bitcoin wallets (header || R || S)
+// The header encodes the recovery ID and whether publicKey is in compressed form
+func BitcoinSignature(hash, signature, publicKey []byte) (string, error) {
+	raw, recoveryID, err := recoverableSignature(hash, signature, publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	header := 27 + recoveryID
+	if len(publicKey) == 33 {
+		header += 4
+	}
+
+	compact := make([]byte, CompactSignatureSize)
+	compact[0] = header
+	copy(compact[1:], raw)
+	return base64.StdEncoding.EncodeToString(compact), nil
+}
+
+// RecoverBitcoinPublicKey recovers the signer public key of a Bitcoin signed message
+// The key is serialized compressed or uncompressed as indicated by the signature header
+func RecoverBitcoinPublicKey(message []byte, signature string) ([]byte, error) {
+	compact, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64 signature: %v", err)
+	}
+	if len(compact) != CompactSignatureSize {
+		return nil, fmt.Errorf("invalid compact signature length: expected %d, got %d", CompactSignatureSize, len(compact))
+	}
+	if compact[0] < 27 || compact[0] > 34 {
+		return nil, fmt.Errorf("invalid compact signature header: %d", compact[0])
+	}
+
+	pubKey, compressed, err := btcecdsa.RecoverCompact(compact, BitcoinMessageHash(message))
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover public key: %v", err)
+	}
+	if compressed {
+		return pubKey.SerializeCompressed(), nil
+	}
+	return pubKey.SerializeUncompressed(), nil
+}
+
+// VerifyBitcoinMessage verifies a base64 compact Bitcoin message signature against a secp256k1 public key
+func VerifyBitcoinMessage(message, publicKey []byte, signature string) (bool, error) {
+	expected, err := parseSecp256k1PublicKey(publicKey)
+	if err != nil {
+		return false, err
+	}
+	recoveredBytes, err := RecoverBitcoinPublicKey(message, signature)
+	if err != nil {
+		return false, err
+	}
+	recovered, err := parseSecp256k1PublicKey(recoveredBytes)
+	if err != nil {
+		return false, err
+	}
+	return recovered.IsEqual(expected), nil
+}
+
+// writeVarInt writes a Bitcoin CompactSize unsigned integer
+func writeVarInt(buf *bytes.Buffer, n uint64) {
+	var tmp [9]byte
+	switch {
+	case n < 0xfd:
+		buf.WriteByte(byte(n))
+	case n <= 0xffff:
+		tmp[0] = 0xfd
+		binary.LittleEndian.PutUint16(tmp[1:], uint16(n))
+		buf.Write(tmp[:3])
+	case n <= 0xffffffff:
+		tmp[0] = 0xfe
+		binary.LittleEndian.PutUint32(tmp[1:], uint32(n))
+		buf.Write(tmp[:5])
+	default:
+		tmp[0] = 0xff
+		binary.LittleEndian.PutUint64(tmp[1:], n)
+		buf.Write(tmp[:9])
+	}
+}
@@ -0,0 +1,84 @@
+package verification
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+func TestBitcoinMessageVerification(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate secp256k1 key: %v", err)
+	}
+	pubKey := privKey.PubKey().SerializeCompressed()
+	message := []byte("Proof of reserves: TEENet")
+	hash := BitcoinMessageHash(message)
+
+	// Signatures produced by the reference compact signer must verify
+	reference := btcecdsa.SignCompact(privKey, hash, true)
+	valid, err := VerifyBitcoinMessage(message, pubKey, base64.StdEncoding.EncodeToString(reference))
+	if err != nil {
+		t.Fatalf("VerifyBitcoinMessage failed: %v", err)
+	}
+	if !valid {
+		t.Error("Reference Bitcoin signature not verified")
+	}
+
+	// Converting a DER signature must produce a wallet-compatible compact signature
+	signature, err := BitcoinSignature(hash, btcecdsa.Sign(privKey, hash).Serialize(), pubKey)
+	if err != nil {
+		t.Fatalf("BitcoinSignature failed: %v", err)
+	}
+	recovered, err := RecoverBitcoinPublicKey(message, signature)
+	if err != nil {
+		t.Fatalf("RecoverBitcoinPublicKey failed: %v", err)
+	}
+	if !bytes.Equal(recovered, pubKey) {
+		t.Errorf("Recovered key %x does not match %x", recovered, pubKey)
+	}
+
+	// Uncompressed keys produce uncompressed headers
+	uncompressed := privKey.PubKey().SerializeUncompressed()
+	signature, err = BitcoinSignature(hash, btcecdsa.Sign(privKey, hash).Serialize(), uncompressed)
+	if err != nil {
+		t.Fatalf("BitcoinSignature failed: %v", err)
+	}
+	recovered, err = RecoverBitcoinPublicKey(message, signature)
+	if err != nil {
+		t.Fatalf("RecoverBitcoinPublicKey failed: %v", err)
+	}
+	if !bytes.Equal(recovered, uncompressed) {
+		t.Error("Expected uncompressed key to be recovered")
+	}
+
+	valid, err = VerifyBitcoinMessage([]byte("Wrong message"), pubKey, signature)
+	if err == nil && valid {
+		t.Error("Signature verified with wrong message")
+	}
+
+	if _, err := VerifyBitcoinMessage(message, pubKey, "not base64!"); err == nil {
+		t.Error("Expected error for invalid base64 signature")
+	}
+}
+
+func TestWriteVarInt(t *testing.T) {
+	cases := map[uint64][]byte{
+		0x18:       {0x18},
+		0xfc:       {0xfc},
+		0xfd:       {0xfd, 0xfd, 0x00},
+		0x1234:     {0xfd, 0x34, 0x12},
+		0x10000:    {0xfe, 0x00, 0x00, 0x01, 0x00},
+		0x12345678: {0xfe, 0x78, 0x56, 0x34, 0x12},
+	}
+	for n, expected := range cases {
+		var buf bytes.Buffer
+		writeVarInt(&buf, n)
+		if !bytes.Equal(buf.Bytes(), expected) {
+			t.Errorf("writeVarInt(%#x) = %x, expected %x", n, buf.Bytes(), expected)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package verification
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TronMessagePrefix is the TIP-191 signed message prefix used by TRON wallets
+const TronMessagePrefix = "\x19TRON Signed Message:\n"
+
+// tronAddressPrefix is the first byte of TRON mainnet addresses, which encode as "T..."
+const tronAddressPrefix = 0x41
+
+// AddressFormat selects how a chain encodes the 20-byte address of a secp256k1 key
+type AddressFormat string
+
+const (
+	// AddressFormatEIP55 is 0x-prefixed hex with the EIP-55 mixed-case checksum
+	AddressFormatEIP55 AddressFormat = "eip55"
+	// AddressFormatEIP1191 is the EIP-55 variant that mixes the chain ID into the checksum (RSK)
+	AddressFormatEIP1191 AddressFormat = "eip1191"
+	// AddressFormatTron is Base58Check of 0x41 || address, as used by TRON
+	AddressFormatTron AddressFormat = "tron"
+)
+
+// Chain describes an EVM-compatible chain: how it encodes addresses and prefixes signed
+// messages. Keys, digests and signatures are the same on all of them
+type Chain struct {
+	Name          string
+	ChainID       uint64
+	AddressFormat AddressFormat
+	MessagePrefix string // Signed message prefix, followed by the decimal message length
+}
+
+// Built-in chains
+var (
+	ChainEthereum  = &Chain{Name: "ethereum", ChainID: 1, AddressFormat: AddressFormatEIP55, MessagePrefix: EthereumMessagePrefix}
+	ChainSepolia   = &Chain{Name: "sepolia", ChainID: 11155111, AddressFormat: AddressFormatEIP55, MessagePrefix: EthereumMessagePrefix}
+	ChainOptimism  = &Chain{Name: "optimism", ChainID: 10, AddressFormat: AddressFormatEIP55, MessagePrefix: EthereumMessagePrefix}
+	ChainBSC       = &Chain{Name: "bsc", ChainID: 56, AddressFormat: AddressFormatEIP55, MessagePrefix: EthereumMessagePrefix}
+	ChainPolygon   = &Chain{Name: "polygon", ChainID: 137, AddressFormat: AddressFormatEIP55, MessagePrefix: EthereumMessagePrefix}
+	ChainBase      = &Chain{Name: "base", ChainID: 8453, AddressFormat: AddressFormatEIP55, MessagePrefix: EthereumMessagePrefix}
+	ChainArbitrum  = &Chain{Name: "arbitrum", ChainID: 42161, AddressFormat: AddressFormatEIP55, MessagePrefix: EthereumMessagePrefix}
+	ChainAvalanche = &Chain{Name: "avalanche", ChainID: 43114, AddressFormat: AddressFormatEIP55, MessagePrefix: EthereumMessagePrefix}
+	ChainRSK       = &Chain{Name: "rsk", ChainID: 30, AddressFormat: AddressFormatEIP1191, MessagePrefix: EthereumMessagePrefix}
+	ChainTron      = &Chain{Name: "tron", ChainID: 728126428, AddressFormat: AddressFormatTron, MessagePrefix: TronMessagePrefix}
+)
+
+// chainRegistry indexes chains by name and chain ID
+type chainRegistry struct {
+	mu     sync.RWMutex
+	byName map[string]*Chain
+	byID   map[uint64]*Chain
+}
+
+var chains = newChainRegistry(ChainEthereum, ChainSepolia, ChainOptimism, ChainBSC, ChainPolygon,
+	ChainBase, ChainArbitrum, ChainAvalanche, ChainRSK, ChainTron)
+
+func newChainRegistry(builtin ...*Chain) *chainRegistry {
+	r := &chainRegistry{byName: make(map[string]*Chain), byID: make(map[uint64]*Chain)}
+	for _, chain := range builtin {
+		r.byName[chain.Name] = chain
+		r.byID[chain.ChainID] = chain
+	}
+	return r
+}
+
+// RegisterChain adds a chain, such as a private network, to the registry used by LookupChain
+// and LookupChainID. Names and chain IDs must be unique
+func RegisterChain(chain *Chain) error {
+	if err := chain.validate(); err != nil {
+		return err
+	}
+	chains.mu.Lock()
+	defer chains.mu.Unlock()
+	if _, exists := chains.byName[chain.Name]; exists {
+		return fmt.Errorf("chain %q is already registered", chain.Name)
+	}
+	if existing, exists := chains.byID[chain.ChainID]; exists {
+		return fmt.Errorf("chain ID %d is already registered as %q", chain.ChainID, existing.Name)
+	}
+	chains.byName[chain.Name] = chain
+	chains.byID[chain.ChainID] = chain
+	return nil
+}
+
+// LookupChain returns a registered chain by name, e.g. "polygon"
+func LookupChain(name string) (*Chain, bool) {
+	chains.mu.RLock()
+	defer chains.mu.RUnlock()
+	chain, ok := chains.byName[strings.ToLower(name)]
+	return chain, ok
+}
+
+// LookupChainID returns a registered chain by chain ID
+func LookupChainID(chainID uint64) (*Chain, bool) {
+	chains.mu.RLock()
+	defer chains.mu.RUnlock()
+	chain, ok := chains.byID[chainID]
+	return chain, ok
+}
+
+// Chains returns the registered chains ordered by chain ID
+func Chains() []*Chain {
+	chains.mu.RLock()
+	defer chains.mu.RUnlock()
+	list := make([]*Chain, 0, len(chains.byID))
+	for _, chain := range chains.byID {
+		list = append(list, chain)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ChainID < list[j].ChainID })
+	return list
+}
+
+// validate checks a chain can be registered
+func (c *Chain) validate() error {
+	switch {
+	case c == nil:
+		return fmt.Errorf("chain is required")
+	case c.Name == "" || strings.ToLower(c.Name) != c.Name:
+		return fmt.Errorf("chain name %q must be non-empty lower case", c.Name)
+	case c.ChainID == 0:
+		return fmt.Errorf("chain %s needs a chain ID", c.Name)
+	case c.MessagePrefix == "":
+		return fmt.Errorf("chain %s needs a message prefix", c.Name)
+	}
+	switch c.AddressFormat {
+	case AddressFormatEIP55, AddressFormatEIP1191, AddressFormatTron:
+		return nil
+	default:
+		return fmt.Errorf("chain %s has unknown address format %q", c.Name, c.AddressFormat)
+	}
+}
+
+// String returns the chain name
+func (c *Chain) String() string {
+	return c.Name
+}
+
+// Address derives the chain's address of a secp256k1 public key
+func (c *Chain) Address(publicKey []byte) (string, error) {
+	pubKey, err := parseSecp256k1PublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	hash := Keccak256(pubKey.SerializeUncompressed()[1:])
+	return c.FormatAddress(hash[12:])
+}
+
+// FormatAddress encodes a 20-byte address in the chain's format
+func (c *Chain) FormatAddress(address []byte) (string, error) {
+	if len(address) != 20 {
+		return "", fmt.Errorf("invalid address length: expected 20, got %d", len(address))
+	}
+	switch c.AddressFormat {
+	case AddressFormatEIP55:
+		return checksumAddress(address, ""), nil
+	case AddressFormatEIP1191:
+		return checksumAddress(address, strconv.FormatUint(c.ChainID, 10)+"0x"), nil
+	case AddressFormatTron:
+		return encodeBase58Check(append([]byte{tronAddressPrefix}, address...)), nil
+	default:
+		return "", fmt.Errorf("chain %s has unknown address format %q", c.Name, c.AddressFormat)
+	}
+}
+
+// ParseAddress decodes an address in the chain's format into its 20 bytes. Mixed-case hex
+// addresses must carry a valid checksum; all lower or upper case ones are accepted unchecked
+func (c *Chain) ParseAddress(address string) ([]byte, error) {
+	if c.AddressFormat == AddressFormatTron {
+		payload, err := decodeBase58Check(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s address %q: %w", c.Name, address, err)
+		}
+		if len(payload) != 21 || payload[0] != tronAddressPrefix {
+			return nil, fmt.Errorf("invalid %s address %q", c.Name, address)
+		}
+		return payload[1:], nil
+	}
+
+	digits, ok := strings.CutPrefix(address, "0x")
+	if !ok || len(digits) != 40 {
+		return nil, fmt.Errorf("invalid %s address %q", c.Name, address)
+	}
+	decoded, err := hex.DecodeString(digits)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s address %q: %w", c.Name, address, err)
+	}
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) {
+		if formatted, _ := c.FormatAddress(decoded); formatted != address {
+			return nil, fmt.Errorf("invalid checksum in %s address %q", c.Name, address)
+		}
+	}
+	return decoded, nil
+}
+
+// MessageHash returns keccak256(prefix + len(message) + message), the digest of a signed message
+func (c *Chain) MessageHash(message []byte) []byte {
+	return Keccak256([]byte(c.MessagePrefix+strconv.Itoa(len(message))), message)
+}
+
+// RecoverMessageAddress recovers the signer address of a 65-byte signed message signature
+func (c *Chain) RecoverMessageAddress(message, signature []byte) (string, error) {
+	raw, recoveryID, err := CompactToRaw(signature)
+	if err != nil {
+		return "", err
+	}
+	pubKey, err := recoverSecp256k1(c.MessageHash(message), raw, recoveryID)
+	if err != nil {
+		return "", err
+	}
+	hash := Keccak256(pubKey.SerializeUncompressed()[1:])
+	return c.FormatAddress(hash[12:])
+}
+
+// VerifyMessageAddress verifies a signed message signature against a signer address in the chain's format
+func (c *Chain) VerifyMessageAddress(message, signature []byte, address string) (bool, error) {
+	expected, err := c.ParseAddress(address)
+	if err != nil {
+		return false, err
+	}
+	recovered, err := c.RecoverMessageAddress(message, signature)
+	if err != nil {
+		return false, err
+	}
+	recoveredBytes, err := c.ParseAddress(recovered)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(recoveredBytes, expected), nil
+}
+
+// LegacyV returns the V of an EIP-155 legacy transaction signature: recoveryID + chainID*2 + 35.
+// Typed transactions carry the recovery ID (0/1) as is
+func (c *Chain) LegacyV(recoveryID byte) uint64 {
+	return uint64(recoveryID) + c.ChainID*2 + 35
+}
+
+// encodeBase58Check appends the double SHA-256 checksum to payload and encodes it in base58
+func encodeBase58Check(payload []byte) string {
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	return EncodeBase58(append(append([]byte{}, payload...), second[:4]...))
+}
+
+// decodeBase58Check decodes base58 and verifies and strips the double SHA-256 checksum
+func decodeBase58Check(s string) ([]byte, error) {
+	decoded, err := DecodeBase58(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) < 4 {
+		return nil, fmt.Errorf("too short for a checksum")
+	}
+	payload, checksum := decoded[:len(decoded)-4], decoded[len(decoded)-4:]
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:4], checksum) {
+		return nil, fmt.Errorf("checksum mismatch")
+	}
+	return payload, nil
+}
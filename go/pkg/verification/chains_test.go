@@ -0,0 +1,133 @@
+package verification
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+func TestChainAddressFormats(t *testing.T) {
+	address, _ := hex.DecodeString("5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	tronAddress, _ := hex.DecodeString("8840e6c55b9ada326d211d818c34a994aeced808")
+
+	vectors := []struct {
+		chain    *Chain
+		address  []byte
+		expected string
+	}{
+		{ChainEthereum, address, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{ChainPolygon, address, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{ChainTron, tronAddress, "TNPeeaaFB7K9cmo4uQpcU32zGK8G1NYqeL"},
+	}
+	for _, v := range vectors {
+		formatted, err := v.chain.FormatAddress(v.address)
+		if err != nil {
+			t.Fatalf("%s FormatAddress failed: %v", v.chain, err)
+		}
+		if formatted != v.expected {
+			t.Errorf("%s address = %s, expected %s", v.chain, formatted, v.expected)
+		}
+		parsed, err := v.chain.ParseAddress(formatted)
+		if err != nil || hex.EncodeToString(parsed) != hex.EncodeToString(v.address) {
+			t.Errorf("%s ParseAddress(%s) = %x, %v", v.chain, formatted, parsed, err)
+		}
+	}
+
+	// EIP-1191 mixes the chain ID into the checksum, so RSK addresses differ from Ethereum ones
+	rsk, _ := ChainRSK.FormatAddress(address)
+	if rsk == vectors[0].expected {
+		t.Error("Expected RSK checksum to differ from EIP-55")
+	}
+	if _, err := ChainEthereum.ParseAddress(rsk); err == nil {
+		t.Error("Expected RSK checksum to be rejected on Ethereum")
+	}
+	if _, err := ChainRSK.ParseAddress(rsk); err != nil {
+		t.Errorf("ParseAddress rejected its own RSK address: %v", err)
+	}
+}
+
+func TestChainParseAddressRejectsInvalid(t *testing.T) {
+	invalid := map[*Chain]string{
+		ChainEthereum: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", // Bad checksum
+		ChainBSC:      "5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",   // Missing 0x
+		ChainTron:     "TNPeeaaFB7K9cmo4uQpcU32zGK8G1NYqeM",         // Bad checksum
+	}
+	for chain, address := range invalid {
+		if _, err := chain.ParseAddress(address); err == nil {
+			t.Errorf("Expected %s to reject %s", chain, address)
+		}
+	}
+
+	// Single-case hex carries no checksum and is accepted
+	if _, err := ChainEthereum.ParseAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"); err != nil {
+		t.Errorf("Expected lower-case address to be accepted: %v", err)
+	}
+}
+
+func TestChainMessageSignature(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate secp256k1 key: %v", err)
+	}
+	pubKey := privKey.PubKey().SerializeCompressed()
+	message := []byte("Hello, TEENet!")
+
+	for _, chain := range []*Chain{ChainEthereum, ChainTron} {
+		hash := chain.MessageHash(message)
+		signature, err := EthereumSignature(hash, btcecdsa.Sign(privKey, hash).Serialize(), pubKey)
+		if err != nil {
+			t.Fatalf("EthereumSignature failed: %v", err)
+		}
+		address, err := chain.Address(pubKey)
+		if err != nil {
+			t.Fatalf("%s Address failed: %v", chain, err)
+		}
+		if ok, err := chain.VerifyMessageAddress(message, signature, address); err != nil || !ok {
+			t.Errorf("%s VerifyMessageAddress = %v, %v", chain, ok, err)
+		}
+	}
+
+	// TRON and Ethereum prefixes differ, so a signature does not carry across
+	hash := ChainEthereum.MessageHash(message)
+	signature, _ := EthereumSignature(hash, btcecdsa.Sign(privKey, hash).Serialize(), pubKey)
+	address, _ := ChainTron.Address(pubKey)
+	if ok, _ := ChainTron.VerifyMessageAddress(message, signature, address); ok {
+		t.Error("Expected an Ethereum message signature to fail on TRON")
+	}
+}
+
+func TestChainRegistry(t *testing.T) {
+	if chain, ok := LookupChainID(137); !ok || chain != ChainPolygon {
+		t.Errorf("LookupChainID(137) = %v, %v", chain, ok)
+	}
+	if chain, ok := LookupChain("BSC"); !ok || chain != ChainBSC {
+		t.Errorf("LookupChain(BSC) = %v, %v", chain, ok)
+	}
+
+	custom := &Chain{Name: "teenet-devnet", ChainID: 990001, AddressFormat: AddressFormatEIP55, MessagePrefix: EthereumMessagePrefix}
+	if err := RegisterChain(custom); err != nil {
+		t.Fatalf("RegisterChain failed: %v", err)
+	}
+	if chain, ok := LookupChain("teenet-devnet"); !ok || chain != custom {
+		t.Error("Expected registered chain to be found")
+	}
+	if err := RegisterChain(&Chain{Name: "other", ChainID: 1, AddressFormat: AddressFormatEIP55, MessagePrefix: EthereumMessagePrefix}); err == nil {
+		t.Error("Expected duplicate chain ID to be rejected")
+	}
+	if err := RegisterChain(&Chain{Name: "bad", ChainID: 990002, AddressFormat: "bech32", MessagePrefix: EthereumMessagePrefix}); err == nil {
+		t.Error("Expected unknown address format to be rejected")
+	}
+
+	list := Chains()
+	for i := 1; i < len(list); i++ {
+		if list[i-1].ChainID >= list[i].ChainID {
+			t.Fatal("Expected chains ordered by chain ID")
+		}
+	}
+
+	if v := ChainBSC.LegacyV(1); v != 56*2+36 {
+		t.Errorf("LegacyV = %d, expected %d", v, 56*2+36)
+	}
+}
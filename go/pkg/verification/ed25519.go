@@ -0,0 +1,72 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package verification
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"fmt"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+)
+
+// MaxED25519ContextSize is the maximum context string length allowed by RFC 8032
+const MaxED25519ContextSize = 255
+
+// ED25519Options selects the RFC 8032 Ed25519 variant used for verification
+type ED25519Options struct {
+	Mode    uint32 // constants.ED25519ModePure, ED25519ModePh or ED25519ModeCtx
+	Context []byte // Context string (up to 255 bytes), required for Ed25519ctx
+}
+
+// VerifyED25519WithOptions verifies an Ed25519, Ed25519ph or Ed25519ctx signature
+// For Ed25519ph, message is the original message; it is prehashed with SHA-512 here
+func VerifyED25519WithOptions(message, publicKey, signature []byte, opts *ED25519Options) (bool, error) {
+	if opts == nil || opts.Mode == constants.ED25519ModePure {
+		if opts != nil && len(opts.Context) > 0 {
+			return false, fmt.Errorf("context is not supported for pure Ed25519, use Ed25519ctx")
+		}
+		return verifyED25519(message, publicKey, signature)
+	}
+	if len(publicKey) != ed25519.PublicKeySize {
+		return false, fmt.Errorf("invalid ED25519 public key size: expected %d, got %d", ed25519.PublicKeySize, len(publicKey))
+	}
+	if len(signature) != ed25519.SignatureSize {
+		return false, fmt.Errorf("invalid ED25519 signature size: expected %d, got %d", ed25519.SignatureSize, len(signature))
+	}
+	if len(opts.Context) > MaxED25519ContextSize {
+		return false, fmt.Errorf("ED25519 context too long: maximum %d bytes, got %d", MaxED25519ContextSize, len(opts.Context))
+	}
+
+	var edOpts ed25519.Options
+	switch opts.Mode {
+	case constants.ED25519ModePh:
+		digest := sha512.Sum512(message)
+		message = digest[:]
+		edOpts.Hash = crypto.SHA512
+	case constants.ED25519ModeCtx:
+		if len(opts.Context) == 0 {
+			return false, fmt.Errorf("Ed25519ctx requires a non-empty context")
+		}
+	default:
+		return false, fmt.Errorf("unsupported ED25519 mode: %d", opts.Mode)
+	}
+	edOpts.Context = string(opts.Context)
+
+	if err := ed25519.VerifyWithOptions(ed25519.PublicKey(publicKey), message, signature, &edOpts); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
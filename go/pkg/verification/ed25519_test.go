@@ -0,0 +1,71 @@
+package verification
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+)
+
+func TestED25519Variants(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate ED25519 key: %v", err)
+	}
+	message := []byte("Hello, TEENet!")
+	context := []byte("teenet-domain")
+
+	// Ed25519ph
+	digest := sha512.Sum512(message)
+	phSig, err := privKey.Sign(nil, digest[:], &ed25519.Options{Hash: crypto.SHA512, Context: string(context)})
+	if err != nil {
+		t.Fatalf("Failed to sign Ed25519ph: %v", err)
+	}
+	valid, err := VerifyED25519WithOptions(message, pubKey, phSig, &ED25519Options{Mode: constants.ED25519ModePh, Context: context})
+	if err != nil {
+		t.Fatalf("Ed25519ph verification failed with error: %v", err)
+	}
+	if !valid {
+		t.Error("Valid Ed25519ph signature not verified")
+	}
+
+	// Ed25519ctx
+	ctxSig, err := privKey.Sign(nil, message, &ed25519.Options{Context: string(context)})
+	if err != nil {
+		t.Fatalf("Failed to sign Ed25519ctx: %v", err)
+	}
+	valid, err = VerifyED25519WithOptions(message, pubKey, ctxSig, &ED25519Options{Mode: constants.ED25519ModeCtx, Context: context})
+	if err != nil {
+		t.Fatalf("Ed25519ctx verification failed with error: %v", err)
+	}
+	if !valid {
+		t.Error("Valid Ed25519ctx signature not verified")
+	}
+
+	// Domain separation: a context signature must not verify under another context or as pure Ed25519
+	valid, _ = VerifyED25519WithOptions(message, pubKey, ctxSig, &ED25519Options{Mode: constants.ED25519ModeCtx, Context: []byte("other")})
+	if valid {
+		t.Error("Ed25519ctx signature verified under a different context")
+	}
+	valid, _ = VerifySignature(message, pubKey, ctxSig, 0, constants.CurveED25519)
+	if valid {
+		t.Error("Ed25519ctx signature verified as pure Ed25519")
+	}
+
+	// Pure mode falls back to plain Ed25519
+	pureSig := ed25519.Sign(privKey, message)
+	valid, err = VerifyED25519WithOptions(message, pubKey, pureSig, nil)
+	if err != nil || !valid {
+		t.Errorf("Pure Ed25519 signature not verified (err: %v)", err)
+	}
+
+	if _, err := VerifyED25519WithOptions(message, pubKey, ctxSig, &ED25519Options{Mode: constants.ED25519ModeCtx}); err == nil {
+		t.Error("Expected error for Ed25519ctx without context")
+	}
+	if _, err := VerifyED25519WithOptions(message, pubKey, ctxSig, &ED25519Options{Mode: constants.ED25519ModeCtx, Context: make([]byte, 256)}); err == nil {
+		t.Error("Expected error for oversized context")
+	}
+}
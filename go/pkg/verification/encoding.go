@@ -0,0 +1,131 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package verification
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+)
+
+// Signature encoding sizes
+const (
+	// RawSignatureSize is the size of a raw R||S signature
+	RawSignatureSize = 64
+	// CompactSignatureSize is the size of a recoverable R||S||V signature
+	CompactSignatureSize = 65
+)
+
+// DERToRaw converts a strictly DER-encoded ECDSA signature into raw R||S form (64 bytes)
+// The encoding must be canonical and R and S must be positive and at most 32 bytes
+func DERToRaw(der []byte) ([]byte, error) {
+	var sig ECDSASignature
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DER signature: %v", err)
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("invalid DER signature: %d trailing bytes", len(rest))
+	}
+	if err := validateSignatureComponents(sig.R, sig.S); err != nil {
+		return nil, err
+	}
+
+	// Reject non-canonical encodings (e.g. long-form lengths) that asn1 tolerates
+	canonical, err := asn1.Marshal(sig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-encode DER signature: %v", err)
+	}
+	if !bytes.Equal(canonical, der) {
+		return nil, fmt.Errorf("invalid DER signature: non-canonical encoding")
+	}
+
+	raw := make([]byte, RawSignatureSize)
+	sig.R.FillBytes(raw[:32])
+	sig.S.FillBytes(raw[32:])
+	return raw, nil
+}
+
+// RawToDER converts a raw R||S signature (64 bytes) into DER encoding
+func RawToDER(raw []byte) ([]byte, error) {
+	if len(raw) != RawSignatureSize {
+		return nil, fmt.Errorf("invalid raw signature length: expected %d, got %d", RawSignatureSize, len(raw))
+	}
+	sig := splitRawSignature(raw)
+	if err := validateSignatureComponents(sig.R, sig.S); err != nil {
+		return nil, err
+	}
+
+	der, err := asn1.Marshal(*sig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode DER signature: %v", err)
+	}
+	return der, nil
+}
+
+// RawToCompact appends a recovery ID to a raw R||S signature, producing the 65-byte R||S||V form
+// recoveryID must be 0 or 1; it is stored as-is without the Ethereum 27 offset
+func RawToCompact(raw []byte, recoveryID byte) ([]byte, error) {
+	if len(raw) != RawSignatureSize {
+		return nil, fmt.Errorf("invalid raw signature length: expected %d, got %d", RawSignatureSize, len(raw))
+	}
+	if recoveryID > 1 {
+		return nil, fmt.Errorf("invalid recovery ID: %d", recoveryID)
+	}
+	sig := splitRawSignature(raw)
+	if err := validateSignatureComponents(sig.R, sig.S); err != nil {
+		return nil, err
+	}
+
+	compact := make([]byte, CompactSignatureSize)
+	copy(compact, raw)
+	compact[RawSignatureSize] = recoveryID
+	return compact, nil
+}
+
+// CompactToRaw splits a 65-byte R||S||V signature into raw R||S form and its recovery ID
+// V may be 0/1 or carry the Ethereum 27 offset (27/28); the returned recovery ID is always 0 or 1
+func CompactToRaw(compact []byte) ([]byte, byte, error) {
+	if len(compact) != CompactSignatureSize {
+		return nil, 0, fmt.Errorf("invalid compact signature length: expected %d, got %d", CompactSignatureSize, len(compact))
+	}
+
+	recoveryID := compact[RawSignatureSize]
+	if recoveryID >= 27 {
+		recoveryID -= 27
+	}
+	if recoveryID > 1 {
+		return nil, 0, fmt.Errorf("invalid recovery ID: %d", compact[RawSignatureSize])
+	}
+
+	raw := make([]byte, RawSignatureSize)
+	copy(raw, compact[:RawSignatureSize])
+	sig := splitRawSignature(raw)
+	if err := validateSignatureComponents(sig.R, sig.S); err != nil {
+		return nil, 0, err
+	}
+	return raw, recoveryID, nil
+}
+
+// validateSignatureComponents checks that R and S are positive and fit in 32 bytes
+func validateSignatureComponents(r, s *big.Int) error {
+	if r == nil || s == nil || r.Sign() <= 0 || s.Sign() <= 0 {
+		return fmt.Errorf("invalid signature: r or s is zero or negative")
+	}
+	if r.BitLen() > 256 || s.BitLen() > 256 {
+		return fmt.Errorf("invalid signature: r or s exceeds 32 bytes")
+	}
+	return nil
+}
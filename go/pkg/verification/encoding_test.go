@@ -0,0 +1,96 @@
+package verification
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+func TestDERRawConversion(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate secp256k1 key: %v", err)
+	}
+	hash := sha256.Sum256([]byte("Hello, TEENet!"))
+	derSig := btcecdsa.Sign(privKey, hash[:]).Serialize()
+
+	raw, err := DERToRaw(derSig)
+	if err != nil {
+		t.Fatalf("DERToRaw failed: %v", err)
+	}
+	if len(raw) != RawSignatureSize {
+		t.Fatalf("Expected %d byte raw signature, got %d", RawSignatureSize, len(raw))
+	}
+
+	der, err := RawToDER(raw)
+	if err != nil {
+		t.Fatalf("RawToDER failed: %v", err)
+	}
+	if !bytes.Equal(der, derSig) {
+		t.Errorf("Round trip mismatch:\n got  %x\n want %x", der, derSig)
+	}
+
+	// Trailing data is rejected
+	if _, err := DERToRaw(append(append([]byte{}, derSig...), 0x00)); err == nil {
+		t.Error("Expected error for DER signature with trailing bytes")
+	}
+
+	// Long-form length encoding is rejected as non-canonical
+	nonCanonical := append([]byte{0x30, 0x81, derSig[1]}, derSig[2:]...)
+	if _, err := DERToRaw(nonCanonical); err == nil {
+		t.Error("Expected error for non-canonical DER signature")
+	}
+
+	// Zero components are rejected
+	if _, err := RawToDER(make([]byte, RawSignatureSize)); err == nil {
+		t.Error("Expected error for zero raw signature")
+	}
+	if _, err := RawToDER(raw[:63]); err == nil {
+		t.Error("Expected error for short raw signature")
+	}
+}
+
+func TestCompactConversion(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate secp256k1 key: %v", err)
+	}
+	hash := sha256.Sum256([]byte("Hello, TEENet!"))
+	raw, err := DERToRaw(btcecdsa.Sign(privKey, hash[:]).Serialize())
+	if err != nil {
+		t.Fatalf("DERToRaw failed: %v", err)
+	}
+
+	compact, err := RawToCompact(raw, 1)
+	if err != nil {
+		t.Fatalf("RawToCompact failed: %v", err)
+	}
+	if len(compact) != CompactSignatureSize || compact[64] != 1 {
+		t.Fatalf("Unexpected compact signature: %x", compact)
+	}
+
+	back, recoveryID, err := CompactToRaw(compact)
+	if err != nil {
+		t.Fatalf("CompactToRaw failed: %v", err)
+	}
+	if !bytes.Equal(back, raw) || recoveryID != 1 {
+		t.Error("Compact round trip mismatch")
+	}
+
+	// Ethereum-style V values are normalized
+	compact[64] = 27
+	if _, recoveryID, err := CompactToRaw(compact); err != nil || recoveryID != 0 {
+		t.Errorf("Expected recovery ID 0 for V=27, got %d (err: %v)", recoveryID, err)
+	}
+
+	compact[64] = 5
+	if _, _, err := CompactToRaw(compact); err == nil {
+		t.Error("Expected error for invalid recovery ID")
+	}
+	if _, err := RawToCompact(raw, 2); err == nil {
+		t.Error("Expected error for out-of-range recovery ID")
+	}
+}
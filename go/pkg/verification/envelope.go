@@ -0,0 +1,145 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package verification
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+)
+
+// EnvelopeVersion is the envelope format produced by this package
+const EnvelopeVersion = 1
+
+// HashAlgorithm names the hash a protocol applies to the message before signing
+type HashAlgorithm string
+
+const (
+	// HashSHA256 is used by ECDSA and Schnorr on secp256k1 and secp256r1
+	HashSHA256 HashAlgorithm = "sha256"
+	// HashSHA512 is used internally by Ed25519
+	HashSHA512 HashAlgorithm = "sha512"
+)
+
+// ErrEnvelopeSignature is returned by OpenEnvelope when the signature does not verify
+var ErrEnvelopeSignature = errors.New("envelope signature is invalid")
+
+// HashAlgorithmFor returns the hash VerifySignature applies for a protocol and curve
+func HashAlgorithmFor(protocol constants.Protocol, curve constants.Curve) HashAlgorithm {
+	if curve == constants.CurveED25519 {
+		return HashSHA512
+	}
+	return HashSHA256
+}
+
+// Envelope is a self-describing signature: everything needed to verify it except the message
+// and a trusted public key. It is encoded as JSON, with the signature in base64
+type Envelope struct {
+	Version    int                `json:"version"`
+	AppID      string             `json:"app_id"`
+	KeyVersion uint32             `json:"key_version,omitempty"` // Zero if the app node does not version keys
+	Protocol   constants.Protocol `json:"protocol"`
+	Curve      constants.Curve    `json:"curve"`
+	Hash       HashAlgorithm      `json:"hash"`
+	Domain     string             `json:"domain,omitempty"` // Domain separation tag the message was signed under
+	Signature  []byte             `json:"signature"`
+	Timestamp  time.Time          `json:"timestamp"`
+}
+
+// Marshal encodes the envelope
+func (e *Envelope) Marshal() ([]byte, error) {
+	if err := e.validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(e)
+}
+
+// SignedMessage returns the bytes the signature covers: message, or its DomainMessage if the
+// envelope carries a domain separation tag
+func (e *Envelope) SignedMessage(message []byte) []byte {
+	if e.Domain == "" {
+		return message
+	}
+	return DomainMessage(e.Domain, e.AppID, message)
+}
+
+// ParseEnvelope decodes and validates an envelope without verifying its signature, e.g. to
+// read the app ID and key version before fetching the key to verify it with
+func ParseEnvelope(data []byte) (*Envelope, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var envelope Envelope
+	if err := decoder.Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode envelope: %w", err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("failed to decode envelope: unexpected data after envelope")
+	}
+	if err := envelope.validate(); err != nil {
+		return nil, err
+	}
+	return &envelope, nil
+}
+
+// OpenEnvelope parses an envelope and verifies its signature over message with publicKey.
+// The key must come from a trusted source, e.g. the app's key for the envelope's key version;
+// callers should also check the app ID and domain are the ones they expect. A signature that
+// does not verify returns ErrEnvelopeSignature
+func OpenEnvelope(data, message, publicKey []byte) (*Envelope, error) {
+	envelope, err := ParseEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+	valid, err := VerifySignature(envelope.SignedMessage(message), publicKey, envelope.Signature, envelope.Protocol, envelope.Curve)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify envelope of %s: %w", envelope.AppID, err)
+	}
+	if !valid {
+		return nil, ErrEnvelopeSignature
+	}
+	return envelope, nil
+}
+
+// validate checks the envelope is complete and internally consistent
+func (e *Envelope) validate() error {
+	switch {
+	case e.Version != EnvelopeVersion:
+		return fmt.Errorf("unsupported envelope version %d", e.Version)
+	case e.AppID == "":
+		return fmt.Errorf("envelope has no app ID")
+	case len(e.Signature) == 0:
+		return fmt.Errorf("envelope has no signature")
+	case e.Timestamp.IsZero():
+		return fmt.Errorf("envelope has no timestamp")
+	case e.Hash != HashAlgorithmFor(e.Protocol, e.Curve):
+		return fmt.Errorf("envelope hash %q does not match %s on %s", e.Hash, e.Protocol, e.Curve)
+	}
+	return nil
+}
+
+// DomainMessage returns the bytes signed for message under domain separation:
+// tag || 0x00 || appID || 0x00 || message
+func DomainMessage(tag, appID string, message []byte) []byte {
+	prefixed := make([]byte, 0, len(tag)+len(appID)+2+len(message))
+	prefixed = append(prefixed, tag...)
+	prefixed = append(prefixed, 0)
+	prefixed = append(prefixed, appID...)
+	prefixed = append(prefixed, 0)
+	return append(prefixed, message...)
+}
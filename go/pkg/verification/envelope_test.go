@@ -0,0 +1,128 @@
+package verification
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate secp256k1 key: %v", err)
+	}
+	pubKey := privKey.PubKey().SerializeCompressed()
+	message := []byte("Hello, envelope!")
+	signed := DomainMessage("TEENET-SIGN-V1", "app-1", message)
+	hash := sha256.Sum256(signed)
+
+	envelope := &Envelope{
+		Version:    EnvelopeVersion,
+		AppID:      "app-1",
+		KeyVersion: 3,
+		Protocol:   constants.ProtocolECDSA,
+		Curve:      constants.CurveSECP256K1,
+		Hash:       HashSHA256,
+		Domain:     "TEENET-SIGN-V1",
+		Signature:  btcecdsa.Sign(privKey, hash[:]).Serialize(),
+		Timestamp:  time.Unix(1700000000, 0).UTC(),
+	}
+	data, err := envelope.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	opened, err := OpenEnvelope(data, message, pubKey)
+	if err != nil {
+		t.Fatalf("OpenEnvelope failed: %v", err)
+	}
+	if opened.AppID != "app-1" || opened.KeyVersion != 3 || opened.Curve != constants.CurveSECP256K1 || !opened.Timestamp.Equal(envelope.Timestamp) {
+		t.Errorf("Expected the sealed fields back, got %+v", opened)
+	}
+
+	if _, err := OpenEnvelope(data, []byte("tampered"), pubKey); !errors.Is(err, ErrEnvelopeSignature) {
+		t.Errorf("Expected ErrEnvelopeSignature for another message, got %v", err)
+	}
+}
+
+func TestEnvelopeEd25519(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate ED25519 key: %v", err)
+	}
+	message := []byte("Hello, ED25519!")
+	envelope := &Envelope{
+		Version:   EnvelopeVersion,
+		AppID:     "app-2",
+		Protocol:  constants.ProtocolSchnorr,
+		Curve:     constants.CurveED25519,
+		Hash:      HashAlgorithmFor(constants.ProtocolSchnorr, constants.CurveED25519),
+		Signature: ed25519.Sign(privKey, message),
+		Timestamp: time.Now(),
+	}
+	data, err := envelope.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if _, err := OpenEnvelope(data, message, pubKey); err != nil {
+		t.Errorf("OpenEnvelope failed: %v", err)
+	}
+}
+
+func TestParseEnvelopeRejects(t *testing.T) {
+	valid := map[string]any{
+		"version":   EnvelopeVersion,
+		"app_id":    "app-1",
+		"protocol":  "ecdsa",
+		"curve":     "secp256k1",
+		"hash":      "sha256",
+		"signature": "AQID",
+		"timestamp": "2024-01-01T00:00:00Z",
+	}
+	tests := []struct {
+		name    string
+		field   string
+		value   any
+		wantErr string
+	}{
+		{"future version", "version", 2, "unsupported envelope version"},
+		{"no app ID", "app_id", "", "no app ID"},
+		{"no signature", "signature", "", "no signature"},
+		{"no timestamp", "timestamp", "0001-01-01T00:00:00Z", "no timestamp"},
+		{"wrong hash", "hash", "sha512", "does not match"},
+		{"unknown field", "extra", true, "unknown field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := make(map[string]any, len(valid)+1)
+			for k, v := range valid {
+				fields[k] = v
+			}
+			fields[tt.field] = tt.value
+			data, _ := json.Marshal(fields)
+			if _, err := ParseEnvelope(data); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	data, _ := json.Marshal(valid)
+	if _, err := ParseEnvelope(data); err != nil {
+		t.Errorf("Expected valid envelope to parse, got %v", err)
+	}
+	if _, err := ParseEnvelope(append(data, " {}"...)); err == nil || !strings.Contains(err.Error(), "after envelope") {
+		t.Errorf("Expected trailing data to be rejected, got %v", err)
+	}
+	if _, err := ParseEnvelope(append(data, "\n"...)); err != nil {
+		t.Errorf("Expected trailing whitespace to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package verification
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+// EthereumMessagePrefix is the EIP-191 (version 0x45) personal_sign prefix
+const EthereumMessagePrefix = "\x19Ethereum Signed Message:\n"
+
+// EthereumMessageHash returns keccak256("\x19Ethereum Signed Message:\n" + len(message) + message)
+func EthereumMessageHash(message []byte) []byte {
+	prefix := EthereumMessagePrefix + strconv.Itoa(len(message))
+	return Keccak256([]byte(prefix), message)
+}
+
+// EthereumAddress derives the EIP-55 checksummed address of a secp256k1 public key
+func EthereumAddress(publicKey []byte) (string, error) {
+	pubKey, err := parseSecp256k1PublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	return ethereumAddressFromKey(pubKey), nil
+}
+
+// EthereumSignature converts a secp256k1 ECDSA signature over hash into the 65-byte
+// R || S || V form used by Ethereum wallets (V = 27/28)
+// S is normalized to the lower half of the curve order, and the recovery ID is
+// derived by matching the recovered key against publicKey
+func EthereumSignature(hash, signature, publicKey []byte) ([]byte, error) {
+	compact, err := EthereumDigestSignature(hash, signature, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	compact[RawSignatureSize] += 27
+	return compact, nil
+}
+
+// EthereumDigestSignature converts a secp256k1 ECDSA signature over a 32-byte digest, such as a
+// transaction signing hash, into the 65-byte R || S || V form with V = 0/1, the y-parity carried
+// by typed (EIP-2718) transactions. S is normalized to the lower half of the curve order
+func EthereumDigestSignature(digest, signature, publicKey []byte) ([]byte, error) {
+	if len(digest) != 32 {
+		return nil, fmt.Errorf("invalid digest length: expected 32, got %d", len(digest))
+	}
+	raw, recoveryID, err := recoverableSignature(digest, signature, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return RawToCompact(raw, recoveryID)
+}
+
+// VerifyEthereumMessage verifies an EIP-191 personal_sign signature against a secp256k1 public key
+// The signature may be 65 bytes (R || S || V) or raw R || S (64 bytes)
+func VerifyEthereumMessage(message, publicKey, signature []byte) (bool, error) {
+	pubKey, err := parseSecp256k1PublicKey(publicKey)
+	if err != nil {
+		return false, err
+	}
+
+	raw := signature
+	if len(signature) == CompactSignatureSize {
+		raw, _, err = CompactToRaw(signature)
+		if err != nil {
+			return false, err
+		}
+	}
+	sig, err := ParseSignature(constants.ProtocolECDSA, raw)
+	if err != nil {
+		return false, err
+	}
+
+	hash := EthereumMessageHash(message)
+	return ecdsa.Verify(pubKey.ToECDSA(), hash, sig.R, sig.S), nil
+}
+
+// RecoverEthereumAddress recovers the signer address of an EIP-191 personal_sign signature
+func RecoverEthereumAddress(message, signature []byte) (string, error) {
+	return RecoverEthereumDigestAddress(EthereumMessageHash(message), signature)
+}
+
+// RecoverEthereumDigestAddress recovers the signer address of a 65-byte R || S || V signature
+// over a 32-byte digest; V may be 0/1 or 27/28
+func RecoverEthereumDigestAddress(digest, signature []byte) (string, error) {
+	raw, recoveryID, err := CompactToRaw(signature)
+	if err != nil {
+		return "", err
+	}
+	pubKey, err := recoverSecp256k1(digest, raw, recoveryID)
+	if err != nil {
+		return "", err
+	}
+	return ethereumAddressFromKey(pubKey), nil
+}
+
+// VerifyEthereumMessageAddress verifies an EIP-191 personal_sign signature against a signer address,
+// the way MetaMask-style verifiers do
+func VerifyEthereumMessageAddress(message, signature []byte, address string) (bool, error) {
+	recovered, err := RecoverEthereumAddress(message, signature)
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(recovered, address), nil
+}
+
+// ethereumAddressFromKey returns the EIP-55 checksummed address for a public key
+func ethereumAddressFromKey(pubKey *btcec.PublicKey) string {
+	hash := Keccak256(pubKey.SerializeUncompressed()[1:])
+	return toChecksumAddress(hash[12:])
+}
+
+// toChecksumAddress applies EIP-55 mixed-case checksum encoding to a 20-byte address
+func toChecksumAddress(address []byte) string {
+	return checksumAddress(address, "")
+}
+
+// checksumAddress applies mixed-case checksum encoding to a 20-byte address, hashing it after
+// hashPrefix: empty for EIP-55, the chain ID followed by "0x" for EIP-1191
+func checksumAddress(address []byte, hashPrefix string) string {
+	lower := hex.EncodeToString(address)
+	hash := Keccak256([]byte(hashPrefix + lower))
+
+	var out bytes.Buffer
+	out.WriteString("0x")
+	for i, c := range lower {
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if c >= 'a' && nibble&0x0f >= 8 {
+			out.WriteRune(c - 'a' + 'A')
+		} else {
+			out.WriteRune(c)
+		}
+	}
+	return out.String()
+}
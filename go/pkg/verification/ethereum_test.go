@@ -0,0 +1,128 @@
+package verification
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+func TestKeccak256(t *testing.T) {
+	vectors := map[string]string{
+		"":    "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+		"abc": "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45",
+	}
+	for input, expected := range vectors {
+		if got := hex.EncodeToString(Keccak256([]byte(input))); got != expected {
+			t.Errorf("Keccak256(%q) = %s, expected %s", input, got, expected)
+		}
+	}
+
+	// Inputs spanning multiple blocks must hash the same whether split or not
+	long := make([]byte, 300)
+	if hex.EncodeToString(Keccak256(long)) != hex.EncodeToString(Keccak256(long[:100], long[100:])) {
+		t.Error("Keccak256 of split input does not match")
+	}
+}
+
+func TestEthereumAddress(t *testing.T) {
+	// Private key 1 has a well-known address
+	privBytes := make([]byte, 32)
+	privBytes[31] = 1
+	_, pubKey := btcec.PrivKeyFromBytes(privBytes)
+
+	address, err := EthereumAddress(pubKey.SerializeCompressed())
+	if err != nil {
+		t.Fatalf("EthereumAddress failed: %v", err)
+	}
+	if address != "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf" {
+		t.Errorf("Unexpected address: %s", address)
+	}
+}
+
+func TestEthereumMessageVerification(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate secp256k1 key: %v", err)
+	}
+	pubKey := privKey.PubKey().SerializeCompressed()
+	message := []byte("Hello, TEENet!")
+	hash := EthereumMessageHash(message)
+
+	// Convert a plain DER signature over the EIP-191 hash into wallet format
+	ethSig, err := EthereumSignature(hash, btcecdsa.Sign(privKey, hash).Serialize(), pubKey)
+	if err != nil {
+		t.Fatalf("EthereumSignature failed: %v", err)
+	}
+	if len(ethSig) != CompactSignatureSize || (ethSig[64] != 27 && ethSig[64] != 28) {
+		t.Fatalf("Unexpected Ethereum signature: %x", ethSig)
+	}
+
+	valid, err := VerifyEthereumMessage(message, pubKey, ethSig)
+	if err != nil {
+		t.Fatalf("VerifyEthereumMessage failed: %v", err)
+	}
+	if !valid {
+		t.Error("Valid Ethereum signature not verified")
+	}
+
+	address, err := EthereumAddress(pubKey)
+	if err != nil {
+		t.Fatalf("EthereumAddress failed: %v", err)
+	}
+	valid, err = VerifyEthereumMessageAddress(message, ethSig, address)
+	if err != nil {
+		t.Fatalf("VerifyEthereumMessageAddress failed: %v", err)
+	}
+	if !valid {
+		t.Error("Signature did not recover to the signer address")
+	}
+
+	valid, err = VerifyEthereumMessage([]byte("Wrong message"), pubKey, ethSig)
+	if err != nil {
+		t.Fatalf("VerifyEthereumMessage failed: %v", err)
+	}
+	if valid {
+		t.Error("Signature verified with wrong message")
+	}
+
+	// A signature over a different hash must not be converted
+	otherHash := EthereumMessageHash([]byte("other"))
+	if _, err := EthereumSignature(otherHash, btcecdsa.Sign(privKey, hash).Serialize(), pubKey); err == nil {
+		t.Error("Expected error converting signature over a different hash")
+	}
+}
+
+func TestEthereumDigestSignature(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate secp256k1 key: %v", err)
+	}
+	pubKey := privKey.PubKey().SerializeCompressed()
+	digest := Keccak256([]byte("transaction signing payload"))
+
+	sig, err := EthereumDigestSignature(digest, btcecdsa.Sign(privKey, digest).Serialize(), pubKey)
+	if err != nil {
+		t.Fatalf("EthereumDigestSignature failed: %v", err)
+	}
+	if len(sig) != CompactSignatureSize || sig[64] > 1 {
+		t.Fatalf("Expected y-parity 0 or 1, got signature %x", sig)
+	}
+
+	address, err := EthereumAddress(pubKey)
+	if err != nil {
+		t.Fatalf("EthereumAddress failed: %v", err)
+	}
+	recovered, err := RecoverEthereumDigestAddress(digest, sig)
+	if err != nil {
+		t.Fatalf("RecoverEthereumDigestAddress failed: %v", err)
+	}
+	if recovered != address {
+		t.Errorf("Recovered %s, expected %s", recovered, address)
+	}
+
+	if _, err := EthereumDigestSignature(digest[:31], btcecdsa.Sign(privKey, digest).Serialize(), pubKey); err == nil {
+		t.Error("Expected error for a short digest")
+	}
+}
@@ -0,0 +1,170 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package verification
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+)
+
+// PublicKeyFormat selects the encoding produced by ExportPublicKey
+type PublicKeyFormat string
+
+const (
+	// PublicKeyFormatRaw is the 32-byte ED25519 key or the 64-byte X||Y point for ECDSA curves
+	PublicKeyFormatRaw PublicKeyFormat = "raw"
+	// PublicKeyFormatCompressed is the 33-byte SEC1 compressed point (ECDSA curves only)
+	PublicKeyFormatCompressed PublicKeyFormat = "compressed"
+	// PublicKeyFormatPEM is a PEM encoded X.509 SubjectPublicKeyInfo ("PUBLIC KEY") block
+	PublicKeyFormatPEM PublicKeyFormat = "pem"
+	// PublicKeyFormatSSH is a single OpenSSH authorized_keys line (ED25519 and SECP256R1 only)
+	PublicKeyFormatSSH PublicKeyFormat = "ssh"
+)
+
+var (
+	oidPublicKeyECDSA   = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidPublicKeyED25519 = asn1.ObjectIdentifier{1, 3, 101, 112}
+	oidNamedCurveK256   = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+	oidNamedCurveP256   = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	sshKeyTypeED25519   = "ssh-ed25519"
+	sshKeyTypeP256      = "ecdsa-sha2-nistp256"
+	sshCurveIdentifier  = "nistp256"
+)
+
+// subjectPublicKeyInfo mirrors the X.509 SubjectPublicKeyInfo structure
+type subjectPublicKeyInfo struct {
+	Algorithm algorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// algorithmIdentifier mirrors pkix.AlgorithmIdentifier, kept local so the package does not
+// depend on crypto/x509 (and through it net), which keeps js/wasm and TinyGo builds small
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+// ExportPublicKey re-encodes a public key in any format accepted by ParsePublicKey
+// into the requested format
+func ExportPublicKey(curve constants.Curve, publicKey []byte, format PublicKeyFormat) ([]byte, error) {
+	key, err := ParsePublicKey(curve, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case PublicKeyFormatRaw:
+		if edKey, ok := key.(ed25519.PublicKey); ok {
+			return []byte(edKey), nil
+		}
+		return marshalUncompressed(key.(*ecdsa.PublicKey))[1:], nil
+	case PublicKeyFormatCompressed:
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("compressed format is not supported for curve %s", curve)
+		}
+		return elliptic.MarshalCompressed(ecKey.Curve, ecKey.X, ecKey.Y), nil
+	case PublicKeyFormatPEM:
+		der, err := marshalSubjectPublicKeyInfo(curve, key)
+		if err != nil {
+			return nil, err
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+	case PublicKeyFormatSSH:
+		return marshalAuthorizedKey(curve, key)
+	default:
+		return nil, fmt.Errorf("unsupported public key format: %q", format)
+	}
+}
+
+// marshalUncompressed returns the 65-byte 0x04 || X || Y encoding of an ECDSA public key
+func marshalUncompressed(key *ecdsa.PublicKey) []byte {
+	out := make([]byte, 65)
+	out[0] = 0x04
+	key.X.FillBytes(out[1:33])
+	key.Y.FillBytes(out[33:])
+	return out
+}
+
+// marshalSubjectPublicKeyInfo DER encodes a key as SubjectPublicKeyInfo (RFC 5480, RFC 8410, SEC 2)
+func marshalSubjectPublicKeyInfo(curve constants.Curve, key any) ([]byte, error) {
+	var algorithm algorithmIdentifier
+	var point []byte
+	switch key := key.(type) {
+	case ed25519.PublicKey:
+		algorithm.Algorithm = oidPublicKeyED25519
+		point = key
+	case *ecdsa.PublicKey:
+		curveOID := oidNamedCurveP256
+		if curve == constants.CurveSECP256K1 {
+			curveOID = oidNamedCurveK256
+		}
+		params, err := asn1.Marshal(curveOID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal curve OID: %w", err)
+		}
+		algorithm = algorithmIdentifier{Algorithm: oidPublicKeyECDSA, Parameters: asn1.RawValue{FullBytes: params}}
+		point = marshalUncompressed(key)
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+
+	der, err := asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: algorithm,
+		PublicKey: asn1.BitString{Bytes: point, BitLength: len(point) * 8},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	return der, nil
+}
+
+// marshalAuthorizedKey encodes a key as an OpenSSH authorized_keys line (RFC 4253, RFC 5656, RFC 8709)
+func marshalAuthorizedKey(curve constants.Curve, key any) ([]byte, error) {
+	var keyType string
+	var blob bytes.Buffer
+	switch curve {
+	case constants.CurveED25519:
+		keyType = sshKeyTypeED25519
+		writeSSHString(&blob, []byte(keyType))
+		writeSSHString(&blob, key.(ed25519.PublicKey))
+	case constants.CurveSECP256R1:
+		keyType = sshKeyTypeP256
+		writeSSHString(&blob, []byte(keyType))
+		writeSSHString(&blob, []byte(sshCurveIdentifier))
+		writeSSHString(&blob, marshalUncompressed(key.(*ecdsa.PublicKey)))
+	default:
+		return nil, fmt.Errorf("OpenSSH format is not supported for curve %s", curve)
+	}
+
+	line := keyType + " " + base64.StdEncoding.EncodeToString(blob.Bytes()) + "\n"
+	return []byte(line), nil
+}
+
+// writeSSHString appends a uint32 length-prefixed string in SSH wire format
+func writeSSHString(buf *bytes.Buffer, data []byte) {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(data)))
+	buf.Write(length[:])
+	buf.Write(data)
+}
@@ -0,0 +1,160 @@
+package verification
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+func TestExportPublicKeyRoundTrip(t *testing.T) {
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate ED25519 key: %v", err)
+	}
+	k1Priv, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate secp256k1 key: %v", err)
+	}
+	r1Priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate P-256 key: %v", err)
+	}
+
+	keys := map[constants.Curve][]byte{
+		constants.CurveED25519:   edPub,
+		constants.CurveSECP256K1: k1Priv.PubKey().SerializeCompressed(),
+		constants.CurveSECP256R1: elliptic.Marshal(elliptic.P256(), r1Priv.X, r1Priv.Y),
+	}
+	for curve, keyBytes := range keys {
+		raw, err := ExportPublicKey(curve, keyBytes, PublicKeyFormatRaw)
+		if err != nil {
+			t.Fatalf("Failed to export %s key as raw: %v", curve, err)
+		}
+		reparsed, err := ExportPublicKey(curve, raw, PublicKeyFormatRaw)
+		if err != nil || !bytes.Equal(raw, reparsed) {
+			t.Errorf("Raw %s key did not round trip: %v", curve, err)
+		}
+
+		if curve == constants.CurveED25519 {
+			if _, err := ExportPublicKey(curve, keyBytes, PublicKeyFormatCompressed); err == nil {
+				t.Error("Expected error for compressed ED25519 key")
+			}
+			continue
+		}
+		compressed, err := ExportPublicKey(curve, keyBytes, PublicKeyFormatCompressed)
+		if err != nil {
+			t.Fatalf("Failed to export %s key as compressed: %v", curve, err)
+		}
+		if len(compressed) != 33 {
+			t.Errorf("Expected 33-byte compressed %s key, got %d", curve, len(compressed))
+		}
+	}
+}
+
+func TestExportPublicKeyPEM(t *testing.T) {
+	r1Priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate P-256 key: %v", err)
+	}
+	out, err := ExportPublicKey(constants.CurveSECP256R1, elliptic.Marshal(elliptic.P256(), r1Priv.X, r1Priv.Y), PublicKeyFormatPEM)
+	if err != nil {
+		t.Fatalf("Failed to export PEM: %v", err)
+	}
+	block, _ := pem.Decode(out)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		t.Fatalf("Expected PUBLIC KEY PEM block, got %q", out)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("Failed to parse SubjectPublicKeyInfo: %v", err)
+	}
+	if !r1Priv.PublicKey.Equal(key) {
+		t.Error("PEM public key does not match")
+	}
+
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate ED25519 key: %v", err)
+	}
+	out, err = ExportPublicKey(constants.CurveED25519, edPub, PublicKeyFormatPEM)
+	if err != nil {
+		t.Fatalf("Failed to export ED25519 PEM: %v", err)
+	}
+	block, _ = pem.Decode(out)
+	if block == nil {
+		t.Fatal("Failed to decode ED25519 PEM")
+	}
+	if key, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil || !edPub.Equal(key) {
+		t.Errorf("ED25519 PEM public key does not match (%v)", err)
+	}
+
+	// secp256k1 is encoded by hand; check the structure and curve OID
+	k1Priv, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate secp256k1 key: %v", err)
+	}
+	out, err = ExportPublicKey(constants.CurveSECP256K1, k1Priv.PubKey().SerializeCompressed(), PublicKeyFormatPEM)
+	if err != nil {
+		t.Fatalf("Failed to export secp256k1 PEM: %v", err)
+	}
+	block, _ = pem.Decode(out)
+	if block == nil {
+		t.Fatal("Failed to decode secp256k1 PEM")
+	}
+	var spki subjectPublicKeyInfo
+	if _, err := asn1.Unmarshal(block.Bytes, &spki); err != nil {
+		t.Fatalf("Failed to unmarshal secp256k1 SubjectPublicKeyInfo: %v", err)
+	}
+	var curveOID asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(spki.Algorithm.Parameters.FullBytes, &curveOID); err != nil || !curveOID.Equal(oidNamedCurveK256) {
+		t.Errorf("Expected secp256k1 curve OID, got %v (%v)", curveOID, err)
+	}
+	if !bytes.Equal(spki.PublicKey.Bytes, k1Priv.PubKey().SerializeUncompressed()) {
+		t.Error("secp256k1 SubjectPublicKeyInfo point does not match")
+	}
+}
+
+func TestExportPublicKeySSH(t *testing.T) {
+	// RFC 8709 test key: 32 zero bytes encode to a fixed blob
+	out, err := ExportPublicKey(constants.CurveED25519, make([]byte, 32), PublicKeyFormatSSH)
+	if err != nil {
+		t.Fatalf("Failed to export ED25519 SSH key: %v", err)
+	}
+	expected := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA\n"
+	if string(out) != expected {
+		t.Errorf("Unexpected SSH key line: %q", out)
+	}
+
+	r1Priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate P-256 key: %v", err)
+	}
+	out, err = ExportPublicKey(constants.CurveSECP256R1, elliptic.Marshal(elliptic.P256(), r1Priv.X, r1Priv.Y), PublicKeyFormatSSH)
+	if err != nil {
+		t.Fatalf("Failed to export P-256 SSH key: %v", err)
+	}
+	if !strings.HasPrefix(string(out), "ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTY") {
+		t.Errorf("Unexpected SSH key line: %q", out)
+	}
+
+	k1Priv, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate secp256k1 key: %v", err)
+	}
+	if _, err := ExportPublicKey(constants.CurveSECP256K1, k1Priv.PubKey().SerializeCompressed(), PublicKeyFormatSSH); err == nil {
+		t.Error("Expected error for secp256k1 SSH export")
+	}
+	if _, err := ExportPublicKey(constants.CurveED25519, make([]byte, 32), "jwk"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
@@ -0,0 +1,186 @@
+package verification
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+// Fuzz targets for parsers that consume untrusted input. Run one with e.g.
+//
+//	go test -run '^$' -fuzz FuzzParsePublicKey -fuzztime 1m .
+
+var fuzzCurves = []constants.Curve{constants.CurveED25519, constants.CurveSECP256K1, constants.CurveSECP256R1}
+
+var fuzzProtocols = []constants.Protocol{constants.ProtocolECDSA, constants.ProtocolSchnorr}
+
+// fuzzKeys returns fixed valid public keys for each curve, so fuzzed signatures reach verification
+func fuzzKeys() map[constants.Curve][]byte {
+	k1 := fuzzSecp256k1Key()
+	r1 := &ecdsa.PublicKey{Curve: elliptic.P256(), X: elliptic.P256().Params().Gx, Y: elliptic.P256().Params().Gy}
+	return map[constants.Curve][]byte{
+		constants.CurveED25519:   bytes.Repeat([]byte{0x42}, 32),
+		constants.CurveSECP256K1: k1.PubKey().SerializeCompressed(),
+		constants.CurveSECP256R1: elliptic.MarshalCompressed(r1.Curve, r1.X, r1.Y),
+	}
+}
+
+func fuzzSecp256k1Key() *btcec.PrivateKey {
+	privKey, _ := btcec.PrivKeyFromBytes(bytes.Repeat([]byte{0x07}, 32))
+	return privKey
+}
+
+func FuzzParsePublicKey(f *testing.F) {
+	for curve, key := range fuzzKeys() {
+		f.Add(uint8(curve), key)
+	}
+	k1 := fuzzSecp256k1Key().PubKey()
+	f.Add(uint8(constants.CurveSECP256K1), k1.SerializeUncompressed())
+	f.Add(uint8(constants.CurveSECP256K1), k1.SerializeUncompressed()[1:])
+	f.Add(uint8(constants.CurveSECP256R1), make([]byte, 64))
+	f.Add(uint8(constants.CurveSECP256R1), append([]byte{0x04}, make([]byte, 64)...))
+
+	f.Fuzz(func(t *testing.T, curveIndex uint8, publicKey []byte) {
+		curve := fuzzCurves[int(curveIndex)%len(fuzzCurves)]
+		key, err := ParsePublicKey(curve, publicKey)
+		if err != nil {
+			return
+		}
+		if ecKey, ok := key.(*ecdsa.PublicKey); ok && !ecKey.Curve.IsOnCurve(ecKey.X, ecKey.Y) {
+			t.Fatalf("Parsed %s key %x is not on the curve", curve, publicKey)
+		}
+
+		// Every accepted key must survive re-encoding in each format
+		for _, format := range []PublicKeyFormat{PublicKeyFormatRaw, PublicKeyFormatCompressed, PublicKeyFormatPEM} {
+			encoded, err := ExportPublicKey(curve, publicKey, format)
+			if err != nil {
+				if format == PublicKeyFormatCompressed && curve == constants.CurveED25519 {
+					continue
+				}
+				t.Fatalf("Failed to export parsed %s key %x as %s: %v", curve, publicKey, format, err)
+			}
+			if format == PublicKeyFormatPEM {
+				continue
+			}
+			reparsed, err := ParsePublicKey(curve, encoded)
+			if err != nil {
+				t.Fatalf("Failed to reparse %s key exported as %s: %v", curve, format, err)
+			}
+			if !reflect.DeepEqual(reparsed, key) {
+				t.Fatalf("%s key changed after %s round trip", curve, format)
+			}
+		}
+	})
+}
+
+func FuzzParseSignature(f *testing.F) {
+	privKey := fuzzSecp256k1Key()
+	hash := sha256.Sum256([]byte("fuzz"))
+	der := btcecdsa.Sign(privKey, hash[:]).Serialize()
+	raw, _ := DERToRaw(der)
+	f.Add(uint8(0), uint8(constants.CurveSECP256K1), der)
+	f.Add(uint8(0), uint8(constants.CurveSECP256R1), raw)
+	f.Add(uint8(1), uint8(constants.CurveSECP256K1), raw)
+	f.Add(uint8(0), uint8(constants.CurveED25519), append(raw, 27))
+	f.Add(uint8(0), uint8(constants.CurveSECP256K1), []byte{0x30, 0x06, 0x02, 0x01, 0x00, 0x02, 0x01, 0x01})
+	f.Add(uint8(0), uint8(constants.CurveSECP256K1), []byte{0x30, 0x81, 0x06, 0x02, 0x01, 0x01, 0x02, 0x01, 0x01})
+
+	keys := fuzzKeys()
+	f.Fuzz(func(t *testing.T, protocolIndex, curveIndex uint8, signature []byte) {
+		protocol := fuzzProtocols[int(protocolIndex)%len(fuzzProtocols)]
+		curve := fuzzCurves[int(curveIndex)%len(fuzzCurves)]
+
+		if sig, err := ParseSignature(protocol, signature); err == nil && (sig.R == nil || sig.S == nil) {
+			t.Fatalf("ParseSignature returned nil components for %x", signature)
+		}
+
+		// Strict DER parsing only accepts canonical encodings, so it must round trip exactly
+		if raw, err := DERToRaw(signature); err == nil {
+			der, err := RawToDER(raw)
+			if err != nil {
+				t.Fatalf("Failed to re-encode accepted DER signature %x: %v", signature, err)
+			}
+			if !bytes.Equal(der, signature) {
+				t.Fatalf("DER round trip changed %x to %x", signature, der)
+			}
+		}
+
+		if raw, recoveryID, err := CompactToRaw(signature); err == nil {
+			compact, err := RawToCompact(raw, recoveryID)
+			if err != nil {
+				t.Fatalf("Failed to re-encode accepted compact signature %x: %v", signature, err)
+			}
+			if !bytes.Equal(compact[:RawSignatureSize], signature[:RawSignatureSize]) {
+				t.Fatalf("Compact round trip changed %x", signature)
+			}
+		}
+
+		// Verification of arbitrary signatures must fail cleanly
+		if valid, _ := VerifySignature([]byte("fuzz"), keys[curve], signature, protocol, curve); valid && curve == constants.CurveED25519 {
+			t.Fatalf("Fuzzed signature %x verified against a fixed ED25519 key", signature)
+		}
+		_, _ = VerifySignatureWithOptions([]byte("fuzz"), keys[curve], signature, protocol, curve, StrictVerifyOptions())
+	})
+}
+
+func FuzzParseEnvelope(f *testing.F) {
+	privKey := fuzzSecp256k1Key()
+	hash := sha256.Sum256(DomainMessage("TEENET-SIGN-V1", "app-1", []byte("fuzz")))
+	envelope := &Envelope{
+		Version:    EnvelopeVersion,
+		AppID:      "app-1",
+		KeyVersion: 2,
+		Protocol:   constants.ProtocolECDSA,
+		Curve:      constants.CurveSECP256K1,
+		Hash:       HashSHA256,
+		Domain:     "TEENET-SIGN-V1",
+		Signature:  btcecdsa.Sign(privKey, hash[:]).Serialize(),
+		Timestamp:  time.Unix(1700000000, 0).UTC(),
+	}
+	data, err := envelope.Marshal()
+	if err != nil {
+		f.Fatalf("Failed to marshal seed envelope: %v", err)
+	}
+	f.Add(data)
+	f.Add([]byte(`{"version":1,"app_id":"a","protocol":2,"curve":"ed25519","hash":"sha512","signature":"AA==","timestamp":"2024-01-01T00:00:00+08:00"}`))
+	f.Add([]byte(`{"version":1,"app_id":"a","protocol":"ecdsa","curve":"secp256r1","hash":"sha256","signature":null}`))
+
+	publicKey := privKey.PubKey().SerializeCompressed()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		parsed, err := ParseEnvelope(data)
+		if err != nil {
+			return
+		}
+		if !json.Valid(data) {
+			t.Fatalf("ParseEnvelope accepted invalid JSON %q", data)
+		}
+
+		// Accepted envelopes must re-encode to an equivalent envelope
+		encoded, err := parsed.Marshal()
+		if err != nil {
+			t.Fatalf("Failed to marshal parsed envelope %q: %v", data, err)
+		}
+		reparsed, err := ParseEnvelope(encoded)
+		if err != nil {
+			t.Fatalf("Failed to reparse envelope %q: %v", encoded, err)
+		}
+		if !reparsed.Timestamp.Equal(parsed.Timestamp) {
+			t.Fatalf("Timestamp changed after round trip: %v != %v", reparsed.Timestamp, parsed.Timestamp)
+		}
+		reparsed.Timestamp = parsed.Timestamp
+		if !reflect.DeepEqual(reparsed, parsed) {
+			t.Fatalf("Envelope changed after round trip: %+v != %+v", reparsed, parsed)
+		}
+
+		_, _ = OpenEnvelope(data, []byte("fuzz"), publicKey)
+	})
+}
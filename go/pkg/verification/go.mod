@@ -0,0 +1,17 @@
+module github.com/TEENet-io/teenet-sdk/go/pkg/verification
+
+go 1.24.2
+
+require (
+	github.com/TEENet-io/teenet-sdk/go/pkg/constants v0.1.0
+	github.com/btcsuite/btcd/btcec/v2 v2.3.5
+	github.com/btcsuite/btcd/chaincfg/chainhash v1.0.1
+)
+
+require (
+	github.com/decred/dcrd/crypto/blake256 v1.1.0 // indirect
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.4.0 // indirect
+)
+
+// Local builds use the working tree; dependents get the go/pkg/constants/vX.Y.Z tag required above
+replace github.com/TEENet-io/teenet-sdk/go/pkg/constants => ../constants
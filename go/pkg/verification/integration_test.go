@@ -178,8 +178,8 @@ func TestSignatureFormatCompatibility(t *testing.T) {
 			message   []byte
 			pubKey    []byte
 			signature []byte
-			protocol  uint32
-			curve     uint32
+			protocol  constants.Protocol
+			curve     constants.Curve
 			expected  bool
 		}{
 			// Add real test vectors here from known implementations
@@ -210,8 +210,8 @@ func TestErrorHandling(t *testing.T) {
 		message   []byte
 		pubKey    []byte
 		signature []byte
-		protocol  uint32
-		curve     uint32
+		protocol  constants.Protocol
+		curve     constants.Curve
 		expectErr bool
 	}{
 		{
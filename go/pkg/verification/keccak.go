@@ -0,0 +1,110 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package verification
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// keccak256Rate is the sponge rate in bytes for Keccak-256
+const keccak256Rate = 136
+
+var keccakRoundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808A, 0x8000000080008000,
+	0x000000000000808B, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008A, 0x0000000000000088, 0x0000000080008009, 0x000000008000000A,
+	0x000000008000808B, 0x800000000000008B, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800A, 0x800000008000000A,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+var keccakRotations = [24]int{1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14, 27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44}
+
+var keccakPiLanes = [24]int{10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4, 15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1}
+
+// Keccak256 computes the legacy Keccak-256 hash used by Ethereum (not NIST SHA3-256)
+func Keccak256(data ...[]byte) []byte {
+	var state [25]uint64
+	var buf []byte
+	for _, d := range data {
+		buf = append(buf, d...)
+	}
+
+	// Absorb full blocks
+	for len(buf) >= keccak256Rate {
+		keccakAbsorb(&state, buf[:keccak256Rate])
+		buf = buf[keccak256Rate:]
+	}
+
+	// Pad the final block with the original Keccak 0x01...0x80 padding
+	block := make([]byte, keccak256Rate)
+	copy(block, buf)
+	block[len(buf)] ^= 0x01
+	block[keccak256Rate-1] ^= 0x80
+	keccakAbsorb(&state, block)
+
+	out := make([]byte, 32)
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(out[i*8:], state[i])
+	}
+	return out
+}
+
+// keccakAbsorb XORs a block into the state and applies the permutation
+func keccakAbsorb(state *[25]uint64, block []byte) {
+	for i := 0; i < keccak256Rate/8; i++ {
+		state[i] ^= binary.LittleEndian.Uint64(block[i*8:])
+	}
+	keccakF1600(state)
+}
+
+// keccakF1600 applies the Keccak-f[1600] permutation
+func keccakF1600(st *[25]uint64) {
+	var bc [5]uint64
+	for round := 0; round < 24; round++ {
+		// Theta
+		for i := 0; i < 5; i++ {
+			bc[i] = st[i] ^ st[i+5] ^ st[i+10] ^ st[i+15] ^ st[i+20]
+		}
+		for i := 0; i < 5; i++ {
+			t := bc[(i+4)%5] ^ bits.RotateLeft64(bc[(i+1)%5], 1)
+			for j := 0; j < 25; j += 5 {
+				st[j+i] ^= t
+			}
+		}
+
+		// Rho and Pi
+		t := st[1]
+		for i := 0; i < 24; i++ {
+			j := keccakPiLanes[i]
+			bc[0] = st[j]
+			st[j] = bits.RotateLeft64(t, keccakRotations[i])
+			t = bc[0]
+		}
+
+		// Chi
+		for j := 0; j < 25; j += 5 {
+			for i := 0; i < 5; i++ {
+				bc[i] = st[j+i]
+			}
+			for i := 0; i < 5; i++ {
+				st[j+i] ^= ^bc[(i+1)%5] & bc[(i+2)%5]
+			}
+		}
+
+		// Iota
+		st[0] ^= keccakRoundConstants[round]
+	}
+}
@@ -0,0 +1,228 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package verification
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+// MuSig2 contribution sizes
+const (
+	MuSig2NonceSize            = musig2.PubNonceSize // Public nonce: two compressed points
+	MuSig2PartialSignatureSize = 32                  // Partial signature: one scalar
+)
+
+// MuSig2Session aggregates the keys, public nonces and partial signatures of a MuSig2 (BIP-327)
+// signing session. Keys are aggregated in sorted order; nonces and partial signatures are passed
+// in the order of the public keys given to NewMuSig2Session
+type MuSig2Session struct {
+	keys      []*btcec.PublicKey
+	message   [32]byte
+	taproot   bool
+	aggregate *musig2.AggregateKey
+
+	nonces         [][musig2.PubNonceSize]byte
+	aggregateNonce [musig2.PubNonceSize]byte
+}
+
+// NewMuSig2Session starts a session for a 32-byte message signed by the secp256k1 public keys
+// With taproot the signature is for the BIP-86 taproot output key of the aggregate key
+func NewMuSig2Session(publicKeys [][]byte, message []byte, taproot bool) (*MuSig2Session, error) {
+	if len(publicKeys) < 2 {
+		return nil, fmt.Errorf("MuSig2 needs at least 2 signers, got %d", len(publicKeys))
+	}
+	if len(message) != 32 {
+		return nil, fmt.Errorf("MuSig2 message must be 32 bytes, got %d", len(message))
+	}
+
+	s := &MuSig2Session{taproot: taproot}
+	copy(s.message[:], message)
+	for i, publicKey := range publicKeys {
+		key, err := parseSecp256k1PublicKey(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("signer %d: %w", i, err)
+		}
+		s.keys = append(s.keys, key)
+	}
+
+	var opts []musig2.KeyAggOption
+	if taproot {
+		opts = append(opts, musig2.WithBIP86KeyTweak())
+	}
+	// musig2 sorts key slices in place; s.keys stays in signer order
+	aggregate, _, _, err := musig2.AggregateKeys(slices.Clone(s.keys), true, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate keys: %w", err)
+	}
+	s.aggregate = aggregate
+	return s, nil
+}
+
+// AggregateKey returns the 32-byte x-only key the final signature verifies under: the taproot
+// output key for taproot sessions
+func (s *MuSig2Session) AggregateKey() []byte {
+	return schnorr.SerializePubKey(s.aggregate.FinalKey)
+}
+
+// InternalKey returns the 32-byte x-only aggregate key before the taproot tweak
+func (s *MuSig2Session) InternalKey() []byte {
+	return schnorr.SerializePubKey(s.aggregate.PreTweakedKey)
+}
+
+// CheckMuSig2Nonce checks that a public nonce is two valid compressed points
+func CheckMuSig2Nonce(nonce []byte) error {
+	if len(nonce) != MuSig2NonceSize {
+		return fmt.Errorf("MuSig2 nonce must be %d bytes, got %d", MuSig2NonceSize, len(nonce))
+	}
+	half := MuSig2NonceSize / 2
+	for _, point := range [][]byte{nonce[:half], nonce[half:]} {
+		if _, err := btcec.ParsePubKey(point); err != nil {
+			return fmt.Errorf("invalid MuSig2 nonce: %w", err)
+		}
+	}
+	return nil
+}
+
+// AggregateNonces sums the signers' public nonces into the aggregate nonce sent to every signer
+func (s *MuSig2Session) AggregateNonces(nonces [][]byte) ([]byte, error) {
+	if len(nonces) != len(s.keys) {
+		return nil, fmt.Errorf("expected %d MuSig2 nonces, got %d", len(s.keys), len(nonces))
+	}
+	s.nonces = make([][musig2.PubNonceSize]byte, len(nonces))
+	for i, nonce := range nonces {
+		if err := CheckMuSig2Nonce(nonce); err != nil {
+			return nil, fmt.Errorf("signer %d: %w", i, err)
+		}
+		copy(s.nonces[i][:], nonce)
+	}
+
+	aggregateNonce, err := musig2.AggregateNonces(s.nonces)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate nonces: %w", err)
+	}
+	s.aggregateNonce = aggregateNonce
+	return aggregateNonce[:], nil
+}
+
+// VerifyPartial checks signer i's partial signature against its key and public nonce
+// AggregateNonces must have been called
+func (s *MuSig2Session) VerifyPartial(i int, partial []byte) error {
+	if s.nonces == nil {
+		return fmt.Errorf("MuSig2 nonces not aggregated")
+	}
+	if i < 0 || i >= len(s.keys) {
+		return fmt.Errorf("no MuSig2 signer %d", i)
+	}
+	sig, err := parsePartialSignature(partial)
+	if err != nil {
+		return err
+	}
+	if !sig.Verify(s.nonces[i], s.aggregateNonce, slices.Clone(s.keys), s.keys[i], s.message, s.signOptions()...) {
+		return fmt.Errorf("invalid MuSig2 partial signature")
+	}
+	return nil
+}
+
+// Combine sums the partial signatures, in signer order, into the 64-byte BIP-340 signature and
+// checks it against the aggregate key
+func (s *MuSig2Session) Combine(partials [][]byte) ([]byte, error) {
+	if s.nonces == nil {
+		return nil, fmt.Errorf("MuSig2 nonces not aggregated")
+	}
+	if len(partials) != len(s.keys) {
+		return nil, fmt.Errorf("expected %d MuSig2 partial signatures, got %d", len(s.keys), len(partials))
+	}
+	sigs := make([]*musig2.PartialSignature, len(partials))
+	for i, partial := range partials {
+		sig, err := parsePartialSignature(partial)
+		if err != nil {
+			return nil, fmt.Errorf("signer %d: %w", i, err)
+		}
+		sigs[i] = sig
+	}
+
+	nonce, err := s.finalNonce()
+	if err != nil {
+		return nil, err
+	}
+	var opts []musig2.CombineOption
+	if s.taproot {
+		opts = append(opts, musig2.WithBip86TweakedCombine(s.message, slices.Clone(s.keys), true))
+	}
+	signature := musig2.CombineSigs(nonce, sigs, opts...)
+	if !signature.Verify(s.message[:], s.aggregate.FinalKey) {
+		return nil, fmt.Errorf("combined MuSig2 signature does not verify under the aggregate key")
+	}
+	return signature.Serialize(), nil
+}
+
+// signOptions returns the signing options every signer of the session uses
+func (s *MuSig2Session) signOptions() []musig2.SignOption {
+	opts := []musig2.SignOption{musig2.WithSortedKeys()}
+	if s.taproot {
+		opts = append(opts, musig2.WithBip86SignTweak())
+	}
+	return opts
+}
+
+// finalNonce computes the session nonce R = R1 + b*R2, where b binds the aggregate nonce to the
+// aggregate key and message
+func (s *MuSig2Session) finalNonce() (*btcec.PublicKey, error) {
+	var buf bytes.Buffer
+	buf.Write(s.aggregateNonce[:])
+	buf.Write(schnorr.SerializePubKey(s.aggregate.FinalKey))
+	buf.Write(s.message[:])
+	blindHash := chainhash.TaggedHash(musig2.NonceBlindTag, buf.Bytes())
+	var blind btcec.ModNScalar
+	blind.SetByteSlice(blindHash[:])
+
+	half := musig2.PubNonceSize / 2
+	r1, err := btcec.ParseJacobian(s.aggregateNonce[:half])
+	if err != nil {
+		return nil, fmt.Errorf("invalid aggregate nonce: %w", err)
+	}
+	r2, err := btcec.ParseJacobian(s.aggregateNonce[half:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid aggregate nonce: %w", err)
+	}
+
+	var nonce btcec.JacobianPoint
+	btcec.ScalarMultNonConst(&blind, &r2, &r2)
+	btcec.AddNonConst(&r1, &r2, &nonce)
+	if nonce.Z.IsZero() {
+		// BIP-327 uses the generator when the nonce sums to infinity
+		btcec.Generator().AsJacobian(&nonce)
+	}
+	nonce.ToAffine()
+	return btcec.NewPublicKey(&nonce.X, &nonce.Y), nil
+}
+
+// parsePartialSignature decodes a 32-byte partial signature scalar
+func parsePartialSignature(partial []byte) (*musig2.PartialSignature, error) {
+	if len(partial) != MuSig2PartialSignatureSize {
+		return nil, fmt.Errorf("MuSig2 partial signature must be %d bytes, got %d", MuSig2PartialSignatureSize, len(partial))
+	}
+	var sig musig2.PartialSignature
+	if err := sig.Decode(bytes.NewReader(partial)); err != nil {
+		return nil, fmt.Errorf("invalid MuSig2 partial signature: %w", err)
+	}
+	return &sig, nil
+}
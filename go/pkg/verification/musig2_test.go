@@ -0,0 +1,169 @@
+package verification
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+// musig2Signer plays the TEE side of a MuSig2 session with a local key
+type musig2Signer struct {
+	key    *btcec.PrivateKey
+	nonces *musig2.Nonces
+}
+
+func newMuSig2Signers(t *testing.T, n int) ([]*musig2Signer, [][]byte) {
+	t.Helper()
+	var signers []*musig2Signer
+	var publicKeys [][]byte
+	for i := 0; i < n; i++ {
+		key, err := btcec.NewPrivateKey()
+		if err != nil {
+			t.Fatalf("Failed to generate key: %v", err)
+		}
+		nonces, err := musig2.GenNonces(musig2.WithPublicKey(key.PubKey()))
+		if err != nil {
+			t.Fatalf("GenNonces failed: %v", err)
+		}
+		signers = append(signers, &musig2Signer{key: key, nonces: nonces})
+		publicKeys = append(publicKeys, key.PubKey().SerializeCompressed())
+	}
+	// Raw X||Y keys, as some apps report them, are accepted too
+	publicKeys[n-1] = signers[n-1].key.PubKey().SerializeUncompressed()[1:]
+	return signers, publicKeys
+}
+
+func (s *musig2Signer) sign(t *testing.T, aggregateNonce []byte, publicKeys [][]byte, message [32]byte, taproot bool) []byte {
+	t.Helper()
+	var keys []*btcec.PublicKey
+	for _, publicKey := range publicKeys {
+		key, err := parseSecp256k1PublicKey(publicKey)
+		if err != nil {
+			t.Fatalf("Failed to parse key: %v", err)
+		}
+		keys = append(keys, key)
+	}
+	opts := []musig2.SignOption{musig2.WithSortedKeys()}
+	if taproot {
+		opts = append(opts, musig2.WithBip86SignTweak())
+	}
+	var combined [musig2.PubNonceSize]byte
+	copy(combined[:], aggregateNonce)
+	partial, err := musig2.Sign(s.nonces.SecNonce, s.key, combined, keys, message, opts...)
+	if err != nil {
+		t.Fatalf("musig2.Sign failed: %v", err)
+	}
+	var buf bytes.Buffer
+	partial.Encode(&buf)
+	return buf.Bytes()
+}
+
+func TestMuSig2Session(t *testing.T) {
+	message := sha256.Sum256([]byte("taproot sighash"))
+
+	for _, taproot := range []bool{false, true} {
+		signers, publicKeys := newMuSig2Signers(t, 3)
+		session, err := NewMuSig2Session(publicKeys, message[:], taproot)
+		if err != nil {
+			t.Fatalf("NewMuSig2Session failed: %v", err)
+		}
+
+		var nonces [][]byte
+		for _, signer := range signers {
+			nonces = append(nonces, signer.nonces.PubNonce[:])
+		}
+		aggregateNonce, err := session.AggregateNonces(nonces)
+		if err != nil {
+			t.Fatalf("AggregateNonces failed: %v", err)
+		}
+
+		var partials [][]byte
+		for i, signer := range signers {
+			partial := signer.sign(t, aggregateNonce, publicKeys, message, taproot)
+			if err := session.VerifyPartial(i, partial); err != nil {
+				t.Errorf("taproot=%t: partial signature %d rejected: %v", taproot, i, err)
+			}
+			partials = append(partials, partial)
+		}
+		if err := session.VerifyPartial(0, partials[1]); err == nil {
+			t.Errorf("taproot=%t: partial signature of another signer accepted", taproot)
+		}
+
+		signature, err := session.Combine(partials)
+		if err != nil {
+			t.Fatalf("taproot=%t: Combine failed: %v", taproot, err)
+		}
+		if len(signature) != schnorr.SignatureSize {
+			t.Fatalf("Expected %d-byte signature, got %d", schnorr.SignatureSize, len(signature))
+		}
+		key, err := schnorr.ParsePubKey(session.AggregateKey())
+		if err != nil {
+			t.Fatalf("Failed to parse aggregate key: %v", err)
+		}
+		sig, _ := schnorr.ParseSignature(signature)
+		if !sig.Verify(message[:], key) {
+			t.Errorf("taproot=%t: signature does not verify under the aggregate key", taproot)
+		}
+
+		// The taproot output key is the internal key tweaked with its own tagged hash (BIP-86)
+		wantTweaked := !bytes.Equal(session.AggregateKey(), session.InternalKey())
+		if wantTweaked != taproot {
+			t.Errorf("taproot=%t: output key tweaked=%t", taproot, wantTweaked)
+		}
+		if taproot {
+			internal, _ := schnorr.ParsePubKey(session.InternalKey())
+			tweakHash := chainhash.TaggedHash([]byte("TapTweak"), session.InternalKey())
+			var tweak btcec.ModNScalar
+			tweak.SetByteSlice(tweakHash[:])
+			var p, tG, q btcec.JacobianPoint
+			internal.AsJacobian(&p)
+			btcec.ScalarBaseMultNonConst(&tweak, &tG)
+			btcec.AddNonConst(&p, &tG, &q)
+			q.ToAffine()
+			if !bytes.Equal(schnorr.SerializePubKey(btcec.NewPublicKey(&q.X, &q.Y)), session.AggregateKey()) {
+				t.Error("Aggregate key is not the BIP-86 output key of the internal key")
+			}
+		}
+
+		// A tampered partial signature spoils the combined signature
+		partials[2] = append([]byte(nil), partials[2]...)
+		partials[2][31] ^= 1
+		if _, err := session.Combine(partials); err == nil {
+			t.Errorf("taproot=%t: Combine accepted a tampered partial signature", taproot)
+		}
+	}
+}
+
+func TestMuSig2SessionErrors(t *testing.T) {
+	_, publicKeys := newMuSig2Signers(t, 2)
+	message := make([]byte, 32)
+
+	if _, err := NewMuSig2Session(publicKeys[:1], message, false); err == nil {
+		t.Error("Expected error for a single signer")
+	}
+	if _, err := NewMuSig2Session(publicKeys, message[:31], false); err == nil {
+		t.Error("Expected error for a short message")
+	}
+	if _, err := NewMuSig2Session([][]byte{publicKeys[0], {1, 2, 3}}, message, false); err == nil {
+		t.Error("Expected error for an invalid key")
+	}
+
+	session, err := NewMuSig2Session(publicKeys, message, false)
+	if err != nil {
+		t.Fatalf("NewMuSig2Session failed: %v", err)
+	}
+	if err := session.VerifyPartial(0, make([]byte, 32)); err == nil {
+		t.Error("Expected error before nonces are aggregated")
+	}
+	if _, err := session.AggregateNonces([][]byte{make([]byte, MuSig2NonceSize)}); err == nil {
+		t.Error("Expected error for a missing nonce")
+	}
+	if _, err := session.AggregateNonces([][]byte{make([]byte, MuSig2NonceSize), make([]byte, MuSig2NonceSize)}); err == nil {
+		t.Error("Expected error for nonces that are not points")
+	}
+}
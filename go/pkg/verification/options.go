@@ -0,0 +1,123 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package verification
+
+import (
+	"crypto/elliptic"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+// SignatureFormat restricts the ECDSA signature encodings accepted by VerifySignatureWithOptions
+type SignatureFormat string
+
+const (
+	// SignatureFormatAny accepts ASN.1 DER and raw R||S (64 bytes) signatures
+	SignatureFormatAny SignatureFormat = ""
+	// SignatureFormatDER accepts only ASN.1 DER signatures
+	SignatureFormatDER SignatureFormat = "der"
+	// SignatureFormatRaw accepts only raw R||S (64 bytes) signatures
+	SignatureFormatRaw SignatureFormat = "raw"
+)
+
+// VerifyOptions controls how strictly ECDSA signatures are validated. The zero value is as
+// lenient as VerifySignature. Ed25519 and Schnorr signatures have a single encoding and are
+// always checked strictly, so the options don't apply to them
+type VerifyOptions struct {
+	RejectHighS bool            // Reject S > n/2, the malleable twin of every valid signature (BIP 62, EIP-2)
+	StrictDER   bool            // Reject DER signatures that are not canonically encoded (BIP 66)
+	Format      SignatureFormat // Accepted encodings, default SignatureFormatAny
+}
+
+// StrictVerifyOptions returns the options of consensus-grade validation: low S and canonical DER
+func StrictVerifyOptions() *VerifyOptions {
+	return &VerifyOptions{RejectHighS: true, StrictDER: true}
+}
+
+// VerifySignatureWithOptions verifies a signature like VerifySignature, first rejecting ECDSA
+// signatures that opts does not allow; nil opts behaves like VerifySignature
+func VerifySignatureWithOptions(message, publicKey, signature []byte, protocol constants.Protocol, curve constants.Curve, opts *VerifyOptions) (bool, error) {
+	if opts != nil && protocol == constants.ProtocolECDSA && curve != constants.CurveED25519 {
+		if err := opts.checkECDSA(signature, curve); err != nil {
+			return false, err
+		}
+	}
+	return VerifySignature(message, publicKey, signature, protocol, curve)
+}
+
+// checkECDSA checks an ECDSA signature's encoding and S value against the options
+func (opts *VerifyOptions) checkECDSA(signature []byte, curve constants.Curve) error {
+	var order *big.Int
+	switch curve {
+	case constants.CurveSECP256K1:
+		order = btcec.S256().N
+	case constants.CurveSECP256R1:
+		order = elliptic.P256().Params().N
+	default:
+		return fmt.Errorf("unsupported curve: %d", curve)
+	}
+
+	var sig ECDSASignature
+	rest, err := asn1.Unmarshal(signature, &sig)
+	isDER := err == nil && len(rest) == 0 && sig.R != nil && sig.S != nil
+	isRaw := !isDER && len(signature) == RawSignatureSize
+
+	switch opts.Format {
+	case SignatureFormatAny:
+	case SignatureFormatDER:
+		if isRaw {
+			return fmt.Errorf("invalid signature: expected DER encoding")
+		}
+	case SignatureFormatRaw:
+		if !isRaw {
+			return fmt.Errorf("invalid signature: expected raw R||S encoding of %d bytes", RawSignatureSize)
+		}
+	default:
+		return fmt.Errorf("unsupported signature format: %q", opts.Format)
+	}
+
+	switch {
+	case isRaw:
+		sig = *splitRawSignature(signature)
+	case opts.StrictDER:
+		if _, err := DERToRaw(signature); err != nil {
+			return err
+		}
+	case !isDER:
+		// secp256k1 signatures are also parsed as BER, which tolerates padding and trailing data
+		parsed, err := btcecdsa.ParseSignature(signature)
+		if curve != constants.CurveSECP256K1 || err != nil {
+			return nil // Left to VerifySignature to reject
+		}
+		r, s := parsed.R(), parsed.S()
+		rBytes, sBytes := r.Bytes(), s.Bytes()
+		sig.R, sig.S = new(big.Int).SetBytes(rBytes[:]), new(big.Int).SetBytes(sBytes[:])
+	}
+
+	if err := validateSignatureComponents(sig.R, sig.S); err != nil {
+		return err
+	}
+	if sig.R.Cmp(order) >= 0 || sig.S.Cmp(order) >= 0 {
+		return fmt.Errorf("invalid signature: r or s is >= curve order")
+	}
+	if opts.RejectHighS && sig.S.Cmp(new(big.Int).Rsh(order, 1)) > 0 {
+		return fmt.Errorf("invalid signature: high S value (malleable)")
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package verification
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+func TestVerifyOptionsSecp256k1(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate secp256k1 key: %v", err)
+	}
+	pubKey := privKey.PubKey().SerializeCompressed()
+	message := []byte("Hello, consensus!")
+	hash := sha256.Sum256(message)
+	derSig := btcecdsa.Sign(privKey, hash[:]).Serialize()
+	raw, err := DERToRaw(derSig)
+	if err != nil {
+		t.Fatalf("DERToRaw failed: %v", err)
+	}
+
+	// The malleable twin (r, n-s) of the low-S signature btcec produces
+	highS := make([]byte, RawSignatureSize)
+	copy(highS, raw[:32])
+	new(big.Int).Sub(btcec.S256().N, new(big.Int).SetBytes(raw[32:])).FillBytes(highS[32:])
+	// BER with trailing data, which the lenient parser truncates
+	trailing := append(append([]byte{}, derSig...), 0x00)
+
+	tests := []struct {
+		name      string
+		signature []byte
+		opts      *VerifyOptions
+		wantErr   bool
+	}{
+		{"DER, nil options", derSig, nil, false},
+		{"DER, strict", derSig, StrictVerifyOptions(), false},
+		{"raw, strict", raw, StrictVerifyOptions(), false},
+		{"high S, nil options", highS, nil, false},
+		{"high S, zero options", highS, &VerifyOptions{}, false},
+		{"high S, strict", highS, StrictVerifyOptions(), true},
+		{"trailing data, nil options", trailing, nil, false},
+		{"trailing data, zero options", trailing, &VerifyOptions{}, false},
+		{"trailing data, strict", trailing, StrictVerifyOptions(), true},
+		{"raw, DER only", raw, &VerifyOptions{Format: SignatureFormatDER}, true},
+		{"DER, raw only", derSig, &VerifyOptions{Format: SignatureFormatRaw}, true},
+		{"raw, raw only", raw, &VerifyOptions{Format: SignatureFormatRaw}, false},
+		{"unknown format", raw, &VerifyOptions{Format: "base64"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := VerifySignatureWithOptions(message, pubKey, tt.signature, constants.ProtocolECDSA, constants.CurveSECP256K1, tt.opts)
+			if tt.wantErr {
+				if err == nil || valid {
+					t.Errorf("Expected rejection, got valid=%v err=%v", valid, err)
+				}
+				return
+			}
+			if err != nil || !valid {
+				t.Errorf("Expected valid signature, got valid=%v err=%v", valid, err)
+			}
+		})
+	}
+}
+
+func TestVerifyOptionsSecp256r1HighS(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate P-256 key: %v", err)
+	}
+	pubKey := elliptic.Marshal(elliptic.P256(), privKey.X, privKey.Y)
+	message := []byte("Hello, P-256!")
+	hash := sha256.Sum256(message)
+	r, s, err := ecdsa.Sign(rand.Reader, privKey, hash[:])
+	if err != nil {
+		t.Fatalf("Failed to sign: %v", err)
+	}
+
+	// Make S high whichever half Go's signer chose
+	order := elliptic.P256().Params().N
+	if s.Cmp(new(big.Int).Rsh(order, 1)) <= 0 {
+		s.Sub(order, s)
+	}
+	highS := make([]byte, RawSignatureSize)
+	r.FillBytes(highS[:32])
+	s.FillBytes(highS[32:])
+
+	if valid, err := VerifySignatureWithOptions(message, pubKey, highS, constants.ProtocolECDSA, constants.CurveSECP256R1, nil); err != nil || !valid {
+		t.Errorf("Expected high-S signature to verify by default, got valid=%v err=%v", valid, err)
+	}
+	if _, err := VerifySignatureWithOptions(message, pubKey, highS, constants.ProtocolECDSA, constants.CurveSECP256R1, &VerifyOptions{RejectHighS: true}); err == nil {
+		t.Error("Expected high-S signature to be rejected")
+	}
+}
+
+func TestVerifyOptionsIgnoredForED25519(t *testing.T) {
+	if _, err := VerifySignatureWithOptions([]byte("m"), make([]byte, 32), make([]byte, 64), constants.ProtocolECDSA, constants.CurveED25519, StrictVerifyOptions()); err != nil {
+		t.Errorf("Expected options to be ignored for ED25519, got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package verification
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+// ParsePublicKey parses a public key for the given curve
+// Accepted formats:
+// - ED25519: 32 bytes, returned as ed25519.PublicKey
+// - SECP256K1/SECP256R1: compressed (33), uncompressed (65) or raw X||Y (64), returned as *ecdsa.PublicKey
+func ParsePublicKey(curve constants.Curve, publicKey []byte) (crypto.PublicKey, error) {
+	switch curve {
+	case constants.CurveED25519:
+		if len(publicKey) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid ED25519 public key size: expected %d, got %d", ed25519.PublicKeySize, len(publicKey))
+		}
+		key := make(ed25519.PublicKey, ed25519.PublicKeySize)
+		copy(key, publicKey)
+		return key, nil
+	case constants.CurveSECP256K1:
+		pubKey, err := parseSecp256k1PublicKey(publicKey)
+		if err != nil {
+			return nil, err
+		}
+		return pubKey.ToECDSA(), nil
+	case constants.CurveSECP256R1:
+		x, y, err := parseSecp256r1PublicKey(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse secp256r1 public key: %v", err)
+		}
+		if !elliptic.P256().IsOnCurve(x, y) {
+			return nil, fmt.Errorf("public key point is not on secp256r1 curve")
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported curve: %d", curve)
+	}
+}
+
+// ParseSignature parses an ECDSA or Schnorr signature into its R and S components
+// ECDSA signatures may be ASN.1 DER encoded or raw R||S (64 bytes);
+// Schnorr signatures must be raw R||S (64 bytes)
+func ParseSignature(protocol constants.Protocol, signature []byte) (*ECDSASignature, error) {
+	switch protocol {
+	case constants.ProtocolECDSA:
+		var sig ECDSASignature
+		if rest, err := asn1.Unmarshal(signature, &sig); err == nil && len(rest) == 0 && sig.R != nil && sig.S != nil {
+			return &sig, nil
+		}
+		if len(signature) != RawSignatureSize {
+			return nil, fmt.Errorf("invalid signature length: expected 64 bytes for raw format or valid DER encoding")
+		}
+		return splitRawSignature(signature), nil
+	case constants.ProtocolSchnorr:
+		if len(signature) != RawSignatureSize {
+			return nil, fmt.Errorf("invalid Schnorr signature length: expected %d, got %d", RawSignatureSize, len(signature))
+		}
+		return splitRawSignature(signature), nil
+	default:
+		return nil, fmt.Errorf("unsupported protocol: %d", protocol)
+	}
+}
+
+// parseSecp256k1PublicKey parses a secp256k1 public key, accepting the raw 64-byte X||Y form in addition to btcec formats
+func parseSecp256k1PublicKey(publicKeyBytes []byte) (*btcec.PublicKey, error) {
+	pubKey, err := btcec.ParsePubKey(publicKeyBytes)
+	if err == nil {
+		return pubKey, nil
+	}
+
+	// btcec expects compressed (33 bytes) or uncompressed (65 bytes) format
+	// For raw 64-byte format, we need to add the uncompressed prefix
+	if len(publicKeyBytes) != 64 {
+		return nil, fmt.Errorf("failed to parse secp256k1 public key: %v", err)
+	}
+	uncompressed := make([]byte, 65)
+	uncompressed[0] = 0x04
+	copy(uncompressed[1:], publicKeyBytes)
+	pubKey, err = btcec.ParsePubKey(uncompressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse secp256k1 public key: %v", err)
+	}
+	return pubKey, nil
+}
+
+// splitRawSignature splits a 64-byte R||S signature into its components
+func splitRawSignature(signature []byte) *ECDSASignature {
+	return &ECDSASignature{
+		R: new(big.Int).SetBytes(signature[:32]),
+		S: new(big.Int).SetBytes(signature[32:64]),
+	}
+}
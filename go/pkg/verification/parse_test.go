@@ -0,0 +1,126 @@
+package verification
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+func TestParsePublicKey(t *testing.T) {
+	// ED25519
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate ED25519 key: %v", err)
+	}
+	key, err := ParsePublicKey(constants.CurveED25519, edPub)
+	if err != nil {
+		t.Fatalf("Failed to parse ED25519 public key: %v", err)
+	}
+	if !edPub.Equal(key) {
+		t.Error("Parsed ED25519 public key does not match")
+	}
+
+	// SECP256K1 in all supported formats
+	k1Priv, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate secp256k1 key: %v", err)
+	}
+	k1Pub := k1Priv.PubKey()
+	k1Formats := map[string][]byte{
+		"compressed":   k1Pub.SerializeCompressed(),
+		"uncompressed": k1Pub.SerializeUncompressed(),
+		"raw":          k1Pub.SerializeUncompressed()[1:],
+	}
+	for name, keyBytes := range k1Formats {
+		key, err := ParsePublicKey(constants.CurveSECP256K1, keyBytes)
+		if err != nil {
+			t.Fatalf("Failed to parse %s secp256k1 public key: %v", name, err)
+		}
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			t.Fatalf("Expected *ecdsa.PublicKey for %s secp256k1 key, got %T", name, key)
+		}
+		if ecKey.X.Cmp(k1Pub.X()) != 0 || ecKey.Y.Cmp(k1Pub.Y()) != 0 {
+			t.Errorf("Parsed %s secp256k1 public key does not match", name)
+		}
+	}
+
+	// SECP256R1 in all supported formats
+	r1Priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate P-256 key: %v", err)
+	}
+	uncompressed := elliptic.Marshal(elliptic.P256(), r1Priv.X, r1Priv.Y)
+	r1Formats := map[string][]byte{
+		"compressed":   elliptic.MarshalCompressed(elliptic.P256(), r1Priv.X, r1Priv.Y),
+		"uncompressed": uncompressed,
+		"raw":          uncompressed[1:],
+	}
+	for name, keyBytes := range r1Formats {
+		key, err := ParsePublicKey(constants.CurveSECP256R1, keyBytes)
+		if err != nil {
+			t.Fatalf("Failed to parse %s secp256r1 public key: %v", name, err)
+		}
+		if !r1Priv.PublicKey.Equal(key) {
+			t.Errorf("Parsed %s secp256r1 public key does not match", name)
+		}
+	}
+
+	// Invalid inputs
+	if _, err := ParsePublicKey(constants.CurveED25519, make([]byte, 31)); err == nil {
+		t.Error("Expected error for short ED25519 public key")
+	}
+	if _, err := ParsePublicKey(constants.CurveSECP256R1, make([]byte, 64)); err == nil {
+		t.Error("Expected error for secp256r1 point not on curve")
+	}
+	if _, err := ParsePublicKey(99, edPub); err == nil {
+		t.Error("Expected error for unsupported curve")
+	}
+}
+
+func TestParseSignature(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate secp256k1 key: %v", err)
+	}
+	hash := sha256.Sum256([]byte("Hello, TEENet!"))
+	sig := btcecdsa.Sign(privKey, hash[:])
+
+	r := sig.R()
+	s := sig.S()
+	rawSig := make([]byte, 64)
+	r.PutBytesUnchecked(rawSig[:32])
+	s.PutBytesUnchecked(rawSig[32:])
+
+	fromDER, err := ParseSignature(constants.ProtocolECDSA, sig.Serialize())
+	if err != nil {
+		t.Fatalf("Failed to parse DER signature: %v", err)
+	}
+	fromRaw, err := ParseSignature(constants.ProtocolECDSA, rawSig)
+	if err != nil {
+		t.Fatalf("Failed to parse raw signature: %v", err)
+	}
+	if fromDER.R.Cmp(fromRaw.R) != 0 || fromDER.S.Cmp(fromRaw.S) != 0 {
+		t.Error("DER and raw signatures parsed to different components")
+	}
+
+	if _, err := ParseSignature(constants.ProtocolSchnorr, rawSig); err != nil {
+		t.Errorf("Failed to parse Schnorr signature: %v", err)
+	}
+	if _, err := ParseSignature(constants.ProtocolSchnorr, sig.Serialize()); err == nil {
+		t.Error("Expected error for DER-encoded Schnorr signature")
+	}
+	if _, err := ParseSignature(constants.ProtocolECDSA, rawSig[:63]); err == nil {
+		t.Error("Expected error for truncated ECDSA signature")
+	}
+	if _, err := ParseSignature(99, rawSig); err == nil {
+		t.Error("Expected error for unsupported protocol")
+	}
+}
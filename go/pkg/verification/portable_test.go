@@ -0,0 +1,34 @@
+package verification
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestPortableDependencies keeps the package buildable for browsers (GOOS=js GOARCH=wasm) and
+// TinyGo: no gRPC, protobuf, networking or other SDK packages beyond constants
+func TestPortableDependencies(t *testing.T) {
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+	cmd := exec.Command(goTool, "list", "-deps", ".")
+	cmd.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("go list failed: %v\n%s", err, out)
+	}
+
+	for _, dep := range strings.Fields(string(out)) {
+		switch {
+		case dep == "net", strings.HasPrefix(dep, "net/"), dep == "os/exec", dep == "crypto/x509",
+			strings.HasPrefix(dep, "google.golang.org/"):
+			t.Errorf("Unexpected dependency %s", dep)
+		case strings.HasPrefix(dep, "github.com/TEENet-io/teenet-sdk/go") &&
+			!strings.HasSuffix(dep, "/pkg/constants") && !strings.HasSuffix(dep, "/pkg/verification"):
+			t.Errorf("Unexpected SDK dependency %s", dep)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package verification
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+// recoverableSignature normalizes a secp256k1 ECDSA signature over hash to low-S raw R||S form
+// and derives the recovery ID by matching the recovered key against publicKey
+func recoverableSignature(hash, signature, publicKey []byte) ([]byte, byte, error) {
+	pubKey, err := parseSecp256k1PublicKey(publicKey)
+	if err != nil {
+		return nil, 0, err
+	}
+	sig, err := ParseSignature(constants.ProtocolECDSA, signature)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := validateSignatureComponents(sig.R, sig.S); err != nil {
+		return nil, 0, err
+	}
+
+	// Enforce low-S as required by most verifiers
+	curveOrder := btcec.S256().N
+	halfOrder := new(big.Int).Rsh(curveOrder, 1)
+	if sig.S.Cmp(halfOrder) > 0 {
+		sig.S = new(big.Int).Sub(curveOrder, sig.S)
+	}
+
+	raw := make([]byte, RawSignatureSize)
+	sig.R.FillBytes(raw[:32])
+	sig.S.FillBytes(raw[32:])
+
+	for recoveryID := byte(0); recoveryID <= 1; recoveryID++ {
+		recovered, err := recoverSecp256k1(hash, raw, recoveryID)
+		if err == nil && recovered.IsEqual(pubKey) {
+			return raw, recoveryID, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("signature does not match public key for the given hash")
+}
+
+// recoverSecp256k1 recovers the public key from a raw signature, hash and recovery ID
+func recoverSecp256k1(hash, raw []byte, recoveryID byte) (*btcec.PublicKey, error) {
+	// btcec expects the Bitcoin compact layout: header || R || S
+	compact := make([]byte, CompactSignatureSize)
+	compact[0] = 27 + recoveryID
+	copy(compact[1:], raw)
+
+	pubKey, _, err := btcecdsa.RecoverCompact(compact, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover public key: %v", err)
+	}
+	return pubKey, nil
+}
@@ -0,0 +1,220 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package testvectors embeds known-answer vectors for signature verification: RFC 8032
+// (Ed25519), RFC 6979 (ECDSA on P-256 and secp256k1) and BIP-340 (Schnorr on secp256k1),
+// plus edge cases derived from them. Files use the Wycheproof JSON schema, so Wycheproof's own
+// ECDSA SHA-256 and EdDSA files can be added to data/ as they are
+package testvectors
+
+import (
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"sort"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+)
+
+//go:embed data/*.json
+var data embed.FS
+
+// Result is the expected outcome of a vector
+type Result string
+
+const (
+	// ResultValid vectors must verify
+	ResultValid Result = "valid"
+	// ResultInvalid vectors must not verify
+	ResultInvalid Result = "invalid"
+	// ResultAcceptable vectors may be accepted or rejected, e.g. BER encodings that lenient
+	// secp256k1 parsing tolerates; strict verification rejects them
+	ResultAcceptable Result = "acceptable"
+)
+
+// Flags noted on vectors that verifiers treat specially
+const (
+	FlagHighS = "HighS" // S is above half the curve order; rejected by strict ECDSA verification
+)
+
+// Vector is one known-answer test case
+type Vector struct {
+	File      string
+	ID        int
+	Comment   string
+	Flags     []string
+	Protocol  constants.Protocol
+	Curve     constants.Curve
+	PublicKey []byte // Ed25519 key, uncompressed SEC1 point, or x-only key for BIP-340
+	Message   []byte
+	Prehashed bool // Message is the 32 bytes signed as is (BIP-340) rather than hashed with SHA-256
+	Signature []byte
+	Result    Result
+}
+
+// HasFlag reports whether the vector carries flag
+func (v *Vector) HasFlag(flag string) bool {
+	return slices.Contains(v.Flags, flag)
+}
+
+// String names the vector for test output
+func (v *Vector) String() string {
+	return fmt.Sprintf("%s#%d", v.File, v.ID)
+}
+
+// vectorFile is the subset of the Wycheproof schema used by the embedded files
+type vectorFile struct {
+	Algorithm     string `json:"algorithm"`
+	NumberOfTests int    `json:"numberOfTests"`
+	TestGroups    []struct {
+		Type      string `json:"type"`
+		PublicKey struct {
+			Curve        string `json:"curve"`
+			Uncompressed string `json:"uncompressed"`
+			PK           string `json:"pk"`
+		} `json:"publicKey"`
+		SHA   string `json:"sha"`
+		Tests []struct {
+			TcID    int      `json:"tcId"`
+			Comment string   `json:"comment"`
+			Flags   []string `json:"flags"`
+			Msg     string   `json:"msg"`
+			Sig     string   `json:"sig"`
+			Result  Result   `json:"result"`
+		} `json:"tests"`
+	} `json:"testGroups"`
+}
+
+// Files returns the names of the embedded vector files
+func Files() []string {
+	matches, _ := fs.Glob(data, "data/*.json")
+	names := make([]string, len(matches))
+	for i, match := range matches {
+		names[i] = path.Base(match)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// All loads every embedded vector
+func All() ([]Vector, error) {
+	var vectors []Vector
+	for _, name := range Files() {
+		loaded, err := Load(name)
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, loaded...)
+	}
+	return vectors, nil
+}
+
+// Load decodes the vectors of one embedded file
+func Load(name string) ([]Vector, error) {
+	raw, err := data.ReadFile("data/" + name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read test vectors %s: %w", name, err)
+	}
+	var file vectorFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode test vectors %s: %w", name, err)
+	}
+
+	var vectors []Vector
+	for _, group := range file.TestGroups {
+		protocol, curve, prehashed, err := groupAlgorithm(group.Type, group.PublicKey.Curve, group.SHA)
+		if err != nil {
+			return nil, fmt.Errorf("test vectors %s: %w", name, err)
+		}
+		keyHex := group.PublicKey.Uncompressed
+		if keyHex == "" {
+			keyHex = group.PublicKey.PK
+		}
+		publicKey, err := hex.DecodeString(keyHex)
+		if err != nil {
+			return nil, fmt.Errorf("test vectors %s: invalid public key: %w", name, err)
+		}
+
+		for _, test := range group.Tests {
+			message, err := hex.DecodeString(test.Msg)
+			if err != nil {
+				return nil, fmt.Errorf("test vectors %s #%d: invalid message: %w", name, test.TcID, err)
+			}
+			signature, err := hex.DecodeString(test.Sig)
+			if err != nil {
+				return nil, fmt.Errorf("test vectors %s #%d: invalid signature: %w", name, test.TcID, err)
+			}
+			switch test.Result {
+			case ResultValid, ResultInvalid, ResultAcceptable:
+			default:
+				return nil, fmt.Errorf("test vectors %s #%d: unknown result %q", name, test.TcID, test.Result)
+			}
+			vectors = append(vectors, Vector{
+				File:      name,
+				ID:        test.TcID,
+				Comment:   test.Comment,
+				Flags:     test.Flags,
+				Protocol:  protocol,
+				Curve:     curve,
+				PublicKey: publicKey,
+				Message:   message,
+				Prehashed: prehashed,
+				Signature: signature,
+				Result:    test.Result,
+			})
+		}
+	}
+	if len(vectors) != file.NumberOfTests {
+		return nil, fmt.Errorf("test vectors %s: expected %d tests, found %d", name, file.NumberOfTests, len(vectors))
+	}
+	return vectors, nil
+}
+
+// groupAlgorithm maps a Wycheproof test group to the protocol and curve it exercises. Only the
+// hash VerifySignature applies (SHA-256) is supported for ECDSA
+func groupAlgorithm(groupType, curveName, sha string) (protocol constants.Protocol, curve constants.Curve, prehashed bool, err error) {
+	switch curveName {
+	case "edwards25519":
+		curve = constants.CurveED25519
+	case "secp256k1":
+		curve = constants.CurveSECP256K1
+	case "secp256r1":
+		curve = constants.CurveSECP256R1
+	default:
+		return 0, 0, false, fmt.Errorf("unsupported curve %q", curveName)
+	}
+
+	switch groupType {
+	case "EddsaVerify":
+		if curve != constants.CurveED25519 {
+			return 0, 0, false, fmt.Errorf("%s group on %s", groupType, curveName)
+		}
+		return constants.ProtocolSchnorr, curve, false, nil
+	case "EcdsaVerify", "EcdsaP1363Verify":
+		if curve == constants.CurveED25519 || sha != "SHA-256" {
+			return 0, 0, false, fmt.Errorf("unsupported %s group on %s with %q", groupType, curveName, sha)
+		}
+		return constants.ProtocolECDSA, curve, false, nil
+	case "SchnorrBip340Verify":
+		if curve != constants.CurveSECP256K1 {
+			return 0, 0, false, fmt.Errorf("%s group on %s", groupType, curveName)
+		}
+		return constants.ProtocolSchnorr, curve, true, nil
+	default:
+		return 0, 0, false, fmt.Errorf("unsupported test group type %q", groupType)
+	}
+}
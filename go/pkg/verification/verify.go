@@ -19,7 +19,6 @@ import (
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/sha256"
-	"encoding/asn1"
 	"fmt"
 	"math/big"
 
@@ -40,7 +39,8 @@ type ECDSASignature struct {
 // - ED25519 with EdDSA (protocol parameter ignored for ED25519)
 // - SECP256K1 with ECDSA or Schnorr protocols (using btcec)
 // - SECP256R1 with ECDSA or Schnorr protocols
-func VerifySignature(message, publicKey, signature []byte, protocol, curve uint32) (bool, error) {
+// ECDSA signatures are checked leniently, see VerifySignatureWithOptions for strict validation
+func VerifySignature(message, publicKey, signature []byte, protocol constants.Protocol, curve constants.Curve) (bool, error) {
 	switch curve {
 	case constants.CurveED25519:
 		return verifyED25519(message, publicKey, signature)
@@ -68,25 +68,11 @@ func verifyED25519(message, publicKey, signature []byte) (bool, error) {
 }
 
 // verifySecp256k1 verifies signatures on secp256k1 curve using btcec
-func verifySecp256k1(message, publicKeyBytes, signature []byte, protocol uint32) (bool, error) {
+func verifySecp256k1(message, publicKeyBytes, signature []byte, protocol constants.Protocol) (bool, error) {
 	// Parse the public key using btcec
-	pubKey, err := btcec.ParsePubKey(publicKeyBytes)
+	pubKey, err := parseSecp256k1PublicKey(publicKeyBytes)
 	if err != nil {
-		// Try alternative formats if standard parsing fails
-		// btcec expects compressed (33 bytes) or uncompressed (65 bytes) format
-		// For raw 64-byte format, we need to add the uncompressed prefix
-		if len(publicKeyBytes) == 64 {
-			// Add uncompressed format prefix
-			uncompressed := make([]byte, 65)
-			uncompressed[0] = 0x04
-			copy(uncompressed[1:], publicKeyBytes)
-			pubKey, err = btcec.ParsePubKey(uncompressed)
-			if err != nil {
-				return false, fmt.Errorf("failed to parse secp256k1 public key: %v", err)
-			}
-		} else {
-			return false, fmt.Errorf("failed to parse secp256k1 public key: %v", err)
-		}
+		return false, err
 	}
 
 	switch protocol {
@@ -134,24 +120,26 @@ func verifySecp256k1Schnorr(message []byte, pubKey *btcec.PublicKey, signature [
 		return false, fmt.Errorf("invalid Schnorr signature size: expected %d, got %d", schnorr.SignatureSize, len(signature))
 	}
 
-	// Parse the signature
-	sig, err := schnorr.ParseSignature(signature)
-	if err != nil {
-		return false, fmt.Errorf("failed to parse Schnorr signature: %v", err)
-	}
-
 	// Hash the message with SHA256 for Schnorr
 	hasher := sha256.New()
 	hasher.Write(message)
 	messageHash := hasher.Sum(nil)
 
-	// Verify the signature
-	return sig.Verify(messageHash, pubKey), nil
+	return verifySecp256k1SchnorrDigest(messageHash, pubKey, signature)
+}
+
+// verifySecp256k1SchnorrDigest verifies a BIP-340 Schnorr signature over a 32-byte digest as is
+func verifySecp256k1SchnorrDigest(digest []byte, pubKey *btcec.PublicKey, signature []byte) (bool, error) {
+	sig, err := schnorr.ParseSignature(signature)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse Schnorr signature: %v", err)
+	}
+	return sig.Verify(digest, pubKey), nil
 }
 
 
 // verifySecp256r1 verifies signatures on secp256r1 curve (NIST P-256)
-func verifySecp256r1(message, publicKeyBytes, signature []byte, protocol uint32) (bool, error) {
+func verifySecp256r1(message, publicKeyBytes, signature []byte, protocol constants.Protocol) (bool, error) {
 	// Parse public key for secp256r1 (P-256)
 	x, y, err := parseSecp256r1PublicKey(publicKeyBytes)
 	if err != nil {
@@ -187,17 +175,9 @@ func verifyP256ECDSA(message []byte, publicKey *ecdsa.PublicKey, signature []byt
 	messageHash := hasher.Sum(nil)
 
 	// Parse ECDSA signature (DER format or raw r,s format)
-	var ecdsaSig ECDSASignature
-
-	// Try to parse as ASN.1 DER format first
-	if _, err := asn1.Unmarshal(signature, &ecdsaSig); err != nil {
-		// If DER parsing fails, try to parse as raw r,s format
-		if len(signature) != 64 {
-			return false, fmt.Errorf("invalid signature length: expected 64 bytes for raw format or valid DER encoding")
-		}
-
-		ecdsaSig.R = new(big.Int).SetBytes(signature[:32])
-		ecdsaSig.S = new(big.Int).SetBytes(signature[32:])
+	ecdsaSig, err := ParseSignature(constants.ProtocolECDSA, signature)
+	if err != nil {
+		return false, err
 	}
 
 	// Verify r and s are in valid range
@@ -15,13 +15,12 @@
 package voting
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -86,35 +85,63 @@ func MarkRequestAsForwarded(requestData []byte) ([]byte, error) {
 
 // SendHTTPVoteRequestWithHeaders sends a vote request to a target app via HTTP with custom headers
 func SendHTTPVoteRequestWithHeaders(target *usermgmt.DeploymentTarget, requestData []byte, headers map[string]string, timeout time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	// Build endpoint URL - send to deployment-client on port 8090 for HTTP forwarding
-	// Format: http://deployment-host:8090/proxy/{app_id}:{port}{voting_sign_path}
-	votingSignPath := target.VotingSignPath
-	if !strings.HasPrefix(votingSignPath, "/") {
-		votingSignPath = "/" + votingSignPath
+	return SendHTTPVoteRequestWithContext(ctx, target, requestData, headers)
+}
+
+// SendHTTPVoteRequestWithContext sends a vote request to a target app via HTTP, bounded by ctx
+// A delegation in the response is not followed and counts as no approval, see SendHTTPVote
+func SendHTTPVoteRequestWithContext(ctx context.Context, target *usermgmt.DeploymentTarget, requestData []byte, headers map[string]string) (bool, error) {
+	response, err := SendHTTPVote(ctx, target, requestData, headers)
+	if err != nil {
+		return false, err
 	}
+	return response.Approved && response.Delegation == nil, nil
+}
 
-	// Include port in proxy path
-	var proxyPath string
-	if target.ServicePort > 0 {
-		proxyPath = fmt.Sprintf("/proxy/%s:%d%s", target.AppID, target.ServicePort, votingSignPath)
-	} else {
-		// Default to 8080 if no port specified
-		proxyPath = fmt.Sprintf("/proxy/%s:8080%s", target.AppID, votingSignPath)
+// SendHTTPVote sends a vote request to a target app via HTTP and returns its full response, bounded by ctx
+func SendHTTPVote(ctx context.Context, target *usermgmt.DeploymentTarget, requestData []byte, headers map[string]string) (*VoteResponse, error) {
+	return (&Sender{}).Vote(ctx, target, &VoteRequest{Data: requestData, Headers: headers})
+}
+
+// Vote sends a vote request to a target app over its transport and returns its full response, bounded by ctx
+// Requests that fail on the way are retried under the target's retry policy, see Sender.Retry
+func (s *Sender) Vote(ctx context.Context, target *usermgmt.DeploymentTarget, request *VoteRequest) (*VoteResponse, error) {
+	policy := s.retryFor(target.AppID)
+	for attempt := 1; ; attempt++ {
+		response, err := s.vote(ctx, target, request)
+		if err == nil || attempt >= policy.Attempts || !Retryable(err) {
+			return response, err
+		}
+
+		delay := policy.backoff(attempt)
+		log.Printf("🔁 Vote request to %s failed (attempt %d/%d), retrying in %s: %v", target.AppID, attempt, policy.Attempts, delay, err)
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(delay):
+		}
 	}
-	
-	// Extract host from DeploymentClientAddress (format: host:port)
-	deploymentHost := target.DeploymentClientAddress
-	if colonIndex := strings.LastIndex(deploymentHost, ":"); colonIndex != -1 {
-		deploymentHost = deploymentHost[:colonIndex] // Remove port, keep only host
+}
+
+// vote sends a vote request to a target app once
+func (s *Sender) vote(ctx context.Context, target *usermgmt.DeploymentTarget, request *VoteRequest) (*VoteResponse, error) {
+	transport := s.transportFor(target.AppID)
+	switch transport {
+	case TransportGRPC:
+		return s.grpcVote(ctx, target, request)
+	case TransportREST:
+		return s.restVote(ctx, target, request)
 	}
-	
-	endpoint := fmt.Sprintf("http://%s:8090%s", deploymentHost, proxyPath)
+	requestData, headers := request.Data, request.Headers
+	endpoint := transport.Endpoint(target)
 
 	// Create HTTP request with provided data
-	req, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(requestData))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, nil)
 	if err != nil {
-		return false, fmt.Errorf("failed to create HTTP request: %w", err)
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
 	// Set default headers
@@ -126,49 +153,80 @@ func SendHTTPVoteRequestWithHeaders(target *usermgmt.DeploymentTarget, requestDa
 			req.Header.Set(key, value)
 		}
 	}
-
-	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: timeout,
+	// Advertise the newest response schema we parse, over any version header forwarded along
+	req.Header.Set(VoteVersionHeader, strconv.Itoa(VoteResponseVersion))
+	release, err := setBody(req, requestData, s.CompressThreshold)
+	if err != nil {
+		return nil, err
 	}
+	defer release()
 
-	// Send request
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
-	req = req.WithContext(ctx)
+	// The request context carries the deadline
+	client := &http.Client{}
 
-	log.Printf("📤 Sending vote request to %s via deployment-client: %s", target.AppID, endpoint)
+	// Send request
+	log.Printf("📤 Sending vote request to %s via %s: %s", target.AppID, transport, endpoint)
 	resp, err := client.Do(req)
 	if err != nil {
-		return false, fmt.Errorf("HTTP vote request failed: %w", err)
+		return nil, fmt.Errorf("HTTP vote request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Read response body
-	bodyBytes, err := io.ReadAll(resp.Body)
+	// Read response body into a pooled buffer; parsing copies what it keeps
+	respBuf, err := readBody(resp.Body)
 	if err != nil {
-		return false, fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
+	defer putBuffer(respBuf)
+	bodyBytes := respBuf.Bytes()
 
 	// Check HTTP status
+	if resp.StatusCode == http.StatusRequestEntityTooLarge {
+		return nil, fmt.Errorf("%w: %s refused a vote request of %d bytes", ErrPayloadTooLarge, target.AppID, len(requestData))
+	}
 	if resp.StatusCode != http.StatusOK {
-		return false, fmt.Errorf("HTTP vote request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
+	}
+
+	// Parse response against the schema version it declares
+	response, err := ParseVoteResponse(bodyBytes, target.AppID, s.MinResponseVersion)
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse response - only check for approved field
-	var response map[string]interface{}
-	if err := json.Unmarshal(bodyBytes, &response); err != nil {
-		return false, fmt.Errorf("failed to parse vote response: %w", err)
+	if response.Delegation != nil {
+		log.Printf("📥 Received vote delegation from %s to %s", target.AppID, response.Delegation.DelegateAppID)
+	} else {
+		log.Printf("📥 Received vote response from %s: approved=%t", target.AppID, response.Approved)
 	}
+	return response, nil
+}
 
-	approved, ok := response["approved"].(bool)
-	if !ok {
-		return false, fmt.Errorf("invalid response format: missing approved field")
+// proxyEndpoint returns the URL of a target app's voting sign path behind its deployment-client
+func proxyEndpoint(target *usermgmt.DeploymentTarget) string {
+	// Build endpoint URL - send to deployment-client on port 8090 for HTTP forwarding
+	// Format: http://deployment-host:8090/proxy/{app_id}:{port}{voting_sign_path}
+	votingSignPath := target.VotingSignPath
+	if !strings.HasPrefix(votingSignPath, "/") {
+		votingSignPath = "/" + votingSignPath
 	}
 
-	log.Printf("📥 Received vote response from %s: approved=%t", target.AppID, approved)
-	return approved, nil
+	// Include port in proxy path
+	var proxyPath string
+	if target.ServicePort > 0 {
+		proxyPath = fmt.Sprintf("/proxy/%s:%d%s", target.AppID, target.ServicePort, votingSignPath)
+	} else {
+		// Default to 8080 if no port specified
+		proxyPath = fmt.Sprintf("/proxy/%s:8080%s", target.AppID, votingSignPath)
+	}
+	
+	// Extract host from DeploymentClientAddress (format: host:port)
+	deploymentHost := target.DeploymentClientAddress
+	if colonIndex := strings.LastIndex(deploymentHost, ":"); colonIndex != -1 {
+		deploymentHost = deploymentHost[:colonIndex] // Remove port, keep only host
+	}
+	
+	return fmt.Sprintf("http://%s:8090%s", deploymentHost, proxyPath)
 }
 
 // ExtractHeadersFromRequest extracts all headers from HTTP request for forwarding
@@ -0,0 +1,77 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package voting
+
+import (
+	"context"
+	"errors"
+)
+
+// RejectionCode says why a vote was not an approval, so callers can tell a peer that said no
+// from a peer that is broken or unreachable
+type RejectionCode string
+
+// Codes sent by voters along with a rejection
+const (
+	CodeRejected        RejectionCode = "rejected"          // The voter decided against, default for rejections without a code
+	CodePolicyViolation RejectionCode = "policy_violation"  // The request breaks the voter's policy
+	CodeParseError      RejectionCode = "parse_error"       // The voter could not parse the request
+	CodeInternalError   RejectionCode = "internal_error"    // The voter failed while deciding
+	CodeUnauthorized    RejectionCode = "unauthorized"      // The requester may not ask the voter about this app
+	CodePayloadTooLarge RejectionCode = "payload_too_large" // The request exceeds the voter's size limits
+)
+
+// Codes assigned by the requesting client when no valid vote arrived
+const (
+	CodeTimeout           RejectionCode = "timeout"            // No answer before the deadline
+	CodeUnreachable       RejectionCode = "unreachable"        // The request could not be delivered or failed
+	CodeInvalidResponse   RejectionCode = "invalid_response"   // The answer didn't follow the vote response schema
+	CodeInvalidDelegation RejectionCode = "invalid_delegation" // The vote was delegated in a way that can't be followed
+	CodeCancelled         RejectionCode = "cancelled"          // The round was cancelled before the answer arrived
+)
+
+// ErrInvalidDelegation is matched by errors.Is for delegated votes that can't be followed
+var ErrInvalidDelegation = errors.New("invalid vote delegation")
+
+// MaxRejectionCodeLength bounds rejection codes in vote responses
+const MaxRejectionCodeLength = 64
+
+// PeerFault reports whether the code means the peer is broken or unreachable rather than that
+// it decided against the request
+func (c RejectionCode) PeerFault() bool {
+	switch c {
+	case CodeParseError, CodeInternalError, CodeTimeout, CodeUnreachable, CodeInvalidResponse, CodeInvalidDelegation:
+		return true
+	default:
+		return false
+	}
+}
+
+// ErrorCode classifies an error from sending a vote request
+func ErrorCode(err error) RejectionCode {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return CodeTimeout
+	case errors.Is(err, context.Canceled):
+		return CodeCancelled
+	case errors.Is(err, ErrInvalidVoteResponse):
+		return CodeInvalidResponse
+	case errors.Is(err, ErrInvalidDelegation):
+		return CodeInvalidDelegation
+	case errors.Is(err, ErrPayloadTooLarge):
+		return CodePayloadTooLarge
+	default:
+		return CodeUnreachable
+	}
+}
@@ -0,0 +1,41 @@
+package voting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want RejectionCode
+	}{
+		{fmt.Errorf("HTTP vote request failed: %w", context.DeadlineExceeded), CodeTimeout},
+		{fmt.Errorf("%w: missing approved field", ErrInvalidVoteResponse), CodeInvalidResponse},
+		{fmt.Errorf("%w: cycle", ErrInvalidDelegation), CodeInvalidDelegation},
+		{errors.New("connection refused"), CodeUnreachable},
+	}
+	for _, tt := range tests {
+		if got := ErrorCode(tt.err); got != tt.want {
+			t.Errorf("ErrorCode(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+		if !tt.want.PeerFault() {
+			t.Errorf("%s should be a peer fault", tt.want)
+		}
+	}
+
+	if got := ErrorCode(fmt.Errorf("HTTP vote request failed: %w", context.Canceled)); got != CodeCancelled {
+		t.Errorf("ErrorCode(canceled) = %s, want %s", got, CodeCancelled)
+	}
+	if got := ErrorCode(fmt.Errorf("transformed vote request: %w", &PayloadTooLargeError{Payload: "vote request", Size: 10, Limit: 5})); got != CodePayloadTooLarge {
+		t.Errorf("ErrorCode(too large) = %s, want %s", got, CodePayloadTooLarge)
+	}
+
+	for _, code := range []RejectionCode{CodeRejected, CodePolicyViolation, CodeCancelled, CodePayloadTooLarge, "custom_reason"} {
+		if code.PeerFault() {
+			t.Errorf("%s should not be a peer fault", code)
+		}
+	}
+}
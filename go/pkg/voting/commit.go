@@ -0,0 +1,169 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package voting
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+)
+
+// Voting round phases, sent in the voting_phase field of forwarded requests
+const (
+	PhasePrepare = "prepare" // Vote request; approvals are given on the message digest
+	PhaseCommit  = "commit"  // Notification carrying the final signature
+	PhaseAbort   = "abort"   // Notification that a round ended without a signature
+)
+
+// Commit is sent to every participant of a voting round once the approved message is signed,
+// giving each voter a verifiable record of what was ultimately signed
+type Commit struct {
+	Phase          string   `json:"voting_phase"`
+	IsForwarded    bool     `json:"is_forwarded"` // Keeps voting handlers from starting a new round
+	SignerAppID    string   `json:"signer_app_id"`
+	Message        []byte   `json:"message"`
+	MessageDigest  string   `json:"message_digest"` // Hex SHA-256 of Message, as sent in the prepare phase
+	Signature      string   `json:"signature"`      // Hex encoded
+	ED25519Mode    uint32   `json:"ed25519_mode,omitempty"`
+	ED25519Context []byte   `json:"ed25519_context,omitempty"`
+	RequiredVotes  int      `json:"required_votes"`
+	ApprovedBy     []string `json:"approved_by"`
+	Timestamp      int64    `json:"timestamp"`
+}
+
+// Abort tells the participants of a voting round that it ended without a signature, e.g. it
+// expired after the signing client restarted, so approvals given for it can be dropped
+type Abort struct {
+	Phase         string `json:"voting_phase"`
+	IsForwarded   bool   `json:"is_forwarded"`
+	SignerAppID   string `json:"signer_app_id"`
+	MessageDigest string `json:"message_digest"`
+	Reason        string `json:"reason"`
+	Timestamp     int64  `json:"timestamp"`
+}
+
+// MessageDigest returns the hex SHA-256 digest voters approve in the prepare phase
+func MessageDigest(message []byte) string {
+	digest := sha256.Sum256(message)
+	return hex.EncodeToString(digest[:])
+}
+
+// MarkRequestAsPrepare marks a forwarded vote request as the prepare phase of a two-phase round,
+// adding the digest of the message being voted on
+func MarkRequestAsPrepare(requestData, message []byte) ([]byte, error) {
+	var requestMap map[string]interface{}
+	if err := json.Unmarshal(requestData, &requestMap); err != nil {
+		return nil, fmt.Errorf("failed to parse request JSON: %w", err)
+	}
+
+	requestMap["is_forwarded"] = true
+	requestMap["voting_phase"] = PhasePrepare
+	requestMap["message_digest"] = MessageDigest(message)
+
+	modifiedData, err := json.Marshal(requestMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal modified request: %w", err)
+	}
+
+	return modifiedData, nil
+}
+
+// ParseCommit returns the commit notification in a request body, if it is one
+func ParseCommit(requestData []byte) (*Commit, bool) {
+	var commit Commit
+	if err := json.Unmarshal(requestData, &commit); err != nil || commit.Phase != PhaseCommit {
+		return nil, false
+	}
+	return &commit, true
+}
+
+// ParseAbort returns the abort notification in a request body, if it is one
+func ParseAbort(requestData []byte) (*Abort, bool) {
+	var abort Abort
+	if err := json.Unmarshal(requestData, &abort); err != nil || abort.Phase != PhaseAbort {
+		return nil, false
+	}
+	return &abort, true
+}
+
+// SendHTTPCommit delivers a commit notification to a voting round participant, bounded by ctx
+func SendHTTPCommit(ctx context.Context, target *usermgmt.DeploymentTarget, commit *Commit, headers map[string]string) error {
+	return (&Sender{}).Commit(ctx, target, commit, headers)
+}
+
+// SendHTTPAbort delivers an abort notification to a voting round participant, bounded by ctx
+func SendHTTPAbort(ctx context.Context, target *usermgmt.DeploymentTarget, abort *Abort, headers map[string]string) error {
+	return (&Sender{}).Abort(ctx, target, abort, headers)
+}
+
+// Commit delivers a commit notification to a voting round participant, bounded by ctx
+func (s *Sender) Commit(ctx context.Context, target *usermgmt.DeploymentTarget, commit *Commit, headers map[string]string) error {
+	return s.notify(ctx, target, "commit", commit, headers)
+}
+
+// Abort delivers an abort notification to a voting round participant, bounded by ctx
+func (s *Sender) Abort(ctx context.Context, target *usermgmt.DeploymentTarget, abort *Abort, headers map[string]string) error {
+	return s.notify(ctx, target, "abort", abort, headers)
+}
+
+// notify posts a round notification to a participant's voting sign path
+func (s *Sender) notify(ctx context.Context, target *usermgmt.DeploymentTarget, kind string, notification any, headers map[string]string) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", kind, err)
+	}
+
+	transport := s.transportFor(target.AppID)
+	switch transport {
+	case TransportGRPC:
+		return s.grpcNotify(ctx, target, kind, body, headers)
+	case TransportREST:
+		return s.restNotify(ctx, target, kind, body, headers)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", transport.Endpoint(target), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	release, err := setBody(req, body, s.CompressThreshold)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	resp, err := (&http.Client{}).Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP %s request failed: %w", kind, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("HTTP %s request failed with status %d: %s", kind, resp.StatusCode, string(bodyBytes))
+	}
+	log.Printf("📨 %s delivered to %s", strings.ToUpper(kind[:1])+kind[1:], target.AppID)
+	return nil
+}
@@ -0,0 +1,79 @@
+package voting
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarkRequestAsPrepare(t *testing.T) {
+	message := []byte("transfer 10")
+	data, err := MarkRequestAsPrepare([]byte(`{"message":"dHJhbnNmZXIgMTA=","signer_app_id":"app"}`), message)
+	if err != nil {
+		t.Fatalf("MarkRequestAsPrepare failed: %v", err)
+	}
+
+	var request map[string]any
+	if err := json.Unmarshal(data, &request); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if request["is_forwarded"] != true || request["voting_phase"] != PhasePrepare {
+		t.Errorf("request not marked as forwarded prepare: %v", request)
+	}
+	if request["message_digest"] != MessageDigest(message) {
+		t.Errorf("message_digest = %v, want %s", request["message_digest"], MessageDigest(message))
+	}
+	if request["signer_app_id"] != "app" {
+		t.Errorf("original fields not kept: %v", request)
+	}
+	if _, ok := ParseCommit(data); ok {
+		t.Error("prepare request parsed as a commit")
+	}
+}
+
+func TestParseCommit(t *testing.T) {
+	commit := &Commit{
+		Phase:         PhaseCommit,
+		IsForwarded:   true,
+		SignerAppID:   "app",
+		Message:       []byte("transfer 10"),
+		MessageDigest: MessageDigest([]byte("transfer 10")),
+		Signature:     "abcd",
+		ApprovedBy:    []string{"a", "b"},
+	}
+	data, err := json.Marshal(commit)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+
+	parsed, ok := ParseCommit(data)
+	if !ok {
+		t.Fatal("commit not recognized")
+	}
+	if string(parsed.Message) != "transfer 10" || parsed.Signature != "abcd" || len(parsed.ApprovedBy) != 2 {
+		t.Errorf("parsed commit = %+v", parsed)
+	}
+
+	if _, ok := ParseCommit([]byte(`{"message":"aGk="}`)); ok {
+		t.Error("vote request parsed as a commit")
+	}
+	if _, ok := ParseCommit([]byte(`not json`)); ok {
+		t.Error("invalid JSON parsed as a commit")
+	}
+}
+
+func TestParseAbort(t *testing.T) {
+	data, err := json.Marshal(&Abort{Phase: PhaseAbort, IsForwarded: true, SignerAppID: "app", Reason: "expired"})
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	abort, ok := ParseAbort(data)
+	if !ok || abort.Reason != "expired" {
+		t.Fatalf("ParseAbort = %+v, %t", abort, ok)
+	}
+	if _, ok := ParseCommit(data); ok {
+		t.Error("abort parsed as a commit")
+	}
+	if _, ok := ParseAbort([]byte(`{"voting_phase":"commit"}`)); ok {
+		t.Error("commit parsed as an abort")
+	}
+}
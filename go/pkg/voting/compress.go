@@ -0,0 +1,120 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package voting
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// EncodingGzip is the Content-Encoding of compressed vote requests, see Sender.CompressThreshold
+const EncodingGzip = "gzip"
+
+// compressBody gzips an HTTP request body larger than threshold bytes; threshold 0 never does
+// It returns the compressed body in a pooled buffer, or nil when body is to be sent as is
+func compressBody(body []byte, threshold int) (*bytes.Buffer, error) {
+	if threshold <= 0 || len(body) <= threshold {
+		return nil, nil
+	}
+	buf := getBuffer()
+	zw := gzipWriterPool.Get().(*gzip.Writer)
+	zw.Reset(buf)
+	_, err := zw.Write(body)
+	if err == nil {
+		err = zw.Close()
+	}
+	gzipWriterPool.Put(zw)
+	if err != nil {
+		putBuffer(buf)
+		return nil, fmt.Errorf("failed to compress request: %w", err)
+	}
+	// Small or incompressible bodies can come out larger
+	if buf.Len() >= len(body) {
+		putBuffer(buf)
+		return nil, nil
+	}
+	return buf, nil
+}
+
+// setBody sets an HTTP request's body, compressed per threshold, replacing a Content-Encoding
+// forwarded along with the original request's headers. The returned function must be called once
+// the request is done; a compressed body goes back to the pool when the transport closed it too
+func setBody(req *http.Request, body []byte, threshold int) (func(), error) {
+	compressed, err := compressBody(body, threshold)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Del("Content-Encoding")
+	if compressed == nil {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		return func() {}, nil
+	}
+
+	pooled := newPooledBody(compressed)
+	req.Body, _ = pooled.open()
+	req.ContentLength = int64(compressed.Len())
+	req.GetBody = pooled.open
+	req.Header.Set("Content-Encoding", EncodingGzip)
+	return pooled.release, nil
+}
+
+// ReadRequestBody reads the body of an incoming vote request, decompressing gzip request bodies
+// Voting handlers that read bodies themselves should use it once senders compress requests
+func ReadRequestBody(r *http.Request) ([]byte, error) {
+	return ReadRequestBodyLimit(r, 0)
+}
+
+// ReadRequestBodyLimit is ReadRequestBody refusing bodies that decompress to more than limit
+// bytes with a PayloadTooLargeError; limit 0 means no limit. The body is never read further
+// than one byte past the limit
+func ReadRequestBodyLimit(r *http.Request, limit int64) ([]byte, error) {
+	var body io.Reader
+	encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding")))
+	switch encoding {
+	case "", "identity":
+		body = r.Body
+	case EncodingGzip:
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip request body: %w", err)
+		}
+		defer zr.Close()
+		body = zr
+	default:
+		return nil, fmt.Errorf("unsupported request Content-Encoding %q", encoding)
+	}
+	if limit > 0 {
+		body = io.LimitReader(body, limit+1)
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		if encoding == EncodingGzip {
+			return nil, fmt.Errorf("invalid gzip request body: %w", err)
+		}
+		return nil, err
+	}
+	if limit > 0 && int64(len(data)) > limit {
+		return nil, &PayloadTooLargeError{Payload: "vote request", Limit: limit}
+	}
+	return data, nil
+}
@@ -0,0 +1,84 @@
+package voting
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCompressBody(t *testing.T) {
+	body := []byte(`{"data":"` + strings.Repeat("a", 4096) + `"}`)
+
+	tests := []struct {
+		name      string
+		body      []byte
+		threshold int
+		encoding  string
+	}{
+		{"disabled", body, 0, ""},
+		{"below threshold", body, len(body), ""},
+		{"above threshold", body, 1024, EncodingGzip},
+		{"incompressible", []byte(`{}`), 1, ""},
+	}
+	for _, tt := range tests {
+		compressed, err := compressBody(tt.body, tt.threshold)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		encoding := ""
+		if compressed != nil {
+			encoding = EncodingGzip
+			if compressed.Len() >= len(tt.body) {
+				t.Errorf("%s: compressed to %d bytes, not less than %d", tt.name, compressed.Len(), len(tt.body))
+			}
+		}
+		if encoding != tt.encoding {
+			t.Errorf("%s: encoding = %q, want %q", tt.name, encoding, tt.encoding)
+		}
+	}
+}
+
+func TestReadRequestBody(t *testing.T) {
+	body := []byte(`{"data":"` + strings.Repeat("a", 4096) + `"}`)
+
+	req, _ := http.NewRequest("POST", "http://app/vote", nil)
+	req.Header.Set("Content-Encoding", "br") // Forwarded from the original request
+	release, err := setBody(req, body, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	if got := req.Header.Get("Content-Encoding"); got != EncodingGzip {
+		t.Fatalf("Content-Encoding = %q, want gzip", got)
+	}
+	if req.ContentLength >= int64(len(body)) {
+		t.Errorf("ContentLength = %d, want less than %d", req.ContentLength, len(body))
+	}
+	got, err := ReadRequestBody(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, body) {
+		t.Error("decompressed body differs")
+	}
+
+	req, _ = http.NewRequest("POST", "http://app/vote", nil)
+	req.Header.Set("Content-Encoding", "br")
+	if _, err := setBody(req, []byte(`{}`), 1024); err != nil {
+		t.Fatal(err)
+	}
+	if got := req.Header.Get("Content-Encoding"); got != "" {
+		t.Errorf("Content-Encoding = %q, want none", got)
+	}
+
+	req, _ = http.NewRequest("POST", "http://app/vote", strings.NewReader("not gzip"))
+	req.Header.Set("Content-Encoding", EncodingGzip)
+	if _, err := ReadRequestBody(req); err == nil {
+		t.Error("invalid gzip body accepted")
+	}
+	req.Header.Set("Content-Encoding", "br")
+	if _, err := ReadRequestBody(req); err == nil {
+		t.Error("unsupported encoding accepted")
+	}
+}
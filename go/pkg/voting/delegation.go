@@ -0,0 +1,67 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package voting
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// delegationDomain prefixes every delegation message so delegation signatures can't be
+// mistaken for signatures over other data
+const delegationDomain = "teenet-vote-delegation/v1"
+
+// Delegation is a target app's signed statement that another app votes in its place
+//
+// A target that delegates answers a vote request with
+//
+//	{"approved": false, "delegation": {"delegate_app_id": "...", "expires_at": 1767225600, "signature": "<hex>"}}
+//
+// where signature is the delegating app's signature over DelegationMessage
+type Delegation struct {
+	DelegateAppID string `json:"delegate_app_id"`
+	ExpiresAt     int64  `json:"expires_at"` // Unix timestamp after which the delegation is void
+	Signature     string `json:"signature"`  // Hex signature by the delegating app's key
+}
+
+// DelegationMessage returns the message a delegating app signs to let delegateAppID vote in its
+// place on votingAppID's voting rounds until expiresAt
+func DelegationMessage(votingAppID, delegatorAppID, delegateAppID string, expiresAt time.Time) []byte {
+	return []byte(strings.Join([]string{
+		delegationDomain,
+		votingAppID,
+		delegatorAppID,
+		delegateAppID,
+		strconv.FormatInt(expiresAt.Unix(), 10),
+	}, "\n"))
+}
+
+// Validate checks the delegation's fields, not its signature
+func (d *Delegation) Validate(delegatorAppID string, now time.Time) error {
+	if d.DelegateAppID == "" {
+		return fmt.Errorf("delegation from %s has no delegate", delegatorAppID)
+	}
+	if d.DelegateAppID == delegatorAppID {
+		return fmt.Errorf("app %s delegated its vote to itself", delegatorAppID)
+	}
+	if d.Signature == "" {
+		return fmt.Errorf("delegation from %s is not signed", delegatorAppID)
+	}
+	if !now.Before(time.Unix(d.ExpiresAt, 0)) {
+		return fmt.Errorf("delegation from %s to %s expired at %s", delegatorAppID, d.DelegateAppID, time.Unix(d.ExpiresAt, 0).UTC().Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package voting
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestDelegationMessage(t *testing.T) {
+	expiresAt := time.Unix(1767225600, 0)
+	msg := DelegationMessage("signer", "alice", "bob", expiresAt)
+	want := []byte("teenet-vote-delegation/v1\nsigner\nalice\nbob\n1767225600")
+	if !bytes.Equal(msg, want) {
+		t.Fatalf("DelegationMessage = %q, want %q", msg, want)
+	}
+	if bytes.Equal(msg, DelegationMessage("signer", "alice", "carol", expiresAt)) {
+		t.Errorf("delegation message does not depend on the delegate")
+	}
+	if bytes.Equal(msg, DelegationMessage("other", "alice", "bob", expiresAt)) {
+		t.Errorf("delegation message does not depend on the voting app")
+	}
+}
+
+func TestDelegationValidate(t *testing.T) {
+	now := time.Unix(1767225600, 0)
+	valid := Delegation{DelegateAppID: "bob", ExpiresAt: now.Add(time.Hour).Unix(), Signature: "00"}
+
+	tests := []struct {
+		name    string
+		modify  func(*Delegation)
+		wantErr bool
+	}{
+		{"valid", func(*Delegation) {}, false},
+		{"no delegate", func(d *Delegation) { d.DelegateAppID = "" }, true},
+		{"self delegation", func(d *Delegation) { d.DelegateAppID = "alice" }, true},
+		{"unsigned", func(d *Delegation) { d.Signature = "" }, true},
+		{"expired", func(d *Delegation) { d.ExpiresAt = now.Unix() }, true},
+	}
+	for _, tt := range tests {
+		d := valid
+		tt.modify(&d)
+		err := d.Validate("alice", now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package voting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/voting"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// GatewayPath is where the REST facade of the VotingService accepts VotingRequests
+const GatewayPath = "/v1/voting"
+
+// maxGatewayBodySize bounds gateway request bodies like gRPC's default receive limit
+const maxGatewayBodySize = 4 << 20
+
+// gatewayJSON encodes gateway responses with the proto field names, e.g. "rejection_code"
+var gatewayJSON = protojson.MarshalOptions{UseProtoNames: true}
+
+// gatewayError is the body of a failed gateway request, as gRPC-Gateway writes it
+type gatewayError struct {
+	Code    codes.Code `json:"code"`
+	Message string     `json:"message"`
+}
+
+// NewGateway returns a REST/JSON facade of the VotingService: POST GatewayPath with a
+// VotingRequest in protobuf JSON answers with the handler's VotingResponse. Request headers
+// reach the handler as incoming gRPC metadata, so handlers serve both protocols alike
+func NewGateway(handler func(context.Context, *pb.VotingRequest) (*pb.VotingResponse, error)) http.Handler {
+	server := NewServer(handler)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+GatewayPath, func(w http.ResponseWriter, r *http.Request) {
+		body, err := ReadRequestBodyLimit(r, maxGatewayBodySize)
+		if err != nil {
+			code := codes.InvalidArgument
+			var tooLarge *PayloadTooLargeError
+			if errors.As(err, &tooLarge) {
+				code = codes.ResourceExhausted
+			}
+			writeGatewayError(w, status.Error(code, err.Error()))
+			return
+		}
+		var req pb.VotingRequest
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, &req); err != nil {
+			writeGatewayError(w, status.Errorf(codes.InvalidArgument, "invalid voting request: %v", err))
+			return
+		}
+
+		md := metadata.MD{}
+		for name, values := range r.Header {
+			if name = strings.ToLower(name); !skippedMetadata[name] {
+				md.Append(name, values...)
+			}
+		}
+		resp, err := server.Voting(metadata.NewIncomingContext(r.Context(), md), &req)
+		if err != nil {
+			writeGatewayError(w, err)
+			return
+		}
+		writeGatewayMessage(w, http.StatusOK, resp)
+	})
+	return mux
+}
+
+// StartVotingGateway serves the REST facade of the VotingService on addr until the returned
+// server is shut down
+func StartVotingGateway(addr string, handler func(context.Context, *pb.VotingRequest) (*pb.VotingResponse, error)) (*http.Server, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	server := &http.Server{
+		Handler:           NewGateway(handler),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Printf("🗳️  Voting REST gateway started on %s", addr)
+	go func() {
+		if err := server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("❌ Voting REST gateway error: %v", err)
+		}
+	}()
+	return server, nil
+}
+
+// writeGatewayMessage writes a protobuf message as JSON
+func writeGatewayMessage(w http.ResponseWriter, statusCode int, msg proto.Message) {
+	data, err := gatewayJSON.Marshal(msg)
+	if err != nil {
+		writeGatewayError(w, status.Error(codes.Internal, err.Error()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(data)
+}
+
+// writeGatewayError writes err with the HTTP status of its gRPC code
+func writeGatewayError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus(st.Code()))
+	data, _ := json.Marshal(gatewayError{Code: st.Code(), Message: st.Message()})
+	w.Write(data)
+}
+
+// httpStatus maps a gRPC code to an HTTP status as gRPC-Gateway does
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return 499
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// gatewayEndpoint returns the URL of the REST facade on a target app's container
+func gatewayEndpoint(target *usermgmt.DeploymentTarget) string {
+	port := target.ServicePort
+	if port <= 0 {
+		port = 8080 // Same default as the proxy
+	}
+	return fmt.Sprintf("http://%s:%d%s", target.ContainerIP, port, GatewayPath)
+}
+
+// restVote sends a vote request to the REST facade of the target's VotingService
+func (s *Sender) restVote(ctx context.Context, target *usermgmt.DeploymentTarget, request *VoteRequest) (*VoteResponse, error) {
+	log.Printf("📤 Sending vote request to %s via rest: %s", target.AppID, gatewayEndpoint(target))
+	response, err := s.callGateway(ctx, target, &pb.VotingRequest{
+		Message:           request.Message,
+		RequiredVotes:     uint32(request.RequiredVotes),
+		TotalParticipants: uint32(request.TotalParticipants),
+		SignerAppId:       request.SignerAppID,
+		RequestData:       request.Data,
+		Principal:         request.Principal.proto(),
+	}, request.Headers)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("📥 Received vote response from %s: approved=%t", target.AppID, response.Success)
+	return voteFromProto(target, response), nil
+}
+
+// restNotify delivers a round notification through the REST facade, like grpcNotify
+func (s *Sender) restNotify(ctx context.Context, target *usermgmt.DeploymentTarget, kind string, body []byte, headers map[string]string) error {
+	if _, err := s.callGateway(ctx, target, &pb.VotingRequest{RequestData: body}, headers); err != nil {
+		return fmt.Errorf("REST %s request failed: %w", kind, err)
+	}
+	log.Printf("📨 %s delivered to %s", strings.ToUpper(kind[:1])+kind[1:], target.AppID)
+	return nil
+}
+
+// callGateway posts one request to the REST facade on the target's container
+func (s *Sender) callGateway(ctx context.Context, target *usermgmt.DeploymentTarget, request *pb.VotingRequest, headers map[string]string) (*pb.VotingResponse, error) {
+	taskID, err := newTaskID()
+	if err != nil {
+		return nil, err
+	}
+	request.TaskId = taskID
+	request.AppId = target.AppID
+	request.TargetContainerIp = target.ContainerIP
+	body, err := gatewayJSON.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal voting request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", gatewayEndpoint(target), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	release, err := setBody(req, body, s.CompressThreshold)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	resp, err := (&http.Client{}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("REST vote request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	respBuf, err := readBody(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	defer putBuffer(respBuf)
+	respBody := respBuf.Bytes()
+	if resp.StatusCode == http.StatusRequestEntityTooLarge {
+		return nil, fmt.Errorf("%w: %s refused a vote request of %d bytes", ErrPayloadTooLarge, target.AppID, len(body))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	var response pb.VotingResponse
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(respBody, &response); err != nil {
+		return nil, fmt.Errorf("%w from %s: %w", ErrInvalidVoteResponse, target.AppID, err)
+	}
+	return &response, nil
+}
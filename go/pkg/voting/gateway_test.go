@@ -0,0 +1,108 @@
+package voting
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/voting"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// gatewayTarget serves handler through the gateway and returns a target pointing at it
+func gatewayTarget(t *testing.T, handler func(context.Context, *pb.VotingRequest) (*pb.VotingResponse, error)) *usermgmt.DeploymentTarget {
+	t.Helper()
+	server := httptest.NewServer(NewGateway(handler))
+	t.Cleanup(server.Close)
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("SplitHostPort failed: %v", err)
+	}
+	servicePort, _ := strconv.Atoi(port)
+	return &usermgmt.DeploymentTarget{AppID: "app-b", ContainerIP: host, ServicePort: int32(servicePort)}
+}
+
+func TestSenderRESTVote(t *testing.T) {
+	received := make(chan *pb.VotingRequest, 2)
+	var receivedAuth []string
+	target := gatewayTarget(t, func(ctx context.Context, req *pb.VotingRequest) (*pb.VotingResponse, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		receivedAuth = md.Get("authorization")
+		received <- req
+		if string(req.Message) != "approve me" {
+			return &pb.VotingResponse{Error: "not today", RejectionCode: string(CodePolicyViolation)}, nil
+		}
+		return &pb.VotingResponse{Success: true, TaskId: req.TaskId}, nil
+	})
+	sender := &Sender{Transport: TransportREST, CompressThreshold: 16}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	response, err := sender.Vote(ctx, target, &VoteRequest{
+		SignerAppID:       "app-a",
+		Message:           []byte("approve me"),
+		Data:              []byte(`{"is_forwarded":true,"padding":"compressed above the threshold"}`),
+		Headers:           map[string]string{"Authorization": "Bearer token"},
+		RequiredVotes:     2,
+		TotalParticipants: 3,
+	})
+	if err != nil {
+		t.Fatalf("Vote failed: %v", err)
+	}
+	if !response.Approved || response.Voter != "app-b" {
+		t.Errorf("Expected approval from app-b, got %+v", response)
+	}
+
+	req := <-received
+	if req.AppId != "app-b" || req.SignerAppId != "app-a" || req.TaskId == "" || req.RequiredVotes != 2 {
+		t.Errorf("Unexpected request: %+v", req)
+	}
+	if !strings.HasPrefix(string(req.RequestData), `{"is_forwarded":true`) {
+		t.Errorf("Unexpected request data %q", req.RequestData)
+	}
+	if len(receivedAuth) != 1 || receivedAuth[0] != "Bearer token" {
+		t.Errorf("Authorization metadata = %v", receivedAuth)
+	}
+
+	response, err = sender.Vote(ctx, target, &VoteRequest{SignerAppID: "app-a", Message: []byte("reject me")})
+	if err != nil {
+		t.Fatalf("Vote failed: %v", err)
+	}
+	if response.Approved || response.Code != CodePolicyViolation || response.Reason != "not today" {
+		t.Errorf("Expected policy rejection, got %+v", response)
+	}
+	<-received
+}
+
+func TestGatewayErrors(t *testing.T) {
+	target := gatewayTarget(t, func(ctx context.Context, req *pb.VotingRequest) (*pb.VotingResponse, error) {
+		return nil, status.Error(codes.PermissionDenied, "unknown signer")
+	})
+	sender := &Sender{Transport: TransportREST}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := sender.Vote(ctx, target, &VoteRequest{SignerAppID: "app-a", Message: []byte("m")})
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusForbidden || !strings.Contains(statusErr.Body, "unknown signer") {
+		t.Errorf("Expected 403 status error, got %v", err)
+	}
+
+	resp, err := http.Post(gatewayEndpoint(target), "application/json", strings.NewReader(`{"message":`))
+	if err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Malformed request status = %d, want 400", resp.StatusCode)
+	}
+}
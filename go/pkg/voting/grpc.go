@@ -0,0 +1,126 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package voting
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/voting"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// skippedMetadata are HTTP headers that describe the HTTP exchange itself and aren't forwarded as gRPC metadata
+var skippedMetadata = map[string]bool{
+	"connection":        true,
+	"content-length":    true,
+	"content-type":      true,
+	"host":              true,
+	"te":                true,
+	"transfer-encoding": true,
+	"upgrade":           true,
+}
+
+// grpcVote sends a vote request to the VotingService of the target's deployment-client
+// gRPC voting has no delegations: a response is an approval or a rejection
+func (s *Sender) grpcVote(ctx context.Context, target *usermgmt.DeploymentTarget, request *VoteRequest) (*VoteResponse, error) {
+	log.Printf("📤 Sending vote request to %s via grpc: %s", target.AppID, target.DeploymentClientAddress)
+	response, err := s.callVoting(ctx, target, &pb.VotingRequest{
+		Message:           request.Message,
+		RequiredVotes:     uint32(request.RequiredVotes),
+		TotalParticipants: uint32(request.TotalParticipants),
+		SignerAppId:       request.SignerAppID,
+		RequestData:       request.Data,
+		Principal:         request.Principal.proto(),
+	}, request.Headers)
+	if err != nil {
+		return nil, fmt.Errorf("gRPC vote request failed: %w", err)
+	}
+	log.Printf("📥 Received vote response from %s: approved=%t", target.AppID, response.Success)
+	return voteFromProto(target, response), nil
+}
+
+// voteFromProto converts a VotingService response; it has no delegations, so it is an
+// approval or a rejection
+func voteFromProto(target *usermgmt.DeploymentTarget, response *pb.VotingResponse) *VoteResponse {
+	vote := &VoteResponse{Approved: response.Success, Voter: target.AppID, Reason: response.Error}
+	if !response.Success {
+		vote.Code = RejectionCode(response.RejectionCode)
+	}
+	if len(response.Musig2) > 0 {
+		vote.MuSig2 = hex.EncodeToString(response.Musig2)
+	}
+	return vote
+}
+
+// grpcNotify delivers a round notification through the VotingService; the notification is the
+// request data and the response's decision is ignored
+func (s *Sender) grpcNotify(ctx context.Context, target *usermgmt.DeploymentTarget, kind string, body []byte, headers map[string]string) error {
+	if _, err := s.callVoting(ctx, target, &pb.VotingRequest{RequestData: body}, headers); err != nil {
+		return fmt.Errorf("gRPC %s request failed: %w", kind, err)
+	}
+	log.Printf("📨 %s delivered to %s", strings.ToUpper(kind[:1])+kind[1:], target.AppID)
+	return nil
+}
+
+// callVoting sends one request to the VotingService of the target's deployment-client,
+// addressed to the target container
+func (s *Sender) callVoting(ctx context.Context, target *usermgmt.DeploymentTarget, request *pb.VotingRequest, headers map[string]string) (*pb.VotingResponse, error) {
+	creds := insecure.NewCredentials()
+	if s.TLSConfig != nil {
+		creds = credentials.NewTLS(s.TLSConfig)
+	}
+	opts := append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, s.DialOptions...)
+	conn, err := grpc.NewClient(target.DeploymentClientAddress, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to deployment-client %s: %w", target.DeploymentClientAddress, err)
+	}
+	defer conn.Close()
+
+	taskID, err := newTaskID()
+	if err != nil {
+		return nil, err
+	}
+	request.TaskId = taskID
+	request.AppId = target.AppID
+	request.TargetContainerIp = target.ContainerIP
+
+	md := metadata.MD{}
+	for key, value := range headers {
+		if key = strings.ToLower(key); !skippedMetadata[key] {
+			md.Set(key, value)
+		}
+	}
+	if len(md) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+	return pb.NewVotingServiceClient(conn).Voting(ctx, request)
+}
+
+// newTaskID returns a random voting task ID
+func newTaskID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate task ID: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
@@ -0,0 +1,49 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package voting
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPayloadTooLarge is matched by errors.Is for messages and request bodies over a size limit
+var ErrPayloadTooLarge = errors.New("payload too large")
+
+// PayloadTooLargeError describes a message or request body over its size limit
+type PayloadTooLargeError struct {
+	Payload string // What was too large, e.g. "message" or "vote request"
+	Size    int64  // Size in bytes, 0 for bodies that were not read past the limit
+	Limit   int64
+}
+
+func (e *PayloadTooLargeError) Error() string {
+	if e.Size == 0 {
+		return fmt.Sprintf("%s exceeds the %d-byte limit", e.Payload, e.Limit)
+	}
+	return fmt.Sprintf("%s of %d bytes exceeds the %d-byte limit", e.Payload, e.Size, e.Limit)
+}
+
+// Is reports whether target is ErrPayloadTooLarge
+func (e *PayloadTooLargeError) Is(target error) bool {
+	return target == ErrPayloadTooLarge
+}
+
+// CheckPayloadSize returns a PayloadTooLargeError if size exceeds limit; limit 0 means no limit
+func CheckPayloadSize(payload string, size, limit int) error {
+	if limit > 0 && size > limit {
+		return &PayloadTooLargeError{Payload: payload, Size: int64(size), Limit: int64(limit)}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package voting
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCheckPayloadSize(t *testing.T) {
+	if err := CheckPayloadSize("message", 10, 0); err != nil {
+		t.Errorf("no limit: %v", err)
+	}
+	if err := CheckPayloadSize("message", 10, 10); err != nil {
+		t.Errorf("at limit: %v", err)
+	}
+	err := CheckPayloadSize("message", 11, 10)
+	if !errors.Is(err, ErrPayloadTooLarge) {
+		t.Fatalf("over limit: %v", err)
+	}
+	var tooLarge *PayloadTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Size != 11 || tooLarge.Limit != 10 {
+		t.Errorf("error = %+v", tooLarge)
+	}
+	if got := err.Error(); got != "message of 11 bytes exceeds the 10-byte limit" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestReadRequestBodyLimit(t *testing.T) {
+	body := strings.Repeat("a", 1<<20)
+
+	req, _ := http.NewRequest("POST", "http://app/vote", strings.NewReader(body))
+	if _, err := ReadRequestBodyLimit(req, 1024); !errors.Is(err, ErrPayloadTooLarge) {
+		t.Errorf("plain body over limit: %v", err)
+	}
+	req, _ = http.NewRequest("POST", "http://app/vote", strings.NewReader(body))
+	if got, err := ReadRequestBodyLimit(req, int64(len(body))); err != nil || len(got) != len(body) {
+		t.Errorf("plain body at limit: %d bytes, %v", len(got), err)
+	}
+
+	// A small gzip body must not expand past the limit
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	zw.Write([]byte(body))
+	zw.Close()
+	req, _ = http.NewRequest("POST", "http://app/vote", &compressed)
+	req.Header.Set("Content-Encoding", EncodingGzip)
+	if _, err := ReadRequestBodyLimit(req, 1024); !errors.Is(err, ErrPayloadTooLarge) {
+		t.Errorf("gzip body over limit: %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package voting
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+)
+
+// MuSig2 session phases, sent in the voting_phase field of forwarded requests
+const (
+	PhaseMuSig2Nonce = "musig2_nonce" // Asks a signer for its public nonce
+	PhaseMuSig2Sign  = "musig2_sign"  // Asks a signer for its partial signature under the aggregate nonce
+)
+
+// ErrMuSig2Refused is matched by errors.Is when a signer declines to take part in a MuSig2 session
+var ErrMuSig2Refused = errors.New("MuSig2 contribution refused")
+
+// MuSig2Request asks a signer of a MuSig2 session for its contribution: first a public nonce,
+// then a partial signature. Voting handlers decide on it like on a vote request and pass it to
+// Sign, which answers with the signer's TEE key; the answer goes back in VoteResponse.MuSig2
+type MuSig2Request struct {
+	Phase          string   `json:"voting_phase"`
+	IsForwarded    bool     `json:"is_forwarded"`
+	SignerAppID    string   `json:"signer_app_id"` // App coordinating the session
+	SessionID      string   `json:"session_id"`
+	Signers        []string `json:"signers"` // Apps whose keys are aggregated, the coordinator included
+	Message        []byte   `json:"message"` // 32-byte message, e.g. a taproot sighash
+	Taproot        bool     `json:"taproot,omitempty"`
+	AggregateNonce string   `json:"aggregate_nonce,omitempty"` // Hex, set in the sign phase
+	Timestamp      int64    `json:"timestamp"`
+}
+
+// ParseMuSig2 returns the MuSig2 request in a request body, if it is one
+func ParseMuSig2(requestData []byte) (*MuSig2Request, bool) {
+	var request MuSig2Request
+	if err := json.Unmarshal(requestData, &request); err != nil {
+		return nil, false
+	}
+	if request.Phase != PhaseMuSig2Nonce && request.Phase != PhaseMuSig2Sign {
+		return nil, false
+	}
+	return &request, true
+}
+
+// Includes reports whether appID is one of the session's signers
+func (r *MuSig2Request) Includes(appID string) bool {
+	return slices.Contains(r.Signers, appID)
+}
+
+// MuSig2 asks a signer for its contribution to a MuSig2 session over its transport, bounded by ctx
+// It returns the signer's public nonce or partial signature; a rejection or delegation is an
+// error matching ErrMuSig2Refused
+func (s *Sender) MuSig2(ctx context.Context, target *usermgmt.DeploymentTarget, request *MuSig2Request, headers map[string]string) ([]byte, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal MuSig2 request: %w", err)
+	}
+	// Sent once: a retried request could make the signer start its part of the session again
+	response, err := s.vote(ctx, target, &VoteRequest{
+		SignerAppID: request.SignerAppID,
+		Message:     request.Message,
+		Data:        body,
+		Headers:     headers,
+		Principal:   PrincipalFromHeaders(headers),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !response.Approved || response.Delegation != nil {
+		reason := response.Reason
+		if reason == "" {
+			reason = "rejected"
+		}
+		return nil, fmt.Errorf("%w by %s: %s", ErrMuSig2Refused, target.AppID, reason)
+	}
+	contribution, err := hex.DecodeString(response.MuSig2)
+	if err != nil || len(contribution) == 0 {
+		return nil, fmt.Errorf("%w: %s sent no MuSig2 contribution", ErrInvalidVoteResponse, target.AppID)
+	}
+	return contribution, nil
+}
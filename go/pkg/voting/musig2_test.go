@@ -0,0 +1,98 @@
+package voting
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/voting"
+	"google.golang.org/grpc"
+)
+
+func TestParseMuSig2(t *testing.T) {
+	if _, ok := ParseMuSig2([]byte(`{"voting_phase":"commit"}`)); ok {
+		t.Error("Commit parsed as MuSig2 request")
+	}
+	if _, ok := ParseMuSig2([]byte(`not json`)); ok {
+		t.Error("Invalid JSON parsed as MuSig2 request")
+	}
+	request, ok := ParseMuSig2([]byte(`{"voting_phase":"musig2_sign","signer_app_id":"app-a","signers":["app-a","app-b"],"aggregate_nonce":"ab"}`))
+	if !ok {
+		t.Fatal("Expected MuSig2 request")
+	}
+	if !request.Includes("app-b") || request.Includes("app-c") || request.AggregateNonce != "ab" {
+		t.Errorf("Unexpected request: %+v", request)
+	}
+}
+
+func TestSenderMuSig2HTTP(t *testing.T) {
+	contribution := []byte{1, 2, 3}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		request, ok := ParseMuSig2(body)
+		if !ok || request.Phase != PhaseMuSig2Nonce {
+			WriteVoteResponse(w, r, &VoteResponse{Voter: "app-b", Code: CodeRejected, Reason: "not a nonce request"})
+			return
+		}
+		WriteVoteResponse(w, r, &VoteResponse{Approved: true, Voter: "app-b", MuSig2: hex.EncodeToString(contribution)})
+	}))
+	defer server.Close()
+
+	host, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	servicePort, _ := strconv.Atoi(port)
+	target := &usermgmt.DeploymentTarget{AppID: "app-b", ContainerIP: host, ServicePort: int32(servicePort), VotingSignPath: "/vote"}
+	sender := &Sender{Transport: TransportDirect}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	request := &MuSig2Request{Phase: PhaseMuSig2Nonce, IsForwarded: true, SignerAppID: "app-a", Signers: []string{"app-a", "app-b"}}
+	got, err := sender.MuSig2(ctx, target, request, nil)
+	if err != nil {
+		t.Fatalf("MuSig2 failed: %v", err)
+	}
+	if hex.EncodeToString(got) != hex.EncodeToString(contribution) {
+		t.Errorf("contribution = %x, want %x", got, contribution)
+	}
+
+	request.Phase = PhaseMuSig2Sign
+	if _, err := sender.MuSig2(ctx, target, request, nil); !errors.Is(err, ErrMuSig2Refused) {
+		t.Errorf("Expected ErrMuSig2Refused, got %v", err)
+	}
+}
+
+func TestSenderMuSig2GRPC(t *testing.T) {
+	server := grpc.NewServer()
+	pb.RegisterVotingServiceServer(server, NewServer(func(ctx context.Context, req *pb.VotingRequest) (*pb.VotingResponse, error) {
+		request, ok := ParseMuSig2(req.RequestData)
+		if !ok || req.SignerAppId != request.SignerAppID {
+			return &pb.VotingResponse{Success: false, TaskId: req.TaskId}, nil
+		}
+		return &pb.VotingResponse{Success: true, TaskId: req.TaskId, Musig2: []byte{4, 5}}, nil
+	}))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	go server.Serve(listener)
+	defer server.Stop()
+
+	target := &usermgmt.DeploymentTarget{AppID: "app-b", DeploymentClientAddress: listener.Addr().String()}
+	sender := &Sender{Transport: TransportGRPC}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	got, err := sender.MuSig2(ctx, target, &MuSig2Request{Phase: PhaseMuSig2Nonce, SignerAppID: "app-a"}, nil)
+	if err != nil {
+		t.Fatalf("MuSig2 failed: %v", err)
+	}
+	if len(got) != 2 || got[0] != 4 || got[1] != 5 {
+		t.Errorf("contribution = %x", got)
+	}
+}
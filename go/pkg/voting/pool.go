@@ -0,0 +1,99 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package voting
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// maxPooledBufferSize keeps buffers grown by unusually large bodies out of the pool,
+// so one large round doesn't pin its memory for the life of the process
+const maxPooledBufferSize = 1 << 20
+
+// bufferPool holds buffers for request and response bodies of the vote fanout
+var bufferPool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// gzipWriterPool holds gzip writers; each allocates about 800 KB of compressor state
+var gzipWriterPool = sync.Pool{New: func() any { return gzip.NewWriter(io.Discard) }}
+
+// getBuffer returns an empty buffer from the pool
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// putBuffer returns buf to the pool; buf must no longer be used
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+// readBody reads r into a pooled buffer, which the caller returns with putBuffer
+func readBody(r io.Reader) (*bytes.Buffer, error) {
+	buf := getBuffer()
+	if _, err := buf.ReadFrom(r); err != nil {
+		putBuffer(buf)
+		return nil, err
+	}
+	return buf, nil
+}
+
+// pooledBody is an HTTP request body held in a pooled buffer. The HTTP transport may close a
+// request body after Do returns, so the buffer goes back to the pool only once the sender has
+// released it and every reader handed out by open was closed
+type pooledBody struct {
+	buf  *bytes.Buffer
+	refs atomic.Int32
+}
+
+// newPooledBody takes ownership of buf, holding the sender's reference
+func newPooledBody(buf *bytes.Buffer) *pooledBody {
+	b := &pooledBody{buf: buf}
+	b.refs.Store(1)
+	return b
+}
+
+// open returns a reader over the body; it serves as http.Request.GetBody
+func (b *pooledBody) open() (io.ReadCloser, error) {
+	b.refs.Add(1)
+	return &pooledBodyReader{Reader: bytes.NewReader(b.buf.Bytes()), body: b}, nil
+}
+
+// release drops a reference, returning the buffer to the pool with the last one
+func (b *pooledBody) release() {
+	if b.refs.Add(-1) == 0 {
+		putBuffer(b.buf)
+	}
+}
+
+// pooledBodyReader reads a pooledBody; closing it more than once releases it once
+type pooledBodyReader struct {
+	*bytes.Reader
+	body   *pooledBody
+	closed atomic.Bool
+}
+
+func (r *pooledBodyReader) Close() error {
+	if r.closed.CompareAndSwap(false, true) {
+		r.body.release()
+	}
+	return nil
+}
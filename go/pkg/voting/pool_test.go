@@ -0,0 +1,108 @@
+package voting
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+)
+
+func TestPooledBodyRelease(t *testing.T) {
+	buf := getBuffer()
+	buf.WriteString("body")
+	pooled := newPooledBody(buf)
+
+	first, _ := pooled.open()
+	second, _ := pooled.open()
+	pooled.release()
+	if got := pooled.refs.Load(); got != 2 {
+		t.Fatalf("refs = %d after sender release, want 2", got)
+	}
+
+	data, _ := io.ReadAll(first)
+	first.Close()
+	first.Close() // Closing twice releases once
+	if string(data) != "body" {
+		t.Errorf("read %q, want body", data)
+	}
+	if got := pooled.refs.Load(); got != 1 {
+		t.Fatalf("refs = %d with one reader open, want 1", got)
+	}
+	second.Close()
+	if got := pooled.refs.Load(); got != 0 {
+		t.Errorf("refs = %d after every reader closed, want 0", got)
+	}
+}
+
+func TestPutBufferDropsLargeBuffers(t *testing.T) {
+	buf := getBuffer()
+	buf.Grow(maxPooledBufferSize + 1)
+	putBuffer(buf) // Must not panic; the buffer is left to the garbage collector
+}
+
+func TestSenderVoteFanoutCompressed(t *testing.T) {
+	data := []byte(`{"is_forwarded":true,"data":"` + strings.Repeat("payload ", 4096) + `"}`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ReadRequestBody(r)
+		if err != nil || r.Header.Get("Content-Encoding") != EncodingGzip || !bytes.Equal(body, data) {
+			WriteVoteResponse(w, r, &VoteResponse{Voter: r.Header.Get("X-Voter"), Code: CodeRejected, Reason: "body differs"})
+			return
+		}
+		WriteVoteResponse(w, r, &VoteResponse{Approved: true, Voter: r.Header.Get("X-Voter")})
+	}))
+	defer server.Close()
+
+	host, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	servicePort, _ := strconv.Atoi(port)
+	sender := &Sender{Transport: TransportDirect, CompressThreshold: 1024}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Targets share the request body while their compressed copies come from the pool
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			appID := "app-" + strconv.Itoa(i)
+			target := &usermgmt.DeploymentTarget{AppID: appID, ContainerIP: host, ServicePort: int32(servicePort), VotingSignPath: "/vote"}
+			for round := 0; round < 4; round++ {
+				response, err := sender.Vote(ctx, target, &VoteRequest{Data: data, Headers: map[string]string{"X-Voter": appID}})
+				if err != nil {
+					t.Errorf("%s: %v", appID, err)
+					return
+				}
+				if !response.Approved {
+					t.Errorf("%s: rejected: %s", appID, response.Reason)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func BenchmarkSetBodyCompressed(b *testing.B) {
+	body := []byte(`{"data":"` + strings.Repeat("payload ", 8192) + `"}`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		req, _ := http.NewRequest("POST", "http://app/vote", nil)
+		release, err := setBody(req, body, 1024)
+		if err != nil {
+			b.Fatal(err)
+		}
+		io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+		release()
+	}
+}
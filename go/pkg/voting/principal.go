@@ -0,0 +1,124 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package voting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode"
+
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/voting"
+	"google.golang.org/grpc/metadata"
+)
+
+// Principal headers carry the identity behind a sign request to voting peers
+const (
+	PrincipalHeader     = "X-Teenet-Principal"
+	JustificationHeader = "X-Teenet-Justification"
+)
+
+// maxPrincipalLength bounds each principal field, which travels in a header
+const maxPrincipalLength = 1024
+
+// Principal identifies who asked for a signature and why, so voters can decide on it
+// It is asserted by the requesting app: voters should trust it as far as they trust that app
+type Principal struct {
+	ID            string `json:"id"`                      // User or service identity, e.g. "alice@example.com"
+	Justification string `json:"justification,omitempty"` // Why the signature is needed, e.g. a ticket reference
+}
+
+// Validate checks that the principal can be sent in headers
+func (p *Principal) Validate() error {
+	if p.ID == "" {
+		return fmt.Errorf("principal ID is required")
+	}
+	for name, value := range map[string]string{"ID": p.ID, "justification": p.Justification} {
+		if len(value) > maxPrincipalLength {
+			return fmt.Errorf("principal %s longer than %d bytes", name, maxPrincipalLength)
+		}
+		if strings.ContainsFunc(value, unicode.IsControl) {
+			return fmt.Errorf("principal %s contains control characters", name)
+		}
+	}
+	return nil
+}
+
+// SetHeaders writes the principal into headers, replacing any principal headers already there
+func (p *Principal) SetHeaders(headers map[string]string) {
+	for name := range headers {
+		if strings.EqualFold(name, PrincipalHeader) || strings.EqualFold(name, JustificationHeader) {
+			delete(headers, name)
+		}
+	}
+	headers[PrincipalHeader] = p.ID
+	if p.Justification != "" {
+		headers[JustificationHeader] = p.Justification
+	}
+}
+
+// proto returns the principal as sent in gRPC vote requests
+func (p *Principal) proto() *pb.Principal {
+	if p == nil {
+		return nil
+	}
+	return &pb.Principal{Id: p.ID, Justification: p.Justification}
+}
+
+// PrincipalFromHeaders returns the principal forwarded in headers, nil if there is none
+func PrincipalFromHeaders(headers map[string]string) *Principal {
+	var p Principal
+	for name, value := range headers {
+		switch {
+		case strings.EqualFold(name, PrincipalHeader):
+			p.ID = value
+		case strings.EqualFold(name, JustificationHeader):
+			p.Justification = value
+		}
+	}
+	if p.ID == "" {
+		return nil
+	}
+	return &p
+}
+
+// PrincipalFromRequest returns the principal of a vote request received over HTTP, nil if none
+func PrincipalFromRequest(r *http.Request) *Principal {
+	if id := r.Header.Get(PrincipalHeader); id != "" {
+		return &Principal{ID: id, Justification: r.Header.Get(JustificationHeader)}
+	}
+	return nil
+}
+
+// PrincipalFromVotingRequest returns the principal of a vote request received by a voting
+// handler, taken from the request or else from the forwarded gRPC metadata; nil if none
+func PrincipalFromVotingRequest(ctx context.Context, req *pb.VotingRequest) *Principal {
+	if p := req.GetPrincipal(); p.GetId() != "" {
+		return &Principal{ID: p.GetId(), Justification: p.GetJustification()}
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	ids := md.Get(PrincipalHeader)
+	if len(ids) == 0 || ids[0] == "" {
+		return nil
+	}
+	p := &Principal{ID: ids[0]}
+	if justifications := md.Get(JustificationHeader); len(justifications) > 0 {
+		p.Justification = justifications[0]
+	}
+	return p
+}
@@ -0,0 +1,70 @@
+package voting
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/voting"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestPrincipalValidate(t *testing.T) {
+	tests := []struct {
+		principal Principal
+		valid     bool
+	}{
+		{Principal{ID: "alice@example.com", Justification: "TICKET-42 payout"}, true},
+		{Principal{ID: "svc:payments"}, true},
+		{Principal{Justification: "no one"}, false},
+		{Principal{ID: "alice", Justification: "line one\nline two"}, false},
+		{Principal{ID: "alice\r\nX-Injected: yes"}, false},
+		{Principal{ID: strings.Repeat("a", maxPrincipalLength+1)}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.principal.Validate(); (err == nil) != tt.valid {
+			t.Errorf("Validate(%q) = %v, want valid %t", tt.principal.ID, err, tt.valid)
+		}
+	}
+}
+
+func TestPrincipalHeaders(t *testing.T) {
+	headers := map[string]string{"x-teenet-principal": "mallory", "X-Teenet-Justification": "forged", "X-Request-Id": "1"}
+	(&Principal{ID: "alice"}).SetHeaders(headers)
+	if len(headers) != 2 || headers[PrincipalHeader] != "alice" || headers["X-Request-Id"] != "1" {
+		t.Errorf("SetHeaders should replace forwarded principal headers, got %v", headers)
+	}
+
+	got := PrincipalFromHeaders(map[string]string{"x-teenet-principal": "bob", "x-teenet-justification": "audit"})
+	if got == nil || got.ID != "bob" || got.Justification != "audit" {
+		t.Errorf("PrincipalFromHeaders = %+v", got)
+	}
+	if got := PrincipalFromHeaders(map[string]string{"X-Teenet-Justification": "anonymous"}); got != nil {
+		t.Errorf("Expected no principal without an ID, got %+v", got)
+	}
+
+	r := httptest.NewRequest("POST", "/vote", nil)
+	r.Header.Set(PrincipalHeader, "carol")
+	r.Header.Set(JustificationHeader, "release")
+	if got := PrincipalFromRequest(r); got == nil || got.ID != "carol" || got.Justification != "release" {
+		t.Errorf("PrincipalFromRequest = %+v", got)
+	}
+}
+
+func TestPrincipalFromVotingRequest(t *testing.T) {
+	req := &pb.VotingRequest{Principal: &pb.Principal{Id: "alice", Justification: "payout"}}
+	if got := PrincipalFromVotingRequest(context.Background(), req); got == nil || got.ID != "alice" || got.Justification != "payout" {
+		t.Errorf("From request field = %+v", got)
+	}
+
+	// Peers that don't set the field still forward the headers as metadata
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-teenet-principal", "bob", "x-teenet-justification", "rotation"))
+	if got := PrincipalFromVotingRequest(ctx, &pb.VotingRequest{}); got == nil || got.ID != "bob" || got.Justification != "rotation" {
+		t.Errorf("From metadata = %+v", got)
+	}
+
+	if got := PrincipalFromVotingRequest(context.Background(), &pb.VotingRequest{}); got != nil {
+		t.Errorf("Expected no principal, got %+v", got)
+	}
+}
@@ -0,0 +1,172 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package voting
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Vote response schema versions
+const (
+	// VoteResponseLegacy is the unversioned schema: any JSON object with an "approved" bool
+	VoteResponseLegacy = 0
+	// VoteResponseV1 adds the voter's identity, a reason and a rejection code, and is validated strictly:
+	// {"version": 1, "approved": bool, "voter": app ID, "reason": string?, "code": string?, "delegation": {...}?}
+	VoteResponseV1 = 1
+	// VoteResponseVersion is the newest schema this package speaks
+	VoteResponseVersion = VoteResponseV1
+)
+
+// VoteVersionHeader carries the newest vote response version the requester accepts
+// Voting handlers answer with that version or older, see WriteVoteResponse
+const VoteVersionHeader = "X-Teenet-Vote-Version"
+
+// MaxVoteReasonLength bounds the reason of a vote response
+const MaxVoteReasonLength = 1024
+
+// ErrInvalidVoteResponse is matched by errors.Is for vote responses that don't follow their schema
+var ErrInvalidVoteResponse = errors.New("invalid vote response")
+
+// VoteResponse is a target app's answer to a vote request
+type VoteResponse struct {
+	Version    int           `json:"version,omitempty"` // Schema version, VoteResponseLegacy if absent
+	Approved   bool          `json:"approved"`
+	Voter      string        `json:"voter,omitempty"`      // App ID of the voter, from version 1
+	Reason     string        `json:"reason,omitempty"`     // Why the voter decided so, from version 1
+	Code       RejectionCode `json:"code,omitempty"`       // Why the vote is not an approval, from version 1
+	Delegation *Delegation   `json:"delegation,omitempty"` // Set when the target delegated its vote
+
+	// MuSig2 is the hex public nonce or partial signature answering a MuSig2Request
+	MuSig2 string `json:"musig2,omitempty"`
+}
+
+// ParseVoteResponse validates a vote response from voter against its declared schema version
+// Responses older than minVersion are refused, so peers can be required to speak the new schema
+func ParseVoteResponse(body []byte, voter string, minVersion int) (*VoteResponse, error) {
+	var header struct {
+		Version *int `json:"version"`
+	}
+	if err := json.Unmarshal(body, &header); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidVoteResponse, err)
+	}
+	version := VoteResponseLegacy
+	if header.Version != nil {
+		version = *header.Version
+	}
+	if version < minVersion {
+		return nil, fmt.Errorf("%w: version %d from %s, at least %d required", ErrInvalidVoteResponse, version, voter, minVersion)
+	}
+
+	switch {
+	case header.Version == nil:
+		return parseLegacyVoteResponse(body)
+	case version == VoteResponseV1:
+		return parseV1VoteResponse(body, voter)
+	default:
+		return nil, fmt.Errorf("%w: unsupported version %d from %s", ErrInvalidVoteResponse, version, voter)
+	}
+}
+
+// parseLegacyVoteResponse accepts any object with an approved bool and an optional delegation
+func parseLegacyVoteResponse(body []byte) (*VoteResponse, error) {
+	var response struct {
+		Approved   *bool       `json:"approved"`
+		Delegation *Delegation `json:"delegation"`
+		MuSig2     string      `json:"musig2"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidVoteResponse, err)
+	}
+	if response.Approved == nil {
+		return nil, fmt.Errorf("%w: missing approved field", ErrInvalidVoteResponse)
+	}
+	return &VoteResponse{Approved: *response.Approved, Delegation: response.Delegation, MuSig2: response.MuSig2}, nil
+}
+
+// parseV1VoteResponse validates a version 1 response: required fields present, no unknown fields,
+// and the voter is the app that was asked
+func parseV1VoteResponse(body []byte, voter string) (*VoteResponse, error) {
+	var response struct {
+		Version    int           `json:"version"`
+		Approved   *bool         `json:"approved"`
+		Voter      *string       `json:"voter"`
+		Reason     string        `json:"reason"`
+		Code       RejectionCode `json:"code"`
+		Delegation *Delegation   `json:"delegation"`
+		MuSig2     string        `json:"musig2"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&response); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidVoteResponse, err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("%w: trailing data after response", ErrInvalidVoteResponse)
+	}
+
+	switch {
+	case response.Approved == nil:
+		return nil, fmt.Errorf("%w: missing approved field", ErrInvalidVoteResponse)
+	case response.Voter == nil || *response.Voter == "":
+		return nil, fmt.Errorf("%w: missing voter field", ErrInvalidVoteResponse)
+	case *response.Voter != voter:
+		return nil, fmt.Errorf("%w: answered by %s, asked %s", ErrInvalidVoteResponse, *response.Voter, voter)
+	case len(response.Reason) > MaxVoteReasonLength:
+		return nil, fmt.Errorf("%w: reason longer than %d bytes", ErrInvalidVoteResponse, MaxVoteReasonLength)
+	case len(response.Code) > MaxRejectionCodeLength:
+		return nil, fmt.Errorf("%w: code longer than %d bytes", ErrInvalidVoteResponse, MaxRejectionCodeLength)
+	case *response.Approved && response.Code != "":
+		return nil, fmt.Errorf("%w: rejection code %q on an approval", ErrInvalidVoteResponse, response.Code)
+	}
+	if d := response.Delegation; d != nil && (d.DelegateAppID == "" || d.ExpiresAt == 0 || d.Signature == "") {
+		return nil, fmt.Errorf("%w: incomplete delegation", ErrInvalidVoteResponse)
+	}
+
+	return &VoteResponse{
+		Version:    VoteResponseV1,
+		Approved:   *response.Approved,
+		Voter:      *response.Voter,
+		Reason:     response.Reason,
+		Code:       response.Code,
+		Delegation: response.Delegation,
+		MuSig2:     response.MuSig2,
+	}, nil
+}
+
+// RequestedVoteVersion returns the vote response version a vote request asks for: the newest
+// version both sides speak, or VoteResponseLegacy for requesters that predate versioning
+func RequestedVoteVersion(req *http.Request) int {
+	version, err := strconv.Atoi(req.Header.Get(VoteVersionHeader))
+	if err != nil || version < VoteResponseLegacy {
+		return VoteResponseLegacy
+	}
+	return min(version, VoteResponseVersion)
+}
+
+// WriteVoteResponse answers a vote request in the schema version it asked for
+// Fields the negotiated version doesn't have are left out, so old requesters keep working
+func WriteVoteResponse(w http.ResponseWriter, req *http.Request, response *VoteResponse) error {
+	out := *response
+	out.Version = RequestedVoteVersion(req)
+	if out.Version == VoteResponseLegacy {
+		out.Voter, out.Reason, out.Code = "", "", ""
+	}
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(&out)
+}
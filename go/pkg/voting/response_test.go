@@ -0,0 +1,92 @@
+package voting
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseVoteResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		minVersion int
+		want       *VoteResponse
+		wantErr    bool
+	}{
+		{name: "legacy", body: `{"approved":true,"extra":1}`, want: &VoteResponse{Approved: true}},
+		{name: "legacy missing approved", body: `{"success":true}`, wantErr: true},
+		{name: "legacy refused", body: `{"approved":true}`, minVersion: VoteResponseV1, wantErr: true},
+		{name: "v1", body: `{"version":1,"approved":false,"voter":"app-b","reason":"amount too high"}`, minVersion: VoteResponseV1,
+			want: &VoteResponse{Version: 1, Approved: false, Voter: "app-b", Reason: "amount too high"}},
+		{name: "v1 unknown field", body: `{"version":1,"approved":true,"voter":"app-b","extra":1}`, wantErr: true},
+		{name: "v1 missing voter", body: `{"version":1,"approved":true}`, wantErr: true},
+		{name: "v1 wrong voter", body: `{"version":1,"approved":true,"voter":"app-c"}`, wantErr: true},
+		{name: "v1 approved not bool", body: `{"version":1,"approved":"yes","voter":"app-b"}`, wantErr: true},
+		{name: "v1 incomplete delegation", body: `{"version":1,"approved":false,"voter":"app-b","delegation":{"delegate_app_id":"app-d"}}`, wantErr: true},
+		{name: "v1 long reason", body: `{"version":1,"approved":true,"voter":"app-b","reason":"` + strings.Repeat("x", MaxVoteReasonLength+1) + `"}`, wantErr: true},
+		{name: "v1 rejection code", body: `{"version":1,"approved":false,"voter":"app-b","code":"policy_violation"}`,
+			want: &VoteResponse{Version: 1, Voter: "app-b", Code: CodePolicyViolation}},
+		{name: "v1 code on approval", body: `{"version":1,"approved":true,"voter":"app-b","code":"rejected"}`, wantErr: true},
+		{name: "future version", body: `{"version":2,"approved":true,"voter":"app-b"}`, wantErr: true},
+		{name: "not JSON", body: `approved`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseVoteResponse([]byte(tt.body), "app-b", tt.minVersion)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidVoteResponse) {
+					t.Fatalf("Expected ErrInvalidVoteResponse, got %v (%+v)", err, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseVoteResponse failed: %v", err)
+			}
+			if *got != *tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteVoteResponseNegotiation(t *testing.T) {
+	response := &VoteResponse{Approved: true, Voter: "app-b", Reason: "looks fine"}
+
+	for _, tt := range []struct {
+		header  string
+		version int
+	}{
+		{"", VoteResponseLegacy},
+		{"1", VoteResponseV1},
+		{"7", VoteResponseVersion},
+		{"junk", VoteResponseLegacy},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/vote", nil)
+		if tt.header != "" {
+			req.Header.Set(VoteVersionHeader, tt.header)
+		}
+		recorder := httptest.NewRecorder()
+		if err := WriteVoteResponse(recorder, req, response); err != nil {
+			t.Fatalf("WriteVoteResponse failed: %v", err)
+		}
+
+		var fields map[string]any
+		json.Unmarshal(recorder.Body.Bytes(), &fields)
+		if tt.version == VoteResponseLegacy {
+			if _, ok := fields["version"]; ok || fields["voter"] != nil {
+				t.Errorf("header %q: legacy response has versioned fields: %v", tt.header, fields)
+			}
+		} else if fields["version"] != float64(tt.version) {
+			t.Errorf("header %q: version = %v, want %d", tt.header, fields["version"], tt.version)
+		}
+
+		// Whatever was negotiated parses on the requesting side
+		if _, err := ParseVoteResponse(recorder.Body.Bytes(), "app-b", tt.version); err != nil {
+			t.Errorf("header %q: response does not parse: %v", tt.header, err)
+		}
+	}
+}
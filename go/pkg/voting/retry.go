@@ -0,0 +1,113 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package voting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Default vote request retry backoff, see RetryPolicy
+const (
+	DefaultRetryMinBackoff = 100 * time.Millisecond
+	DefaultRetryMaxBackoff = 2 * time.Second
+)
+
+// RetryPolicy retries vote requests that failed on the way to a target, with exponential
+// backoff and jitter. Rejections are answers and are never retried
+type RetryPolicy struct {
+	Attempts   int           // Attempts per request including the first; 0 or 1 never retries
+	MinBackoff time.Duration // Delay before the first retry, doubled after each; default 100ms
+	MaxBackoff time.Duration // Longest delay between attempts; default 2s
+}
+
+// backoff returns the delay before retry n (1 for the first retry): between half and all of the
+// exponential delay, so targets that failed together don't retry in lockstep
+func (p RetryPolicy) backoff(n int) time.Duration {
+	minBackoff, maxBackoff := p.MinBackoff, p.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = DefaultRetryMinBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryMaxBackoff
+	}
+	delay := minBackoff
+	for i := 1; i < n && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxBackoff)
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// StatusError is an HTTP vote request answered with a status other than 200 OK
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("HTTP vote request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// Retryable reports whether a failed vote request may succeed when sent again: network errors,
+// HTTP 429, 502, 503 and 504, and unavailable or overloaded gRPC services. Invalid responses,
+// refused payloads and the request's own deadline or cancellation are definitive
+func Retryable(err error) bool {
+	switch {
+	case err == nil,
+		errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, ErrInvalidVoteResponse),
+		errors.Is(err, ErrInvalidDelegation),
+		errors.Is(err, ErrPayloadTooLarge):
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		default:
+			return false
+		}
+	}
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+			return true
+		default:
+			return false
+		}
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retryFor returns the retry policy for a target app
+func (s *Sender) retryFor(appID string) RetryPolicy {
+	if policy, ok := s.TargetRetries[appID]; ok {
+		return policy
+	}
+	return s.Retry
+}
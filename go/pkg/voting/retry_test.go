@@ -0,0 +1,91 @@
+package voting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&StatusError{StatusCode: http.StatusServiceUnavailable}, true},
+		{&StatusError{StatusCode: http.StatusTooManyRequests}, true},
+		{&StatusError{StatusCode: http.StatusBadRequest}, false},
+		{&StatusError{StatusCode: http.StatusInternalServerError}, false},
+		{fmt.Errorf("gRPC vote request failed: %w", status.Error(codes.Unavailable, "down")), true},
+		{fmt.Errorf("gRPC vote request failed: %w", status.Error(codes.PermissionDenied, "no")), false},
+		{fmt.Errorf("HTTP vote request failed: %w", context.DeadlineExceeded), false},
+		{fmt.Errorf("%w: bad json", ErrInvalidVoteResponse), false},
+		{&PayloadTooLargeError{Payload: "vote request", Limit: 1}, false},
+		{errors.New("something else"), false},
+	}
+	for _, tt := range tests {
+		if got := Retryable(tt.err); got != tt.want {
+			t.Errorf("Retryable(%v) = %t, want %t", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: 400 * time.Millisecond}
+	for n, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond, 10: 400 * time.Millisecond} {
+		for range 20 {
+			if got := policy.backoff(n); got < want/2 || got > want {
+				t.Fatalf("backoff(%d) = %s, want within [%s, %s]", n, got, want/2, want)
+			}
+		}
+	}
+}
+
+func TestVoteRetries(t *testing.T) {
+	var calls atomic.Int32
+	failures := int32(2)
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			http.Error(w, "busy", status)
+			return
+		}
+		WriteVoteResponse(w, r, &VoteResponse{Approved: true, Voter: "app"})
+	}))
+	defer server.Close()
+
+	host, port, _ := strings.Cut(strings.TrimPrefix(server.URL, "http://"), ":")
+	var servicePort int32
+	fmt.Sscan(port, &servicePort)
+	target := &usermgmt.DeploymentTarget{AppID: "app", ContainerIP: host, ServicePort: servicePort, VotingSignPath: "/vote"}
+	sender := &Sender{Transport: TransportDirect, Retry: RetryPolicy{Attempts: 3, MinBackoff: time.Millisecond}}
+
+	response, err := sender.Vote(context.Background(), target, &VoteRequest{Data: []byte(`{}`)})
+	if err != nil || !response.Approved || calls.Load() != 3 {
+		t.Fatalf("after transient failures: %v, %d calls", err, calls.Load())
+	}
+
+	// A definitive failure is not retried
+	calls.Store(0)
+	status = http.StatusBadRequest
+	if _, err := sender.Vote(context.Background(), target, &VoteRequest{Data: []byte(`{}`)}); err == nil || calls.Load() != 1 {
+		t.Fatalf("after bad request: %v, %d calls", err, calls.Load())
+	}
+
+	// Attempts run out
+	calls.Store(0)
+	status, failures = http.StatusServiceUnavailable, 5
+	var statusErr *StatusError
+	if _, err := sender.Vote(context.Background(), target, &VoteRequest{Data: []byte(`{}`)}); !errors.As(err, &statusErr) || calls.Load() != 3 {
+		t.Fatalf("after exhausted attempts: %v, %d calls", err, calls.Load())
+	}
+}
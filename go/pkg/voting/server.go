@@ -20,6 +20,7 @@ import (
 	"log"
 	"net"
 
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
 	pb "github.com/TEENet-io/teenet-sdk/go/proto/voting"
 	"google.golang.org/grpc"
 )
@@ -58,25 +59,31 @@ func (vs *Server) Voting(ctx context.Context, req *pb.VotingRequest) (*pb.Voting
 
 // StartVotingService starts the gRPC voting service to receive voting requests from other clients
 func StartVotingService(votingHandler func(context.Context, *pb.VotingRequest) (*pb.VotingResponse, error), existingServer **grpc.Server) error {
+	return StartVotingServiceWithOptions(constants.DefaultVotingAddr, votingHandler, existingServer)
+}
+
+// StartVotingServiceWithOptions starts the gRPC voting service on addr with additional server options
+func StartVotingServiceWithOptions(addr string, votingHandler func(context.Context, *pb.VotingRequest) (*pb.VotingResponse, error), existingServer **grpc.Server, opts ...grpc.ServerOption) error {
 	// Stop existing voting service if running
 	if *existingServer != nil {
 		(*existingServer).GracefulStop()
 		*existingServer = nil
 	}
 
-	lis, err := net.Listen("tcp", ":50051")
+	lis, err := net.Listen("tcp", addr)
 	if err != nil {
-		return fmt.Errorf("failed to listen on port 50051: %w", err)
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
 	}
 
-	*existingServer = grpc.NewServer()
-	votingServer := NewServer(votingHandler)
-	pb.RegisterVotingServiceServer(*existingServer, votingServer)
+	// Serve the local server rather than *existingServer, which the caller may clear
+	grpcServer := grpc.NewServer(opts...)
+	pb.RegisterVotingServiceServer(grpcServer, NewServer(votingHandler))
+	*existingServer = grpcServer
 
-	log.Printf("🗳️  Voting service started on port 50051")
+	log.Printf("🗳️  Voting service started on %s", addr)
 
 	go func() {
-		if err := (*existingServer).Serve(lis); err != nil {
+		if err := grpcServer.Serve(lis); err != nil {
 			log.Printf("❌ Voting service error: %v", err)
 		}
 	}()
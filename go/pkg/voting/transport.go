@@ -0,0 +1,145 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package voting
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+	"google.golang.org/grpc"
+)
+
+// Transport selects how vote requests and round notifications reach a target app
+type Transport string
+
+// Vote transports
+const (
+	// TransportProxy goes through the deployment-client HTTP proxy on the target's host (default)
+	TransportProxy Transport = "proxy"
+	// TransportDirect calls the target container's service port directly, for flat networks
+	// where every container is reachable from the others
+	TransportDirect Transport = "direct"
+	// TransportGRPC calls the VotingService of the target's deployment-client, which forwards
+	// the request to the container's voting service
+	TransportGRPC Transport = "grpc"
+	// TransportREST posts VotingService requests as protobuf JSON to the REST facade on the
+	// target container's service port (see NewGateway), for peers without gRPC
+	TransportREST Transport = "rest"
+)
+
+// String returns the transport name; the zero value is the proxy
+func (t Transport) String() string {
+	if t == "" {
+		return string(TransportProxy)
+	}
+	return string(t)
+}
+
+// UnmarshalText accepts "proxy", "direct", "grpc" or "rest", so transports can be read from config files
+func (t *Transport) UnmarshalText(text []byte) error {
+	switch transport := Transport(strings.ToLower(string(text))); transport {
+	case TransportProxy, TransportDirect, TransportGRPC, TransportREST:
+		*t = transport
+		return nil
+	default:
+		return fmt.Errorf("unknown vote transport %q (want %q, %q, %q or %q)", text, TransportProxy, TransportDirect, TransportGRPC, TransportREST)
+	}
+}
+
+// Endpoint returns where a target app is reached over this transport: the URL of its voting
+// sign path, the deployment-client gRPC address for TransportGRPC, or the URL of the REST
+// facade for TransportREST
+func (t Transport) Endpoint(target *usermgmt.DeploymentTarget) string {
+	switch t {
+	case TransportDirect:
+		return directEndpoint(target)
+	case TransportREST:
+		return gatewayEndpoint(target)
+	case TransportGRPC:
+		return target.DeploymentClientAddress
+	default:
+		return proxyEndpoint(target)
+	}
+}
+
+// directEndpoint returns the URL of a target app's voting sign path on its container
+// Format: http://{container_ip}:{port}{voting_sign_path}
+func directEndpoint(target *usermgmt.DeploymentTarget) string {
+	votingSignPath := target.VotingSignPath
+	if !strings.HasPrefix(votingSignPath, "/") {
+		votingSignPath = "/" + votingSignPath
+	}
+	port := target.ServicePort
+	if port <= 0 {
+		port = 8080 // Same default as the proxy
+	}
+	return fmt.Sprintf("http://%s:%d%s", target.ContainerIP, port, votingSignPath)
+}
+
+// Sender sends vote requests and round notifications to target apps
+// The zero value sends over HTTP through the deployment-client proxy
+type Sender struct {
+	Transport        Transport            // Default transport
+	TargetTransports map[string]Transport // Per-target overrides, by app ID
+
+	// TLSConfig secures TransportGRPC connections to deployment-clients; nil dials in plaintext
+	TLSConfig   *tls.Config
+	DialOptions []grpc.DialOption // Extra options for TransportGRPC connections
+
+	// MinResponseVersion refuses HTTP vote responses older than this schema version
+	// The default accepts every version, including unversioned responses of older peers
+	MinResponseVersion int
+
+	// CompressThreshold gzips HTTP request bodies larger than this many bytes, sent with
+	// "Content-Encoding: gzip"; 0 sends every body as is. gRPC has its own compression
+	CompressThreshold int
+
+	// Retry retries vote requests that failed on the way to a target; the zero value sends once
+	Retry         RetryPolicy
+	TargetRetries map[string]RetryPolicy // Per-target overrides, by app ID
+}
+
+// VoteRequest is what a target app is asked to vote on
+type VoteRequest struct {
+	SignerAppID       string            // App whose voting round this is
+	Message           []byte            // Message to be signed
+	Data              []byte            // Request body forwarded to the target's voting handler
+	Headers           map[string]string // Headers forwarded with the request
+	Principal         *Principal        // Who asked for the signature; also sent in the headers
+	RequiredVotes     int
+	TotalParticipants int
+}
+
+// UsesTransport reports whether any target is reached over transport
+func (s *Sender) UsesTransport(transport Transport) bool {
+	if s.Transport == transport {
+		return true
+	}
+	for _, t := range s.TargetTransports {
+		if t == transport {
+			return true
+		}
+	}
+	return false
+}
+
+// transportFor returns the transport used for a target app
+func (s *Sender) transportFor(appID string) Transport {
+	if transport, ok := s.TargetTransports[appID]; ok {
+		return transport
+	}
+	return s.Transport
+}
@@ -0,0 +1,120 @@
+package voting
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/voting"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTransportEndpoint(t *testing.T) {
+	target := &usermgmt.DeploymentTarget{
+		AppID:                   "app",
+		ContainerIP:             "10.0.0.5",
+		DeploymentClientAddress: "192.168.1.2:50053",
+		VotingSignPath:          "vote",
+		ServicePort:             9000,
+	}
+
+	tests := []struct {
+		transport Transport
+		want      string
+	}{
+		{"", "http://192.168.1.2:8090/proxy/app:9000/vote"},
+		{TransportProxy, "http://192.168.1.2:8090/proxy/app:9000/vote"},
+		{TransportDirect, "http://10.0.0.5:9000/vote"},
+		{TransportREST, "http://10.0.0.5:9000/v1/voting"},
+	}
+	for _, tt := range tests {
+		if got := tt.transport.Endpoint(target); got != tt.want {
+			t.Errorf("%s endpoint = %s, want %s", tt.transport, got, tt.want)
+		}
+	}
+
+	target.ServicePort = 0
+	if got := TransportDirect.Endpoint(target); got != "http://10.0.0.5:8080/vote" {
+		t.Errorf("direct endpoint without port = %s", got)
+	}
+}
+
+func TestTransportUnmarshal(t *testing.T) {
+	var config struct {
+		Transport Transport `json:"transport"`
+	}
+	if err := json.Unmarshal([]byte(`{"transport":"Direct"}`), &config); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if config.Transport != TransportDirect {
+		t.Errorf("transport = %s, want direct", config.Transport)
+	}
+	if err := json.Unmarshal([]byte(`{"transport":"carrier-pigeon"}`), &config); err == nil {
+		t.Error("Expected error for unknown transport")
+	}
+}
+
+func TestSenderGRPCVote(t *testing.T) {
+	received := make(chan *pb.VotingRequest, 2)
+	var receivedAuth []string
+	server := grpc.NewServer()
+	pb.RegisterVotingServiceServer(server, NewServer(func(ctx context.Context, req *pb.VotingRequest) (*pb.VotingResponse, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		receivedAuth = md.Get("authorization")
+		received <- req
+		return &pb.VotingResponse{Success: string(req.Message) == "approve me", TaskId: req.TaskId}, nil
+	}))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	go server.Serve(listener)
+	defer server.Stop()
+
+	target := &usermgmt.DeploymentTarget{AppID: "app-b", ContainerIP: "10.0.0.5", DeploymentClientAddress: listener.Addr().String()}
+	sender := &Sender{TargetTransports: map[string]Transport{"app-b": TransportGRPC}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	response, err := sender.Vote(ctx, target, &VoteRequest{
+		SignerAppID:       "app-a",
+		Message:           []byte("approve me"),
+		Data:              []byte(`{"is_forwarded":true}`),
+		Headers:           map[string]string{"Authorization": "Bearer token", "Content-Length": "21"},
+		Principal:         &Principal{ID: "alice", Justification: "payout"},
+		RequiredVotes:     2,
+		TotalParticipants: 3,
+	})
+	if err != nil {
+		t.Fatalf("Vote failed: %v", err)
+	}
+	if !response.Approved {
+		t.Error("Expected approval")
+	}
+
+	req := <-received
+	if req.AppId != "app-b" || req.TargetContainerIp != "10.0.0.5" || req.SignerAppId != "app-a" || req.TaskId == "" {
+		t.Errorf("Unexpected routing fields: %+v", req)
+	}
+	if req.RequiredVotes != 2 || req.TotalParticipants != 3 || string(req.RequestData) != `{"is_forwarded":true}` {
+		t.Errorf("Unexpected request: %+v", req)
+	}
+	if p := PrincipalFromVotingRequest(context.Background(), req); p == nil || p.ID != "alice" || p.Justification != "payout" {
+		t.Errorf("Principal = %+v", p)
+	}
+	if len(receivedAuth) != 1 || receivedAuth[0] != "Bearer token" {
+		t.Errorf("Authorization metadata = %v", receivedAuth)
+	}
+
+	// Notifications travel as request data
+	if err := sender.Commit(ctx, target, &Commit{Phase: PhaseCommit, SignerAppID: "app-a"}, nil); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if _, ok := ParseCommit((<-received).RequestData); !ok {
+		t.Error("Expected commit notification in request data")
+	}
+}
@@ -0,0 +1,90 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package votingtest
+
+import (
+	"context"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+)
+
+// Vote is the outcome of asking one peer
+type Vote struct {
+	AppID    string
+	Approved bool
+	Reason   string
+	Code     voting.RejectionCode // Set for every vote that isn't an approval
+	Err      error                // Set when no valid vote arrived
+	Latency  time.Duration
+}
+
+// Outcome is the result of a voting round over the network
+type Outcome struct {
+	Approved      bool // At least RequiredVotes approvals were received
+	Approvals     int
+	RequiredVotes int
+	Votes         []Vote // In the order they arrived
+}
+
+// Vote returns the vote of an app ID, or nil if it wasn't asked
+func (o *Outcome) Vote(appID string) *Vote {
+	for i := range o.Votes {
+		if o.Votes[i].AppID == appID {
+			return &o.Votes[i]
+		}
+	}
+	return nil
+}
+
+// Round asks every peer for its vote concurrently and waits for all of them, the way a client's
+// voting round does; ctx bounds the round, so peers that answer too late count as timeouts
+func (n *Network) Round(ctx context.Context, request *voting.VoteRequest, requiredVotes int) *Outcome {
+	return n.RoundWith(ctx, n.Sender(), request, requiredVotes)
+}
+
+// RoundWith is Round sending the requests through sender
+func (n *Network) RoundWith(ctx context.Context, sender *voting.Sender, request *voting.VoteRequest, requiredVotes int) *Outcome {
+	votes := make(chan Vote, len(n.order))
+	start := time.Now()
+	for _, appID := range n.order {
+		go func(peer *Peer) {
+			response, err := sender.Vote(ctx, peer.Target(), request)
+			vote := Vote{AppID: peer.AppID, Err: err, Latency: time.Since(start)}
+			switch {
+			case err != nil:
+				vote.Code = voting.ErrorCode(err)
+			case response.Approved:
+				vote.Approved = true
+			default:
+				vote.Reason, vote.Code = response.Reason, response.Code
+				if vote.Code == "" {
+					vote.Code = voting.CodeRejected
+				}
+			}
+			votes <- vote
+		}(n.peers[appID])
+	}
+
+	outcome := &Outcome{RequiredVotes: requiredVotes}
+	for range n.order {
+		vote := <-votes
+		if vote.Approved {
+			outcome.Approvals++
+		}
+		outcome.Votes = append(outcome.Votes, vote)
+	}
+	outcome.Approved = requiredVotes > 0 && outcome.Approvals >= requiredVotes
+	return outcome
+}
@@ -0,0 +1,255 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+// Package votingtest runs in-process voting peers with scripted behaviors, so quorum logic and
+// timeouts can be tested without the deployment infrastructure
+//
+// Peers answer vote requests over HTTP on the loopback interface; Network.Targets and
+// Network.Sender reach them with the direct vote transport
+package votingtest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+)
+
+// VotingSignPath is the path peers serve vote requests on
+const VotingSignPath = "/vote"
+
+// Request is a vote request received by a peer
+type Request struct {
+	Body   []byte
+	Header http.Header
+}
+
+// Behavior decides how a peer answers a vote request. It returns nil to drop the request
+// without an answer, or an error to fail it with an HTTP error status
+type Behavior func(ctx context.Context, request *Request) (*voting.VoteResponse, error)
+
+// Approve votes for every request
+func Approve() Behavior {
+	return func(context.Context, *Request) (*voting.VoteResponse, error) {
+		return &voting.VoteResponse{Approved: true}, nil
+	}
+}
+
+// Reject votes against every request with a rejection code and reason
+func Reject(code voting.RejectionCode, reason string) Behavior {
+	return func(context.Context, *Request) (*voting.VoteResponse, error) {
+		return &voting.VoteResponse{Code: code, Reason: reason}, nil
+	}
+}
+
+// Delay waits before answering as behavior does; the wait ends early if the requester gives up
+func Delay(d time.Duration, behavior Behavior) Behavior {
+	return func(ctx context.Context, request *Request) (*voting.VoteResponse, error) {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			return behavior(ctx, request)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Drop never answers; the requester sees a timeout once its deadline passes
+func Drop() Behavior {
+	return func(context.Context, *Request) (*voting.VoteResponse, error) {
+		return nil, nil
+	}
+}
+
+// Fail answers every request with an HTTP 500, like a broken voting handler
+func Fail(reason string) Behavior {
+	return func(context.Context, *Request) (*voting.VoteResponse, error) {
+		return nil, fmt.Errorf("%s", reason)
+	}
+}
+
+// Sequence answers the n-th request as the n-th behavior, and all later requests as the last one
+func Sequence(behaviors ...Behavior) Behavior {
+	var mu sync.Mutex
+	next := 0
+	return func(ctx context.Context, request *Request) (*voting.VoteResponse, error) {
+		if len(behaviors) == 0 {
+			return nil, nil
+		}
+		mu.Lock()
+		behavior := behaviors[min(next, len(behaviors)-1)]
+		next++
+		mu.Unlock()
+		return behavior(ctx, request)
+	}
+}
+
+// Peer is an in-process voting target
+type Peer struct {
+	AppID string
+
+	server  *httptest.Server
+	closing <-chan struct{}
+
+	mu       sync.Mutex
+	behavior Behavior
+	requests []*Request
+}
+
+// SetBehavior changes how the peer answers later requests
+func (p *Peer) SetBehavior(behavior Behavior) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.behavior = behavior
+}
+
+// Requests returns the vote requests the peer received, in order
+func (p *Peer) Requests() []*Request {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*Request(nil), p.requests...)
+}
+
+// Target returns the deployment target that reaches the peer over the direct transport
+func (p *Peer) Target() *usermgmt.DeploymentTarget {
+	host, port, _ := net.SplitHostPort(p.server.Listener.Addr().String())
+	servicePort, _ := strconv.Atoi(port)
+	return &usermgmt.DeploymentTarget{
+		AppID:          p.AppID,
+		ContainerIP:    host,
+		VotingSignPath: VotingSignPath,
+		HTTPBaseURL:    p.server.URL,
+		ServicePort:    int32(servicePort),
+	}
+}
+
+func (p *Peer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := voting.ReadRequestBody(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	request := &Request{Body: body, Header: r.Header.Clone()}
+
+	p.mu.Lock()
+	p.requests = append(p.requests, request)
+	behavior := p.behavior
+	p.mu.Unlock()
+
+	// Behaviors stop waiting when the requester gives up or the network closes
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-p.closing:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	response, err := behavior(ctx, request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if response == nil {
+		<-ctx.Done()
+		panic(http.ErrAbortHandler)
+	}
+	if response.Voter == "" {
+		response.Voter = p.AppID
+	}
+	voting.WriteVoteResponse(w, r, response)
+}
+
+// Network is a set of in-process voting peers
+type Network struct {
+	peers   map[string]*Peer
+	order   []string
+	closing chan struct{}
+	once    sync.Once
+}
+
+// NewNetwork starts a peer for each app ID; peers approve every request until told otherwise
+func NewNetwork(appIDs ...string) *Network {
+	n := &Network{
+		peers:   make(map[string]*Peer, len(appIDs)),
+		closing: make(chan struct{}),
+	}
+	for _, appID := range appIDs {
+		if _, exists := n.peers[appID]; exists {
+			continue
+		}
+		peer := &Peer{AppID: appID, closing: n.closing, behavior: Approve()}
+		mux := http.NewServeMux()
+		mux.Handle("POST "+VotingSignPath, peer)
+		peer.server = httptest.NewServer(mux)
+		n.peers[appID] = peer
+		n.order = append(n.order, appID)
+	}
+	return n
+}
+
+// Peer returns the peer of an app ID, or nil if the network has none
+func (n *Network) Peer(appID string) *Peer {
+	return n.peers[appID]
+}
+
+// SetBehavior changes how a peer answers later requests
+func (n *Network) SetBehavior(appID string, behavior Behavior) {
+	if peer := n.peers[appID]; peer != nil {
+		peer.SetBehavior(behavior)
+	}
+}
+
+// Targets returns the deployment targets of every peer, by app ID
+func (n *Network) Targets() map[string]*usermgmt.DeploymentTarget {
+	targets := make(map[string]*usermgmt.DeploymentTarget, len(n.peers))
+	for appID, peer := range n.peers {
+		targets[appID] = peer.Target()
+	}
+	return targets
+}
+
+// VotingSignConfig returns a voting configuration over every peer
+func (n *Network) VotingSignConfig(requiredVotes int) *usermgmt.VotingSignConfig {
+	return &usermgmt.VotingSignConfig{
+		Targets:        n.Targets(),
+		VotingSignPath: VotingSignPath,
+		RequiredVotes:  int32(requiredVotes),
+	}
+}
+
+// Sender returns a vote sender that reaches the peers
+func (n *Network) Sender() *voting.Sender {
+	return &voting.Sender{Transport: voting.TransportDirect}
+}
+
+// Close stops every peer; requests still waiting on a peer are dropped
+func (n *Network) Close() {
+	n.once.Do(func() {
+		close(n.closing)
+		for _, appID := range n.order {
+			n.peers[appID].server.Close()
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package votingtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+)
+
+func TestRoundBehaviors(t *testing.T) {
+	network := NewNetwork("approver", "rejecter", "slow", "dropper", "broken")
+	defer network.Close()
+	network.SetBehavior("rejecter", Reject(voting.CodePolicyViolation, "amount too high"))
+	network.SetBehavior("slow", Delay(time.Hour, Approve()))
+	network.SetBehavior("dropper", Drop())
+	network.SetBehavior("broken", Fail("handler crashed"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	outcome := network.Round(ctx, &voting.VoteRequest{Data: []byte(`{"amount":100}`)}, 2)
+
+	if outcome.Approved || outcome.Approvals != 1 {
+		t.Fatalf("outcome approved=%t approvals=%d, want rejected with 1 approval", outcome.Approved, outcome.Approvals)
+	}
+	if len(outcome.Votes) != 5 {
+		t.Fatalf("got %d votes, want 5", len(outcome.Votes))
+	}
+	tests := []struct {
+		appID     string
+		approved  bool
+		code      voting.RejectionCode
+		peerFault bool
+	}{
+		{"approver", true, "", false},
+		{"rejecter", false, voting.CodePolicyViolation, false},
+		{"slow", false, voting.CodeTimeout, true},
+		{"dropper", false, voting.CodeTimeout, true},
+		{"broken", false, voting.CodeUnreachable, true},
+	}
+	for _, tt := range tests {
+		vote := outcome.Vote(tt.appID)
+		if vote == nil {
+			t.Fatalf("no vote from %s", tt.appID)
+		}
+		if vote.Approved != tt.approved || vote.Code != tt.code || vote.Code.PeerFault() != tt.peerFault {
+			t.Errorf("%s: approved=%t code=%q err=%v, want approved=%t code=%q", tt.appID, vote.Approved, vote.Code, vote.Err, tt.approved, tt.code)
+		}
+	}
+	if reason := outcome.Vote("rejecter").Reason; reason != "amount too high" {
+		t.Errorf("rejecter reason = %q", reason)
+	}
+	if requests := network.Peer("approver").Requests(); len(requests) != 1 || string(requests[0].Body) != `{"amount":100}` {
+		t.Errorf("approver received %v", requests)
+	}
+}
+
+func TestSequence(t *testing.T) {
+	network := NewNetwork("a", "b")
+	defer network.Close()
+	network.SetBehavior("b", Sequence(Reject(voting.CodeRejected, ""), Approve()))
+
+	for i, want := range []bool{false, true, true} {
+		outcome := network.Round(context.Background(), &voting.VoteRequest{}, 2)
+		if outcome.Approved != want {
+			t.Errorf("round %d approved = %t, want %t", i, outcome.Approved, want)
+		}
+	}
+	if got := len(network.Peer("b").Requests()); got != 3 {
+		t.Errorf("b received %d requests, want 3", got)
+	}
+}
+
+func TestDelayWithinDeadline(t *testing.T) {
+	network := NewNetwork("a")
+	defer network.Close()
+	network.SetBehavior("a", Delay(50*time.Millisecond, Approve()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	outcome := network.Round(ctx, &voting.VoteRequest{}, 1)
+	if !outcome.Approved {
+		t.Fatalf("round rejected: %+v", outcome.Votes)
+	}
+	if latency := outcome.Votes[0].Latency; latency < 50*time.Millisecond {
+		t.Errorf("latency %s shorter than the delay", latency)
+	}
+}
+
+func TestCloseReleasesDroppedRequests(t *testing.T) {
+	network := NewNetwork("a")
+	network.SetBehavior("a", Drop())
+
+	done := make(chan *Outcome)
+	go func() { done <- network.Round(context.Background(), &voting.VoteRequest{}, 1) }()
+	for len(network.Peer("a").Requests()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	network.Close()
+
+	select {
+	case outcome := <-done:
+		if outcome.Approved || outcome.Votes[0].Err == nil {
+			t.Errorf("dropped vote = %+v, want an error", outcome.Votes[0])
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("round still waiting after Close")
+	}
+}
+
+func TestTargets(t *testing.T) {
+	network := NewNetwork("a", "b", "a")
+	defer network.Close()
+
+	config := network.VotingSignConfig(2)
+	if len(config.Targets) != 2 || config.RequiredVotes != 2 {
+		t.Fatalf("config = %+v", config)
+	}
+	target := config.Targets["a"]
+	if target.AppID != "a" || target.ServicePort == 0 || target.VotingSignPath != VotingSignPath {
+		t.Errorf("target = %+v", target)
+	}
+	if network.Peer("missing") != nil {
+		t.Error("Peer returned a peer for an unknown app")
+	}
+}
@@ -0,0 +1,174 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/policy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// cachedPolicy is an app's signing policy as last fetched from the App node
+type cachedPolicy struct {
+	policy  *policy.Policy // nil if the app has no policy
+	expires time.Time
+}
+
+// requestStartKey is the context key holding when a sign request started
+type requestStartKey struct{}
+
+// withRequestStart records when a sign request started, for signing policy time-locks
+func withRequestStart(ctx context.Context, start time.Time) context.Context {
+	return context.WithValue(ctx, requestStartKey{}, start)
+}
+
+// requestStart returns when the sign request bound to ctx started, or now if unknown
+func requestStart(ctx context.Context) time.Time {
+	if start, ok := ctx.Value(requestStartKey{}).(time.Time); ok {
+		return start
+	}
+	return time.Now()
+}
+
+// SigningPolicy returns the signing policy the App node provides for appID, or nil if it has none
+//
+// Sign enforces it: signatures are only produced inside the policy's time windows and no
+// earlier than its minimum delay after the sign request started, waiting for the delay if the
+// request's deadline allows. Policies are cached for constants.SigningPolicyCacheTTL. App nodes
+// that predate signing policies are treated as having none
+func (c *Client) SigningPolicy(appID string) (*policy.Policy, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	return c.signingPolicy(ctx, appID)
+}
+
+// signingPolicy returns the cached or freshly fetched signing policy of appID
+func (c *Client) signingPolicy(ctx context.Context, appID string) (*policy.Policy, error) {
+	c.policiesMu.Lock()
+	cached, ok := c.policies[appID]
+	c.policiesMu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.policy, nil
+	}
+
+	userMgmtClient := c.userMgmt()
+	if userMgmtClient == nil {
+		return nil, fmt.Errorf("client not initialized")
+	}
+	pb, err := userMgmtClient.GetSigningPolicy(ctx, appID)
+	if err != nil && status.Code(err) != codes.Unimplemented {
+		return nil, err
+	}
+	p, err := policy.FromProto(pb)
+	if err != nil {
+		return nil, err
+	}
+
+	c.policiesMu.Lock()
+	if c.policies == nil {
+		c.policies = make(map[string]cachedPolicy)
+	}
+	c.policies[appID] = cachedPolicy{policy: p, expires: time.Now().Add(constants.SigningPolicyCacheTTL)}
+	c.policiesMu.Unlock()
+	return p, nil
+}
+
+// checkSigningPolicy refuses a sign request early if appID's policy won't allow it to be signed,
+// so a doomed request doesn't start a voting round
+func (c *Client) checkSigningPolicy(ctx context.Context, appID string) error {
+	p, err := c.signingPolicy(ctx, appID)
+	if err != nil || p == nil {
+		return err
+	}
+	earliest := p.EarliestSign(requestStart(ctx))
+	if err := checkDeadline(ctx, earliest); err != nil {
+		return err
+	}
+	return p.CheckTime(maxTime(earliest, time.Now()))
+}
+
+// enforceSigningPolicy waits out appID's policy delay and checks its time windows right
+// before the TEE is asked for a signature
+func (c *Client) enforceSigningPolicy(ctx context.Context, appID string) error {
+	p, err := c.signingPolicy(ctx, appID)
+	if err != nil || p == nil {
+		return err
+	}
+
+	earliest := p.EarliestSign(requestStart(ctx))
+	if wait := time.Until(earliest); wait > 0 {
+		if err := checkDeadline(ctx, earliest); err != nil {
+			return err
+		}
+		log.Printf("⏳ Signing for app %s is time-locked, waiting %s", appID, wait.Round(time.Second))
+		setOperationState(ctx, OperationTimeLocked)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return p.CheckTime(time.Now())
+}
+
+// AddPolicyPlugin adds a client-side signing policy, such as policy.SpendingPolicy, consulted
+// before every signature in the order added. A refusal fails the sign request before any voting
+// round starts; requests served from the dedup cache are checked too but not counted again.
+// Must be called before Init
+func (c *Client) AddPolicyPlugin(plugin policy.Plugin) {
+	c.policyPlugins = append(c.policyPlugins, plugin)
+}
+
+// authorizeSign asks every policy plugin to authorize a signature, returning a function that
+// reports the outcome to all of them. If one refuses, those that already agreed are released
+func (c *Client) authorizeSign(ctx context.Context, appID string, message []byte) (func(signed bool), error) {
+	var finishers []func(bool)
+	finish := func(signed bool) {
+		for _, f := range finishers {
+			f(signed)
+		}
+	}
+	for _, plugin := range c.policyPlugins {
+		f, err := plugin.Authorize(ctx, appID, message)
+		if err != nil {
+			finish(false)
+			return nil, err
+		}
+		finishers = append(finishers, f)
+	}
+	return finish, nil
+}
+
+// checkDeadline fails if ctx's deadline comes before a time-locked signature may be produced
+func checkDeadline(ctx context.Context, earliest time.Time) error {
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(earliest) {
+		return fmt.Errorf("%w: signing is time-locked until %s, after the request deadline %s",
+			ErrPolicyViolation, earliest.Format(time.RFC3339), deadline.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// maxTime returns the later of a and b
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
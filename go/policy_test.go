@@ -0,0 +1,72 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/policy"
+	"github.com/TEENet-io/teenet-sdk/go/proto/appid"
+)
+
+func TestSigningWindows(t *testing.T) {
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "closed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	addApp(t, deployment, "open-app", constants.ProtocolSchnorr, constants.CurveED25519)
+
+	// A window open every day but today, and one open all day today
+	today := int32(time.Now().UTC().Weekday())
+	var otherDays []int32
+	for day := int32(0); day < 7; day++ {
+		if day != today {
+			otherDays = append(otherDays, day)
+		}
+	}
+	closed := &appid.SigningPolicy{Windows: []*appid.TimeWindow{{Days: otherDays, StartMinute: 0, EndMinute: 24 * 60}}}
+	open := &appid.SigningPolicy{Windows: []*appid.TimeWindow{{StartMinute: 0, EndMinute: 24 * 60}}}
+	if err := deployment.SetSigningPolicy("closed-app", closed); err != nil {
+		t.Fatalf("SetSigningPolicy failed: %v", err)
+	}
+	if err := deployment.SetSigningPolicy("open-app", open); err != nil {
+		t.Fatalf("SetSigningPolicy failed: %v", err)
+	}
+
+	var windowErr *policy.WindowError
+	if _, err := c.Sign(&SignRequest{AppID: "closed-app", Message: []byte("hello")}); !errors.As(err, &windowErr) {
+		t.Errorf("Expected a window error outside the signing windows, got %v", err)
+	} else if windowErr.NextOpen.IsZero() {
+		t.Error("Expected the window error to say when signing opens")
+	}
+	if result, err := c.Sign(&SignRequest{AppID: "open-app", Message: []byte("hello")}); err != nil || !result.Success {
+		t.Errorf("Sign inside the signing window failed: %v", err)
+	}
+	if n := len(deployment.SignRequests()); n != 1 {
+		t.Errorf("Expected 1 TEE sign request, got %d", n)
+	}
+}
+
+func TestSigningDelay(t *testing.T) {
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := deployment.SetSigningPolicy("ed-app", &appid.SigningPolicy{MinDelaySeconds: 1}); err != nil {
+		t.Fatalf("SetSigningPolicy failed: %v", err)
+	}
+
+	// A deadline before the delay ends fails at once
+	start := time.Now()
+	if _, err := c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("hello"), Timeout: 300 * time.Millisecond}); !errors.Is(err, ErrPolicyViolation) {
+		t.Errorf("Expected ErrPolicyViolation for a deadline within the delay, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Errorf("Expected the request to fail without waiting, took %s", elapsed)
+	}
+
+	start = time.Now()
+	if result, err := c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("hello")}); err != nil || !result.Success {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Expected the signature to wait out the 1s delay, took %s", elapsed)
+	}
+}
@@ -21,10 +21,68 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// AppEventType identifies what changed
+type AppEventType int32
+
+const (
+	AppEventType_APP_EVENT_TYPE_UNSPECIFIED           AppEventType = 0
+	AppEventType_APP_EVENT_TYPE_KEY_ROTATED           AppEventType = 1 // The app's signing key changed
+	AppEventType_APP_EVENT_TYPE_VOTING_CONFIG_CHANGED AppEventType = 2 // Voting targets or required votes changed
+	AppEventType_APP_EVENT_TYPE_DEPLOYMENT_UP         AppEventType = 3 // A deployment target became available
+	AppEventType_APP_EVENT_TYPE_DEPLOYMENT_DOWN       AppEventType = 4 // A deployment target went away
+)
+
+// Enum value maps for AppEventType.
+var (
+	AppEventType_name = map[int32]string{
+		0: "APP_EVENT_TYPE_UNSPECIFIED",
+		1: "APP_EVENT_TYPE_KEY_ROTATED",
+		2: "APP_EVENT_TYPE_VOTING_CONFIG_CHANGED",
+		3: "APP_EVENT_TYPE_DEPLOYMENT_UP",
+		4: "APP_EVENT_TYPE_DEPLOYMENT_DOWN",
+	}
+	AppEventType_value = map[string]int32{
+		"APP_EVENT_TYPE_UNSPECIFIED":           0,
+		"APP_EVENT_TYPE_KEY_ROTATED":           1,
+		"APP_EVENT_TYPE_VOTING_CONFIG_CHANGED": 2,
+		"APP_EVENT_TYPE_DEPLOYMENT_UP":         3,
+		"APP_EVENT_TYPE_DEPLOYMENT_DOWN":       4,
+	}
+)
+
+func (x AppEventType) Enum() *AppEventType {
+	p := new(AppEventType)
+	*p = x
+	return p
+}
+
+func (x AppEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AppEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_appid_appid_service_proto_enumTypes[0].Descriptor()
+}
+
+func (AppEventType) Type() protoreflect.EnumType {
+	return &file_proto_appid_appid_service_proto_enumTypes[0]
+}
+
+func (x AppEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AppEventType.Descriptor instead.
+func (AppEventType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_appid_appid_service_proto_rawDescGZIP(), []int{0}
+}
+
 // Request message for getting public key by app ID
 type GetPublicKeyByAppIDRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AppId         string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	KeyVersion    uint32                 `protobuf:"varint,2,opt,name=key_version,json=keyVersion,proto3" json:"key_version,omitempty"` // Key version to return; 0 for the current key
+	ValidAt       int64                  `protobuf:"varint,3,opt,name=valid_at,json=validAt,proto3" json:"valid_at,omitempty"`          // Unix timestamp the returned key must have been the app's key at; 0 for now
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -66,12 +124,30 @@ func (x *GetPublicKeyByAppIDRequest) GetAppId() string {
 	return ""
 }
 
+func (x *GetPublicKeyByAppIDRequest) GetKeyVersion() uint32 {
+	if x != nil {
+		return x.KeyVersion
+	}
+	return 0
+}
+
+func (x *GetPublicKeyByAppIDRequest) GetValidAt() int64 {
+	if x != nil {
+		return x.ValidAt
+	}
+	return 0
+}
+
 // Response message for getting public key by app ID
 type GetPublicKeyByAppIDResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Publickey     string                 `protobuf:"bytes,1,opt,name=publickey,proto3" json:"publickey,omitempty"`
 	Protocol      string                 `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
 	Curve         string                 `protobuf:"bytes,3,opt,name=curve,proto3" json:"curve,omitempty"`
+	KeyUsage      []string               `protobuf:"bytes,4,rep,name=key_usage,json=keyUsage,proto3" json:"key_usage,omitempty"`        // Operations the key may be used for: "sign", "encrypt", "derive"; unrestricted if empty
+	KeyVersion    uint32                 `protobuf:"varint,5,opt,name=key_version,json=keyVersion,proto3" json:"key_version,omitempty"` // Version of the key, starting at 1 and incremented by each rotation; 0 if unversioned
+	ValidFrom     int64                  `protobuf:"varint,6,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`    // Unix timestamp the key became the app's key; 0 if unknown
+	ValidUntil    int64                  `protobuf:"varint,7,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"` // Unix timestamp a rotation replaced the key; 0 while it is current
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -127,6 +203,34 @@ func (x *GetPublicKeyByAppIDResponse) GetCurve() string {
 	return ""
 }
 
+func (x *GetPublicKeyByAppIDResponse) GetKeyUsage() []string {
+	if x != nil {
+		return x.KeyUsage
+	}
+	return nil
+}
+
+func (x *GetPublicKeyByAppIDResponse) GetKeyVersion() uint32 {
+	if x != nil {
+		return x.KeyVersion
+	}
+	return 0
+}
+
+func (x *GetPublicKeyByAppIDResponse) GetValidFrom() int64 {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return 0
+}
+
+func (x *GetPublicKeyByAppIDResponse) GetValidUntil() int64 {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return 0
+}
+
 // Voting service messages
 // GetDeploymentAddressesRequest for voting coordinator to get deployment-client addresses
 type GetDeploymentAddressesRequest struct {
@@ -179,6 +283,9 @@ type GetDeploymentAddressesResponse struct {
 	NotFound       []string                   `protobuf:"bytes,2,rep,name=not_found,json=notFound,proto3" json:"not_found,omitempty"`                                                                 // App IDs that were not found or not deployed
 	VotingSignPath string                     `protobuf:"bytes,3,opt,name=voting_sign_path,json=votingSignPath,proto3" json:"voting_sign_path,omitempty"`                                             // Shared VotingSign API path for all instances
 	RequiredVotes  int32                      `protobuf:"varint,4,opt,name=required_votes,json=requiredVotes,proto3" json:"required_votes,omitempty"`                                                 // Shared required votes for all instances
+	Groups         []*VotingGroup             `protobuf:"bytes,5,rep,name=groups,proto3" json:"groups,omitempty"`                                                                                     // Groups that must each contribute at least one approval
+	IssuedAt       int64                      `protobuf:"varint,6,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`                                                                // Unix time the signature was made
+	Signature      []byte                     `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`                                                                               // Voting authority signature, see usermgmt.VotingConfigDigest
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -241,6 +348,80 @@ func (x *GetDeploymentAddressesResponse) GetRequiredVotes() int32 {
 	return 0
 }
 
+func (x *GetDeploymentAddressesResponse) GetGroups() []*VotingGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *GetDeploymentAddressesResponse) GetIssuedAt() int64 {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return 0
+}
+
+func (x *GetDeploymentAddressesResponse) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// VotingGroup is a named set of voting targets (e.g. "finance", "security")
+type VotingGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Members       []string               `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"` // Target app IDs in the group
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VotingGroup) Reset() {
+	*x = VotingGroup{}
+	mi := &file_proto_appid_appid_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VotingGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VotingGroup) ProtoMessage() {}
+
+func (x *VotingGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appid_appid_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VotingGroup.ProtoReflect.Descriptor instead.
+func (*VotingGroup) Descriptor() ([]byte, []int) {
+	return file_proto_appid_appid_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VotingGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VotingGroup) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
 // DeploymentInfo represents deployment information for an app
 type DeploymentInfo struct {
 	state                   protoimpl.MessageState `protogen:"open.v1"`
@@ -258,7 +439,7 @@ type DeploymentInfo struct {
 
 func (x *DeploymentInfo) Reset() {
 	*x = DeploymentInfo{}
-	mi := &file_proto_appid_appid_service_proto_msgTypes[4]
+	mi := &file_proto_appid_appid_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -270,7 +451,7 @@ func (x *DeploymentInfo) String() string {
 func (*DeploymentInfo) ProtoMessage() {}
 
 func (x *DeploymentInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_appid_appid_service_proto_msgTypes[4]
+	mi := &file_proto_appid_appid_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -283,7 +464,7 @@ func (x *DeploymentInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeploymentInfo.ProtoReflect.Descriptor instead.
 func (*DeploymentInfo) Descriptor() ([]byte, []int) {
-	return file_proto_appid_appid_service_proto_rawDescGZIP(), []int{4}
+	return file_proto_appid_appid_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *DeploymentInfo) GetAppId() string {
@@ -342,27 +523,611 @@ func (x *DeploymentInfo) GetDeploymentType() string {
 	return ""
 }
 
+// Event subscription messages
+// SubscribeEventsRequest selects the apps to receive events for
+type SubscribeEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppIds        []string               `protobuf:"bytes,1,rep,name=app_ids,json=appIds,proto3" json:"app_ids,omitempty"` // App IDs to watch; empty for all apps
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeEventsRequest) Reset() {
+	*x = SubscribeEventsRequest{}
+	mi := &file_proto_appid_appid_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeEventsRequest) ProtoMessage() {}
+
+func (x *SubscribeEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appid_appid_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeEventsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_appid_appid_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SubscribeEventsRequest) GetAppIds() []string {
+	if x != nil {
+		return x.AppIds
+	}
+	return nil
+}
+
+// AppEvent is a change notification for an app
+type AppEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          AppEventType           `protobuf:"varint,1,opt,name=type,proto3,enum=appid.AppEventType" json:"type,omitempty"`
+	AppId         string                 `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`                     // App the event applies to
+	TargetAppId   string                 `protobuf:"bytes,3,opt,name=target_app_id,json=targetAppId,proto3" json:"target_app_id,omitempty"` // Deployment target that changed, for deployment events
+	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                         // Unix timestamp of the change
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppEvent) Reset() {
+	*x = AppEvent{}
+	mi := &file_proto_appid_appid_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppEvent) ProtoMessage() {}
+
+func (x *AppEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appid_appid_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppEvent.ProtoReflect.Descriptor instead.
+func (*AppEvent) Descriptor() ([]byte, []int) {
+	return file_proto_appid_appid_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AppEvent) GetType() AppEventType {
+	if x != nil {
+		return x.Type
+	}
+	return AppEventType_APP_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *AppEvent) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *AppEvent) GetTargetAppId() string {
+	if x != nil {
+		return x.TargetAppId
+	}
+	return ""
+}
+
+func (x *AppEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// Signing policy messages
+type GetSigningPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSigningPolicyRequest) Reset() {
+	*x = GetSigningPolicyRequest{}
+	mi := &file_proto_appid_appid_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSigningPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSigningPolicyRequest) ProtoMessage() {}
+
+func (x *GetSigningPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appid_appid_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSigningPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetSigningPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_appid_appid_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetSigningPolicyRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+type GetSigningPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *SigningPolicy         `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"` // Unset when the app has no signing policy
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSigningPolicyResponse) Reset() {
+	*x = GetSigningPolicyResponse{}
+	mi := &file_proto_appid_appid_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSigningPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSigningPolicyResponse) ProtoMessage() {}
+
+func (x *GetSigningPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appid_appid_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSigningPolicyResponse.ProtoReflect.Descriptor instead.
+func (*GetSigningPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_appid_appid_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetSigningPolicyResponse) GetPolicy() *SigningPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+// SigningPolicy restricts when an app's signatures may be produced
+type SigningPolicy struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Timezone        string                 `protobuf:"bytes,1,opt,name=timezone,proto3" json:"timezone,omitempty"`                                         // IANA time zone the windows are expressed in; UTC if empty
+	Windows         []*TimeWindow          `protobuf:"bytes,2,rep,name=windows,proto3" json:"windows,omitempty"`                                           // Signing is only allowed inside one of these; any time if none
+	MinDelaySeconds int64                  `protobuf:"varint,3,opt,name=min_delay_seconds,json=minDelaySeconds,proto3" json:"min_delay_seconds,omitempty"` // Minimum delay between the start of a sign request and its signature
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SigningPolicy) Reset() {
+	*x = SigningPolicy{}
+	mi := &file_proto_appid_appid_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SigningPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SigningPolicy) ProtoMessage() {}
+
+func (x *SigningPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appid_appid_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SigningPolicy.ProtoReflect.Descriptor instead.
+func (*SigningPolicy) Descriptor() ([]byte, []int) {
+	return file_proto_appid_appid_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SigningPolicy) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *SigningPolicy) GetWindows() []*TimeWindow {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
+
+func (x *SigningPolicy) GetMinDelaySeconds() int64 {
+	if x != nil {
+		return x.MinDelaySeconds
+	}
+	return 0
+}
+
+// TimeWindow is a daily period in which signing is allowed
+type TimeWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          []int32                `protobuf:"varint,1,rep,packed,name=days,proto3" json:"days,omitempty"`                           // Weekdays the window opens on (0 = Sunday); every day if empty
+	StartMinute   int32                  `protobuf:"varint,2,opt,name=start_minute,json=startMinute,proto3" json:"start_minute,omitempty"` // Minutes after midnight the window opens
+	EndMinute     int32                  `protobuf:"varint,3,opt,name=end_minute,json=endMinute,proto3" json:"end_minute,omitempty"`       // Minutes after midnight the window closes; at or below start_minute to close the next day
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeWindow) Reset() {
+	*x = TimeWindow{}
+	mi := &file_proto_appid_appid_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeWindow) ProtoMessage() {}
+
+func (x *TimeWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appid_appid_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeWindow.ProtoReflect.Descriptor instead.
+func (*TimeWindow) Descriptor() ([]byte, []int) {
+	return file_proto_appid_appid_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TimeWindow) GetDays() []int32 {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *TimeWindow) GetStartMinute() int32 {
+	if x != nil {
+		return x.StartMinute
+	}
+	return 0
+}
+
+func (x *TimeWindow) GetEndMinute() int32 {
+	if x != nil {
+		return x.EndMinute
+	}
+	return 0
+}
+
+// Key details messages
+type GetKeyDetailsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyDetailsRequest) Reset() {
+	*x = GetKeyDetailsRequest{}
+	mi := &file_proto_appid_appid_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyDetailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyDetailsRequest) ProtoMessage() {}
+
+func (x *GetKeyDetailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appid_appid_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyDetailsRequest.ProtoReflect.Descriptor instead.
+func (*GetKeyDetailsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_appid_appid_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetKeyDetailsRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+type GetKeyDetailsResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Publickey         string                 `protobuf:"bytes,1,opt,name=publickey,proto3" json:"publickey,omitempty"`
+	Protocol          string                 `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Curve             string                 `protobuf:"bytes,3,opt,name=curve,proto3" json:"curve,omitempty"`
+	Scheme            string                 `protobuf:"bytes,4,opt,name=scheme,proto3" json:"scheme,omitempty"`                                                 // Threshold scheme, e.g. "frost" or "gg20"
+	SchemeVersion     string                 `protobuf:"bytes,5,opt,name=scheme_version,json=schemeVersion,proto3" json:"scheme_version,omitempty"`              // Version of the scheme implementation that generated the key
+	Threshold         uint32                 `protobuf:"varint,6,opt,name=threshold,proto3" json:"threshold,omitempty"`                                          // t: participants needed to produce a signature
+	TotalParticipants uint32                 `protobuf:"varint,7,opt,name=total_participants,json=totalParticipants,proto3" json:"total_participants,omitempty"` // n: participants holding a key share
+	ParticipantIds    []uint32               `protobuf:"varint,8,rep,packed,name=participant_ids,json=participantIds,proto3" json:"participant_ids,omitempty"`   // TEE node IDs holding a key share
+	CreatedAt         int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                         // Unix timestamp the key was generated; 0 if unknown
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetKeyDetailsResponse) Reset() {
+	*x = GetKeyDetailsResponse{}
+	mi := &file_proto_appid_appid_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyDetailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyDetailsResponse) ProtoMessage() {}
+
+func (x *GetKeyDetailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appid_appid_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyDetailsResponse.ProtoReflect.Descriptor instead.
+func (*GetKeyDetailsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_appid_appid_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetKeyDetailsResponse) GetPublickey() string {
+	if x != nil {
+		return x.Publickey
+	}
+	return ""
+}
+
+func (x *GetKeyDetailsResponse) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *GetKeyDetailsResponse) GetCurve() string {
+	if x != nil {
+		return x.Curve
+	}
+	return ""
+}
+
+func (x *GetKeyDetailsResponse) GetScheme() string {
+	if x != nil {
+		return x.Scheme
+	}
+	return ""
+}
+
+func (x *GetKeyDetailsResponse) GetSchemeVersion() string {
+	if x != nil {
+		return x.SchemeVersion
+	}
+	return ""
+}
+
+func (x *GetKeyDetailsResponse) GetThreshold() uint32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *GetKeyDetailsResponse) GetTotalParticipants() uint32 {
+	if x != nil {
+		return x.TotalParticipants
+	}
+	return 0
+}
+
+func (x *GetKeyDetailsResponse) GetParticipantIds() []uint32 {
+	if x != nil {
+		return x.ParticipantIds
+	}
+	return nil
+}
+
+func (x *GetKeyDetailsResponse) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// Key rotation messages
+type RotateKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateKeyRequest) Reset() {
+	*x = RotateKeyRequest{}
+	mi := &file_proto_appid_appid_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateKeyRequest) ProtoMessage() {}
+
+func (x *RotateKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appid_appid_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateKeyRequest.ProtoReflect.Descriptor instead.
+func (*RotateKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_appid_appid_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RotateKeyRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+type RotateKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OperationId   string                 `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"` // DKG operation generating the new key; the app's key changes when it completes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateKeyResponse) Reset() {
+	*x = RotateKeyResponse{}
+	mi := &file_proto_appid_appid_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateKeyResponse) ProtoMessage() {}
+
+func (x *RotateKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appid_appid_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateKeyResponse.ProtoReflect.Descriptor instead.
+func (*RotateKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_appid_appid_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RotateKeyResponse) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
 var File_proto_appid_appid_service_proto protoreflect.FileDescriptor
 
 const file_proto_appid_appid_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1fproto/appid/appid_service.proto\x12\x05appid\"3\n" +
+	"\x1fproto/appid/appid_service.proto\x12\x05appid\"o\n" +
 	"\x1aGetPublicKeyByAppIDRequest\x12\x15\n" +
-	"\x06app_id\x18\x01 \x01(\tR\x05appId\"m\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\x12\x1f\n" +
+	"\vkey_version\x18\x02 \x01(\rR\n" +
+	"keyVersion\x12\x19\n" +
+	"\bvalid_at\x18\x03 \x01(\x03R\avalidAt\"\xeb\x01\n" +
 	"\x1bGetPublicKeyByAppIDResponse\x12\x1c\n" +
 	"\tpublickey\x18\x01 \x01(\tR\tpublickey\x12\x1a\n" +
 	"\bprotocol\x18\x02 \x01(\tR\bprotocol\x12\x14\n" +
-	"\x05curve\x18\x03 \x01(\tR\x05curve\"6\n" +
+	"\x05curve\x18\x03 \x01(\tR\x05curve\x12\x1b\n" +
+	"\tkey_usage\x18\x04 \x03(\tR\bkeyUsage\x12\x1f\n" +
+	"\vkey_version\x18\x05 \x01(\rR\n" +
+	"keyVersion\x12\x1d\n" +
+	"\n" +
+	"valid_from\x18\x06 \x01(\x03R\tvalidFrom\x12\x1f\n" +
+	"\vvalid_until\x18\a \x01(\x03R\n" +
+	"validUntil\"6\n" +
 	"\x1dGetDeploymentAddressesRequest\x12\x15\n" +
-	"\x06app_id\x18\x01 \x01(\tR\x05appId\"\xbf\x02\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\"\xa6\x03\n" +
 	"\x1eGetDeploymentAddressesResponse\x12X\n" +
 	"\vdeployments\x18\x01 \x03(\v26.appid.GetDeploymentAddressesResponse.DeploymentsEntryR\vdeployments\x12\x1b\n" +
 	"\tnot_found\x18\x02 \x03(\tR\bnotFound\x12(\n" +
 	"\x10voting_sign_path\x18\x03 \x01(\tR\x0evotingSignPath\x12%\n" +
-	"\x0erequired_votes\x18\x04 \x01(\x05R\rrequiredVotes\x1aU\n" +
+	"\x0erequired_votes\x18\x04 \x01(\x05R\rrequiredVotes\x12*\n" +
+	"\x06groups\x18\x05 \x03(\v2\x12.appid.VotingGroupR\x06groups\x12\x1b\n" +
+	"\tissued_at\x18\x06 \x01(\x03R\bissuedAt\x12\x1c\n" +
+	"\tsignature\x18\a \x01(\fR\tsignature\x1aU\n" +
 	"\x10DeploymentsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
-	"\x05value\x18\x02 \x01(\v2\x15.appid.DeploymentInfoR\x05value:\x028\x01\"\xbf\x02\n" +
+	"\x05value\x18\x02 \x01(\v2\x15.appid.DeploymentInfoR\x05value:\x028\x01\";\n" +
+	"\vVotingGroup\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\amembers\x18\x02 \x03(\tR\amembers\"\xbf\x02\n" +
 	"\x0eDeploymentInfo\x12\x15\n" +
 	"\x06app_id\x18\x01 \x01(\tR\x05appId\x12!\n" +
 	"\fproject_name\x18\x02 \x01(\tR\vprojectName\x12'\n" +
@@ -372,10 +1137,58 @@ const file_proto_appid_appid_service_proto_rawDesc = "" +
 	"\x19deployment_client_address\x18\x06 \x01(\tR\x17deploymentClientAddress\x12\x1f\n" +
 	"\vdeployed_at\x18\a \x01(\x03R\n" +
 	"deployedAt\x12'\n" +
-	"\x0fdeployment_type\x18\b \x01(\tR\x0edeploymentType2\xd3\x01\n" +
+	"\x0fdeployment_type\x18\b \x01(\tR\x0edeploymentType\"1\n" +
+	"\x16SubscribeEventsRequest\x12\x17\n" +
+	"\aapp_ids\x18\x01 \x03(\tR\x06appIds\"\x8c\x01\n" +
+	"\bAppEvent\x12'\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x13.appid.AppEventTypeR\x04type\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12\"\n" +
+	"\rtarget_app_id\x18\x03 \x01(\tR\vtargetAppId\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\"0\n" +
+	"\x17GetSigningPolicyRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\"H\n" +
+	"\x18GetSigningPolicyResponse\x12,\n" +
+	"\x06policy\x18\x01 \x01(\v2\x14.appid.SigningPolicyR\x06policy\"\x84\x01\n" +
+	"\rSigningPolicy\x12\x1a\n" +
+	"\btimezone\x18\x01 \x01(\tR\btimezone\x12+\n" +
+	"\awindows\x18\x02 \x03(\v2\x11.appid.TimeWindowR\awindows\x12*\n" +
+	"\x11min_delay_seconds\x18\x03 \x01(\x03R\x0fminDelaySeconds\"b\n" +
+	"\n" +
+	"TimeWindow\x12\x12\n" +
+	"\x04days\x18\x01 \x03(\x05R\x04days\x12!\n" +
+	"\fstart_minute\x18\x02 \x01(\x05R\vstartMinute\x12\x1d\n" +
+	"\n" +
+	"end_minute\x18\x03 \x01(\x05R\tendMinute\"-\n" +
+	"\x14GetKeyDetailsRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\"\xbb\x02\n" +
+	"\x15GetKeyDetailsResponse\x12\x1c\n" +
+	"\tpublickey\x18\x01 \x01(\tR\tpublickey\x12\x1a\n" +
+	"\bprotocol\x18\x02 \x01(\tR\bprotocol\x12\x14\n" +
+	"\x05curve\x18\x03 \x01(\tR\x05curve\x12\x16\n" +
+	"\x06scheme\x18\x04 \x01(\tR\x06scheme\x12%\n" +
+	"\x0escheme_version\x18\x05 \x01(\tR\rschemeVersion\x12\x1c\n" +
+	"\tthreshold\x18\x06 \x01(\rR\tthreshold\x12-\n" +
+	"\x12total_participants\x18\a \x01(\rR\x11totalParticipants\x12'\n" +
+	"\x0fparticipant_ids\x18\b \x03(\rR\x0eparticipantIds\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\")\n" +
+	"\x10RotateKeyRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\"6\n" +
+	"\x11RotateKeyResponse\x12!\n" +
+	"\foperation_id\x18\x01 \x01(\tR\voperationId*\xbe\x01\n" +
+	"\fAppEventType\x12\x1e\n" +
+	"\x1aAPP_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aAPP_EVENT_TYPE_KEY_ROTATED\x10\x01\x12(\n" +
+	"$APP_EVENT_TYPE_VOTING_CONFIG_CHANGED\x10\x02\x12 \n" +
+	"\x1cAPP_EVENT_TYPE_DEPLOYMENT_UP\x10\x03\x12\"\n" +
+	"\x1eAPP_EVENT_TYPE_DEPLOYMENT_DOWN\x10\x042\xf9\x03\n" +
 	"\fAppIDService\x12\\\n" +
 	"\x13GetPublicKeyByAppID\x12!.appid.GetPublicKeyByAppIDRequest\x1a\".appid.GetPublicKeyByAppIDResponse\x12e\n" +
-	"\x16GetDeploymentAddresses\x12$.appid.GetDeploymentAddressesRequest\x1a%.appid.GetDeploymentAddressesResponseB\n" +
+	"\x16GetDeploymentAddresses\x12$.appid.GetDeploymentAddressesRequest\x1a%.appid.GetDeploymentAddressesResponse\x12C\n" +
+	"\x0fSubscribeEvents\x12\x1d.appid.SubscribeEventsRequest\x1a\x0f.appid.AppEvent0\x01\x12S\n" +
+	"\x10GetSigningPolicy\x12\x1e.appid.GetSigningPolicyRequest\x1a\x1f.appid.GetSigningPolicyResponse\x12J\n" +
+	"\rGetKeyDetails\x12\x1b.appid.GetKeyDetailsRequest\x1a\x1c.appid.GetKeyDetailsResponse\x12>\n" +
+	"\tRotateKey\x12\x17.appid.RotateKeyRequest\x1a\x18.appid.RotateKeyResponseB\n" +
 	"Z\b./;appidb\x06proto3"
 
 var (
@@ -390,27 +1203,52 @@ func file_proto_appid_appid_service_proto_rawDescGZIP() []byte {
 	return file_proto_appid_appid_service_proto_rawDescData
 }
 
-var file_proto_appid_appid_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_appid_appid_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_appid_appid_service_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_proto_appid_appid_service_proto_goTypes = []any{
-	(*GetPublicKeyByAppIDRequest)(nil),     // 0: appid.GetPublicKeyByAppIDRequest
-	(*GetPublicKeyByAppIDResponse)(nil),    // 1: appid.GetPublicKeyByAppIDResponse
-	(*GetDeploymentAddressesRequest)(nil),  // 2: appid.GetDeploymentAddressesRequest
-	(*GetDeploymentAddressesResponse)(nil), // 3: appid.GetDeploymentAddressesResponse
-	(*DeploymentInfo)(nil),                 // 4: appid.DeploymentInfo
-	nil,                                    // 5: appid.GetDeploymentAddressesResponse.DeploymentsEntry
+	(AppEventType)(0),                      // 0: appid.AppEventType
+	(*GetPublicKeyByAppIDRequest)(nil),     // 1: appid.GetPublicKeyByAppIDRequest
+	(*GetPublicKeyByAppIDResponse)(nil),    // 2: appid.GetPublicKeyByAppIDResponse
+	(*GetDeploymentAddressesRequest)(nil),  // 3: appid.GetDeploymentAddressesRequest
+	(*GetDeploymentAddressesResponse)(nil), // 4: appid.GetDeploymentAddressesResponse
+	(*VotingGroup)(nil),                    // 5: appid.VotingGroup
+	(*DeploymentInfo)(nil),                 // 6: appid.DeploymentInfo
+	(*SubscribeEventsRequest)(nil),         // 7: appid.SubscribeEventsRequest
+	(*AppEvent)(nil),                       // 8: appid.AppEvent
+	(*GetSigningPolicyRequest)(nil),        // 9: appid.GetSigningPolicyRequest
+	(*GetSigningPolicyResponse)(nil),       // 10: appid.GetSigningPolicyResponse
+	(*SigningPolicy)(nil),                  // 11: appid.SigningPolicy
+	(*TimeWindow)(nil),                     // 12: appid.TimeWindow
+	(*GetKeyDetailsRequest)(nil),           // 13: appid.GetKeyDetailsRequest
+	(*GetKeyDetailsResponse)(nil),          // 14: appid.GetKeyDetailsResponse
+	(*RotateKeyRequest)(nil),               // 15: appid.RotateKeyRequest
+	(*RotateKeyResponse)(nil),              // 16: appid.RotateKeyResponse
+	nil,                                    // 17: appid.GetDeploymentAddressesResponse.DeploymentsEntry
 }
 var file_proto_appid_appid_service_proto_depIdxs = []int32{
-	5, // 0: appid.GetDeploymentAddressesResponse.deployments:type_name -> appid.GetDeploymentAddressesResponse.DeploymentsEntry
-	4, // 1: appid.GetDeploymentAddressesResponse.DeploymentsEntry.value:type_name -> appid.DeploymentInfo
-	0, // 2: appid.AppIDService.GetPublicKeyByAppID:input_type -> appid.GetPublicKeyByAppIDRequest
-	2, // 3: appid.AppIDService.GetDeploymentAddresses:input_type -> appid.GetDeploymentAddressesRequest
-	1, // 4: appid.AppIDService.GetPublicKeyByAppID:output_type -> appid.GetPublicKeyByAppIDResponse
-	3, // 5: appid.AppIDService.GetDeploymentAddresses:output_type -> appid.GetDeploymentAddressesResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	17, // 0: appid.GetDeploymentAddressesResponse.deployments:type_name -> appid.GetDeploymentAddressesResponse.DeploymentsEntry
+	5,  // 1: appid.GetDeploymentAddressesResponse.groups:type_name -> appid.VotingGroup
+	0,  // 2: appid.AppEvent.type:type_name -> appid.AppEventType
+	11, // 3: appid.GetSigningPolicyResponse.policy:type_name -> appid.SigningPolicy
+	12, // 4: appid.SigningPolicy.windows:type_name -> appid.TimeWindow
+	6,  // 5: appid.GetDeploymentAddressesResponse.DeploymentsEntry.value:type_name -> appid.DeploymentInfo
+	1,  // 6: appid.AppIDService.GetPublicKeyByAppID:input_type -> appid.GetPublicKeyByAppIDRequest
+	3,  // 7: appid.AppIDService.GetDeploymentAddresses:input_type -> appid.GetDeploymentAddressesRequest
+	7,  // 8: appid.AppIDService.SubscribeEvents:input_type -> appid.SubscribeEventsRequest
+	9,  // 9: appid.AppIDService.GetSigningPolicy:input_type -> appid.GetSigningPolicyRequest
+	13, // 10: appid.AppIDService.GetKeyDetails:input_type -> appid.GetKeyDetailsRequest
+	15, // 11: appid.AppIDService.RotateKey:input_type -> appid.RotateKeyRequest
+	2,  // 12: appid.AppIDService.GetPublicKeyByAppID:output_type -> appid.GetPublicKeyByAppIDResponse
+	4,  // 13: appid.AppIDService.GetDeploymentAddresses:output_type -> appid.GetDeploymentAddressesResponse
+	8,  // 14: appid.AppIDService.SubscribeEvents:output_type -> appid.AppEvent
+	10, // 15: appid.AppIDService.GetSigningPolicy:output_type -> appid.GetSigningPolicyResponse
+	14, // 16: appid.AppIDService.GetKeyDetails:output_type -> appid.GetKeyDetailsResponse
+	16, // 17: appid.AppIDService.RotateKey:output_type -> appid.RotateKeyResponse
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_proto_appid_appid_service_proto_init() }
@@ -423,13 +1261,14 @@ func file_proto_appid_appid_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_appid_appid_service_proto_rawDesc), len(file_proto_appid_appid_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      1,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_appid_appid_service_proto_goTypes,
 		DependencyIndexes: file_proto_appid_appid_service_proto_depIdxs,
+		EnumInfos:         file_proto_appid_appid_service_proto_enumTypes,
 		MessageInfos:      file_proto_appid_appid_service_proto_msgTypes,
 	}.Build()
 	File_proto_appid_appid_service_proto = out.File
@@ -21,6 +21,10 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	AppIDService_GetPublicKeyByAppID_FullMethodName    = "/appid.AppIDService/GetPublicKeyByAppID"
 	AppIDService_GetDeploymentAddresses_FullMethodName = "/appid.AppIDService/GetDeploymentAddresses"
+	AppIDService_SubscribeEvents_FullMethodName        = "/appid.AppIDService/SubscribeEvents"
+	AppIDService_GetSigningPolicy_FullMethodName       = "/appid.AppIDService/GetSigningPolicy"
+	AppIDService_GetKeyDetails_FullMethodName          = "/appid.AppIDService/GetKeyDetails"
+	AppIDService_RotateKey_FullMethodName              = "/appid.AppIDService/RotateKey"
 )
 
 // AppIDServiceClient is the client API for AppIDService service.
@@ -34,6 +38,14 @@ type AppIDServiceClient interface {
 	// Voting service methods
 	// GetDeploymentAddresses gets deployment-client addresses for given app IDs (for voting coordinator)
 	GetDeploymentAddresses(ctx context.Context, in *GetDeploymentAddressesRequest, opts ...grpc.CallOption) (*GetDeploymentAddressesResponse, error)
+	// SubscribeEvents streams notifications when an app's key, voting config or deployments change
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AppEvent], error)
+	// GetSigningPolicy gets the signing restrictions the client enforces for an app
+	GetSigningPolicy(ctx context.Context, in *GetSigningPolicyRequest, opts ...grpc.CallOption) (*GetSigningPolicyResponse, error)
+	// GetKeyDetails gets the threshold scheme parameters behind an app's key
+	GetKeyDetails(ctx context.Context, in *GetKeyDetailsRequest, opts ...grpc.CallOption) (*GetKeyDetailsResponse, error)
+	// RotateKey starts generating a new key for an app, watched with UserTask.WatchKeyOperation
+	RotateKey(ctx context.Context, in *RotateKeyRequest, opts ...grpc.CallOption) (*RotateKeyResponse, error)
 }
 
 type appIDServiceClient struct {
@@ -64,6 +76,55 @@ func (c *appIDServiceClient) GetDeploymentAddresses(ctx context.Context, in *Get
 	return out, nil
 }
 
+func (c *appIDServiceClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AppEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AppIDService_ServiceDesc.Streams[0], AppIDService_SubscribeEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeEventsRequest, AppEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AppIDService_SubscribeEventsClient = grpc.ServerStreamingClient[AppEvent]
+
+func (c *appIDServiceClient) GetSigningPolicy(ctx context.Context, in *GetSigningPolicyRequest, opts ...grpc.CallOption) (*GetSigningPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSigningPolicyResponse)
+	err := c.cc.Invoke(ctx, AppIDService_GetSigningPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appIDServiceClient) GetKeyDetails(ctx context.Context, in *GetKeyDetailsRequest, opts ...grpc.CallOption) (*GetKeyDetailsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetKeyDetailsResponse)
+	err := c.cc.Invoke(ctx, AppIDService_GetKeyDetails_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appIDServiceClient) RotateKey(ctx context.Context, in *RotateKeyRequest, opts ...grpc.CallOption) (*RotateKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateKeyResponse)
+	err := c.cc.Invoke(ctx, AppIDService_RotateKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AppIDServiceServer is the server API for AppIDService service.
 // All implementations must embed UnimplementedAppIDServiceServer
 // for forward compatibility.
@@ -75,6 +136,14 @@ type AppIDServiceServer interface {
 	// Voting service methods
 	// GetDeploymentAddresses gets deployment-client addresses for given app IDs (for voting coordinator)
 	GetDeploymentAddresses(context.Context, *GetDeploymentAddressesRequest) (*GetDeploymentAddressesResponse, error)
+	// SubscribeEvents streams notifications when an app's key, voting config or deployments change
+	SubscribeEvents(*SubscribeEventsRequest, grpc.ServerStreamingServer[AppEvent]) error
+	// GetSigningPolicy gets the signing restrictions the client enforces for an app
+	GetSigningPolicy(context.Context, *GetSigningPolicyRequest) (*GetSigningPolicyResponse, error)
+	// GetKeyDetails gets the threshold scheme parameters behind an app's key
+	GetKeyDetails(context.Context, *GetKeyDetailsRequest) (*GetKeyDetailsResponse, error)
+	// RotateKey starts generating a new key for an app, watched with UserTask.WatchKeyOperation
+	RotateKey(context.Context, *RotateKeyRequest) (*RotateKeyResponse, error)
 	mustEmbedUnimplementedAppIDServiceServer()
 }
 
@@ -91,6 +160,18 @@ func (UnimplementedAppIDServiceServer) GetPublicKeyByAppID(context.Context, *Get
 func (UnimplementedAppIDServiceServer) GetDeploymentAddresses(context.Context, *GetDeploymentAddressesRequest) (*GetDeploymentAddressesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDeploymentAddresses not implemented")
 }
+func (UnimplementedAppIDServiceServer) SubscribeEvents(*SubscribeEventsRequest, grpc.ServerStreamingServer[AppEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (UnimplementedAppIDServiceServer) GetSigningPolicy(context.Context, *GetSigningPolicyRequest) (*GetSigningPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSigningPolicy not implemented")
+}
+func (UnimplementedAppIDServiceServer) GetKeyDetails(context.Context, *GetKeyDetailsRequest) (*GetKeyDetailsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeyDetails not implemented")
+}
+func (UnimplementedAppIDServiceServer) RotateKey(context.Context, *RotateKeyRequest) (*RotateKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateKey not implemented")
+}
 func (UnimplementedAppIDServiceServer) mustEmbedUnimplementedAppIDServiceServer() {}
 func (UnimplementedAppIDServiceServer) testEmbeddedByValue()                      {}
 
@@ -148,6 +229,71 @@ func _AppIDService_GetDeploymentAddresses_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AppIDService_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AppIDServiceServer).SubscribeEvents(m, &grpc.GenericServerStream[SubscribeEventsRequest, AppEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AppIDService_SubscribeEventsServer = grpc.ServerStreamingServer[AppEvent]
+
+func _AppIDService_GetSigningPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSigningPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppIDServiceServer).GetSigningPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppIDService_GetSigningPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppIDServiceServer).GetSigningPolicy(ctx, req.(*GetSigningPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppIDService_GetKeyDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyDetailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppIDServiceServer).GetKeyDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppIDService_GetKeyDetails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppIDServiceServer).GetKeyDetails(ctx, req.(*GetKeyDetailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppIDService_RotateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppIDServiceServer).RotateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppIDService_RotateKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppIDServiceServer).RotateKey(ctx, req.(*RotateKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AppIDService_ServiceDesc is the grpc.ServiceDesc for AppIDService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -163,7 +309,25 @@ var AppIDService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDeploymentAddresses",
 			Handler:    _AppIDService_GetDeploymentAddresses_Handler,
 		},
+		{
+			MethodName: "GetSigningPolicy",
+			Handler:    _AppIDService_GetSigningPolicy_Handler,
+		},
+		{
+			MethodName: "GetKeyDetails",
+			Handler:    _AppIDService_GetKeyDetails_Handler,
+		},
+		{
+			MethodName: "RotateKey",
+			Handler:    _AppIDService_RotateKey_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _AppIDService_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/appid/appid_service.proto",
 }
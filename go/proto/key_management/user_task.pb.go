@@ -22,14 +22,18 @@ const (
 )
 
 type SignRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	From          uint32                 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`                                         // sender id
-	PublicKeyInfo []byte                 `protobuf:"bytes,2,opt,name=public_key_info,json=publicKeyInfo,proto3" json:"public_key_info,omitempty"` // public key
-	Msg           []byte                 `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`                                            // message
-	Protocol      uint32                 `protobuf:"varint,4,opt,name=protocol,proto3" json:"protocol,omitempty"`                                 // 1: ECDSA, 2: Schnorr
-	Curve         uint32                 `protobuf:"varint,5,opt,name=curve,proto3" json:"curve,omitempty"`                                       // 1: ED25519, 2: SECP256K1, 3: SECP256R1
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	From           uint32                 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`                                          // sender id
+	PublicKeyInfo  []byte                 `protobuf:"bytes,2,opt,name=public_key_info,json=publicKeyInfo,proto3" json:"public_key_info,omitempty"`  // public key
+	Msg            []byte                 `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`                                             // message
+	Protocol       uint32                 `protobuf:"varint,4,opt,name=protocol,proto3" json:"protocol,omitempty"`                                  // 1: ECDSA, 2: Schnorr, 3: MuSig2
+	Curve          uint32                 `protobuf:"varint,5,opt,name=curve,proto3" json:"curve,omitempty"`                                        // 1: ED25519, 2: SECP256K1, 3: SECP256R1
+	Ed25519Mode    uint32                 `protobuf:"varint,6,opt,name=ed25519_mode,json=ed25519Mode,proto3" json:"ed25519_mode,omitempty"`         // ED25519 only. 0: Ed25519, 1: Ed25519ph, 2: Ed25519ctx
+	Ed25519Context []byte                 `protobuf:"bytes,7,opt,name=ed25519_context,json=ed25519Context,proto3" json:"ed25519_context,omitempty"` // ED25519 only. Context string for Ed25519ph/Ed25519ctx
+	Musig2         *MuSig2Step            `protobuf:"bytes,8,opt,name=musig2,proto3" json:"musig2,omitempty"`                                       // MuSig2 only. Session step to run with the key
+	Prehashed      bool                   `protobuf:"varint,9,opt,name=prehashed,proto3" json:"prehashed,omitempty"`                                // ECDSA only. msg is a 32-byte digest to sign as is, instead of hashing it with SHA-256
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *SignRequest) Reset() {
@@ -97,6 +101,105 @@ func (x *SignRequest) GetCurve() uint32 {
 	return 0
 }
 
+func (x *SignRequest) GetEd25519Mode() uint32 {
+	if x != nil {
+		return x.Ed25519Mode
+	}
+	return 0
+}
+
+func (x *SignRequest) GetEd25519Context() []byte {
+	if x != nil {
+		return x.Ed25519Context
+	}
+	return nil
+}
+
+func (x *SignRequest) GetMusig2() *MuSig2Step {
+	if x != nil {
+		return x.Musig2
+	}
+	return nil
+}
+
+func (x *SignRequest) GetPrehashed() bool {
+	if x != nil {
+		return x.Prehashed
+	}
+	return false
+}
+
+// MuSig2Step is one step of a MuSig2 (BIP-327) signing session; msg is the 32-byte message.
+// Without aggregate_nonce the TEE returns a fresh 66-byte public nonce and keeps its secret nonce
+// for session_id. With it, the TEE returns the 32-byte partial signature and forgets the secret nonce
+type MuSig2Step struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SessionId      string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`                // Session the nonce belongs to
+	Signers        [][]byte               `protobuf:"bytes,2,rep,name=signers,proto3" json:"signers,omitempty"`                                     // Public keys of all signers, aggregated in sorted order
+	Taproot        bool                   `protobuf:"varint,3,opt,name=taproot,proto3" json:"taproot,omitempty"`                                    // Sign for the BIP-86 taproot output key of the aggregate key
+	AggregateNonce []byte                 `protobuf:"bytes,4,opt,name=aggregate_nonce,json=aggregateNonce,proto3" json:"aggregate_nonce,omitempty"` // Sum of the signers' public nonces, set in the signing step
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MuSig2Step) Reset() {
+	*x = MuSig2Step{}
+	mi := &file_user_task_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuSig2Step) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuSig2Step) ProtoMessage() {}
+
+func (x *MuSig2Step) ProtoReflect() protoreflect.Message {
+	mi := &file_user_task_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuSig2Step.ProtoReflect.Descriptor instead.
+func (*MuSig2Step) Descriptor() ([]byte, []int) {
+	return file_user_task_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MuSig2Step) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *MuSig2Step) GetSigners() [][]byte {
+	if x != nil {
+		return x.Signers
+	}
+	return nil
+}
+
+func (x *MuSig2Step) GetTaproot() bool {
+	if x != nil {
+		return x.Taproot
+	}
+	return false
+}
+
+func (x *MuSig2Step) GetAggregateNonce() []byte {
+	if x != nil {
+		return x.AggregateNonce
+	}
+	return nil
+}
+
 type SignResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Signature     []byte                 `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
@@ -108,7 +211,7 @@ type SignResponse struct {
 
 func (x *SignResponse) Reset() {
 	*x = SignResponse{}
-	mi := &file_user_task_proto_msgTypes[1]
+	mi := &file_user_task_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -120,7 +223,7 @@ func (x *SignResponse) String() string {
 func (*SignResponse) ProtoMessage() {}
 
 func (x *SignResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_task_proto_msgTypes[1]
+	mi := &file_user_task_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -133,7 +236,7 @@ func (x *SignResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignResponse.ProtoReflect.Descriptor instead.
 func (*SignResponse) Descriptor() ([]byte, []int) {
-	return file_user_task_proto_rawDescGZIP(), []int{1}
+	return file_user_task_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *SignResponse) GetSignature() []byte {
@@ -157,23 +260,287 @@ func (x *SignResponse) GetError() string {
 	return ""
 }
 
+type WatchKeyOperationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          uint32                 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`                                 // sender id
+	OperationId   string                 `protobuf:"bytes,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"` // DKG or resharing operation to watch
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchKeyOperationRequest) Reset() {
+	*x = WatchKeyOperationRequest{}
+	mi := &file_user_task_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchKeyOperationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchKeyOperationRequest) ProtoMessage() {}
+
+func (x *WatchKeyOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_task_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchKeyOperationRequest.ProtoReflect.Descriptor instead.
+func (*WatchKeyOperationRequest) Descriptor() ([]byte, []int) {
+	return file_user_task_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WatchKeyOperationRequest) GetFrom() uint32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *WatchKeyOperationRequest) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+// KeyOperationStatus reports the progress of a DKG or resharing operation. The current status
+// is sent when the watch starts and again on every change; the stream ends once the operation
+// has completed or failed
+type KeyOperationStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OperationId   string                 `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	Type          uint32                 `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`                                  // 1: DKG, 2: Resharing
+	State         uint32                 `protobuf:"varint,3,opt,name=state,proto3" json:"state,omitempty"`                                // 0: Pending, 1: Running, 2: Completed, 3: Failed
+	Round         uint32                 `protobuf:"varint,4,opt,name=round,proto3" json:"round,omitempty"`                                // Protocol round in progress
+	TotalRounds   uint32                 `protobuf:"varint,5,opt,name=total_rounds,json=totalRounds,proto3" json:"total_rounds,omitempty"` // Rounds the protocol takes
+	Participants  []*ParticipantProgress `protobuf:"bytes,6,rep,name=participants,proto3" json:"participants,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`                           // Why the operation failed
+	PublicKey     []byte                 `protobuf:"bytes,8,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`  // Group public key, once completed
+	UpdatedAt     int64                  `protobuf:"varint,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // Unix timestamp of the last change
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyOperationStatus) Reset() {
+	*x = KeyOperationStatus{}
+	mi := &file_user_task_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyOperationStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyOperationStatus) ProtoMessage() {}
+
+func (x *KeyOperationStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_user_task_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyOperationStatus.ProtoReflect.Descriptor instead.
+func (*KeyOperationStatus) Descriptor() ([]byte, []int) {
+	return file_user_task_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *KeyOperationStatus) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+func (x *KeyOperationStatus) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *KeyOperationStatus) GetState() uint32 {
+	if x != nil {
+		return x.State
+	}
+	return 0
+}
+
+func (x *KeyOperationStatus) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *KeyOperationStatus) GetTotalRounds() uint32 {
+	if x != nil {
+		return x.TotalRounds
+	}
+	return 0
+}
+
+func (x *KeyOperationStatus) GetParticipants() []*ParticipantProgress {
+	if x != nil {
+		return x.Participants
+	}
+	return nil
+}
+
+func (x *KeyOperationStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *KeyOperationStatus) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *KeyOperationStatus) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// ParticipantProgress is one TEE node's progress in a key operation
+type ParticipantProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`       // TEE node ID
+	State         uint32                 `protobuf:"varint,2,opt,name=state,proto3" json:"state,omitempty"` // 0: Pending, 1: Running, 2: Completed, 3: Failed
+	Round         uint32                 `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"` // Last round the node completed
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`  // Why the node failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParticipantProgress) Reset() {
+	*x = ParticipantProgress{}
+	mi := &file_user_task_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParticipantProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParticipantProgress) ProtoMessage() {}
+
+func (x *ParticipantProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_user_task_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParticipantProgress.ProtoReflect.Descriptor instead.
+func (*ParticipantProgress) Descriptor() ([]byte, []int) {
+	return file_user_task_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ParticipantProgress) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ParticipantProgress) GetState() uint32 {
+	if x != nil {
+		return x.State
+	}
+	return 0
+}
+
+func (x *ParticipantProgress) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *ParticipantProgress) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 var File_user_task_proto protoreflect.FileDescriptor
 
 const file_user_task_proto_rawDesc = "" +
 	"\n" +
-	"\x0fuser_task.proto\"\x8d\x01\n" +
+	"\x0fuser_task.proto\"\x9c\x02\n" +
 	"\vSignRequest\x12\x12\n" +
 	"\x04from\x18\x01 \x01(\rR\x04from\x12&\n" +
 	"\x0fpublic_key_info\x18\x02 \x01(\fR\rpublicKeyInfo\x12\x10\n" +
 	"\x03msg\x18\x03 \x01(\fR\x03msg\x12\x1a\n" +
 	"\bprotocol\x18\x04 \x01(\rR\bprotocol\x12\x14\n" +
-	"\x05curve\x18\x05 \x01(\rR\x05curve\"\\\n" +
+	"\x05curve\x18\x05 \x01(\rR\x05curve\x12!\n" +
+	"\fed25519_mode\x18\x06 \x01(\rR\ved25519Mode\x12'\n" +
+	"\x0fed25519_context\x18\a \x01(\fR\x0eed25519Context\x12#\n" +
+	"\x06musig2\x18\b \x01(\v2\v.MuSig2StepR\x06musig2\x12\x1c\n" +
+	"\tprehashed\x18\t \x01(\bR\tprehashed\"\x88\x01\n" +
+	"\n" +
+	"MuSig2Step\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x18\n" +
+	"\asigners\x18\x02 \x03(\fR\asigners\x12\x18\n" +
+	"\ataproot\x18\x03 \x01(\bR\ataproot\x12'\n" +
+	"\x0faggregate_nonce\x18\x04 \x01(\fR\x0eaggregateNonce\"\\\n" +
 	"\fSignResponse\x12\x1c\n" +
 	"\tsignature\x18\x01 \x01(\fR\tsignature\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error21\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"Q\n" +
+	"\x18WatchKeyOperationRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\rR\x04from\x12!\n" +
+	"\foperation_id\x18\x02 \x01(\tR\voperationId\"\xa8\x02\n" +
+	"\x12KeyOperationStatus\x12!\n" +
+	"\foperation_id\x18\x01 \x01(\tR\voperationId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\rR\x04type\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\rR\x05state\x12\x14\n" +
+	"\x05round\x18\x04 \x01(\rR\x05round\x12!\n" +
+	"\ftotal_rounds\x18\x05 \x01(\rR\vtotalRounds\x128\n" +
+	"\fparticipants\x18\x06 \x03(\v2\x14.ParticipantProgressR\fparticipants\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\b \x01(\fR\tpublicKey\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\x03R\tupdatedAt\"g\n" +
+	"\x13ParticipantProgress\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\rR\x05state\x12\x14\n" +
+	"\x05round\x18\x03 \x01(\rR\x05round\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error2z\n" +
 	"\bUserTask\x12%\n" +
-	"\x04Sign\x12\f.SignRequest\x1a\r.SignResponse\"\x00B9Z7github.com/TEENet-io/teenet-sdk/go/proto/key_managementb\x06proto3"
+	"\x04Sign\x12\f.SignRequest\x1a\r.SignResponse\"\x00\x12G\n" +
+	"\x11WatchKeyOperation\x12\x19.WatchKeyOperationRequest\x1a\x13.KeyOperationStatus\"\x000\x01B9Z7github.com/TEENet-io/teenet-sdk/go/proto/key_managementb\x06proto3"
 
 var (
 	file_user_task_proto_rawDescOnce sync.Once
@@ -187,19 +554,27 @@ func file_user_task_proto_rawDescGZIP() []byte {
 	return file_user_task_proto_rawDescData
 }
 
-var file_user_task_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_user_task_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_user_task_proto_goTypes = []any{
-	(*SignRequest)(nil),  // 0: SignRequest
-	(*SignResponse)(nil), // 1: SignResponse
+	(*SignRequest)(nil),              // 0: SignRequest
+	(*MuSig2Step)(nil),               // 1: MuSig2Step
+	(*SignResponse)(nil),             // 2: SignResponse
+	(*WatchKeyOperationRequest)(nil), // 3: WatchKeyOperationRequest
+	(*KeyOperationStatus)(nil),       // 4: KeyOperationStatus
+	(*ParticipantProgress)(nil),      // 5: ParticipantProgress
 }
 var file_user_task_proto_depIdxs = []int32{
-	0, // 0: UserTask.Sign:input_type -> SignRequest
-	1, // 1: UserTask.Sign:output_type -> SignResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: SignRequest.musig2:type_name -> MuSig2Step
+	5, // 1: KeyOperationStatus.participants:type_name -> ParticipantProgress
+	0, // 2: UserTask.Sign:input_type -> SignRequest
+	3, // 3: UserTask.WatchKeyOperation:input_type -> WatchKeyOperationRequest
+	2, // 4: UserTask.Sign:output_type -> SignResponse
+	4, // 5: UserTask.WatchKeyOperation:output_type -> KeyOperationStatus
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_user_task_proto_init() }
@@ -213,7 +588,7 @@ func file_user_task_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_task_proto_rawDesc), len(file_user_task_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserTask_Sign_FullMethodName = "/UserTask/Sign"
+	UserTask_Sign_FullMethodName              = "/UserTask/Sign"
+	UserTask_WatchKeyOperation_FullMethodName = "/UserTask/WatchKeyOperation"
 )
 
 // UserTaskClient is the client API for UserTask service.
@@ -29,6 +30,7 @@ const (
 // UserTask service for user operations in the key management system.
 type UserTaskClient interface {
 	Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error)
+	WatchKeyOperation(ctx context.Context, in *WatchKeyOperationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[KeyOperationStatus], error)
 }
 
 type userTaskClient struct {
@@ -49,6 +51,25 @@ func (c *userTaskClient) Sign(ctx context.Context, in *SignRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *userTaskClient) WatchKeyOperation(ctx context.Context, in *WatchKeyOperationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[KeyOperationStatus], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserTask_ServiceDesc.Streams[0], UserTask_WatchKeyOperation_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchKeyOperationRequest, KeyOperationStatus]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserTask_WatchKeyOperationClient = grpc.ServerStreamingClient[KeyOperationStatus]
+
 // UserTaskServer is the server API for UserTask service.
 // All implementations must embed UnimplementedUserTaskServer
 // for forward compatibility.
@@ -56,6 +77,7 @@ func (c *userTaskClient) Sign(ctx context.Context, in *SignRequest, opts ...grpc
 // UserTask service for user operations in the key management system.
 type UserTaskServer interface {
 	Sign(context.Context, *SignRequest) (*SignResponse, error)
+	WatchKeyOperation(*WatchKeyOperationRequest, grpc.ServerStreamingServer[KeyOperationStatus]) error
 	mustEmbedUnimplementedUserTaskServer()
 }
 
@@ -69,6 +91,9 @@ type UnimplementedUserTaskServer struct{}
 func (UnimplementedUserTaskServer) Sign(context.Context, *SignRequest) (*SignResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Sign not implemented")
 }
+func (UnimplementedUserTaskServer) WatchKeyOperation(*WatchKeyOperationRequest, grpc.ServerStreamingServer[KeyOperationStatus]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchKeyOperation not implemented")
+}
 func (UnimplementedUserTaskServer) mustEmbedUnimplementedUserTaskServer() {}
 func (UnimplementedUserTaskServer) testEmbeddedByValue()                  {}
 
@@ -108,6 +133,17 @@ func _UserTask_Sign_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserTask_WatchKeyOperation_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchKeyOperationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserTaskServer).WatchKeyOperation(m, &grpc.GenericServerStream[WatchKeyOperationRequest, KeyOperationStatus]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserTask_WatchKeyOperationServer = grpc.ServerStreamingServer[KeyOperationStatus]
+
 // UserTask_ServiceDesc is the grpc.ServiceDesc for UserTask service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -120,6 +156,12 @@ var UserTask_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _UserTask_Sign_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchKeyOperation",
+			Handler:       _UserTask_WatchKeyOperation_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "user_task.proto",
 }
@@ -175,7 +175,9 @@ type Peer struct {
 	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	RpcAddress    string                 `protobuf:"bytes,2,opt,name=rpc_address,json=rpcAddress,proto3" json:"rpc_address,omitempty"`
 	Cert          []byte                 `protobuf:"bytes,3,opt,name=cert,proto3" json:"cert,omitempty"`
-	Type          uint32                 `protobuf:"varint,4,opt,name=type,proto3" json:"type,omitempty"` // 1: TEE-DAO node, 2: mesh node
+	Type          uint32                 `protobuf:"varint,4,opt,name=type,proto3" json:"type,omitempty"`    // 1: TEE-DAO node, 2: mesh node
+	Region        string                 `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"` // Deployment region label, empty if unknown
+	Zone          string                 `protobuf:"bytes,6,opt,name=zone,proto3" json:"zone,omitempty"`     // Deployment zone label within the region, empty if unknown
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -238,6 +240,20 @@ func (x *Peer) GetType() uint32 {
 	return 0
 }
 
+func (x *Peer) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Peer) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
 type GetPeerNodeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Peers         []*Peer                `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
@@ -295,13 +311,15 @@ const file_proto_node_management_node_management_proto_rawDesc = "" +
 	"\x04cert\x18\x03 \x01(\fR\x04cert\x12\x10\n" +
 	"\x03key\x18\x04 \x01(\fR\x03key\"1\n" +
 	"\x12GetPeerNodeRequest\x12\x1b\n" +
-	"\tnode_type\x18\x01 \x01(\tR\bnodeType\"_\n" +
+	"\tnode_type\x18\x01 \x01(\tR\bnodeType\"\x8b\x01\n" +
 	"\x04Peer\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1f\n" +
 	"\vrpc_address\x18\x02 \x01(\tR\n" +
 	"rpcAddress\x12\x12\n" +
 	"\x04cert\x18\x03 \x01(\fR\x04cert\x12\x12\n" +
-	"\x04type\x18\x04 \x01(\rR\x04type\"F\n" +
+	"\x04type\x18\x04 \x01(\rR\x04type\x12\x16\n" +
+	"\x06region\x18\x05 \x01(\tR\x06region\x12\x12\n" +
+	"\x04zone\x18\x06 \x01(\tR\x04zone\"F\n" +
 	"\x13GetPeerNodeResponse\x12/\n" +
 	"\x05peers\x18\x01 \x03(\v2\x19.tee_node_management.PeerR\x05peers2\xd3\x01\n" +
 	"\rCLIRPCService\x12`\n" +
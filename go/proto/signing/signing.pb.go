@@ -0,0 +1,901 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v5.29.3
+// source: signing.proto
+
+package signing
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SignRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AppId           string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`                                                                  // App ID whose key signs the message
+	Message         []byte                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                                                                           // Message to sign
+	EnableVoting    bool                   `protobuf:"varint,3,opt,name=enable_voting,json=enableVoting,proto3" json:"enable_voting,omitempty"`                                            // Run a voting round before signing
+	LocalApproval   bool                   `protobuf:"varint,4,opt,name=local_approval,json=localApproval,proto3" json:"local_approval,omitempty"`                                         // Local vote when voting is enabled
+	VoteRequestData []byte                 `protobuf:"bytes,5,opt,name=vote_request_data,json=voteRequestData,proto3" json:"vote_request_data,omitempty"`                                  // Request body forwarded to voters
+	Headers         map[string]string      `protobuf:"bytes,6,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Headers forwarded to voters
+	TimeoutMs       uint32                 `protobuf:"varint,7,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`                                                     // Request timeout, 0 uses the server default
+	Ed25519Mode     uint32                 `protobuf:"varint,8,opt,name=ed25519_mode,json=ed25519Mode,proto3" json:"ed25519_mode,omitempty"`                                               // ED25519 variant (0 pure, 1 ph, 2 ctx)
+	Ed25519Context  []byte                 `protobuf:"bytes,9,opt,name=ed25519_context,json=ed25519Context,proto3" json:"ed25519_context,omitempty"`                                       // Context for Ed25519ph/Ed25519ctx
+	IdempotencyKey  string                 `protobuf:"bytes,10,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`                                      // Returns the earlier result for a repeated key
+	Priority        int32                  `protobuf:"varint,11,opt,name=priority,proto3" json:"priority,omitempty"`                                                                       // Sign queue priority (-1 batch, 0 normal, 1 interactive)
+	DeadlineUnixMs  int64                  `protobuf:"varint,12,opt,name=deadline_unix_ms,json=deadlineUnixMs,proto3" json:"deadline_unix_ms,omitempty"`                                   // Absolute deadline, takes precedence over timeout_ms
+	QueueExpiryMs   uint32                 `protobuf:"varint,13,opt,name=queue_expiry_ms,json=queueExpiryMs,proto3" json:"queue_expiry_ms,omitempty"`                                      // How long the request may wait in the offline queue
+	BypassDedup     bool                   `protobuf:"varint,14,opt,name=bypass_dedup,json=bypassDedup,proto3" json:"bypass_dedup,omitempty"`                                              // Sign afresh even if an identical request was signed
+	Principal       *Principal             `protobuf:"bytes,15,opt,name=principal,proto3" json:"principal,omitempty"`                                                                      // Who asked for the signature and why
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SignRequest) Reset() {
+	*x = SignRequest{}
+	mi := &file_signing_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignRequest) ProtoMessage() {}
+
+func (x *SignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_signing_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignRequest.ProtoReflect.Descriptor instead.
+func (*SignRequest) Descriptor() ([]byte, []int) {
+	return file_signing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SignRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *SignRequest) GetMessage() []byte {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *SignRequest) GetEnableVoting() bool {
+	if x != nil {
+		return x.EnableVoting
+	}
+	return false
+}
+
+func (x *SignRequest) GetLocalApproval() bool {
+	if x != nil {
+		return x.LocalApproval
+	}
+	return false
+}
+
+func (x *SignRequest) GetVoteRequestData() []byte {
+	if x != nil {
+		return x.VoteRequestData
+	}
+	return nil
+}
+
+func (x *SignRequest) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *SignRequest) GetTimeoutMs() uint32 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+func (x *SignRequest) GetEd25519Mode() uint32 {
+	if x != nil {
+		return x.Ed25519Mode
+	}
+	return 0
+}
+
+func (x *SignRequest) GetEd25519Context() []byte {
+	if x != nil {
+		return x.Ed25519Context
+	}
+	return nil
+}
+
+func (x *SignRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *SignRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *SignRequest) GetDeadlineUnixMs() int64 {
+	if x != nil {
+		return x.DeadlineUnixMs
+	}
+	return 0
+}
+
+func (x *SignRequest) GetQueueExpiryMs() uint32 {
+	if x != nil {
+		return x.QueueExpiryMs
+	}
+	return 0
+}
+
+func (x *SignRequest) GetBypassDedup() bool {
+	if x != nil {
+		return x.BypassDedup
+	}
+	return false
+}
+
+func (x *SignRequest) GetPrincipal() *Principal {
+	if x != nil {
+		return x.Principal
+	}
+	return nil
+}
+
+// Principal identifies the caller a signature is made for
+type Principal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                       // User or service identity
+	Justification string                 `protobuf:"bytes,2,opt,name=justification,proto3" json:"justification,omitempty"` // Why the signature is needed, e.g. a ticket reference
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Principal) Reset() {
+	*x = Principal{}
+	mi := &file_signing_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Principal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Principal) ProtoMessage() {}
+
+func (x *Principal) ProtoReflect() protoreflect.Message {
+	mi := &file_signing_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Principal.ProtoReflect.Descriptor instead.
+func (*Principal) Descriptor() ([]byte, []int) {
+	return file_signing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Principal) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Principal) GetJustification() string {
+	if x != nil {
+		return x.Justification
+	}
+	return ""
+}
+
+type VoteDetail struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ClientId        string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Success         bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Response        bool                   `protobuf:"varint,3,opt,name=response,proto3" json:"response,omitempty"`
+	Error           string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	DelegationChain []string               `protobuf:"bytes,5,rep,name=delegation_chain,json=delegationChain,proto3" json:"delegation_chain,omitempty"` // client_id and the apps its vote was delegated to
+	Reason          string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`                                          // The voter's reason
+	Code            string                 `protobuf:"bytes,7,opt,name=code,proto3" json:"code,omitempty"`                                              // Rejection code, e.g. "policy_violation" or "timeout"
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *VoteDetail) Reset() {
+	*x = VoteDetail{}
+	mi := &file_signing_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoteDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoteDetail) ProtoMessage() {}
+
+func (x *VoteDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_signing_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoteDetail.ProtoReflect.Descriptor instead.
+func (*VoteDetail) Descriptor() ([]byte, []int) {
+	return file_signing_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *VoteDetail) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *VoteDetail) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *VoteDetail) GetResponse() bool {
+	if x != nil {
+		return x.Response
+	}
+	return false
+}
+
+func (x *VoteDetail) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *VoteDetail) GetDelegationChain() []string {
+	if x != nil {
+		return x.DelegationChain
+	}
+	return nil
+}
+
+func (x *VoteDetail) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *VoteDetail) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type VotingInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TotalTargets    int32                  `protobuf:"varint,1,opt,name=total_targets,json=totalTargets,proto3" json:"total_targets,omitempty"`
+	SuccessfulVotes int32                  `protobuf:"varint,2,opt,name=successful_votes,json=successfulVotes,proto3" json:"successful_votes,omitempty"`
+	RequiredVotes   int32                  `protobuf:"varint,3,opt,name=required_votes,json=requiredVotes,proto3" json:"required_votes,omitempty"`
+	VoteDetails     []*VoteDetail          `protobuf:"bytes,4,rep,name=vote_details,json=voteDetails,proto3" json:"vote_details,omitempty"`
+	MissingGroups   []string               `protobuf:"bytes,5,rep,name=missing_groups,json=missingGroups,proto3" json:"missing_groups,omitempty"`    // Voting groups without an approval
+	MessageClass    string                 `protobuf:"bytes,6,opt,name=message_class,json=messageClass,proto3" json:"message_class,omitempty"`       // Message class that set required_votes
+	CommitFailures  []string               `protobuf:"bytes,7,rep,name=commit_failures,json=commitFailures,proto3" json:"commit_failures,omitempty"` // Participants the commit notification didn't reach
+	RoundId         string                 `protobuf:"bytes,8,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`                      // Identifies the round to cancel
+	Cancelled       bool                   `protobuf:"varint,9,opt,name=cancelled,proto3" json:"cancelled,omitempty"`                                // The round was cancelled before it was signed
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *VotingInfo) Reset() {
+	*x = VotingInfo{}
+	mi := &file_signing_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VotingInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VotingInfo) ProtoMessage() {}
+
+func (x *VotingInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_signing_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VotingInfo.ProtoReflect.Descriptor instead.
+func (*VotingInfo) Descriptor() ([]byte, []int) {
+	return file_signing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *VotingInfo) GetTotalTargets() int32 {
+	if x != nil {
+		return x.TotalTargets
+	}
+	return 0
+}
+
+func (x *VotingInfo) GetSuccessfulVotes() int32 {
+	if x != nil {
+		return x.SuccessfulVotes
+	}
+	return 0
+}
+
+func (x *VotingInfo) GetRequiredVotes() int32 {
+	if x != nil {
+		return x.RequiredVotes
+	}
+	return 0
+}
+
+func (x *VotingInfo) GetVoteDetails() []*VoteDetail {
+	if x != nil {
+		return x.VoteDetails
+	}
+	return nil
+}
+
+func (x *VotingInfo) GetMissingGroups() []string {
+	if x != nil {
+		return x.MissingGroups
+	}
+	return nil
+}
+
+func (x *VotingInfo) GetMessageClass() string {
+	if x != nil {
+		return x.MessageClass
+	}
+	return ""
+}
+
+func (x *VotingInfo) GetCommitFailures() []string {
+	if x != nil {
+		return x.CommitFailures
+	}
+	return nil
+}
+
+func (x *VotingInfo) GetRoundId() string {
+	if x != nil {
+		return x.RoundId
+	}
+	return ""
+}
+
+func (x *VotingInfo) GetCancelled() bool {
+	if x != nil {
+		return x.Cancelled
+	}
+	return false
+}
+
+type SignResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Success          bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Signature        []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	Error            string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	VotingInfo       *VotingInfo            `protobuf:"bytes,4,opt,name=voting_info,json=votingInfo,proto3" json:"voting_info,omitempty"`                    // Present when voting was performed
+	IdempotentReplay bool                   `protobuf:"varint,5,opt,name=idempotent_replay,json=idempotentReplay,proto3" json:"idempotent_replay,omitempty"` // Result was returned for a repeated idempotency key
+	QueuedId         string                 `protobuf:"bytes,6,opt,name=queued_id,json=queuedId,proto3" json:"queued_id,omitempty"`                          // Set when the request was saved to the offline queue
+	Cached           bool                   `protobuf:"varint,7,opt,name=cached,proto3" json:"cached,omitempty"`                                             // The signature came from the dedup cache
+	SharedRound      bool                   `protobuf:"varint,8,opt,name=shared_round,json=sharedRound,proto3" json:"shared_round,omitempty"`                // Another replica ran the voting round
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SignResponse) Reset() {
+	*x = SignResponse{}
+	mi := &file_signing_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignResponse) ProtoMessage() {}
+
+func (x *SignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_signing_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignResponse.ProtoReflect.Descriptor instead.
+func (*SignResponse) Descriptor() ([]byte, []int) {
+	return file_signing_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SignResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SignResponse) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *SignResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SignResponse) GetVotingInfo() *VotingInfo {
+	if x != nil {
+		return x.VotingInfo
+	}
+	return nil
+}
+
+func (x *SignResponse) GetIdempotentReplay() bool {
+	if x != nil {
+		return x.IdempotentReplay
+	}
+	return false
+}
+
+func (x *SignResponse) GetQueuedId() string {
+	if x != nil {
+		return x.QueuedId
+	}
+	return ""
+}
+
+func (x *SignResponse) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+func (x *SignResponse) GetSharedRound() bool {
+	if x != nil {
+		return x.SharedRound
+	}
+	return false
+}
+
+type VerifyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Message       []byte                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Signature     []byte                 `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyRequest) Reset() {
+	*x = VerifyRequest{}
+	mi := &file_signing_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyRequest) ProtoMessage() {}
+
+func (x *VerifyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_signing_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyRequest.ProtoReflect.Descriptor instead.
+func (*VerifyRequest) Descriptor() ([]byte, []int) {
+	return file_signing_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *VerifyRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *VerifyRequest) GetMessage() []byte {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *VerifyRequest) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type VerifyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyResponse) Reset() {
+	*x = VerifyResponse{}
+	mi := &file_signing_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyResponse) ProtoMessage() {}
+
+func (x *VerifyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_signing_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyResponse.ProtoReflect.Descriptor instead.
+func (*VerifyResponse) Descriptor() ([]byte, []int) {
+	return file_signing_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *VerifyResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *VerifyResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetPublicKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicKeyRequest) Reset() {
+	*x = GetPublicKeyRequest{}
+	mi := &file_signing_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicKeyRequest) ProtoMessage() {}
+
+func (x *GetPublicKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_signing_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicKeyRequest.ProtoReflect.Descriptor instead.
+func (*GetPublicKeyRequest) Descriptor() ([]byte, []int) {
+	return file_signing_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetPublicKeyRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+type GetPublicKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PublicKey     []byte                 `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Protocol      string                 `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`                 // e.g. "ecdsa"
+	Curve         string                 `protobuf:"bytes,3,opt,name=curve,proto3" json:"curve,omitempty"`                       // e.g. "secp256k1"
+	KeyUsage      []string               `protobuf:"bytes,4,rep,name=key_usage,json=keyUsage,proto3" json:"key_usage,omitempty"` // e.g. ["sign"]; unrestricted if empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicKeyResponse) Reset() {
+	*x = GetPublicKeyResponse{}
+	mi := &file_signing_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicKeyResponse) ProtoMessage() {}
+
+func (x *GetPublicKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_signing_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicKeyResponse.ProtoReflect.Descriptor instead.
+func (*GetPublicKeyResponse) Descriptor() ([]byte, []int) {
+	return file_signing_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetPublicKeyResponse) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *GetPublicKeyResponse) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *GetPublicKeyResponse) GetCurve() string {
+	if x != nil {
+		return x.Curve
+	}
+	return ""
+}
+
+func (x *GetPublicKeyResponse) GetKeyUsage() []string {
+	if x != nil {
+		return x.KeyUsage
+	}
+	return nil
+}
+
+var File_signing_proto protoreflect.FileDescriptor
+
+const file_signing_proto_rawDesc = "" +
+	"\n" +
+	"\rsigning.proto\x12\x0eteenet.signing\"\x94\x05\n" +
+	"\vSignRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\fR\amessage\x12#\n" +
+	"\renable_voting\x18\x03 \x01(\bR\fenableVoting\x12%\n" +
+	"\x0elocal_approval\x18\x04 \x01(\bR\rlocalApproval\x12*\n" +
+	"\x11vote_request_data\x18\x05 \x01(\fR\x0fvoteRequestData\x12B\n" +
+	"\aheaders\x18\x06 \x03(\v2(.teenet.signing.SignRequest.HeadersEntryR\aheaders\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\a \x01(\rR\ttimeoutMs\x12!\n" +
+	"\fed25519_mode\x18\b \x01(\rR\ved25519Mode\x12'\n" +
+	"\x0fed25519_context\x18\t \x01(\fR\x0eed25519Context\x12'\n" +
+	"\x0fidempotency_key\x18\n" +
+	" \x01(\tR\x0eidempotencyKey\x12\x1a\n" +
+	"\bpriority\x18\v \x01(\x05R\bpriority\x12(\n" +
+	"\x10deadline_unix_ms\x18\f \x01(\x03R\x0edeadlineUnixMs\x12&\n" +
+	"\x0fqueue_expiry_ms\x18\r \x01(\rR\rqueueExpiryMs\x12!\n" +
+	"\fbypass_dedup\x18\x0e \x01(\bR\vbypassDedup\x127\n" +
+	"\tprincipal\x18\x0f \x01(\v2\x19.teenet.signing.PrincipalR\tprincipal\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"A\n" +
+	"\tPrincipal\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12$\n" +
+	"\rjustification\x18\x02 \x01(\tR\rjustification\"\xcc\x01\n" +
+	"\n" +
+	"VoteDetail\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x1a\n" +
+	"\bresponse\x18\x03 \x01(\bR\bresponse\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12)\n" +
+	"\x10delegation_chain\x18\x05 \x03(\tR\x0fdelegationChain\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x12\n" +
+	"\x04code\x18\a \x01(\tR\x04code\"\xf0\x02\n" +
+	"\n" +
+	"VotingInfo\x12#\n" +
+	"\rtotal_targets\x18\x01 \x01(\x05R\ftotalTargets\x12)\n" +
+	"\x10successful_votes\x18\x02 \x01(\x05R\x0fsuccessfulVotes\x12%\n" +
+	"\x0erequired_votes\x18\x03 \x01(\x05R\rrequiredVotes\x12=\n" +
+	"\fvote_details\x18\x04 \x03(\v2\x1a.teenet.signing.VoteDetailR\vvoteDetails\x12%\n" +
+	"\x0emissing_groups\x18\x05 \x03(\tR\rmissingGroups\x12#\n" +
+	"\rmessage_class\x18\x06 \x01(\tR\fmessageClass\x12'\n" +
+	"\x0fcommit_failures\x18\a \x03(\tR\x0ecommitFailures\x12\x19\n" +
+	"\bround_id\x18\b \x01(\tR\aroundId\x12\x1c\n" +
+	"\tcancelled\x18\t \x01(\bR\tcancelled\"\x9e\x02\n" +
+	"\fSignResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\fR\tsignature\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12;\n" +
+	"\vvoting_info\x18\x04 \x01(\v2\x1a.teenet.signing.VotingInfoR\n" +
+	"votingInfo\x12+\n" +
+	"\x11idempotent_replay\x18\x05 \x01(\bR\x10idempotentReplay\x12\x1b\n" +
+	"\tqueued_id\x18\x06 \x01(\tR\bqueuedId\x12\x16\n" +
+	"\x06cached\x18\a \x01(\bR\x06cached\x12!\n" +
+	"\fshared_round\x18\b \x01(\bR\vsharedRound\"^\n" +
+	"\rVerifyRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\fR\amessage\x12\x1c\n" +
+	"\tsignature\x18\x03 \x01(\fR\tsignature\"<\n" +
+	"\x0eVerifyResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\",\n" +
+	"\x13GetPublicKeyRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\"\x84\x01\n" +
+	"\x14GetPublicKeyResponse\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x01 \x01(\fR\tpublicKey\x12\x1a\n" +
+	"\bprotocol\x18\x02 \x01(\tR\bprotocol\x12\x14\n" +
+	"\x05curve\x18\x03 \x01(\tR\x05curve\x12\x1b\n" +
+	"\tkey_usage\x18\x04 \x03(\tR\bkeyUsage2\xfd\x01\n" +
+	"\x0eSigningService\x12C\n" +
+	"\x04Sign\x12\x1b.teenet.signing.SignRequest\x1a\x1c.teenet.signing.SignResponse\"\x00\x12I\n" +
+	"\x06Verify\x12\x1d.teenet.signing.VerifyRequest\x1a\x1e.teenet.signing.VerifyResponse\"\x00\x12[\n" +
+	"\fGetPublicKey\x12#.teenet.signing.GetPublicKeyRequest\x1a$.teenet.signing.GetPublicKeyResponse\"\x00B2Z0github.com/TEENet-io/teenet-sdk/go/proto/signingb\x06proto3"
+
+var (
+	file_signing_proto_rawDescOnce sync.Once
+	file_signing_proto_rawDescData []byte
+)
+
+func file_signing_proto_rawDescGZIP() []byte {
+	file_signing_proto_rawDescOnce.Do(func() {
+		file_signing_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_signing_proto_rawDesc), len(file_signing_proto_rawDesc)))
+	})
+	return file_signing_proto_rawDescData
+}
+
+var file_signing_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_signing_proto_goTypes = []any{
+	(*SignRequest)(nil),          // 0: teenet.signing.SignRequest
+	(*Principal)(nil),            // 1: teenet.signing.Principal
+	(*VoteDetail)(nil),           // 2: teenet.signing.VoteDetail
+	(*VotingInfo)(nil),           // 3: teenet.signing.VotingInfo
+	(*SignResponse)(nil),         // 4: teenet.signing.SignResponse
+	(*VerifyRequest)(nil),        // 5: teenet.signing.VerifyRequest
+	(*VerifyResponse)(nil),       // 6: teenet.signing.VerifyResponse
+	(*GetPublicKeyRequest)(nil),  // 7: teenet.signing.GetPublicKeyRequest
+	(*GetPublicKeyResponse)(nil), // 8: teenet.signing.GetPublicKeyResponse
+	nil,                          // 9: teenet.signing.SignRequest.HeadersEntry
+}
+var file_signing_proto_depIdxs = []int32{
+	9, // 0: teenet.signing.SignRequest.headers:type_name -> teenet.signing.SignRequest.HeadersEntry
+	1, // 1: teenet.signing.SignRequest.principal:type_name -> teenet.signing.Principal
+	2, // 2: teenet.signing.VotingInfo.vote_details:type_name -> teenet.signing.VoteDetail
+	3, // 3: teenet.signing.SignResponse.voting_info:type_name -> teenet.signing.VotingInfo
+	0, // 4: teenet.signing.SigningService.Sign:input_type -> teenet.signing.SignRequest
+	5, // 5: teenet.signing.SigningService.Verify:input_type -> teenet.signing.VerifyRequest
+	7, // 6: teenet.signing.SigningService.GetPublicKey:input_type -> teenet.signing.GetPublicKeyRequest
+	4, // 7: teenet.signing.SigningService.Sign:output_type -> teenet.signing.SignResponse
+	6, // 8: teenet.signing.SigningService.Verify:output_type -> teenet.signing.VerifyResponse
+	8, // 9: teenet.signing.SigningService.GetPublicKey:output_type -> teenet.signing.GetPublicKeyResponse
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_signing_proto_init() }
+func file_signing_proto_init() {
+	if File_signing_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_signing_proto_rawDesc), len(file_signing_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_signing_proto_goTypes,
+		DependencyIndexes: file_signing_proto_depIdxs,
+		MessageInfos:      file_signing_proto_msgTypes,
+	}.Build()
+	File_signing_proto = out.File
+	file_signing_proto_goTypes = nil
+	file_signing_proto_depIdxs = nil
+}
@@ -30,6 +30,9 @@ type VotingRequest struct {
 	TotalParticipants uint32                 `protobuf:"varint,4,opt,name=total_participants,json=totalParticipants,proto3" json:"total_participants,omitempty"`  // n in m-of-n
 	AppId             string                 `protobuf:"bytes,5,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`                                       // Application ID
 	TargetContainerIp string                 `protobuf:"bytes,6,opt,name=target_container_ip,json=targetContainerIp,proto3" json:"target_container_ip,omitempty"` // Target container IP for this specific request
+	SignerAppId       string                 `protobuf:"bytes,7,opt,name=signer_app_id,json=signerAppId,proto3" json:"signer_app_id,omitempty"`                   // App whose voting round this is
+	RequestData       []byte                 `protobuf:"bytes,8,opt,name=request_data,json=requestData,proto3" json:"request_data,omitempty"`                     // Vote request body as sent over HTTP (JSON), incl. round notifications
+	Principal         *Principal             `protobuf:"bytes,9,opt,name=principal,proto3" json:"principal,omitempty"`                                            // Who asked for the signature, if the requester said
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -106,18 +109,93 @@ func (x *VotingRequest) GetTargetContainerIp() string {
 	return ""
 }
 
+func (x *VotingRequest) GetSignerAppId() string {
+	if x != nil {
+		return x.SignerAppId
+	}
+	return ""
+}
+
+func (x *VotingRequest) GetRequestData() []byte {
+	if x != nil {
+		return x.RequestData
+	}
+	return nil
+}
+
+func (x *VotingRequest) GetPrincipal() *Principal {
+	if x != nil {
+		return x.Principal
+	}
+	return nil
+}
+
+type Principal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                       // User or service identity
+	Justification string                 `protobuf:"bytes,2,opt,name=justification,proto3" json:"justification,omitempty"` // Why the signature is needed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Principal) Reset() {
+	*x = Principal{}
+	mi := &file_voting_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Principal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Principal) ProtoMessage() {}
+
+func (x *Principal) ProtoReflect() protoreflect.Message {
+	mi := &file_voting_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Principal.ProtoReflect.Descriptor instead.
+func (*Principal) Descriptor() ([]byte, []int) {
+	return file_voting_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Principal) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Principal) GetJustification() string {
+	if x != nil {
+		return x.Justification
+	}
+	return ""
+}
+
 type VotingResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
 	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	RejectionCode string                 `protobuf:"bytes,4,opt,name=rejection_code,json=rejectionCode,proto3" json:"rejection_code,omitempty"` // Machine-readable reason when success is false, e.g. "policy_violation"
+	Musig2        []byte                 `protobuf:"bytes,5,opt,name=musig2,proto3" json:"musig2,omitempty"`                                    // Public nonce or partial signature answering a MuSig2 request
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *VotingResponse) Reset() {
 	*x = VotingResponse{}
-	mi := &file_voting_proto_msgTypes[1]
+	mi := &file_voting_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -129,7 +207,7 @@ func (x *VotingResponse) String() string {
 func (*VotingResponse) ProtoMessage() {}
 
 func (x *VotingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_voting_proto_msgTypes[1]
+	mi := &file_voting_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -142,7 +220,7 @@ func (x *VotingResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VotingResponse.ProtoReflect.Descriptor instead.
 func (*VotingResponse) Descriptor() ([]byte, []int) {
-	return file_voting_proto_rawDescGZIP(), []int{1}
+	return file_voting_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *VotingResponse) GetSuccess() bool {
@@ -166,22 +244,45 @@ func (x *VotingResponse) GetError() string {
 	return ""
 }
 
+func (x *VotingResponse) GetRejectionCode() string {
+	if x != nil {
+		return x.RejectionCode
+	}
+	return ""
+}
+
+func (x *VotingResponse) GetMusig2() []byte {
+	if x != nil {
+		return x.Musig2
+	}
+	return nil
+}
+
 var File_voting_proto protoreflect.FileDescriptor
 
 const file_voting_proto_rawDesc = "" +
 	"\n" +
-	"\fvoting.proto\"\xdf\x01\n" +
+	"\fvoting.proto\"\xd0\x02\n" +
 	"\rVotingRequest\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\fR\amessage\x12%\n" +
 	"\x0erequired_votes\x18\x03 \x01(\rR\rrequiredVotes\x12-\n" +
 	"\x12total_participants\x18\x04 \x01(\rR\x11totalParticipants\x12\x15\n" +
 	"\x06app_id\x18\x05 \x01(\tR\x05appId\x12.\n" +
-	"\x13target_container_ip\x18\x06 \x01(\tR\x11targetContainerIp\"Y\n" +
+	"\x13target_container_ip\x18\x06 \x01(\tR\x11targetContainerIp\x12\"\n" +
+	"\rsigner_app_id\x18\a \x01(\tR\vsignerAppId\x12!\n" +
+	"\frequest_data\x18\b \x01(\fR\vrequestData\x12(\n" +
+	"\tprincipal\x18\t \x01(\v2\n" +
+	".PrincipalR\tprincipal\"A\n" +
+	"\tPrincipal\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12$\n" +
+	"\rjustification\x18\x02 \x01(\tR\rjustification\"\x98\x01\n" +
 	"\x0eVotingResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x17\n" +
 	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error2<\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12%\n" +
+	"\x0erejection_code\x18\x04 \x01(\tR\rrejectionCode\x12\x16\n" +
+	"\x06musig2\x18\x05 \x01(\fR\x06musig22<\n" +
 	"\rVotingService\x12+\n" +
 	"\x06Voting\x12\x0e.VotingRequest\x1a\x0f.VotingResponse\"\x00B1Z/github.com/TEENet-io/teenet-sdk/go/proto/votingb\x06proto3"
 
@@ -197,19 +298,21 @@ func file_voting_proto_rawDescGZIP() []byte {
 	return file_voting_proto_rawDescData
 }
 
-var file_voting_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_voting_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_voting_proto_goTypes = []any{
 	(*VotingRequest)(nil),  // 0: VotingRequest
-	(*VotingResponse)(nil), // 1: VotingResponse
+	(*Principal)(nil),      // 1: Principal
+	(*VotingResponse)(nil), // 2: VotingResponse
 }
 var file_voting_proto_depIdxs = []int32{
-	0, // 0: VotingService.Voting:input_type -> VotingRequest
-	1, // 1: VotingService.Voting:output_type -> VotingResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: VotingRequest.principal:type_name -> Principal
+	0, // 1: VotingService.Voting:input_type -> VotingRequest
+	2, // 2: VotingService.Voting:output_type -> VotingResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_voting_proto_init() }
@@ -223,7 +326,7 @@ func file_voting_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_voting_proto_rawDesc), len(file_voting_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,145 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/rounds"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/task"
+)
+
+// Replica round dedup defaults
+const (
+	DefaultRoundLeaseTTL     = 2 * time.Minute
+	DefaultRoundOutcomeTTL   = 30 * time.Second
+	DefaultRoundPollInterval = 500 * time.Millisecond
+)
+
+// ReplicaDedupConfig configures voting round dedup across app replicas, see EnableReplicaDedup
+type ReplicaDedupConfig struct {
+	Coordinator  rounds.Coordinator // Shared by all replicas of the app
+	ReplicaID    string             // Identifies this replica; a random ID by default
+	LeaseTTL     time.Duration      // How long a replica leads a round before others may take over
+	OutcomeTTL   time.Duration      // How long a finished round's result is handed to late duplicates
+	PollInterval time.Duration      // How often waiting replicas check for the result
+}
+
+// EnableReplicaDedup makes replicas of an app that receive the same sign request at the same
+// time run a single voting round between them. Rounds are identified by a hash of the app ID,
+// message and signing options; the replica that claims a round in the coordinator runs it and
+// publishes the result, the others wait for it and return it with SignResult.SharedRound set.
+// If the leading replica fails or disappears, a waiting one takes over once its lease expires.
+// Must be called before Init
+func (c *Client) EnableReplicaDedup(config ReplicaDedupConfig) error {
+	if config.Coordinator == nil {
+		return fmt.Errorf("replica dedup requires a coordinator")
+	}
+	if config.ReplicaID == "" {
+		id, err := rounds.NewID()
+		if err != nil {
+			return fmt.Errorf("failed to generate replica ID: %w", err)
+		}
+		config.ReplicaID = id
+	}
+	if config.LeaseTTL <= 0 {
+		config.LeaseTTL = DefaultRoundLeaseTTL
+	}
+	if config.OutcomeTTL <= 0 {
+		config.OutcomeTTL = DefaultRoundOutcomeTTL
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultRoundPollInterval
+	}
+	c.replicaDedup = &config
+	return nil
+}
+
+// sharedRound runs a voting round once across replicas: it returns the result another replica
+// published for the same request, or leads the round with run and publishes its result
+func (c *Client) sharedRound(ctx context.Context, appID string, message []byte, signOpts *task.SignOptions, run func() (*SignResult, error)) (*SignResult, error) {
+	config := c.replicaDedup
+	var edMode uint32
+	var edContext []byte
+	if signOpts != nil {
+		edMode, edContext = signOpts.ED25519Mode, signOpts.ED25519Context
+	}
+	key := "round:" + requestHash(appID, message, edMode, edContext)
+
+	for {
+		if result, ok := c.publishedRound(key); ok {
+			return result, nil
+		}
+		claimed, err := config.Coordinator.Claim(key, config.ReplicaID, config.LeaseTTL)
+		if err != nil {
+			// Without the coordinator the round runs unshared rather than not at all
+			log.Printf("⚠️  Round coordinator unavailable, running round for %s alone: %v", appID, err)
+			return run()
+		}
+		if claimed {
+			return c.leadRound(key, run)
+		}
+
+		log.Printf("⏳ Another replica is running the voting round for %s, waiting for its result", appID)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for the voting round of another replica: %w", ctx.Err())
+		case <-time.After(config.PollInterval):
+		}
+	}
+}
+
+// leadRound runs a claimed round and shares its result with the other replicas
+// Errors are not shared, so a waiting replica takes the round over instead
+func (c *Client) leadRound(key string, run func() (*SignResult, error)) (*SignResult, error) {
+	config := c.replicaDedup
+	defer func() {
+		if err := config.Coordinator.Release(key, config.ReplicaID); err != nil {
+			log.Printf("⚠️  Failed to release voting round claim: %v", err)
+		}
+	}()
+
+	result, err := run()
+	if err != nil || result == nil {
+		return result, err
+	}
+	outcome, marshalErr := json.Marshal(result)
+	if marshalErr == nil {
+		marshalErr = config.Coordinator.Publish(key, outcome, config.OutcomeTTL)
+	}
+	if marshalErr != nil {
+		log.Printf("⚠️  Failed to share voting round result: %v", marshalErr)
+	}
+	return result, nil
+}
+
+// publishedRound returns the result another replica published for a round
+func (c *Client) publishedRound(key string) (*SignResult, bool) {
+	outcome, ok, err := c.replicaDedup.Coordinator.Outcome(key)
+	if err != nil || !ok {
+		return nil, false
+	}
+	var result SignResult
+	if err := json.Unmarshal(outcome, &result); err != nil {
+		log.Printf("⚠️  Invalid voting round result from another replica: %v", err)
+		return nil, false
+	}
+	result.SharedRound = true
+	log.Printf("🤝 Using voting round result of another replica")
+	return &result, true
+}
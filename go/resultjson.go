@@ -0,0 +1,126 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// SignatureEncoding selects how MarshalSignResult encodes the signature
+type SignatureEncoding string
+
+const (
+	// SignatureEncodingBase64 is standard padded base64, as encoding/json writes byte slices
+	SignatureEncodingBase64 SignatureEncoding = "base64"
+	// SignatureEncodingHex is lowercase hex without a 0x prefix
+	SignatureEncodingHex SignatureEncoding = "hex"
+)
+
+// UnmarshalText accepts "base64" or "hex"
+func (e *SignatureEncoding) UnmarshalText(text []byte) error {
+	switch encoding := SignatureEncoding(text); encoding {
+	case SignatureEncodingBase64, SignatureEncodingHex:
+		*e = encoding
+		return nil
+	default:
+		return fmt.Errorf("unknown signature encoding %q (want base64 or hex)", text)
+	}
+}
+
+// JSONOptions configures MarshalSignResult and MarshalVotingInfo
+type JSONOptions struct {
+	SignatureEncoding SignatureEncoding // Default SignatureEncodingBase64
+}
+
+// MarshalSignResult encodes result as canonical JSON: object keys sorted at every level, no
+// insignificant whitespace and no HTML escaping, so equal results always encode to the same
+// bytes. Field names and omitted empty fields are those of the struct tags. The signature is
+// encoded as options select; use json.Marshal where SignResult must decode again, e.g. to store it
+func MarshalSignResult(result *SignResult, options JSONOptions) ([]byte, error) {
+	if result == nil {
+		return []byte("null"), nil
+	}
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	object, err := jsonObject(result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Signature) > 0 {
+		object["signature"] = options.encodeSignature(result.Signature)
+	}
+	return canonicalJSON(object)
+}
+
+// MarshalVotingInfo encodes info as canonical JSON, see MarshalSignResult
+func MarshalVotingInfo(info *VotingInfo, options JSONOptions) ([]byte, error) {
+	if info == nil {
+		return []byte("null"), nil
+	}
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	object, err := jsonObject(info)
+	if err != nil {
+		return nil, err
+	}
+	return canonicalJSON(object)
+}
+
+// validate rejects unknown signature encodings
+func (o JSONOptions) validate() error {
+	if o.SignatureEncoding == "" {
+		return nil
+	}
+	return new(SignatureEncoding).UnmarshalText([]byte(o.SignatureEncoding))
+}
+
+// encodeSignature encodes a signature as the options select
+func (o JSONOptions) encodeSignature(signature []byte) string {
+	if o.SignatureEncoding == SignatureEncodingHex {
+		return hex.EncodeToString(signature)
+	}
+	return base64.StdEncoding.EncodeToString(signature)
+}
+
+// jsonObject converts v to its generic JSON object form, keeping numbers exact
+func jsonObject(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var object map[string]any
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// canonicalJSON encodes a generic JSON value; encoding/json sorts map keys
+func canonicalJSON(value any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
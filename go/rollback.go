@@ -0,0 +1,147 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"log"
+	"sort"
+	"sync"
+)
+
+// RollbackAction is what a voting round does when the server reports a weaker voting configuration
+type RollbackAction int
+
+// Rollback actions
+const (
+	RollbackReject RollbackAction = iota // Refuse the voting round (default)
+	RollbackWarn                         // Report through OnRollback and continue with the new configuration
+)
+
+// RollbackConfig configures voting configuration anti-rollback protection, see EnableRollbackProtection
+type RollbackConfig struct {
+	Action     RollbackAction
+	OnRollback func(*VotingConfigRollbackError) // Called for every weaker configuration seen
+}
+
+// votingConfigGuard remembers the last accepted voting configuration per app ID
+type votingConfigGuard struct {
+	config RollbackConfig
+
+	mu   sync.Mutex
+	seen map[string]VotingConfig
+}
+
+// EnableRollbackProtection remembers the voting targets and required votes last seen for each
+// app ID and checks every voting round against them. A configuration is weaker when fewer of the
+// previously known targets need to approve, i.e. the required votes were lowered or new targets
+// were added without raising them as much, or when a voting group was dropped or gained members,
+// which a compromised App node could do to push a message through. Legitimate changes must be accepted with AcceptVotingConfig.
+// Must be called before Init
+func (c *Client) EnableRollbackProtection(config RollbackConfig) {
+	c.rollbackGuard = &votingConfigGuard{config: config, seen: make(map[string]VotingConfig)}
+}
+
+// AcceptVotingConfig forgets the remembered voting configuration of appID, so the next voting
+// round accepts whatever the server reports. Use it after an intended quorum change
+func (c *Client) AcceptVotingConfig(appID string) {
+	if c.rollbackGuard == nil {
+		return
+	}
+	c.rollbackGuard.mu.Lock()
+	delete(c.rollbackGuard.seen, appID)
+	c.rollbackGuard.mu.Unlock()
+}
+
+// checkVotingConfig compares a voting configuration with the one last seen for its app ID
+// It remembers the configuration unless it is weaker and the action is RollbackReject
+func (c *Client) checkVotingConfig(appID string, targets []string, requiredVotes int, groups map[string][]string) error {
+	guard := c.rollbackGuard
+	if guard == nil {
+		return nil
+	}
+
+	current := VotingConfig{AppID: appID, Targets: append([]string(nil), targets...), RequiredVotes: requiredVotes, Groups: groups}
+	sort.Strings(current.Targets)
+
+	guard.mu.Lock()
+	previous, ok := guard.seen[appID]
+	var rollback *VotingConfigRollbackError
+	if ok {
+		needed := previousTargetsNeeded(previous, current)
+		weakened := weakenedGroups(previous, current)
+		if needed < previous.RequiredVotes || len(weakened) > 0 {
+			rollback = &VotingConfigRollbackError{
+				AppID:                 appID,
+				Previous:              previous,
+				Current:               current,
+				PreviousTargetsNeeded: needed,
+				WeakenedGroups:        weakened,
+			}
+		}
+	}
+	if rollback == nil || guard.config.Action == RollbackWarn {
+		guard.seen[appID] = current
+	}
+	guard.mu.Unlock()
+
+	if rollback == nil {
+		return nil
+	}
+	log.Printf("⚠️  %v", rollback)
+	if guard.config.OnRollback != nil {
+		guard.config.OnRollback(rollback)
+	}
+	if guard.config.Action == RollbackWarn {
+		return nil
+	}
+	return rollback
+}
+
+// previousTargetsNeeded returns how many of previous's targets must approve to reach current's quorum
+func previousTargetsNeeded(previous, current VotingConfig) int {
+	known := make(map[string]bool, len(previous.Targets))
+	for _, target := range previous.Targets {
+		known[target] = true
+	}
+	needed := current.RequiredVotes
+	for _, target := range current.Targets {
+		if !known[target] {
+			needed--
+		}
+	}
+	return max(needed, 0)
+}
+
+// weakenedGroups returns the sorted names of previous's groups that current dropped or added members to
+func weakenedGroups(previous, current VotingConfig) []string {
+	var weakened []string
+	for _, name := range sortedGroupNames(previous.Groups) {
+		members, ok := current.Groups[name]
+		if !ok {
+			weakened = append(weakened, name)
+			continue
+		}
+		known := make(map[string]bool, len(previous.Groups[name]))
+		for _, member := range previous.Groups[name] {
+			known[member] = true
+		}
+		for _, member := range members {
+			if !known[member] {
+				weakened = append(weakened, name)
+				break
+			}
+		}
+	}
+	return weakened
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/votingtest"
+)
+
+func TestRollbackProtection(t *testing.T) {
+	network := votingtest.NewNetwork("ed-app", "alice", "bob")
+	defer network.Close()
+	var reported []*VotingConfigRollbackError
+	c, deployment := newTestClient(t, func(c *Client) {
+		c.EnableRollbackProtection(RollbackConfig{OnRollback: func(err *VotingConfigRollbackError) {
+			reported = append(reported, err)
+		}})
+	})
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := deployment.SetVoting("ed-app", network, 3, nil); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+	sign := func(message string) (*SignResult, error) {
+		return c.Sign(&SignRequest{AppID: "ed-app", Message: []byte(message), EnableVoting: true, LocalApproval: true, VoteRequestData: []byte(`{"amount":10}`)})
+	}
+
+	if result, err := sign("pay 10"); err != nil || !result.Success {
+		t.Fatalf("Sign with the initial configuration failed: %v", err)
+	}
+
+	// A compromised App node lowers the quorum
+	if err := deployment.SetVoting("ed-app", network, 1, nil); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+	if _, err := sign("pay 20"); !errors.Is(err, ErrVotingConfigRollback) {
+		t.Fatalf("Expected ErrVotingConfigRollback for a lowered quorum, got %v", err)
+	}
+	if len(reported) != 1 || reported[0].PreviousTargetsNeeded != 1 {
+		t.Errorf("Expected OnRollback to report 1 previous target needed, got %+v", reported)
+	}
+	if n := len(network.Peer("alice").Requests()); n != 1 {
+		t.Errorf("Expected no vote requests for the refused round, alice got %d in total", n)
+	}
+
+	// Adding a target without raising the quorum weakens it as well
+	wider := votingtest.NewNetwork("ed-app", "alice", "bob", "mallory")
+	defer wider.Close()
+	if err := deployment.SetVoting("ed-app", wider, 3, nil); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+	if _, err := sign("pay 30"); !errors.Is(err, ErrVotingConfigRollback) {
+		t.Fatalf("Expected ErrVotingConfigRollback for an added target, got %v", err)
+	}
+
+	// An intended change is accepted explicitly
+	c.AcceptVotingConfig("ed-app")
+	if result, err := sign("pay 40"); err != nil || !result.Success {
+		t.Errorf("Sign after AcceptVotingConfig failed: %v", err)
+	}
+}
+
+func TestRollbackProtectionGroups(t *testing.T) {
+	network := votingtest.NewNetwork("ed-app", "alice", "bob")
+	defer network.Close()
+	c, deployment := newTestClient(t, func(c *Client) {
+		c.EnableRollbackProtection(RollbackConfig{})
+	})
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := deployment.SetVoting("ed-app", network, 2, map[string][]string{"security": {"bob"}}); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+	req := &SignRequest{AppID: "ed-app", Message: []byte("pay 10"), EnableVoting: true, LocalApproval: true, VoteRequestData: []byte(`{"amount":10}`)}
+	if result, err := c.Sign(req); err != nil || !result.Success {
+		t.Fatalf("Sign with the initial configuration failed: %v", err)
+	}
+
+	// Letting alice approve for the security group weakens it
+	if err := deployment.SetVoting("ed-app", network, 2, map[string][]string{"security": {"alice", "bob"}}); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+	var rollback *VotingConfigRollbackError
+	if _, err := c.Sign(req); !errors.As(err, &rollback) || len(rollback.WeakenedGroups) != 1 || rollback.WeakenedGroups[0] != "security" {
+		t.Errorf("Expected a rollback of the security group, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/acl"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/verification"
+)
+
+// DefaultKeyRotationGracePeriod is how long a rotated-out key keeps verifying signatures by default
+const DefaultKeyRotationGracePeriod = 24 * time.Hour
+
+// previousKey is a rotated-out key that Verify still accepts until expires
+type previousKey struct {
+	key     PublicKeyInfo
+	expires time.Time
+}
+
+// SetKeyRotationGracePeriod sets how long Verify keeps accepting signatures made with a key
+// replaced by RotateKey (default DefaultKeyRotationGracePeriod). A negative period stops
+// accepting them at once. Must be called before Init
+func (c *Client) SetKeyRotationGracePeriod(period time.Duration) {
+	c.previousKeysMu.Lock()
+	defer c.previousKeysMu.Unlock()
+	c.rotationGrace = period
+}
+
+// RotateKey provisions a new key for appID and returns it once the TEE nodes have generated it.
+// Signatures from then on use the new key; signatures made with the old one keep verifying in
+// Verify for the grace period, see SetKeyRotationGracePeriod. Other clients learn of the new key
+// when their cached key expires or a key rotated event arrives, but only this client accepts the
+// old one. Callers need sign access to appID. Cancelling ctx stops waiting, not the rotation
+func (c *Client) RotateKey(ctx context.Context, appID string) (*PublicKeyInfo, error) {
+	userMgmtClient := c.userMgmt()
+	if userMgmtClient == nil {
+		return nil, errNotInitialized
+	}
+	if err := c.authorize(ctx, c.principal, appID, acl.OpSign); err != nil {
+		return nil, err
+	}
+
+	old, err := c.fetchPublicKey(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	operationID, err := userMgmtClient.RotateKey(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("🔄 Rotating key of %s, DKG operation %s", appID, operationID)
+
+	watch, err := c.WatchKeyOperation(ctx, operationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch key rotation of %s: %w", appID, err)
+	}
+	for range watch.Updates() {
+	}
+	if err := watch.Err(); err != nil {
+		return nil, fmt.Errorf("key rotation of %s: %w", appID, err)
+	}
+
+	c.invalidateKeys([]string{appID})
+	current, err := c.getPublicKey(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(current.Key, old.Key) {
+		return nil, fmt.Errorf("key rotation of %s completed, but the app node still reports the old key", appID)
+	}
+
+	c.previousKeysMu.Lock()
+	grace := c.rotationGrace
+	if grace == 0 {
+		grace = DefaultKeyRotationGracePeriod
+	}
+	if grace > 0 {
+		if c.previousKeys == nil {
+			c.previousKeys = make(map[string][]previousKey)
+		}
+		c.previousKeys[appID] = append(c.previousKeys[appID], previousKey{key: *old, expires: time.Now().Add(grace)})
+	}
+	c.previousKeysMu.Unlock()
+
+	log.Printf("✅ Rotated key of %s; the previous key verifies for %s", appID, max(grace, 0))
+	return current, nil
+}
+
+// previousKeysOf returns appID's rotated-out keys still in their grace period, newest first,
+// dropping expired ones
+func (c *Client) previousKeysOf(appID string) []PublicKeyInfo {
+	c.previousKeysMu.Lock()
+	defer c.previousKeysMu.Unlock()
+	now := time.Now()
+	var kept []previousKey
+	for _, previous := range c.previousKeys[appID] {
+		if now.Before(previous.expires) {
+			kept = append(kept, previous)
+		}
+	}
+	if len(kept) == 0 {
+		delete(c.previousKeys, appID)
+		return nil
+	}
+	c.previousKeys[appID] = kept
+
+	keys := make([]PublicKeyInfo, len(kept))
+	for i, previous := range kept {
+		keys[len(kept)-1-i] = previous.key
+	}
+	return keys
+}
+
+// verifyWithPreviousKeys verifies a signature that failed against appID's current key with the
+// keys RotateKey replaced, reporting whether one of them accepts it
+func (c *Client) verifyWithPreviousKeys(appID string, message, signature []byte) bool {
+	for _, key := range c.previousKeysOf(appID) {
+		if valid, err := verification.VerifySignature(message, key.Key, signature, key.Protocol, key.Curve); err == nil && valid {
+			return true
+		}
+	}
+	return false
+}
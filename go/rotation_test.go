@@ -0,0 +1,87 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+)
+
+func TestRotateKeyGracePeriod(t *testing.T) {
+	c, deployment := newTestClient(t)
+	oldKey := addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	message := []byte("hello")
+	before, err := c.Sign(&SignRequest{AppID: "ed-app", Message: message})
+	if err != nil || !before.Success {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	current, err := c.RotateKey(context.Background(), "ed-app")
+	if err != nil {
+		t.Fatalf("RotateKey failed: %v", err)
+	}
+	if bytes.Equal(current.Key, oldKey) {
+		t.Fatal("Expected RotateKey to return the new key")
+	}
+
+	after, err := c.Sign(&SignRequest{AppID: "ed-app", Message: message})
+	if err != nil || !after.Success {
+		t.Fatalf("Sign after rotation failed: %v", err)
+	}
+	if requests := deployment.SignRequests(); !bytes.Equal(requests[len(requests)-1].PublicKeyInfo, current.Key) {
+		t.Error("Expected signatures after the rotation to use the new key")
+	}
+	for name, signature := range map[string][]byte{"old": before.Signature, "new": after.Signature} {
+		if valid, err := c.Verify(message, signature, "ed-app"); err != nil || !valid {
+			t.Errorf("Verify of the %s key's signature failed: valid=%t err=%v", name, valid, err)
+		}
+	}
+}
+
+func TestRotateKeyWithoutGracePeriod(t *testing.T) {
+	c, deployment := newTestClient(t, func(c *Client) {
+		c.SetKeyRotationGracePeriod(-1)
+	})
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	message := []byte("hello")
+	before, err := c.Sign(&SignRequest{AppID: "ed-app", Message: message})
+	if err != nil || !before.Success {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if _, err := c.RotateKey(context.Background(), "ed-app"); err != nil {
+		t.Fatalf("RotateKey failed: %v", err)
+	}
+	if valid, _ := c.Verify(message, before.Signature, "ed-app"); valid {
+		t.Error("Expected the old key's signature to be rejected without a grace period")
+	}
+}
+
+func TestRotateKeyBypassesDedup(t *testing.T) {
+	c, deployment := newTestClient(t, func(c *Client) {
+		c.EnableSignatureDedup(DedupConfig{})
+	})
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	req := &SignRequest{AppID: "ed-app", Message: []byte("hello")}
+	if result, err := c.Sign(req); err != nil || !result.Success {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if result, err := c.Sign(req); err != nil || !result.Cached {
+		t.Fatalf("Expected the repeated request to be served from the cache, err=%v", err)
+	}
+
+	current, err := c.RotateKey(context.Background(), "ed-app")
+	if err != nil {
+		t.Fatalf("RotateKey failed: %v", err)
+	}
+	result, err := c.Sign(req)
+	if err != nil || !result.Success {
+		t.Fatalf("Sign after rotation failed: %v", err)
+	}
+	if result.Cached {
+		t.Error("Expected a fresh signature after the rotation, got the old key's cached one")
+	}
+	if requests := deployment.SignRequests(); len(requests) != 2 || !bytes.Equal(requests[1].PublicKeyInfo, current.Key) {
+		t.Errorf("Expected a second TEE sign request with the new key, got %d requests", len(requests))
+	}
+}
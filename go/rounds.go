@@ -0,0 +1,265 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/rounds"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/task"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+)
+
+// DefaultRoundExpiry is how long an interrupted voting round may still be resumed by default
+const DefaultRoundExpiry = 10 * time.Minute
+
+// RoundConfig configures voting round persistence, see EnableRoundPersistence
+type RoundConfig struct {
+	Store  rounds.Store  // Where rounds in progress are kept, e.g. rounds.NewFileStore
+	Expiry time.Duration // How long after it started an interrupted round may be resumed
+
+	// OnRecovered is called once for every round found after a restart: with the signature if
+	// it was resumed, or with ErrVotingRoundExpired if it was abandoned
+	OnRecovered func(round *rounds.Round, result *SignResult, err error)
+}
+
+// roundPersistence is the client's voting round persistence state
+type roundPersistence struct {
+	config    RoundConfig
+	recoverMu sync.Mutex // one recovery at a time
+
+	liveMu sync.Mutex
+	live   map[string]bool // rounds run by this process, never recovered
+}
+
+// EnableRoundPersistence keeps voting rounds coordinated by this client in a store until they
+// finish, so a restart mid-round doesn't lose them
+//
+// After Init, rounds left from a previous run are recovered: rounds that had collected enough
+// approvals are signed (and committed, see EnableVoteCommit); rounds still collecting votes, or
+// older than Expiry, are abandoned and every app that voted receives an abort notification so it
+// can drop its approval. Must be called before Init
+func (c *Client) EnableRoundPersistence(config RoundConfig) error {
+	if config.Store == nil {
+		return fmt.Errorf("round persistence requires a store")
+	}
+	if config.Expiry <= 0 {
+		config.Expiry = DefaultRoundExpiry
+	}
+	c.rounds = &roundPersistence{config: config, live: make(map[string]bool)}
+	return nil
+}
+
+// RecoverVotingRounds resumes or abandons the voting rounds left in the store by a previous run
+// Init runs it in the background; rounds running in this process are left alone
+func (c *Client) RecoverVotingRounds() error {
+	if c.rounds == nil {
+		return fmt.Errorf("round persistence not enabled")
+	}
+	p := c.rounds
+	p.recoverMu.Lock()
+	defer p.recoverMu.Unlock()
+
+	stored, err := p.config.Store.List()
+	if err != nil {
+		return fmt.Errorf("failed to list voting rounds: %w", err)
+	}
+
+	for _, round := range stored {
+		p.liveMu.Lock()
+		live := p.live[round.ID]
+		p.liveMu.Unlock()
+		if live {
+			continue
+		}
+
+		done, err := c.beginRequest()
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+		ctx, untrack := c.trackOperation(ctx, OperationVotingRound, round.SignerAppID)
+		result, err := c.recoverRound(ctx, round)
+		untrack()
+		cancel()
+		done()
+
+		c.removeRound(round.ID)
+		if p.config.OnRecovered != nil {
+			p.config.OnRecovered(round, result, err)
+		}
+	}
+	return nil
+}
+
+// recoverRound signs a round that was approved before the restart, or aborts it
+func (c *Client) recoverRound(ctx context.Context, round *rounds.Round) (*SignResult, error) {
+	if round.Expired(time.Now()) || round.State != rounds.StateApproved {
+		reason := "interrupted before enough votes were collected"
+		if round.Expired(time.Now()) {
+			reason = "expired"
+		}
+		log.Printf("⌛ Voting round %s for app %s abandoned: %s", round.ID, round.SignerAppID, reason)
+		c.abortRound(ctx, round, reason)
+		return nil, fmt.Errorf("%w: %s", ErrVotingRoundExpired, reason)
+	}
+
+	log.Printf("🔁 Resuming approved voting round %s for app %s", round.ID, round.SignerAppID)
+	signOpts := &task.SignOptions{
+		ED25519Mode:    round.ED25519Mode,
+		ED25519Context: round.ED25519Context,
+		Priority:       task.Priority(round.Priority),
+	}
+	signature, _, err := c.signMessage(ctx, round.Message, round.SignerAppID, signOpts)
+	if err != nil {
+		log.Printf("❌ Resumed voting round %s failed to sign: %v", round.ID, err)
+		c.abortRound(ctx, round, "signing failed")
+		return &SignResult{Success: false, Error: err.Error()}, err
+	}
+
+	approvedBy := make(map[string]bool)
+	var voteDetails []VoteDetail
+	for appID, approved := range round.Votes {
+		voteDetails = append(voteDetails, VoteDetail{ClientID: appID, Success: true, Response: approved})
+		if approved {
+			approvedBy[appID] = true
+		}
+	}
+	result := &SignResult{
+		Signature: signature,
+		Success:   true,
+		VotingInfo: &VotingInfo{
+			TotalTargets:    len(round.Votes),
+			SuccessfulVotes: len(approvedBy),
+			RequiredVotes:   round.RequiredVotes,
+			VoteDetails:     voteDetails,
+		},
+	}
+	if c.voteCommit != nil {
+		commit := newCommit(round.SignerAppID, round.Message, signature, signOpts, round.RequiredVotes, approvedBy)
+		result.VotingInfo.CommitFailures = c.commitRound(ctx, round.ID, commit, voteDetails, nil, nil)
+	}
+	log.Printf("✅ Resumed voting round %s signed", round.ID)
+	return result, nil
+}
+
+// abortRound tells every app that voted in a round that it ended without a signature
+func (c *Client) abortRound(ctx context.Context, round *rounds.Round, reason string) {
+	abort := &voting.Abort{
+		Phase:         voting.PhaseAbort,
+		IsForwarded:   true,
+		SignerAppID:   round.SignerAppID,
+		MessageDigest: voting.MessageDigest(round.Message),
+		Reason:        reason,
+		Timestamp:     time.Now().Unix(),
+	}
+	var voters []string
+	for appID := range round.Votes {
+		if appID != round.SignerAppID {
+			voters = append(voters, appID)
+		}
+	}
+	c.sendAborts(ctx, round.ID, abort, voters, nil, nil)
+}
+
+// startRound stores a new voting round; it returns nil if persistence is off or the store failed,
+// in which case the round runs unpersisted
+func (c *Client) startRound(signerAppID string, message []byte, signOpts *task.SignOptions, requiredVotes int) *rounds.Round {
+	if c.rounds == nil {
+		return nil
+	}
+	id, err := rounds.NewID()
+	if err != nil {
+		log.Printf("⚠️  Failed to persist voting round: %v", err)
+		return nil
+	}
+	now := time.Now()
+	round := &rounds.Round{
+		ID:            id,
+		SignerAppID:   signerAppID,
+		Message:       message,
+		RequiredVotes: requiredVotes,
+		State:         rounds.StateVoting,
+		Votes:         make(map[string]bool),
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(c.rounds.config.Expiry),
+	}
+	if signOpts != nil {
+		round.ED25519Mode, round.ED25519Context, round.Priority = signOpts.ED25519Mode, signOpts.ED25519Context, int(signOpts.Priority)
+	}
+	c.rounds.liveMu.Lock()
+	c.rounds.live[id] = true
+	c.rounds.liveMu.Unlock()
+	if !c.saveRound(round) {
+		c.finishRound(round)
+		return nil
+	}
+	return round
+}
+
+// recordVote stores a vote received in a persisted round
+func (c *Client) recordVote(round *rounds.Round, appID string, approved bool) {
+	if round == nil {
+		return
+	}
+	round.Votes[appID] = approved
+	c.saveRound(round)
+}
+
+// approveRound marks a persisted round as approved, so a restart signs it instead of abandoning it
+func (c *Client) approveRound(round *rounds.Round) {
+	if round == nil {
+		return
+	}
+	round.State = rounds.StateApproved
+	c.saveRound(round)
+}
+
+// finishRound removes a persisted round once it is signed or rejected
+func (c *Client) finishRound(round *rounds.Round) {
+	if round == nil {
+		return
+	}
+	c.removeRound(round.ID)
+	c.rounds.liveMu.Lock()
+	delete(c.rounds.live, round.ID)
+	c.rounds.liveMu.Unlock()
+}
+
+// saveRound writes a round to the store, logging failures
+func (c *Client) saveRound(round *rounds.Round) bool {
+	if err := c.rounds.config.Store.Put(round); err != nil {
+		log.Printf("⚠️  Failed to persist voting round %s: %v", round.ID, err)
+		return false
+	}
+	return true
+}
+
+// removeRound deletes a round from the store, logging failures
+func (c *Client) removeRound(id string) {
+	if err := c.rounds.config.Store.Delete(id); err != nil && !errors.Is(err, rounds.ErrNotFound) {
+		log.Printf("⚠️  Failed to remove voting round %s: %v", id, err)
+	}
+}
+
+// receiveAbort answers an abort notification that reached this app's voting handler
+func receiveAbort(abort *voting.Abort) *SignResult {
+	log.Printf("🛑 Voting round of %s for message digest %s aborted: %s", abort.SignerAppID, abort.MessageDigest, abort.Reason)
+	return &SignResult{Success: false, Error: fmt.Sprintf("Voting round aborted: %s", abort.Reason), Abort: abort}
+}
@@ -0,0 +1,174 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/acl"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/task"
+)
+
+// sessionKeyCache is the cache label used for session key lookups in metrics
+const sessionKeyCache = "session_key"
+
+// SessionConfig holds per-app defaults applied to requests made through a Session
+type SessionConfig struct {
+	EnableVoting bool          // Enable voting for every request made through the session
+	Timeout      time.Duration // Default request timeout; zero uses the client default
+	Priority     task.Priority // Default sign queue priority
+}
+
+// Session operates on behalf of a single app ID
+// It caches the app's public key and applies per-app defaults, so one client
+// can serve several tenants concurrently. Sessions are safe for concurrent use
+type Session struct {
+	client *Client
+	appID  string
+
+	mu         sync.RWMutex
+	config     SessionConfig
+	key        *PublicKeyInfo
+	keyExpires time.Time
+}
+
+// Session returns the session for appID, creating it on first use
+func (c *Client) Session(appID string) *Session {
+	c.sessionsMu.Lock()
+	defer c.sessionsMu.Unlock()
+
+	if c.sessions == nil {
+		c.sessions = make(map[string]*Session)
+	}
+	session, ok := c.sessions[appID]
+	if !ok {
+		session = &Session{client: c, appID: appID}
+		c.sessions[appID] = session
+	}
+	return session
+}
+
+// CloseSession drops the session for appID along with its cached key
+func (c *Client) CloseSession(appID string) {
+	c.sessionsMu.Lock()
+	defer c.sessionsMu.Unlock()
+	delete(c.sessions, appID)
+}
+
+// lookupSession returns the session for appID if one exists
+func (c *Client) lookupSession(appID string) *Session {
+	c.sessionsMu.Lock()
+	defer c.sessionsMu.Unlock()
+	return c.sessions[appID]
+}
+
+// AppID returns the app ID the session acts for
+func (s *Session) AppID() string {
+	return s.appID
+}
+
+// SetConfig replaces the session defaults
+func (s *Session) SetConfig(config SessionConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config = config
+}
+
+// Config returns the session defaults
+func (s *Session) Config() SessionConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.config
+}
+
+// PublicKey returns the app's public key, fetching it on first use
+func (s *Session) PublicKey() (*PublicKeyInfo, error) {
+	if s.client.userMgmt() == nil {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.client.timeout)
+	defer cancel()
+	if err := s.client.authorize(ctx, s.client.principal, s.appID, acl.OpGetKey); err != nil {
+		return nil, err
+	}
+
+	return s.client.getPublicKey(ctx, s.appID)
+}
+
+// InvalidateKey drops the cached public key, e.g. after a key rotation
+func (s *Session) InvalidateKey() {
+	s.client.keys.invalidate(publicKeyPrefix, []string{s.appID})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.key = nil
+}
+
+// Sign signs a message with the session defaults
+func (s *Session) Sign(message []byte) (*SignResult, error) {
+	return s.SignRequest(&SignRequest{Message: message})
+}
+
+// SignRequest signs with the session's app ID, filling unset fields from the session defaults
+func (s *Session) SignRequest(req *SignRequest) (*SignResult, error) {
+	if req == nil {
+		return nil, fmt.Errorf("sign request cannot be nil")
+	}
+	if req.AppID != "" && req.AppID != s.appID {
+		return nil, fmt.Errorf("sign request app ID %s does not match session app ID %s", req.AppID, s.appID)
+	}
+
+	config := s.Config()
+	scoped := *req
+	scoped.AppID = s.appID
+	scoped.EnableVoting = req.EnableVoting || config.EnableVoting
+	if scoped.Timeout == 0 && scoped.Deadline.IsZero() {
+		scoped.Timeout = config.Timeout
+	}
+	if scoped.Priority == task.PriorityNormal {
+		scoped.Priority = config.Priority
+	}
+	return s.client.Sign(&scoped)
+}
+
+// Verify verifies a signature against the session's cached public key
+func (s *Session) Verify(message, signature []byte) (bool, error) {
+	return s.client.Verify(message, signature, s.appID)
+}
+
+// cachedKey returns the cached public key, if any and not expired
+func (s *Session) cachedKey() *PublicKeyInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.key == nil || time.Now().After(s.keyExpires) {
+		return nil
+	}
+	return s.key
+}
+
+// storeKey caches the public key for the client's public key cache TTL
+func (s *Session) storeKey(key *PublicKeyInfo) {
+	ttl := s.client.keys.ttlFor(publicKeyPrefix)
+	if ttl <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.key = key
+	s.keyExpires = time.Now().Add(ttl)
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+)
+
+func TestSessionReusesKey(t *testing.T) {
+	c, deployment := newTestClient(t)
+	publicKey := addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	session := c.Session("ed-app")
+
+	for i := 0; i < 3; i++ {
+		keyInfo, err := session.PublicKey()
+		if err != nil {
+			t.Fatalf("PublicKey failed: %v", err)
+		}
+		if !bytes.Equal(keyInfo.Key, publicKey) {
+			t.Fatal("Expected the app's public key")
+		}
+	}
+	result, err := session.Sign([]byte("hello"))
+	if err != nil || !result.Success {
+		t.Fatalf("Session sign failed: %v", err)
+	}
+	if valid, err := session.Verify([]byte("hello"), result.Signature); err != nil || !valid {
+		t.Errorf("Session verify failed: valid=%t err=%v", valid, err)
+	}
+	if n := deployment.PublicKeyLookups("ed-app"); n != 1 {
+		t.Errorf("Expected the session to fetch the key once, got %d lookups", n)
+	}
+	if c.Session("ed-app") != session {
+		t.Error("Expected Session to return the existing session")
+	}
+}
+
+func TestSessionKeyExpires(t *testing.T) {
+	c, deployment := newTestClient(t, func(c *Client) {
+		c.SetPublicKeyCacheTTL(50 * time.Millisecond)
+	})
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	session := c.Session("ed-app")
+
+	if _, err := session.PublicKey(); err != nil {
+		t.Fatalf("PublicKey failed: %v", err)
+	}
+	if _, err := session.PublicKey(); err != nil {
+		t.Fatalf("PublicKey failed: %v", err)
+	}
+	if n := deployment.PublicKeyLookups("ed-app"); n != 1 {
+		t.Fatalf("Expected 1 lookup within the TTL, got %d", n)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, err := session.PublicKey(); err != nil {
+		t.Fatalf("PublicKey failed: %v", err)
+	}
+	if n := deployment.PublicKeyLookups("ed-app"); n != 2 {
+		t.Errorf("Expected the expired key to be fetched again, got %d lookups", n)
+	}
+}
+
+func TestSessionKeyInvalidatedOnRotation(t *testing.T) {
+	c, deployment := newTestClient(t)
+	oldKey := addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	session := c.Session("ed-app")
+	if _, err := session.PublicKey(); err != nil {
+		t.Fatalf("PublicKey failed: %v", err)
+	}
+
+	// Rotating through the client drops the session's key
+	current, err := c.RotateKey(context.Background(), "ed-app")
+	if err != nil {
+		t.Fatalf("RotateKey failed: %v", err)
+	}
+	keyInfo, err := session.PublicKey()
+	if err != nil {
+		t.Fatalf("PublicKey failed: %v", err)
+	}
+	if bytes.Equal(keyInfo.Key, oldKey) || !bytes.Equal(keyInfo.Key, current.Key) {
+		t.Fatal("Expected the session to serve the rotated key")
+	}
+
+	// A rotation elsewhere needs InvalidateKey
+	rotated, err := deployment.RotateKey("ed-app")
+	if err != nil {
+		t.Fatalf("RotateKey failed: %v", err)
+	}
+	if keyInfo, _ := session.PublicKey(); !bytes.Equal(keyInfo.Key, current.Key) {
+		t.Fatal("Expected the cached key until it is invalidated")
+	}
+	session.InvalidateKey()
+	if keyInfo, _ := session.PublicKey(); !bytes.Equal(keyInfo.Key, rotated) {
+		t.Error("Expected the new key after InvalidateKey")
+	}
+}
@@ -0,0 +1,130 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// Shutdown gracefully stops the client
+//
+// It stops accepting new sign requests and incoming votes, waits for in-flight sign
+// calls (including their voting rounds) and vote handlers to finish, then closes all
+// connections. If ctx expires first, the voting service is stopped forcibly, the
+// connections are closed anyway and ctx's error is returned. Shutting down a closed
+// client returns ErrAlreadyClosed
+func (c *Client) Shutdown(ctx context.Context) error {
+	c.initMu.Lock()
+	defer c.initMu.Unlock()
+	if c.lifecycle == stateClosed {
+		return ErrAlreadyClosed
+	}
+	c.lifecycle = stateClosed
+	inflight := c.stopAccepting()
+	c.stopOfflineFlusher()
+	log.Printf("🛑 Shutting down client, draining in-flight requests...")
+
+	// GracefulStop closes the listener and waits for running vote handlers
+	votingDone := make(chan struct{})
+	votingServer, gateway := c.detachVotingServer(), c.detachVotingGateway()
+	go func() {
+		if votingServer != nil {
+			votingServer.GracefulStop()
+		}
+		if gateway != nil {
+			gateway.Shutdown(ctx)
+		}
+		close(votingDone)
+	}()
+
+	signDone := make(chan struct{})
+	go func() {
+		inflight.Wait()
+		close(signDone)
+	}()
+
+	// The goroutines close their own channels, so the loop waits on copies it can clear
+	var drainErr error
+	waitVoting, waitSign := votingDone, signDone
+	for waitVoting != nil || waitSign != nil {
+		select {
+		case <-waitVoting:
+			waitVoting = nil
+		case <-waitSign:
+			waitSign = nil
+		case <-ctx.Done():
+			log.Printf("⚠️  Shutdown deadline reached, closing with requests still in flight")
+			if votingServer != nil {
+				votingServer.Stop()
+			}
+			if gateway != nil {
+				gateway.Close()
+			}
+			drainErr = fmt.Errorf("shutdown drain incomplete: %w", ctx.Err())
+			waitVoting, waitSign = nil, nil
+		}
+	}
+
+	if err := c.closeConnections(); err != nil {
+		return err
+	}
+	if drainErr != nil {
+		return drainErr
+	}
+	log.Printf("✅ Client shut down cleanly")
+	return nil
+}
+
+// stopAccepting makes subsequent sign requests fail with ErrShuttingDown and returns the
+// counter of the requests still running
+func (c *Client) stopAccepting() *sync.WaitGroup {
+	c.drainMu.Lock()
+	defer c.drainMu.Unlock()
+	c.closing = true
+	return c.inflight
+}
+
+// resumeAccepting undoes stopAccepting when a closed client is initialized again. Requests
+// are counted afresh, since a Shutdown that gave up may still be waiting on the old counter
+func (c *Client) resumeAccepting() {
+	c.drainMu.Lock()
+	defer c.drainMu.Unlock()
+	if c.closing {
+		c.inflight = new(sync.WaitGroup)
+	}
+	c.closing = false
+}
+
+// isClosing reports whether the client was closed or is shutting down
+func (c *Client) isClosing() bool {
+	c.drainMu.RLock()
+	defer c.drainMu.RUnlock()
+	return c.closing
+}
+
+// beginRequest registers an in-flight sign request; the returned function must be
+// called when it completes
+func (c *Client) beginRequest() (func(), error) {
+	c.drainMu.RLock()
+	defer c.drainMu.RUnlock()
+	if c.closing {
+		return nil, ErrShuttingDown
+	}
+	inflight := c.inflight
+	inflight.Add(1)
+	return inflight.Done, nil
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/votingtest"
+)
+
+func TestShutdownDrainsInFlightRounds(t *testing.T) {
+	network := votingtest.NewNetwork("ed-app", "approver")
+	defer network.Close()
+	network.SetBehavior("approver", votingtest.Delay(300*time.Millisecond, votingtest.Approve()))
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := deployment.SetVoting("ed-app", network, 2, nil); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+
+	type outcome struct {
+		result *SignResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("pay 10"), EnableVoting: true, LocalApproval: true, VoteRequestData: []byte(`{"amount":10}`)})
+		done <- outcome{result, err}
+	}()
+	for len(network.Peer("approver").Requests()) == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := c.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	// Shutdown returned after the round it waited for
+	select {
+	case o := <-done:
+		if o.err != nil || !o.result.Success {
+			t.Errorf("Expected the in-flight round to complete, got err=%v", o.err)
+		}
+	default:
+		t.Fatal("Shutdown returned before the in-flight round finished")
+	}
+
+	if _, err := c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("late")}); !errors.Is(err, ErrShuttingDown) {
+		t.Errorf("Expected ErrShuttingDown after Shutdown, got %v", err)
+	}
+	if err := c.Shutdown(ctx); !errors.Is(err, ErrAlreadyClosed) {
+		t.Errorf("Expected ErrAlreadyClosed from a second Shutdown, got %v", err)
+	}
+}
+
+func TestShutdownDeadline(t *testing.T) {
+	network := votingtest.NewNetwork("ed-app", "silent")
+	defer network.Close()
+	network.SetBehavior("silent", votingtest.Drop())
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := deployment.SetVoting("ed-app", network, 2, nil); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+
+	go c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("pay 10"), EnableVoting: true, LocalApproval: true, VoteRequestData: []byte(`{"amount":10}`), Timeout: 10 * time.Second})
+	for len(network.Peer("silent").Requests()) == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := c.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected Shutdown to give up at its deadline, got %v", err)
+	}
+}
+
+func TestInitAfterShutdownDeadline(t *testing.T) {
+	network := votingtest.NewNetwork("ed-app", "silent")
+	defer network.Close()
+	network.SetBehavior("silent", votingtest.Drop())
+	c, deployment := newTestClient(t)
+	addApp(t, deployment, "ed-app", constants.ProtocolSchnorr, constants.CurveED25519)
+	if err := deployment.SetVoting("ed-app", network, 2, nil); err != nil {
+		t.Fatalf("SetVoting failed: %v", err)
+	}
+
+	go c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("pay 10"), EnableVoting: true, LocalApproval: true, VoteRequestData: []byte(`{"amount":10}`), Timeout: 10 * time.Second})
+	for len(network.Peer("silent").Requests()) == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := c.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected Shutdown to give up at its deadline, got %v", err)
+	}
+
+	// The abandoned round is still running; the new generation doesn't wait for it
+	if err := c.Init(nil); err != nil {
+		t.Fatalf("Init after Shutdown failed: %v", err)
+	}
+	if result, err := c.Sign(&SignRequest{AppID: "ed-app", Message: []byte("hello")}); err != nil || !result.Success {
+		t.Fatalf("Sign after re-Init failed: %v", err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := c.Shutdown(ctx); err != nil {
+		t.Errorf("Expected the second Shutdown to drain only its own requests, got %v", err)
+	}
+}
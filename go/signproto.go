@@ -0,0 +1,177 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/task"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/voting"
+	signingpb "github.com/TEENet-io/teenet-sdk/go/proto/signing"
+)
+
+// SignRequestToProto converts a sign request to its wire form in the signing service schema
+// HTTPRequest has no wire form and is dropped; durations are rounded down to milliseconds
+func SignRequestToProto(req *SignRequest) *signingpb.SignRequest {
+	if req == nil {
+		return nil
+	}
+	msg := &signingpb.SignRequest{
+		AppId:           req.AppID,
+		Message:         req.Message,
+		EnableVoting:    req.EnableVoting,
+		LocalApproval:   req.LocalApproval,
+		VoteRequestData: req.VoteRequestData,
+		Headers:         req.Headers,
+		TimeoutMs:       durationMillis(req.Timeout),
+		Ed25519Mode:     req.ED25519Mode,
+		Ed25519Context:  req.ED25519Context,
+		IdempotencyKey:  req.IdempotencyKey,
+		Priority:        int32(req.Priority),
+		QueueExpiryMs:   durationMillis(req.QueueExpiry),
+		BypassDedup:     req.BypassDedup,
+	}
+	if !req.Deadline.IsZero() {
+		msg.DeadlineUnixMs = req.Deadline.UnixMilli()
+	}
+	if req.Principal != nil {
+		msg.Principal = &signingpb.Principal{Id: req.Principal.ID, Justification: req.Principal.Justification}
+	}
+	return msg
+}
+
+// SignRequestFromProto converts a sign request from its wire form. Principal, EnableVoting,
+// LocalApproval and BypassDedup are as the sender set them; services taking requests from
+// untrusted callers must replace them, as pkg/server does
+func SignRequestFromProto(msg *signingpb.SignRequest) *SignRequest {
+	if msg == nil {
+		return nil
+	}
+	req := &SignRequest{
+		Message:         msg.Message,
+		AppID:           msg.AppId,
+		EnableVoting:    msg.EnableVoting,
+		LocalApproval:   msg.LocalApproval,
+		VoteRequestData: msg.VoteRequestData,
+		Headers:         msg.Headers,
+		Timeout:         time.Duration(msg.TimeoutMs) * time.Millisecond,
+		ED25519Mode:     msg.Ed25519Mode,
+		ED25519Context:  msg.Ed25519Context,
+		IdempotencyKey:  msg.IdempotencyKey,
+		Priority:        task.Priority(msg.Priority),
+		QueueExpiry:     time.Duration(msg.QueueExpiryMs) * time.Millisecond,
+		BypassDedup:     msg.BypassDedup,
+	}
+	if msg.DeadlineUnixMs > 0 {
+		req.Deadline = time.UnixMilli(msg.DeadlineUnixMs)
+	}
+	if msg.Principal != nil {
+		req.Principal = &voting.Principal{ID: msg.Principal.Id, Justification: msg.Principal.Justification}
+	}
+	return req
+}
+
+// SignResultToProto converts a sign result to its wire form in the signing service schema
+// Commit, Abort and MuSig2 only answer other apps' voting rounds and have no wire form
+func SignResultToProto(result *SignResult) *signingpb.SignResponse {
+	if result == nil {
+		return nil
+	}
+	msg := &signingpb.SignResponse{
+		Success:          result.Success,
+		Signature:        result.Signature,
+		Error:            result.Error,
+		IdempotentReplay: result.IdempotentReplay,
+		QueuedId:         result.QueuedID,
+		Cached:           result.Cached,
+		SharedRound:      result.SharedRound,
+	}
+	if info := result.VotingInfo; info != nil {
+		msg.VotingInfo = &signingpb.VotingInfo{
+			TotalTargets:    int32(info.TotalTargets),
+			SuccessfulVotes: int32(info.SuccessfulVotes),
+			RequiredVotes:   int32(info.RequiredVotes),
+			MissingGroups:   info.MissingGroups,
+			MessageClass:    info.MessageClass,
+			CommitFailures:  info.CommitFailures,
+			RoundId:         info.RoundID,
+			Cancelled:       info.Cancelled,
+		}
+		for _, detail := range info.VoteDetails {
+			msg.VotingInfo.VoteDetails = append(msg.VotingInfo.VoteDetails, &signingpb.VoteDetail{
+				ClientId:        detail.ClientID,
+				Success:         detail.Success,
+				Response:        detail.Response,
+				Error:           detail.Error,
+				DelegationChain: detail.DelegationChain,
+				Reason:          detail.Reason,
+				Code:            string(detail.Code),
+			})
+		}
+	}
+	return msg
+}
+
+// SignResultFromProto converts a sign result from its wire form
+func SignResultFromProto(msg *signingpb.SignResponse) *SignResult {
+	if msg == nil {
+		return nil
+	}
+	result := &SignResult{
+		Success:          msg.Success,
+		Signature:        msg.Signature,
+		Error:            msg.Error,
+		IdempotentReplay: msg.IdempotentReplay,
+		QueuedID:         msg.QueuedId,
+		Cached:           msg.Cached,
+		SharedRound:      msg.SharedRound,
+	}
+	if info := msg.VotingInfo; info != nil {
+		result.VotingInfo = &VotingInfo{
+			TotalTargets:    int(info.TotalTargets),
+			SuccessfulVotes: int(info.SuccessfulVotes),
+			RequiredVotes:   int(info.RequiredVotes),
+			MissingGroups:   info.MissingGroups,
+			MessageClass:    info.MessageClass,
+			CommitFailures:  info.CommitFailures,
+			RoundID:         info.RoundId,
+			Cancelled:       info.Cancelled,
+		}
+		for _, detail := range info.VoteDetails {
+			result.VotingInfo.VoteDetails = append(result.VotingInfo.VoteDetails, VoteDetail{
+				ClientID:        detail.ClientId,
+				Success:         detail.Success,
+				Response:        detail.Response,
+				Error:           detail.Error,
+				DelegationChain: detail.DelegationChain,
+				Reason:          detail.Reason,
+				Code:            voting.RejectionCode(detail.Code),
+			})
+		}
+	}
+	return result
+}
+
+// durationMillis converts d to whole milliseconds, saturating at the uint32 range
+func durationMillis(d time.Duration) uint32 {
+	ms := d.Milliseconds()
+	switch {
+	case ms <= 0:
+		return 0
+	case ms > int64(^uint32(0)):
+		return ^uint32(0)
+	default:
+		return uint32(ms)
+	}
+}
@@ -0,0 +1,71 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/solana"
+)
+
+// SolanaAddress returns the Solana address of an app's ED25519 key, for use as fee payer or signer
+func (c *Client) SolanaAddress(appID string) (solana.PublicKey, error) {
+	keyInfo, err := c.GetPublicKeyByAppID(appID)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	if keyInfo.Curve != constants.CurveED25519 {
+		return solana.PublicKey{}, fmt.Errorf("app %s must use an ED25519 key, got %s", appID, keyInfo.Curve)
+	}
+	return solana.PublicKeyFromBytes(keyInfo.Key)
+}
+
+// SignSolanaTransaction signs the transaction's message with the app's ED25519 key, which must be
+// one of its signers, and stores the signature in the app's slot. It returns the base58 signature,
+// which is the transaction ID once every signer has signed. The message is signed as is, without
+// domain separation, and recorded in the audit log as AuditOpSolana
+func (c *Client) SignSolanaTransaction(tx *solana.Transaction, appID string) (string, error) {
+	if tx == nil || tx.Message == nil {
+		return "", fmt.Errorf("transaction message is required")
+	}
+	message := tx.Message.Serialize()
+	signature, keyInfo, err := c.signEncoded(AuditOpSolana, message, message, appID, nil, func(keyInfo *PublicKeyInfo) error {
+		if keyInfo.Curve != constants.CurveED25519 {
+			return fmt.Errorf("app %s must use an ED25519 key, got %s", appID, keyInfo.Curve)
+		}
+		signer, err := solana.PublicKeyFromBytes(keyInfo.Key)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(tx.Message.Signers(), signer) {
+			return fmt.Errorf("app %s (%s) is not a signer of the transaction", appID, signer)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signer, err := solana.PublicKeyFromBytes(keyInfo.Key)
+	if err != nil {
+		return "", err
+	}
+	encoded, err := tx.AddSignature(signer, signature)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrSignatureMismatch, err)
+	}
+	return encoded, nil
+}
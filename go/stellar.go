@@ -0,0 +1,65 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/constants"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/stellar"
+)
+
+// StellarAccountID returns the Stellar account ID ("G...") of an app's ED25519 key
+func (c *Client) StellarAccountID(appID string) (string, error) {
+	keyInfo, err := c.GetPublicKeyByAppID(appID)
+	if err != nil {
+		return "", err
+	}
+	if keyInfo.Curve != constants.CurveED25519 {
+		return "", fmt.Errorf("app %s must use an ED25519 key, got %s", appID, keyInfo.Curve)
+	}
+	return stellar.EncodeAccountID(keyInfo.Key)
+}
+
+// SignStellarTransaction hashes an XDR-encoded Transaction (or FeeBumpTransaction, with
+// stellar.EnvelopeTypeTxFeeBump) for the network named by passphrase and signs the hash with the
+// app's ED25519 key. Add the returned decorated signature to the transaction envelope
+func (c *Client) SignStellarTransaction(transactionXDR []byte, passphrase string, envelopeType stellar.EnvelopeType, appID string) (*stellar.DecoratedSignature, error) {
+	hash, err := stellar.TransactionHash(passphrase, envelopeType, transactionXDR)
+	if err != nil {
+		return nil, err
+	}
+	return c.SignStellarTransactionHash(hash, appID)
+}
+
+// SignStellarTransactionHash signs a transaction hash computed by a Stellar SDK with the app's
+// ED25519 key, without domain separation, and returns it decorated with the key's signature hint.
+// The signature is checked against the hash and recorded in the audit log as AuditOpStellar
+func (c *Client) SignStellarTransactionHash(hash [32]byte, appID string) (*stellar.DecoratedSignature, error) {
+	signature, keyInfo, err := c.signEncoded(AuditOpStellar, hash[:], hash[:], appID, nil, func(keyInfo *PublicKeyInfo) error {
+		if keyInfo.Curve != constants.CurveED25519 {
+			return fmt.Errorf("app %s must use an ED25519 key, got %s", appID, keyInfo.Curve)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	decorated, err := stellar.NewDecoratedSignature(keyInfo.Key, hash, signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSignatureMismatch, err)
+	}
+	return decorated, nil
+}
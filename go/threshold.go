@@ -0,0 +1,60 @@
+// -----------------------------------------------------------------------------
+// Copyright (c) 2025 TEENet Technology (Hong Kong) Limited. All Rights Reserved.
+//
+// This software and its associated documentation files (the "Software") are
+// the proprietary and confidential information of TEENet Technology (Hong Kong) Limited.
+// Unauthorized copying of this file, via any medium, is strictly prohibited.
+//
+// No license, express or implied, is hereby granted, except by written agreement
+// with TEENet Technology (Hong Kong) Limited. Use of this software without permission
+// is a violation of applicable laws.
+//
+// -----------------------------------------------------------------------------
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/acl"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/threshold"
+)
+
+// GetKeyDetails returns the threshold scheme parameters (t-of-n, participant IDs, scheme
+// version) behind an app's key, so operators can check them with threshold.Details.Check
+func (c *Client) GetKeyDetails(appID string) (*threshold.Details, error) {
+	userMgmtClient := c.userMgmt()
+	if userMgmtClient == nil {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	if err := c.authorize(ctx, c.principal, appID, acl.OpGetKey); err != nil {
+		return nil, err
+	}
+
+	resp, err := userMgmtClient.GetKeyDetails(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	details, err := threshold.FromProto(resp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key details for app %s: %w", appID, err)
+	}
+	return details, nil
+}
+
+// CheckKeyThreshold returns an error matching ErrThresholdRequirement if appID's key
+// doesn't meet req
+func (c *Client) CheckKeyThreshold(appID string, req threshold.Requirement) error {
+	details, err := c.GetKeyDetails(appID)
+	if err != nil {
+		return err
+	}
+	if err := details.Check(req); err != nil {
+		return fmt.Errorf("app %s key (%s): %w", appID, details, err)
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+// Package certgen generates the throwaway CA and TLS certificates the mock servers use
+package certgen
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultDir is where the mock servers look for certificates
+const DefaultDir = "certs"
+
+// Leaves are the certificates issued by the CA; each is written as <name>.crt and <name>.key
+var Leaves = []string{"dao-server", "app-node", "client"}
+
+// lockWait bounds how long a server waits for another one to finish generating certificates
+const lockWait = 30 * time.Second
+
+// Ensure generates certificates in dir unless a complete set is already there; extra names
+// further leaves, such as those of additional cluster nodes, which are issued from the
+// existing CA when only they are missing
+func Ensure(dir string, extra ...string) error {
+	names := append(append([]string(nil), Leaves...), extra...)
+	if len(missing(dir, names)) == 0 {
+		return nil
+	}
+	return withLock(dir, func() error {
+		// Another server may have generated them while we waited
+		if len(missing(dir, Leaves)) > 0 {
+			return generate(dir, names)
+		}
+		if names := missing(dir, names); len(names) > 0 {
+			return issue(dir, names)
+		}
+		return nil
+	})
+}
+
+// Generate replaces the certificates in dir with a fresh set, including the extra leaves
+func Generate(dir string, extra ...string) error {
+	names := append(append([]string(nil), Leaves...), extra...)
+	return withLock(dir, func() error { return generate(dir, names) })
+}
+
+// missing returns the leaves whose certificate or key is not in dir; sets made by
+// generate-certs.sh have no CA and are used as they are
+func missing(dir string, names []string) []string {
+	var absent []string
+	for _, name := range names {
+		for _, ext := range []string{".crt", ".key"} {
+			if _, err := os.Stat(filepath.Join(dir, name+ext)); err != nil {
+				absent = append(absent, name)
+				break
+			}
+		}
+	}
+	return absent
+}
+
+// withLock runs fn while holding a lock file next to dir, so servers started together don't
+// generate different sets
+func withLock(dir string, fn func() error) error {
+	lock := filepath.Clean(dir) + ".lock"
+	deadline := time.Now().Add(lockWait)
+	for {
+		f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+			break
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("failed to lock %s: %w", dir, err)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for %s; remove it if no server is generating certificates", lock)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	defer os.Remove(lock)
+	return fn()
+}
+
+// generate writes a new CA and the named leaf certificates to dir
+func generate(dir string, names []string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	caCert, caKey, err := newCA(dir)
+	if err != nil {
+		return err
+	}
+	if err := issueLeaves(dir, names, caCert, caKey); err != nil {
+		return err
+	}
+	log.Printf("Generated throwaway CA and certificates for %v in %s", names, dir)
+	return nil
+}
+
+// issue adds the named leaf certificates to dir, signed by its CA; a set without a CA
+// gets a new one, which leaves the existing certificates valid
+func issue(dir string, names []string) error {
+	caCert, caKey, err := loadCA(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		caCert, caKey, err = newCA(dir)
+	}
+	if err != nil {
+		return err
+	}
+	if err := issueLeaves(dir, names, caCert, caKey); err != nil {
+		return err
+	}
+	log.Printf("Issued throwaway certificates for %v in %s", names, dir)
+	return nil
+}
+
+// newCA writes a new self-signed CA to dir
+func newCA(dir string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	now := time.Now()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate CA key: %w", err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          serialNumber(),
+		Subject:               pkix.Name{Country: []string{"HK"}, Organization: []string{"TEENet"}, CommonName: "TEENet Mock CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.AddDate(1, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create CA certificate: %w", err)
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+	if err := write(dir, "ca", caDER, caKey); err != nil {
+		return nil, nil, err
+	}
+	return caCert, caKey, nil
+}
+
+// loadCA reads the CA certificate and key from dir
+func loadCA(dir string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	certPEM, err := os.ReadFile(filepath.Join(dir, "ca.crt"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+	keyPEM, err := os.ReadFile(filepath.Join(dir, "ca.key"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read CA key: %w", err)
+	}
+	certBlock, _ := pem.Decode(certPEM)
+	keyBlock, _ := pem.Decode(keyPEM)
+	if certBlock == nil || keyBlock == nil {
+		return nil, nil, fmt.Errorf("invalid CA files in %s", dir)
+	}
+	caCert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse CA key: %w", err)
+	}
+	caKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported CA key type %T", key)
+	}
+	return caCert, caKey, nil
+}
+
+// issueLeaves writes a certificate and key for each name, signed by the CA
+func issueLeaves(dir string, names []string, caCert *x509.Certificate, caKey *ecdsa.PrivateKey) error {
+	now := time.Now()
+	for _, name := range names {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return fmt.Errorf("failed to generate %s key: %w", name, err)
+		}
+		template := &x509.Certificate{
+			SerialNumber: serialNumber(),
+			Subject:      pkix.Name{Country: []string{"HK"}, Organization: []string{"TEENet"}, CommonName: "localhost"},
+			NotBefore:    now.Add(-time.Hour),
+			NotAfter:     now.AddDate(1, 0, 0),
+			KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+			DNSNames:     []string{"localhost", name, "*.localhost"},
+			IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		if err != nil {
+			return fmt.Errorf("failed to create %s certificate: %w", name, err)
+		}
+		if err := write(dir, name, der, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write stores a certificate and its key as PEM files
+func write(dir, name string, der []byte, key *ecdsa.PrivateKey) error {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s key: %w", name, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".key"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s key: %w", name, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s certificate: %w", name, err)
+	}
+	return nil
+}
+
+// serialNumber returns a random certificate serial number
+func serialNumber() *big.Int {
+	serial, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+	return serial
+}
@@ -0,0 +1,122 @@
+package certgen
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"tee-dao-mock-server/tokenauth"
+)
+
+// readCert parses the certificate of a leaf or the CA in dir
+func readCert(t *testing.T, dir, name string) *x509.Certificate {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name+".crt"))
+	if err != nil {
+		t.Fatalf("read %s certificate: %v", name, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatalf("%s certificate is not PEM", name)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("parse %s certificate: %v", name, err)
+	}
+	return cert
+}
+
+// verify checks that a leaf in dir is issued by its CA for localhost
+func verify(t *testing.T, dir, name string) {
+	t.Helper()
+	roots := x509.NewCertPool()
+	roots.AddCert(readCert(t, dir, "ca"))
+	_, err := readCert(t, dir, name).Verify(x509.VerifyOptions{
+		Roots:     roots,
+		DNSName:   "localhost",
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		t.Errorf("%s certificate does not verify against the CA: %v", name, err)
+	}
+}
+
+func TestEnsure(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "certs")
+	if err := Ensure(dir); err != nil {
+		t.Fatalf("Ensure: %v", err)
+	}
+	for _, name := range Leaves {
+		verify(t, dir, name)
+	}
+	if _, err := os.Stat(dir + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("lock file left behind: %v", err)
+	}
+
+	ca, _ := os.ReadFile(filepath.Join(dir, "ca.crt"))
+	leaf, _ := os.ReadFile(filepath.Join(dir, "dao-server.crt"))
+	if err := Ensure(dir, "dao-server-2"); err != nil {
+		t.Fatalf("Ensure with an extra leaf: %v", err)
+	}
+	verify(t, dir, "dao-server-2")
+	if current, _ := os.ReadFile(filepath.Join(dir, "ca.crt")); !bytes.Equal(current, ca) {
+		t.Error("Ensure replaced the CA to issue an extra leaf")
+	}
+	if current, _ := os.ReadFile(filepath.Join(dir, "dao-server.crt")); !bytes.Equal(current, leaf) {
+		t.Error("Ensure replaced an existing leaf")
+	}
+
+	if err := Generate(dir); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if current, _ := os.ReadFile(filepath.Join(dir, "ca.crt")); bytes.Equal(current, ca) {
+		t.Error("Generate kept the old CA")
+	}
+	verify(t, dir, "dao-server")
+}
+
+func TestServerTLSConfigStrict(t *testing.T) {
+	dir := t.TempDir()
+	if err := Generate(dir); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	t.Setenv("MOCK_TLS_STRICT", "true")
+	t.Setenv(tokenauth.EnvVar, "")
+
+	serverConfig, err := ServerTLSConfig(filepath.Join(dir, "dao-server.crt"), filepath.Join(dir, "dao-server.key"), filepath.Join(dir, "ca.crt"))
+	if err != nil {
+		t.Fatalf("ServerTLSConfig: %v", err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(readCert(t, dir, "ca"))
+	clientCert, err := tls.LoadX509KeyPair(filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key"))
+	if err != nil {
+		t.Fatalf("load client certificate: %v", err)
+	}
+
+	handshake := func(clientConfig *tls.Config) error {
+		serverConn, clientConn := net.Pipe()
+		defer serverConn.Close()
+		defer clientConn.Close()
+		done := make(chan error, 1)
+		go func() { done <- tls.Server(serverConn, serverConfig).Handshake() }()
+		clientErr := tls.Client(clientConn, clientConfig).Handshake()
+		clientConn.Close()
+		if serverErr := <-done; serverErr != nil {
+			return serverErr
+		}
+		return clientErr
+	}
+
+	if err := handshake(&tls.Config{RootCAs: roots, ServerName: "localhost", Certificates: []tls.Certificate{clientCert}}); err != nil {
+		t.Errorf("handshake with a CA-issued client certificate: %v", err)
+	}
+	if err := handshake(&tls.Config{RootCAs: roots, ServerName: "localhost"}); err == nil {
+		t.Error("strict TLS accepted a client without a certificate")
+	}
+}
@@ -0,0 +1,59 @@
+package certgen
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"tee-dao-mock-server/tokenauth"
+)
+
+// ServerTLSConfig returns the TLS configuration of a mock server. By default any client
+// certificate is accepted; with MOCK_TLS_STRICT=true clients must present one issued by the CA
+// in caFile (default certs/ca.crt) and TLS 1.3 is required. With MOCK_AUTH_TOKENS set, clients
+// may leave the certificate out and authenticate with a bearer token instead
+func ServerTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientAuth:   tls.RequireAnyClientCert, // Require client certificate but don't verify against CA
+	}
+
+	tokens := tokenauth.Enabled()
+	if tokens {
+		tlsConfig.ClientAuth = tls.RequestClientCert
+	}
+
+	strict, _ := strconv.ParseBool(os.Getenv("MOCK_TLS_STRICT"))
+	if !strict {
+		log.Printf("TLS: Accepting any client certificate on %s (set MOCK_TLS_STRICT=true to verify)", certFile)
+		return tlsConfig, nil
+	}
+
+	if caFile == "" {
+		caFile = filepath.Join(DefaultDir, "ca.crt")
+	}
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate for strict TLS: %w", err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("failed to parse CA certificate %s", caFile)
+	}
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	if tokens {
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	tlsConfig.ClientCAs = clientCAs
+	tlsConfig.MinVersion = tls.VersionTLS13
+	log.Printf("TLS: Verifying client certificates against %s, TLS 1.3 only", caFile)
+	return tlsConfig, nil
+}
@@ -0,0 +1,149 @@
+// Package cluster describes the logical TEE and app nodes the mock servers run, so the
+// DAO server, app node and config server agree on their IDs, ports and certificates
+package cluster
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"tee-dao-mock-server/certgen"
+)
+
+// PortStep separates the ports of consecutive nodes of one kind, e.g. 50051, 50061, 50071
+const PortStep = 10
+
+// MaxNodes bounds the number of nodes of one kind
+const MaxNodes = 50
+
+// Peer types as reported by the config server
+const (
+	TypeTEE = 1
+	TypeApp = 3
+)
+
+// Node is one logical node
+type Node struct {
+	ID     uint32
+	Type   uint32
+	Index  int    // Position among the nodes of its kind, from 0
+	Name   string // Certificate name, e.g. "dao-server" or "dao-server-2"
+	Port   int
+	Region string
+	Zone   string
+}
+
+// Address returns the node's RPC address
+func (n Node) Address() string {
+	return net.JoinHostPort("localhost", strconv.Itoa(n.Port))
+}
+
+// ListenAddr returns the address the node listens on
+func (n Node) ListenAddr() string {
+	return ":" + strconv.Itoa(n.Port)
+}
+
+// CertFile returns the path of the node's certificate
+func (n Node) CertFile() string {
+	return filepath.Join(certgen.DefaultDir, n.Name+".crt")
+}
+
+// KeyFile returns the path of the node's private key
+func (n Node) KeyFile() string {
+	return filepath.Join(certgen.DefaultDir, n.Name+".key")
+}
+
+// TEENodes returns the DAO server nodes: MOCK_TEE_NODES of them (default 1) from port
+// MOCK_DAO_PORT (default 50051), with IDs from 2001
+func TEENodes() ([]Node, error) {
+	return nodes("MOCK_TEE_NODES", "MOCK_DAO_PORT", 50051, 2001, TypeTEE, "dao-server")
+}
+
+// AppNodes returns the app nodes: MOCK_APP_NODES of them (default 1) from port
+// APP_NODE_PORT (default 50053), with IDs from 3001
+func AppNodes() ([]Node, error) {
+	return nodes("MOCK_APP_NODES", "APP_NODE_PORT", 50053, 3001, TypeApp, "app-node")
+}
+
+// CertNames returns the certificate names of the nodes beyond the first of each kind,
+// which certgen.Leaves doesn't cover
+func CertNames() ([]string, error) {
+	teeNodes, err := TEENodes()
+	if err != nil {
+		return nil, err
+	}
+	appNodes, err := AppNodes()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, node := range append(teeNodes, appNodes...) {
+		if node.Index > 0 {
+			names = append(names, node.Name)
+		}
+	}
+	return names, nil
+}
+
+// nodes builds the nodes of one kind from the environment
+func nodes(countEnv, portEnv string, defaultPort int, firstID uint32, nodeType uint32, name string) ([]Node, error) {
+	count := 1
+	if value := os.Getenv(countEnv); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > MaxNodes {
+			return nil, fmt.Errorf("invalid %s %q (want 1 to %d)", countEnv, value, MaxNodes)
+		}
+		count = n
+	}
+	port := defaultPort
+	if value := os.Getenv(portEnv); value != "" {
+		p, err := strconv.Atoi(value)
+		if err != nil || p < 1 || p+(count-1)*PortStep > 65535 {
+			return nil, fmt.Errorf("invalid %s %q", portEnv, value)
+		}
+		port = p
+	}
+	localities, err := parseLocalities(os.Getenv("MOCK_NODE_LOCALITIES"))
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]Node, count)
+	for i := range nodes {
+		nodes[i] = Node{
+			ID:    firstID + uint32(i),
+			Type:  nodeType,
+			Index: i,
+			Name:  name,
+			Port:  port + i*PortStep,
+		}
+		if i > 0 {
+			nodes[i].Name = fmt.Sprintf("%s-%d", name, i+1)
+		}
+		if len(localities) > 0 {
+			locality := localities[i%len(localities)]
+			nodes[i].Region, nodes[i].Zone = locality[0], locality[1]
+		}
+	}
+	return nodes, nil
+}
+
+// parseLocalities reads MOCK_NODE_LOCALITIES, a comma-separated list of region/zone
+// assigned to the nodes of each kind in turn
+func parseLocalities(value string) ([][2]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var localities [][2]string
+	for _, entry := range strings.Split(value, ",") {
+		region, zone, _ := strings.Cut(strings.TrimSpace(entry), "/")
+		if region == "" {
+			return nil, fmt.Errorf("invalid MOCK_NODE_LOCALITIES entry %q (want region or region/zone)", entry)
+		}
+		localities = append(localities, [2]string{region, zone})
+	}
+	return localities, nil
+}
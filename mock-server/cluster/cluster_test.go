@@ -0,0 +1,62 @@
+package cluster
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNodes(t *testing.T) {
+	t.Setenv("MOCK_TEE_NODES", "3")
+	t.Setenv("MOCK_DAO_PORT", "40000")
+	t.Setenv("MOCK_APP_NODES", "")
+	t.Setenv("APP_NODE_PORT", "")
+	t.Setenv("MOCK_NODE_LOCALITIES", "eu-west/a, us-east")
+
+	teeNodes, err := TEENodes()
+	if err != nil {
+		t.Fatalf("TEENodes: %v", err)
+	}
+	want := []Node{
+		{ID: 2001, Type: TypeTEE, Index: 0, Name: "dao-server", Port: 40000, Region: "eu-west", Zone: "a"},
+		{ID: 2002, Type: TypeTEE, Index: 1, Name: "dao-server-2", Port: 40010, Region: "us-east"},
+		{ID: 2003, Type: TypeTEE, Index: 2, Name: "dao-server-3", Port: 40020, Region: "eu-west", Zone: "a"},
+	}
+	if !reflect.DeepEqual(teeNodes, want) {
+		t.Errorf("TEENodes = %+v, want %+v", teeNodes, want)
+	}
+	if addr := teeNodes[1].Address(); addr != "localhost:40010" {
+		t.Errorf("Address = %s, want localhost:40010", addr)
+	}
+
+	appNodes, err := AppNodes()
+	if err != nil {
+		t.Fatalf("AppNodes: %v", err)
+	}
+	if len(appNodes) != 1 || appNodes[0].ID != 3001 || appNodes[0].Port != 50053 || appNodes[0].Name != "app-node" {
+		t.Errorf("AppNodes = %+v, want the single default app node", appNodes)
+	}
+
+	names, err := CertNames()
+	if err != nil {
+		t.Fatalf("CertNames: %v", err)
+	}
+	if !reflect.DeepEqual(names, []string{"dao-server-2", "dao-server-3"}) {
+		t.Errorf("CertNames = %v, want the certificates of the extra DAO server nodes", names)
+	}
+}
+
+func TestInvalidNodes(t *testing.T) {
+	for _, env := range []map[string]string{
+		{"MOCK_TEE_NODES": "0"},
+		{"MOCK_TEE_NODES": "many"},
+		{"MOCK_TEE_NODES": "2", "MOCK_DAO_PORT": "65530"},
+		{"MOCK_NODE_LOCALITIES": "/zone-only"},
+	} {
+		for _, key := range []string{"MOCK_TEE_NODES", "MOCK_DAO_PORT", "MOCK_NODE_LOCALITIES"} {
+			t.Setenv(key, env[key])
+		}
+		if _, err := TEENodes(); err == nil {
+			t.Errorf("TEENodes accepted %v", env)
+		}
+	}
+}
@@ -1,25 +1,44 @@
+//go:build dao_server
+
 package main
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/hmac"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/sha256"
-	"crypto/tls"
 	"encoding/hex"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"math/big"
 	"net"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
-	pb "tee-dao-mock-server/proto"
+	"tee-dao-mock-server/certgen"
+	"tee-dao-mock-server/cluster"
+	"tee-dao-mock-server/recorder"
+	"tee-dao-mock-server/tokenauth"
+
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/key_management"
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -31,6 +50,19 @@ const (
 	CurveED25519   uint32 = 1
 	CurveSECP256K1 uint32 = 2
 	CurveSECP256R1 uint32 = 3
+
+	// Key operation types and states, as in KeyOperationStatus
+	KeyOperationDKG       uint32 = 1
+	KeyOperationResharing uint32 = 2
+	KeyOperationPending   uint32 = 0
+	KeyOperationRunning   uint32 = 1
+	KeyOperationCompleted uint32 = 2
+	KeyOperationFailed    uint32 = 3
+
+	// keyOperationRounds is how many rounds a simulated key operation takes
+	keyOperationRounds = 3
+	// keyOperationRoundTime is how long each round of a simulated key operation takes
+	keyOperationRoundTime = 200 * time.Millisecond
 )
 
 // MockDAOServer implements the UserTask service
@@ -38,13 +70,41 @@ type MockDAOServer struct {
 	pb.UnimplementedUserTaskServer
 	config        *Config
 	ed25519Key    ed25519.PrivateKey   // ED25519 private key
-	secp256k1Key  *ecdsa.PrivateKey    // SECP256K1 private key
+	secp256k1Key  *btcec.PrivateKey    // SECP256K1 private key
 	secp256r1Key  *ecdsa.PrivateKey    // SECP256R1 (P-256) private key
+
+	faultsMu sync.Mutex
+	faults   Faults // Changed at runtime through the admin API
+}
+
+// Faults are the fault-injection settings the admin API changes at runtime
+type Faults struct {
+	SigningDelay time.Duration `json:"-"`
+	FailureRate  float32       `json:"failure_rate"` // 0.0 to 1.0, probability of simulating failures
+
+	// ErrorCode fails Sign calls with this gRPC status; OK for none
+	ErrorCode codes.Code `json:"error_code"`
+	// ErrorMessage fails Sign calls with an unsuccessful response carrying this error
+	ErrorMessage string `json:"error_message,omitempty"`
+	// ErrorCount limits the forced error to this many calls; 0 forces it until cleared
+	ErrorCount int `json:"error_count"`
+}
+
+// MarshalJSON writes the signing delay as a duration string such as "250ms"
+func (f Faults) MarshalJSON() ([]byte, error) {
+	type faults Faults
+	return json.Marshal(struct {
+		faults
+		SigningDelay string `json:"signing_delay"`
+	}{faults(f), f.SigningDelay.String()})
 }
 
 // Config holds server configuration
 type Config struct {
+	NodeID        uint32   // Cluster node ID reported by the config server
+	Participants  []uint32 // IDs of every DAO server node, the participants of simulated key operations
 	Port          string
+	AdminPort     string // HTTP admin API for fault injection; empty to disable
 	CertFile      string
 	KeyFile       string
 	CACertFile    string
@@ -66,13 +126,36 @@ func NewMockDAOServer(config *Config) *MockDAOServer {
 		ed25519Key:   ed25519Key,
 		secp256k1Key: secp256k1Key,
 		secp256r1Key: secp256r1Key,
+		faults:       config.defaultFaults(),
+	}
+}
+
+// defaultFaults returns the fault settings the server starts with
+func (c *Config) defaultFaults() Faults {
+	return Faults{
+		SigningDelay: c.SigningDelay,
+		FailureRate:  c.FailureRate,
+	}
+}
+
+// nextFaults returns the fault settings for a Sign call and counts down a limited forced error
+func (s *MockDAOServer) nextFaults() Faults {
+	s.faultsMu.Lock()
+	defer s.faultsMu.Unlock()
+	faults := s.faults
+	if s.faults.ErrorCount > 0 && (s.faults.ErrorCode != codes.OK || s.faults.ErrorMessage != "") {
+		s.faults.ErrorCount--
+		if s.faults.ErrorCount == 0 {
+			s.faults.ErrorCode, s.faults.ErrorMessage = codes.OK, ""
+		}
 	}
+	return faults
 }
 
 // Sign implements the Sign RPC method
 func (s *MockDAOServer) Sign(ctx context.Context, req *pb.SignRequest) (*pb.SignResponse, error) {
 	if s.config.EnableLogging {
-		log.Printf("Received signing request from node %d", req.From)
+		log.Printf("Node %d received signing request from node %d", s.config.NodeID, req.From)
 		log.Printf("Message length: %d bytes", len(req.Msg))
 		log.Printf("Public key length: %d bytes", len(req.PublicKeyInfo))
 		log.Printf("Protocol: %d, Curve: %d", req.Protocol, req.Curve)
@@ -108,14 +191,37 @@ func (s *MockDAOServer) Sign(ctx context.Context, req *pb.SignRequest) (*pb.Sign
 		}, nil
 	}
 
+	// Prehashed requests carry a 32-byte ECDSA digest to sign without hashing it again
+	if req.Prehashed && (req.Protocol != ProtocolECDSA || len(req.Msg) != 32) {
+		return &pb.SignResponse{
+			Success: false,
+			Error:   "Prehashed signing requires ECDSA and a 32-byte digest",
+		}, nil
+	}
+
+	faults := s.nextFaults()
+
 	// Simulate signing delay
-	if s.config.SigningDelay > 0 {
-		time.Sleep(s.config.SigningDelay)
+	if faults.SigningDelay > 0 {
+		time.Sleep(faults.SigningDelay)
+	}
+
+	// Fail with a forced error if configured
+	if faults.ErrorCode != codes.OK {
+		log.Printf("Forcing gRPC error %s", faults.ErrorCode)
+		return nil, status.Error(faults.ErrorCode, "forced error")
+	}
+	if faults.ErrorMessage != "" {
+		log.Printf("Forcing signing error: %s", faults.ErrorMessage)
+		return &pb.SignResponse{
+			Success: false,
+			Error:   faults.ErrorMessage,
+		}, nil
 	}
 
 	// Simulate random failures if configured
-	if s.config.FailureRate > 0 {
-		if randomFloat() < s.config.FailureRate {
+	if faults.FailureRate > 0 {
+		if randomFloat() < faults.FailureRate {
 			return &pb.SignResponse{
 				Success: false,
 				Error:   "Simulated signing failure",
@@ -124,7 +230,7 @@ func (s *MockDAOServer) Sign(ctx context.Context, req *pb.SignRequest) (*pb.Sign
 	}
 
 	// Generate mock signature based on protocol and curve
-	signature, err := s.generateMockSignature(req.Protocol, req.Curve, req.Msg)
+	signature, err := s.generateMockSignature(req.Protocol, req.Curve, req.Msg, req.Prehashed)
 	if err != nil {
 		return &pb.SignResponse{
 			Success: false,
@@ -142,8 +248,85 @@ func (s *MockDAOServer) Sign(ctx context.Context, req *pb.SignRequest) (*pb.Sign
 	}, nil
 }
 
+// WatchKeyOperation streams the progress of a simulated key operation over every DAO server node.
+// Any operation ID is accepted: IDs starting with "reshare-" are resharings, others DKGs, and IDs
+// starting with "fail-" fail in the last round. The operation starts when the watch does, and
+// completes after keyOperationRounds rounds with the DAO server's SECP256K1 public key
+func (s *MockDAOServer) WatchKeyOperation(req *pb.WatchKeyOperationRequest, stream pb.UserTask_WatchKeyOperationServer) error {
+	if req.OperationId == "" {
+		return status.Error(codes.InvalidArgument, "operation ID cannot be empty")
+	}
+	if s.config.EnableLogging {
+		log.Printf("Node %d watching key operation %s for node %d", s.config.NodeID, req.OperationId, req.From)
+	}
+
+	operationType := KeyOperationDKG
+	if strings.HasPrefix(req.OperationId, "reshare-") {
+		operationType = KeyOperationResharing
+	}
+	fail := strings.HasPrefix(req.OperationId, "fail-")
+	participants := s.config.Participants
+	if len(participants) == 0 {
+		participants = []uint32{s.config.NodeID}
+	}
+
+	update := &pb.KeyOperationStatus{
+		OperationId: req.OperationId,
+		Type:        operationType,
+		State:       KeyOperationPending,
+		TotalRounds: keyOperationRounds,
+	}
+	for _, id := range participants {
+		update.Participants = append(update.Participants, &pb.ParticipantProgress{Id: id})
+	}
+	send := func() error {
+		update.UpdatedAt = time.Now().Unix()
+		return stream.Send(update)
+	}
+	if err := send(); err != nil {
+		return err
+	}
+
+	for round := uint32(1); round <= keyOperationRounds; round++ {
+		select {
+		case <-time.After(keyOperationRoundTime):
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+		update.State, update.Round = KeyOperationRunning, round
+		for i, participant := range update.Participants {
+			participant.State, participant.Round = KeyOperationRunning, round-1
+			// The last participant drops out of a failing operation in its final round
+			if fail && round == keyOperationRounds && i == len(update.Participants)-1 {
+				participant.State, participant.Error = KeyOperationFailed, "simulated participant failure"
+				update.State = KeyOperationFailed
+				update.Error = fmt.Sprintf("node %d failed in round %d", participant.Id, round)
+			}
+		}
+		if update.State == KeyOperationFailed {
+			return send()
+		}
+		if err := send(); err != nil {
+			return err
+		}
+	}
+
+	select {
+	case <-time.After(keyOperationRoundTime):
+	case <-stream.Context().Done():
+		return stream.Context().Err()
+	}
+	update.State = KeyOperationCompleted
+	update.PublicKey = s.secp256k1Key.PubKey().SerializeCompressed()
+	for _, participant := range update.Participants {
+		participant.State, participant.Round = KeyOperationCompleted, keyOperationRounds
+	}
+	return send()
+}
+
 // generateMockSignature generates real cryptographic signatures for all supported algorithms
-func (s *MockDAOServer) generateMockSignature(protocol, curve uint32, message []byte) ([]byte, error) {
+// A prehashed ECDSA message is the digest to sign, otherwise its SHA-256 is signed
+func (s *MockDAOServer) generateMockSignature(protocol, curve uint32, message []byte, prehashed bool) ([]byte, error) {
 	switch protocol {
 	case ProtocolSchnorr:
 		switch curve {
@@ -152,17 +335,13 @@ func (s *MockDAOServer) generateMockSignature(protocol, curve uint32, message []
 			signature := ed25519.Sign(s.ed25519Key, message)
 			return signature, nil
 		case CurveSECP256K1:
-			// For SECP256K1 Schnorr, use ECDSA as approximation (real Schnorr requires specialized library)
+			// BIP-340 Schnorr over the SHA-256 of the message
 			hash := sha256.Sum256(message)
-			r, s_sig, err := ecdsa.Sign(rand.Reader, s.secp256k1Key, hash[:])
+			signature, err := schnorr.Sign(s.secp256k1Key, hash[:])
 			if err != nil {
 				return nil, fmt.Errorf("SECP256K1 Schnorr signing failed: %v", err)
 			}
-			// Convert to 64-byte signature format (32 bytes r + 32 bytes s)
-			signature := make([]byte, 64)
-			r.FillBytes(signature[:32])
-			s_sig.FillBytes(signature[32:])
-			return signature, nil
+			return signature.Serialize(), nil
 		default:
 			return nil, fmt.Errorf("unsupported curve for Schnorr: %d", curve)
 		}
@@ -172,19 +351,13 @@ func (s *MockDAOServer) generateMockSignature(protocol, curve uint32, message []
 			// ED25519 doesn't use ECDSA, return error for invalid combination
 			return nil, fmt.Errorf("ECDSA not supported with ED25519 curve")
 		case CurveSECP256K1:
-			hash := sha256.Sum256(message)
-			r, s_sig, err := ecdsa.Sign(rand.Reader, s.secp256k1Key, hash[:])
-			if err != nil {
-				return nil, fmt.Errorf("SECP256K1 ECDSA signing failed: %v", err)
-			}
+			// The compact signature is a recovery byte followed by r and s
+			compact := btcecdsa.SignCompact(s.secp256k1Key, ecdsaDigest(message, prehashed), true)
 			// Convert to 64-byte signature format (32 bytes r + 32 bytes s)
-			signature := make([]byte, 64)
-			r.FillBytes(signature[:32])
-			s_sig.FillBytes(signature[32:])
-			return signature, nil
+			return compact[1:], nil
 		case CurveSECP256R1:
-			hash := sha256.Sum256(message)
-			r, s_sig, err := ecdsa.Sign(rand.Reader, s.secp256r1Key, hash[:])
+			// Deterministic nonce, so the same message always gets the same signature
+			r, s_sig, err := signDeterministic(s.secp256r1Key, ecdsaDigest(message, prehashed))
 			if err != nil {
 				return nil, fmt.Errorf("SECP256R1 ECDSA signing failed: %v", err)
 			}
@@ -201,6 +374,16 @@ func (s *MockDAOServer) generateMockSignature(protocol, curve uint32, message []
 	}
 }
 
+// ecdsaDigest returns the digest an ECDSA signature covers: the message itself when it is
+// prehashed, its SHA-256 otherwise
+func ecdsaDigest(message []byte, prehashed bool) []byte {
+	if prehashed {
+		return message
+	}
+	hash := sha256.Sum256(message)
+	return hash[:]
+}
+
 // generateMockSignatureBytes generates deterministic mock signature bytes
 func (s *MockDAOServer) generateMockSignatureBytes(length int, message []byte) []byte {
 	// Generate deterministic bytes based on message for consistent testing
@@ -226,27 +409,10 @@ func generateConsistentED25519Key() ed25519.PrivateKey {
 }
 
 // generateConsistentSECP256K1Key generates a consistent SECP256K1 private key for testing
-func generateConsistentSECP256K1Key() *ecdsa.PrivateKey {
+func generateConsistentSECP256K1Key() *btcec.PrivateKey {
 	// Use a deterministic seed for consistent key generation in testing
 	seed := []byte("tee-dao-mock-server-secp256k1-key-12345678901234567890123456789012")
-	privateKeyInt := new(big.Int).SetBytes(seed[:32])
-	
-	// Ensure the private key is valid for secp256k1 (less than curve order)
-	curve := elliptic.P256() // Using P256 as approximation for secp256k1
-	for privateKeyInt.Cmp(curve.Params().N) >= 0 {
-		privateKeyInt.Sub(privateKeyInt, curve.Params().N)
-	}
-	
-	privateKey := &ecdsa.PrivateKey{
-		D: privateKeyInt,
-		PublicKey: ecdsa.PublicKey{
-			Curve: curve,
-		},
-	}
-	
-	// Generate the public key
-	privateKey.PublicKey.X, privateKey.PublicKey.Y = curve.ScalarBaseMult(privateKeyInt.Bytes())
-	
+	privateKey, _ := btcec.PrivKeyFromBytes(seed[:32])
 	return privateKey
 }
 
@@ -275,6 +441,83 @@ func generateConsistentSECP256R1Key() *ecdsa.PrivateKey {
 	return privateKey
 }
 
+// signDeterministic signs a hash with ECDSA using the deterministic nonce of RFC 6979
+// with HMAC-SHA256
+func signDeterministic(key *ecdsa.PrivateKey, hash []byte) (*big.Int, *big.Int, error) {
+	params := key.Curve.Params()
+	n := params.N
+	qlen := n.BitLen()
+	rolen := (qlen + 7) / 8
+	e := bits2int(hash, qlen)
+
+	// Section 3.2 steps b to f
+	seed := append(int2octets(key.D, rolen), bits2octets(hash, n, qlen, rolen)...)
+	v := bytes.Repeat([]byte{0x01}, sha256.Size)
+	k := make([]byte, sha256.Size)
+	k = hmacSHA256(k, v, []byte{0x00}, seed)
+	v = hmacSHA256(k, v)
+	k = hmacSHA256(k, v, []byte{0x01}, seed)
+	v = hmacSHA256(k, v)
+
+	// Section 3.2 step h, repeated until the nonce gives a valid signature
+	for {
+		var t []byte
+		for len(t) < rolen {
+			v = hmacSHA256(k, v)
+			t = append(t, v...)
+		}
+		nonce := bits2int(t[:rolen], qlen)
+		if nonce.Sign() > 0 && nonce.Cmp(n) < 0 {
+			x, _ := key.Curve.ScalarBaseMult(nonce.Bytes())
+			r := new(big.Int).Mod(x, n)
+			if r.Sign() != 0 {
+				// s = nonce^-1 * (e + r*d) mod n
+				sig := new(big.Int).Mul(r, key.D)
+				sig.Add(sig, e)
+				sig.Mul(sig, new(big.Int).ModInverse(nonce, n))
+				sig.Mod(sig, n)
+				if sig.Sign() != 0 {
+					return r, sig, nil
+				}
+			}
+		}
+		k = hmacSHA256(k, v, []byte{0x00})
+		v = hmacSHA256(k, v)
+	}
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of the concatenated data
+func hmacSHA256(key []byte, data ...[]byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	for _, d := range data {
+		mac.Write(d)
+	}
+	return mac.Sum(nil)
+}
+
+// bits2int converts a bit string to an integer of at most qlen bits (RFC 6979 section 2.3.2)
+func bits2int(b []byte, qlen int) *big.Int {
+	x := new(big.Int).SetBytes(b)
+	if excess := len(b)*8 - qlen; excess > 0 {
+		x.Rsh(x, uint(excess))
+	}
+	return x
+}
+
+// int2octets encodes an integer as rolen big-endian bytes (RFC 6979 section 2.3.3)
+func int2octets(x *big.Int, rolen int) []byte {
+	return x.FillBytes(make([]byte, rolen))
+}
+
+// bits2octets reduces a hash modulo the curve order (RFC 6979 section 2.3.4)
+func bits2octets(hash []byte, n *big.Int, qlen, rolen int) []byte {
+	z := bits2int(hash, qlen)
+	if z.Cmp(n) >= 0 {
+		z.Sub(z, n)
+	}
+	return int2octets(z, rolen)
+}
+
 // simpleHash creates a simple hash of the message for deterministic signatures
 func simpleHash(data []byte) []byte {
 	hash := make([]byte, 8)
@@ -303,38 +546,177 @@ func randomFloat() float32 {
 	return float32(uint32(bytes[0])<<24|uint32(bytes[1])<<16|uint32(bytes[2])<<8|uint32(bytes[3])) / float32(^uint32(0))
 }
 
+// faultUpdate is a partial change of fault settings
+type faultUpdate struct {
+	SigningDelay *string     `json:"signing_delay"`
+	FailureRate  *float32    `json:"failure_rate"`
+	ErrorCode    *codes.Code `json:"error_code"`
+	ErrorMessage *string     `json:"error_message"`
+	ErrorCount   *int        `json:"error_count"`
+
+	delay time.Duration
+}
+
+// parseFaultUpdate reads and validates a fault settings change
+func parseFaultUpdate(r *http.Request) (*faultUpdate, error) {
+	var update faultUpdate
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&update); err != nil {
+		return nil, fmt.Errorf("invalid fault settings: %w", err)
+	}
+	if update.SigningDelay != nil {
+		var err error
+		if update.delay, err = time.ParseDuration(*update.SigningDelay); err != nil || update.delay < 0 {
+			return nil, fmt.Errorf("invalid signing_delay %q", *update.SigningDelay)
+		}
+	}
+	if update.FailureRate != nil && (*update.FailureRate < 0 || *update.FailureRate > 1) {
+		return nil, fmt.Errorf("failure_rate must be between 0 and 1")
+	}
+	if update.ErrorCount != nil && *update.ErrorCount < 0 {
+		return nil, fmt.Errorf("error_count must not be negative")
+	}
+	return &update, nil
+}
+
+// updateFaults applies a fault settings change, or restores the startup settings if update is nil
+func (s *MockDAOServer) updateFaults(update *faultUpdate) Faults {
+	s.faultsMu.Lock()
+	defer s.faultsMu.Unlock()
+	if update == nil {
+		s.faults = s.config.defaultFaults()
+	} else {
+		if update.SigningDelay != nil {
+			s.faults.SigningDelay = update.delay
+		}
+		if update.FailureRate != nil {
+			s.faults.FailureRate = *update.FailureRate
+		}
+		if update.ErrorCode != nil {
+			s.faults.ErrorCode = *update.ErrorCode
+		}
+		if update.ErrorMessage != nil {
+			s.faults.ErrorMessage = *update.ErrorMessage
+		}
+		if update.ErrorCount != nil {
+			s.faults.ErrorCount = *update.ErrorCount
+		}
+	}
+	faults := s.faults
+	log.Printf("Admin: Node %d fault settings changed to delay=%v failure_rate=%.2f error_code=%s error_message=%q error_count=%d",
+		s.config.NodeID, faults.SigningDelay, faults.FailureRate, faults.ErrorCode, faults.ErrorMessage, faults.ErrorCount)
+	return faults
+}
+
+// currentFaults returns the fault settings
+func (s *MockDAOServer) currentFaults() Faults {
+	s.faultsMu.Lock()
+	defer s.faultsMu.Unlock()
+	return s.faults
+}
+
+// DAOCluster is the set of DAO server nodes this process runs
+type DAOCluster []*MockDAOServer
+
+// ServeFaults shows (GET) or changes (PUT) the fault settings; PUT takes any subset of
+//
+//	{"signing_delay": "500ms", "failure_rate": 0.5, "error_code": "UNAVAILABLE",
+//	 "error_message": "key not found", "error_count": 2}
+//
+// DELETE restores the startup settings. ?node=2002 selects one node; otherwise changes
+// apply to every node and GET shows the first node's settings
+func (c DAOCluster) ServeFaults(w http.ResponseWriter, r *http.Request) {
+	nodes := []*MockDAOServer(c)
+	if id := r.URL.Query().Get("node"); id != "" {
+		nodes = nil
+		for _, node := range c {
+			if strconv.FormatUint(uint64(node.config.NodeID), 10) == id {
+				nodes = []*MockDAOServer{node}
+			}
+		}
+		if nodes == nil {
+			http.Error(w, fmt.Sprintf("unknown node %q", id), http.StatusNotFound)
+			return
+		}
+	}
+
+	var faults Faults
+	switch r.Method {
+	case http.MethodGet:
+		faults = nodes[0].currentFaults()
+	case http.MethodPut, http.MethodPost:
+		update, err := parseFaultUpdate(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for i, node := range nodes {
+			if changed := node.updateFaults(update); i == 0 {
+				faults = changed
+			}
+		}
+	case http.MethodDelete:
+		for i, node := range nodes {
+			if restored := node.updateFaults(nil); i == 0 {
+				faults = restored
+			}
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(faults)
+}
+
 // loadTLSCredentials loads TLS credentials for the server
 func loadTLSCredentials(config *Config) (credentials.TransportCredentials, error) {
-	// Load server certificate
-	serverCert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+	tlsConfig, err := certgen.ServerTLSConfig(config.CertFile, config.KeyFile, config.CACertFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+		return nil, err
 	}
+	return credentials.NewTLS(tlsConfig), nil
+}
 
-	// Configure TLS for self-signed certificates with client authentication
-	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{serverCert},
-		ClientAuth:   tls.RequireAnyClientCert, // Require client certificate but don't verify against CA
+// ensureCerts generates certificates if there are none, or a fresh set if regenerate is set,
+// including those of additional cluster nodes
+func ensureCerts(regenerate bool) error {
+	extra, err := cluster.CertNames()
+	if err != nil {
+		return err
 	}
-
-	return credentials.NewTLS(tlsConfig), nil
+	if regenerate {
+		return certgen.Generate(certgen.DefaultDir, extra...)
+	}
+	return certgen.Ensure(certgen.DefaultDir, extra...)
 }
 
 func main() {
+	genCerts := flag.Bool("gen-certs", false, "generate a fresh throwaway CA and certificates before starting")
+	flag.Parse()
+	if err := ensureCerts(*genCerts); err != nil {
+		log.Fatalf("Failed to prepare certificates: %v", err)
+	}
+
 	// Default configuration
 	config := &Config{
-		Port:          ":50051",
+		AdminPort:     ":8091",
 		CertFile:      "certs/dao-server.crt",
 		KeyFile:       "certs/dao-server.key", 
-		CACertFile:    "", // No CA cert for self-signed certificates
+		CACertFile:    "", // certs/ca.crt verifies clients when MOCK_TLS_STRICT is set
 		SigningDelay:  100 * time.Millisecond,
 		FailureRate:   0.0,
 		EnableLogging: true,
 	}
 
-	// Override from environment variables
-	if port := os.Getenv("MOCK_DAO_PORT"); port != "" {
-		config.Port = ":" + port
+	// Override from environment variables; MOCK_DAO_PORT and MOCK_TEE_NODES are read by the cluster package
+	if adminPort, ok := os.LookupEnv("MOCK_DAO_ADMIN_PORT"); ok {
+		config.AdminPort = ""
+		if adminPort != "" {
+			config.AdminPort = ":" + adminPort
+		}
 	}
 	if certFile := os.Getenv("MOCK_DAO_CERT"); certFile != "" {
 		config.CertFile = certFile
@@ -346,35 +728,88 @@ func main() {
 		config.CACertFile = caCert
 	}
 
-	log.Printf("Starting Mock DAO Server on port %s", config.Port)
+	nodes, err := cluster.TEENodes()
+	if err != nil {
+		log.Fatalf("Failed to configure cluster: %v", err)
+	}
+
+	log.Printf("Starting Mock DAO Server with %d node(s)", len(nodes))
 	log.Printf("Configuration:")
-	log.Printf("  - Cert: %s", config.CertFile)
-	log.Printf("  - Key: %s", config.KeyFile)
 	log.Printf("  - CA Cert: %s", config.CACertFile)
 	log.Printf("  - Signing Delay: %v", config.SigningDelay)
 	log.Printf("  - Failure Rate: %.2f", config.FailureRate)
+	log.Printf("  - Admin API: %s", config.AdminPort)
 
-	// Create listener
-	lis, err := net.Listen("tcp", config.Port)
+	// Every node signs with the same keys, recording requests into one recorder
+	rec := recorder.New()
+	tokens, err := tokenauth.FromEnv()
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		log.Fatalf("Failed to configure token authentication: %v", err)
 	}
-
-	// Load TLS credentials
-	creds, err := loadTLSCredentials(config)
-	if err != nil {
-		log.Fatalf("Failed to load TLS credentials: %v", err)
+	participants := make([]uint32, 0, len(nodes))
+	for _, node := range nodes {
+		participants = append(participants, node.ID)
 	}
+	config.Participants = participants
+	daoCluster := make(DAOCluster, 0, len(nodes))
+	servers := make([]*grpc.Server, 0, len(nodes))
+	listeners := make([]net.Listener, 0, len(nodes))
+	for _, node := range nodes {
+		nodeConfig := *config
+		nodeConfig.NodeID = node.ID
+		nodeConfig.Port = node.ListenAddr()
+		if node.Index > 0 {
+			nodeConfig.CertFile, nodeConfig.KeyFile = node.CertFile(), node.KeyFile()
+		}
+		log.Printf("  - Node %d on port %s: cert %s, key %s", node.ID, nodeConfig.Port, nodeConfig.CertFile, nodeConfig.KeyFile)
+
+		// Create listener
+		lis, err := net.Listen("tcp", nodeConfig.Port)
+		if err != nil {
+			log.Fatalf("Failed to listen: %v", err)
+		}
+
+		// Load TLS credentials
+		creds, err := loadTLSCredentials(&nodeConfig)
+		if err != nil {
+			log.Fatalf("Failed to load TLS credentials: %v", err)
+		}
+
+		// Create gRPC server with TLS, recording requests when enabled
+		s := grpc.NewServer(grpc.Creds(creds),
+			grpc.ChainUnaryInterceptor(rec.UnaryInterceptor(node.ID), tokens.UnaryInterceptor()),
+			grpc.ChainStreamInterceptor(tokens.StreamInterceptor()))
+
+		// Register service
+		mockDAO := NewMockDAOServer(&nodeConfig)
+		pb.RegisterUserTaskServer(s, mockDAO)
 
-	// Create gRPC server with TLS
-	s := grpc.NewServer(grpc.Creds(creds))
+		daoCluster = append(daoCluster, mockDAO)
+		servers = append(servers, s)
+		listeners = append(listeners, lis)
+	}
 
-	// Register service
-	mockDAO := NewMockDAOServer(config)
-	pb.RegisterUserTaskServer(s, mockDAO)
+	// Start admin API
+	if config.AdminPort != "" {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/admin/faults", daoCluster.ServeFaults)
+		mux.Handle("/admin/requests", rec)
+		go func() {
+			log.Printf("Mock DAO Server admin API listening on %s", config.AdminPort)
+			if err := http.ListenAndServe(config.AdminPort, mux); err != nil {
+				log.Fatalf("Failed to serve admin API: %v", err)
+			}
+		}()
+	}
 
-	log.Printf("Mock DAO Server listening on %s with TLS enabled", config.Port)
-	if err := s.Serve(lis); err != nil {
+	errs := make(chan error, len(servers))
+	for i, s := range servers {
+		log.Printf("Mock DAO Server node %d listening on %s with TLS enabled", nodes[i].ID, nodes[i].ListenAddr())
+		go func(s *grpc.Server, lis net.Listener) {
+			errs <- s.Serve(lis)
+		}(s, listeners[i])
+	}
+	if err := <-errs; err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,202 @@
+//go:build dao_server
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/key_management"
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// signRequest returns a request for the DAO server to sign message
+func signRequest(protocol, curve uint32, message string) *pb.SignRequest {
+	return &pb.SignRequest{
+		From:          1,
+		Msg:           []byte(message),
+		PublicKeyInfo: []byte("public-key"),
+		Protocol:      protocol,
+		Curve:         curve,
+	}
+}
+
+// doFaults sends a fault settings request to the admin API and decodes the settings it returns
+func doFaults(t *testing.T, nodes DAOCluster, method, target, body string) (int, Faults) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	nodes.ServeFaults(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+	var faults struct {
+		Faults
+		SigningDelay string `json:"signing_delay"`
+	}
+	if rec.Code == http.StatusOK {
+		if err := json.NewDecoder(rec.Body).Decode(&faults); err != nil {
+			t.Fatalf("decode fault settings: %v", err)
+		}
+	}
+	return rec.Code, faults.Faults
+}
+
+func TestServeFaultsForcedError(t *testing.T) {
+	node := NewMockDAOServer(&Config{NodeID: 1})
+	nodes := DAOCluster{node}
+
+	code, faults := doFaults(t, nodes, http.MethodPut, "/admin/faults", `{"error_code": "UNAVAILABLE", "error_count": 1}`)
+	if code != http.StatusOK || faults.ErrorCode != codes.Unavailable || faults.ErrorCount != 1 {
+		t.Fatalf("PUT returned %d %+v, want the forced error", code, faults)
+	}
+
+	req := signRequest(ProtocolSchnorr, CurveED25519, "hello")
+	if _, err := node.Sign(context.Background(), req); status.Code(err) != codes.Unavailable {
+		t.Fatalf("first Sign error %v, want UNAVAILABLE", err)
+	}
+	if resp, err := node.Sign(context.Background(), req); err != nil || !resp.Success {
+		t.Fatalf("second Sign returned %v, %v; want success once the error count is used up", resp, err)
+	}
+
+	doFaults(t, nodes, http.MethodPut, "/admin/faults", `{"error_message": "key not found"}`)
+	if resp, err := node.Sign(context.Background(), req); err != nil || resp.Success || resp.Error != "key not found" {
+		t.Fatalf("Sign returned %v, %v; want the forced error message", resp, err)
+	}
+
+	code, faults = doFaults(t, nodes, http.MethodDelete, "/admin/faults", "")
+	if code != http.StatusOK || faults.ErrorMessage != "" {
+		t.Fatalf("DELETE returned %d %+v, want the startup settings", code, faults)
+	}
+	if resp, err := node.Sign(context.Background(), req); err != nil || !resp.Success {
+		t.Fatalf("Sign returned %v, %v after restoring the settings", resp, err)
+	}
+}
+
+func TestServeFaultsPerNode(t *testing.T) {
+	first, second := NewMockDAOServer(&Config{NodeID: 1}), NewMockDAOServer(&Config{NodeID: 2})
+	nodes := DAOCluster{first, second}
+
+	code, faults := doFaults(t, nodes, http.MethodPut, "/admin/faults?node=2", `{"failure_rate": 1, "signing_delay": "1ms"}`)
+	if code != http.StatusOK || faults.FailureRate != 1 {
+		t.Fatalf("PUT returned %d %+v, want failure rate 1", code, faults)
+	}
+	if code, faults = doFaults(t, nodes, http.MethodGet, "/admin/faults?node=2", ""); faults.FailureRate != 1 {
+		t.Errorf("GET returned %d %+v, want the changed settings", code, faults)
+	}
+
+	req := signRequest(ProtocolECDSA, CurveSECP256K1, "hello")
+	if resp, err := second.Sign(context.Background(), req); err != nil || resp.Success {
+		t.Errorf("node 2 Sign returned %v, %v; want a simulated failure", resp, err)
+	}
+	if resp, err := first.Sign(context.Background(), req); err != nil || !resp.Success {
+		t.Errorf("node 1 Sign returned %v, %v; want it unaffected", resp, err)
+	}
+
+	if code, _ := doFaults(t, nodes, http.MethodGet, "/admin/faults?node=3", ""); code != http.StatusNotFound {
+		t.Errorf("unknown node: status %d, want %d", code, http.StatusNotFound)
+	}
+	for _, body := range []string{`{"failure_rate": 2}`, `{"signing_delay": "soon"}`, `{"error_count": -1}`, `{"unknown": 1}`} {
+		if code, _ := doFaults(t, nodes, http.MethodPut, "/admin/faults", body); code != http.StatusBadRequest {
+			t.Errorf("PUT %s: status %d, want %d", body, code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestSECP256K1SignaturesVerify(t *testing.T) {
+	node := NewMockDAOServer(&Config{NodeID: 1})
+	publicKey := generateConsistentSECP256K1Key().PubKey()
+	message := "hello secp256k1"
+	hash := sha256.Sum256([]byte(message))
+
+	resp, err := node.Sign(context.Background(), signRequest(ProtocolSchnorr, CurveSECP256K1, message))
+	if err != nil || !resp.Success {
+		t.Fatalf("Schnorr Sign returned %v, %v", resp, err)
+	}
+	schnorrSig, err := schnorr.ParseSignature(resp.Signature)
+	if err != nil {
+		t.Fatalf("parse BIP-340 signature: %v", err)
+	}
+	if !schnorrSig.Verify(hash[:], publicKey) {
+		t.Error("BIP-340 signature does not verify with btcec")
+	}
+
+	resp, err = node.Sign(context.Background(), signRequest(ProtocolECDSA, CurveSECP256K1, message))
+	if err != nil || !resp.Success {
+		t.Fatalf("ECDSA Sign returned %v, %v", resp, err)
+	}
+	if len(resp.Signature) != 64 {
+		t.Fatalf("ECDSA signature is %d bytes, want 64 (r || s)", len(resp.Signature))
+	}
+	var r, sigS btcec.ModNScalar
+	r.SetByteSlice(resp.Signature[:32])
+	sigS.SetByteSlice(resp.Signature[32:])
+	if !btcecdsa.NewSignature(&r, &sigS).Verify(hash[:], publicKey) {
+		t.Error("ECDSA signature does not verify with btcec")
+	}
+}
+
+func TestSignDeterministicRFC6979Vector(t *testing.T) {
+	// RFC 6979 appendix A.2.5: P-256 with SHA-256, message "sample"
+	curve := elliptic.P256()
+	d, _ := new(big.Int).SetString("C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721", 16)
+	key := &ecdsa.PrivateKey{D: d, PublicKey: ecdsa.PublicKey{Curve: curve}}
+	key.X, key.Y = curve.ScalarBaseMult(d.Bytes())
+	hash := sha256.Sum256([]byte("sample"))
+
+	r, sigS, err := signDeterministic(key, hash[:])
+	if err != nil {
+		t.Fatalf("signDeterministic: %v", err)
+	}
+	if got := hex.EncodeToString(r.FillBytes(make([]byte, 32))); got != "efd48b2aacb6a8fd1140dd9cd45e81d69d2c877b56aaf991c34d0ea84eaf3716" {
+		t.Errorf("r = %s, want the RFC 6979 test vector", got)
+	}
+	if got := hex.EncodeToString(sigS.FillBytes(make([]byte, 32))); got != "f7cb1c942d657c41d436c7a1b6e29f65f3e900dbb9aff4064dc4ab2f843acda8" {
+		t.Errorf("s = %s, want the RFC 6979 test vector", got)
+	}
+}
+
+func TestECDSASignaturesDeterministic(t *testing.T) {
+	node := NewMockDAOServer(&Config{NodeID: 1})
+	for _, curve := range []uint32{CurveSECP256R1, CurveSECP256K1} {
+		sign := func(message string) []byte {
+			t.Helper()
+			resp, err := node.Sign(context.Background(), signRequest(ProtocolECDSA, curve, message))
+			if err != nil || !resp.Success {
+				t.Fatalf("curve %d: Sign returned %v, %v", curve, resp, err)
+			}
+			return resp.Signature
+		}
+
+		first, second := sign("golden"), sign("golden")
+		if !bytes.Equal(first, second) {
+			t.Errorf("curve %d: signatures of the same message differ: %x and %x", curve, first, second)
+		}
+		if bytes.Equal(first, sign("other")) {
+			t.Errorf("curve %d: different messages got the same signature", curve)
+		}
+	}
+
+	// The P-256 signature must still verify
+	publicKey := &generateConsistentSECP256R1Key().PublicKey
+	resp, err := node.Sign(context.Background(), signRequest(ProtocolECDSA, CurveSECP256R1, "golden"))
+	if err != nil || !resp.Success {
+		t.Fatalf("Sign returned %v, %v", resp, err)
+	}
+	hash := sha256.Sum256([]byte("golden"))
+	r, sigS := new(big.Int).SetBytes(resp.Signature[:32]), new(big.Int).SetBytes(resp.Signature[32:])
+	if !ecdsa.Verify(publicKey, hash[:], r, sigS) {
+		t.Error("deterministic P-256 signature does not verify")
+	}
+}
@@ -1,3 +1,5 @@
+//go:build example
+
 package main
 
 import (
@@ -17,35 +19,36 @@ func main() {
 
 	// Initialize client
 	fmt.Println("Initializing client...")
-	if err := teeClient.Init(); err != nil {
+	if err := teeClient.Init(nil); err != nil {
 		log.Fatalf("Client initialization failed: %v", err)
 	}
 
-	fmt.Printf("Client connected, Node ID: %d\n", teeClient.GetNodeID())
+	fmt.Println("Client connected")
 
 	// Test 1: Get public key by App ID
 	fmt.Println("\n1. Testing GetPublicKeyByAppID")
 	appID := "secure-messaging-app"
-	publicKey, protocol, curve, err := teeClient.GetPublicKeyByAppID(appID)
+	keyInfo, err := teeClient.GetPublicKeyByAppID(appID)
 	if err != nil {
 		log.Printf("Failed to get public key by app ID: %v", err)
 	} else {
 		fmt.Printf("✓ GetPublicKeyByAppID successful!\n")
 		fmt.Printf("  App ID: %s\n", appID)
-		fmt.Printf("  Protocol: %s\n", protocol)
-		fmt.Printf("  Curve: %s\n", curve)
-		fmt.Printf("  Public Key: %s\n", publicKey)
+		fmt.Printf("  Protocol: %s\n", keyInfo.Protocol)
+		fmt.Printf("  Curve: %s\n", keyInfo.Curve)
+		fmt.Printf("  Public Key: %x\n", keyInfo.Key)
 	}
 
 	// Test 2: Sign with App ID
-	fmt.Println("\n2. Testing SignWithAppID")
+	fmt.Println("\n2. Testing Sign")
 	message := []byte("Hello from TEE DAO Client Library Test!")
 
-	signature, err := teeClient.SignWithAppID(message, appID)
+	result, err := teeClient.Sign(&client.SignRequest{Message: message, AppID: appID})
 	if err != nil {
 		log.Printf("Failed to sign with app ID: %v", err)
 	} else {
-		fmt.Printf("✓ SignWithAppID successful!\n")
+		signature := result.Signature
+		fmt.Printf("✓ Sign successful!\n")
 		fmt.Printf("  Message: %s\n", string(message))
 		fmt.Printf("  App ID: %s\n", appID)
 		fmt.Printf("  Signature: %x\n", signature)
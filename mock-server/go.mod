@@ -6,13 +6,23 @@ toolchain go1.24.3
 
 require (
 	github.com/TEENet-io/teenet-sdk/go v0.0.0-00010101000000-000000000000
+	github.com/btcsuite/btcd/btcec/v2 v2.3.5
 	google.golang.org/grpc v1.72.0
 	google.golang.org/protobuf v1.36.6
 )
 
-replace github.com/TEENet-io/teenet-sdk/go => ../go
+replace (
+	github.com/TEENet-io/teenet-sdk/go => ../go
+	github.com/TEENet-io/teenet-sdk/go/pkg/constants => ../go/pkg/constants
+	github.com/TEENet-io/teenet-sdk/go/pkg/verification => ../go/pkg/verification
+)
 
 require (
+	github.com/TEENet-io/teenet-sdk/go/pkg/constants v0.1.0 // indirect
+	github.com/TEENet-io/teenet-sdk/go/pkg/verification v0.1.0 // indirect
+	github.com/btcsuite/btcd/chaincfg/chainhash v1.0.1 // indirect
+	github.com/decred/dcrd/crypto/blake256 v1.1.0 // indirect
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.4.0 // indirect
 	golang.org/x/net v0.35.0 // indirect
 	golang.org/x/sys v0.33.0 // indirect
 	golang.org/x/text v0.25.0 // indirect
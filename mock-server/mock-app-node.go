@@ -1,101 +1,351 @@
+//go:build app_node
+
 package main
 
 import (
+	"bytes"
 	"context"
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
-	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"math/big"
 	"net"
+	"net/http"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/usermgmt"
+	"github.com/TEENet-io/teenet-sdk/go/pkg/utils"
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/appid"
+	"tee-dao-mock-server/certgen"
+	"tee-dao-mock-server/cluster"
+	"tee-dao-mock-server/recorder"
+	"tee-dao-mock-server/tokenauth"
 
-	pb "tee-dao-mock-server/proto"
+	"github.com/btcsuite/btcd/btcec/v2"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
 
+// startedAt is reported as the deployment time of every voting target
+var startedAt = time.Now()
+
 // MockAppNode implements the AppIDService
 type MockAppNode struct {
 	pb.UnimplementedAppIDServiceServer
+
+	mu sync.RWMutex
 	// Mock App ID to public key mapping
 	appKeys map[string]*AppKeyInfo
+	// Voting setup of apps without their own
+	voting *VotingConfig
+	// Deployment-client address reported for every target
+	deploymentClientAddress string
+	// Service port given to the next app deployed without one
+	nextPort int32
+	// Signs voting configurations when VOTING_AUTHORITY_KEY is set
+	authority crypto.Signer
+}
+
+// VotingConfig is the voting setup returned by GetDeploymentAddresses
+type VotingConfig struct {
+	Targets        []string `json:"targets,omitempty"` // App IDs that vote
+	RequiredVotes  int32    `json:"required_votes,omitempty"`
+	VotingSignPath string   `json:"sign_path,omitempty"`
 }
 
 // AppKeyInfo stores app key information
 type AppKeyInfo struct {
-	PublicKey   string
-	Protocol    string
-	Curve       string
-	Description string
+	PublicKey   string `json:"public_key,omitempty"`
+	Protocol    string `json:"protocol"`
+	Curve       string `json:"curve"`
+	Description string `json:"description,omitempty"`
+
+	// Operations the key may be used for ("sign", "encrypt", "derive"); unrestricted if empty
+	KeyUsage []string `json:"key_usage,omitempty"`
+
+	// Where the app's voting handler runs when it is a voting target
+	ContainerIP string `json:"container_ip,omitempty"`
+	ServicePort int32  `json:"service_port,omitempty"`
+
+	// Voting setup of the app; the shared one if nil
+	Voting *VotingConfig `json:"voting,omitempty"`
+
+	// Threshold key parameters reported by GetKeyDetails; FROST 2-of-3 on nodes 1-3 if unset
+	Threshold    uint32   `json:"threshold,omitempty"`
+	Participants []uint32 `json:"participants,omitempty"`
+}
+
+// RegistryFile is the layout of the app registry file set by APP_REGISTRY
+type RegistryFile struct {
+	Voting                  *VotingConfig          `json:"voting"`
+	DeploymentClientAddress string                 `json:"deployment_client_address"`
+	Apps                    map[string]*AppKeyInfo `json:"apps"`
+}
+
+// NewMockAppNode creates a new mock app node with the apps of the APP_REGISTRY file, or the
+// built-in apps if it is unset
+func NewMockAppNode() (*MockAppNode, error) {
+	s := &MockAppNode{
+		appKeys:                 make(map[string]*AppKeyInfo),
+		voting:                  &VotingConfig{RequiredVotes: 2, VotingSignPath: "/vote"},
+		deploymentClientAddress: "127.0.0.1:50054",
+		nextPort:                8081,
+	}
+
+	apps := defaultApps()
+	if path := os.Getenv("APP_REGISTRY"); path != "" {
+		registry, err := loadRegistryFile(path)
+		if err != nil {
+			return nil, err
+		}
+		apps = registry.Apps
+		if registry.Voting != nil {
+			s.voting = mergeVotingConfig(s.voting, registry.Voting)
+		}
+		if registry.DeploymentClientAddress != "" {
+			s.deploymentClientAddress = registry.DeploymentClientAddress
+		}
+	}
+
+	appIDs := make([]string, 0, len(apps))
+	for appID := range apps {
+		appIDs = append(appIDs, appID)
+	}
+	sort.Strings(appIDs)
+	for _, appID := range appIDs {
+		if err := s.addApp(appID, apps[appID]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.applyVotingEnv(); err != nil {
+		return nil, err
+	}
+	if path := os.Getenv("VOTING_AUTHORITY_KEY"); path != "" {
+		authority, err := loadAuthorityKey(path)
+		if err != nil {
+			return nil, err
+		}
+		s.authority = authority
+	}
+	return s, nil
 }
 
-// NewMockAppNode creates a new mock app node
-func NewMockAppNode() *MockAppNode {
-	return &MockAppNode{
-		appKeys: generateMockAppKeys(),
+// loadAuthorityKey reads the PKCS #8 PEM key voting configurations are signed with, generating
+// an Ed25519 key at path if there is none. The public key is written next to it as path.pub
+func loadAuthorityKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate voting authority key: %w", err)
+		}
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode voting authority key: %w", err)
+		}
+		data = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write voting authority key: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read voting authority key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM key in %s", path)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid voting authority key %s: %w", path, err)
+	}
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported voting authority key type %T", parsed)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode voting authority public key: %w", err)
 	}
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+	if err := os.WriteFile(path+".pub", publicPEM, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write voting authority public key: %w", err)
+	}
+	log.Printf("App node: Signing voting configurations; pin %s.pub (TEENET_VOTING_AUTHORITY_KEY_FILE)", path)
+	return signer, nil
 }
 
-// generateMockAppKeys generates real cryptographic keys for all apps
-func generateMockAppKeys() map[string]*AppKeyInfo {
-	keys := make(map[string]*AppKeyInfo)
+// loadRegistryFile reads an app registry from a YAML or JSON file, chosen by extension
+func loadRegistryFile(path string) (*RegistryFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read app registry: %w", err)
+	}
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+		values, err := utils.ParseSimpleYAML(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse app registry %s: %w", path, err)
+		}
+		if data, err = json.Marshal(values); err != nil {
+			return nil, fmt.Errorf("failed to convert app registry %s: %w", path, err)
+		}
+	}
 
-	// Generate the same consistent keys as the DAO server
-	ed25519Key := generateConsistentED25519Key()
-	secp256k1Key := generateConsistentSECP256K1Key()
-	secp256r1Key := generateConsistentSECP256R1Key()
+	var registry RegistryFile
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&registry); err != nil {
+		return nil, fmt.Errorf("invalid app registry %s: %w", path, err)
+	}
+	if len(registry.Apps) == 0 {
+		return nil, fmt.Errorf("app registry %s has no apps", path)
+	}
+	return &registry, nil
+}
 
-	// Generate some example App IDs and corresponding public keys
-	apps := []struct {
-		appID       string
-		protocol    string
-		curve       string
-		description string
-	}{
-		{"secure-messaging-app", "schnorr", "ed25519", "Secure Messaging Application - Schnorr/ED25519"},
-		{"financial-trading-platform", "ecdsa", "secp256r1", "Financial Trading Platform - ECDSA/SECP256R1"},
-		{"digital-identity-service", "schnorr", "secp256k1", "Digital Identity Service - Schnorr/SECP256K1"},
-		{"bitcoin-wallet-app", "ecdsa", "secp256k1", "Bitcoin Wallet - ECDSA/SECP256K1"},
+// mergeVotingConfig returns base with the fields set in override
+func mergeVotingConfig(base, override *VotingConfig) *VotingConfig {
+	merged := *base
+	if override.Targets != nil {
+		merged.Targets = override.Targets
+	}
+	if override.RequiredVotes != 0 {
+		merged.RequiredVotes = override.RequiredVotes
 	}
+	if override.VotingSignPath != "" {
+		merged.VotingSignPath = override.VotingSignPath
+	}
+	return &merged
+}
 
-	for _, app := range apps {
-		var publicKeyB64 string
+// applyVotingEnv overrides the shared voting setup from the environment:
+//
+//	VOTING_TARGETS            comma-separated app IDs, each optionally followed by =host:port of
+//	                          its voting service (default: all apps on 127.0.0.1, ports from 8081)
+//	VOTING_REQUIRED_VOTES     approvals needed (default: 2, at most the number of targets)
+//	VOTING_SIGN_PATH          HTTP path of the voting handler (default: /vote)
+//	DEPLOYMENT_CLIENT_ADDRESS gRPC address of the deployment-client (default: 127.0.0.1:50054)
+func (s *MockAppNode) applyVotingEnv() error {
+	if path := os.Getenv("VOTING_SIGN_PATH"); path != "" {
+		s.voting.VotingSignPath = path
+	}
+	if addr := os.Getenv("DEPLOYMENT_CLIENT_ADDRESS"); addr != "" {
+		s.deploymentClientAddress = addr
+	}
 
-		switch app.curve {
-		case "ed25519":
-			publicKey := ed25519Key.Public().(ed25519.PublicKey)
-			publicKeyB64 = base64.StdEncoding.EncodeToString(publicKey)
-		case "secp256k1":
-			// Generate compressed public key for secp256k1
-			publicKeyBytes := elliptic.MarshalCompressed(secp256k1Key.Curve, secp256k1Key.X, secp256k1Key.Y)
-			publicKeyB64 = base64.StdEncoding.EncodeToString(publicKeyBytes)
-		case "secp256r1":
-			// Generate compressed public key for secp256r1 (P-256)
-			publicKeyBytes := elliptic.MarshalCompressed(secp256r1Key.Curve, secp256r1Key.X, secp256r1Key.Y)
-			publicKeyB64 = base64.StdEncoding.EncodeToString(publicKeyBytes)
-		default:
-			// Fallback to random key for unknown curves
-			keyBytes := make([]byte, 33)
-			rand.Read(keyBytes)
-			keyBytes[0] = 0x02 // Compressed public key prefix
-			publicKeyB64 = base64.StdEncoding.EncodeToString(keyBytes)
+	if targets := os.Getenv("VOTING_TARGETS"); targets != "" {
+		s.voting.Targets = nil
+		for _, entry := range strings.Split(targets, ",") {
+			appID, addr, hasAddr := strings.Cut(strings.TrimSpace(entry), "=")
+			if appID == "" {
+				continue
+			}
+			app, exists := s.appKeys[appID]
+			if !exists {
+				return fmt.Errorf("voting target %s is not a known app", appID)
+			}
+			if hasAddr {
+				host, port, err := net.SplitHostPort(addr)
+				if err != nil {
+					return fmt.Errorf("invalid address for voting target %s: %w", appID, err)
+				}
+				servicePort, err := strconv.ParseInt(port, 10, 32)
+				if err != nil {
+					return fmt.Errorf("invalid port for voting target %s: %w", appID, err)
+				}
+				app.ContainerIP, app.ServicePort = host, int32(servicePort)
+			}
+			s.voting.Targets = append(s.voting.Targets, appID)
 		}
+	}
+
+	if votes := os.Getenv("VOTING_REQUIRED_VOTES"); votes != "" {
+		requiredVotes, err := strconv.ParseInt(votes, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid VOTING_REQUIRED_VOTES: %w", err)
+		}
+		s.voting.RequiredVotes = int32(requiredVotes)
+	}
+	return nil
+}
 
-		keys[app.appID] = &AppKeyInfo{
-			PublicKey:   publicKeyB64,
-			Protocol:    app.protocol,
-			Curve:       app.curve,
-			Description: app.description,
+// defaultApps returns the built-in example apps
+func defaultApps() map[string]*AppKeyInfo {
+	return map[string]*AppKeyInfo{
+		"secure-messaging-app":       {Protocol: "schnorr", Curve: "ed25519", Description: "Secure Messaging Application - Schnorr/ED25519"},
+		"financial-trading-platform": {Protocol: "ecdsa", Curve: "secp256r1", Description: "Financial Trading Platform - ECDSA/SECP256R1"},
+		"digital-identity-service":   {Protocol: "schnorr", Curve: "secp256k1", Description: "Digital Identity Service - Schnorr/SECP256K1"},
+		"bitcoin-wallet-app":         {Protocol: "ecdsa", Curve: "secp256k1", Description: "Bitcoin Wallet - ECDSA/SECP256K1"},
+	}
+}
+
+// addApp validates an app and adds or replaces it; its public key is the DAO server's key for
+// its curve, and it gets the next free service port on 127.0.0.1 unless it has a deployment
+func (s *MockAppNode) addApp(appID string, app *AppKeyInfo) error {
+	if appID == "" {
+		return fmt.Errorf("app_id is required")
+	}
+	publicKey, err := mockPublicKey(app.Protocol, app.Curve)
+	if err != nil {
+		return fmt.Errorf("app %s: %w", appID, err)
+	}
+	if app.Voting != nil && app.Voting.RequiredVotes < 0 {
+		return fmt.Errorf("app %s: required_votes must not be negative", appID)
+	}
+
+	added := *app
+	added.PublicKey = publicKey
+	if added.ContainerIP == "" {
+		added.ContainerIP = "127.0.0.1"
+	}
+	if added.ServicePort == 0 {
+		if existing, ok := s.appKeys[appID]; ok {
+			added.ServicePort = existing.ServicePort
+		} else {
+			added.ServicePort = s.nextPort
+			s.nextPort++
 		}
 	}
+	s.appKeys[appID] = &added
+	return nil
+}
 
-	return keys
+// mockPublicKey returns the base64 public key the DAO server signs with for a protocol and curve
+func mockPublicKey(protocol, curve string) (string, error) {
+	switch {
+	case protocol == "schnorr" && curve == "ed25519":
+		publicKey := generateConsistentED25519Key().Public().(ed25519.PublicKey)
+		return base64.StdEncoding.EncodeToString(publicKey), nil
+	case (protocol == "schnorr" || protocol == "ecdsa") && curve == "secp256k1":
+		// Compressed public key for secp256k1
+		publicKeyBytes := generateConsistentSECP256K1Key().PubKey().SerializeCompressed()
+		return base64.StdEncoding.EncodeToString(publicKeyBytes), nil
+	case (protocol == "schnorr" || protocol == "ecdsa") && curve == "secp256r1":
+		// Compressed public key for secp256r1 (P-256)
+		secp256r1Key := generateConsistentSECP256R1Key()
+		publicKeyBytes := elliptic.MarshalCompressed(secp256r1Key.Curve, secp256r1Key.X, secp256r1Key.Y)
+		return base64.StdEncoding.EncodeToString(publicKeyBytes), nil
+	default:
+		return "", fmt.Errorf("unsupported protocol %q with curve %q", protocol, curve)
+	}
 }
 
 // GetPublicKeyByAppID implements the AppID service method
@@ -109,22 +359,181 @@ func (s *MockAppNode) GetPublicKeyByAppID(ctx context.Context, req *pb.GetPublic
 	}
 
 	// Look up App key information
+	s.mu.RLock()
 	keyInfo, exists := s.appKeys[req.AppId]
+	s.mu.RUnlock()
 	if !exists {
 		log.Printf("App node: App ID not found: %s", req.AppId)
 		return nil, fmt.Errorf("app_id not found: %s", req.AppId)
 	}
 
+	// Mock keys are never rotated, so version 1 is the only one
+	if req.KeyVersion > 1 {
+		log.Printf("App node: Key version %d not found for app_id %s", req.KeyVersion, req.AppId)
+		return nil, fmt.Errorf("key version %d not found for app_id: %s", req.KeyVersion, req.AppId)
+	}
+
 	log.Printf("App node: Found key for app_id %s - protocol: %s, curve: %s",
 		req.AppId, keyInfo.Protocol, keyInfo.Curve)
 
 	return &pb.GetPublicKeyByAppIDResponse{
-		Publickey: keyInfo.PublicKey,
-		Protocol:  keyInfo.Protocol,
-		Curve:     keyInfo.Curve,
+		Publickey:  keyInfo.PublicKey,
+		Protocol:   keyInfo.Protocol,
+		Curve:      keyInfo.Curve,
+		KeyUsage:   keyInfo.KeyUsage,
+		KeyVersion: 1,
 	}, nil
 }
 
+// GetKeyDetails implements the AppID service method
+func (s *MockAppNode) GetKeyDetails(ctx context.Context, req *pb.GetKeyDetailsRequest) (*pb.GetKeyDetailsResponse, error) {
+	log.Printf("App node: GetKeyDetails called for app_id: %s", req.AppId)
+
+	s.mu.RLock()
+	keyInfo, exists := s.appKeys[req.AppId]
+	s.mu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("app_id not found: %s", req.AppId)
+	}
+
+	threshold, participants := keyInfo.Threshold, keyInfo.Participants
+	if threshold == 0 {
+		threshold = 2
+	}
+	if len(participants) == 0 {
+		participants = []uint32{1, 2, 3}
+	}
+	return &pb.GetKeyDetailsResponse{
+		Publickey:         keyInfo.PublicKey,
+		Protocol:          keyInfo.Protocol,
+		Curve:             keyInfo.Curve,
+		Scheme:            "frost",
+		SchemeVersion:     "v1",
+		Threshold:         threshold,
+		TotalParticipants: uint32(len(participants)),
+		ParticipantIds:    participants,
+		CreatedAt:         startedAt.Unix(),
+	}, nil
+}
+
+// votingConfig returns the voting setup of an app; every app votes if no targets are configured
+func (s *MockAppNode) votingConfig(app *AppKeyInfo) *VotingConfig {
+	config := s.voting
+	if app.Voting != nil {
+		config = mergeVotingConfig(s.voting, app.Voting)
+	}
+	if config.Targets == nil {
+		all := *config
+		for appID := range s.appKeys {
+			all.Targets = append(all.Targets, appID)
+		}
+		sort.Strings(all.Targets)
+		config = &all
+	}
+	return config
+}
+
+// GetDeploymentAddresses implements the AppID service method used by voting coordinators
+func (s *MockAppNode) GetDeploymentAddresses(ctx context.Context, req *pb.GetDeploymentAddressesRequest) (*pb.GetDeploymentAddressesResponse, error) {
+	log.Printf("App node: GetDeploymentAddresses called for app_id: %s", req.AppId)
+
+	if req.AppId == "" {
+		return nil, fmt.Errorf("app_id is required")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	app, exists := s.appKeys[req.AppId]
+	if !exists {
+		log.Printf("App node: App ID not found: %s", req.AppId)
+		return nil, fmt.Errorf("app_id not found: %s", req.AppId)
+	}
+	config := s.votingConfig(app)
+
+	host, _, _ := net.SplitHostPort(s.deploymentClientAddress)
+	deployments := make(map[string]*pb.DeploymentInfo, len(config.Targets))
+	var notFound []string
+	for _, targetAppID := range config.Targets {
+		target, ok := s.appKeys[targetAppID]
+		if !ok {
+			notFound = append(notFound, targetAppID)
+			continue
+		}
+		deployments[targetAppID] = &pb.DeploymentInfo{
+			AppId:                   targetAppID,
+			ProjectName:             targetAppID,
+			DeploymentHost:          host,
+			ContainerIp:             target.ContainerIP,
+			ServicePort:             target.ServicePort,
+			DeploymentClientAddress: s.deploymentClientAddress,
+			DeployedAt:              startedAt.Unix(),
+			DeploymentType:          "docker",
+		}
+	}
+
+	// Required votes can't exceed the deployed targets
+	requiredVotes := min(config.RequiredVotes, int32(len(deployments)))
+
+	log.Printf("App node: Returning %d voting targets for app_id %s (required votes: %d, path: %s)",
+		len(deployments), req.AppId, requiredVotes, config.VotingSignPath)
+
+	resp := &pb.GetDeploymentAddressesResponse{
+		Deployments:    deployments,
+		NotFound:       notFound,
+		VotingSignPath: config.VotingSignPath,
+		RequiredVotes:  requiredVotes,
+	}
+	if s.authority != nil {
+		if err := usermgmt.SignVotingConfig(s.authority, req.AppId, resp); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// ServeApps lists apps (GET /admin/apps), adds or replaces one (PUT /admin/apps/{app_id} with
+// the app's registry entry) or removes one (DELETE /admin/apps/{app_id})
+func (s *MockAppNode) ServeApps(w http.ResponseWriter, r *http.Request) {
+	appID := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/admin/apps"), "/")
+	switch {
+	case r.Method == http.MethodGet && appID == "":
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.appKeys)
+	case r.Method == http.MethodPut && appID != "":
+		var app AppKeyInfo
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&app); err != nil {
+			http.Error(w, fmt.Sprintf("invalid app: %v", err), http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		err := s.addApp(appID, &app)
+		s.mu.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("App node: Admin added app %s (%s + %s)", appID, app.Protocol, app.Curve)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete && appID != "":
+		s.mu.Lock()
+		_, exists := s.appKeys[appID]
+		delete(s.appKeys, appID)
+		s.mu.Unlock()
+		if !exists {
+			http.Error(w, fmt.Sprintf("app_id not found: %s", appID), http.StatusNotFound)
+			return
+		}
+		log.Printf("App node: Admin removed app %s", appID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "unsupported app request", http.StatusMethodNotAllowed)
+	}
+}
+
 // generateConsistentED25519Key generates a consistent ED25519 private key for testing
 func generateConsistentED25519Key() ed25519.PrivateKey {
 	// Use a deterministic seed for consistent key generation in testing
@@ -136,27 +545,10 @@ func generateConsistentED25519Key() ed25519.PrivateKey {
 }
 
 // generateConsistentSECP256K1Key generates a consistent SECP256K1 private key for testing
-func generateConsistentSECP256K1Key() *ecdsa.PrivateKey {
+func generateConsistentSECP256K1Key() *btcec.PrivateKey {
 	// Use a deterministic seed for consistent key generation in testing
 	seed := []byte("tee-dao-mock-server-secp256k1-key-12345678901234567890123456789012")
-	privateKeyInt := new(big.Int).SetBytes(seed[:32])
-	
-	// Ensure the private key is valid for secp256k1 (less than curve order)
-	curve := elliptic.P256() // Using P256 as approximation for secp256k1
-	for privateKeyInt.Cmp(curve.Params().N) >= 0 {
-		privateKeyInt.Sub(privateKeyInt, curve.Params().N)
-	}
-	
-	privateKey := &ecdsa.PrivateKey{
-		D: privateKeyInt,
-		PublicKey: ecdsa.PublicKey{
-			Curve: curve,
-		},
-	}
-	
-	// Generate the public key
-	privateKey.PublicKey.X, privateKey.PublicKey.Y = curve.ScalarBaseMult(privateKeyInt.Bytes())
-	
+	privateKey, _ := btcec.PrivKeyFromBytes(seed[:32])
 	return privateKey
 }
 
@@ -185,53 +577,126 @@ func generateConsistentSECP256R1Key() *ecdsa.PrivateKey {
 	return privateKey
 }
 
-func main() {
-	port := ":50053"
-	if p := os.Getenv("APP_NODE_PORT"); p != "" {
-		port = ":" + p
+// ensureCerts generates certificates if there are none, or a fresh set if regenerate is set,
+// including those of additional cluster nodes
+func ensureCerts(regenerate bool) error {
+	extra, err := cluster.CertNames()
+	if err != nil {
+		return err
 	}
+	if regenerate {
+		return certgen.Generate(certgen.DefaultDir, extra...)
+	}
+	return certgen.Ensure(certgen.DefaultDir, extra...)
+}
 
-	log.Printf("Starting Mock App Node (User Management System) on port %s", port)
+func main() {
+	genCerts := flag.Bool("gen-certs", false, "generate a fresh throwaway CA and certificates before starting")
+	flag.Parse()
+	if err := ensureCerts(*genCerts); err != nil {
+		log.Fatalf("Failed to prepare certificates: %v", err)
+	}
 
-	// Create listener
-	lis, err := net.Listen("tcp", port)
+	// APP_NODE_PORT and MOCK_APP_NODES are read by the cluster package
+	nodes, err := cluster.AppNodes()
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		log.Fatalf("Failed to configure cluster: %v", err)
 	}
 
-	// Load TLS certificates
-	cert, err := tls.LoadX509KeyPair("certs/app-node.crt", "certs/app-node.key")
+	log.Printf("Starting Mock App Node (User Management System) with %d node(s)", len(nodes))
+
+	appNode, err := NewMockAppNode()
 	if err != nil {
-		log.Fatalf("Failed to load TLS credentials: %v", err)
+		log.Fatalf("Failed to configure app node: %v", err)
 	}
 
-	// Configure TLS for self-signed certificates with client authentication
-	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{cert},
-		ClientAuth:   tls.RequireAnyClientCert, // Require client certificate but don't verify against CA
+	// Every node serves the same registry, recording requests into one recorder
+	rec := recorder.New()
+	tokens, err := tokenauth.FromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure token authentication: %v", err)
 	}
+	servers := make([]*grpc.Server, 0, len(nodes))
+	listeners := make([]net.Listener, 0, len(nodes))
+	for _, node := range nodes {
+		// Create listener
+		lis, err := net.Listen("tcp", node.ListenAddr())
+		if err != nil {
+			log.Fatalf("Failed to listen: %v", err)
+		}
+
+		// Load TLS certificates, verifying clients when MOCK_TLS_STRICT is set
+		tlsConfig, err := certgen.ServerTLSConfig(node.CertFile(), node.KeyFile(), "")
+		if err != nil {
+			log.Fatalf("Failed to load TLS credentials: %v", err)
+		}
+
+		// Create gRPC server with mutual TLS, recording requests when enabled
+		creds := credentials.NewTLS(tlsConfig)
+		s := grpc.NewServer(grpc.Creds(creds), grpc.ChainUnaryInterceptor(rec.UnaryInterceptor(node.ID), tokens.UnaryInterceptor()))
+
+		// Register service
+		pb.RegisterAppIDServiceServer(s, appNode)
 
-	// Create gRPC server with mutual TLS
-	creds := credentials.NewTLS(tlsConfig)
-	s := grpc.NewServer(grpc.Creds(creds))
+		servers = append(servers, s)
+		listeners = append(listeners, lis)
+	}
 
-	// Register service
-	appNode := NewMockAppNode()
-	pb.RegisterAppIDServiceServer(s, appNode)
+	// Start admin API
+	adminPort := ":8092"
+	if p, ok := os.LookupEnv("APP_NODE_ADMIN_PORT"); ok {
+		adminPort = ""
+		if p != "" {
+			adminPort = ":" + p
+		}
+	}
+	if adminPort != "" {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/admin/apps", appNode.ServeApps)
+		mux.HandleFunc("/admin/apps/", appNode.ServeApps)
+		mux.Handle("/admin/requests", rec)
+		go func() {
+			log.Printf("App node admin API listening on %s", adminPort)
+			if err := http.ListenAndServe(adminPort, mux); err != nil {
+				log.Fatalf("Failed to serve admin API: %v", err)
+			}
+		}()
+	}
 
 	// Print available App ID list
-	fmt.Printf("Mock App Node listening on %s (with mutual TLS)\n", port)
+	for _, node := range nodes {
+		fmt.Printf("Mock App Node %d listening on %s (with mutual TLS)\n", node.ID, node.ListenAddr())
+	}
 	fmt.Println("Available App IDs for testing:")
-	for appID, keyInfo := range appNode.appKeys {
+	appIDs := make([]string, 0, len(appNode.appKeys))
+	for appID := range appNode.appKeys {
+		appIDs = append(appIDs, appID)
+	}
+	sort.Strings(appIDs)
+	for _, appID := range appIDs {
+		keyInfo := appNode.appKeys[appID]
 		fmt.Printf("  - %s (%s + %s) - %s\n", appID, keyInfo.Protocol, keyInfo.Curve, keyInfo.Description)
 	}
+	voting := appNode.votingConfig(&AppKeyInfo{})
+	fmt.Printf("Voting targets (%d required votes, path %s):\n", voting.RequiredVotes, voting.VotingSignPath)
+	for _, appID := range voting.Targets {
+		if target, ok := appNode.appKeys[appID]; ok {
+			fmt.Printf("  - %s at %s:%d\n", appID, target.ContainerIP, target.ServicePort)
+		}
+	}
 	fmt.Println("")
 	fmt.Println("💡 Usage Tips:")
 	fmt.Println("   Copy any of the above App IDs to use in your client programs")
 	fmt.Println("   Each App ID corresponds to different signature protocol and curve combinations")
 	fmt.Println("")
 
-	if err := s.Serve(lis); err != nil {
+	errs := make(chan error, len(servers))
+	for i, s := range servers {
+		go func(s *grpc.Server, lis net.Listener) {
+			errs <- s.Serve(lis)
+		}(s, listeners[i])
+	}
+	if err := <-errs; err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
 }
@@ -1,13 +1,18 @@
+//go:build config_server
+
 package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 	"os"
 
-	pb "tee-dao-mock-server/proto"
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/node_management"
+	"tee-dao-mock-server/certgen"
+	"tee-dao-mock-server/cluster"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -18,8 +23,7 @@ type MockConfigServer struct {
 	pb.UnimplementedCLIRPCServiceServer
 	clientCert []byte
 	clientKey  []byte
-	teeCert    []byte
-	appCert    []byte
+	peers      []*pb.Peer // Every TEE and App node of the cluster
 }
 
 // NewMockConfigServer creates a new mock config server
@@ -35,21 +39,35 @@ func NewMockConfigServer() *MockConfigServer {
 		log.Fatalf("Failed to load client key: %v", err)
 	}
 
-	serverCert, err := os.ReadFile("certs/dao-server.crt")
+	teeNodes, err := cluster.TEENodes()
 	if err != nil {
-		log.Fatalf("Failed to load server certificate: %v", err)
+		log.Fatalf("Failed to configure cluster: %v", err)
 	}
-
-	appCert, err := os.ReadFile("certs/app-node.crt")
+	appNodes, err := cluster.AppNodes()
 	if err != nil {
-		log.Fatalf("Failed to load app certificate: %v", err)
+		log.Fatalf("Failed to configure cluster: %v", err)
+	}
+
+	var peers []*pb.Peer
+	for _, node := range append(teeNodes, appNodes...) {
+		cert, err := os.ReadFile(node.CertFile())
+		if err != nil {
+			log.Fatalf("Failed to load certificate of node %d: %v", node.ID, err)
+		}
+		peers = append(peers, &pb.Peer{
+			Id:         node.ID,
+			RpcAddress: node.Address(),
+			Cert:       cert,
+			Type:       node.Type, // 1: TEE node (mock DAO server), 3: App node
+			Region:     node.Region,
+			Zone:       node.Zone,
+		})
 	}
 
 	return &MockConfigServer{
 		clientCert: clientCert,
 		clientKey:  clientKey,
-		teeCert:    serverCert,
-		appCert:    appCert,
+		peers:      peers,
 	}
 }
 
@@ -69,27 +87,31 @@ func (s *MockConfigServer) GetNodeInfo(ctx context.Context, req *pb.GetNodeInfoR
 func (s *MockConfigServer) GetPeerNode(ctx context.Context, req *pb.GetPeerNodeRequest) (*pb.GetPeerNodeResponse, error) {
 	log.Printf("Config server: GetPeerNode called with type: %s", req.NodeType)
 
-	peers := []*pb.Peer{
-		{
-			Id:         2001,
-			RpcAddress: "localhost:50051", // Mock DAO server address
-			Cert:       s.teeCert,
-			Type:       1, // TEE node type
-		},
-		{
-			Id:         3001,
-			RpcAddress: "localhost:50053", // Mock App node address 
-			Cert:       s.appCert,
-			Type:       3, // App node type
-		},
-	}
-
 	return &pb.GetPeerNodeResponse{
-		Peers: peers,
+		Peers: s.peers,
 	}, nil
 }
 
+// ensureCerts generates certificates if there are none, or a fresh set if regenerate is set,
+// including those of additional cluster nodes
+func ensureCerts(regenerate bool) error {
+	extra, err := cluster.CertNames()
+	if err != nil {
+		return err
+	}
+	if regenerate {
+		return certgen.Generate(certgen.DefaultDir, extra...)
+	}
+	return certgen.Ensure(certgen.DefaultDir, extra...)
+}
+
 func main() {
+	genCerts := flag.Bool("gen-certs", false, "generate a fresh throwaway CA and certificates before starting")
+	flag.Parse()
+	if err := ensureCerts(*genCerts); err != nil {
+		log.Fatalf("Failed to prepare certificates: %v", err)
+	}
+
 	port := ":50052"
 	if p := os.Getenv("CONFIG_SERVER_PORT"); p != "" {
 		port = ":" + p
@@ -111,6 +133,14 @@ func main() {
 	pb.RegisterCLIRPCServiceServer(s, configServer)
 
 	fmt.Printf("Mock Config Server listening on %s (no TLS)\n", port)
+	fmt.Println("Peers:")
+	for _, peer := range configServer.peers {
+		fmt.Printf("  - node %d (type %d) at %s", peer.Id, peer.Type, peer.RpcAddress)
+		if peer.Region != "" {
+			fmt.Printf(" in %s/%s", peer.Region, peer.Zone)
+		}
+		fmt.Println()
+	}
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
@@ -0,0 +1,205 @@
+//go:build deployment_client
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"tee-dao-mock-server/recorder"
+)
+
+// MockDeploymentClient mimics the HTTP proxy of a deployment-client, which forwards
+// /proxy/{app_id}:{port}{path} to the app's container
+type MockDeploymentClient struct {
+	mu sync.RWMutex
+	// Registered app ID to handler base URL, e.g. http://127.0.0.1:9001
+	routes map[string]*url.URL
+	// Captures forwarded requests when recording is enabled
+	recorder *recorder.Recorder
+}
+
+// NewMockDeploymentClient creates a mock deployment-client with the routes from PROXY_ROUTES,
+// a comma-separated list of app_id=host:port
+func NewMockDeploymentClient() (*MockDeploymentClient, error) {
+	dc := &MockDeploymentClient{routes: make(map[string]*url.URL), recorder: recorder.New()}
+	if routes := os.Getenv("PROXY_ROUTES"); routes != "" {
+		for _, entry := range strings.Split(routes, ",") {
+			appID, addr, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			if !ok || appID == "" {
+				return nil, fmt.Errorf("invalid proxy route %q (want app_id=host:port)", entry)
+			}
+			if err := dc.Register(appID, addr); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return dc, nil
+}
+
+// Register routes an app's requests to a local handler; addr is host:port or a base URL
+func (dc *MockDeploymentClient) Register(appID, addr string) error {
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+	target, err := url.Parse(addr)
+	if err != nil || target.Host == "" {
+		return fmt.Errorf("invalid handler address %q for app %s", addr, appID)
+	}
+
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	dc.routes[appID] = target
+	log.Printf("Deployment client: Routing %s to %s", appID, target)
+	return nil
+}
+
+// Unregister removes an app's route; its requests go to localhost again
+func (dc *MockDeploymentClient) Unregister(appID string) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	delete(dc.routes, appID)
+	log.Printf("Deployment client: Removed route for %s", appID)
+}
+
+// target returns where a request for an app on a container port goes: its registered
+// handler, or the port on localhost as if the container ran on this machine
+func (dc *MockDeploymentClient) target(appID, port string) *url.URL {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+	if target, ok := dc.routes[appID]; ok {
+		return target
+	}
+	return &url.URL{Scheme: "http", Host: net.JoinHostPort("127.0.0.1", port)}
+}
+
+// ServeProxy forwards /proxy/{app_id}:{port}{path}
+func (dc *MockDeploymentClient) ServeProxy(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/proxy/")
+	appAndPort, path, _ := strings.Cut(rest, "/")
+	appID, port, ok := strings.Cut(appAndPort, ":")
+	if !ok || appID == "" || port == "" {
+		http.Error(w, "expected /proxy/{app_id}:{port}{path}", http.StatusBadRequest)
+		return
+	}
+
+	target := dc.target(appID, port)
+	log.Printf("Deployment client: %s %s -> %s/%s", r.Method, r.URL.Path, target, path)
+
+	if dc.recorder.Enabled() {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read request: %v", err), http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		headers := make(map[string]string, len(r.Header))
+		for key := range r.Header {
+			headers[key] = r.Header.Get(key)
+		}
+		dc.recorder.Record("Proxy", &recorder.HTTPRequest{
+			AppID:   appID,
+			Port:    port,
+			Path:    "/" + path,
+			Method:  r.Method,
+			Headers: headers,
+			Body:    body,
+		})
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(target)
+			pr.Out.URL.Path = strings.TrimSuffix(target.Path, "/") + "/" + path
+			pr.Out.URL.RawPath = ""
+			pr.SetXForwarded()
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			log.Printf("Deployment client: Failed to reach %s for %s: %v", target, appID, err)
+			http.Error(w, fmt.Sprintf("app %s unreachable: %v", appID, err), http.StatusBadGateway)
+		},
+	}
+	proxy.ServeHTTP(w, r)
+}
+
+// ServeRoutes lists routes (GET), registers one (PUT /routes/{app_id} with {"address": "host:port"})
+// or removes one (DELETE /routes/{app_id})
+func (dc *MockDeploymentClient) ServeRoutes(w http.ResponseWriter, r *http.Request) {
+	appID := strings.TrimPrefix(r.URL.Path, "/routes/")
+	switch {
+	case r.Method == http.MethodGet && (r.URL.Path == "/routes" || r.URL.Path == "/routes/"):
+		dc.mu.RLock()
+		routes := make(map[string]string, len(dc.routes))
+		for appID, target := range dc.routes {
+			routes[appID] = target.String()
+		}
+		dc.mu.RUnlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(routes)
+	case r.Method == http.MethodPut && appID != "":
+		var body struct {
+			Address string `json:"address"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, fmt.Sprintf("invalid body: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := dc.Register(appID, body.Address); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete && appID != "":
+		dc.Unregister(appID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "unsupported route request", http.StatusMethodNotAllowed)
+	}
+}
+
+func main() {
+	port := ":8090"
+	if p := os.Getenv("PROXY_PORT"); p != "" {
+		port = ":" + p
+	}
+
+	dc, err := NewMockDeploymentClient()
+	if err != nil {
+		log.Fatalf("Failed to configure deployment client: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/proxy/", dc.ServeProxy)
+	mux.HandleFunc("/routes", dc.ServeRoutes)
+	mux.HandleFunc("/routes/", dc.ServeRoutes)
+	mux.Handle("/admin/requests", dc.recorder)
+
+	log.Printf("Starting Mock Deployment Client proxy on port %s", port)
+	fmt.Printf("Mock Deployment Client listening on %s (HTTP)\n", port)
+	fmt.Println("Forwarding /proxy/{app_id}:{port}{path} to registered handlers, or to localhost:{port}")
+	dc.mu.RLock()
+	appIDs := make([]string, 0, len(dc.routes))
+	for appID := range dc.routes {
+		appIDs = append(appIDs, appID)
+	}
+	sort.Strings(appIDs)
+	for _, appID := range appIDs {
+		fmt.Printf("  - %s -> %s\n", appID, dc.routes[appID])
+	}
+	dc.mu.RUnlock()
+
+	if err := http.ListenAndServe(port, mux); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
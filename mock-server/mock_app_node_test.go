@@ -0,0 +1,182 @@
+//go:build app_node
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/appid"
+)
+
+// newTestAppNode creates an app node with the built-in apps and the voting setup of env
+func newTestAppNode(t *testing.T, env map[string]string) *MockAppNode {
+	t.Helper()
+	for _, key := range []string{"APP_REGISTRY", "VOTING_AUTHORITY_KEY", "VOTING_TARGETS",
+		"VOTING_REQUIRED_VOTES", "VOTING_SIGN_PATH", "DEPLOYMENT_CLIENT_ADDRESS"} {
+		t.Setenv(key, env[key])
+	}
+	node, err := NewMockAppNode()
+	if err != nil {
+		t.Fatalf("NewMockAppNode: %v", err)
+	}
+	return node
+}
+
+func TestGetDeploymentAddresses(t *testing.T) {
+	node := newTestAppNode(t, map[string]string{
+		"VOTING_TARGETS":            "secure-messaging-app=10.0.0.5:9000, bitcoin-wallet-app",
+		"VOTING_REQUIRED_VOTES":     "5",
+		"VOTING_SIGN_PATH":          "/approve",
+		"DEPLOYMENT_CLIENT_ADDRESS": "10.0.0.1:50054",
+	})
+
+	resp, err := node.GetDeploymentAddresses(context.Background(), &pb.GetDeploymentAddressesRequest{AppId: "financial-trading-platform"})
+	if err != nil {
+		t.Fatalf("GetDeploymentAddresses: %v", err)
+	}
+	if len(resp.Deployments) != 2 || len(resp.NotFound) != 0 {
+		t.Fatalf("got deployments %v, not found %v; want the two configured targets", resp.Deployments, resp.NotFound)
+	}
+	if resp.RequiredVotes != 2 {
+		t.Errorf("required votes %d, want 2 (capped at the number of targets)", resp.RequiredVotes)
+	}
+	if resp.VotingSignPath != "/approve" {
+		t.Errorf("voting path %q, want /approve", resp.VotingSignPath)
+	}
+
+	target := resp.Deployments["secure-messaging-app"]
+	if target == nil || target.ContainerIp != "10.0.0.5" || target.ServicePort != 9000 {
+		t.Errorf("secure-messaging-app deployment %v, want 10.0.0.5:9000", target)
+	}
+	target = resp.Deployments["bitcoin-wallet-app"]
+	if target == nil || target.ContainerIp != "127.0.0.1" || target.ServicePort == 0 {
+		t.Errorf("bitcoin-wallet-app deployment %v, want 127.0.0.1 with an assigned port", target)
+	}
+	if target != nil && (target.DeploymentHost != "10.0.0.1" || target.DeploymentClientAddress != "10.0.0.1:50054") {
+		t.Errorf("deployment client %s on %s, want 10.0.0.1:50054", target.DeploymentClientAddress, target.DeploymentHost)
+	}
+}
+
+func TestGetDeploymentAddressesDefaults(t *testing.T) {
+	node := newTestAppNode(t, nil)
+
+	resp, err := node.GetDeploymentAddresses(context.Background(), &pb.GetDeploymentAddressesRequest{AppId: "secure-messaging-app"})
+	if err != nil {
+		t.Fatalf("GetDeploymentAddresses: %v", err)
+	}
+	if len(resp.Deployments) != len(defaultApps()) {
+		t.Errorf("got %d deployments, want every app to vote", len(resp.Deployments))
+	}
+	if resp.RequiredVotes != 2 || resp.VotingSignPath != "/vote" {
+		t.Errorf("required votes %d on %q, want 2 on /vote", resp.RequiredVotes, resp.VotingSignPath)
+	}
+
+	if _, err := node.GetDeploymentAddresses(context.Background(), &pb.GetDeploymentAddressesRequest{AppId: "unknown-app"}); err == nil {
+		t.Error("GetDeploymentAddresses succeeded for an unknown app")
+	}
+}
+
+func TestUnknownVotingTarget(t *testing.T) {
+	t.Setenv("APP_REGISTRY", "")
+	t.Setenv("VOTING_AUTHORITY_KEY", "")
+	t.Setenv("VOTING_TARGETS", "no-such-app")
+	if _, err := NewMockAppNode(); err == nil {
+		t.Error("NewMockAppNode accepted a voting target that is not a known app")
+	}
+}
+
+func TestRegistryFile(t *testing.T) {
+	node := newTestAppNode(t, map[string]string{"APP_REGISTRY": "apps.example.yaml"})
+
+	if len(node.appKeys) != 4 {
+		t.Fatalf("got %d apps, want the 4 of the registry file", len(node.appKeys))
+	}
+	key, err := node.GetPublicKeyByAppID(context.Background(), &pb.GetPublicKeyByAppIDRequest{AppId: "treasury-app"})
+	if err != nil {
+		t.Fatalf("GetPublicKeyByAppID: %v", err)
+	}
+	if key.Protocol != "ecdsa" || key.Curve != "secp256k1" || len(key.KeyUsage) != 1 || key.KeyUsage[0] != "sign" {
+		t.Errorf("treasury-app key %v, want ecdsa secp256k1 for signing", key)
+	}
+
+	resp, err := node.GetDeploymentAddresses(context.Background(), &pb.GetDeploymentAddressesRequest{AppId: "payments-app"})
+	if err != nil {
+		t.Fatalf("GetDeploymentAddresses: %v", err)
+	}
+	if len(resp.Deployments) != 2 || resp.Deployments["risk-app"] == nil || resp.Deployments["risk-app"].ServicePort != 9002 {
+		t.Errorf("payments-app deployments %v, want its own targets treasury-app and risk-app", resp.Deployments)
+	}
+
+	details, err := node.GetKeyDetails(context.Background(), &pb.GetKeyDetailsRequest{AppId: "treasury-app"})
+	if err != nil {
+		t.Fatalf("GetKeyDetails: %v", err)
+	}
+	if details.Threshold != 3 || details.TotalParticipants != 5 {
+		t.Errorf("treasury-app key is %d-of-%d, want 3-of-5", details.Threshold, details.TotalParticipants)
+	}
+}
+
+func TestInvalidRegistryFile(t *testing.T) {
+	for name, content := range map[string]string{
+		"unknown-field.yaml": "apps:\n  some-app:\n    protocol: ecdsa\n    curve: secp256k1\n    colour: red\n",
+		"bad-curve.yaml":     "apps:\n  some-app:\n    protocol: ecdsa\n    curve: ed25519\n",
+		"no-apps.json":       `{"voting": {"required_votes": 1}}`,
+	} {
+		path := filepath.Join(t.TempDir(), name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv("APP_REGISTRY", path)
+		if _, err := NewMockAppNode(); err == nil {
+			t.Errorf("NewMockAppNode accepted %s", name)
+		}
+	}
+}
+
+func TestServeApps(t *testing.T) {
+	node := newTestAppNode(t, nil)
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		node.ServeApps(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return rec
+	}
+
+	rec := serve(http.MethodPut, "/admin/apps/new-app", `{"protocol": "schnorr", "curve": "ed25519", "service_port": 9100}`)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("PUT returned %d %s", rec.Code, rec.Body)
+	}
+	key, err := node.GetPublicKeyByAppID(context.Background(), &pb.GetPublicKeyByAppIDRequest{AppId: "new-app"})
+	if err != nil || key.Curve != "ed25519" || key.Publickey == "" {
+		t.Fatalf("GetPublicKeyByAppID of the added app returned %v, %v", key, err)
+	}
+
+	var apps map[string]*AppKeyInfo
+	rec = serve(http.MethodGet, "/admin/apps", "")
+	if err := json.NewDecoder(rec.Body).Decode(&apps); err != nil {
+		t.Fatalf("decode apps: %v", err)
+	}
+	if app := apps["new-app"]; app == nil || app.ServicePort != 9100 || app.ContainerIP != "127.0.0.1" {
+		t.Errorf("listed new-app as %+v, want it on 127.0.0.1:9100", app)
+	}
+
+	if rec = serve(http.MethodPut, "/admin/apps/bad-app", `{"protocol": "rsa", "curve": "ed25519"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("PUT of an unsupported key: status %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	if rec = serve(http.MethodDelete, "/admin/apps/new-app", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("DELETE returned %d %s", rec.Code, rec.Body)
+	}
+	if _, err := node.GetPublicKeyByAppID(context.Background(), &pb.GetPublicKeyByAppIDRequest{AppId: "new-app"}); err == nil {
+		t.Error("GetPublicKeyByAppID found the removed app")
+	}
+	if rec = serve(http.MethodDelete, "/admin/apps/new-app", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("second DELETE: status %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,68 @@
+//go:build config_server
+
+package main
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/node_management"
+	"tee-dao-mock-server/certgen"
+)
+
+func TestGetPeerNodeCluster(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("MOCK_TEE_NODES", "3")
+	t.Setenv("MOCK_APP_NODES", "2")
+	t.Setenv("MOCK_DAO_PORT", "")
+	t.Setenv("APP_NODE_PORT", "")
+	t.Setenv("MOCK_NODE_LOCALITIES", "")
+	if err := ensureCerts(false); err != nil {
+		t.Fatalf("ensureCerts: %v", err)
+	}
+
+	resp, err := NewMockConfigServer().GetPeerNode(context.Background(), &pb.GetPeerNodeRequest{})
+	if err != nil {
+		t.Fatalf("GetPeerNode: %v", err)
+	}
+	if len(resp.Peers) != 5 {
+		t.Fatalf("got %d peers, want 3 TEE and 2 app nodes", len(resp.Peers))
+	}
+
+	caPEM, err := os.ReadFile(filepath.Join(certgen.DefaultDir, "ca.crt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(caPEM)
+
+	ids := make(map[uint32]bool)
+	addresses := make(map[string]bool)
+	certs := make(map[string]bool)
+	for _, peer := range resp.Peers {
+		ids[peer.Id], addresses[peer.RpcAddress], certs[string(peer.Cert)] = true, true, true
+		block, _ := pem.Decode(peer.Cert)
+		if block == nil {
+			t.Fatalf("node %d certificate is not PEM", peer.Id)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			t.Fatalf("node %d certificate: %v", peer.Id, err)
+		}
+		if _, err := cert.Verify(x509.VerifyOptions{Roots: roots, DNSName: "localhost"}); err != nil {
+			t.Errorf("node %d certificate does not verify against the CA: %v", peer.Id, err)
+		}
+	}
+	if len(ids) != 5 || len(addresses) != 5 || len(certs) != 5 {
+		t.Errorf("peers share IDs, addresses or certificates: %v", resp.Peers)
+	}
+	for _, id := range []uint32{2001, 2002, 2003, 3001, 3002} {
+		if !ids[id] {
+			t.Errorf("no peer with ID %d", id)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+//go:build deployment_client
+
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestProxy serves a deployment-client with the routes API and the proxy
+func newTestProxy(t *testing.T) (*MockDeploymentClient, *httptest.Server) {
+	t.Helper()
+	t.Setenv("PROXY_ROUTES", "")
+	dc, err := NewMockDeploymentClient()
+	if err != nil {
+		t.Fatalf("NewMockDeploymentClient: %v", err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/proxy/", dc.ServeProxy)
+	mux.HandleFunc("/routes", dc.ServeRoutes)
+	mux.HandleFunc("/routes/", dc.ServeRoutes)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return dc, server
+}
+
+func TestProxyForwardsToRegisteredHandler(t *testing.T) {
+	var gotPath, gotBody string
+	handler := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotPath, gotBody = r.URL.Path, string(body)
+		w.Write([]byte(`{"approved":true}`))
+	}))
+	defer handler.Close()
+
+	_, server := newTestProxy(t)
+	req, _ := http.NewRequest(http.MethodPut, server.URL+"/routes/voter-app",
+		strings.NewReader(`{"address":"`+strings.TrimPrefix(handler.URL, "http://")+`"}`))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("register route: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("register route: status %d", resp.StatusCode)
+	}
+
+	resp, err = http.Post(server.URL+"/proxy/voter-app:9000/vote", "application/json", strings.NewReader(`{"app_id":"voter-app"}`))
+	if err != nil {
+		t.Fatalf("proxy request: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != `{"approved":true}` {
+		t.Fatalf("proxy response %d %s, want the handler's", resp.StatusCode, body)
+	}
+	if gotPath != "/vote" || gotBody != `{"app_id":"voter-app"}` {
+		t.Errorf("handler got %s %s, want /vote with the request body", gotPath, gotBody)
+	}
+}
+
+func TestProxyErrors(t *testing.T) {
+	dc, server := newTestProxy(t)
+
+	resp, err := http.Get(server.URL + "/proxy/voter-app/vote")
+	if err != nil {
+		t.Fatalf("proxy request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("request without a port: status %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+
+	// A closed listener's address refuses connections
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	if err := dc.Register("voter-app", closed.URL); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	resp, err = http.Get(server.URL + "/proxy/voter-app:9000/vote")
+	if err != nil {
+		t.Fatalf("proxy request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("unreachable handler: status %d, want %d", resp.StatusCode, http.StatusBadGateway)
+	}
+}
@@ -0,0 +1,181 @@
+// Package recorder captures the requests the mock servers receive, so tests can assert exactly
+// what the SDK sent
+package recorder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultLimit bounds how many records are kept; the oldest are dropped first
+const DefaultLimit = 10000
+
+// Record is a captured request
+type Record struct {
+	Seq     uint64          `json:"seq"`
+	Time    time.Time       `json:"time"`
+	Node    uint32          `json:"node,omitempty"` // ID of the cluster node that received it
+	Method  string          `json:"method"`         // RPC name such as "Sign", or "Proxy" for forwarded HTTP requests
+	Request json.RawMessage `json:"request"`        // Protobuf requests use proto field names; bytes are base64
+}
+
+// HTTPRequest is the request of a "Proxy" record
+type HTTPRequest struct {
+	AppID   string            `json:"app_id"`
+	Port    string            `json:"port"`
+	Path    string            `json:"path"`
+	Method  string            `json:"method"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    []byte            `json:"body,omitempty"`
+}
+
+// Recorder keeps captured requests in memory
+type Recorder struct {
+	mu      sync.Mutex
+	enabled bool
+	limit   int
+	seq     uint64
+	records []Record
+}
+
+// New creates a recorder, enabled if the MOCK_RECORD environment variable is true
+func New() *Recorder {
+	enabled, _ := strconv.ParseBool(os.Getenv("MOCK_RECORD"))
+	return &Recorder{enabled: enabled, limit: DefaultLimit}
+}
+
+// Enabled reports whether requests are being recorded
+func (r *Recorder) Enabled() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.enabled
+}
+
+// SetEnabled starts or stops recording
+func (r *Recorder) SetEnabled(enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.enabled = enabled
+}
+
+// Record captures a request if recording is enabled; protobuf messages are stored as protobuf JSON
+func (r *Recorder) Record(method string, request any) {
+	r.record(0, method, request)
+}
+
+// record captures a request received by a node, 0 if not known
+func (r *Recorder) record(node uint32, method string, request any) {
+	if !r.Enabled() {
+		return
+	}
+
+	var data []byte
+	var err error
+	if message, ok := request.(proto.Message); ok {
+		data, err = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(message)
+	} else {
+		data, err = json.Marshal(request)
+	}
+	if err != nil {
+		log.Printf("Recorder: Failed to encode %s request: %v", method, err)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	r.records = append(r.records, Record{Seq: r.seq, Time: time.Now(), Node: node, Method: method, Request: data})
+	if len(r.records) > r.limit {
+		r.records = append([]Record(nil), r.records[len(r.records)-r.limit:]...)
+	}
+}
+
+// Records returns the records after sequence number since, optionally only those of one method
+// and one node
+func (r *Recorder) Records(method string, node uint32, since uint64) []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	records := []Record{}
+	for _, record := range r.records {
+		if record.Seq > since && (method == "" || record.Method == method) && (node == 0 || record.Node == node) {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// Reset drops all records; sequence numbers keep counting
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = nil
+}
+
+// UnaryInterceptor records every unary RPC a gRPC server receives, attributed to a cluster
+// node ID, or 0
+func (r *Recorder) UnaryInterceptor(node uint32) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		r.record(node, path.Base(info.FullMethod), req)
+		return handler(ctx, req)
+	}
+}
+
+// ServeHTTP serves the recording API:
+//
+//	GET    /admin/requests?method=Sign&node=2002&since=12  records, optionally filtered
+//	DELETE /admin/requests                                 drop all records
+//	PUT    /admin/requests {"enabled": true}               start or stop recording
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		var since uint64
+		if s := req.URL.Query().Get("since"); s != "" {
+			var err error
+			if since, err = strconv.ParseUint(s, 10, 64); err != nil {
+				http.Error(w, fmt.Sprintf("invalid since %q", s), http.StatusBadRequest)
+				return
+			}
+		}
+		var node uint64
+		if n := req.URL.Query().Get("node"); n != "" {
+			var err error
+			if node, err = strconv.ParseUint(n, 10, 32); err != nil {
+				http.Error(w, fmt.Sprintf("invalid node %q", n), http.StatusBadRequest)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			Enabled bool     `json:"enabled"`
+			Records []Record `json:"records"`
+		}{r.Enabled(), r.Records(req.URL.Query().Get("method"), uint32(node), since)})
+	case http.MethodDelete:
+		r.Reset()
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodPut, http.MethodPost:
+		var body struct {
+			Enabled *bool `json:"enabled"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil || body.Enabled == nil {
+			http.Error(w, `expected {"enabled": true|false}`, http.StatusBadRequest)
+			return
+		}
+		r.SetEnabled(*body.Enabled)
+		log.Printf("Recorder: Recording enabled=%t", *body.Enabled)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,131 @@
+package recorder
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	appid "github.com/TEENet-io/teenet-sdk/go/proto/appid"
+	pb "github.com/TEENet-io/teenet-sdk/go/proto/key_management"
+
+	"google.golang.org/grpc"
+)
+
+// listing is the body of GET /admin/requests
+type listing struct {
+	Enabled bool     `json:"enabled"`
+	Records []Record `json:"records"`
+}
+
+// serve sends a request to the recording API
+func serve(r *Recorder, method, target, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return rec
+}
+
+// list returns the records GET /admin/requests returns for target
+func list(t *testing.T, r *Recorder, target string) listing {
+	t.Helper()
+	rec := serve(r, http.MethodGet, target, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s returned %d %s", target, rec.Code, rec.Body)
+	}
+	var result listing
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("decode records: %v", err)
+	}
+	return result
+}
+
+// call runs a unary RPC through the recorder's interceptor for a node
+func call(t *testing.T, r *Recorder, node uint32, fullMethod string, req any) {
+	t.Helper()
+	handler := func(ctx context.Context, req any) (any, error) { return nil, nil }
+	if _, err := r.UnaryInterceptor(node)(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: fullMethod}, handler); err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+}
+
+func TestRecordRPCs(t *testing.T) {
+	t.Setenv("MOCK_RECORD", "true")
+	r := New()
+
+	call(t, r, 2001, "/key_management.UserTask/Sign", &pb.SignRequest{Msg: []byte("hello"), Protocol: 2, Curve: 1})
+	call(t, r, 2002, "/key_management.UserTask/Sign", &pb.SignRequest{Msg: []byte("world"), Protocol: 1, Curve: 2})
+	r.Record("Proxy", &HTTPRequest{AppID: "voter-app", Port: "9000", Path: "/vote", Method: http.MethodPost})
+
+	all := list(t, r, "/admin/requests")
+	if !all.Enabled || len(all.Records) != 3 {
+		t.Fatalf("got %d records (enabled %t), want 3", len(all.Records), all.Enabled)
+	}
+
+	signs := list(t, r, "/admin/requests?method=Sign&node=2002").Records
+	if len(signs) != 1 || signs[0].Node != 2002 || signs[0].Method != "Sign" {
+		t.Fatalf("filtered records %+v, want the Sign of node 2002", signs)
+	}
+	var sign struct {
+		Msg      []byte `json:"msg"`
+		Protocol uint32 `json:"protocol"`
+		Curve    uint32 `json:"curve"`
+	}
+	if err := json.Unmarshal(signs[0].Request, &sign); err != nil {
+		t.Fatalf("decode recorded request: %v", err)
+	}
+	if string(sign.Msg) != "world" || sign.Protocol != 1 || sign.Curve != 2 {
+		t.Errorf("recorded request %s, want the message, protocol and curve sent", signs[0].Request)
+	}
+
+	if since := list(t, r, "/admin/requests?since=2").Records; len(since) != 1 || since[0].Method != "Proxy" {
+		t.Errorf("records since 2: %+v, want the proxied request", since)
+	}
+	if rec := serve(r, http.MethodGet, "/admin/requests?since=x", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid since: status %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestRecordingControl(t *testing.T) {
+	t.Setenv("MOCK_RECORD", "")
+	r := New()
+
+	call(t, r, 1, "/appid.AppIDService/GetPublicKeyByAppID", &appid.GetPublicKeyByAppIDRequest{AppId: "some-app"})
+	if records := list(t, r, "/admin/requests").Records; len(records) != 0 {
+		t.Fatalf("recorded %d requests while disabled", len(records))
+	}
+
+	if rec := serve(r, http.MethodPut, "/admin/requests", `{"enabled": true}`); rec.Code != http.StatusNoContent {
+		t.Fatalf("PUT returned %d %s", rec.Code, rec.Body)
+	}
+	call(t, r, 1, "/appid.AppIDService/GetPublicKeyByAppID", &appid.GetPublicKeyByAppIDRequest{AppId: "some-app"})
+	records := list(t, r, "/admin/requests").Records
+	if len(records) != 1 || records[0].Method != "GetPublicKeyByAppID" {
+		t.Fatalf("records %+v, want the call made after enabling", records)
+	}
+
+	if rec := serve(r, http.MethodDelete, "/admin/requests", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("DELETE returned %d", rec.Code)
+	}
+	call(t, r, 1, "/appid.AppIDService/GetPublicKeyByAppID", &appid.GetPublicKeyByAppIDRequest{AppId: "some-app"})
+	records = list(t, r, "/admin/requests").Records
+	if len(records) != 1 || records[0].Seq != 2 {
+		t.Errorf("records after reset %+v, want one with the next sequence number", records)
+	}
+
+	if rec := serve(r, http.MethodPut, "/admin/requests", `{}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("PUT without enabled: status %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestRecordLimit(t *testing.T) {
+	r := &Recorder{enabled: true, limit: 2}
+	for _, method := range []string{"A", "B", "C"} {
+		r.Record(method, struct{}{})
+	}
+	records := r.Records("", 0, 0)
+	if len(records) != 2 || records[0].Method != "B" || records[1].Method != "C" {
+		t.Errorf("records %+v, want the 2 newest", records)
+	}
+}
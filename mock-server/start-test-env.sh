@@ -15,19 +15,6 @@ check_dependencies() {
         exit 1
     fi
     
-    if ! command -v protoc &> /dev/null; then
-        echo "Error: protoc compiler not found"
-        echo "On macOS run: brew install protobuf"
-        echo "On Ubuntu run: sudo apt-get install protobuf-compiler"
-        exit 1
-    fi
-    
-    if ! command -v openssl &> /dev/null; then
-        echo "Error: openssl not found"
-        echo "On macOS run: brew install openssl"
-        exit 1
-    fi
-    
     echo "✓ All dependencies check passed"
 }
 
@@ -35,13 +22,7 @@ check_dependencies() {
 setup_environment() {
     echo "Setting up test environment..."
     
-    # Generate protobuf code
-    echo "Generating protobuf code..."
-    make proto
-    
-    # Generate certificates
-    echo "Generating TLS certificates..."
-    make certs
+    # TLS certificates are generated by the first server that starts
     
     # Build all components
     echo "Building project components..."
@@ -56,6 +37,8 @@ start_services() {
     echo "  Config server port: 50052"
     echo "  DAO server port: 50051"
     echo "  App node port: 50053"
+    echo "  Deployment client proxy port: 8090"
+    echo "  Cluster: ${MOCK_TEE_NODES:-1} DAO node(s), ${MOCK_APP_NODES:-1} app node(s)"
     
     # Create logs directory
     mkdir -p logs
@@ -78,6 +61,12 @@ start_services() {
     # Wait for app node to start
     sleep 2
     
+    # Start deployment client proxy
+    echo "Starting deployment client proxy..."
+    ./deployment-client > logs/deployment-client.log 2>&1 &
+    PROXY_PID=$!
+    echo "  Deployment client PID: $PROXY_PID"
+    
     # Start DAO server
     echo "Starting DAO server..."
     ./dao-server > logs/dao-server.log 2>&1 &
@@ -91,6 +80,7 @@ start_services() {
     echo $CONFIG_PID > logs/config-server.pid
     echo $APP_PID > logs/app-node.pid
     echo $DAO_PID > logs/dao-server.pid
+    echo $PROXY_PID > logs/deployment-client.pid
     
     echo "✓ All services started successfully"
 }
@@ -113,6 +103,7 @@ show_status() {
     echo "  Config Server: localhost:50052 (PID: $(cat logs/config-server.pid 2>/dev/null || echo 'N/A'))"
     echo "  DAO Server:    localhost:50051 (PID: $(cat logs/dao-server.pid 2>/dev/null || echo 'N/A'))"
     echo "  App Node:      localhost:50053 (PID: $(cat logs/app-node.pid 2>/dev/null || echo 'N/A'))"
+    echo "  Proxy:         localhost:8090 (PID: $(cat logs/deployment-client.pid 2>/dev/null || echo 'N/A'))"
     echo ""
     
     # Display available App IDs from log file
@@ -132,6 +123,7 @@ show_status() {
     echo "  Config server logs: logs/config-server.log"
     echo "  DAO server logs:    logs/dao-server.log"
     echo "  App node logs:      logs/app-node.log"
+    echo "  Proxy logs:         logs/deployment-client.log"
     echo ""
     echo "Usage:"
     echo "  1. Your program should connect to config server: localhost:50052"
@@ -174,6 +166,15 @@ if [ -f logs/dao-server.pid ]; then
     rm -f logs/dao-server.pid
 fi
 
+if [ -f logs/deployment-client.pid ]; then
+    PROXY_PID=$(cat logs/deployment-client.pid)
+    if kill -0 $PROXY_PID 2>/dev/null; then
+        kill $PROXY_PID
+        echo "✓ Deployment client stopped (PID: $PROXY_PID)"
+    fi
+    rm -f logs/deployment-client.pid
+fi
+
 echo "Test environment stopped"
 EOF
     chmod +x stop-test-env.sh
@@ -28,4 +28,13 @@ if [ -f logs/dao-server.pid ]; then
     rm -f logs/dao-server.pid
 fi
 
+if [ -f logs/deployment-client.pid ]; then
+    PROXY_PID=$(cat logs/deployment-client.pid)
+    if kill -0 $PROXY_PID 2>/dev/null; then
+        kill $PROXY_PID
+        echo "✓ Deployment client stopped (PID: $PROXY_PID)"
+    fi
+    rm -f logs/deployment-client.pid
+fi
+
 echo "Test environment stopped"
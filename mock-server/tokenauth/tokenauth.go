@@ -0,0 +1,117 @@
+// Package tokenauth lets the mock servers accept bearer tokens from clients without a client
+// certificate, mirroring the SDK's token authentication mode
+package tokenauth
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/TEENet-io/teenet-sdk/go/pkg/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// EnvVar lists the accepted tokens as appID=token pairs; a token without an app ID is valid
+// for every app
+const EnvVar = "MOCK_AUTH_TOKENS"
+
+// Enabled reports whether token authentication is configured
+func Enabled() bool {
+	return os.Getenv(EnvVar) != ""
+}
+
+// Verifier checks the bearer tokens of calls made without a client certificate
+type Verifier struct {
+	tokens auth.Tokens
+}
+
+// FromEnv returns a verifier for MOCK_AUTH_TOKENS, or nil if it isn't set
+func FromEnv() (*Verifier, error) {
+	value := os.Getenv(EnvVar)
+	if value == "" {
+		return nil, nil
+	}
+	tokens, err := auth.ParseTokens(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", EnvVar, err)
+	}
+	log.Printf("Auth: Accepting bearer tokens from clients without a certificate: %s", tokens)
+	return &Verifier{tokens: tokens}, nil
+}
+
+// UnaryInterceptor rejects calls that present neither a client certificate nor a valid token
+// for their app ID; a nil verifier accepts every call
+func (v *Verifier) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if v != nil && !hasClientCert(ctx) {
+			appID := ""
+			if r, ok := req.(interface{ GetAppId() string }); ok {
+				appID = r.GetAppId()
+			}
+			if err := v.check(ctx, appID); err != nil {
+				log.Printf("Auth: Rejected %s: %v", info.FullMethod, err)
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor is UnaryInterceptor for streaming calls, which take the app ID from the
+// app ID header
+func (v *Verifier) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if v != nil && !hasClientCert(stream.Context()) {
+			if err := v.check(stream.Context(), ""); err != nil {
+				log.Printf("Auth: Rejected %s: %v", info.FullMethod, err)
+				return err
+			}
+		}
+		return handler(srv, stream)
+	}
+}
+
+// check validates the call's bearer token for appID, taken from the request or the app ID header
+func (v *Verifier) check(ctx context.Context, appID string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(auth.AuthorizationHeader)
+	if len(values) == 0 {
+		return status.Error(codes.Unauthenticated, "no client certificate or bearer token")
+	}
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return status.Error(codes.Unauthenticated, "authorization is not a bearer token")
+	}
+	if appID == "" {
+		if ids := md.Get(auth.AppIDHeader); len(ids) > 0 {
+			appID = ids[0]
+		}
+	}
+	if equal(token, v.tokens.Default) || equal(token, v.tokens.PerApp[appID]) {
+		return nil
+	}
+	return status.Errorf(codes.Unauthenticated, "invalid token for app %q", appID)
+}
+
+// equal compares a token in constant time; an empty expected token matches nothing
+func equal(token, expected string) bool {
+	return expected != "" && subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1
+}
+
+// hasClientCert reports whether the call came over TLS with a client certificate
+func hasClientCert(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	return ok && len(info.State.PeerCertificates) > 0
+}